@@ -0,0 +1,172 @@
+package main
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// exportExcludedColumns are never written to an export
+var exportExcludedColumns = map[string]bool{
+	"password": true,
+}
+
+// export writes the rows of a model table as CSV or JSON
+func (a *admin) export(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	table := fs.String("table", "", "table to export, e.g. customers")
+	format := fs.String("format", "csv", "output format: csv or json")
+	out := fs.String("out", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tables, err := a.exportableTables()
+	if err != nil {
+		return err
+	}
+	model, ok := tables[*table]
+	if !ok {
+		names := make([]string, 0, len(tables))
+		for name := range tables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown table %q, available: %v", *table, names)
+	}
+	if *format != "csv" && *format != "json" {
+		return errors.New("format must be csv or json")
+	}
+
+	var writer io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	stmt := &gorm.Statement{DB: a.db}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	var columns []string
+	for _, column := range stmt.Schema.DBNames {
+		if !exportExcludedColumns[column] {
+			columns = append(columns, column)
+		}
+	}
+
+	query := a.db.WithContext(ctx).Model(model).Select(columns)
+	if field := stmt.Schema.PrioritizedPrimaryField; field != nil {
+		query = query.Order(field.DBName)
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var records []map[string]interface{}
+	var csvWriter *csv.Writer
+	if *format == "csv" {
+		csvWriter = csv.NewWriter(writer)
+		if err := csvWriter.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	count := 0
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+
+		if csvWriter != nil {
+			record := make([]string, len(columns))
+			for i, value := range values {
+				record[i] = exportString(value)
+			}
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		} else {
+			record := make(map[string]interface{}, len(columns))
+			for i, column := range columns {
+				if raw, ok := values[i].([]byte); ok {
+					record[column] = string(raw)
+				} else {
+					record[column] = values[i]
+				}
+			}
+			records = append(records, record)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if csvWriter != nil {
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+	} else {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if records == nil {
+			records = []map[string]interface{}{}
+		}
+		if err := encoder.Encode(records); err != nil {
+			return err
+		}
+	}
+
+	if *out != "" {
+		fmt.Printf("exported %d row(s) from %s to %s\n", count, *table, *out)
+	}
+	return nil
+}
+
+// exportableTables maps table names to the models registered for migration
+func (a *admin) exportableTables() (map[string]interface{}, error) {
+	tables := make(map[string]interface{})
+	for _, model := range models.GetAllModels() {
+		stmt := &gorm.Statement{DB: a.db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		tables[stmt.Schema.Table] = model
+	}
+	return tables, nil
+}
+
+func exportString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
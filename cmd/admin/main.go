@@ -0,0 +1,117 @@
+package main
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase"
+	"boilerplate/pkg/infra/db"
+	"boilerplate/pkg/infra/logger"
+	"boilerplate/pkg/notify"
+	"boilerplate/pkg/storage"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const usageText = `Usage: admin [-env local] <command> [options]
+
+Commands:
+  user create        create a user account (-name, -email, -password, -outlet, -role)
+  role grant         grant permissions to a role (-permission) and/or the role to a user (-user)
+  seed               insert default categories, unit types, payment methods, permissions and the owner role
+  migrate            manage the schema: status | up | down [N] | create NAME
+  recalc-stock       compare product stock with the stock ledger (-apply to correct it)
+  recalc-job-totals  recalculate service job totals (-id for a single job)
+  export             export a table as CSV or JSON (-table, -format, -out)
+`
+
+// admin carries the shared wiring used by every subcommand
+type admin struct {
+	conf     *config.Config
+	log      *logrus.Logger
+	db       *gorm.DB
+	repo     *repository.RepositoryManager
+	usecase  *usecase.UsecaseManager
+	migrator *db.Migrator
+}
+
+func main() {
+
+	env := flag.String("env", "local", "config environment: local, dev, staging, prod or docker")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usageText) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	//* ====================== Config ======================
+
+	conf := config.InitConfig(*env)
+	appLogger := logger.NewLogrusLogger(&conf.Logger.Logrus)
+
+	//* ====================== Connection DB ======================
+
+	database := db.NewGORMConnection(&conf.Connection.DatabaseApp, appLogger)
+
+	//* ====================== Wiring ======================
+
+	repoManager := repository.NewRepositoryManager(database)
+	store, err := storage.FromConfig(context.Background(), conf)
+	if err != nil {
+		appLogger.Fatalf("storage: %v", err)
+	}
+	senders, err := notify.FromConfig(conf.Notification)
+	if err != nil {
+		appLogger.Fatalf("notify: %v", err)
+	}
+	app := &admin{
+		conf:     conf,
+		log:      appLogger,
+		db:       database,
+		repo:     repoManager,
+		usecase:  usecase.NewUsecaseManager(repoManager, store, senders, conf),
+		migrator: db.NewMigrator(database, db.DefaultMigrationsDir, conf.Connection.DatabaseApp.DriverName),
+	}
+
+	if err := app.run(context.Background(), args); err != nil {
+		appLogger.Errorf("%s: %v", args[0], err)
+		os.Exit(1)
+	}
+}
+
+func (a *admin) run(ctx context.Context, args []string) error {
+	command, rest := args[0], args[1:]
+
+	switch command {
+	case "user":
+		if len(rest) == 0 || rest[0] != "create" {
+			return fmt.Errorf("usage: user create [options]")
+		}
+		return a.createUser(ctx, rest[1:])
+	case "role":
+		if len(rest) == 0 || rest[0] != "grant" {
+			return fmt.Errorf("usage: role grant [options]")
+		}
+		return a.grantRole(ctx, rest[1:])
+	case "seed":
+		return a.seed(ctx)
+	case "migrate":
+		return db.RunMigrationCommand(a.migrator, rest, os.Stdout)
+	case "recalc-stock":
+		return a.recalcStock(ctx, rest)
+	case "recalc-job-totals":
+		return a.recalcJobTotals(ctx, rest)
+	case "export":
+		return a.export(ctx, rest)
+	}
+
+	fmt.Fprint(os.Stderr, usageText)
+	return fmt.Errorf("unknown command %q", command)
+}
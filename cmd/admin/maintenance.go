@@ -0,0 +1,79 @@
+package main
+
+import (
+	"boilerplate/pkg/query"
+	"context"
+	"flag"
+	"fmt"
+)
+
+// recalcStock reports products whose stock differs from their stock ledger
+func (a *admin) recalcStock(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("recalc-stock", flag.ContinueOnError)
+	apply := fs.Bool("apply", false, "overwrite product stock with the ledger value")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	results, err := a.usecase.Product.RecalculateStock(ctx, *apply)
+	for _, result := range results {
+		state := "differs"
+		if result.Applied {
+			state = "corrected"
+		} else if result.NoOpeningBalance {
+			state = "differs, no opening balance in the ledger"
+		}
+		fmt.Printf("product %-6d %-40s stock %6d ledger %6d  %s\n",
+			result.ProductID, result.ProductName, result.CurrentStock, result.CalculatedStock, state)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Println("all product stock matches the stock ledger")
+	} else if !*apply {
+		fmt.Println("run again with -apply to correct the stock")
+	}
+	return nil
+}
+
+// recalcJobTotals recalculates grand total, commission and profit of service jobs
+func (a *admin) recalcJobTotals(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("recalc-job-totals", flag.ContinueOnError)
+	jobID := fs.Uint("id", 0, "only recalculate this service job")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *jobID > 0 {
+		if err := a.usecase.ServiceJob.CalculateServiceJobTotals(ctx, uint(*jobID)); err != nil {
+			return err
+		}
+		fmt.Printf("recalculated service job %d\n", *jobID)
+		return nil
+	}
+
+	const batchSize = 100
+	total := 0
+	for offset := 0; ; offset += batchSize {
+		q := query.New(batchSize, offset)
+		q.Sorts = []query.Sort{{Field: "service_job_id"}}
+		jobs, _, err := a.usecase.ServiceJob.ListServiceJobs(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			if err := a.usecase.ServiceJob.CalculateServiceJobTotals(ctx, job.ServiceJobID); err != nil {
+				return fmt.Errorf("service job %d: %w", job.ServiceJobID, err)
+			}
+			total++
+		}
+		if len(jobs) < batchSize {
+			break
+		}
+	}
+
+	fmt.Printf("recalculated %d service job(s)\n", total)
+	return nil
+}
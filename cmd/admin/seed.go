@@ -0,0 +1,161 @@
+package main
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/exception"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+//* ====================== Default master data ======================
+
+var defaultCategories = []string{
+	"Oli & Pelumas",
+	"Suku Cadang Mesin",
+	"Sistem Rem",
+	"Kelistrikan & Aki",
+	"Ban & Velg",
+	"Filter",
+	"Body & Aksesoris",
+}
+
+var defaultUnitTypes = []string{
+	"Pcs",
+	"Set",
+	"Liter",
+	"Botol",
+	"Meter",
+	"Box",
+}
+
+var defaultPaymentMethods = []string{
+	"Tunai",
+	"Transfer Bank",
+	"QRIS",
+	"Kartu Debit",
+	"Kartu Kredit",
+}
+
+// defaultPermissionResources are combined with defaultPermissionActions into "<resource>.<action>"
+var defaultPermissionResources = []string{
+	"users",
+	"outlets",
+	"roles",
+	"permissions",
+	"customers",
+	"customer-vehicles",
+	"products",
+	"categories",
+	"suppliers",
+	"unit-types",
+	"services",
+	"service-categories",
+	"service-jobs",
+	"transactions",
+	"payment-methods",
+	"cash-flows",
+	"audit-logs",
+}
+
+var defaultPermissionActions = []string{"view", "create", "update", "delete"}
+
+// defaultOwnerRole receives every seeded permission
+const defaultOwnerRole = "owner"
+
+// seed inserts the default master data; records that already exist are skipped
+func (a *admin) seed(ctx context.Context) error {
+	err := seedNamed("category", defaultCategories,
+		func(name string) error {
+			_, err := a.usecase.Category.GetCategoryByName(ctx, name)
+			return err
+		},
+		func(name string) error {
+			_, err := a.usecase.Category.CreateCategory(ctx, interfaces.CreateCategoryRequest{Name: name})
+			return err
+		})
+	if err != nil {
+		return err
+	}
+
+	err = seedNamed("unit type", defaultUnitTypes,
+		func(name string) error {
+			_, err := a.usecase.UnitType.GetUnitTypeByName(ctx, name)
+			return err
+		},
+		func(name string) error {
+			_, err := a.usecase.UnitType.CreateUnitType(ctx, interfaces.CreateUnitTypeRequest{Name: name})
+			return err
+		})
+	if err != nil {
+		return err
+	}
+
+	err = seedNamed("payment method", defaultPaymentMethods,
+		func(name string) error {
+			_, err := a.usecase.PaymentMethod.GetPaymentMethodByName(ctx, name)
+			return err
+		},
+		func(name string) error {
+			_, err := a.usecase.PaymentMethod.CreatePaymentMethod(ctx, interfaces.CreatePaymentMethodRequest{Name: name, Status: models.StatusAktif})
+			return err
+		})
+	if err != nil {
+		return err
+	}
+
+	var permissionIDs []uint
+	for _, resource := range defaultPermissionResources {
+		for _, action := range defaultPermissionActions {
+			name := resource + "." + action
+			permission, err := a.usecase.Permission.GetPermissionByName(ctx, name)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				permission, err = a.usecase.Permission.CreatePermission(ctx, interfaces.CreatePermissionRequest{Name: name})
+				if err == nil {
+					fmt.Printf("created permission %q\n", name)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("permission %q: %w", name, err)
+			}
+			permissionIDs = append(permissionIDs, permission.ID)
+		}
+	}
+
+	role, err := a.usecase.Role.GetRoleByName(ctx, defaultOwnerRole)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		role, err = a.usecase.Role.CreateRole(ctx, interfaces.CreateRoleRequest{Name: defaultOwnerRole})
+	}
+	if err != nil {
+		return fmt.Errorf("role %q: %w", defaultOwnerRole, err)
+	}
+	if err := a.usecase.Role.AttachPermissions(ctx, role.ID, permissionIDs); err != nil {
+		return err
+	}
+	fmt.Printf("role %q has %d permission(s)\n", role.Name, len(permissionIDs))
+
+	return nil
+}
+
+// seedNamed creates every name lookup does not find, any other lookup failure stops the seeding
+func seedNamed(kind string, names []string, lookup func(name string) error, create func(name string) error) error {
+	for _, name := range names {
+		err := lookup(name)
+		if err == nil {
+			fmt.Printf("skipped %s %q (exists)\n", kind, name)
+			continue
+		}
+		// The usecases answer a missing record with their not found error, the repositories with gorm's
+		if !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, exception.ErrNotFound) {
+			return fmt.Errorf("%s %q: %w", kind, name, err)
+		}
+		if err := create(name); err != nil {
+			return fmt.Errorf("%s %q: %w", kind, name, err)
+		}
+		fmt.Printf("created %s %q\n", kind, name)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/validator"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// createUser creates a user account, optionally assigning a role by name
+func (a *admin) createUser(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+	name := fs.String("name", "", "full name")
+	email := fs.String("email", "", "login email")
+	password := fs.String("password", "", "initial password (min 6 characters)")
+	outletID := fs.Uint("outlet", 0, "outlet ID the user belongs to")
+	roleName := fs.String("role", "", "role name to assign, e.g. owner")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req := interfaces.CreateUserRequest{
+		Name:     *name,
+		Email:    *email,
+		Password: *password,
+	}
+	if *outletID > 0 {
+		id := uint(*outletID)
+		req.OutletID = &id
+	}
+	if message, _ := validator.ValidateDataRequest(req); message != "" {
+		return errors.New(message)
+	}
+
+	// An unknown role must not leave a user behind without it
+	var role *models.Role
+	if *roleName != "" {
+		var err error
+		role, err = a.usecase.Role.GetRoleByName(ctx, *roleName)
+		if err != nil {
+			return fmt.Errorf("role %q: %w", *roleName, err)
+		}
+	}
+
+	user, err := a.usecase.User.CreateUser(ctx, req)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created user %d <%s>\n", user.UserID, user.Email)
+
+	if role != nil {
+		if err := a.usecase.User.AssignRoles(ctx, user.UserID, []uint{role.ID}); err != nil {
+			return err
+		}
+		fmt.Printf("granted role %s to %s\n", role.Name, user.Email)
+	}
+	return nil
+}
+
+// grantRole attaches permissions to a role and/or assigns the role to a user
+func (a *admin) grantRole(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("role grant", flag.ContinueOnError)
+	roleName := fs.String("role", "", "role name, created when it does not exist")
+	permissionList := fs.String("permission", "", "comma separated permission names, or * for all")
+	userEmail := fs.String("user", "", "email of the user receiving the role")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *roleName == "" || (*permissionList == "" && *userEmail == "") {
+		return errors.New("usage: role grant -role NAME [-permission a,b | -permission '*'] [-user EMAIL]")
+	}
+
+	role, err := a.usecase.Role.GetRoleByName(ctx, *roleName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		role, err = a.usecase.Role.CreateRole(ctx, interfaces.CreateRoleRequest{Name: *roleName})
+		if err == nil {
+			fmt.Printf("created role %s\n", role.Name)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if *permissionList != "" {
+		var permissionIDs []uint
+		if *permissionList == "*" {
+			for q := query.New(query.MaxPerPage, 0); ; q.Offset += q.Limit {
+				permissions, total, err := a.usecase.Permission.ListPermissions(ctx, q)
+				if err != nil {
+					return err
+				}
+				for _, permission := range permissions {
+					permissionIDs = append(permissionIDs, permission.ID)
+				}
+				if int64(q.Offset+q.Limit) >= total {
+					break
+				}
+			}
+		} else {
+			for _, name := range strings.Split(*permissionList, ",") {
+				permission, err := a.usecase.Permission.GetPermissionByName(ctx, strings.TrimSpace(name))
+				if err != nil {
+					return fmt.Errorf("permission %q: %w", name, err)
+				}
+				permissionIDs = append(permissionIDs, permission.ID)
+			}
+		}
+		if err := a.usecase.Role.AttachPermissions(ctx, role.ID, permissionIDs); err != nil {
+			return err
+		}
+		fmt.Printf("granted %d permission(s) to role %s\n", len(permissionIDs), role.Name)
+	}
+
+	if *userEmail != "" {
+		user, err := a.usecase.User.GetUserByEmail(ctx, *userEmail)
+		if err != nil {
+			return fmt.Errorf("user %q: %w", *userEmail, err)
+		}
+		if err := a.usecase.User.AssignRoles(ctx, user.UserID, []uint{role.ID}); err != nil {
+			return err
+		}
+		fmt.Printf("granted role %s to %s\n", role.Name, user.Email)
+	}
+	return nil
+}
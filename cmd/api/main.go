@@ -5,6 +5,7 @@ import (
 	"boilerplate/internal/server"
 	"boilerplate/pkg/infra/db"
 	"boilerplate/pkg/infra/logger"
+	"os"
 )
 
 func main() {
@@ -37,10 +38,35 @@ func main() {
 	//? Wab Fondasi Mongo DB
 
 	//* ====================== Run Migrations ======================
-	
-	err := db.RunMigrations(dbList.DatabaseApp)
-	if err != nil {
-		appLogger.Fatalf("Failed to run migrations: %v", err)
+
+	migrator := db.NewMigrator(dbList.DatabaseApp, db.DefaultMigrationsDir, conf.Connection.DatabaseApp.DriverName)
+
+	//* `api migrate <status|up|down N|create NAME>` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := db.RunMigrationCommand(migrator, os.Args[2:], os.Stdout); err != nil {
+			appLogger.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	if conf.App.IsProduction {
+		//* Production schema is only changed through versioned SQL migrations
+		pending, err := migrator.Pending()
+		if err != nil {
+			appLogger.Fatalf("Failed to read migrations: %v", err)
+		}
+		if len(pending) > 0 {
+			appLogger.Warnf("%d pending migration(s), run `migrate up` before serving traffic", len(pending))
+		}
+	} else {
+		//* Elsewhere the pending migrations are applied on startup for convenience
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			appLogger.Infof("Applied migration %d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			appLogger.Fatalf("Failed to run migrations: %v", err)
+		}
 	}
 
 	//* ====================== Running Server ======================
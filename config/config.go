@@ -17,6 +17,17 @@ type Config struct {
 	CloudStorage  CloudStorageAccount
 	Grafana       GrafanaAccount
 	Redis         RedisClient
+	Report        ReportAccount
+	Dashboard     DashboardAccount
+	Replenishment ReplenishmentAccount
+	Scheduler     SchedulerAccount
+	Notification  NotificationAccount
+	Estimate      EstimateAccount
+	Appointment   AppointmentAccount
+	Sequence      SequenceAccount
+	Board         BoardAccount
+	Technician    TechnicianAccount
+	Labour        LabourAccount
 }
 
 type AppAccount struct {
@@ -104,7 +115,9 @@ type BasicAccount struct {
 }
 
 type CloudStorageAccount struct {
+	Driver        string // "local" (default) or "gcs"
 	GoogleStorage GoogleStorageAccount
+	Local         LocalStorageAccount
 }
 
 type GoogleStorageAccount struct {
@@ -116,6 +129,12 @@ type GoogleStorageAccount struct {
 	DefaultMaxUploadSize     int
 }
 
+// LocalStorageAccount keeps files in a directory on disk, public files are served below BaseURL
+type LocalStorageAccount struct {
+	Directory string
+	BaseURL   string
+}
+
 type GrafanaAccount struct {
 	IsActive bool
 	LokiURL  string
@@ -128,6 +147,119 @@ type RedisClient struct {
 	RedisKey string
 }
 
+// ReportAccount configures the background report workers, zero values fall back to the defaults
+type ReportAccount struct {
+	Workers      int // number of concurrent workers, default 2
+	PollInterval int // seconds to wait when the queue is empty, default 5
+	StaleAfter   int // minutes before a report stuck in Diproses is queued again, default 15
+}
+
+// DashboardAccount configures the owner dashboard, zero values fall back to the defaults
+type DashboardAccount struct {
+	CacheSeconds      int // seconds a computed dashboard is served from memory, default 30
+	LowStockThreshold int // products at or below this stock count as low stock, default 5
+}
+
+// ReplenishmentAccount configures the replenishment job, zero values fall back to the defaults
+type ReplenishmentAccount struct {
+	IntervalHours int  // hours between runs of the job, default 24
+	LookbackDays  int  // days of sales and service usage the average daily consumption is taken from, default 30
+	CoverDays     int  // days of consumption an order should cover above the min stock, default 14
+	OutletID      uint // outlet that receives the orders of products planned against their total stock, default the first active outlet
+	Disabled      bool // only generate draft orders on request
+}
+
+// SchedulerAccount configures the scheduled jobs, zero values fall back to the defaults
+type SchedulerAccount struct {
+	Disabled     bool                           // do not run jobs on schedule in this instance, manual runs still work
+	LockMinutes  int                            // minutes a run may take before another instance may start the job again, default 30
+	ReportUserID uint                           // user the daily reports are requested for
+	ReportTypes  []string                       // report types the daily reports job requests, default Penjualan and Keuangan
+	ApplyStock   bool                           // let the stock reconciliation job correct product stock to the ledger
+	Jobs         map[string]SchedulerJobAccount // per job overrides by job name
+}
+
+// SchedulerJobAccount overrides the schedule of a job
+type SchedulerJobAccount struct {
+	Schedule string // cron expression, e.g. "0 1 * * *"
+	Disabled bool
+}
+
+// NotificationAccount configures the customer notifications, zero values fall back to the defaults
+type NotificationAccount struct {
+	Disabled           bool              // do not deliver queued notifications in this instance
+	Channels           []string          // channels a notification is sent through, default whatsapp
+	NotifyStatuses     []string          // service job statuses the customer is told about besides Selesai
+	DueReminderDays    int               // days before its due date a receivable is reminded of, default 3
+	PickupReminderDays int               // days a finished job waits for pickup before the customer is reminded, default 2
+	MaxAttempts        int               // delivery attempts before a notification is marked Gagal, default 5
+	RetryMinutes       int               // minutes multiplied by the attempt number before the next attempt, default 5
+	PollInterval       int               // seconds to wait when the queue is empty, default 10
+	Templates          map[string]string // message templates by event, replacing the built-in ones
+	WhatsApp           NotificationChannelAccount
+	SMS                NotificationChannelAccount
+	Email              NotificationChannelAccount
+}
+
+// NotificationChannelAccount selects and configures the sender of a channel
+type NotificationChannelAccount struct {
+	Driver   string // "log" (default), "file", "gateway" for WhatsApp and SMS or "smtp" for email
+	File     string // file the "file" driver appends to
+	URL      string // gateway endpoint
+	Token    string // gateway bearer token
+	Host     string // SMTP server
+	Port     int    // SMTP port, default 587
+	Username string
+	Password string
+	From     string // sender address of emails
+}
+
+// EstimateAccount configures the repair estimates customers approve before work starts
+type EstimateAccount struct {
+	Required      bool    // work on a job can only start and grow within an estimate the customer approved
+	CallThreshold float64 // a revision adding more than this must be approved by phone or at the counter, 0 for no limit
+	LinkValidDays int     // days an approval link stays valid, default 7
+	SigningKey    string  // key the approval links are signed with, default a key derived from the JWT access token key
+	Notify        bool    // send the approval link to the customer when a revision is created
+}
+
+// AppointmentAccount configures appointment booking, zero values fall back to the defaults
+type AppointmentAccount struct {
+	SlotMinutes        int    // appointments start on multiples of this many minutes after opening, default 30
+	DefaultMinutes     int    // duration of an appointment whose services have no standard time, default 60
+	OpensAt            string // opening time of outlets without opening hours, Monday to Saturday, default "08:00"
+	ClosesAt           string // closing time of outlets without opening hours, default "17:00"
+	MaxDaysAhead       int    // how many days ahead appointments can be booked, default 60
+	NoShowGraceMinutes int    // minutes after the start an appointment not checked in becomes a no-show, default 30
+	MaxNoShows         int    // customers with this many no-shows in the lookback days cannot book, 0 for no limit
+	NoShowLookbackDays int    // days no-shows are counted over, default 90
+}
+
+// SequenceAccount configures document numbering
+type SequenceAccount struct {
+	// Patterns by document type (service_code, queue_number, invoice, purchase_order, vehicle_purchase), e.g.
+	// "INV/{OUTLET}/{YYYY}/{MM}/{SEQ:5}"; the counter restarts whenever the rest of the number changes
+	Patterns map[string]string
+}
+
+// BoardAccount configures the live workshop boards streamed to the counter screen and the technicians' tablets
+type BoardAccount struct {
+	PollSeconds      int // how often a stream looks for events written by other instances, default 2
+	HeartbeatSeconds int // how often an idle stream sends a comment to keep proxies from closing it, default 15
+	RetentionHours   int // how long events are kept for clients resuming after a reconnect, default 24
+}
+
+// TechnicianAccount configures the workload of the technicians that jobs are assigned by
+type TechnicianAccount struct {
+	DefaultJobMinutes int // the work assumed for a job without services of a standard time, default 60
+}
+
+// LabourAccount configures the time-based commission of the clocked labour
+type LabourAccount struct {
+	CommissionPerHour float64 // paid per hour of the basis, 0 reports no time-based commission
+	CommissionBasis   string  // "standard" (default) pays the standard time of the finished lines, "clocked" the clocked time
+}
+
 //=================================================================================================================
 
 // * Init Config
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AnalyticsHandler handles sales analytics HTTP requests
+type AnalyticsHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(usecase *usecase.UsecaseManager) *AnalyticsHandler {
+	return &AnalyticsHandler{usecase: usecase}
+}
+
+// GetSalesSummary returns revenue, COGS, margin and ticket figures grouped by period and outlet, cashier or payment method
+func (h *AnalyticsHandler) GetSalesSummary(c *fiber.Ctx) error {
+	var req interfaces.SalesAnalyticsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	summary, err := h.usecase.Analytics.SalesSummary(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Sales summary retrieved successfully",
+		Data:    summary,
+	})
+}
+
+// GetTopProducts returns the best selling products by quantity or margin
+func (h *AnalyticsHandler) GetTopProducts(c *fiber.Ctx) error {
+	var req interfaces.TopItemsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	top, err := h.usecase.Analytics.TopProducts(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Top products retrieved successfully",
+		Data:    top,
+	})
+}
+
+// GetTopServices returns the most performed services by quantity or margin
+func (h *AnalyticsHandler) GetTopServices(c *fiber.Ctx) error {
+	var req interfaces.TopItemsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	top, err := h.usecase.Analytics.TopServices(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Top services retrieved successfully",
+		Data:    top,
+	})
+}
+
+// GetTechnicianPerformance returns jobs completed, queue and repair times, comebacks, revenue and commission per technician or outlet
+func (h *AnalyticsHandler) GetTechnicianPerformance(c *fiber.Ctx) error {
+	var req interfaces.TechnicianPerformanceRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	performance, err := h.usecase.Analytics.TechnicianPerformance(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Technician performance retrieved successfully",
+		Data:    performance,
+	})
+}
+
+// GetLabourEfficiency returns the clocked time against the standard time of the finished lines and the time-based commission per technician or service
+func (h *AnalyticsHandler) GetLabourEfficiency(c *fiber.Ctx) error {
+	var req interfaces.LabourEfficiencyRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	efficiency, err := h.usecase.Analytics.LabourEfficiency(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Labour efficiency retrieved successfully",
+		Data:    efficiency,
+	})
+}
+
+// GetStockAnalysis returns fast, slow and dead moving products with ABC classes and the capital tied up per category and supplier
+func (h *AnalyticsHandler) GetStockAnalysis(c *fiber.Ctx) error {
+	var req interfaces.StockAnalysisRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	analysis, err := h.usecase.Analytics.StockAnalysis(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Stock analysis retrieved successfully",
+		Data:    analysis,
+	})
+}
@@ -0,0 +1,419 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AppointmentHandler handles appointment booking, the outlets' opening hours and holidays
+type AppointmentHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewAppointmentHandler creates a new appointment handler
+func NewAppointmentHandler(usecase *usecase.UsecaseManager) *AppointmentHandler {
+	return &AppointmentHandler{usecase: usecase}
+}
+
+// CreateAppointment books a vehicle into a slot of an outlet
+func (h *AppointmentHandler) CreateAppointment(c *fiber.Ctx) error {
+	var req interfaces.CreateAppointmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	appointment, err := h.usecase.Appointment.CreateAppointment(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.Response{
+		Status:  "success",
+		Message: "Appointment created successfully",
+		Data:    appointment,
+	})
+}
+
+// ListAppointments lists appointments with filtering, sorting and pagination
+func (h *AppointmentHandler) ListAppointments(c *fiber.Ctx) error {
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	appointments, total, err := h.usecase.Appointment.ListAppointments(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Appointments retrieved successfully",
+		Data:       appointments,
+		Pagination: listPagination(q, total),
+	})
+}
+
+// GetCalendar returns the days of an outlet with their hours and appointments
+func (h *AppointmentHandler) GetCalendar(c *fiber.Ctx) error {
+	var req interfaces.AppointmentCalendarQuery
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	days, err := h.usecase.Appointment.GetCalendar(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Appointment calendar retrieved successfully",
+		Data:    days,
+	})
+}
+
+// GetAppointment returns a single appointment with its services
+func (h *AppointmentHandler) GetAppointment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid appointment ID",
+			Error:   err.Error(),
+		})
+	}
+
+	appointment, err := h.usecase.Appointment.GetAppointment(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Appointment retrieved successfully",
+		Data:    appointment,
+	})
+}
+
+// UpdateAppointment reschedules an appointment
+func (h *AppointmentHandler) UpdateAppointment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid appointment ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.UpdateAppointmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	appointment, err := h.usecase.Appointment.UpdateAppointment(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Appointment updated successfully",
+		Data:    appointment,
+	})
+}
+
+// CancelAppointment cancels an appointment
+func (h *AppointmentHandler) CancelAppointment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid appointment ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.CancelAppointmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	appointment, err := h.usecase.Appointment.CancelAppointment(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Appointment cancelled successfully",
+		Data:    appointment,
+	})
+}
+
+// MarkNoShow records that the customer did not come
+func (h *AppointmentHandler) MarkNoShow(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid appointment ID",
+			Error:   err.Error(),
+		})
+	}
+
+	appointment, err := h.usecase.Appointment.MarkNoShow(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Appointment marked as no-show successfully",
+		Data:    appointment,
+	})
+}
+
+// CheckIn turns an arriving appointment into a service job
+func (h *AppointmentHandler) CheckIn(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid appointment ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.CheckInAppointmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	appointment, err := h.usecase.Appointment.CheckIn(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Appointment checked in successfully",
+		Data:    appointment,
+	})
+}
+
+// GetAvailableSlots returns the slots of an outlet's day with the bays free in them
+func (h *AppointmentHandler) GetAvailableSlots(c *fiber.Ctx) error {
+	outletID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid outlet ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.AvailableSlotsQuery
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	day, err := h.usecase.Appointment.GetAvailableSlots(c.UserContext(), uint(outletID), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Appointment slots retrieved successfully",
+		Data:    day,
+	})
+}
+
+// GetOpeningHours returns the hours an outlet takes appointments
+func (h *AppointmentHandler) GetOpeningHours(c *fiber.Ctx) error {
+	outletID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid outlet ID",
+			Error:   err.Error(),
+		})
+	}
+
+	hours, err := h.usecase.Appointment.GetOpeningHours(c.UserContext(), uint(outletID))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Opening hours retrieved successfully",
+		Data:    hours,
+	})
+}
+
+// SetOpeningHours replaces the opening hours of an outlet
+func (h *AppointmentHandler) SetOpeningHours(c *fiber.Ctx) error {
+	outletID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid outlet ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.SetOpeningHoursRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	hours, err := h.usecase.Appointment.SetOpeningHours(c.UserContext(), uint(outletID), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Opening hours updated successfully",
+		Data:    hours,
+	})
+}
+
+// ListHolidays lists the holidays of an outlet and of all outlets
+func (h *AppointmentHandler) ListHolidays(c *fiber.Ctx) error {
+	var req interfaces.HolidayQuery
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	holidays, err := h.usecase.Appointment.ListHolidays(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Holidays retrieved successfully",
+		Data:    holidays,
+	})
+}
+
+// CreateHoliday closes a day for an outlet or for all outlets
+func (h *AppointmentHandler) CreateHoliday(c *fiber.Ctx) error {
+	var req interfaces.CreateHolidayRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	holiday, err := h.usecase.Appointment.CreateHoliday(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.Response{
+		Status:  "success",
+		Message: "Holiday created successfully",
+		Data:    holiday,
+	})
+}
+
+// DeleteHoliday deletes a holiday
+func (h *AppointmentHandler) DeleteHoliday(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid holiday ID",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := h.usecase.Appointment.DeleteHoliday(c.UserContext(), uint(id)); err != nil {
+		return err
+	}
+
+	return c.JSON(responses.Response{
+		Status:  "success",
+		Message: "Holiday deleted successfully",
+	})
+}
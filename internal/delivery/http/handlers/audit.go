@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditHandler handles audit log HTTP requests
+type AuditHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(usecase *usecase.UsecaseManager) *AuditHandler {
+	return &AuditHandler{usecase: usecase}
+}
+
+// ListAuditLogs lists audit log entries, filterable by entity, action, user, outlet and created_at
+func (h *AuditHandler) ListAuditLogs(c *fiber.Ctx) error {
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	logs, total, err := h.usecase.AuditLog.ListAuditLogs(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Audit logs retrieved successfully",
+		Data:       logs,
+		Pagination: listPagination(q, total),
+	})
+}
+
+// GetAuditLog retrieves an audit log entry by ID
+func (h *AuditHandler) GetAuditLog(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid audit log ID",
+			Error:   err.Error(),
+		})
+	}
+
+	log, err := h.usecase.AuditLog.GetAuditLog(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Audit log retrieved successfully",
+		Data:    log,
+	})
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// sseRetryMillis is how long browsers wait before reconnecting a dropped stream
+const sseRetryMillis = 3000
+
+// BoardHandler handles the live workshop boards: the staff board and the public queue display of an outlet
+type BoardHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewBoardHandler creates a new board handler
+func NewBoardHandler(usecase *usecase.UsecaseManager) *BoardHandler {
+	return &BoardHandler{usecase: usecase}
+}
+
+// GetBoard returns the jobs on the board of an outlet
+func (h *BoardHandler) GetBoard(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid outlet ID",
+			Error:   err.Error(),
+		})
+	}
+
+	jobs, err := h.usecase.Board.GetBoard(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(responses.Response{
+		Status:  "success",
+		Message: "Board retrieved successfully",
+		Data:    jobs,
+	})
+}
+
+// StreamBoard streams the board of an outlet as server-sent events, resuming after the Last-Event-ID header or the
+// last_event_id query parameter
+func (h *BoardHandler) StreamBoard(c *fiber.Ctx) error {
+	id, lastEventID, err := parseBoardStream(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid board stream",
+			Error:   err.Error(),
+		})
+	}
+
+	// The stream outlives the handler, it ends when the client goes away
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := h.usecase.Board.Subscribe(ctx, id, lastEventID)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	return streamEvents(c, cancel, func() (sseEvent, bool) {
+		event, ok := <-events
+		return sseEvent{ID: event.ID, Type: event.Type, Data: event}, ok
+	})
+}
+
+// GetQueueDisplay returns the public queue display of an outlet
+func (h *BoardHandler) GetQueueDisplay(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid outlet ID",
+			Error:   err.Error(),
+		})
+	}
+
+	jobs, err := h.usecase.Board.GetPublicBoard(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(responses.Response{
+		Status:  "success",
+		Message: "Queue retrieved successfully",
+		Data:    jobs,
+	})
+}
+
+// StreamQueueDisplay streams the public queue display of an outlet as server-sent events
+func (h *BoardHandler) StreamQueueDisplay(c *fiber.Ctx) error {
+	id, lastEventID, err := parseBoardStream(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid queue stream",
+			Error:   err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := h.usecase.Board.SubscribePublic(ctx, id, lastEventID)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	return streamEvents(c, cancel, func() (sseEvent, bool) {
+		event, ok := <-events
+		return sseEvent{ID: event.ID, Type: event.Type, Data: event}, ok
+	})
+}
+
+// QueueDisplayPage renders the queue screen of an outlet from views/queue.html, it follows the public stream
+func (h *BoardHandler) QueueDisplayPage(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	outlet, err := h.usecase.Outlet.GetOutlet(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Render("queue", fiber.Map{
+		"Title":      "Antrian " + outlet.OutletName,
+		"OutletName": outlet.OutletName,
+		"StreamURL":  fmt.Sprintf("/api/v1/queue-display/%d/stream", id),
+	})
+}
+
+// parseBoardStream reads the outlet of a stream and the last event the client saw, 0 for none
+func parseBoardStream(c *fiber.Ctx) (uint, uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid outlet ID: %w", err)
+	}
+	last := c.Get("Last-Event-ID")
+	if last == "" {
+		last = c.Query("last_event_id")
+	}
+	if last == "" {
+		return uint(id), 0, nil
+	}
+	lastEventID, err := strconv.ParseUint(last, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid last event ID: %w", err)
+	}
+	return uint(id), uint(lastEventID), nil
+}
+
+// sseEvent is a server-sent event; events without ID, such as the first snapshot before any change, leave the
+// client's last event ID as it is
+type sseEvent struct {
+	ID   uint
+	Type string
+	Data interface{}
+}
+
+// streamEvents writes the events returned by next as server-sent events until next reports the end or the client
+// goes away, then calls cancel to stop the subscription
+func streamEvents(c *fiber.Ctx, cancel context.CancelFunc, next func() (sseEvent, bool)) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// Keeps nginx from buffering the stream
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		fmt.Fprintf(w, "retry: %d\n\n", sseRetryMillis)
+		if err := w.Flush(); err != nil {
+			return
+		}
+		for {
+			event, ok := next()
+			if !ok {
+				return
+			}
+			if event.Type == interfaces.BoardHeartbeat {
+				fmt.Fprint(w, ": heartbeat\n\n")
+			} else {
+				data, err := json.Marshal(event.Data)
+				if err != nil {
+					return
+				}
+				if event.ID > 0 {
+					fmt.Fprintf(w, "id: %d\n", event.ID)
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			}
+			// A failed flush is the client gone
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}))
+	return nil
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CostingHandler handles stock receipt, cost method and inventory valuation HTTP requests
+type CostingHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewCostingHandler creates a new costing handler
+func NewCostingHandler(usecase *usecase.UsecaseManager) *CostingHandler {
+	return &CostingHandler{usecase: usecase}
+}
+
+// ReceiveStock books a stock receipt at its purchase cost
+func (h *CostingHandler) ReceiveStock(c *fiber.Ctx) error {
+	var req interfaces.StockReceiptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	receipt, err := h.usecase.Costing.ReceiveStock(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.Response{
+		Status:  "success",
+		Message: "Stock received successfully",
+		Data:    receipt,
+	})
+}
+
+// ChangeCostMethod switches the cost method of a product and returns the revaluation entries
+func (h *CostingHandler) ChangeCostMethod(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid product ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.ChangeCostMethodRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	change, err := h.usecase.Costing.ChangeCostMethod(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Cost method changed successfully",
+		Data:    change,
+	})
+}
+
+// GetCostLayers returns the open cost layers of a product
+func (h *CostingHandler) GetCostLayers(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid product ID",
+			Error:   err.Error(),
+		})
+	}
+
+	layers, err := h.usecase.Costing.GetCostLayers(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Cost layers retrieved successfully",
+		Data:    layers,
+	})
+}
+
+// GetInventoryValuation values the stock per outlet at the end of a day
+func (h *CostingHandler) GetInventoryValuation(c *fiber.Ctx) error {
+	var req interfaces.InventoryValuationRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	valuation, err := h.usecase.Costing.InventoryValuation(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Inventory valuation retrieved successfully",
+		Data:    valuation,
+	})
+}
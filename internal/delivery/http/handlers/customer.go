@@ -4,6 +4,7 @@ import (
 	"boilerplate/internal/delivery/http/responses"
 	"boilerplate/internal/usecase"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -32,13 +33,13 @@ func (h *CustomerHandler) CreateCustomer(c *fiber.Ctx) error {
 		})
 	}
 
-	customer, err := h.usecase.Customer.CreateCustomer(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	customer, err := h.usecase.Customer.CreateCustomer(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create customer",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -60,13 +61,9 @@ func (h *CustomerHandler) GetCustomer(c *fiber.Ctx) error {
 		})
 	}
 
-	customer, err := h.usecase.Customer.GetCustomer(c.Context(), uint(id))
+	customer, err := h.usecase.Customer.GetCustomer(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Customer not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -86,13 +83,9 @@ func (h *CustomerHandler) GetCustomerByPhoneNumber(c *fiber.Ctx) error {
 		})
 	}
 
-	customer, err := h.usecase.Customer.GetCustomerByPhoneNumber(c.Context(), phoneNumber)
+	customer, err := h.usecase.Customer.GetCustomerByPhoneNumber(c.UserContext(), phoneNumber)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Customer not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -123,13 +116,13 @@ func (h *CustomerHandler) UpdateCustomer(c *fiber.Ctx) error {
 		})
 	}
 
-	customer, err := h.usecase.Customer.UpdateCustomer(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	customer, err := h.usecase.Customer.UpdateCustomer(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update customer",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -151,13 +144,9 @@ func (h *CustomerHandler) DeleteCustomer(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.Customer.DeleteCustomer(c.Context(), uint(id))
+	err = h.usecase.Customer.DeleteCustomer(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete customer",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -168,17 +157,14 @@ func (h *CustomerHandler) DeleteCustomer(c *fiber.Ctx) error {
 
 // ListCustomers handles listing customers with pagination
 func (h *CustomerHandler) ListCustomers(c *fiber.Ctx) error {
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	offset := (page - 1) * limit
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	customers, err := h.usecase.Customer.ListCustomers(c.Context(), limit, offset)
+	customers, total, err := h.usecase.Customer.ListCustomers(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve customers",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var customerResponses []responses.CustomerResponse
@@ -186,10 +172,11 @@ func (h *CustomerHandler) ListCustomers(c *fiber.Ctx) error {
 		customerResponses = append(customerResponses, *responses.ToCustomerResponse(customer))
 	}
 
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Customers retrieved successfully",
-		Data:    customerResponses,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Customers retrieved successfully",
+		Data:       customerResponses,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -207,13 +194,9 @@ func (h *CustomerHandler) SearchCustomers(c *fiber.Ctx) error {
 	limit := c.QueryInt("limit", 10)
 	offset := (page - 1) * limit
 
-	customers, err := h.usecase.Customer.SearchCustomers(c.Context(), query, limit, offset)
+	customers, err := h.usecase.Customer.SearchCustomers(c.UserContext(), query, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to search customers",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var customerResponses []responses.CustomerResponse
@@ -241,13 +224,13 @@ func (h *CustomerHandler) CreateCustomerVehicle(c *fiber.Ctx) error {
 		})
 	}
 
-	vehicle, err := h.usecase.CustomerVehicle.CreateCustomerVehicle(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	vehicle, err := h.usecase.CustomerVehicle.CreateCustomerVehicle(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create customer vehicle",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -269,13 +252,9 @@ func (h *CustomerHandler) GetCustomerVehicle(c *fiber.Ctx) error {
 		})
 	}
 
-	vehicle, err := h.usecase.CustomerVehicle.GetCustomerVehicle(c.Context(), uint(id))
+	vehicle, err := h.usecase.CustomerVehicle.GetCustomerVehicle(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Customer vehicle not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -306,13 +285,13 @@ func (h *CustomerHandler) UpdateCustomerVehicle(c *fiber.Ctx) error {
 		})
 	}
 
-	vehicle, err := h.usecase.CustomerVehicle.UpdateCustomerVehicle(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	vehicle, err := h.usecase.CustomerVehicle.UpdateCustomerVehicle(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update customer vehicle",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -334,13 +313,9 @@ func (h *CustomerHandler) DeleteCustomerVehicle(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.CustomerVehicle.DeleteCustomerVehicle(c.Context(), uint(id))
+	err = h.usecase.CustomerVehicle.DeleteCustomerVehicle(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete customer vehicle",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -351,17 +326,14 @@ func (h *CustomerHandler) DeleteCustomerVehicle(c *fiber.Ctx) error {
 
 // ListCustomerVehicles handles listing customer vehicles with pagination
 func (h *CustomerHandler) ListCustomerVehicles(c *fiber.Ctx) error {
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	offset := (page - 1) * limit
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	vehicles, err := h.usecase.CustomerVehicle.ListCustomerVehicles(c.Context(), limit, offset)
+	vehicles, total, err := h.usecase.CustomerVehicle.ListCustomerVehicles(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve customer vehicles",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var vehicleResponses []responses.CustomerVehicleResponse
@@ -369,10 +341,11 @@ func (h *CustomerHandler) ListCustomerVehicles(c *fiber.Ctx) error {
 		vehicleResponses = append(vehicleResponses, *responses.ToCustomerVehicleResponse(vehicle))
 	}
 
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Customer vehicles retrieved successfully",
-		Data:    vehicleResponses,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Customer vehicles retrieved successfully",
+		Data:       vehicleResponses,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -388,13 +361,9 @@ func (h *CustomerHandler) GetCustomerVehiclesByCustomerID(c *fiber.Ctx) error {
 		})
 	}
 
-	vehicles, err := h.usecase.CustomerVehicle.GetCustomerVehiclesByCustomerID(c.Context(), uint(customerID))
+	vehicles, err := h.usecase.CustomerVehicle.GetCustomerVehiclesByCustomerID(c.UserContext(), uint(customerID))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve customer vehicles",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var vehicleResponses []responses.CustomerVehicleResponse
@@ -423,13 +392,9 @@ func (h *CustomerHandler) SearchCustomerVehicles(c *fiber.Ctx) error {
 	limit := c.QueryInt("limit", 10)
 	offset := (page - 1) * limit
 
-	vehicles, err := h.usecase.CustomerVehicle.SearchCustomerVehicles(c.Context(), query, limit, offset)
+	vehicles, err := h.usecase.CustomerVehicle.SearchCustomerVehicles(c.UserContext(), query, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to search customer vehicles",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var vehicleResponses []responses.CustomerVehicleResponse
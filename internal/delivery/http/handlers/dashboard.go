@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DashboardHandler handles owner dashboard HTTP requests
+type DashboardHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(usecase *usecase.UsecaseManager) *DashboardHandler {
+	return &DashboardHandler{usecase: usecase}
+}
+
+// GetDashboard returns today's and this month's figures per outlet with the comparison to the previous period
+func (h *DashboardHandler) GetDashboard(c *fiber.Ctx) error {
+	var req interfaces.DashboardRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	dashboard, err := h.usecase.Dashboard.GetDashboard(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Dashboard retrieved successfully",
+		Data:    dashboard,
+	})
+}
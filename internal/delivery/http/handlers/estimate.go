@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/exception"
+	"boilerplate/pkg/validator"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// EstimateHandler handles the repair estimates of service jobs, for staff and through the customer's approval link
+type EstimateHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewEstimateHandler creates a new estimate handler
+func NewEstimateHandler(usecase *usecase.UsecaseManager) *EstimateHandler {
+	return &EstimateHandler{usecase: usecase}
+}
+
+// CreateEstimate creates the next estimate revision of a service job
+func (h *EstimateHandler) CreateEstimate(c *fiber.Ctx) error {
+	serviceJobID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.CreateEstimateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	estimate, err := h.usecase.Estimate.CreateEstimate(c.UserContext(), uint(serviceJobID), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.Response{
+		Status:  "success",
+		Message: "Estimate created successfully",
+		Data:    estimate,
+	})
+}
+
+// ListEstimates lists the estimate revisions of a service job, newest first
+func (h *EstimateHandler) ListEstimates(c *fiber.Ctx) error {
+	serviceJobID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	estimates, err := h.usecase.Estimate.ListEstimates(c.UserContext(), uint(serviceJobID))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Estimates retrieved successfully",
+		Data:    estimates,
+	})
+}
+
+// GetEstimate returns a single estimate revision with its items and decision
+func (h *EstimateHandler) GetEstimate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid estimate ID",
+			Error:   err.Error(),
+		})
+	}
+
+	estimate, err := h.usecase.Estimate.GetEstimate(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Estimate retrieved successfully",
+		Data:    estimate,
+	})
+}
+
+// DecideEstimate records the decision the customer gave at the counter or by phone
+func (h *EstimateHandler) DecideEstimate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid estimate ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.EstimateDecisionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	estimate, err := h.usecase.Estimate.DecideEstimate(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Estimate decision recorded successfully",
+		Data:    estimate,
+	})
+}
+
+// GetPublicEstimate returns the estimate of a signed approval link
+func (h *EstimateHandler) GetPublicEstimate(c *fiber.Ctx) error {
+	id, link, err := parseEstimateLink(c)
+	if err != nil {
+		return err
+	}
+
+	estimate, err := h.usecase.Estimate.GetPublicEstimate(c.UserContext(), id, link)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Estimate retrieved successfully",
+		Data:    estimate,
+	})
+}
+
+// DecidePublicEstimate records the customer's decision through a signed approval link
+func (h *EstimateHandler) DecidePublicEstimate(c *fiber.Ctx) error {
+	id, link, err := parseEstimateLink(c)
+	if err != nil {
+		return err
+	}
+
+	var req interfaces.PublicEstimateDecisionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	estimate, err := h.usecase.Estimate.DecidePublicEstimate(c.UserContext(), id, link, req, c.IP())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Estimate decision recorded successfully",
+		Data:    estimate,
+	})
+}
+
+// EstimatePage renders the estimate of a signed approval link with the approve and reject form
+func (h *EstimateHandler) EstimatePage(c *fiber.Ctx) error {
+	id, link, err := parseEstimateLink(c)
+	if err != nil {
+		return h.renderEstimate(c, interfaces.PublicEstimateDecisionRequest{}, nil, "", err)
+	}
+
+	estimate, err := h.usecase.Estimate.GetPublicEstimate(c.UserContext(), id, link)
+	return h.renderEstimate(c, interfaces.PublicEstimateDecisionRequest{}, estimate, "", err)
+}
+
+// EstimatePageDecision records the decision posted by the form of the estimate page
+func (h *EstimateHandler) EstimatePageDecision(c *fiber.Ctx) error {
+	id, link, err := parseEstimateLink(c)
+	if err != nil {
+		return h.renderEstimate(c, interfaces.PublicEstimateDecisionRequest{}, nil, "", err)
+	}
+
+	var req interfaces.PublicEstimateDecisionRequest
+	err = c.BodyParser(&req)
+	if err == nil {
+		err = validator.Validate(req)
+	}
+	if err != nil {
+		// The form is shown again with the estimate and the values entered
+		estimate, getErr := h.usecase.Estimate.GetPublicEstimate(c.UserContext(), id, link)
+		if getErr != nil {
+			return h.renderEstimate(c, req, nil, "", getErr)
+		}
+		return h.renderEstimate(c, req, estimate, "", err)
+	}
+
+	estimate, err := h.usecase.Estimate.DecidePublicEstimate(c.UserContext(), id, link, req, c.IP())
+	if err != nil {
+		current, getErr := h.usecase.Estimate.GetPublicEstimate(c.UserContext(), id, link)
+		if getErr != nil {
+			return h.renderEstimate(c, req, nil, "", getErr)
+		}
+		return h.renderEstimate(c, req, current, "", err)
+	}
+
+	notice := "Terima kasih, estimasi biaya telah Anda setujui. Kami akan segera mengerjakan kendaraan Anda."
+	if req.Decision == interfaces.EstimateDecisionReject {
+		notice = "Terima kasih, penolakan estimasi biaya telah kami terima. Kami akan menghubungi Anda."
+	}
+	return h.renderEstimate(c, req, estimate, notice, nil)
+}
+
+// renderEstimate renders views/estimate.html; domain and validation errors are shown on the page in Indonesian,
+// anything else is left to the error handler
+func (h *EstimateHandler) renderEstimate(c *fiber.Ctx, req interfaces.PublicEstimateDecisionRequest, estimate *interfaces.PublicEstimate, notice string, err error) error {
+	status := fiber.StatusOK
+	errorMessage := ""
+	if err != nil {
+		domainErr, ok := exception.AsDomainError(err)
+		if !ok {
+			return err
+		}
+		status = domainErr.StatusCode()
+		errorMessage = domainErr.MessageInd
+		if errors.Is(err, validator.ErrValidationFailed) {
+			errorMessage = "Pilih setuju atau tolak dan tuliskan nama lengkap Anda sebagai tanda tangan."
+		}
+	}
+
+	return c.Status(status).Render("estimate", fiber.Map{
+		"Title":    "Estimasi Biaya Servis",
+		"Action":   c.OriginalURL(),
+		"Name":     req.Name,
+		"Note":     req.Note,
+		"Estimate": estimate,
+		"Notice":   notice,
+		"Error":    errorMessage,
+	})
+}
+
+// parseEstimateLink reads the estimate ID and the signature of an approval link; a malformed link is as invalid
+// as a wrong signature
+func parseEstimateLink(c *fiber.Ctx) (uint, interfaces.EstimateLink, error) {
+	var link interfaces.EstimateLink
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, link, interfaces.ErrEstimateLinkInvalid
+	}
+	if err := c.QueryParser(&link); err != nil {
+		return 0, link, interfaces.ErrEstimateLinkInvalid
+	}
+	if err := validator.Validate(link); err != nil {
+		return 0, link, interfaces.ErrEstimateLinkInvalid
+	}
+	return uint(id), link, nil
+}
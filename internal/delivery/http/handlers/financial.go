@@ -2,11 +2,11 @@ package handlers
 
 import (
 	"boilerplate/internal/delivery/http/responses"
-	"boilerplate/internal/models"
 	"boilerplate/internal/usecase"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/validator"
 	"strconv"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -34,13 +34,13 @@ func (h *FinancialHandler) CreatePaymentMethod(c *fiber.Ctx) error {
 		})
 	}
 
-	paymentMethod, err := h.usecase.PaymentMethod.CreatePaymentMethod(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	paymentMethod, err := h.usecase.PaymentMethod.CreatePaymentMethod(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create payment method",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -52,22 +52,21 @@ func (h *FinancialHandler) CreatePaymentMethod(c *fiber.Ctx) error {
 
 // ListPaymentMethods lists all payment methods with pagination
 func (h *FinancialHandler) ListPaymentMethods(c *fiber.Ctx) error {
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	paymentMethods, err := h.usecase.PaymentMethod.ListPaymentMethods(c.Context(), limit, offset)
+	paymentMethods, total, err := h.usecase.PaymentMethod.ListPaymentMethods(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve payment methods",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Payment methods retrieved successfully",
-		Data:    paymentMethods,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Payment methods retrieved successfully",
+		Data:       paymentMethods,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -82,13 +81,9 @@ func (h *FinancialHandler) GetPaymentMethod(c *fiber.Ctx) error {
 		})
 	}
 
-	paymentMethod, err := h.usecase.PaymentMethod.GetPaymentMethod(c.Context(), uint(id))
+	paymentMethod, err := h.usecase.PaymentMethod.GetPaymentMethod(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Payment method not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -118,13 +113,13 @@ func (h *FinancialHandler) UpdatePaymentMethod(c *fiber.Ctx) error {
 		})
 	}
 
-	paymentMethod, err := h.usecase.PaymentMethod.UpdatePaymentMethod(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	paymentMethod, err := h.usecase.PaymentMethod.UpdatePaymentMethod(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update payment method",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -145,13 +140,9 @@ func (h *FinancialHandler) DeletePaymentMethod(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.PaymentMethod.DeletePaymentMethod(c.Context(), uint(id))
+	err = h.usecase.PaymentMethod.DeletePaymentMethod(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete payment method",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -174,13 +165,13 @@ func (h *FinancialHandler) CreateTransaction(c *fiber.Ctx) error {
 		})
 	}
 
-	transaction, err := h.usecase.Transaction.CreateTransaction(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	transaction, err := h.usecase.Transaction.CreateTransaction(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create transaction",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -192,22 +183,45 @@ func (h *FinancialHandler) CreateTransaction(c *fiber.Ctx) error {
 
 // ListTransactions lists all transactions with pagination
 func (h *FinancialHandler) ListTransactions(c *fiber.Ctx) error {
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
-
-	transactions, err := h.usecase.Transaction.ListTransactions(c.Context(), limit, offset)
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve transactions",
-			Error:   err.Error(),
+		return err
+	}
+
+	return h.listTransactions(c, q)
+}
+
+// listTransactions answers a page of the transactions matching q, by cursor when the query asks for it
+func (h *FinancialHandler) listTransactions(c *fiber.Ctx, q *query.ListQuery) error {
+	// Large histories are better paged with ?cursor=, which avoids counting and deep offsets
+	if q.CursorPaging {
+		transactions, nextCursor, err := h.usecase.Transaction.ListTransactionsByCursor(c.UserContext(), q)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusOK).JSON(responses.CursorPaginatedResponse{
+			Status:  "success",
+			Message: "Transactions retrieved successfully",
+			Data:    transactions,
+			Pagination: responses.CursorPagination{
+				Limit:      q.Limit,
+				NextCursor: nextCursor,
+				HasMore:    nextCursor != "",
+			},
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Transactions retrieved successfully",
-		Data:    transactions,
+	transactions, total, err := h.usecase.Transaction.ListTransactions(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Transactions retrieved successfully",
+		Data:       transactions,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -222,13 +236,9 @@ func (h *FinancialHandler) GetTransaction(c *fiber.Ctx) error {
 		})
 	}
 
-	transaction, err := h.usecase.Transaction.GetTransaction(c.Context(), uint(id))
+	transaction, err := h.usecase.Transaction.GetTransaction(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Transaction not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -249,13 +259,9 @@ func (h *FinancialHandler) GetTransactionByInvoiceNumber(c *fiber.Ctx) error {
 		})
 	}
 
-	transaction, err := h.usecase.Transaction.GetTransactionByInvoiceNumber(c.Context(), invoiceNumber)
+	transaction, err := h.usecase.Transaction.GetTransactionByInvoiceNumber(c.UserContext(), invoiceNumber)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Transaction not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -285,13 +291,13 @@ func (h *FinancialHandler) UpdateTransaction(c *fiber.Ctx) error {
 		})
 	}
 
-	transaction, err := h.usecase.Transaction.UpdateTransaction(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	transaction, err := h.usecase.Transaction.UpdateTransaction(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update transaction",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -312,13 +318,9 @@ func (h *FinancialHandler) DeleteTransaction(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.Transaction.DeleteTransaction(c.Context(), uint(id))
+	err = h.usecase.Transaction.DeleteTransaction(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete transaction",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -328,7 +330,7 @@ func (h *FinancialHandler) DeleteTransaction(c *fiber.Ctx) error {
 	})
 }
 
-// GetTransactionsByCustomer retrieves transactions by customer ID
+// GetTransactionsByCustomer retrieves the transactions of a customer, it takes the list query of ListTransactions
 func (h *FinancialHandler) GetTransactionsByCustomer(c *fiber.Ctx) error {
 	customerID, err := strconv.ParseUint(c.Params("customer_id"), 10, 32)
 	if err != nil {
@@ -339,23 +341,15 @@ func (h *FinancialHandler) GetTransactionsByCustomer(c *fiber.Ctx) error {
 		})
 	}
 
-	transactions, err := h.usecase.Transaction.GetTransactionsByCustomer(c.Context(), uint(customerID))
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve transactions",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Transactions retrieved successfully",
-		Data:    transactions,
-	})
+	return h.listTransactions(c, q.Where("customer_id", customerID))
 }
 
-// GetTransactionsByOutlet retrieves transactions by outlet ID
+// GetTransactionsByOutlet retrieves the transactions of an outlet, it takes the list query of ListTransactions
 func (h *FinancialHandler) GetTransactionsByOutlet(c *fiber.Ctx) error {
 	outletID, err := strconv.ParseUint(c.Params("outlet_id"), 10, 32)
 	if err != nil {
@@ -366,23 +360,15 @@ func (h *FinancialHandler) GetTransactionsByOutlet(c *fiber.Ctx) error {
 		})
 	}
 
-	transactions, err := h.usecase.Transaction.GetTransactionsByOutlet(c.Context(), uint(outletID))
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve transactions",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Transactions retrieved successfully",
-		Data:    transactions,
-	})
+	return h.listTransactions(c, q.Where("outlet_id", outletID))
 }
 
-// GetTransactionsByStatus retrieves transactions by status
+// GetTransactionsByStatus retrieves the transactions of a status, it takes the list query of ListTransactions
 func (h *FinancialHandler) GetTransactionsByStatus(c *fiber.Ctx) error {
 	status := c.Query("status")
 	if status == "" {
@@ -393,28 +379,21 @@ func (h *FinancialHandler) GetTransactionsByStatus(c *fiber.Ctx) error {
 		})
 	}
 
-	transactions, err := h.usecase.Transaction.GetTransactionsByStatus(c.Context(), models.TransactionStatus(status))
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve transactions",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Transactions retrieved successfully",
-		Data:    transactions,
-	})
+	return h.listTransactions(c, q.Where("status", status))
 }
 
-// GetTransactionsByDateRange retrieves transactions by date range
+// GetTransactionsByDateRange retrieves the transactions dated from start_date up to and including end_date,
+// it takes the list query of ListTransactions
 func (h *FinancialHandler) GetTransactionsByDateRange(c *fiber.Ctx) error {
-	startDateStr := c.Query("start_date")
-	endDateStr := c.Query("end_date")
+	startDate := c.Query("start_date")
+	endDate := c.Query("end_date")
 
-	if startDateStr == "" || endDateStr == "" {
+	if startDate == "" || endDate == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
 			Status:  "error",
 			Message: "Start date and end date are required",
@@ -422,38 +401,17 @@ func (h *FinancialHandler) GetTransactionsByDateRange(c *fiber.Ctx) error {
 		})
 	}
 
-	startDate, err := time.Parse("2006-01-02", startDateStr)
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
-			Status:  "error",
-			Message: "Invalid start date format",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	endDate, err := time.Parse("2006-01-02", endDateStr)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
-			Status:  "error",
-			Message: "Invalid end date format",
-			Error:   err.Error(),
-		})
-	}
-
-	transactions, err := h.usecase.Transaction.GetTransactionsByDateRange(c.Context(), startDate, endDate)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve transactions",
-			Error:   err.Error(),
-		})
-	}
-
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Transactions retrieved successfully",
-		Data:    transactions,
-	})
+	// Bare dates cover the whole day, so lte keeps the transactions of the end date
+	q.Filters = append(q.Filters,
+		query.Filter{Field: "transaction_date", Operator: query.OpGte, Value: startDate},
+		query.Filter{Field: "transaction_date", Operator: query.OpLte, Value: endDate},
+	)
+	return h.listTransactions(c, q)
 }
 
 // ============= Cash Flow Handlers =============
@@ -469,13 +427,13 @@ func (h *FinancialHandler) CreateCashFlow(c *fiber.Ctx) error {
 		})
 	}
 
-	cashFlow, err := h.usecase.CashFlow.CreateCashFlow(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	cashFlow, err := h.usecase.CashFlow.CreateCashFlow(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create cash flow",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -487,22 +445,26 @@ func (h *FinancialHandler) CreateCashFlow(c *fiber.Ctx) error {
 
 // ListCashFlows lists all cash flows with pagination
 func (h *FinancialHandler) ListCashFlows(c *fiber.Ctx) error {
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	return h.listCashFlows(c, q)
+}
 
-	cashFlows, err := h.usecase.CashFlow.ListCashFlows(c.Context(), limit, offset)
+// listCashFlows answers a page of the cash flows matching q
+func (h *FinancialHandler) listCashFlows(c *fiber.Ctx, q *query.ListQuery) error {
+	cashFlows, total, err := h.usecase.CashFlow.ListCashFlows(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve cash flows",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Cash flows retrieved successfully",
-		Data:    cashFlows,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Cash flows retrieved successfully",
+		Data:       cashFlows,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -517,13 +479,9 @@ func (h *FinancialHandler) GetCashFlow(c *fiber.Ctx) error {
 		})
 	}
 
-	cashFlow, err := h.usecase.CashFlow.GetCashFlow(c.Context(), uint(id))
+	cashFlow, err := h.usecase.CashFlow.GetCashFlow(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Cash flow not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -553,13 +511,13 @@ func (h *FinancialHandler) UpdateCashFlow(c *fiber.Ctx) error {
 		})
 	}
 
-	cashFlow, err := h.usecase.CashFlow.UpdateCashFlow(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	cashFlow, err := h.usecase.CashFlow.UpdateCashFlow(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update cash flow",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -580,13 +538,9 @@ func (h *FinancialHandler) DeleteCashFlow(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.CashFlow.DeleteCashFlow(c.Context(), uint(id))
+	err = h.usecase.CashFlow.DeleteCashFlow(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete cash flow",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -596,7 +550,7 @@ func (h *FinancialHandler) DeleteCashFlow(c *fiber.Ctx) error {
 	})
 }
 
-// GetCashFlowsByType retrieves cash flows by type
+// GetCashFlowsByType retrieves the cash flows of a type, it takes the list query of ListCashFlows
 func (h *FinancialHandler) GetCashFlowsByType(c *fiber.Ctx) error {
 	flowType := c.Query("type")
 	if flowType == "" {
@@ -607,18 +561,10 @@ func (h *FinancialHandler) GetCashFlowsByType(c *fiber.Ctx) error {
 		})
 	}
 
-	cashFlows, err := h.usecase.CashFlow.GetCashFlowsByType(c.Context(), models.CashFlowType(flowType))
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve cash flows",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Cash flows retrieved successfully",
-		Data:    cashFlows,
-	})
-}
\ No newline at end of file
+	return h.listCashFlows(c, q.Where("type", flowType))
+}
@@ -4,6 +4,7 @@ import (
 	"boilerplate/internal/delivery/http/responses"
 	"boilerplate/internal/usecase"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -32,13 +33,13 @@ func (h *FoundationHandler) CreateUser(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.usecase.User.CreateUser(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	user, err := h.usecase.User.CreateUser(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create user",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -60,13 +61,9 @@ func (h *FoundationHandler) GetUser(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.usecase.User.GetUser(c.Context(), uint(id))
+	user, err := h.usecase.User.GetUser(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "User not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -97,13 +94,13 @@ func (h *FoundationHandler) UpdateUser(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.usecase.User.UpdateUser(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	user, err := h.usecase.User.UpdateUser(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update user",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -125,13 +122,9 @@ func (h *FoundationHandler) DeleteUser(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.User.DeleteUser(c.Context(), uint(id))
+	err = h.usecase.User.DeleteUser(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete user",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -142,17 +135,14 @@ func (h *FoundationHandler) DeleteUser(c *fiber.Ctx) error {
 
 // ListUsers handles listing users with pagination
 func (h *FoundationHandler) ListUsers(c *fiber.Ctx) error {
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	offset := (page - 1) * limit
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	users, err := h.usecase.User.ListUsers(c.Context(), limit, offset)
+	users, total, err := h.usecase.User.ListUsers(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve users",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var userResponses []responses.UserResponse
@@ -160,10 +150,11 @@ func (h *FoundationHandler) ListUsers(c *fiber.Ctx) error {
 		userResponses = append(userResponses, *responses.ToUserResponse(user))
 	}
 
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Users retrieved successfully",
-		Data:    userResponses,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Users retrieved successfully",
+		Data:       userResponses,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -180,13 +171,13 @@ func (h *FoundationHandler) CreateOutlet(c *fiber.Ctx) error {
 		})
 	}
 
-	outlet, err := h.usecase.Outlet.CreateOutlet(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	outlet, err := h.usecase.Outlet.CreateOutlet(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create outlet",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -208,35 +199,65 @@ func (h *FoundationHandler) GetOutlet(c *fiber.Ctx) error {
 		})
 	}
 
-	outlet, err := h.usecase.Outlet.GetOutlet(c.Context(), uint(id))
+	outlet, err := h.usecase.Outlet.GetOutlet(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(responses.Response{
+		Status:  "success",
+		Message: "Outlet retrieved successfully",
+		Data:    responses.ToOutletResponse(outlet),
+	})
+}
+
+// UpdateOutlet handles outlet updates
+func (h *FoundationHandler) UpdateOutlet(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid outlet ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.UpdateOutletRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
 			Status:  "error",
-			Message: "Outlet not found",
+			Message: "Invalid request body",
 			Error:   err.Error(),
 		})
 	}
 
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	outlet, err := h.usecase.Outlet.UpdateOutlet(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
 	return c.JSON(responses.Response{
 		Status:  "success",
-		Message: "Outlet retrieved successfully",
+		Message: "Outlet updated successfully",
 		Data:    responses.ToOutletResponse(outlet),
 	})
 }
 
 // ListOutlets handles listing outlets
 func (h *FoundationHandler) ListOutlets(c *fiber.Ctx) error {
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	offset := (page - 1) * limit
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	outlets, err := h.usecase.Outlet.ListOutlets(c.Context(), limit, offset)
+	outlets, total, err := h.usecase.Outlet.ListOutlets(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve outlets",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var outletResponses []responses.OutletResponse
@@ -244,9 +265,10 @@ func (h *FoundationHandler) ListOutlets(c *fiber.Ctx) error {
 		outletResponses = append(outletResponses, *responses.ToOutletResponse(outlet))
 	}
 
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Outlets retrieved successfully",
-		Data:    outletResponses,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Outlets retrieved successfully",
+		Data:       outletResponses,
+		Pagination: listPagination(q, total),
 	})
-}
\ No newline at end of file
+}
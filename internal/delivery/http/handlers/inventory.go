@@ -5,6 +5,8 @@ import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/usecase"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/validator"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -33,13 +35,13 @@ func (h *InventoryHandler) CreateProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	product, err := h.usecase.Product.CreateProduct(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	product, err := h.usecase.Product.CreateProduct(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create product",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -61,13 +63,9 @@ func (h *InventoryHandler) GetProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	product, err := h.usecase.Product.GetProduct(c.Context(), uint(id))
+	product, err := h.usecase.Product.GetProduct(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Product not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -87,13 +85,9 @@ func (h *InventoryHandler) GetProductBySKU(c *fiber.Ctx) error {
 		})
 	}
 
-	product, err := h.usecase.Product.GetProductBySKU(c.Context(), sku)
+	product, err := h.usecase.Product.GetProductBySKU(c.UserContext(), sku)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Product not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -113,13 +107,9 @@ func (h *InventoryHandler) GetProductByBarcode(c *fiber.Ctx) error {
 		})
 	}
 
-	product, err := h.usecase.Product.GetProductByBarcode(c.Context(), barcode)
+	product, err := h.usecase.Product.GetProductByBarcode(c.UserContext(), barcode)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Product not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -150,13 +140,13 @@ func (h *InventoryHandler) UpdateProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	product, err := h.usecase.Product.UpdateProduct(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	product, err := h.usecase.Product.UpdateProduct(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update product",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -178,13 +168,9 @@ func (h *InventoryHandler) DeleteProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.Product.DeleteProduct(c.Context(), uint(id))
+	err = h.usecase.Product.DeleteProduct(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete product",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -195,32 +181,39 @@ func (h *InventoryHandler) DeleteProduct(c *fiber.Ctx) error {
 
 // ListProducts handles listing products with pagination
 func (h *InventoryHandler) ListProducts(c *fiber.Ctx) error {
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	offset := (page - 1) * limit
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	return h.listProducts(c, q)
+}
 
-	products, err := h.usecase.Product.ListProducts(c.Context(), limit, offset)
+// listProducts answers a page of the products matching q
+func (h *InventoryHandler) listProducts(c *fiber.Ctx, q *query.ListQuery) error {
+	products, total, err := h.usecase.Product.ListProducts(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve products",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Products retrieved successfully",
+		Data:       toProductResponses(products),
+		Pagination: listPagination(q, total),
+	})
+}
+
+// toProductResponses converts a page of products to their responses
+func toProductResponses(products []*models.Product) []responses.ProductResponse {
 	var productResponses []responses.ProductResponse
 	for _, product := range products {
 		productResponses = append(productResponses, *responses.ToProductResponse(product))
 	}
-
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Products retrieved successfully",
-		Data:    productResponses,
-	})
+	return productResponses
 }
 
-// GetProductsByCategory handles getting products by category
+// GetProductsByCategory handles listing the products of a category, it takes the list query of ListProducts
 func (h *InventoryHandler) GetProductsByCategory(c *fiber.Ctx) error {
 	idParam := c.Params("category_id")
 	categoryID, err := strconv.ParseUint(idParam, 10, 32)
@@ -232,28 +225,15 @@ func (h *InventoryHandler) GetProductsByCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	products, err := h.usecase.Product.GetProductsByCategory(c.Context(), uint(categoryID))
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve products",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	var productResponses []responses.ProductResponse
-	for _, product := range products {
-		productResponses = append(productResponses, *responses.ToProductResponse(product))
-	}
-
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Products retrieved successfully",
-		Data:    productResponses,
-	})
+	return h.listProducts(c, q.Where("category_id", categoryID))
 }
 
-// GetProductsBySupplier handles getting products by supplier
+// GetProductsBySupplier handles listing the products of a supplier, it takes the list query of ListProducts
 func (h *InventoryHandler) GetProductsBySupplier(c *fiber.Ctx) error {
 	idParam := c.Params("supplier_id")
 	supplierID, err := strconv.ParseUint(idParam, 10, 32)
@@ -265,28 +245,15 @@ func (h *InventoryHandler) GetProductsBySupplier(c *fiber.Ctx) error {
 		})
 	}
 
-	products, err := h.usecase.Product.GetProductsBySupplier(c.Context(), uint(supplierID))
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve products",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	var productResponses []responses.ProductResponse
-	for _, product := range products {
-		productResponses = append(productResponses, *responses.ToProductResponse(product))
-	}
-
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Products retrieved successfully",
-		Data:    productResponses,
-	})
+	return h.listProducts(c, q.Where("supplier_id", supplierID))
 }
 
-// GetProductsByUsageStatus handles getting products by usage status
+// GetProductsByUsageStatus handles listing the products of a usage status, it takes the list query of ListProducts
 func (h *InventoryHandler) GetProductsByUsageStatus(c *fiber.Ctx) error {
 	statusParam := c.Query("usage_status")
 	if statusParam == "" {
@@ -296,26 +263,12 @@ func (h *InventoryHandler) GetProductsByUsageStatus(c *fiber.Ctx) error {
 		})
 	}
 
-	status := models.ProductUsageStatus(statusParam)
-	products, err := h.usecase.Product.GetProductsByUsageStatus(c.Context(), status)
+	q, err := parseListQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve products",
-			Error:   err.Error(),
-		})
-	}
-
-	var productResponses []responses.ProductResponse
-	for _, product := range products {
-		productResponses = append(productResponses, *responses.ToProductResponse(product))
+		return err
 	}
 
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Products retrieved successfully",
-		Data:    productResponses,
-	})
+	return h.listProducts(c, q.Where("usage_status", statusParam))
 }
 
 // SearchProducts handles searching products
@@ -332,13 +285,9 @@ func (h *InventoryHandler) SearchProducts(c *fiber.Ctx) error {
 	limit := c.QueryInt("limit", 10)
 	offset := (page - 1) * limit
 
-	products, err := h.usecase.Product.SearchProducts(c.Context(), query, limit, offset)
+	products, err := h.usecase.Product.SearchProducts(c.UserContext(), query, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to search products",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var productResponses []responses.ProductResponse
@@ -376,43 +325,111 @@ func (h *InventoryHandler) UpdateProductStock(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.Product.UpdateProductStock(c.Context(), uint(id), req.Quantity)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	err = h.usecase.Product.UpdateProductStock(c.UserContext(), uint(id), req.Quantity)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
+		return err
+	}
+
+	return c.JSON(responses.Response{
+		Status:  "success",
+		Message: "Product stock updated successfully",
+	})
+}
+
+// UploadProductImage handles uploading the product image from the multipart field "image"
+func (h *InventoryHandler) UploadProductImage(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
 			Status:  "error",
-			Message: "Failed to update product stock",
+			Message: "Invalid product ID",
 			Error:   err.Error(),
 		})
 	}
 
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Image file is required",
+			Error:   err.Error(),
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid image file",
+			Error:   err.Error(),
+		})
+	}
+	defer file.Close()
+
+	product, err := h.usecase.Product.UploadProductImage(c.UserContext(), uint(id), interfaces.ProductImageUpload{
+		FileName: fileHeader.Filename,
+		Size:     fileHeader.Size,
+		Body:     file,
+	})
+	if err != nil {
+		return err
+	}
+
 	return c.JSON(responses.Response{
 		Status:  "success",
-		Message: "Product stock updated successfully",
+		Message: "Product image uploaded successfully",
+		Data:    responses.ToProductResponse(product),
 	})
 }
 
-// GetLowStockProducts handles getting low stock products
-func (h *InventoryHandler) GetLowStockProducts(c *fiber.Ctx) error {
-	threshold := c.QueryInt("threshold", 5)
-
-	products, err := h.usecase.Product.GetLowStockProducts(c.Context(), threshold)
+// DeleteProductImage handles removing the product image
+func (h *InventoryHandler) DeleteProductImage(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
 			Status:  "error",
-			Message: "Failed to retrieve low stock products",
+			Message: "Invalid product ID",
 			Error:   err.Error(),
 		})
 	}
 
-	var productResponses []responses.ProductResponse
-	for _, product := range products {
-		productResponses = append(productResponses, *responses.ToProductResponse(product))
+	product, err := h.usecase.Product.DeleteProductImage(c.UserContext(), uint(id))
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(responses.Response{
 		Status:  "success",
-		Message: "Low stock products retrieved successfully",
-		Data:    productResponses,
+		Message: "Product image deleted successfully",
+		Data:    responses.ToProductResponse(product),
+	})
+}
+
+// GetLowStockProducts handles listing the products at or below their min stock, or the threshold when they have none,
+// it takes the list query of ListProducts
+func (h *InventoryHandler) GetLowStockProducts(c *fiber.Ctx) error {
+	threshold := c.QueryInt("threshold", 5)
+
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	products, total, err := h.usecase.Product.GetLowStockProducts(c.UserContext(), threshold, q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Low stock products retrieved successfully",
+		Data:       toProductResponses(products),
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -429,13 +446,13 @@ func (h *InventoryHandler) CreateCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	category, err := h.usecase.Category.CreateCategory(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	category, err := h.usecase.Category.CreateCategory(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create category",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -457,13 +474,9 @@ func (h *InventoryHandler) GetCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	category, err := h.usecase.Category.GetCategory(c.Context(), uint(id))
+	category, err := h.usecase.Category.GetCategory(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Category not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -494,13 +507,13 @@ func (h *InventoryHandler) UpdateCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	category, err := h.usecase.Category.UpdateCategory(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	category, err := h.usecase.Category.UpdateCategory(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update category",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -522,13 +535,9 @@ func (h *InventoryHandler) DeleteCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.Category.DeleteCategory(c.Context(), uint(id))
+	err = h.usecase.Category.DeleteCategory(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete category",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -539,17 +548,14 @@ func (h *InventoryHandler) DeleteCategory(c *fiber.Ctx) error {
 
 // ListCategories handles listing categories with pagination
 func (h *InventoryHandler) ListCategories(c *fiber.Ctx) error {
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	offset := (page - 1) * limit
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	categories, err := h.usecase.Category.ListCategories(c.Context(), limit, offset)
+	categories, total, err := h.usecase.Category.ListCategories(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve categories",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var categoryResponses []responses.CategoryResponse
@@ -557,10 +563,11 @@ func (h *InventoryHandler) ListCategories(c *fiber.Ctx) error {
 		categoryResponses = append(categoryResponses, *responses.ToCategoryResponse(category))
 	}
 
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Categories retrieved successfully",
-		Data:    categoryResponses,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Categories retrieved successfully",
+		Data:       categoryResponses,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -577,13 +584,13 @@ func (h *InventoryHandler) CreateSupplier(c *fiber.Ctx) error {
 		})
 	}
 
-	supplier, err := h.usecase.Supplier.CreateSupplier(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	supplier, err := h.usecase.Supplier.CreateSupplier(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create supplier",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -605,13 +612,9 @@ func (h *InventoryHandler) GetSupplier(c *fiber.Ctx) error {
 		})
 	}
 
-	supplier, err := h.usecase.Supplier.GetSupplier(c.Context(), uint(id))
+	supplier, err := h.usecase.Supplier.GetSupplier(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Supplier not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -642,13 +645,13 @@ func (h *InventoryHandler) UpdateSupplier(c *fiber.Ctx) error {
 		})
 	}
 
-	supplier, err := h.usecase.Supplier.UpdateSupplier(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	supplier, err := h.usecase.Supplier.UpdateSupplier(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update supplier",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -670,13 +673,9 @@ func (h *InventoryHandler) DeleteSupplier(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.Supplier.DeleteSupplier(c.Context(), uint(id))
+	err = h.usecase.Supplier.DeleteSupplier(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete supplier",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -687,17 +686,14 @@ func (h *InventoryHandler) DeleteSupplier(c *fiber.Ctx) error {
 
 // ListSuppliers handles listing suppliers with pagination
 func (h *InventoryHandler) ListSuppliers(c *fiber.Ctx) error {
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	offset := (page - 1) * limit
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	suppliers, err := h.usecase.Supplier.ListSuppliers(c.Context(), limit, offset)
+	suppliers, total, err := h.usecase.Supplier.ListSuppliers(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve suppliers",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var supplierResponses []responses.SupplierResponse
@@ -705,10 +701,11 @@ func (h *InventoryHandler) ListSuppliers(c *fiber.Ctx) error {
 		supplierResponses = append(supplierResponses, *responses.ToSupplierResponse(supplier))
 	}
 
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Suppliers retrieved successfully",
-		Data:    supplierResponses,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Suppliers retrieved successfully",
+		Data:       supplierResponses,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -726,13 +723,9 @@ func (h *InventoryHandler) SearchSuppliers(c *fiber.Ctx) error {
 	limit := c.QueryInt("limit", 10)
 	offset := (page - 1) * limit
 
-	suppliers, err := h.usecase.Supplier.SearchSuppliers(c.Context(), query, limit, offset)
+	suppliers, err := h.usecase.Supplier.SearchSuppliers(c.UserContext(), query, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to search suppliers",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var supplierResponses []responses.SupplierResponse
@@ -760,13 +753,13 @@ func (h *InventoryHandler) CreateUnitType(c *fiber.Ctx) error {
 		})
 	}
 
-	unitType, err := h.usecase.UnitType.CreateUnitType(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	unitType, err := h.usecase.UnitType.CreateUnitType(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create unit type",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -788,13 +781,9 @@ func (h *InventoryHandler) GetUnitType(c *fiber.Ctx) error {
 		})
 	}
 
-	unitType, err := h.usecase.UnitType.GetUnitType(c.Context(), uint(id))
+	unitType, err := h.usecase.UnitType.GetUnitType(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Unit type not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -825,13 +814,13 @@ func (h *InventoryHandler) UpdateUnitType(c *fiber.Ctx) error {
 		})
 	}
 
-	unitType, err := h.usecase.UnitType.UpdateUnitType(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	unitType, err := h.usecase.UnitType.UpdateUnitType(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update unit type",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -853,13 +842,9 @@ func (h *InventoryHandler) DeleteUnitType(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.UnitType.DeleteUnitType(c.Context(), uint(id))
+	err = h.usecase.UnitType.DeleteUnitType(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete unit type",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(responses.Response{
@@ -870,17 +855,14 @@ func (h *InventoryHandler) DeleteUnitType(c *fiber.Ctx) error {
 
 // ListUnitTypes handles listing unit types with pagination
 func (h *InventoryHandler) ListUnitTypes(c *fiber.Ctx) error {
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	offset := (page - 1) * limit
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	unitTypes, err := h.usecase.UnitType.ListUnitTypes(c.Context(), limit, offset)
+	unitTypes, total, err := h.usecase.UnitType.ListUnitTypes(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve unit types",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	var unitTypeResponses []responses.UnitTypeResponse
@@ -888,9 +870,10 @@ func (h *InventoryHandler) ListUnitTypes(c *fiber.Ctx) error {
 		unitTypeResponses = append(unitTypeResponses, *responses.ToUnitTypeResponse(unitType))
 	}
 
-	return c.JSON(responses.Response{
-		Status:  "success",
-		Message: "Unit types retrieved successfully",
-		Data:    unitTypeResponses,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Unit types retrieved successfully",
+		Data:       unitTypeResponses,
+		Pagination: listPagination(q, total),
 	})
-}
\ No newline at end of file
+}
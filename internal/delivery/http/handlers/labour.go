@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LabourHandler handles the clocking of technicians on service jobs
+type LabourHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewLabourHandler creates a new labour handler
+func NewLabourHandler(usecase *usecase.UsecaseManager) *LabourHandler {
+	return &LabourHandler{usecase: usecase}
+}
+
+// StartWork clocks a technician in on a service job or one of its service lines
+func (h *LabourHandler) StartWork(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.StartLabourRequest
+	// The body is optional, without it the authenticated user clocks in on the whole job
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+				Status:  "error",
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	entry, err := h.usecase.Labour.StartWork(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Work started successfully",
+		Data:    entry,
+	})
+}
+
+// PauseWork clocks a technician out of a service job with the reason of the pause
+func (h *LabourHandler) PauseWork(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.PauseLabourRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	pause, err := h.usecase.Labour.PauseWork(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Work paused successfully",
+		Data:    pause,
+	})
+}
+
+// FinishWork clocks a technician out of a service job with the work done
+func (h *LabourHandler) FinishWork(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.FinishLabourRequest
+	// The body is optional, without it the authenticated user clocks out
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+				Status:  "error",
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	entry, err := h.usecase.Labour.FinishWork(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Work finished successfully",
+		Data:    entry,
+	})
+}
+
+// GetJobLabour returns the clocked time of a service job against the standard time of its service lines
+func (h *LabourHandler) GetJobLabour(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	labour, err := h.usecase.Labour.GetJobLabour(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Labour retrieved successfully",
+		Data:    labour,
+	})
+}
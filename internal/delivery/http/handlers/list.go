@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/pkg/query"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseListQuery reads filter[...], sort, page/per_page (or limit/offset) and cursor from the query string
+func parseListQuery(c *fiber.Ctx) (*query.ListQuery, error) {
+	params := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		params[string(key)] = string(value)
+	})
+	return query.Parse(params)
+}
+
+// listPagination builds the pagination metadata of a list page
+func listPagination(q *query.ListQuery, total int64) responses.Pagination {
+	return responses.Pagination{
+		Page:  q.Page(),
+		Limit: q.Limit,
+		Total: total,
+		Pages: q.Pages(total),
+	}
+}
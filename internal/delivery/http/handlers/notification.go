@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NotificationHandler handles customer notification HTTP requests
+type NotificationHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(usecase *usecase.UsecaseManager) *NotificationHandler {
+	return &NotificationHandler{usecase: usecase}
+}
+
+// ListNotifications lists the notifications, e.g. ?filter[status][eq]=Gagal&filter[customer_id][eq]=1
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	notifications, total, err := h.usecase.Notification.ListNotifications(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Notifications retrieved successfully",
+		Data:       notifications,
+		Pagination: listPagination(q, total),
+	})
+}
+
+// GetNotification returns a single notification with its delivery status
+func (h *NotificationHandler) GetNotification(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid notification ID",
+			Error:   err.Error(),
+		})
+	}
+
+	notification, err := h.usecase.Notification.GetNotification(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Notification retrieved successfully",
+		Data:    notification,
+	})
+}
+
+// RetryNotification queues a failed notification again
+func (h *NotificationHandler) RetryNotification(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid notification ID",
+			Error:   err.Error(),
+		})
+	}
+
+	notification, err := h.usecase.Notification.RetryNotification(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Notification queued again",
+		Data:    notification,
+	})
+}
+
+// GetPreferences returns per channel whether the customer receives notifications through it
+func (h *NotificationHandler) GetPreferences(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid customer ID",
+			Error:   err.Error(),
+		})
+	}
+
+	preferences, err := h.usecase.Notification.GetPreferences(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Notification preferences retrieved successfully",
+		Data:    preferences,
+	})
+}
+
+// UpdatePreferences opts the customer in or out of channels
+func (h *NotificationHandler) UpdatePreferences(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid customer ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.UpdateNotificationPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	preferences, err := h.usecase.Notification.UpdatePreferences(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Notification preferences updated successfully",
+		Data:    preferences,
+	})
+}
@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReplenishmentHandler handles reorder point, replenishment and purchase order HTTP requests
+type ReplenishmentHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewReplenishmentHandler creates a new replenishment handler
+func NewReplenishmentHandler(usecase *usecase.UsecaseManager) *ReplenishmentHandler {
+	return &ReplenishmentHandler{usecase: usecase}
+}
+
+// GetSuggestions lists the products to reorder with the suggested quantities
+func (h *ReplenishmentHandler) GetSuggestions(c *fiber.Ctx) error {
+	var req interfaces.ReplenishmentRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	plan, err := h.usecase.Replenishment.Suggestions(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Replenishment suggestions retrieved successfully",
+		Data:    plan,
+	})
+}
+
+// GenerateDraftOrders drafts purchase orders for the current suggestions
+func (h *ReplenishmentHandler) GenerateDraftOrders(c *fiber.Ctx) error {
+	var req interfaces.ReplenishmentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+				Status:  "error",
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	drafts, err := h.usecase.Replenishment.GenerateDraftOrders(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.Response{
+		Status:  "success",
+		Message: "Draft purchase orders generated successfully",
+		Data:    drafts,
+	})
+}
+
+// GetStockLevels returns the outlet reorder points of a product
+func (h *ReplenishmentHandler) GetStockLevels(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid product ID",
+			Error:   err.Error(),
+		})
+	}
+
+	levels, err := h.usecase.Replenishment.GetStockLevels(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Stock levels retrieved successfully",
+		Data:    levels,
+	})
+}
+
+// SetStockLevel sets the reorder point of a product at an outlet
+func (h *ReplenishmentHandler) SetStockLevel(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid product ID",
+			Error:   err.Error(),
+		})
+	}
+	outletID, err := strconv.ParseUint(c.Params("outlet_id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid outlet ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.SetStockLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	level, err := h.usecase.Replenishment.SetStockLevel(c.UserContext(), uint(id), uint(outletID), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Stock level set successfully",
+		Data:    level,
+	})
+}
+
+// DeleteStockLevel removes the reorder point of a product at an outlet
+func (h *ReplenishmentHandler) DeleteStockLevel(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid product ID",
+			Error:   err.Error(),
+		})
+	}
+	outletID, err := strconv.ParseUint(c.Params("outlet_id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid outlet ID",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := h.usecase.Replenishment.DeleteStockLevel(c.UserContext(), uint(id), uint(outletID)); err != nil {
+		return err
+	}
+
+	return c.JSON(responses.Response{
+		Status:  "success",
+		Message: "Stock level deleted successfully",
+	})
+}
+
+// ListPurchaseOrders lists purchase orders with pagination, e.g. ?filter[status][eq]=Draft
+func (h *ReplenishmentHandler) ListPurchaseOrders(c *fiber.Ctx) error {
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	purchaseOrders, total, err := h.usecase.PurchaseOrder.ListPurchaseOrders(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Purchase orders retrieved successfully",
+		Data:       purchaseOrders,
+		Pagination: listPagination(q, total),
+	})
+}
+
+// GetPurchaseOrder returns a purchase order with its lines
+func (h *ReplenishmentHandler) GetPurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid purchase order ID",
+			Error:   err.Error(),
+		})
+	}
+
+	purchaseOrder, err := h.usecase.PurchaseOrder.GetPurchaseOrder(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Purchase order retrieved successfully",
+		Data:    purchaseOrder,
+	})
+}
+
+// UpdatePurchaseOrder changes the supplier, payment type, notes or lines of a draft purchase order
+func (h *ReplenishmentHandler) UpdatePurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid purchase order ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.UpdatePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	purchaseOrder, err := h.usecase.PurchaseOrder.UpdateDraftPurchaseOrder(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Purchase order updated successfully",
+		Data:    purchaseOrder,
+	})
+}
+
+// ConfirmPurchaseOrder confirms a draft purchase order after review
+func (h *ReplenishmentHandler) ConfirmPurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid purchase order ID",
+			Error:   err.Error(),
+		})
+	}
+
+	purchaseOrder, err := h.usecase.PurchaseOrder.ConfirmPurchaseOrder(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Purchase order confirmed successfully",
+		Data:    purchaseOrder,
+	})
+}
+
+// ReceivePurchaseOrder books the goods of a pending purchase order into stock
+func (h *ReplenishmentHandler) ReceivePurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid purchase order ID",
+			Error:   err.Error(),
+		})
+	}
+
+	receipt, err := h.usecase.PurchaseOrder.ReceivePurchaseOrder(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Purchase order received successfully",
+		Data:    receipt,
+	})
+}
+
+// DeletePurchaseOrder discards a draft purchase order
+func (h *ReplenishmentHandler) DeletePurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid purchase order ID",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := h.usecase.PurchaseOrder.DeleteDraftPurchaseOrder(c.UserContext(), uint(id)); err != nil {
+		return err
+	}
+
+	return c.JSON(responses.Response{
+		Status:  "success",
+		Message: "Purchase order deleted successfully",
+	})
+}
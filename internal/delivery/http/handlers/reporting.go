@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReportHandler handles report HTTP requests
+type ReportHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(usecase *usecase.UsecaseManager) *ReportHandler {
+	return &ReportHandler{usecase: usecase}
+}
+
+// CreateReport queues a report, the file is generated in the background
+func (h *ReportHandler) CreateReport(c *fiber.Ctx) error {
+	var req interfaces.CreateReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	report, err := h.usecase.Report.RequestReport(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(responses.Response{
+		Status:  "success",
+		Message: "Report queued for generation",
+		Data:    report,
+	})
+}
+
+// ListReports lists reports, filterable by type, format, status, outlet, user and dates
+func (h *ReportHandler) ListReports(c *fiber.Ctx) error {
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	reports, total, err := h.usecase.Report.ListReports(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Reports retrieved successfully",
+		Data:       reports,
+		Pagination: listPagination(q, total),
+	})
+}
+
+// GetReport retrieves a report by ID, used to poll its status
+func (h *ReportHandler) GetReport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid report ID",
+			Error:   err.Error(),
+		})
+	}
+
+	report, err := h.usecase.Report.GetReport(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Report retrieved successfully",
+		Data:    report,
+	})
+}
+
+// DownloadReport streams the generated file of a finished report
+func (h *ReportHandler) DownloadReport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid report ID",
+			Error:   err.Error(),
+		})
+	}
+
+	file, err := h.usecase.Report.DownloadReport(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	// Fiber closes the body once the response has been written
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.Name))
+	return c.Status(fiber.StatusOK).SendStream(file.Body)
+}
+
+// RetryReport queues a failed report again
+func (h *ReportHandler) RetryReport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid report ID",
+			Error:   err.Error(),
+		})
+	}
+
+	report, err := h.usecase.Report.RetryReport(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(responses.Response{
+		Status:  "success",
+		Message: "Report queued for generation",
+		Data:    report,
+	})
+}
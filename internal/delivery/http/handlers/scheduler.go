@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SchedulerHandler handles scheduled job HTTP requests
+type SchedulerHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewSchedulerHandler creates a new scheduler handler
+func NewSchedulerHandler(usecase *usecase.UsecaseManager) *SchedulerHandler {
+	return &SchedulerHandler{usecase: usecase}
+}
+
+// ListJobs lists the scheduled jobs with their schedule, next run and latest run
+func (h *SchedulerHandler) ListJobs(c *fiber.Ctx) error {
+	jobs, err := h.usecase.Scheduler.ListJobs(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Jobs retrieved successfully",
+		Data:    jobs,
+	})
+}
+
+// TriggerJob starts a job now, the run continues in the background
+func (h *SchedulerHandler) TriggerJob(c *fiber.Ctx) error {
+	run, err := h.usecase.Scheduler.TriggerJob(c.UserContext(), c.Params("name"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(responses.Response{
+		Status:  "success",
+		Message: "Job started",
+		Data:    run,
+	})
+}
+
+// ListJobRuns lists the run history, e.g. ?filter[job_name][eq]=daily-reports&filter[status][eq]=Gagal
+func (h *SchedulerHandler) ListJobRuns(c *fiber.Ctx) error {
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
+
+	runs, total, err := h.usecase.Scheduler.ListRuns(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Job runs retrieved successfully",
+		Data:       runs,
+		Pagination: listPagination(q, total),
+	})
+}
+
+// GetJobRun returns a single run
+func (h *SchedulerHandler) GetJobRun(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid job run ID",
+			Error:   err.Error(),
+		})
+	}
+
+	run, err := h.usecase.Scheduler.GetRun(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Job run retrieved successfully",
+		Data:    run,
+	})
+}
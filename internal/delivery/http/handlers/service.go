@@ -5,6 +5,8 @@ import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/usecase"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/validator"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -33,13 +35,13 @@ func (h *ServiceHandler) CreateServiceCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	serviceCategory, err := h.usecase.ServiceCategory.CreateServiceCategory(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	serviceCategory, err := h.usecase.ServiceCategory.CreateServiceCategory(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create service category",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -51,22 +53,21 @@ func (h *ServiceHandler) CreateServiceCategory(c *fiber.Ctx) error {
 
 // ListServiceCategories lists all service categories with pagination
 func (h *ServiceHandler) ListServiceCategories(c *fiber.Ctx) error {
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	serviceCategories, err := h.usecase.ServiceCategory.ListServiceCategories(c.Context(), limit, offset)
+	serviceCategories, total, err := h.usecase.ServiceCategory.ListServiceCategories(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve service categories",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Service categories retrieved successfully",
-		Data:    serviceCategories,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Service categories retrieved successfully",
+		Data:       serviceCategories,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -81,13 +82,9 @@ func (h *ServiceHandler) GetServiceCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	serviceCategory, err := h.usecase.ServiceCategory.GetServiceCategory(c.Context(), uint(id))
+	serviceCategory, err := h.usecase.ServiceCategory.GetServiceCategory(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Service category not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -117,13 +114,13 @@ func (h *ServiceHandler) UpdateServiceCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	serviceCategory, err := h.usecase.ServiceCategory.UpdateServiceCategory(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	serviceCategory, err := h.usecase.ServiceCategory.UpdateServiceCategory(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update service category",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -144,13 +141,9 @@ func (h *ServiceHandler) DeleteServiceCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.ServiceCategory.DeleteServiceCategory(c.Context(), uint(id))
+	err = h.usecase.ServiceCategory.DeleteServiceCategory(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete service category",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -173,13 +166,13 @@ func (h *ServiceHandler) CreateService(c *fiber.Ctx) error {
 		})
 	}
 
-	service, err := h.usecase.Service.CreateService(c.Context(), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	service, err := h.usecase.Service.CreateService(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to create service",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -191,22 +184,21 @@ func (h *ServiceHandler) CreateService(c *fiber.Ctx) error {
 
 // ListServices lists all services with pagination
 func (h *ServiceHandler) ListServices(c *fiber.Ctx) error {
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	q, err := parseListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	services, err := h.usecase.Service.ListServices(c.Context(), limit, offset)
+	services, total, err := h.usecase.Service.ListServices(c.UserContext(), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve services",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.Response{
-		Status:  "success",
-		Message: "Services retrieved successfully",
-		Data:    services,
+	return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+		Status:     "success",
+		Message:    "Services retrieved successfully",
+		Data:       services,
+		Pagination: listPagination(q, total),
 	})
 }
 
@@ -221,13 +213,9 @@ func (h *ServiceHandler) GetService(c *fiber.Ctx) error {
 		})
 	}
 
-	service, err := h.usecase.Service.GetService(c.Context(), uint(id))
+	service, err := h.usecase.Service.GetService(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Service not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -248,13 +236,9 @@ func (h *ServiceHandler) GetServiceByCode(c *fiber.Ctx) error {
 		})
 	}
 
-	service, err := h.usecase.Service.GetServiceByServiceCode(c.Context(), serviceCode)
+	service, err := h.usecase.Service.GetServiceByServiceCode(c.UserContext(), serviceCode)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-			Status:  "error",
-			Message: "Service not found",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -284,13 +268,13 @@ func (h *ServiceHandler) UpdateService(c *fiber.Ctx) error {
 		})
 	}
 
-	service, err := h.usecase.Service.UpdateService(c.Context(), uint(id), req)
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	service, err := h.usecase.Service.UpdateService(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to update service",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -311,13 +295,9 @@ func (h *ServiceHandler) DeleteService(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.usecase.Service.DeleteService(c.Context(), uint(id))
+	err = h.usecase.Service.DeleteService(c.UserContext(), uint(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to delete service",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -341,13 +321,9 @@ func (h *ServiceHandler) SearchServices(c *fiber.Ctx) error {
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	offset, _ := strconv.Atoi(c.Query("offset", "0"))
 
-	services, err := h.usecase.Service.SearchServices(c.Context(), query, limit, offset)
+	services, err := h.usecase.Service.SearchServices(c.UserContext(), query, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to search services",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -368,13 +344,9 @@ func (h *ServiceHandler) GetServicesByCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	services, err := h.usecase.Service.GetServicesByCategory(c.Context(), uint(categoryID))
+	services, err := h.usecase.Service.GetServicesByCategory(c.UserContext(), uint(categoryID))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-			Status:  "error",
-			Message: "Failed to retrieve services",
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -396,13 +368,13 @@ Error:   err.Error(),
 })
 }
 
-serviceJob, err := h.usecase.ServiceJob.CreateServiceJob(c.Context(), req)
+if err := validator.Validate(req); err != nil {
+return err
+}
+
+serviceJob, err := h.usecase.ServiceJob.CreateServiceJob(c.UserContext(), req)
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to create service job",
-Error:   err.Error(),
-})
+return err
 }
 
 return c.Status(fiber.StatusCreated).JSON(responses.Response{
@@ -423,13 +395,9 @@ Error:   err.Error(),
 })
 }
 
-serviceJob, err := h.usecase.ServiceJob.GetServiceJob(c.Context(), uint(id))
+serviceJob, err := h.usecase.ServiceJob.GetServiceJob(c.UserContext(), uint(id))
 if err != nil {
-return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-Status:  "error",
-Message: "Service job not found",
-Error:   err.Error(),
-})
+return err
 }
 
 return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -441,16 +409,19 @@ Data:    responses.ToServiceJobResponse(serviceJob),
 
 // ListServiceJobs retrieves service jobs with pagination
 func (h *ServiceHandler) ListServiceJobs(c *fiber.Ctx) error {
-limit, _ := strconv.Atoi(c.Query("limit", "10"))
-offset, _ := strconv.Atoi(c.Query("offset", "0"))
+q, err := parseListQuery(c)
+if err != nil {
+return err
+}
 
-serviceJobs, err := h.usecase.ServiceJob.ListServiceJobs(c.Context(), limit, offset)
+return h.listServiceJobs(c, q)
+}
+
+// listServiceJobs answers a page of the service jobs matching q
+func (h *ServiceHandler) listServiceJobs(c *fiber.Ctx, q *query.ListQuery) error {
+serviceJobs, total, err := h.usecase.ServiceJob.ListServiceJobs(c.UserContext(), q)
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to retrieve service jobs",
-Error:   err.Error(),
-})
+return err
 }
 
 var serviceJobResponses []interface{}
@@ -458,13 +429,13 @@ for _, serviceJob := range serviceJobs {
 serviceJobResponses = append(serviceJobResponses, responses.ToServiceJobResponse(serviceJob))
 }
 
-return c.Status(fiber.StatusOK).JSON(responses.Response{
-Status:  "success",
-Message: "Service jobs retrieved successfully",
-Data:    serviceJobResponses,
+return c.Status(fiber.StatusOK).JSON(responses.PaginatedResponse{
+Status:     "success",
+Message:    "Service jobs retrieved successfully",
+Data:       serviceJobResponses,
+Pagination: listPagination(q, total),
 })
 }
-
 // UpdateServiceJob updates a service job
 func (h *ServiceHandler) UpdateServiceJob(c *fiber.Ctx) error {
 id, err := strconv.ParseUint(c.Params("id"), 10, 32)
@@ -485,13 +456,13 @@ Error:   err.Error(),
 })
 }
 
-serviceJob, err := h.usecase.ServiceJob.UpdateServiceJob(c.Context(), uint(id), req)
+if err := validator.Validate(req); err != nil {
+return err
+}
+
+serviceJob, err := h.usecase.ServiceJob.UpdateServiceJob(c.UserContext(), uint(id), req)
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to update service job",
-Error:   err.Error(),
-})
+return err
 }
 
 return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -512,13 +483,9 @@ Error:   err.Error(),
 })
 }
 
-err = h.usecase.ServiceJob.DeleteServiceJob(c.Context(), uint(id))
+err = h.usecase.ServiceJob.DeleteServiceJob(c.UserContext(), uint(id))
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to delete service job",
-Error:   err.Error(),
-})
+return err
 }
 
 return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -527,7 +494,7 @@ Message: "Service job deleted successfully",
 })
 }
 
-// GetServiceJobsByCustomer retrieves service jobs by customer ID
+// GetServiceJobsByCustomer retrieves the service jobs of a customer, it takes the list query of ListServiceJobs
 func (h *ServiceHandler) GetServiceJobsByCustomer(c *fiber.Ctx) error {
 customerID, err := strconv.ParseUint(c.Params("customer_id"), 10, 32)
 if err != nil {
@@ -538,28 +505,14 @@ Error:   err.Error(),
 })
 }
 
-serviceJobs, err := h.usecase.ServiceJob.GetServiceJobsByCustomer(c.Context(), uint(customerID))
+q, err := parseListQuery(c)
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to retrieve service jobs",
-Error:   err.Error(),
-})
+return err
 }
 
-var serviceJobResponses []interface{}
-for _, serviceJob := range serviceJobs {
-serviceJobResponses = append(serviceJobResponses, responses.ToServiceJobResponse(serviceJob))
-}
-
-return c.Status(fiber.StatusOK).JSON(responses.Response{
-Status:  "success",
-Message: "Service jobs retrieved successfully",
-Data:    serviceJobResponses,
-})
+return h.listServiceJobs(c, q.Where("customer_id", customerID))
 }
-
-// GetServiceJobsByStatus retrieves service jobs by status
+// GetServiceJobsByStatus retrieves the service jobs of a status, it takes the list query of ListServiceJobs
 func (h *ServiceHandler) GetServiceJobsByStatus(c *fiber.Ctx) error {
 status := c.Query("status")
 if status == "" {
@@ -569,27 +522,13 @@ Message: "Status is required",
 })
 }
 
-serviceJobs, err := h.usecase.ServiceJob.GetServiceJobsByStatus(c.Context(), models.ServiceStatusEnum(status))
+q, err := parseListQuery(c)
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to retrieve service jobs",
-Error:   err.Error(),
-})
-}
-
-var serviceJobResponses []interface{}
-for _, serviceJob := range serviceJobs {
-serviceJobResponses = append(serviceJobResponses, responses.ToServiceJobResponse(serviceJob))
+return err
 }
 
-return c.Status(fiber.StatusOK).JSON(responses.Response{
-Status:  "success",
-Message: "Service jobs retrieved successfully",
-Data:    serviceJobResponses,
-})
+return h.listServiceJobs(c, q.Where("status", status))
 }
-
 // ============= Service Detail Handlers =============
 
 // CreateServiceDetail creates a new service detail
@@ -603,19 +542,28 @@ Error:   err.Error(),
 })
 }
 
-serviceDetail, err := h.usecase.ServiceDetail.CreateServiceDetail(c.Context(), req)
+if err := validator.Validate(req); err != nil {
+return err
+}
+
+created, err := h.usecase.ServiceDetail.CreateServiceDetail(c.UserContext(), req)
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to create service detail",
-Error:   err.Error(),
+return err
+}
+
+// A part short in stock is special-ordered and the job waits for it
+if created.SpecialOrder != nil {
+return c.Status(fiber.StatusAccepted).JSON(responses.Response{
+Status:  "success",
+Message: "Part not in stock, special order requested",
+Data:    created.SpecialOrder,
 })
 }
 
 return c.Status(fiber.StatusCreated).JSON(responses.Response{
 Status:  "success",
 Message: "Service detail created successfully",
-Data:    responses.ToServiceDetailResponse(serviceDetail),
+Data:    responses.ToServiceDetailResponse(created.ServiceDetail),
 })
 }
 
@@ -630,13 +578,9 @@ Error:   err.Error(),
 })
 }
 
-serviceDetails, err := h.usecase.ServiceDetail.GetServiceDetailsByServiceJob(c.Context(), uint(serviceJobID))
+serviceDetails, err := h.usecase.ServiceDetail.GetServiceDetailsByServiceJob(c.UserContext(), uint(serviceJobID))
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to retrieve service details",
-Error:   err.Error(),
-})
+return err
 }
 
 var serviceDetailResponses []interface{}
@@ -671,13 +615,13 @@ Error:   err.Error(),
 })
 }
 
-serviceDetail, err := h.usecase.ServiceDetail.UpdateServiceDetail(c.Context(), uint(id), req)
+if err := validator.Validate(req); err != nil {
+return err
+}
+
+serviceDetail, err := h.usecase.ServiceDetail.UpdateServiceDetail(c.UserContext(), uint(id), req)
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to update service detail",
-Error:   err.Error(),
-})
+return err
 }
 
 return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -698,13 +642,9 @@ Error:   err.Error(),
 })
 }
 
-err = h.usecase.ServiceDetail.DeleteServiceDetail(c.Context(), uint(id))
+err = h.usecase.ServiceDetail.DeleteServiceDetail(c.UserContext(), uint(id))
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to delete service detail",
-Error:   err.Error(),
-})
+return err
 }
 
 return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -726,13 +666,9 @@ Error:   err.Error(),
 })
 }
 
-histories, err := h.usecase.ServiceJobHistory.GetServiceJobHistoriesByServiceJob(c.Context(), uint(serviceJobID))
+histories, err := h.usecase.ServiceJobHistory.GetServiceJobHistoriesByServiceJob(c.UserContext(), uint(serviceJobID))
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to retrieve service job histories",
-Error:   err.Error(),
-})
+return err
 }
 
 var historyResponses []interface{}
@@ -757,13 +693,9 @@ Message: "Service code is required",
 })
 }
 
-serviceJob, err := h.usecase.ServiceJob.GetServiceJobByServiceCode(c.Context(), serviceCode)
+serviceJob, err := h.usecase.ServiceJob.GetServiceJobByServiceCode(c.UserContext(), serviceCode)
 if err != nil {
-return c.Status(fiber.StatusNotFound).JSON(responses.Response{
-Status:  "error",
-Message: "Service job not found",
-Error:   err.Error(),
-})
+return err
 }
 
 return c.Status(fiber.StatusOK).JSON(responses.Response{
@@ -785,9 +717,9 @@ Error:   err.Error(),
 }
 
 var req struct {
-Status string  `json:"status" validate:"required"`
-UserID uint    `json:"user_id" validate:"required"`
-Notes  *string `json:"notes,omitempty"`
+Status models.ServiceStatusEnum `json:"status" validate:"required,enum"`
+UserID uint                     `json:"user_id" validate:"required"`
+Notes  *string                  `json:"notes,omitempty"`
 }
 if err := c.BodyParser(&req); err != nil {
 return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
@@ -797,13 +729,13 @@ Error:   err.Error(),
 })
 }
 
-err = h.usecase.ServiceJob.UpdateServiceJobStatus(c.Context(), uint(id), models.ServiceStatusEnum(req.Status), req.UserID, req.Notes)
+if err := validator.Validate(req); err != nil {
+return err
+}
+
+err = h.usecase.ServiceJob.UpdateServiceJobStatus(c.UserContext(), uint(id), req.Status, req.UserID, req.Notes)
 if err != nil {
-return c.Status(fiber.StatusInternalServerError).JSON(responses.Response{
-Status:  "error",
-Message: "Failed to update service job status",
-Error:   err.Error(),
-})
+return err
 }
 
 return c.Status(fiber.StatusOK).JSON(responses.Response{
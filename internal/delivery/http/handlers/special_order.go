@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SpecialOrderHandler handles the parts ordered for service jobs
+type SpecialOrderHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewSpecialOrderHandler creates a new special order handler
+func NewSpecialOrderHandler(usecase *usecase.UsecaseManager) *SpecialOrderHandler {
+	return &SpecialOrderHandler{usecase: usecase}
+}
+
+// RequestParts orders parts that are not in stock for a service job, the job then waits for them
+func (h *SpecialOrderHandler) RequestParts(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.RequestPartsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	orders, err := h.usecase.SpecialOrder.RequestParts(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.Response{
+		Status:  "success",
+		Message: "Parts requested successfully",
+		Data:    orders,
+	})
+}
+
+// GetJobSpecialOrders returns the special orders of a service job
+func (h *SpecialOrderHandler) GetJobSpecialOrders(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	serviceJobID := uint(id)
+	orders, err := h.usecase.SpecialOrder.ListSpecialOrders(c.UserContext(), interfaces.SpecialOrderQuery{ServiceJobID: &serviceJobID})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Special orders retrieved successfully",
+		Data:    orders,
+	})
+}
+
+// ListSpecialOrders lists the special orders by status, outlet and service job
+func (h *SpecialOrderHandler) ListSpecialOrders(c *fiber.Ctx) error {
+	var q interfaces.SpecialOrderQuery
+	if err := c.QueryParser(&q); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(q); err != nil {
+		return err
+	}
+
+	orders, err := h.usecase.SpecialOrder.ListSpecialOrders(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Special orders retrieved successfully",
+		Data:    orders,
+	})
+}
+
+// CancelSpecialOrder cancels a requested or reserved special order
+func (h *SpecialOrderHandler) CancelSpecialOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid special order ID",
+			Error:   err.Error(),
+		})
+	}
+
+	order, err := h.usecase.SpecialOrder.CancelSpecialOrder(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Special order cancelled successfully",
+		Data:    order,
+	})
+}
+
+// GenerateDraftOrders rolls the requested parts into draft purchase orders
+func (h *SpecialOrderHandler) GenerateDraftOrders(c *fiber.Ctx) error {
+	var req interfaces.SpecialOrderDraftRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+				Status:  "error",
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+		}
+	}
+
+	drafts, err := h.usecase.SpecialOrder.GenerateDraftOrders(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.Response{
+		Status:  "success",
+		Message: "Draft purchase orders generated successfully",
+		Data:    drafts,
+	})
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/validator"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TechnicianHandler handles the technician profiles, their workload and the assignment of jobs to them
+type TechnicianHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewTechnicianHandler creates a new technician handler
+func NewTechnicianHandler(usecase *usecase.UsecaseManager) *TechnicianHandler {
+	return &TechnicianHandler{usecase: usecase}
+}
+
+// ListTechnicians lists the technician profiles, optionally of an outlet and by active flag
+func (h *TechnicianHandler) ListTechnicians(c *fiber.Ctx) error {
+	var req interfaces.TechnicianQuery
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	technicians, err := h.usecase.Technician.ListTechnicians(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Technicians retrieved successfully",
+		Data:    technicians,
+	})
+}
+
+// GetTechnician returns the profile of a technician with skills and shifts
+func (h *TechnicianHandler) GetTechnician(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid user ID",
+			Error:   err.Error(),
+		})
+	}
+
+	technician, err := h.usecase.Technician.GetTechnician(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Technician retrieved successfully",
+		Data:    technician,
+	})
+}
+
+// SaveTechnician makes a user a technician or replaces the profile's skills and shifts
+func (h *TechnicianHandler) SaveTechnician(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid user ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.SaveTechnicianRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	technician, err := h.usecase.Technician.SaveTechnician(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Technician saved successfully",
+		Data:    technician,
+	})
+}
+
+// GetWorkload returns the open jobs and estimated hours of the technicians of an outlet
+func (h *TechnicianHandler) GetWorkload(c *fiber.Ctx) error {
+	var req interfaces.TechnicianWorkloadQuery
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	workload, err := h.usecase.Technician.GetWorkload(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Workload retrieved successfully",
+		Data:    workload,
+	})
+}
+
+// SuggestTechnicians ranks the technicians for the requested services of a service job
+func (h *TechnicianHandler) SuggestTechnicians(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	suggestion, err := h.usecase.Technician.SuggestTechnicians(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Technician suggestions retrieved successfully",
+		Data:    suggestion,
+	})
+}
+
+// AssignTechnician assigns a service job to the technician asked for, or to the suggested one
+func (h *TechnicianHandler) AssignTechnician(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid service job ID",
+			Error:   err.Error(),
+		})
+	}
+
+	var req interfaces.AssignTechnicianRequest
+	// The body is optional, without it the suggested technician is assigned
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+				Status:  "error",
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+		}
+	}
+
+	serviceJob, err := h.usecase.Technician.AssignTechnician(c.UserContext(), uint(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Technician assigned successfully",
+		Data:    serviceJob,
+	})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"boilerplate/internal/delivery/http/responses"
+	"boilerplate/internal/usecase"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/exception"
+	"boilerplate/pkg/validator"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrackingHandler handles the public job tracking, the API and the page customers open
+type TrackingHandler struct {
+	usecase *usecase.UsecaseManager
+}
+
+// NewTrackingHandler creates a new tracking handler
+func NewTrackingHandler(usecase *usecase.UsecaseManager) *TrackingHandler {
+	return &TrackingHandler{usecase: usecase}
+}
+
+// TrackByToken returns the tracking view of the job with the tracking token
+func (h *TrackingHandler) TrackByToken(c *fiber.Ctx) error {
+	tracking, err := h.usecase.Tracking.TrackByToken(c.UserContext(), c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Service job retrieved successfully",
+		Data:    tracking,
+	})
+}
+
+// TrackByServiceCode returns the tracking view of the job with the service code and phone number digits
+func (h *TrackingHandler) TrackByServiceCode(c *fiber.Ctx) error {
+	var req interfaces.TrackServiceJobRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Response{
+			Status:  "error",
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return err
+	}
+
+	tracking, err := h.usecase.Tracking.TrackByServiceCode(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.Response{
+		Status:  "success",
+		Message: "Service job retrieved successfully",
+		Data:    tracking,
+	})
+}
+
+// TrackingPageByToken renders the tracking page of the job with the tracking token
+func (h *TrackingHandler) TrackingPageByToken(c *fiber.Ctx) error {
+	tracking, err := h.usecase.Tracking.TrackByToken(c.UserContext(), c.Params("token"))
+	return h.renderTracking(c, interfaces.TrackServiceJobRequest{}, tracking, err)
+}
+
+// TrackingPage renders the lookup form, and the job once a service code and phone number digits are entered
+func (h *TrackingHandler) TrackingPage(c *fiber.Ctx) error {
+	var req interfaces.TrackServiceJobRequest
+	if err := c.QueryParser(&req); err != nil || (req.ServiceCode == "" && req.Phone == "") {
+		return h.renderTracking(c, req, nil, nil)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return h.renderTracking(c, req, nil, err)
+	}
+
+	tracking, err := h.usecase.Tracking.TrackByServiceCode(c.UserContext(), req)
+	return h.renderTracking(c, req, tracking, err)
+}
+
+// renderTracking renders views/track.html; domain and validation errors are shown on the page in Indonesian,
+// anything else is left to the error handler
+func (h *TrackingHandler) renderTracking(c *fiber.Ctx, req interfaces.TrackServiceJobRequest, tracking *interfaces.ServiceJobTracking, err error) error {
+	status := fiber.StatusOK
+	errorMessage := ""
+	if err != nil {
+		domainErr, ok := exception.AsDomainError(err)
+		if !ok {
+			return err
+		}
+		status = domainErr.StatusCode()
+		errorMessage = domainErr.MessageInd
+		if errors.Is(err, validator.ErrValidationFailed) {
+			errorMessage = fmt.Sprintf("Masukkan kode servis dan %d digit terakhir nomor telepon Anda.", interfaces.TrackingPhoneDigits)
+		}
+	}
+
+	return c.Status(status).Render("track", fiber.Map{
+		"Title":       "Lacak Servis",
+		"ServiceCode": req.ServiceCode,
+		"Phone":       req.Phone,
+		"Digits":      interfaces.TrackingPhoneDigits,
+		"Error":       errorMessage,
+		"Tracking":    tracking,
+	})
+}
@@ -21,6 +21,21 @@ type PaginatedResponse struct {
 	Pagination Pagination  `json:"pagination"`
 }
 
+// CursorPaginatedResponse represents a cursor paginated API response
+type CursorPaginatedResponse struct {
+	Status     string           `json:"status"`
+	Message    string           `json:"message"`
+	Data       interface{}      `json:"data"`
+	Pagination CursorPagination `json:"pagination"`
+}
+
+// CursorPagination contains cursor pagination metadata
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
 // Pagination contains pagination metadata
 type Pagination struct {
 	Page    int   `json:"page"`
@@ -48,6 +63,7 @@ type OutletResponse struct {
 	City        string            `json:"city"`
 	Address     *string           `json:"address"`
 	PhoneNumber *string           `json:"phone_number"`
+	BayCount    int               `json:"bay_count"`
 	Status      models.StatusUmum `json:"status"`
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
@@ -75,6 +91,7 @@ type CustomerResponse struct {
 	CustomerID  uint              `json:"customer_id"`
 	Name        string            `json:"name"`
 	PhoneNumber string            `json:"phone_number"`
+	Email       *string           `json:"email"`
 	Address     *string           `json:"address"`
 	Status      models.StatusUmum `json:"status"`
 	Vehicles    []CustomerVehicleResponse `json:"vehicles,omitempty"`
@@ -109,6 +126,8 @@ type ProductResponse struct {
 	CostPrice          float64                   `json:"cost_price"`
 	SellingPrice       float64                   `json:"selling_price"`
 	Stock              int                       `json:"stock"`
+	MinStock           int                       `json:"min_stock"`
+	ReorderQuantity    int                       `json:"reorder_quantity"`
 	SKU                *string                   `json:"sku"`
 	Barcode            *string                   `json:"barcode"`
 	HasSerialNumber    bool                      `json:"has_serial_number"`
@@ -196,6 +215,7 @@ func ToOutletResponse(outlet *models.Outlet) *OutletResponse {
 		City:        outlet.City,
 		Address:     outlet.Address,
 		PhoneNumber: outlet.PhoneNumber,
+		BayCount:    outlet.BayCount,
 		Status:      outlet.Status,
 		CreatedAt:   outlet.CreatedAt,
 		UpdatedAt:   outlet.UpdatedAt,
@@ -233,6 +253,7 @@ func ToCustomerResponse(customer *models.Customer) *CustomerResponse {
 		CustomerID:  customer.CustomerID,
 		Name:        customer.Name,
 		PhoneNumber: customer.PhoneNumber,
+		Email:       customer.Email,
 		Address:     customer.Address,
 		Status:      customer.Status,
 		CreatedAt:   customer.CreatedAt,
@@ -288,6 +309,8 @@ ProductImage:       product.ProductImage,
 CostPrice:          product.CostPrice,
 SellingPrice:       product.SellingPrice,
 Stock:              product.Stock,
+MinStock:           product.MinStock,
+ReorderQuantity:    product.ReorderQuantity,
 SKU:                product.SKU,
 Barcode:            product.Barcode,
 HasSerialNumber:    product.HasSerialNumber,
@@ -428,6 +451,7 @@ ServiceCode       string                    `json:"service_code"`
 Name              string                    `json:"name"`
 ServiceCategoryID uint                      `json:"service_category_id"`
 Fee               float64                   `json:"fee"`
+StandardMinutes   int                       `json:"standard_minutes"`
 Status            models.StatusUmum         `json:"status"`
 ServiceCategory   *ServiceCategoryResponse  `json:"service_category,omitempty"`
 CreatedAt         time.Time                 `json:"created_at"`
@@ -449,6 +473,7 @@ type ServiceJobResponse struct {
 ServiceJobID               uint                      `json:"service_job_id"`
 ServiceCode                string                    `json:"service_code"`
 QueueNumber                int                       `json:"queue_number"`
+Priority                   int                       `json:"priority"`
 CustomerID                 uint                      `json:"customer_id"`
 VehicleID                  uint                      `json:"vehicle_id"`
 TechnicianID               *uint                     `json:"technician_id"`
@@ -462,6 +487,8 @@ PickedUpDate               *time.Time                `json:"picked_up_date"`
 ComplainDate               *time.Time                `json:"complain_date"`
 WarrantyExpiresAt          *time.Time                `json:"warranty_expires_at"`
 NextServiceReminderDate    *time.Time                `json:"next_service_reminder_date"`
+EstimatedCompletionAt      *time.Time                `json:"estimated_completion_at"`
+TrackingToken              string                    `json:"tracking_token"`
 DownPayment                float64                   `json:"down_payment"`
 GrandTotal                 float64                   `json:"grand_total"`
 TechnicianCommission       float64                   `json:"technician_commission"`
@@ -497,6 +524,7 @@ HistoryID    uint               `json:"history_id"`
 ServiceJobID uint               `json:"service_job_id"`
 UserID       uint               `json:"user_id"`
 Notes        *string            `json:"notes"`
+Status       *models.ServiceStatusEnum `json:"status"`
 ChangedAt    time.Time          `json:"changed_at"`
 ServiceJob   *ServiceJobResponse `json:"service_job,omitempty"`
 User         *UserResponse      `json:"user,omitempty"`
@@ -530,6 +558,7 @@ SerialNumberID  *uint                       `json:"serial_number_id"`
 Quantity        int                         `json:"quantity"`
 UnitPrice       float64                     `json:"unit_price"`
 TotalPrice      float64                     `json:"total_price"`
+UnitCost        float64                     `json:"unit_cost"`
 Transaction     *TransactionResponse        `json:"transaction,omitempty"`
 Product         *ProductResponse            `json:"product,omitempty"`
 SerialNumber    *ProductSerialNumberResponse `json:"serial_number,omitempty"`
@@ -568,6 +597,7 @@ ServiceCode:       service.ServiceCode,
 Name:              service.Name,
 ServiceCategoryID: service.ServiceCategoryID,
 Fee:               service.Fee,
+StandardMinutes:   service.StandardMinutes,
 Status:            service.Status,
 CreatedAt:         service.CreatedAt,
 UpdatedAt:         service.UpdatedAt,
@@ -609,6 +639,7 @@ response := &ServiceJobResponse{
 ServiceJobID:               serviceJob.ServiceJobID,
 ServiceCode:                serviceJob.ServiceCode,
 QueueNumber:                serviceJob.QueueNumber,
+Priority:                   serviceJob.Priority,
 CustomerID:                 serviceJob.CustomerID,
 VehicleID:                  serviceJob.VehicleID,
 TechnicianID:               serviceJob.TechnicianID,
@@ -622,6 +653,8 @@ PickedUpDate:               serviceJob.PickedUpDate,
 ComplainDate:               serviceJob.ComplainDate,
 WarrantyExpiresAt:          serviceJob.WarrantyExpiresAt,
 NextServiceReminderDate:    serviceJob.NextServiceReminderDate,
+EstimatedCompletionAt:      serviceJob.EstimatedCompletionAt,
+TrackingToken:              serviceJob.TrackingToken,
 DownPayment:                serviceJob.DownPayment,
 GrandTotal:                 serviceJob.GrandTotal,
 TechnicianCommission:       serviceJob.TechnicianCommission,
@@ -688,6 +721,7 @@ HistoryID:    history.HistoryID,
 ServiceJobID: history.ServiceJobID,
 UserID:       history.UserID,
 Notes:        history.Notes,
+Status:       history.Status,
 ChangedAt:    history.ChangedAt,
 }
 
@@ -744,6 +778,7 @@ SerialNumberID:  detail.SerialNumberID,
 Quantity:        detail.Quantity,
 UnitPrice:       detail.UnitPrice,
 TotalPrice:      detail.TotalPrice,
+UnitCost:        detail.UnitCost,
 CreatedAt:       detail.CreatedAt,
 UpdatedAt:       detail.UpdatedAt,
 }
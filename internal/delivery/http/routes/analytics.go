@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupAnalyticsRoutes sets up routes for sales and workshop analytics endpoints
+func SetupAnalyticsRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	analyticsHandler := handlers.NewAnalyticsHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Analytics routes (read-only)
+	analytics := api.Group("/analytics")
+	analytics.Get("/sales", analyticsHandler.GetSalesSummary)
+	analytics.Get("/top-products", analyticsHandler.GetTopProducts)
+	analytics.Get("/top-services", analyticsHandler.GetTopServices)
+	analytics.Get("/technicians", analyticsHandler.GetTechnicianPerformance)
+	analytics.Get("/labour", analyticsHandler.GetLabourEfficiency)
+	analytics.Get("/stock", analyticsHandler.GetStockAnalysis)
+}
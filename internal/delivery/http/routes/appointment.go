@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupAppointmentRoutes sets up appointment booking with the opening hours and holidays it is booked within
+func SetupAppointmentRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	appointmentHandler := handlers.NewAppointmentHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Appointment routes
+	appointments := api.Group("/appointments")
+	appointments.Post("/", appointmentHandler.CreateAppointment)
+	appointments.Get("/", appointmentHandler.ListAppointments)
+	appointments.Get("/calendar", appointmentHandler.GetCalendar)
+	appointments.Get("/:id", appointmentHandler.GetAppointment)
+	appointments.Put("/:id", appointmentHandler.UpdateAppointment)
+	appointments.Post("/:id/cancel", appointmentHandler.CancelAppointment)
+	appointments.Post("/:id/no-show", appointmentHandler.MarkNoShow)
+	appointments.Post("/:id/check-in", appointmentHandler.CheckIn)
+
+	// Booking schedule of an outlet
+	outlets := api.Group("/outlets")
+	outlets.Get("/:id/appointment-slots", appointmentHandler.GetAvailableSlots)
+	outlets.Get("/:id/opening-hours", appointmentHandler.GetOpeningHours)
+	outlets.Put("/:id/opening-hours", appointmentHandler.SetOpeningHours)
+
+	// Holiday routes
+	holidays := api.Group("/outlet-holidays")
+	holidays.Get("/", appointmentHandler.ListHolidays)
+	holidays.Post("/", appointmentHandler.CreateHoliday)
+	holidays.Delete("/:id", appointmentHandler.DeleteHoliday)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/middleware"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupAuditRoutes sets up routes for audit log endpoints
+func SetupAuditRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	auditHandler := handlers.NewAuditHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Audit log routes (read-only, administrators only)
+	auditLogs := api.Group("/audit-logs", middleware.RequireAdmin())
+	auditLogs.Get("/", auditHandler.ListAuditLogs)
+	auditLogs.Get("/:id", auditHandler.GetAuditLog)
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/middleware"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupBoardRoutes sets up the live workshop boards; the queue display routes are public and need no token
+func SetupBoardRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	boardHandler := handlers.NewBoardHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Staff board of an outlet, signed in users only
+	board := api.Group("/outlets/:id/board", middleware.RequireActor())
+	board.Get("/", boardHandler.GetBoard)
+	board.Get("/stream", boardHandler.StreamBoard)
+
+	// Public queue display, limited per IP
+	display := api.Group("/queue-display", middleware.QueueDisplayLimiterMiddleware())
+	display.Get("/:id", boardHandler.GetQueueDisplay)
+	display.Get("/:id/stream", boardHandler.StreamQueueDisplay)
+
+	// Queue screen, rendered from views/queue.html
+	app.Get("/queue-display/:id", boardHandler.QueueDisplayPage)
+}
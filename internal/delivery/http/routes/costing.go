@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupCostingRoutes sets up routes for stock receipts, cost methods and inventory valuation
+func SetupCostingRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	costingHandler := handlers.NewCostingHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Stock receipt routes
+	api.Post("/stock-receipts", costingHandler.ReceiveStock)
+
+	// Product costing routes
+	products := api.Group("/products")
+	products.Put("/:id/cost-method", costingHandler.ChangeCostMethod)
+	products.Get("/:id/cost-layers", costingHandler.GetCostLayers)
+
+	// Inventory valuation routes
+	api.Get("/inventory/valuation", costingHandler.GetInventoryValuation)
+}
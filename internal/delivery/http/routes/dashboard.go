@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupDashboardRoutes sets up routes for the owner dashboard
+func SetupDashboardRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	dashboardHandler := handlers.NewDashboardHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Dashboard routes (read-only)
+	api.Get("/dashboard", dashboardHandler.GetDashboard)
+}
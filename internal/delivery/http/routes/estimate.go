@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupEstimateRoutes sets up the repair estimates; the approval link routes need no token, they check the
+// link's signature instead
+func SetupEstimateRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	estimateHandler := handlers.NewEstimateHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Estimate revisions of a service job
+	serviceJobs := api.Group("/service-jobs")
+	serviceJobs.Post("/:id/estimates", estimateHandler.CreateEstimate)
+	serviceJobs.Get("/:id/estimates", estimateHandler.ListEstimates)
+
+	// Estimate routes
+	estimates := api.Group("/estimates")
+	estimates.Get("/:id", estimateHandler.GetEstimate)
+	estimates.Post("/:id/decision", estimateHandler.DecideEstimate)
+
+	// Approval link API, ?expires=...&signature=...
+	approval := api.Group("/estimate-approval")
+	approval.Get("/:id", estimateHandler.GetPublicEstimate)
+	approval.Post("/:id", estimateHandler.DecidePublicEstimate)
+
+	// Approval page the link sent to the customer opens, rendered from views/estimate.html
+	page := app.Group("/estimates")
+	page.Get("/:id", estimateHandler.EstimatePage)
+	page.Post("/:id", estimateHandler.EstimatePageDecision)
+}
@@ -28,4 +28,5 @@ func SetupFoundationRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
 	outlets.Post("/", foundationHandler.CreateOutlet)
 	outlets.Get("/", foundationHandler.ListOutlets)
 	outlets.Get("/:id", foundationHandler.GetOutlet)
+	outlets.Put("/:id", foundationHandler.UpdateOutlet)
 }
\ No newline at end of file
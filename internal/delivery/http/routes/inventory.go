@@ -28,6 +28,8 @@ func SetupInventoryRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
 	products.Put("/:id", inventoryHandler.UpdateProduct)
 	products.Delete("/:id", inventoryHandler.DeleteProduct)
 	products.Post("/:id/stock", inventoryHandler.UpdateProductStock)
+	products.Post("/:id/image", inventoryHandler.UploadProductImage)
+	products.Delete("/:id/image", inventoryHandler.DeleteProductImage)
 
 	// Category routes
 	categories := api.Group("/categories")
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupLabourRoutes sets up the clocking of technicians on service jobs
+func SetupLabourRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	labourHandler := handlers.NewLabourHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Labour routes, by service job ID
+	serviceJobs := api.Group("/service-jobs")
+	serviceJobs.Get("/:id/labour", labourHandler.GetJobLabour)
+	serviceJobs.Post("/:id/labour/start", labourHandler.StartWork)
+	serviceJobs.Post("/:id/labour/pause", labourHandler.PauseWork)
+	serviceJobs.Post("/:id/labour/finish", labourHandler.FinishWork)
+}
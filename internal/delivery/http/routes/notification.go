@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupNotificationRoutes sets up routes for customer notification endpoints
+func SetupNotificationRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	notificationHandler := handlers.NewNotificationHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Notification routes
+	notifications := api.Group("/notifications")
+	notifications.Get("/", notificationHandler.ListNotifications)
+	notifications.Get("/:id", notificationHandler.GetNotification)
+	notifications.Post("/:id/retry", notificationHandler.RetryNotification)
+
+	// Channel opt-outs of a customer
+	customers := api.Group("/customers")
+	customers.Get("/:id/notification-preferences", notificationHandler.GetPreferences)
+	customers.Put("/:id/notification-preferences", notificationHandler.UpdatePreferences)
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupReplenishmentRoutes sets up routes for reorder points, replenishment and purchase orders
+func SetupReplenishmentRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	replenishmentHandler := handlers.NewReplenishmentHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Outlet reorder point routes
+	products := api.Group("/products")
+	products.Get("/:id/stock-levels", replenishmentHandler.GetStockLevels)
+	products.Put("/:id/stock-levels/:outlet_id", replenishmentHandler.SetStockLevel)
+	products.Delete("/:id/stock-levels/:outlet_id", replenishmentHandler.DeleteStockLevel)
+
+	// Replenishment routes
+	replenishment := api.Group("/replenishment")
+	replenishment.Get("/suggestions", replenishmentHandler.GetSuggestions)
+	replenishment.Post("/draft-orders", replenishmentHandler.GenerateDraftOrders)
+
+	// Purchase order routes
+	purchaseOrders := api.Group("/purchase-orders")
+	purchaseOrders.Get("/", replenishmentHandler.ListPurchaseOrders)
+	purchaseOrders.Get("/:id", replenishmentHandler.GetPurchaseOrder)
+	purchaseOrders.Put("/:id", replenishmentHandler.UpdatePurchaseOrder)
+	purchaseOrders.Post("/:id/confirm", replenishmentHandler.ConfirmPurchaseOrder)
+	purchaseOrders.Post("/:id/receive", replenishmentHandler.ReceivePurchaseOrder)
+	purchaseOrders.Delete("/:id", replenishmentHandler.DeletePurchaseOrder)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupReportRoutes sets up routes for report endpoints
+func SetupReportRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	reportHandler := handlers.NewReportHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Report routes
+	reports := api.Group("/reports")
+	reports.Post("/", reportHandler.CreateReport)
+	reports.Get("/", reportHandler.ListReports)
+	reports.Get("/:id", reportHandler.GetReport)
+	reports.Get("/:id/download", reportHandler.DownloadReport)
+	reports.Post("/:id/retry", reportHandler.RetryReport)
+}
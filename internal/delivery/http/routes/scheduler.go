@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/middleware"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupSchedulerRoutes sets up the administrator routes of the scheduled jobs
+func SetupSchedulerRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	schedulerHandler := handlers.NewSchedulerHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Scheduled job routes (administrators only)
+	jobs := api.Group("/jobs", middleware.RequireAdmin())
+	jobs.Get("/", schedulerHandler.ListJobs)
+	jobs.Get("/runs", schedulerHandler.ListJobRuns)
+	jobs.Get("/runs/:id", schedulerHandler.GetJobRun)
+	jobs.Post("/:name/run", schedulerHandler.TriggerJob)
+}
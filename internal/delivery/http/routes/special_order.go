@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupSpecialOrderRoutes sets up the parts ordered for service jobs waiting for them
+func SetupSpecialOrderRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	specialOrderHandler := handlers.NewSpecialOrderHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Special order routes, by service job ID
+	serviceJobs := api.Group("/service-jobs")
+	serviceJobs.Get("/:id/special-orders", specialOrderHandler.GetJobSpecialOrders)
+	serviceJobs.Post("/:id/special-orders", specialOrderHandler.RequestParts)
+
+	// Special order routes
+	specialOrders := api.Group("/special-orders")
+	specialOrders.Get("/", specialOrderHandler.ListSpecialOrders)
+	specialOrders.Post("/draft-orders", specialOrderHandler.GenerateDraftOrders)
+	specialOrders.Post("/:id/cancel", specialOrderHandler.CancelSpecialOrder)
+}
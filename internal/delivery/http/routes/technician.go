@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupTechnicianRoutes sets up the technician profiles, their workload and the assignment of service jobs
+func SetupTechnicianRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	technicianHandler := handlers.NewTechnicianHandler(usecase)
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Technician routes, by user ID
+	technicians := api.Group("/technicians")
+	technicians.Get("/", technicianHandler.ListTechnicians)
+	technicians.Get("/workload", technicianHandler.GetWorkload)
+	technicians.Get("/:id", technicianHandler.GetTechnician)
+	technicians.Put("/:id", technicianHandler.SaveTechnician)
+
+	// Assignment of service jobs
+	serviceJobs := api.Group("/service-jobs")
+	serviceJobs.Get("/:id/technician-suggestions", technicianHandler.SuggestTechnicians)
+	serviceJobs.Post("/:id/assign", technicianHandler.AssignTechnician)
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"boilerplate/internal/delivery/http/handlers"
+	"boilerplate/internal/middleware"
+	"boilerplate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupTrackingRoutes sets up the public job tracking, these routes need no token
+func SetupTrackingRoutes(app *fiber.App, usecase *usecase.UsecaseManager) {
+	// Create handler
+	trackingHandler := handlers.NewTrackingHandler(usecase)
+
+	// One limiter for the API and the page, failed lookups count against both
+	limiter := middleware.TrackingLimiterMiddleware()
+
+	// API group
+	api := app.Group("/api/v1")
+
+	// Tracking API routes
+	track := api.Group("/track", limiter)
+	track.Get("/", trackingHandler.TrackByServiceCode)
+	track.Get("/:token", trackingHandler.TrackByToken)
+
+	// Tracking page, rendered from views/track.html
+	page := app.Group("/track", limiter)
+	page.Get("/", trackingHandler.TrackingPage)
+	page.Get("/:token", trackingHandler.TrackingPageByToken)
+}
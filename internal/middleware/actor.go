@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"boilerplate/pkg/exception"
+	"boilerplate/pkg/utils"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ActorMiddleware resolves the authenticated user from the bearer token and
+// stores it in the request user context, so that usecases and repositories can
+// stamp CreatedBy and audit entries without relying on the request body.
+// Requests without a valid token continue anonymously.
+func ActorMiddleware() {
+
+	app := initData.App
+	conf := initData.Conf
+
+	app.Use(func(c *fiber.Ctx) error {
+		authorizationHeader := c.Get("Authorization")
+		if !strings.HasPrefix(authorizationHeader, "Bearer ") {
+			return c.Next()
+		}
+
+		accessToken := strings.TrimPrefix(authorizationHeader, "Bearer ")
+		claims, err := utils.CheckAccessToken(conf, accessToken)
+		if err != nil {
+			return c.Next()
+		}
+
+		actor := utils.ActorFromClaims(claims)
+		if actor == nil {
+			return c.Next()
+		}
+
+		c.Locals("actor", actor)
+		c.SetUserContext(utils.WithActor(c.UserContext(), actor))
+
+		return c.Next()
+	})
+}
+
+// errLoginRequired is returned to requests without a valid token
+var errLoginRequired = exception.Unauthorized("LOGIN_REQUIRED", "this endpoint requires a valid token", "endpoint ini memerlukan token yang valid")
+
+// RequireActor only lets requests through whose actor was resolved by ActorMiddleware
+func RequireActor() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := utils.ActorFromContext(c.UserContext()); !ok {
+			return errLoginRequired
+		}
+		return c.Next()
+	}
+}
+
+// errAdminRequired is returned to requests without an administrator token
+var errAdminRequired = exception.Forbidden("ADMIN_REQUIRED", "this endpoint requires an administrator", "endpoint ini hanya untuk administrator")
+
+// RequireAdmin only lets requests through whose actor, resolved by ActorMiddleware, is an administrator
+func RequireAdmin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		actor, ok := utils.ActorFromContext(c.UserContext())
+		if !ok || !actor.IsAdmin {
+			return errAdminRequired
+		}
+		return c.Next()
+	}
+}
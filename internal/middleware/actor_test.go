@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"boilerplate/config"
+	"boilerplate/pkg/utils"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// statusAs answers a request through the guard as the actor, nil for an anonymous request
+func statusAs(t *testing.T, guard fiber.Handler, actor *utils.Actor) int {
+	t.Helper()
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	InitMiddlewareConfig(app, nil, &config.Config{}, log)
+	app.Use(func(c *fiber.Ctx) error {
+		if actor != nil {
+			c.SetUserContext(utils.WithActor(c.UserContext(), actor))
+		}
+		return c.Next()
+	})
+	app.Get("/", guard, func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestRequireActor(t *testing.T) {
+	tests := []struct {
+		name   string
+		guard  fiber.Handler
+		actor  *utils.Actor
+		status int
+	}{
+		{"anonymous", RequireActor(), nil, fiber.StatusUnauthorized},
+		{"signed in", RequireActor(), &utils.Actor{UserID: 3}, fiber.StatusOK},
+		{"anonymous admin route", RequireAdmin(), nil, fiber.StatusForbidden},
+		{"staff on admin route", RequireAdmin(), &utils.Actor{UserID: 3}, fiber.StatusForbidden},
+		{"administrator", RequireAdmin(), &utils.Actor{UserID: 1, IsAdmin: true}, fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		if status := statusAs(t, tt.guard, tt.actor); status != tt.status {
+			t.Fatalf("%s: got %d, want %d", tt.name, status, tt.status)
+		}
+	}
+}
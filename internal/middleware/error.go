@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"boilerplate/pkg/exception"
+	"boilerplate/pkg/query"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// errRecordNotFound answers lookups that reach the handler as a bare gorm.ErrRecordNotFound
+var errRecordNotFound = exception.NotFound("RECORD_NOT_FOUND", "record not found", "data tidak ditemukan")
+
+// errQueryInvalid answers list queries the shared query parser or the field allowlists reject
+var errQueryInvalid = exception.Validation("QUERY_INVALID", "invalid list query", "query daftar tidak valid")
+
+// ErrorHandler is the central Fiber error handler. Domain errors are answered with their
+// status, error code and bilingual message; anything unexpected is logged and answered
+// with a generic 500 that does not expose its cause.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	init := exception.InitException(c, initData.Conf, initData.Log)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = errRecordNotFound.Wrap(err)
+	}
+	if errors.Is(err, query.ErrInvalidQuery) {
+		reason := strings.TrimPrefix(err.Error(), query.ErrInvalidQuery.Error()+": ")
+		err = errQueryInvalid.Wrap(err).WithDetails(map[string]interface{}{"reason": reason})
+	}
+	if domainErr, ok := exception.AsDomainError(err); ok {
+		return exception.CreateResponse_DomainError(init, domainErr)
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return exception.CreateResponse_Log(init, fiberErr.Code, fiberErr.Message, fiberErr.Message, nil)
+	}
+
+	initData.Log.Error(err)
+	return exception.CreateResponse_Log(init, fiber.StatusInternalServerError, "Internal server error", "Terjadi kesalahan pada server", nil)
+}
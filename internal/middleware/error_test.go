@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"boilerplate/config"
+	"boilerplate/pkg/exception"
+	"boilerplate/pkg/query"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// errorTestResponse is the part of the response the error tests look at
+type errorTestResponse struct {
+	Data   map[string]interface{}       `json:"data"`
+	Status exception.StatusResponseData `json:"status"`
+}
+
+// respondWithError answers a request whose handler fails with err and returns the response and what was logged
+func respondWithError(t *testing.T, err error) (int, errorTestResponse, string) {
+	t.Helper()
+	var logged bytes.Buffer
+	log := logrus.New()
+	log.SetOutput(&logged)
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	InitMiddlewareConfig(app, nil, &config.Config{}, log)
+	app.Get("/", func(c *fiber.Ctx) error { return err })
+
+	resp, testErr := app.Test(httptest.NewRequest("GET", "/", nil))
+	if testErr != nil {
+		t.Fatalf("request: %v", testErr)
+	}
+	defer resp.Body.Close()
+	var body errorTestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp.StatusCode, body, logged.String()
+}
+
+func TestErrorHandlerHidesUnexpectedErrors(t *testing.T) {
+	status, body, logged := respondWithError(t, errors.New(`pq: duplicate key value violates unique constraint "users_email_key"`))
+	if status != fiber.StatusInternalServerError || body.Status.Message != "Internal server error" || body.Status.MessageInd == "" {
+		t.Fatalf("got %d %+v, want a generic bilingual 500", status, body.Status)
+	}
+	raw, _ := json.Marshal(body)
+	if strings.Contains(string(raw), "users_email_key") {
+		t.Fatalf("the cause reached the client: %s", raw)
+	}
+	if !strings.Contains(logged, "users_email_key") {
+		t.Fatalf("the cause was not logged: %q", logged)
+	}
+}
+
+func TestErrorHandlerInvalidQuery(t *testing.T) {
+	err := fmt.Errorf("%w: sorting by %q is not allowed", query.ErrInvalidQuery, "password")
+	status, body, _ := respondWithError(t, err)
+	if status != fiber.StatusUnprocessableEntity || body.Status.ErrorCode != "QUERY_INVALID" {
+		t.Fatalf("got %d %+v, want 422 QUERY_INVALID", status, body.Status)
+	}
+	if body.Data["reason"] != `sorting by "password" is not allowed` {
+		t.Fatalf("got data %v, want the reason", body.Data)
+	}
+}
+
+func TestErrorHandlerDomainError(t *testing.T) {
+	notFound := exception.NotFound("CUSTOMER_NOT_FOUND", "customer not found", "pelanggan tidak ditemukan")
+	status, body, _ := respondWithError(t, notFound.Wrap(errors.New("record not found")))
+	if status != fiber.StatusNotFound || body.Status.ErrorCode != "CUSTOMER_NOT_FOUND" || body.Status.MessageInd != "pelanggan tidak ditemukan" {
+		t.Fatalf("got %d %+v, want 404 CUSTOMER_NOT_FOUND", status, body.Status)
+	}
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
@@ -56,3 +58,42 @@ func DefaultLimitterMiddleware() {
 // 		LimiterMiddleware:      limiter.FixedWindow{},
 // 	}))
 // }
+
+// TrackingLimiterMiddleware limits the failed lookups of the public tracking per IP, so service codes and
+// phone digits cannot be tried out
+func TrackingLimiterMiddleware() fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        10,
+		Expiration: 1 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(&fiber.Map{
+				"status":  "fail",
+				"message": "Too many failed lookups, please try again 1 minute later",
+			})
+		},
+		SkipSuccessfulRequests: true,
+		LimiterMiddleware:      limiter.FixedWindow{},
+	})
+}
+
+// QueueDisplayLimiterMiddleware limits the requests to the public queue display per IP, a screen opens its stream
+// once and reconnects only when it drops, so more than that is someone flooding the streams
+func QueueDisplayLimiterMiddleware() fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        20,
+		Expiration: 1 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(&fiber.Map{
+				"status":  "fail",
+				"message": "Too many queue display requests, please try again 1 minute later",
+			})
+		},
+		LimiterMiddleware: limiter.FixedWindow{},
+	})
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Appointments table (Booking Servis), a vehicle booked into an outlet for a time slot. Checking the appointment in
+// creates its service job.
+type Appointment struct {
+	AppointmentID   uint              `gorm:"primaryKey;autoIncrement" json:"appointment_id"`
+	OutletID        uint              `gorm:"not null;index" json:"outlet_id"`
+	CustomerID      uint              `gorm:"not null;index" json:"customer_id"`
+	VehicleID       uint              `gorm:"not null;index" json:"vehicle_id"`
+	TechnicianID    *uint             `gorm:"index" json:"technician_id"`
+	StartAt         time.Time         `gorm:"not null;index" json:"start_at"`
+	EndAt           time.Time         `gorm:"not null" json:"end_at"`
+	DurationMinutes int               `gorm:"not null" json:"duration_minutes"`
+	Status          AppointmentStatus `gorm:"size:20;not null;default:'Dijadwalkan';index" json:"status"`
+	Notes           *string           `gorm:"type:text" json:"notes"`
+	// ServiceJobID is the job the appointment was checked in as
+	ServiceJobID *uint      `gorm:"index" json:"service_job_id"`
+	ArrivedAt    *time.Time `json:"arrived_at"`
+	NoShowAt     *time.Time `json:"no_show_at"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CancelReason *string    `gorm:"type:text" json:"cancel_reason"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	CreatedBy    *uint      `json:"created_by"`
+
+	// Relationships
+	Services []AppointmentService `gorm:"foreignKey:AppointmentID" json:"services,omitempty"`
+}
+
+// AppointmentServices table, the services requested for an appointment with their name and standard time when booked
+type AppointmentService struct {
+	AppointmentServiceID uint   `gorm:"primaryKey;autoIncrement" json:"appointment_service_id"`
+	AppointmentID        uint   `gorm:"not null;index" json:"appointment_id"`
+	ServiceID            uint   `gorm:"not null" json:"service_id"`
+	Name                 string `gorm:"size:255;not null" json:"name"`
+	StandardMinutes      int    `gorm:"not null;default:0" json:"standard_minutes"`
+}
+
+// OutletOpeningHours table, the hours an outlet takes appointments per day of the week (0 is Sunday).
+// A day without a row is closed; an outlet without any row uses the Appointment config hours.
+type OutletOpeningHour struct {
+	OutletID uint   `gorm:"primaryKey;autoIncrement:false" json:"outlet_id"`
+	Weekday  int    `gorm:"primaryKey;autoIncrement:false" json:"weekday"`
+	OpensAt  string `gorm:"size:5;not null" json:"opens_at"`  // "08:00"
+	ClosesAt string `gorm:"size:5;not null" json:"closes_at"` // "17:00"
+}
+
+// OutletHolidays table, days an outlet takes no appointments; without outlet the day is closed for all outlets
+type OutletHoliday struct {
+	HolidayID uint      `gorm:"primaryKey;autoIncrement" json:"holiday_id"`
+	OutletID  *uint     `gorm:"index" json:"outlet_id"`
+	Date      time.Time `gorm:"type:date;not null;index" json:"date"`
+	Name      string    `gorm:"size:255;not null" json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy *uint     `json:"created_by"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit logs table (append-only, written by the GORM audit callbacks)
+type AuditLog struct {
+	AuditLogID uint        `gorm:"primaryKey;autoIncrement" json:"audit_log_id"`
+	Entity     string      `gorm:"size:100;not null;index" json:"entity"`
+	EntityID   string      `gorm:"size:100;index" json:"entity_id"`
+	Action     AuditAction `gorm:"size:20;not null" json:"action"`
+	UserID     *uint       `gorm:"index" json:"user_id"`
+	OutletID   *uint       `gorm:"index" json:"outlet_id"`
+	Before     *string     `gorm:"type:text" json:"before"`
+	After      *string     `gorm:"type:text" json:"after"`
+	CreatedAt  time.Time   `gorm:"index" json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Service job event types, what changed on the live board of an outlet
+const (
+	ServiceJobEventCreated       = "job.created"
+	ServiceJobEventStatusChanged = "job.status_changed"
+	ServiceJobEventAssigned      = "job.assigned"
+	ServiceJobEventUpdated       = "job.updated"
+	ServiceJobEventRemoved       = "job.removed"
+	// ServiceJobEventPartsArrived tells the front desk that the special orders of a waiting job were received
+	ServiceJobEventPartsArrived = "job.parts_arrived"
+)
+
+// ServiceJobEvents table, the changes to service jobs streamed to the live boards. The ID orders the events of all
+// outlets and is the id clients resume from; the payload is the job as the board shows it after the change.
+type ServiceJobEvent struct {
+	EventID      uint      `gorm:"primaryKey;autoIncrement" json:"event_id"`
+	OutletID     uint      `gorm:"not null;index" json:"outlet_id"`
+	ServiceJobID uint      `gorm:"not null" json:"service_job_id"`
+	Type         string    `gorm:"size:30;not null" json:"type"`
+	Payload      string    `gorm:"type:text;not null" json:"payload"`
+	CreatedAt    time.Time `gorm:"index" json:"created_at"`
+}
@@ -11,6 +11,7 @@ type Customer struct {
 	CustomerID  uint           `gorm:"primaryKey;autoIncrement" json:"customer_id"`
 	Name        string         `gorm:"size:255;not null" json:"name"`
 	PhoneNumber string         `gorm:"size:20;unique;not null" json:"phone_number"`
+	Email       *string        `gorm:"size:255" json:"email"`
 	Address     *string        `gorm:"type:text" json:"address"`
 	Status      StatusUmum     `gorm:"not null;default:'Aktif'" json:"status"`
 	CreatedAt   time.Time      `json:"created_at"`
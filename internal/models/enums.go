@@ -24,6 +24,25 @@ const (
 	SNStatusRusak    SNStatus = "Rusak"
 )
 
+type StockMovementType string
+
+const (
+	StockMovementPurchase   StockMovementType = "purchase"
+	StockMovementSale       StockMovementType = "sale"
+	StockMovementService    StockMovementType = "service"
+	StockMovementAdjustment StockMovementType = "adjustment"
+	// StockMovementRevaluation changes the value of the stock on hand, not its quantity
+	StockMovementRevaluation StockMovementType = "revaluation"
+)
+
+// CostMethod is how a product's stock is valued and what a sold unit costs
+type CostMethod string
+
+const (
+	CostMethodAverage CostMethod = "average"
+	CostMethodFIFO    CostMethod = "fifo"
+)
+
 type ServiceStatusEnum string
 
 const (
@@ -32,6 +51,8 @@ const (
 	ServiceStatusSelesai   ServiceStatusEnum = "Selesai"
 	ServiceStatusDiambil   ServiceStatusEnum = "Diambil"
 	ServiceStatusKomplain  ServiceStatusEnum = "Komplain"
+	// ServiceStatusMenungguSparepart is a job stalled until special-ordered parts arrive
+	ServiceStatusMenungguSparepart ServiceStatusEnum = "Menunggu Sparepart"
 )
 
 type TransactionStatus string
@@ -47,6 +68,7 @@ type PurchaseStatus string
 const (
 	PurchaseStatusSelesai PurchaseStatus = "Selesai"
 	PurchaseStatusPending PurchaseStatus = "Pending"
+	PurchaseStatusDraft   PurchaseStatus = "Draft" // generated by replenishment, not yet confirmed by a buyer
 )
 
 type PaymentTypeEnum string
@@ -74,17 +96,137 @@ const (
 type ReportTypeEnum string
 
 const (
-	ReportTypePenjualan ReportTypeEnum = "Penjualan"
-	ReportTypeKeuangan  ReportTypeEnum = "Keuangan"
-	ReportTypeInventory ReportTypeEnum = "Inventory"
+	ReportTypePenjualan  ReportTypeEnum = "Penjualan"
+	ReportTypeKeuangan   ReportTypeEnum = "Keuangan"
+	ReportTypeInventory  ReportTypeEnum = "Inventory"
+	ReportTypeTeknisi    ReportTypeEnum = "Teknisi"
+	ReportTypePerputaran ReportTypeEnum = "Perputaran"
+	ReportTypeEfisiensi  ReportTypeEnum = "Efisiensi"
 )
 
 type ReportStatus string
 
 const (
-	ReportStatusPending ReportStatus = "Pending"
-	ReportStatusSelesai ReportStatus = "Selesai"
-	ReportStatusGagal   ReportStatus = "Gagal"
+	ReportStatusPending  ReportStatus = "Pending"
+	ReportStatusDiproses ReportStatus = "Diproses"
+	ReportStatusSelesai  ReportStatus = "Selesai"
+	ReportStatusGagal    ReportStatus = "Gagal"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatCSV  ReportFormat = "csv"
+	ReportFormatXLSX ReportFormat = "xlsx"
+	ReportFormatPDF  ReportFormat = "pdf"
+)
+
+// JobTrigger is what started a scheduled job run
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule"
+	JobTriggerManual   JobTrigger = "manual"
+)
+
+type JobRunStatus string
+
+const (
+	JobRunStatusDiproses JobRunStatus = "Diproses"
+	JobRunStatusSelesai  JobRunStatus = "Selesai"
+	JobRunStatusGagal    JobRunStatus = "Gagal"
+)
+
+// NotificationChannel is the way a notification reaches the customer
+type NotificationChannel string
+
+const (
+	NotificationChannelWhatsApp NotificationChannel = "whatsapp"
+	NotificationChannelSMS      NotificationChannel = "sms"
+	NotificationChannelEmail    NotificationChannel = "email"
+)
+
+// NotificationEvent is what a notification tells the customer about, it selects the template
+type NotificationEvent string
+
+const (
+	NotificationEventJobStatus       NotificationEvent = "job_status"
+	NotificationEventJobCompleted    NotificationEvent = "job_completed"
+	NotificationEventServiceReminder NotificationEvent = "service_reminder"
+	NotificationEventReceivableDue   NotificationEvent = "receivable_due"
+	NotificationEventPickupReminder  NotificationEvent = "pickup_reminder"
+	NotificationEventEstimateReady   NotificationEvent = "estimate_ready"
+	NotificationEventPartsArrived    NotificationEvent = "parts_arrived"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationStatusPending  NotificationStatus = "Pending"
+	NotificationStatusDiproses NotificationStatus = "Diproses"
+	NotificationStatusTerkirim NotificationStatus = "Terkirim"
+	NotificationStatusGagal    NotificationStatus = "Gagal"
+)
+
+// EstimateStatus is the state of a service estimate revision: Menunggu waits for the customer, Digantikan was
+// replaced by a newer revision before it was decided
+type EstimateStatus string
+
+const (
+	EstimateStatusMenunggu   EstimateStatus = "Menunggu"
+	EstimateStatusDisetujui  EstimateStatus = "Disetujui"
+	EstimateStatusDitolak    EstimateStatus = "Ditolak"
+	EstimateStatusDigantikan EstimateStatus = "Digantikan"
+)
+
+// EstimateChannel is how the customer's decision on an estimate was given
+type EstimateChannel string
+
+const (
+	EstimateChannelLink    EstimateChannel = "link"
+	EstimateChannelCounter EstimateChannel = "counter"
+	EstimateChannelPhone   EstimateChannel = "phone"
+)
+
+// AppointmentStatus is the state of an appointment: Datang once it was checked in as a service job, Tidak Datang
+// when the customer did not show up
+type AppointmentStatus string
+
+const (
+	AppointmentStatusDijadwalkan AppointmentStatus = "Dijadwalkan"
+	AppointmentStatusDatang      AppointmentStatus = "Datang"
+	AppointmentStatusTidakDatang AppointmentStatus = "Tidak Datang"
+	AppointmentStatusDibatalkan  AppointmentStatus = "Dibatalkan"
+)
+
+// LabourEntryKind tells a period a technician worked on a job from a period the job was paused
+type LabourEntryKind string
+
+const (
+	LabourEntryKerja LabourEntryKind = "Kerja"
+	LabourEntryJeda  LabourEntryKind = "Jeda"
+)
+
+// LabourPauseReason is why the work on a job was paused
+type LabourPauseReason string
+
+const (
+	LabourPauseMenungguSparepart   LabourPauseReason = "Menunggu Sparepart"
+	LabourPauseMenungguPersetujuan LabourPauseReason = "Menunggu Persetujuan"
+	LabourPauseIstirahat           LabourPauseReason = "Istirahat"
+	LabourPauseLainnya             LabourPauseReason = "Lainnya"
+)
+
+// SpecialOrderStatus is the state of a part ordered for a service job: Diminta waits to be put on a purchase order,
+// Dipesan is on one, Dicadangkan was received and is held for the job until a part line uses it (Terpakai)
+type SpecialOrderStatus string
+
+const (
+	SpecialOrderDiminta     SpecialOrderStatus = "Diminta"
+	SpecialOrderDipesan     SpecialOrderStatus = "Dipesan"
+	SpecialOrderDicadangkan SpecialOrderStatus = "Dicadangkan"
+	SpecialOrderTerpakai    SpecialOrderStatus = "Terpakai"
+	SpecialOrderDibatalkan  SpecialOrderStatus = "Dibatalkan"
 )
 
 type PromotionType string
@@ -92,4 +234,231 @@ type PromotionType string
 const (
 	PromotionTypePercentage PromotionType = "percentage"
 	PromotionTypeFixed      PromotionType = "fixed"
-)
\ No newline at end of file
+)
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// IsValid reports whether the value is one of the declared constants; the request validator's enum rule relies on it
+func (s StatusUmum) IsValid() bool {
+	switch s {
+	case StatusAktif, StatusTidakAktif:
+		return true
+	}
+	return false
+}
+
+func (s ProductUsageStatus) IsValid() bool {
+	switch s {
+	case ProductUsageJual, ProductUsagePakaiSendiri, ProductUsageRusak:
+		return true
+	}
+	return false
+}
+
+func (s SNStatus) IsValid() bool {
+	switch s {
+	case SNStatusTersedia, SNStatusTerpakai, SNStatusRusak:
+		return true
+	}
+	return false
+}
+
+func (t StockMovementType) IsValid() bool {
+	switch t {
+	case StockMovementPurchase, StockMovementSale, StockMovementService, StockMovementAdjustment, StockMovementRevaluation:
+		return true
+	}
+	return false
+}
+
+func (m CostMethod) IsValid() bool {
+	switch m {
+	case CostMethodAverage, CostMethodFIFO:
+		return true
+	}
+	return false
+}
+
+func (s ServiceStatusEnum) IsValid() bool {
+	switch s {
+	case ServiceStatusAntri, ServiceStatusDikerjakan, ServiceStatusSelesai, ServiceStatusDiambil, ServiceStatusKomplain,
+		ServiceStatusMenungguSparepart:
+		return true
+	}
+	return false
+}
+
+func (s TransactionStatus) IsValid() bool {
+	switch s {
+	case TransactionStatusPending, TransactionStatusSukses, TransactionStatusGagal:
+		return true
+	}
+	return false
+}
+
+func (s PurchaseStatus) IsValid() bool {
+	switch s {
+	case PurchaseStatusSelesai, PurchaseStatusPending, PurchaseStatusDraft:
+		return true
+	}
+	return false
+}
+
+func (t PaymentTypeEnum) IsValid() bool {
+	switch t {
+	case PaymentTypeTunai, PaymentTypeTransfer, PaymentTypeCicilan:
+		return true
+	}
+	return false
+}
+
+func (s APARStatus) IsValid() bool {
+	switch s {
+	case APARStatusBelumLunas, APARStatusLunas:
+		return true
+	}
+	return false
+}
+
+func (t CashFlowType) IsValid() bool {
+	switch t {
+	case CashFlowTypePemasukan, CashFlowTypePengeluaran:
+		return true
+	}
+	return false
+}
+
+func (t ReportTypeEnum) IsValid() bool {
+	switch t {
+	case ReportTypePenjualan, ReportTypeKeuangan, ReportTypeInventory, ReportTypeTeknisi, ReportTypePerputaran, ReportTypeEfisiensi:
+		return true
+	}
+	return false
+}
+
+func (s ReportStatus) IsValid() bool {
+	switch s {
+	case ReportStatusPending, ReportStatusDiproses, ReportStatusSelesai, ReportStatusGagal:
+		return true
+	}
+	return false
+}
+
+func (f ReportFormat) IsValid() bool {
+	switch f {
+	case ReportFormatCSV, ReportFormatXLSX, ReportFormatPDF:
+		return true
+	}
+	return false
+}
+
+func (t JobTrigger) IsValid() bool {
+	switch t {
+	case JobTriggerSchedule, JobTriggerManual:
+		return true
+	}
+	return false
+}
+
+func (s JobRunStatus) IsValid() bool {
+	switch s {
+	case JobRunStatusDiproses, JobRunStatusSelesai, JobRunStatusGagal:
+		return true
+	}
+	return false
+}
+
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case NotificationChannelWhatsApp, NotificationChannelSMS, NotificationChannelEmail:
+		return true
+	}
+	return false
+}
+
+func (e NotificationEvent) IsValid() bool {
+	switch e {
+	case NotificationEventJobStatus, NotificationEventJobCompleted, NotificationEventServiceReminder,
+		NotificationEventReceivableDue, NotificationEventPickupReminder, NotificationEventEstimateReady,
+		NotificationEventPartsArrived:
+		return true
+	}
+	return false
+}
+
+func (s NotificationStatus) IsValid() bool {
+	switch s {
+	case NotificationStatusPending, NotificationStatusDiproses, NotificationStatusTerkirim, NotificationStatusGagal:
+		return true
+	}
+	return false
+}
+
+func (t PromotionType) IsValid() bool {
+	switch t {
+	case PromotionTypePercentage, PromotionTypeFixed:
+		return true
+	}
+	return false
+}
+
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionCreate, AuditActionUpdate, AuditActionDelete:
+		return true
+	}
+	return false
+}
+
+func (s EstimateStatus) IsValid() bool {
+	switch s {
+	case EstimateStatusMenunggu, EstimateStatusDisetujui, EstimateStatusDitolak, EstimateStatusDigantikan:
+		return true
+	}
+	return false
+}
+
+func (c EstimateChannel) IsValid() bool {
+	switch c {
+	case EstimateChannelLink, EstimateChannelCounter, EstimateChannelPhone:
+		return true
+	}
+	return false
+}
+
+func (s AppointmentStatus) IsValid() bool {
+	switch s {
+	case AppointmentStatusDijadwalkan, AppointmentStatusDatang, AppointmentStatusTidakDatang, AppointmentStatusDibatalkan:
+		return true
+	}
+	return false
+}
+
+func (k LabourEntryKind) IsValid() bool {
+	switch k {
+	case LabourEntryKerja, LabourEntryJeda:
+		return true
+	}
+	return false
+}
+
+func (r LabourPauseReason) IsValid() bool {
+	switch r {
+	case LabourPauseMenungguSparepart, LabourPauseMenungguPersetujuan, LabourPauseIstirahat, LabourPauseLainnya:
+		return true
+	}
+	return false
+}
+
+func (s SpecialOrderStatus) IsValid() bool {
+	switch s {
+	case SpecialOrderDiminta, SpecialOrderDipesan, SpecialOrderDicadangkan, SpecialOrderTerpakai, SpecialOrderDibatalkan:
+		return true
+	}
+	return false
+}
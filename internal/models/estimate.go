@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// ServiceEstimates table (Estimasi Biaya), a revision of the quotation of a service job. Every revision has its own
+// version, the customer approves or rejects it through its signed link or staff record the decision.
+type ServiceEstimate struct {
+	EstimateID   uint           `gorm:"primaryKey;autoIncrement" json:"estimate_id"`
+	ServiceJobID uint           `gorm:"not null;uniqueIndex:idx_service_estimates_job_version" json:"service_job_id"`
+	Version      int            `gorm:"not null;uniqueIndex:idx_service_estimates_job_version" json:"version"`
+	Status       EstimateStatus `gorm:"size:20;not null;default:'Menunggu';index" json:"status"`
+	PartsTotal   float64        `gorm:"type:decimal(15,2);not null;default:0" json:"parts_total"`
+	LabourTotal  float64        `gorm:"type:decimal(15,2);not null;default:0" json:"labour_total"`
+	Total        float64        `gorm:"type:decimal(15,2);not null;default:0" json:"total"`
+	// ExtraAmount is what the revision adds to the estimate approved before it, the whole total for the first one
+	ExtraAmount float64 `gorm:"type:decimal(15,2);not null;default:0" json:"extra_amount"`
+	// RequiresCall is set when the extra amount exceeds Estimate.CallThreshold, the link cannot approve it then
+	RequiresCall  bool      `gorm:"not null;default:false" json:"requires_call"`
+	Notes         *string   `gorm:"type:text" json:"notes"`
+	LinkExpiresAt time.Time `gorm:"not null" json:"link_expires_at"`
+	// The decision: when, through which channel, the name the customer signed with or staff spoke to,
+	// the user who recorded it at the counter or by phone and the IP a link decision came from
+	DecidedAt       *time.Time       `json:"decided_at"`
+	DecisionChannel *EstimateChannel `gorm:"size:20" json:"decision_channel"`
+	DecidedByName   *string          `gorm:"size:255" json:"decided_by_name"`
+	DecidedByUserID *uint            `json:"decided_by_user_id"`
+	DecisionNote    *string          `gorm:"type:text" json:"decision_note"`
+	DecisionIP      *string          `gorm:"size:45" json:"decision_ip"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	CreatedBy       *uint            `json:"created_by"`
+
+	// ApprovalURL is the signed link of a revision waiting for the customer, it is not stored
+	ApprovalURL string `gorm:"-" json:"approval_url,omitempty"`
+
+	// Relationships
+	Items []ServiceEstimateItem `gorm:"foreignKey:EstimateID" json:"items,omitempty"`
+}
+
+// ServiceEstimateItems table, the parts (product) and labour (service) lines of an estimate revision
+type ServiceEstimateItem struct {
+	EstimateItemID uint    `gorm:"primaryKey;autoIncrement" json:"estimate_item_id"`
+	EstimateID     uint    `gorm:"not null;index" json:"estimate_id"`
+	ItemType       string  `gorm:"size:20;not null" json:"item_type"`
+	ItemID         uint    `gorm:"not null" json:"item_id"`
+	Description    string  `gorm:"size:255;not null" json:"description"`
+	Quantity       int     `gorm:"not null" json:"quantity"`
+	PricePerItem   float64 `gorm:"type:decimal(15,2);not null" json:"price_per_item"`
+	Subtotal       float64 `gorm:"type:decimal(15,2);not null" json:"subtotal"`
+}
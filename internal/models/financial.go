@@ -47,6 +47,7 @@ type AccountsPayable struct {
 	AmountPaid      float64        `gorm:"type:decimal(15,2);not null;default:0" json:"amount_paid"`
 	DueDate         time.Time      `gorm:"type:date;not null" json:"due_date"`
 	Status          APARStatus     `gorm:"not null;default:'Belum Lunas'" json:"status"`
+	OverdueAt       *time.Time     `json:"overdue_at"` // flagged by the overdue job once the due date passed unpaid
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -83,6 +84,7 @@ type AccountsReceivable struct {
 	AmountPaid    float64        `gorm:"type:decimal(15,2);not null;default:0" json:"amount_paid"`
 	DueDate       time.Time      `gorm:"type:date;not null" json:"due_date"`
 	Status        APARStatus     `gorm:"not null;default:'Belum Lunas'" json:"status"`
+	OverdueAt     *time.Time     `json:"overdue_at"` // flagged by the overdue job once the due date passed unpaid
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -20,6 +20,7 @@ type User struct {
 
 	// Relationships
 	Outlet *Outlet `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+	Roles  []Role  `gorm:"many2many:user_has_roles;joinForeignKey:UserID;joinReferences:RoleID" json:"roles,omitempty"`
 }
 
 // Outlets table
@@ -30,6 +31,8 @@ type Outlet struct {
 	City         string         `gorm:"size:100;not null" json:"city"`
 	Address      *string        `gorm:"type:text" json:"address"`
 	PhoneNumber  *string        `gorm:"size:20" json:"phone_number"`
+	// BayCount is how many vehicles the outlet works on at the same time, it limits the appointments per slot
+	BayCount     int            `gorm:"not null;default:1" json:"bay_count"`
 	Status       StatusUmum     `gorm:"not null;default:'Aktif'" json:"status"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
@@ -74,4 +77,13 @@ type RoleHasPermission struct {
 	// Relationships
 	Permission Permission `gorm:"foreignKey:PermissionID" json:"permission,omitempty"`
 	Role       Role       `gorm:"foreignKey:RoleID" json:"role,omitempty"`
-}
\ No newline at end of file
+}
+// UserHasRoles table (pivot table)
+type UserHasRole struct {
+	UserID uint `gorm:"primaryKey" json:"user_id"`
+	RoleID uint `gorm:"primaryKey" json:"role_id"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Role Role `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+}
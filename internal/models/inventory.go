@@ -12,9 +12,12 @@ type Product struct {
 	ProductName        string             `gorm:"size:255;not null" json:"product_name"`
 	ProductDescription *string            `gorm:"type:text" json:"product_description"`
 	ProductImage       *string            `gorm:"size:255" json:"product_image"`
-	CostPrice          float64            `gorm:"type:decimal(15,2);not null" json:"cost_price"`
+	CostPrice          float64            `gorm:"type:decimal(15,2);not null" json:"cost_price"` // moving average, kept by the stock ledger
+	CostMethod         CostMethod         `gorm:"size:10;not null;default:'average'" json:"cost_method"`
 	SellingPrice       float64            `gorm:"type:decimal(15,2);not null" json:"selling_price"`
 	Stock              int                `gorm:"not null;default:0" json:"stock"`
+	MinStock           int                `gorm:"not null;default:0" json:"min_stock"`        // reorder point, 0 when the product is not replenished
+	ReorderQuantity    int                `gorm:"not null;default:0" json:"reorder_quantity"` // smallest quantity ordered at once
 	SKU                *string            `gorm:"size:100;unique" json:"sku"`
 	Barcode            *string            `gorm:"size:100;unique" json:"barcode"`
 	HasSerialNumber    bool               `gorm:"not null;default:false" json:"has_serial_number"`
@@ -38,6 +41,21 @@ type Product struct {
 	SerialNumbers []ProductSerialNumber `gorm:"foreignKey:ProductID" json:"serial_numbers,omitempty"`
 }
 
+// ProductStockLevels table (reorder point of a product at one outlet, replaces the product's own)
+type ProductStockLevel struct {
+	StockLevelID    uint      `gorm:"primaryKey;autoIncrement" json:"stock_level_id"`
+	ProductID       uint      `gorm:"not null;uniqueIndex:idx_product_stock_levels_product_outlet" json:"product_id"`
+	OutletID        uint      `gorm:"not null;uniqueIndex:idx_product_stock_levels_product_outlet;index" json:"outlet_id"`
+	MinStock        int       `gorm:"not null;default:0" json:"min_stock"`
+	ReorderQuantity int       `gorm:"not null;default:0" json:"reorder_quantity"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Relationships
+	Product *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Outlet  *Outlet  `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+}
+
 // ProductSerialNumbers table
 type ProductSerialNumber struct {
 	SerialNumberID uint           `gorm:"primaryKey;autoIncrement" json:"serial_number_id"`
@@ -96,4 +114,41 @@ type UnitType struct {
 
 	// Relationships
 	Products []Product `gorm:"foreignKey:UnitTypeID" json:"products,omitempty"`
-}
\ No newline at end of file
+}
+// StockReferenceOpening is the reference type of the entry a product's ledger opens with, zero for a product without stock
+const StockReferenceOpening = "opening"
+
+// StockMovements table (stock ledger, every change of Product.Stock is recorded here)
+type StockMovement struct {
+	MovementID    uint              `gorm:"primaryKey;autoIncrement" json:"movement_id"`
+	ProductID     uint              `gorm:"not null;index" json:"product_id"`
+	OutletID      *uint             `gorm:"index" json:"outlet_id"`
+	MovementType  StockMovementType `gorm:"size:20;not null;index" json:"movement_type"`
+	Quantity      int               `gorm:"not null" json:"quantity"`
+	UnitCost      float64           `gorm:"type:decimal(15,2);not null;default:0" json:"unit_cost"` // product cost when the stock moved
+	ReferenceType *string           `gorm:"size:50" json:"reference_type"`
+	ReferenceID   *uint             `json:"reference_id"`
+	Notes         *string           `gorm:"type:text" json:"notes"`
+	CreatedAt     time.Time         `gorm:"index" json:"created_at"`
+	CreatedBy     *uint             `json:"created_by"`
+
+	// Relationships
+	Product *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Outlet  *Outlet  `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+}
+
+// CostLayers table (what is left of each inbound stock movement, consumed oldest first)
+type CostLayer struct {
+	LayerID    uint      `gorm:"primaryKey;autoIncrement" json:"layer_id"`
+	ProductID  uint      `gorm:"not null;index" json:"product_id"`
+	OutletID   *uint     `gorm:"index" json:"outlet_id"`
+	MovementID *uint     `gorm:"index" json:"movement_id"`
+	Quantity   int       `gorm:"not null" json:"quantity"`
+	Remaining  int       `gorm:"not null" json:"remaining"`
+	UnitCost   float64   `gorm:"type:decimal(15,2);not null" json:"unit_cost"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+
+	// Relationships
+	Product *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Outlet  *Outlet  `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// LabourEntries table, the clocked time of a service job. A Kerja entry is a period a technician worked on the job,
+// on one of its service lines when DetailID is set; it is open until the technician pauses or finishes. A Jeda entry
+// is a period the work was paused for PauseReason, open until work on the job or line starts again.
+type LabourEntry struct {
+	EntryID      uint               `gorm:"primaryKey;autoIncrement" json:"entry_id"`
+	ServiceJobID uint               `gorm:"not null;index" json:"service_job_id"`
+	DetailID     *uint              `gorm:"index" json:"detail_id"`
+	TechnicianID uint               `gorm:"not null;index;uniqueIndex:idx_labour_entries_open_work,where:ended_at IS NULL AND kind = 'Kerja'" json:"technician_id"`
+	Kind         LabourEntryKind    `gorm:"size:10;not null" json:"kind"`
+	PauseReason  *LabourPauseReason `gorm:"size:30" json:"pause_reason"`
+	StartedAt    time.Time          `gorm:"not null;index" json:"started_at"`
+	EndedAt      *time.Time         `json:"ended_at"`
+	// Finished marks the Kerja entry the technician finished the job or line with
+	Finished  bool      `gorm:"not null;default:false" json:"finished"`
+	Notes     *string   `gorm:"type:text" json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Technician *User `gorm:"foreignKey:TechnicianID;references:UserID" json:"technician,omitempty"`
+}
@@ -11,6 +11,7 @@ type (
 	RoleModel            = Role
 	PermissionModel      = Permission
 	RoleHasPermissionModel = RoleHasPermission
+	UserHasRoleModel       = UserHasRole
 
 	// Customer & Vehicle
 	CustomerModel        = Customer
@@ -22,6 +23,9 @@ type (
 	CategoryModel            = Category
 	SupplierModel            = Supplier
 	UnitTypeModel            = UnitType
+	StockMovementModel       = StockMovement
+	CostLayerModel           = CostLayer
+	ProductStockLevelModel   = ProductStockLevel
 
 	// Services
 	ServiceModel         = Service
@@ -51,6 +55,44 @@ type (
 	// Reporting & Promotions
 	ReportModel    = Report
 	PromotionModel = Promotion
+
+	// Scheduler
+	JobLockModel = JobLock
+	JobRunModel  = JobRun
+
+	// Notifications
+	NotificationModel       = Notification
+	NotificationOptOutModel = NotificationOptOut
+
+	// Estimates
+	ServiceEstimateModel     = ServiceEstimate
+	ServiceEstimateItemModel = ServiceEstimateItem
+
+	// Appointments
+	AppointmentModel        = Appointment
+	AppointmentServiceModel = AppointmentService
+	OutletOpeningHourModel  = OutletOpeningHour
+	OutletHolidayModel      = OutletHoliday
+
+	// Document numbering
+	DocumentSequenceModel = DocumentSequence
+
+	// Live board
+	ServiceJobEventModel = ServiceJobEvent
+
+	// Technicians
+	TechnicianProfileModel = TechnicianProfile
+	TechnicianSkillModel   = TechnicianSkill
+	TechnicianShiftModel   = TechnicianShift
+
+	// Labour time
+	LabourEntryModel = LabourEntry
+
+	// Special orders
+	SpecialOrderModel = SpecialOrder
+
+	// Audit
+	AuditLogModel = AuditLog
 )
 
 // GetAllModels returns a slice of all model types for migration purposes
@@ -62,6 +104,7 @@ func GetAllModels() []interface{} {
 		&Role{},
 		&Permission{},
 		&RoleHasPermission{},
+		&UserHasRole{},
 
 		// Customer & Vehicle
 		&Customer{},
@@ -73,6 +116,9 @@ func GetAllModels() []interface{} {
 		&Category{},
 		&Supplier{},
 		&UnitType{},
+		&StockMovement{},
+		&CostLayer{},
+		&ProductStockLevel{},
 
 		// Services
 		&Service{},
@@ -102,5 +148,43 @@ func GetAllModels() []interface{} {
 		// Reporting & Promotions
 		&Report{},
 		&Promotion{},
+
+		// Scheduler
+		&JobLock{},
+		&JobRun{},
+
+		// Notifications
+		&Notification{},
+		&NotificationOptOut{},
+
+		// Estimates
+		&ServiceEstimate{},
+		&ServiceEstimateItem{},
+
+		// Appointments
+		&Appointment{},
+		&AppointmentService{},
+		&OutletOpeningHour{},
+		&OutletHoliday{},
+
+		// Document numbering
+		&DocumentSequence{},
+
+		// Live board
+		&ServiceJobEvent{},
+
+		// Technicians
+		&TechnicianProfile{},
+		&TechnicianSkill{},
+		&TechnicianShift{},
+
+		// Labour time
+		&LabourEntry{},
+
+		// Special orders
+		&SpecialOrder{},
+
+		// Audit
+		&AuditLog{},
 	}
 }
\ No newline at end of file
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Notifications table (messages to customers, queued and delivered by the notification worker)
+type Notification struct {
+	NotificationID    uint                `gorm:"primaryKey;autoIncrement" json:"notification_id"`
+	CustomerID        uint                `gorm:"not null;index" json:"customer_id"`
+	Channel           NotificationChannel `gorm:"size:20;not null" json:"channel"`
+	Event             NotificationEvent   `gorm:"size:30;not null;index" json:"event"`
+	Recipient         string              `gorm:"size:255;not null" json:"recipient"`
+	Subject           string              `gorm:"size:255;not null;default:''" json:"subject"`
+	Body              string              `gorm:"type:text;not null" json:"body"`
+	Status            NotificationStatus  `gorm:"size:20;not null;default:'Pending';index" json:"status"`
+	Attempts          int                 `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt     *time.Time          `json:"next_attempt_at"`
+	SentAt            *time.Time          `json:"sent_at"`
+	ProviderMessageID *string             `gorm:"size:255" json:"provider_message_id"`
+	ErrorMessage      *string             `gorm:"type:text" json:"error_message"`
+	ServiceJobID      *uint               `gorm:"index" json:"service_job_id"`
+	ReceivableID      *uint               `gorm:"index" json:"receivable_id"`
+	DedupeKey         *string             `gorm:"size:255;uniqueIndex" json:"-"` // a reminder is queued once per key, e.g. "pickup_reminder:12:whatsapp"
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+	CreatedBy         *uint               `json:"created_by"`
+
+	// Relationships
+	Customer   *Customer   `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	ServiceJob *ServiceJob `gorm:"foreignKey:ServiceJobID" json:"service_job,omitempty"`
+}
+
+// NotificationOptOuts table (channels a customer does not want to be notified through)
+type NotificationOptOut struct {
+	CustomerID uint                `gorm:"primaryKey" json:"customer_id"`
+	Channel    NotificationChannel `gorm:"primaryKey;size:20" json:"channel"`
+	CreatedAt  time.Time           `json:"created_at"`
+	CreatedBy  *uint               `json:"created_by"`
+}
@@ -16,7 +16,12 @@ type Report struct {
 	OutletID         *uint          `gorm:"index" json:"outlet_id"`
 	UserID           uint           `gorm:"not null;index" json:"user_id"`
 	GeneratedFilePath *string       `gorm:"size:255" json:"generated_file_path"`
-	Status           ReportStatus   `gorm:"default:'Pending'" json:"status"`
+	Format           ReportFormat   `gorm:"size:10;not null;default:'csv'" json:"format"`
+	Status           ReportStatus   `gorm:"default:'Pending';index" json:"status"`
+	Attempts         int            `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt    *time.Time     `json:"next_attempt_at"`
+	ErrorMessage     *string        `gorm:"type:text" json:"error_message"`
+	CompletedAt      *time.Time     `json:"completed_at"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
 	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// JobLocks table (one row per scheduled job, held by the instance running it)
+type JobLock struct {
+	JobName         string     `gorm:"primaryKey;size:100" json:"job_name"`
+	LockedBy        string     `gorm:"size:255;not null" json:"locked_by"`
+	LockedUntil     time.Time  `gorm:"not null" json:"locked_until"`
+	LastScheduledAt *time.Time `json:"last_scheduled_at"` // schedule slot of the last scheduled run, each slot runs once
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// JobRuns table (run history of the scheduled jobs)
+type JobRun struct {
+	RunID        uint         `gorm:"primaryKey;autoIncrement" json:"run_id"`
+	JobName      string       `gorm:"size:100;not null;index" json:"job_name"`
+	Trigger      JobTrigger   `gorm:"column:trigger_type;size:20;not null" json:"trigger"`
+	Status       JobRunStatus `gorm:"size:20;not null;index" json:"status"`
+	Instance     string       `gorm:"size:255;not null" json:"instance"`
+	ScheduledAt  *time.Time   `json:"scheduled_at"`
+	StartedAt    time.Time    `gorm:"not null;index" json:"started_at"`
+	FinishedAt   *time.Time   `json:"finished_at"`
+	DurationMs   int64        `gorm:"not null;default:0" json:"duration_ms"`
+	Result       *string      `gorm:"type:text" json:"result"`
+	ErrorMessage *string      `gorm:"type:text" json:"error_message"`
+	TriggeredBy  *uint        `json:"triggered_by"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:TriggeredBy" json:"user,omitempty"`
+}
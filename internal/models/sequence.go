@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// DocumentSequences table, the last number handed out per document type and scope. The scope is the document's
+// pattern filled in up to the counter, e.g. "INV/1/2024/01/{SEQ:5}", so counters restart per outlet and period.
+type DocumentSequence struct {
+	Name      string    `gorm:"primaryKey;size:50" json:"name"`
+	Scope     string    `gorm:"primaryKey;size:255" json:"scope"`
+	LastValue int64     `gorm:"not null" json:"last_value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -13,6 +13,8 @@ type Service struct {
 	Name              string         `gorm:"size:255;not null" json:"name"`
 	ServiceCategoryID uint           `gorm:"not null;index" json:"service_category_id"`
 	Fee               float64        `gorm:"type:decimal(15,2);not null" json:"fee"`
+	// StandardMinutes is the time the service normally takes, appointments are booked for it
+	StandardMinutes   int            `gorm:"not null;default:0" json:"standard_minutes"`
 	Status            StatusUmum     `gorm:"not null;default:'Aktif'" json:"status"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
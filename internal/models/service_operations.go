@@ -6,11 +6,19 @@ import (
 	"gorm.io/gorm"
 )
 
+// Queue priorities of service jobs
+const (
+	ServiceJobPriorityWalkIn      = 0
+	ServiceJobPriorityAppointment = 1
+)
+
 // ServiceJobs table
 type ServiceJob struct {
 	ServiceJobID               uint              `gorm:"primaryKey;autoIncrement" json:"service_job_id"`
 	ServiceCode                string            `gorm:"size:50;unique;not null" json:"service_code"`
 	QueueNumber                int               `gorm:"not null" json:"queue_number"`
+	// Priority orders the queue before the queue number, jobs of appointments go first
+	Priority                   int               `gorm:"not null;default:0" json:"priority"`
 	CustomerID                 uint              `gorm:"not null;index" json:"customer_id"`
 	VehicleID                  uint              `gorm:"not null;index" json:"vehicle_id"`
 	TechnicianID               *uint             `gorm:"index" json:"technician_id"`
@@ -24,6 +32,10 @@ type ServiceJob struct {
 	ComplainDate               *time.Time        `json:"complain_date"`
 	WarrantyExpiresAt          *time.Time        `gorm:"type:date" json:"warranty_expires_at"`
 	NextServiceReminderDate    *time.Time        `gorm:"type:date" json:"next_service_reminder_date"`
+	// EstimatedCompletionAt is when the customer is told the vehicle will be ready
+	EstimatedCompletionAt      *time.Time        `json:"estimated_completion_at"`
+	// TrackingToken is the unguessable token of the public tracking page
+	TrackingToken              string            `gorm:"size:64;uniqueIndex" json:"tracking_token"`
 	DownPayment                float64           `gorm:"type:decimal(15,2);default:0" json:"down_payment"`
 	GrandTotal                 float64           `gorm:"type:decimal(15,2);default:0" json:"grand_total"`
 	TechnicianCommission       float64           `gorm:"type:decimal(15,2);default:0" json:"technician_commission"`
@@ -34,8 +46,8 @@ type ServiceJob struct {
 	CreatedBy                  *uint             `json:"created_by"`
 
 	// Relationships
-	Customer       *Customer       `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
-	Vehicle        *CustomerVehicle `gorm:"foreignKey:VehicleID" json:"vehicle,omitempty"`
+	Customer       *Customer       `gorm:"foreignKey:CustomerID;references:CustomerID" json:"customer,omitempty"`
+	Vehicle        *CustomerVehicle `gorm:"foreignKey:VehicleID;references:VehicleID" json:"vehicle,omitempty"`
 	Technician     *User           `gorm:"foreignKey:TechnicianID" json:"technician,omitempty"`
 	ReceivedByUser *User           `gorm:"foreignKey:ReceivedByUserID" json:"received_by_user,omitempty"`
 	Outlet         *Outlet         `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
@@ -65,6 +77,8 @@ type ServiceJobHistory struct {
 	ServiceJobID uint       `gorm:"not null;index" json:"service_job_id"`
 	UserID       uint       `gorm:"not null;index" json:"user_id"`
 	Notes        *string    `gorm:"type:text" json:"notes"`
+	// Status is the status the job moved to, nil for entries that only add notes
+	Status       *ServiceStatusEnum `gorm:"index" json:"status"`
 	ChangedAt    time.Time  `json:"changed_at"`
 
 	// Relationships
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// SpecialOrders table, parts a service job waits for because they are not in stock. Requests are rolled into draft
+// purchase orders; receiving the order reserves the quantity for the job until a part line of the job uses it.
+type SpecialOrder struct {
+	SpecialOrderID  uint               `gorm:"primaryKey;autoIncrement" json:"special_order_id"`
+	ServiceJobID    uint               `gorm:"not null;index" json:"service_job_id"`
+	OutletID        uint               `gorm:"not null;index" json:"outlet_id"`
+	ProductID       uint               `gorm:"not null;index" json:"product_id"`
+	Quantity        int                `gorm:"not null" json:"quantity"`
+	Status          SpecialOrderStatus `gorm:"size:20;not null;index" json:"status"`
+	PurchaseOrderID *uint              `gorm:"index" json:"purchase_order_id"`
+	// DetailID is the part line of the job that used the reserved parts
+	DetailID   *uint      `json:"detail_id"`
+	Notes      *string    `gorm:"type:text" json:"notes"`
+	ReceivedAt *time.Time `json:"received_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	CreatedBy  *uint      `json:"created_by"`
+
+	// Relationships
+	Product    *Product    `gorm:"foreignKey:ProductID;references:ProductID" json:"product,omitempty"`
+	ServiceJob *ServiceJob `gorm:"foreignKey:ServiceJobID;references:ServiceJobID" json:"service_job,omitempty"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// TechnicianProfiles table, the users who work on service jobs. Only active technicians are assigned to jobs and
+// appointments.
+type TechnicianProfile struct {
+	UserID    uint      `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	IsActive  bool      `gorm:"not null" json:"is_active"`
+	Notes     *string   `gorm:"type:text" json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedBy *uint     `json:"created_by"`
+
+	// Relationships
+	User   *User             `gorm:"foreignKey:UserID;references:UserID" json:"user,omitempty"`
+	Skills []TechnicianSkill `gorm:"foreignKey:UserID;references:UserID" json:"skills"`
+	Shifts []TechnicianShift `gorm:"foreignKey:UserID;references:UserID" json:"shifts"`
+}
+
+// TechnicianSkills table, the service categories a technician is qualified to work on
+type TechnicianSkill struct {
+	UserID            uint `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	ServiceCategoryID uint `gorm:"primaryKey;autoIncrement:false" json:"service_category_id"`
+
+	// Relationships
+	ServiceCategory *ServiceCategory `gorm:"foreignKey:ServiceCategoryID;references:ServiceCategoryID" json:"service_category,omitempty"`
+}
+
+// TechnicianShifts table, the hours a technician works per day of the week (0 is Sunday). A day without a row is off;
+// a technician without any row works whenever the outlet is open.
+type TechnicianShift struct {
+	UserID   uint   `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	Weekday  int    `gorm:"primaryKey;autoIncrement:false" json:"weekday"`
+	StartsAt string `gorm:"size:5;not null" json:"starts_at"` // "08:00"
+	EndsAt   string `gorm:"size:5;not null" json:"ends_at"`   // "16:00"
+}
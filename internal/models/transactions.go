@@ -39,6 +39,7 @@ type TransactionDetail struct {
 	Quantity         int            `gorm:"not null" json:"quantity"`
 	UnitPrice        float64        `gorm:"type:decimal(15,2);not null" json:"unit_price"`
 	TotalPrice       float64        `gorm:"type:decimal(15,2);not null" json:"total_price"`
+	UnitCost         float64        `gorm:"type:decimal(15,2);not null;default:0" json:"unit_cost"` // product cost at the time of sale
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
 	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -0,0 +1,317 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AnalyticsRepository implements the analytics repository interface with SQL aggregations
+// that run on both Postgres and SQLite; only the date bucketing differs per dialect.
+type AnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsRepository creates a new analytics repository
+func NewAnalyticsRepository(db *gorm.DB) interfaces.AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// SalesSummary aggregates successful transactions. Revenue is the sum of the transaction lines and
+// COGS their quantity times the unit cost snapshotted when the line was sold. Split by payment method, each transaction is shared
+// between its successful payments by amount, unpaid transactions are left out.
+func (r *AnalyticsRepository) SalesSummary(ctx context.Context, filter interfaces.AnalyticsFilter, period interfaces.AnalyticsPeriod, dimension interfaces.AnalyticsDimension) ([]interfaces.SalesSummaryRow, error) {
+	transactions := r.db.
+		Table("transactions AS t").
+		Select(`t.transaction_id, t.transaction_date, t.outlet_id, t.user_id,
+			COALESCE(SUM(d.total_price), 0) AS revenue,
+			COALESCE(SUM(d.quantity * d.unit_cost), 0) AS cogs`).
+		Joins("LEFT JOIN transaction_details d ON d.transaction_id = t.transaction_id AND d.deleted_at IS NULL").
+		Where("t.deleted_at IS NULL AND t.status = ?", models.TransactionStatusSukses).
+		Where("t.transaction_date >= ? AND t.transaction_date < ?", filter.StartDate, filter.EndDate)
+	if filter.OutletID != nil {
+		transactions = transactions.Where("t.outlet_id = ?", *filter.OutletID)
+	}
+	transactions = transactions.Group("t.transaction_id, t.transaction_date, t.outlet_id, t.user_id")
+
+	periodExpr := "''"
+	var groups []string
+	if period != interfaces.AnalyticsPeriodNone {
+		periodExpr = r.periodExpr("ts.transaction_date", period, filter.UTCOffset)
+		groups = append(groups, periodExpr)
+	}
+
+	db := r.db.WithContext(ctx).Table("(?) AS ts", transactions)
+	revenue, cogs, count := "SUM(ts.revenue)", "SUM(ts.cogs)", "COUNT(*)"
+	dimensionID, dimensionName := "NULL", "''"
+
+	switch dimension {
+	case interfaces.AnalyticsDimensionOutlet:
+		db = db.Joins("LEFT JOIN outlets o ON o.outlet_id = ts.outlet_id")
+		dimensionID, dimensionName = "ts.outlet_id", "COALESCE(o.outlet_name, '')"
+	case interfaces.AnalyticsDimensionCashier:
+		db = db.Joins("LEFT JOIN users u ON u.user_id = ts.user_id")
+		dimensionID, dimensionName = "ts.user_id", "COALESCE(u.name, '')"
+	case interfaces.AnalyticsDimensionPaymentMethod:
+		byMethod := r.db.Table("payments").
+			Select("transaction_id, method_id, SUM(amount) AS amount").
+			Where("deleted_at IS NULL AND status = ?", models.TransactionStatusSukses).
+			Group("transaction_id, method_id")
+		paid := r.db.Table("payments").
+			Select("transaction_id, SUM(amount) AS amount").
+			Where("deleted_at IS NULL AND status = ?", models.TransactionStatusSukses).
+			Group("transaction_id").
+			Having("SUM(amount) > 0")
+		db = db.
+			Joins("JOIN (?) AS pay ON pay.transaction_id = ts.transaction_id", byMethod).
+			Joins("JOIN (?) AS paid ON paid.transaction_id = ts.transaction_id", paid).
+			Joins("LEFT JOIN payment_methods pm ON pm.method_id = pay.method_id")
+		revenue = "SUM(ts.revenue * pay.amount / paid.amount)"
+		cogs = "SUM(ts.cogs * pay.amount / paid.amount)"
+		count = "COUNT(DISTINCT ts.transaction_id)"
+		dimensionID, dimensionName = "pay.method_id", "COALESCE(pm.name, '')"
+	}
+	if dimension != interfaces.AnalyticsDimensionNone {
+		groups = append(groups, dimensionID, dimensionName)
+	}
+
+	db = db.Select(fmt.Sprintf(`%s AS period, %s AS dimension_id, %s AS dimension_name,
+		COALESCE(%s, 0) AS revenue, COALESCE(%s, 0) AS cogs, %s AS transaction_count`,
+		periodExpr, dimensionID, dimensionName, revenue, cogs, count))
+	if len(groups) > 0 {
+		db = db.Group(strings.Join(groups, ", "))
+	}
+	if period != interfaces.AnalyticsPeriodNone {
+		db = db.Order(periodExpr)
+	}
+
+	var rows []interfaces.SalesSummaryRow
+	if err := db.Order("revenue DESC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	// Without a dimension and period the aggregate always returns one row, with no sales it is all zero
+	if len(rows) == 1 && rows[0].TransactionCount == 0 {
+		return nil, nil
+	}
+	return rows, nil
+}
+
+// TopProducts ranks the products sold in successful transactions
+func (r *AnalyticsRepository) TopProducts(ctx context.Context, filter interfaces.AnalyticsFilter, rank interfaces.AnalyticsRank, limit int) ([]interfaces.TopItemRow, error) {
+	db := r.db.WithContext(ctx).
+		Table("transaction_details AS d").
+		Select(`p.product_id AS item_id, p.product_name AS name,
+			SUM(d.quantity) AS quantity,
+			SUM(d.total_price) AS revenue,
+			SUM(d.quantity * d.unit_cost) AS cogs`).
+		Joins("JOIN transactions t ON t.transaction_id = d.transaction_id").
+		Joins("JOIN products p ON p.product_id = d.product_id").
+		Where("d.deleted_at IS NULL AND t.deleted_at IS NULL AND t.status = ?", models.TransactionStatusSukses).
+		Where("t.transaction_date >= ? AND t.transaction_date < ?", filter.StartDate, filter.EndDate)
+	if filter.OutletID != nil {
+		db = db.Where("t.outlet_id = ?", *filter.OutletID)
+	}
+
+	var rows []interfaces.TopItemRow
+	err := db.
+		Group("p.product_id, p.product_name").
+		Order(topItemOrder(rank, "SUM(d.quantity)", "SUM(d.total_price) - SUM(d.quantity * d.unit_cost)")).
+		Order("p.product_id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// TopServices ranks the services of finished service jobs received in the period
+func (r *AnalyticsRepository) TopServices(ctx context.Context, filter interfaces.AnalyticsFilter, rank interfaces.AnalyticsRank, limit int) ([]interfaces.TopItemRow, error) {
+	db := r.db.WithContext(ctx).
+		Table("service_details AS sd").
+		Select(`s.service_id AS item_id, s.name AS name,
+			SUM(sd.quantity) AS quantity,
+			SUM(sd.quantity * sd.price_per_item) AS revenue,
+			SUM(sd.quantity * sd.cost_per_item) AS cogs`).
+		Joins("JOIN service_jobs j ON j.service_job_id = sd.service_job_id").
+		Joins("JOIN services s ON s.service_id = sd.item_id").
+		Where("sd.item_type = ?", "service").
+		Where("j.deleted_at IS NULL AND j.status IN ?", []models.ServiceStatusEnum{models.ServiceStatusSelesai, models.ServiceStatusDiambil}).
+		Where("j.service_in_date >= ? AND j.service_in_date < ?", filter.StartDate, filter.EndDate)
+	if filter.OutletID != nil {
+		db = db.Where("j.outlet_id = ?", *filter.OutletID)
+	}
+
+	var rows []interfaces.TopItemRow
+	err := db.
+		Group("s.service_id, s.name").
+		Order(topItemOrder(rank, "SUM(sd.quantity)", "SUM(sd.quantity * (sd.price_per_item - sd.cost_per_item))")).
+		Order("s.service_id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CompletedServiceJobs retrieves the service jobs first marked Selesai in the period, with their histories
+// in order, technician and outlet
+func (r *AnalyticsRepository) CompletedServiceJobs(ctx context.Context, filter interfaces.AnalyticsFilter) ([]*models.ServiceJob, error) {
+	db := r.db.WithContext(ctx).
+		Preload("Histories", func(db *gorm.DB) *gorm.DB {
+			return db.Order("changed_at").Order("history_id")
+		}).
+		Preload("Technician").
+		Preload("Outlet").
+		Where("service_job_id IN (?)", completedJobIDs(r.db, filter))
+	if filter.OutletID != nil {
+		db = db.Where("outlet_id = ?", *filter.OutletID)
+	}
+
+	var jobs []*models.ServiceJob
+	if err := db.Order("service_job_id").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// completedJobIDs selects the IDs of the service jobs whose first Selesai history entry falls in the filter's range
+func completedJobIDs(db *gorm.DB, filter interfaces.AnalyticsFilter) *gorm.DB {
+	return db.
+		Table("service_job_histories").
+		Select("service_job_id").
+		Where("status = ?", models.ServiceStatusSelesai).
+		Group("service_job_id").
+		Having("MIN(changed_at) >= ? AND MIN(changed_at) < ?", filter.StartDate, filter.EndDate)
+}
+
+// VehicleVisits retrieves the service jobs of the vehicles received from since on, oldest first;
+// only the job, vehicle and service in date are loaded
+func (r *AnalyticsRepository) VehicleVisits(ctx context.Context, vehicleIDs []uint, since time.Time) ([]*models.ServiceJob, error) {
+	var jobs []*models.ServiceJob
+	if len(vehicleIDs) == 0 {
+		return jobs, nil
+	}
+	err := r.db.WithContext(ctx).
+		Select("service_job_id, vehicle_id, service_in_date").
+		Where("vehicle_id IN ? AND service_in_date >= ?", vehicleIDs, since).
+		Order("service_in_date").Order("service_job_id").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func topItemOrder(rank interfaces.AnalyticsRank, quantity, margin string) string {
+	if rank == interfaces.AnalyticsRankMargin {
+		return margin + " DESC"
+	}
+	return quantity + " DESC"
+}
+
+// periodExpr formats the bucket of a timestamp column as text, shifted by the UTC offset in seconds first.
+// Weeks start on Monday and are labelled with their first day.
+func (r *AnalyticsRepository) periodExpr(column string, period interfaces.AnalyticsPeriod, offset int) string {
+	if r.db.Dialector.Name() == "sqlite" {
+		shift := fmt.Sprintf("'%+d seconds'", offset)
+		switch period {
+		case interfaces.AnalyticsPeriodWeek:
+			return fmt.Sprintf("date(%s, %s, 'weekday 0', '-6 days')", column, shift)
+		case interfaces.AnalyticsPeriodMonth:
+			return fmt.Sprintf("strftime('%%Y-%%m', %s, %s)", column, shift)
+		}
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d', %s, %s)", column, shift)
+	}
+
+	local := fmt.Sprintf("(%s AT TIME ZONE 'UTC' + INTERVAL '%d seconds')", column, offset)
+	switch period {
+	case interfaces.AnalyticsPeriodWeek:
+		return fmt.Sprintf("to_char(date_trunc('week', %s), 'YYYY-MM-DD')", local)
+	case interfaces.AnalyticsPeriodMonth:
+		return fmt.Sprintf("to_char(%s, 'YYYY-MM')", local)
+	}
+	return fmt.Sprintf("to_char(%s, 'YYYY-MM-DD')", local)
+}
+
+// StockProducts retrieves the products that are not deleted with their category and supplier names
+func (r *AnalyticsRepository) StockProducts(ctx context.Context) ([]interfaces.StockProductRow, error) {
+	var rows []interfaces.StockProductRow
+	err := r.db.WithContext(ctx).
+		Table("products AS p").
+		Select(`p.product_id, p.product_name, p.sku, p.is_active, p.category_id, c.name AS category_name,
+			p.supplier_id, s.supplier_name, p.stock, p.cost_price, p.created_at`).
+		Joins("LEFT JOIN categories c ON c.category_id = p.category_id").
+		Joins("LEFT JOIN suppliers s ON s.supplier_id = p.supplier_id").
+		Where("p.deleted_at IS NULL").
+		Order("p.product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ProductConsumption sums the products sold in successful transactions and used as parts on service jobs. Parts
+// leave the stock when they are added to a job, so the parts of jobs in every status count, by service in date.
+func (r *AnalyticsRepository) ProductConsumption(ctx context.Context, filter interfaces.AnalyticsFilter) ([]interfaces.ProductConsumptionRow, error) {
+	inRange := func(column, value string) string {
+		return fmt.Sprintf("SUM(CASE WHEN %s >= @start THEN %s ELSE 0 END)", column, value)
+	}
+	args := map[string]interface{}{"start": filter.StartDate}
+
+	var sold []interfaces.ProductConsumptionRow
+	err := r.db.WithContext(ctx).
+		Table("transaction_details AS d").
+		Select(fmt.Sprintf("d.product_id, %s AS quantity, %s AS value, MAX(%s) AS last_moved",
+			inRange("t.transaction_date", "d.quantity"), inRange("t.transaction_date", "d.quantity * d.unit_cost"),
+			r.periodExpr("t.transaction_date", interfaces.AnalyticsPeriodDay, filter.UTCOffset)), args).
+		Joins("JOIN transactions t ON t.transaction_id = d.transaction_id").
+		Where("d.product_id IS NOT NULL AND d.deleted_at IS NULL AND t.deleted_at IS NULL").
+		Where("t.status = ? AND t.transaction_date < ?", models.TransactionStatusSukses, filter.EndDate).
+		Group("d.product_id").
+		Scan(&sold).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var used []interfaces.ProductConsumptionRow
+	err = r.db.WithContext(ctx).
+		Table("service_details AS sd").
+		Select(fmt.Sprintf("sd.item_id AS product_id, %s AS quantity, %s AS value, MAX(%s) AS last_moved",
+			inRange("j.service_in_date", "sd.quantity"), inRange("j.service_in_date", "sd.quantity * sd.cost_per_item"),
+			r.periodExpr("j.service_in_date", interfaces.AnalyticsPeriodDay, filter.UTCOffset)), args).
+		Joins("JOIN service_jobs j ON j.service_job_id = sd.service_job_id").
+		Where("sd.item_type = ? AND j.deleted_at IS NULL AND j.service_in_date < ?", "product", filter.EndDate).
+		Group("sd.item_id").
+		Scan(&used).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rows := sold
+	index := make(map[uint]int, len(sold))
+	for i, row := range sold {
+		index[row.ProductID] = i
+	}
+	for _, row := range used {
+		i, ok := index[row.ProductID]
+		if !ok {
+			rows = append(rows, row)
+			continue
+		}
+		rows[i].Quantity += row.Quantity
+		rows[i].Value += row.Value
+		// Days are formatted as YYYY-MM-DD, so they compare as text
+		if row.LastMoved > rows[i].LastMoved {
+			rows[i].LastMoved = row.LastMoved
+		}
+	}
+	return rows, nil
+}
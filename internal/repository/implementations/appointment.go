@@ -0,0 +1,250 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AppointmentRepository implements the appointment repository interface
+type AppointmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAppointmentRepository creates a new appointment repository
+func NewAppointmentRepository(db *gorm.DB) interfaces.AppointmentRepository {
+	return &AppointmentRepository{db: db}
+}
+
+// Create stores the appointment with its services
+func (r *AppointmentRepository) Create(ctx context.Context, appointment *models.Appointment) error {
+	return r.db.WithContext(ctx).Create(appointment).Error
+}
+
+// GetByID retrieves an appointment by ID with its services
+func (r *AppointmentRepository) GetByID(ctx context.Context, id uint) (*models.Appointment, error) {
+	var appointment models.Appointment
+	err := r.db.WithContext(ctx).
+		Preload("Services").
+		First(&appointment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &appointment, nil
+}
+
+// appointmentListFields are the columns appointments can be filtered and sorted by
+var appointmentListFields = query.Fields{
+	Key:         "appointment_id",
+	DefaultSort: "start_at",
+	Allowed: map[string]query.FieldType{
+		"outlet_id":      query.Number,
+		"customer_id":    query.Number,
+		"vehicle_id":     query.Number,
+		"technician_id":  query.Number,
+		"start_at":       query.Time,
+		"status":         query.String,
+		"service_job_id": query.Number,
+		"created_at":     query.Time,
+	},
+}
+
+// List retrieves appointments matching the list query
+func (r *AppointmentRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Appointment, int64, error) {
+	var appointments []*models.Appointment
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Appointment{}), q, appointmentListFields, &appointments, "Services")
+	if err != nil {
+		return nil, 0, err
+	}
+	return appointments, total, nil
+}
+
+// Update stores the appointment and replaces its services
+func (r *AppointmentRepository) Update(ctx context.Context, appointment *models.Appointment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Services").Save(appointment).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("appointment_id = ?", appointment.AppointmentID).Delete(&models.AppointmentService{}).Error; err != nil {
+			return err
+		}
+		for i := range appointment.Services {
+			appointment.Services[i].AppointmentServiceID = 0
+			appointment.Services[i].AppointmentID = appointment.AppointmentID
+		}
+		if len(appointment.Services) == 0 {
+			return nil
+		}
+		return tx.Create(&appointment.Services).Error
+	})
+}
+
+// Transition stores the status of the appointment with the fields that go with it; the status condition lets
+// one of two concurrent check-ins or no-show runs win
+func (r *AppointmentRepository) Transition(ctx context.Context, appointment *models.Appointment, from models.AppointmentStatus) (bool, error) {
+	appointment.UpdatedAt = time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&models.Appointment{}).
+		Where("appointment_id = ? AND status = ?", appointment.AppointmentID, from).
+		Updates(map[string]interface{}{
+			"status":         appointment.Status,
+			"service_job_id": appointment.ServiceJobID,
+			"arrived_at":     appointment.ArrivedAt,
+			"no_show_at":     appointment.NoShowAt,
+			"cancelled_at":   appointment.CancelledAt,
+			"cancel_reason":  appointment.CancelReason,
+			"updated_at":     appointment.UpdatedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// LockOutlet reads the outlet for update; SQLite already serialises write transactions and has no row locks
+func (r *AppointmentRepository) LockOutlet(ctx context.Context, outletID uint) (*models.Outlet, error) {
+	db := r.db.WithContext(ctx)
+	if db.Dialector.Name() != "sqlite" {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var outlet models.Outlet
+	if err := db.First(&outlet, outletID).Error; err != nil {
+		return nil, err
+	}
+	return &outlet, nil
+}
+
+// GetScheduledByOutlet retrieves the Dijadwalkan appointments of an outlet overlapping [from, to)
+func (r *AppointmentRepository) GetScheduledByOutlet(ctx context.Context, outletID uint, from, to time.Time) ([]*models.Appointment, error) {
+	var appointments []*models.Appointment
+	err := r.db.WithContext(ctx).
+		Where("outlet_id = ? AND status = ? AND start_at < ? AND end_at > ?", outletID, models.AppointmentStatusDijadwalkan, to, from).
+		Order("start_at ASC").
+		Find(&appointments).Error
+	if err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// GetScheduledByTechnician retrieves the Dijadwalkan appointments of a technician overlapping [from, to)
+func (r *AppointmentRepository) GetScheduledByTechnician(ctx context.Context, technicianID uint, from, to time.Time) ([]*models.Appointment, error) {
+	var appointments []*models.Appointment
+	err := r.db.WithContext(ctx).
+		Where("technician_id = ? AND status = ? AND start_at < ? AND end_at > ?", technicianID, models.AppointmentStatusDijadwalkan, to, from).
+		Order("start_at ASC").
+		Find(&appointments).Error
+	if err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// GetByOutletBetween retrieves the appointments of an outlet starting in [from, to) with their services
+func (r *AppointmentRepository) GetByOutletBetween(ctx context.Context, outletID uint, from, to time.Time) ([]*models.Appointment, error) {
+	var appointments []*models.Appointment
+	err := r.db.WithContext(ctx).
+		Preload("Services").
+		Where("outlet_id = ? AND start_at >= ? AND start_at < ?", outletID, from, to).
+		Order("start_at ASC").
+		Order("appointment_id ASC").
+		Find(&appointments).Error
+	if err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// GetDueNoShows retrieves the Dijadwalkan appointments that started before the given time
+func (r *AppointmentRepository) GetDueNoShows(ctx context.Context, startedBefore time.Time) ([]*models.Appointment, error) {
+	var appointments []*models.Appointment
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND start_at < ?", models.AppointmentStatusDijadwalkan, startedBefore).
+		Order("start_at ASC").
+		Find(&appointments).Error
+	if err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// CountNoShows counts the no-shows of a customer with appointments starting since the given time
+func (r *AppointmentRepository) CountNoShows(ctx context.Context, customerID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Appointment{}).
+		Where("customer_id = ? AND status = ? AND start_at >= ?", customerID, models.AppointmentStatusTidakDatang, since).
+		Count(&count).Error
+	return count, err
+}
+
+// OutletScheduleRepository implements the outlet schedule repository interface
+type OutletScheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewOutletScheduleRepository creates a new outlet schedule repository
+func NewOutletScheduleRepository(db *gorm.DB) interfaces.OutletScheduleRepository {
+	return &OutletScheduleRepository{db: db}
+}
+
+// GetOpeningHours retrieves the opening hours of an outlet by day of the week
+func (r *OutletScheduleRepository) GetOpeningHours(ctx context.Context, outletID uint) ([]models.OutletOpeningHour, error) {
+	var hours []models.OutletOpeningHour
+	err := r.db.WithContext(ctx).
+		Where("outlet_id = ?", outletID).
+		Order("weekday ASC").
+		Find(&hours).Error
+	if err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+// ReplaceOpeningHours replaces the opening hours of an outlet in one transaction
+func (r *OutletScheduleRepository) ReplaceOpeningHours(ctx context.Context, outletID uint, hours []models.OutletOpeningHour) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("outlet_id = ?", outletID).Delete(&models.OutletOpeningHour{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		return tx.Create(&hours).Error
+	})
+}
+
+// CreateHoliday creates a holiday
+func (r *OutletScheduleRepository) CreateHoliday(ctx context.Context, holiday *models.OutletHoliday) error {
+	return r.db.WithContext(ctx).Create(holiday).Error
+}
+
+// GetHolidayByID retrieves a holiday by ID
+func (r *OutletScheduleRepository) GetHolidayByID(ctx context.Context, id uint) (*models.OutletHoliday, error) {
+	var holiday models.OutletHoliday
+	if err := r.db.WithContext(ctx).First(&holiday, id).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// GetHolidays retrieves the holidays in [from, to) of an outlet and of all outlets, by date. Dates are compared as
+// days, which also holds for SQLite where they are stored as text.
+func (r *OutletScheduleRepository) GetHolidays(ctx context.Context, outletID uint, from, to string) ([]*models.OutletHoliday, error) {
+	db := r.db.WithContext(ctx).Where("date >= ? AND date < ?", from, to)
+	if outletID != 0 {
+		db = db.Where("(outlet_id = ? OR outlet_id IS NULL)", outletID)
+	}
+	var holidays []*models.OutletHoliday
+	if err := db.Order("date ASC").Order("holiday_id ASC").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// DeleteHoliday deletes a holiday
+func (r *OutletScheduleRepository) DeleteHoliday(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.OutletHoliday{}, id).Error
+}
@@ -0,0 +1,59 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository implements the audit log repository interface
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *gorm.DB) interfaces.AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create appends a new audit log entry
+func (r *AuditLogRepository) Create(ctx context.Context, log *models.AuditLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// GetByID retrieves an audit log entry by ID
+func (r *AuditLogRepository) GetByID(ctx context.Context, id uint) (*models.AuditLog, error) {
+	var log models.AuditLog
+	err := r.db.WithContext(ctx).First(&log, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// auditLogListFields are the columns audit logs can be filtered and sorted by
+var auditLogListFields = query.Fields{
+	Key:         "audit_log_id",
+	DefaultSort: "-created_at",
+	Allowed: map[string]query.FieldType{
+		"entity":     query.String,
+		"entity_id":  query.String,
+		"action":     query.String,
+		"user_id":    query.Number,
+		"outlet_id":  query.Number,
+		"created_at": query.Time,
+	},
+}
+
+// List retrieves audit log entries matching the list query, newest first by default
+func (r *AuditLogRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.AuditLog, int64, error) {
+	var logs []*models.AuditLog
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.AuditLog{}), q, auditLogListFields, &logs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
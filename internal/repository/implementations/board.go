@@ -0,0 +1,63 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ServiceJobEventRepository implements the service job event repository interface
+type ServiceJobEventRepository struct {
+	db *gorm.DB
+}
+
+// NewServiceJobEventRepository creates a new service job event repository
+func NewServiceJobEventRepository(db *gorm.DB) interfaces.ServiceJobEventRepository {
+	return &ServiceJobEventRepository{db: db}
+}
+
+// Create appends an event to the log
+func (r *ServiceJobEventRepository) Create(ctx context.Context, event *models.ServiceJobEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// ListAfter retrieves the events of the outlet following afterID, oldest first
+func (r *ServiceJobEventRepository) ListAfter(ctx context.Context, outletID uint, afterID uint, limit int) ([]*models.ServiceJobEvent, error) {
+	var events []*models.ServiceJobEvent
+	err := r.db.WithContext(ctx).
+		Where("outlet_id = ? AND event_id > ?", outletID, afterID).
+		Order("event_id ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Bounds returns the lowest and highest event ID kept
+func (r *ServiceJobEventRepository) Bounds(ctx context.Context) (uint, uint, error) {
+	var bounds struct {
+		First uint
+		Last  uint
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.ServiceJobEvent{}).
+		Select("COALESCE(MIN(event_id), 0) AS first, COALESCE(MAX(event_id), 0) AS last").
+		Scan(&bounds).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return bounds.First, bounds.Last, nil
+}
+
+// DeleteBefore deletes the events created before the given time
+func (r *ServiceJobEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("created_at < ?", before.UTC()).
+		Delete(&models.ServiceJobEvent{})
+	return result.RowsAffected, result.Error
+}
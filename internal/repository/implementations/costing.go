@@ -0,0 +1,335 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CostingRepository implements the costing repository interface. Product.CostPrice is the moving average
+// of everything received and cost layers are kept for every product, so the cost method can be switched
+// at any time; the method only decides which of the two an issued unit costs.
+type CostingRepository struct {
+	db *gorm.DB
+}
+
+// NewCostingRepository creates a new costing repository
+func NewCostingRepository(db *gorm.DB) interfaces.CostingRepository {
+	return &CostingRepository{db: db}
+}
+
+// Receive records inbound movements in one transaction
+func (r *CostingRepository) Receive(ctx context.Context, movements ...*models.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, movement := range movements {
+			if err := receive(tx, movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func receive(tx *gorm.DB, movement *models.StockMovement) error {
+	if movement.Quantity <= 0 {
+		return fmt.Errorf("costing: received quantity must be positive, got %d", movement.Quantity)
+	}
+	product, err := lockProduct(tx, movement.ProductID)
+	if err != nil {
+		return err
+	}
+
+	if movement.CreatedAt.IsZero() {
+		movement.CreatedAt = time.Now()
+	}
+	if err := tx.Create(movement).Error; err != nil {
+		return err
+	}
+
+	// Stock issued before it was on record takes its share of the new layer straight away
+	remaining := movement.Quantity
+	onHand := product.Stock
+	if onHand < 0 {
+		remaining += onHand
+		onHand = 0
+	}
+	if remaining > 0 {
+		layer := &models.CostLayer{
+			ProductID:  movement.ProductID,
+			OutletID:   movement.OutletID,
+			MovementID: &movement.MovementID,
+			Quantity:   movement.Quantity,
+			Remaining:  remaining,
+			UnitCost:   movement.UnitCost,
+			CreatedAt:  movement.CreatedAt,
+		}
+		if err := tx.Create(layer).Error; err != nil {
+			return err
+		}
+	}
+
+	averageCost := roundCost((float64(onHand)*product.CostPrice + float64(movement.Quantity)*movement.UnitCost) /
+		float64(onHand+movement.Quantity))
+	return tx.Model(&models.Product{}).
+		Where("product_id = ?", movement.ProductID).
+		Updates(map[string]interface{}{
+			"stock":      gorm.Expr("stock + ?", movement.Quantity),
+			"cost_price": averageCost,
+		}).Error
+}
+
+// Issue records an outbound movement the stock on hand covers. Units not covered by a cost layer, stock from
+// before the ledger, are costed at the moving average.
+func (r *CostingRepository) Issue(ctx context.Context, movement *models.StockMovement) error {
+	if movement.Quantity >= 0 {
+		return fmt.Errorf("costing: issued quantity must be negative, got %d", movement.Quantity)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		product, err := lockProduct(tx, movement.ProductID)
+		if err != nil {
+			return err
+		}
+		if product.Stock+movement.Quantity < 0 {
+			return interfaces.ErrInsufficientStock
+		}
+		if movement.MovementType == models.StockMovementSale || movement.MovementType == models.StockMovementService {
+			reserved, err := reservedStock(tx, movement)
+			if err != nil {
+				return err
+			}
+			if reserved > 0 && product.Stock+movement.Quantity < reserved {
+				return interfaces.ErrStockReserved
+			}
+		}
+
+		quantity := -movement.Quantity
+		covered, layerCost, err := consumeLayers(tx, movement.ProductID, quantity)
+		if err != nil {
+			return err
+		}
+
+		movement.UnitCost = product.CostPrice
+		if product.CostMethod == models.CostMethodFIFO {
+			movement.UnitCost = roundCost((layerCost + float64(quantity-covered)*product.CostPrice) / float64(quantity))
+		}
+		if movement.CreatedAt.IsZero() {
+			movement.CreatedAt = time.Now()
+		}
+		if err := tx.Create(movement).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Product{}).
+			Where("product_id = ?", movement.ProductID).
+			Update("stock", gorm.Expr("stock + ?", movement.Quantity)).Error
+	})
+}
+
+// Available returns the stock on hand of the product less the stock reserved for the special orders of other jobs
+func (r *CostingRepository) Available(ctx context.Context, productID, serviceJobID uint) (int, error) {
+	tx := r.db.WithContext(ctx)
+	var product models.Product
+	if err := tx.First(&product, productID).Error; err != nil {
+		return 0, err
+	}
+	referenceType := "service_job"
+	reserved, err := reservedStock(tx, &models.StockMovement{ProductID: productID, ReferenceType: &referenceType, ReferenceID: &serviceJobID})
+	if err != nil {
+		return 0, err
+	}
+	return product.Stock - reserved, nil
+}
+
+// reservedStock returns the quantity of the product received for special orders and not used yet, except the orders
+// of the job a service movement references
+func reservedStock(tx *gorm.DB, movement *models.StockMovement) (int, error) {
+	db := tx.Model(&models.SpecialOrder{}).
+		Where("product_id = ? AND status = ?", movement.ProductID, models.SpecialOrderDicadangkan)
+	if movement.ReferenceType != nil && *movement.ReferenceType == "service_job" && movement.ReferenceID != nil {
+		db = db.Where("service_job_id <> ?", *movement.ReferenceID)
+	}
+	var reserved int
+	if err := db.Select("COALESCE(SUM(quantity), 0)").Scan(&reserved).Error; err != nil {
+		return 0, err
+	}
+	return reserved, nil
+}
+
+// consumeLayers takes up to quantity units from the open layers of a product, oldest first,
+// and returns how many units they covered and what those units cost
+func consumeLayers(tx *gorm.DB, productID uint, quantity int) (int, float64, error) {
+	var layers []*models.CostLayer
+	err := tx.Where("product_id = ? AND remaining > 0", productID).
+		Order("created_at").Order("layer_id").
+		Find(&layers).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	covered, cost := 0, 0.0
+	for _, layer := range layers {
+		if covered == quantity {
+			break
+		}
+		take := layer.Remaining
+		if take > quantity-covered {
+			take = quantity - covered
+		}
+		if err := tx.Model(layer).Update("remaining", layer.Remaining-take).Error; err != nil {
+			return 0, 0, err
+		}
+		covered += take
+		cost += float64(take) * layer.UnitCost
+	}
+	return covered, cost, nil
+}
+
+// ChangeCostMethod moves the stock on hand of every outlet out at the old method's unit cost and back in
+// at the new one, so the ledger value follows the new method while quantities stay the same
+func (r *CostingRepository) ChangeCostMethod(ctx context.Context, productID uint, method models.CostMethod, createdBy *uint) ([]*models.StockMovement, error) {
+	var movements []*models.StockMovement
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		product, err := lockProduct(tx, productID)
+		if err != nil {
+			return err
+		}
+		if product.CostMethod == method {
+			return nil
+		}
+
+		var layers struct {
+			Quantity int64
+			Value    float64
+		}
+		err = tx.Model(&models.CostLayer{}).
+			Select("COALESCE(SUM(remaining), 0) AS quantity, COALESCE(SUM(remaining * unit_cost), 0) AS value").
+			Where("product_id = ? AND remaining > 0", productID).
+			Scan(&layers).Error
+		if err != nil {
+			return err
+		}
+		fifoCost := product.CostPrice
+		if layers.Quantity > 0 {
+			fifoCost = roundCost(layers.Value / float64(layers.Quantity))
+		}
+		from, to := product.CostPrice, fifoCost
+		if method == models.CostMethodAverage {
+			from, to = fifoCost, product.CostPrice
+		}
+
+		if from != to {
+			var stocks []struct {
+				OutletID *uint
+				Quantity int
+			}
+			err = tx.Model(&models.StockMovement{}).
+				Select("outlet_id, SUM(quantity) AS quantity").
+				Where("product_id = ?", productID).
+				Group("outlet_id").
+				Having("SUM(quantity) > 0").
+				Scan(&stocks).Error
+			if err != nil {
+				return err
+			}
+
+			referenceType := "product"
+			notes := fmt.Sprintf("cost method changed from %s to %s", product.CostMethod, method)
+			now := time.Now()
+			for _, stock := range stocks {
+				for _, entry := range []struct {
+					quantity int
+					unitCost float64
+				}{{-stock.Quantity, from}, {stock.Quantity, to}} {
+					movements = append(movements, &models.StockMovement{
+						ProductID:     productID,
+						OutletID:      stock.OutletID,
+						MovementType:  models.StockMovementRevaluation,
+						Quantity:      entry.quantity,
+						UnitCost:      entry.unitCost,
+						ReferenceType: &referenceType,
+						ReferenceID:   &product.ProductID,
+						Notes:         &notes,
+						CreatedAt:     now,
+						CreatedBy:     createdBy,
+					})
+				}
+			}
+			if len(movements) > 0 {
+				if err := tx.Create(&movements).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Model(&models.Product{}).
+			Where("product_id = ?", productID).
+			Update("cost_method", method).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// GetOpenLayers retrieves the cost layers of a product that still hold stock, in the order they are consumed
+func (r *CostingRepository) GetOpenLayers(ctx context.Context, productID uint) ([]*models.CostLayer, error) {
+	var layers []*models.CostLayer
+	err := r.db.WithContext(ctx).
+		Where("product_id = ? AND remaining > 0", productID).
+		Order("created_at").Order("layer_id").
+		Find(&layers).Error
+	if err != nil {
+		return nil, err
+	}
+	return layers, nil
+}
+
+// Valuation sums the stock ledger per outlet and product up to asOf (exclusive); the value of a row is
+// the sum of its movements' quantity times unit cost
+func (r *CostingRepository) Valuation(ctx context.Context, asOf time.Time, outletID *uint) ([]interfaces.ValuationRow, error) {
+	db := r.db.WithContext(ctx).
+		Table("stock_movements AS m").
+		Select(`m.outlet_id, COALESCE(o.outlet_name, '') AS outlet_name,
+			p.product_id, p.product_name, p.sku, p.cost_method,
+			SUM(m.quantity) AS quantity,
+			SUM(m.quantity * m.unit_cost) AS value`).
+		Joins("JOIN products p ON p.product_id = m.product_id").
+		Joins("LEFT JOIN outlets o ON o.outlet_id = m.outlet_id").
+		Where("m.created_at < ?", asOf)
+	if outletID != nil {
+		db = db.Where("m.outlet_id = ?", *outletID)
+	}
+
+	var rows []interfaces.ValuationRow
+	err := db.
+		Group("m.outlet_id, o.outlet_name, p.product_id, p.product_name, p.sku, p.cost_method").
+		Having("SUM(m.quantity) <> 0 OR SUM(m.quantity * m.unit_cost) <> 0").
+		Order("m.outlet_id").Order("p.product_name").Order("p.product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// lockProduct reads a product for update; SQLite already serialises write transactions and has no row locks
+func lockProduct(tx *gorm.DB, productID uint) (*models.Product, error) {
+	if tx.Dialector.Name() != "sqlite" {
+		tx = tx.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var product models.Product
+	if err := tx.First(&product, productID).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// roundCost rounds a unit cost to cents like the decimal(15,2) columns it is stored in
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
@@ -0,0 +1,296 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens a file database with the schema of every model
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=10000"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(models.GetAllModels()...); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("database handle: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// newCostingTestProduct stores a product without stock costed by the method
+func newCostingTestProduct(t *testing.T, db *gorm.DB, method models.CostMethod) *models.Product {
+	t.Helper()
+	product := &models.Product{
+		ProductName:  "Engine oil",
+		CostMethod:   method,
+		SellingPrice: 1000,
+		UsageStatus:  models.ProductUsageJual,
+		IsActive:     true,
+	}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	return product
+}
+
+// costingTestOutlet is the outlet the costing tests move stock in
+var costingTestOutlet = uint(1)
+
+// receiveTestStock receives stock into the test outlet
+func receiveTestStock(t *testing.T, r interfaces.CostingRepository, productID uint, quantity int, unitCost float64) {
+	t.Helper()
+	err := r.Receive(context.Background(), &models.StockMovement{
+		ProductID:    productID,
+		OutletID:     &costingTestOutlet,
+		MovementType: models.StockMovementPurchase,
+		Quantity:     quantity,
+		UnitCost:     unitCost,
+	})
+	if err != nil {
+		t.Fatalf("receive %d at %.2f: %v", quantity, unitCost, err)
+	}
+}
+
+// issueTestStock issues stock from the test outlet and returns the movement with its unit cost
+func issueTestStock(r interfaces.CostingRepository, productID uint, movementType models.StockMovementType, quantity int) (*models.StockMovement, error) {
+	movement := &models.StockMovement{
+		ProductID:    productID,
+		OutletID:     &costingTestOutlet,
+		MovementType: movementType,
+		Quantity:     -quantity,
+	}
+	return movement, r.Issue(context.Background(), movement)
+}
+
+// reloadTestProduct reads the product's stock and cost as the ledger left them
+func reloadTestProduct(t *testing.T, db *gorm.DB, productID uint) *models.Product {
+	t.Helper()
+	var product models.Product
+	if err := db.First(&product, productID).Error; err != nil {
+		t.Fatalf("reload product: %v", err)
+	}
+	return &product
+}
+
+func TestCostingMovingAverage(t *testing.T) {
+	db := newTestDB(t)
+	r := NewCostingRepository(db)
+	product := newCostingTestProduct(t, db, models.CostMethodAverage)
+
+	receiveTestStock(t, r, product.ProductID, 10, 100)
+	receiveTestStock(t, r, product.ProductID, 10, 200)
+	if reloaded := reloadTestProduct(t, db, product.ProductID); reloaded.CostPrice != 150 || reloaded.Stock != 20 {
+		t.Fatalf("got stock %d at %.2f, want 20 at 150", reloaded.Stock, reloaded.CostPrice)
+	}
+
+	movement, err := issueTestStock(r, product.ProductID, models.StockMovementSale, 5)
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if movement.UnitCost != 150 {
+		t.Fatalf("issued at %.2f, want the moving average 150", movement.UnitCost)
+	}
+
+	// The average of the rest is not changed by the issue, only by the next receipt
+	receiveTestStock(t, r, product.ProductID, 5, 250)
+	if reloaded := reloadTestProduct(t, db, product.ProductID); reloaded.CostPrice != 175 || reloaded.Stock != 20 {
+		t.Fatalf("got stock %d at %.2f, want 20 at 175", reloaded.Stock, reloaded.CostPrice)
+	}
+}
+
+func TestCostingFIFOLayers(t *testing.T) {
+	db := newTestDB(t)
+	r := NewCostingRepository(db)
+	product := newCostingTestProduct(t, db, models.CostMethodFIFO)
+
+	receiveTestStock(t, r, product.ProductID, 4, 100)
+	receiveTestStock(t, r, product.ProductID, 4, 200)
+
+	movement, err := issueTestStock(r, product.ProductID, models.StockMovementSale, 6)
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if movement.UnitCost != 133.33 {
+		t.Fatalf("issued at %.2f, want (4 x 100 + 2 x 200) / 6 = 133.33", movement.UnitCost)
+	}
+
+	layers, err := r.GetOpenLayers(context.Background(), product.ProductID)
+	if err != nil {
+		t.Fatalf("open layers: %v", err)
+	}
+	if len(layers) != 1 || layers[0].Remaining != 2 || layers[0].UnitCost != 200 {
+		t.Fatalf("got open layers %+v, want 2 left at 200", layers)
+	}
+
+	movement, err = issueTestStock(r, product.ProductID, models.StockMovementSale, 2)
+	if err != nil {
+		t.Fatalf("issue the rest: %v", err)
+	}
+	if movement.UnitCost != 200 {
+		t.Fatalf("issued the rest at %.2f, want 200", movement.UnitCost)
+	}
+}
+
+func TestCostingIssueBeyondStockOnHand(t *testing.T) {
+	db := newTestDB(t)
+	r := NewCostingRepository(db)
+	product := newCostingTestProduct(t, db, models.CostMethodFIFO)
+	receiveTestStock(t, r, product.ProductID, 2, 100)
+
+	for _, movementType := range []models.StockMovementType{models.StockMovementSale, models.StockMovementService, models.StockMovementAdjustment} {
+		if _, err := issueTestStock(r, product.ProductID, movementType, 3); !errors.Is(err, interfaces.ErrInsufficientStock) {
+			t.Fatalf("%s of 3 with 2 on hand: got %v, want %v", movementType, err, interfaces.ErrInsufficientStock)
+		}
+	}
+
+	var movements int64
+	if err := db.Model(&models.StockMovement{}).Where("product_id = ?", product.ProductID).Count(&movements).Error; err != nil {
+		t.Fatalf("count movements: %v", err)
+	}
+	reloaded := reloadTestProduct(t, db, product.ProductID)
+	if movements != 1 || reloaded.Stock != 2 {
+		t.Fatalf("got %d movements and stock %d after the rejected issues, want 1 and 2", movements, reloaded.Stock)
+	}
+}
+
+func TestCostingIssueLeavesReservedStock(t *testing.T) {
+	db := newTestDB(t)
+	r := NewCostingRepository(db)
+	product := newCostingTestProduct(t, db, models.CostMethodAverage)
+	receiveTestStock(t, r, product.ProductID, 3, 100)
+
+	const serviceJobID = 7
+	now := time.Now()
+	reservation := &models.SpecialOrder{
+		ServiceJobID: serviceJobID,
+		OutletID:     costingTestOutlet,
+		ProductID:    product.ProductID,
+		Quantity:     2,
+		Status:       models.SpecialOrderDicadangkan,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := db.Omit("Product", "ServiceJob").Create(reservation).Error; err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+
+	if _, err := issueTestStock(r, product.ProductID, models.StockMovementSale, 2); !errors.Is(err, interfaces.ErrStockReserved) {
+		t.Fatalf("sale of reserved stock: got %v, want %v", err, interfaces.ErrStockReserved)
+	}
+	if _, err := issueTestStock(r, product.ProductID, models.StockMovementSale, 1); err != nil {
+		t.Fatalf("sale of the stock not reserved: %v", err)
+	}
+
+	// The job the parts are reserved for uses them
+	referenceType := "service_job"
+	jobID := uint(serviceJobID)
+	err := r.Issue(context.Background(), &models.StockMovement{
+		ProductID:     product.ProductID,
+		OutletID:      &costingTestOutlet,
+		MovementType:  models.StockMovementService,
+		Quantity:      -2,
+		ReferenceType: &referenceType,
+		ReferenceID:   &jobID,
+	})
+	if err != nil {
+		t.Fatalf("part line of the job: %v", err)
+	}
+}
+
+func TestCostingChangeCostMethodRevaluesStock(t *testing.T) {
+	db := newTestDB(t)
+	r := NewCostingRepository(db)
+	ctx := context.Background()
+	product := newCostingTestProduct(t, db, models.CostMethodAverage)
+
+	// Two at 100 and two at 300 average 200; issuing two at the average leaves the layer of 300 open
+	receiveTestStock(t, r, product.ProductID, 2, 100)
+	receiveTestStock(t, r, product.ProductID, 2, 300)
+	if _, err := issueTestStock(r, product.ProductID, models.StockMovementSale, 2); err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+
+	value := func() float64 {
+		t.Helper()
+		rows, err := r.Valuation(ctx, time.Now().Add(time.Minute), nil)
+		if err != nil {
+			t.Fatalf("valuation: %v", err)
+		}
+		if len(rows) != 1 || rows[0].Quantity != 2 {
+			t.Fatalf("got valuation rows %+v, want 2 on hand", rows)
+		}
+		return rows[0].Value
+	}
+	if v := value(); v != 400 {
+		t.Fatalf("value at the average: got %.2f, want 400", v)
+	}
+
+	movements, err := r.ChangeCostMethod(ctx, product.ProductID, models.CostMethodFIFO, nil)
+	if err != nil {
+		t.Fatalf("change to fifo: %v", err)
+	}
+	if len(movements) != 2 || movements[0].Quantity != -2 || movements[0].UnitCost != 200 || movements[1].Quantity != 2 || movements[1].UnitCost != 300 {
+		t.Fatalf("got revaluation %+v %+v, want 2 out at 200 and in at 300", *movements[0], *movements[1])
+	}
+	if v := value(); v != 600 {
+		t.Fatalf("value at fifo: got %.2f, want 600", v)
+	}
+	if reloaded := reloadTestProduct(t, db, product.ProductID); reloaded.Stock != 2 || reloaded.CostMethod != models.CostMethodFIFO {
+		t.Fatalf("got stock %d costed by %s, want 2 by fifo", reloaded.Stock, reloaded.CostMethod)
+	}
+
+	// Changing to the method in use changes nothing
+	if movements, err := r.ChangeCostMethod(ctx, product.ProductID, models.CostMethodFIFO, nil); err != nil || len(movements) != 0 {
+		t.Fatalf("change to the same method: got %d movements, %v", len(movements), err)
+	}
+
+	if _, err := r.ChangeCostMethod(ctx, product.ProductID, models.CostMethodAverage, nil); err != nil {
+		t.Fatalf("change back to average: %v", err)
+	}
+	if v := value(); v != 400 {
+		t.Fatalf("value back at the average: got %.2f, want 400", v)
+	}
+}
+
+func TestProductUpdateKeepsReceivedCostPrice(t *testing.T) {
+	db := newTestDB(t)
+	products := NewProductRepository(db)
+	product := newCostingTestProduct(t, db, models.CostMethodAverage)
+	ctx := context.Background()
+
+	// A product edited from a copy read before a receipt keeps the cost price of the receipt
+	stale := reloadTestProduct(t, db, product.ProductID)
+	receiveTestStock(t, NewCostingRepository(db), product.ProductID, 10, 100)
+	stale.ProductName = "Gear oil"
+	stale.CostPrice = 80
+	if err := products.Update(ctx, stale); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if reloaded := reloadTestProduct(t, db, product.ProductID); reloaded.CostPrice != 100 || reloaded.ProductName != "Gear oil" {
+		t.Fatalf("got %s at %.2f, want Gear oil at 100", reloaded.ProductName, reloaded.CostPrice)
+	}
+
+	if set, err := products.SetCostPrice(ctx, product.ProductID, 80); err != nil || set {
+		t.Fatalf("set cost price with stock: got %v, %v, want it refused", set, err)
+	}
+	other := newCostingTestProduct(t, db, models.CostMethodAverage)
+	if set, err := products.SetCostPrice(ctx, other.ProductID, 80); err != nil || !set {
+		t.Fatalf("set cost price without stock: got %v, %v, want it set", set, err)
+	}
+}
@@ -3,6 +3,7 @@ package implementations
 import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
 	"context"
 
 	"gorm.io/gorm"
@@ -53,14 +54,28 @@ func (r *CustomerRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Customer{}, id).Error
 }
 
-// List retrieves customers with pagination
-func (r *CustomerRepository) List(ctx context.Context, limit, offset int) ([]*models.Customer, error) {
+// customerListFields are the columns customers can be filtered and sorted by
+var customerListFields = query.Fields{
+	Key:         "customer_id",
+	DefaultSort: "-created_at",
+	Allowed: map[string]query.FieldType{
+		"name":         query.String,
+		"phone_number": query.String,
+		"email":        query.String,
+		"address":      query.String,
+		"status":       query.String,
+		"created_at":   query.Time,
+	},
+}
+
+// List retrieves customers matching the list query
+func (r *CustomerRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Customer, int64, error) {
 	var customers []*models.Customer
-	err := r.db.WithContext(ctx).Preload("Vehicles").Limit(limit).Offset(offset).Find(&customers).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Customer{}), q, customerListFields, &customers, "Vehicles")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return customers, nil
+	return customers, total, nil
 }
 
 // GetByStatus retrieves customers by status
@@ -156,14 +171,30 @@ func (r *CustomerVehicleRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.CustomerVehicle{}, id).Error
 }
 
-// List retrieves customer vehicles with pagination
-func (r *CustomerVehicleRepository) List(ctx context.Context, limit, offset int) ([]*models.CustomerVehicle, error) {
+// customerVehicleListFields are the columns customer vehicles can be filtered and sorted by
+var customerVehicleListFields = query.Fields{
+	Key:         "vehicle_id",
+	DefaultSort: "-created_at",
+	Allowed: map[string]query.FieldType{
+		"customer_id":     query.Number,
+		"plate_number":    query.String,
+		"brand":           query.String,
+		"model":           query.String,
+		"type":            query.String,
+		"production_year": query.Number,
+		"color":           query.String,
+		"created_at":      query.Time,
+	},
+}
+
+// List retrieves customer vehicles matching the list query
+func (r *CustomerVehicleRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.CustomerVehicle, int64, error) {
 	var vehicles []*models.CustomerVehicle
-	err := r.db.WithContext(ctx).Preload("Customer").Limit(limit).Offset(offset).Find(&vehicles).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.CustomerVehicle{}), q, customerVehicleListFields, &vehicles, "Customer")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return vehicles, nil
+	return vehicles, total, nil
 }
 
 // GetByCustomerID retrieves customer vehicles by customer ID
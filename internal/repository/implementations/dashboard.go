@@ -0,0 +1,121 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DashboardRepository implements the dashboard repository interface, every query groups by outlet
+type DashboardRepository struct {
+	db *gorm.DB
+}
+
+// NewDashboardRepository creates a new dashboard repository
+func NewDashboardRepository(db *gorm.DB) interfaces.DashboardRepository {
+	return &DashboardRepository{db: db}
+}
+
+// ServiceRevenue counts the service jobs first completed in the range and sums their grand total
+func (r *DashboardRepository) ServiceRevenue(ctx context.Context, filter interfaces.AnalyticsFilter) ([]interfaces.OutletAmountRow, error) {
+	db := r.db.WithContext(ctx).
+		Table("service_jobs").
+		Select("outlet_id, COUNT(*) AS count, COALESCE(SUM(grand_total), 0) AS amount").
+		Where("deleted_at IS NULL AND service_job_id IN (?)", completedJobIDs(r.db, filter))
+	if filter.OutletID != nil {
+		db = db.Where("outlet_id = ?", *filter.OutletID)
+	}
+
+	var rows []interfaces.OutletAmountRow
+	if err := db.Group("outlet_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ServiceJobStatuses counts the service jobs received in the range by their current status
+func (r *DashboardRepository) ServiceJobStatuses(ctx context.Context, filter interfaces.AnalyticsFilter) ([]interfaces.OutletStatusRow, error) {
+	db := r.db.WithContext(ctx).
+		Table("service_jobs").
+		Select("outlet_id, status, COUNT(*) AS count").
+		Where("deleted_at IS NULL").
+		Where("service_in_date >= ? AND service_in_date < ?", filter.StartDate, filter.EndDate)
+	if filter.OutletID != nil {
+		db = db.Where("outlet_id = ?", *filter.OutletID)
+	}
+
+	var rows []interfaces.OutletStatusRow
+	if err := db.Group("outlet_id, status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CashFlowTotals sums the cash flows dated in [startDate, endDate) per type and outlet of the user who recorded them
+func (r *DashboardRepository) CashFlowTotals(ctx context.Context, startDate, endDate time.Time, outletID *uint) ([]interfaces.OutletCashFlowRow, error) {
+	db := r.db.WithContext(ctx).
+		Table("cash_flows AS cf").
+		Select("u.outlet_id, cf.type, SUM(cf.amount) AS amount").
+		Joins("LEFT JOIN users u ON u.user_id = cf.user_id").
+		Where("cf.deleted_at IS NULL").
+		Where("cf.date >= ? AND cf.date < ?", startDate, endDate)
+	if outletID != nil {
+		db = db.Where("u.outlet_id = ?", *outletID)
+	}
+
+	var rows []interfaces.OutletCashFlowRow
+	if err := db.Group("u.outlet_id, cf.type").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// OverduePayables counts the unpaid payables due before asOf and sums what is left to pay, per outlet of the purchase order
+func (r *DashboardRepository) OverduePayables(ctx context.Context, asOf time.Time, outletID *uint) ([]interfaces.OutletAmountRow, error) {
+	db := r.db.WithContext(ctx).
+		Table("accounts_payables AS ap").
+		Select("po.outlet_id, COUNT(*) AS count, SUM(ap.total_amount - ap.amount_paid) AS amount").
+		Joins("LEFT JOIN purchase_orders po ON po.purchase_order_id = ap.purchase_order_id").
+		Where("ap.deleted_at IS NULL AND ap.status = ? AND ap.due_date < ?", models.APARStatusBelumLunas, asOf)
+	if outletID != nil {
+		db = db.Where("po.outlet_id = ?", *outletID)
+	}
+
+	var rows []interfaces.OutletAmountRow
+	if err := db.Group("po.outlet_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// OverdueReceivables counts the unpaid receivables due before asOf and sums what is left to collect, per outlet of the transaction
+func (r *DashboardRepository) OverdueReceivables(ctx context.Context, asOf time.Time, outletID *uint) ([]interfaces.OutletAmountRow, error) {
+	db := r.db.WithContext(ctx).
+		Table("accounts_receivables AS ar").
+		Select("t.outlet_id, COUNT(*) AS count, SUM(ar.total_amount - ar.amount_paid) AS amount").
+		Joins("LEFT JOIN transactions t ON t.transaction_id = ar.transaction_id").
+		Where("ar.deleted_at IS NULL AND ar.status = ? AND ar.due_date < ?", models.APARStatusBelumLunas, asOf)
+	if outletID != nil {
+		db = db.Where("t.outlet_id = ?", *outletID)
+	}
+
+	var rows []interfaces.OutletAmountRow
+	if err := db.Group("t.outlet_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// LowStockCount counts the products whose stock is at or below their min stock or the threshold, like
+// ProductRepository.GetLowStock
+func (r *DashboardRepository) LowStockCount(ctx context.Context, threshold int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Where(lowStockCondition, threshold).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,105 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ServiceEstimateRepository implements the service estimate repository interface
+type ServiceEstimateRepository struct {
+	db *gorm.DB
+}
+
+// NewServiceEstimateRepository creates a new service estimate repository
+func NewServiceEstimateRepository(db *gorm.DB) interfaces.ServiceEstimateRepository {
+	return &ServiceEstimateRepository{db: db}
+}
+
+// CreateRevision stores the estimate as the next version of its job. Two revisions created at the same time
+// get the same version, the unique index on job and version rejects the second.
+func (r *ServiceEstimateRepository) CreateRevision(ctx context.Context, estimate *models.ServiceEstimate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var version int
+		err := tx.Model(&models.ServiceEstimate{}).
+			Where("service_job_id = ?", estimate.ServiceJobID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&version).Error
+		if err != nil {
+			return err
+		}
+		estimate.Version = version + 1
+
+		err = tx.Model(&models.ServiceEstimate{}).
+			Where("service_job_id = ? AND status = ?", estimate.ServiceJobID, models.EstimateStatusMenunggu).
+			Update("status", models.EstimateStatusDigantikan).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(estimate).Error
+	})
+}
+
+// GetByID retrieves an estimate by ID with its items
+func (r *ServiceEstimateRepository) GetByID(ctx context.Context, id uint) (*models.ServiceEstimate, error) {
+	var estimate models.ServiceEstimate
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("estimate_item_id ASC")
+		}).
+		First(&estimate, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
+
+// GetByServiceJobID retrieves the revisions of a job with their items, newest first
+func (r *ServiceEstimateRepository) GetByServiceJobID(ctx context.Context, serviceJobID uint) ([]*models.ServiceEstimate, error) {
+	var estimates []*models.ServiceEstimate
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("estimate_item_id ASC")
+		}).
+		Where("service_job_id = ?", serviceJobID).
+		Order("version DESC").
+		Find(&estimates).Error
+	if err != nil {
+		return nil, err
+	}
+	return estimates, nil
+}
+
+// GetLatestApproved retrieves the newest approved revision of a job, nil when none is approved
+func (r *ServiceEstimateRepository) GetLatestApproved(ctx context.Context, serviceJobID uint) (*models.ServiceEstimate, error) {
+	var estimates []*models.ServiceEstimate
+	err := r.db.WithContext(ctx).
+		Where("service_job_id = ? AND status = ?", serviceJobID, models.EstimateStatusDisetujui).
+		Order("version DESC").
+		Limit(1).
+		Find(&estimates).Error
+	if err != nil || len(estimates) == 0 {
+		return nil, err
+	}
+	return estimates[0], nil
+}
+
+// Decide stores the decision of a revision still waiting for the customer; the status condition makes sure a
+// revision is decided once and a replaced one not at all
+func (r *ServiceEstimateRepository) Decide(ctx context.Context, estimate *models.ServiceEstimate) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ServiceEstimate{}).
+		Where("estimate_id = ? AND status = ?", estimate.EstimateID, models.EstimateStatusMenunggu).
+		Updates(map[string]interface{}{
+			"status":             estimate.Status,
+			"decided_at":         estimate.DecidedAt,
+			"decision_channel":   estimate.DecisionChannel,
+			"decided_by_name":    estimate.DecidedByName,
+			"decided_by_user_id": estimate.DecidedByUserID,
+			"decision_note":      estimate.DecisionNote,
+			"decision_ip":        estimate.DecisionIP,
+		})
+	return result.RowsAffected == 1, result.Error
+}
@@ -3,6 +3,7 @@ package implementations
 import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
 	"context"
 	"time"
 
@@ -54,14 +55,25 @@ func (r *PaymentMethodRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.PaymentMethod{}, id).Error
 }
 
-// List retrieves payment methods with pagination
-func (r *PaymentMethodRepository) List(ctx context.Context, limit, offset int) ([]*models.PaymentMethod, error) {
+// paymentMethodListFields are the columns payment methods can be filtered and sorted by
+var paymentMethodListFields = query.Fields{
+	Key:         "method_id",
+	DefaultSort: "name",
+	Allowed: map[string]query.FieldType{
+		"name":       query.String,
+		"status":     query.String,
+		"created_at": query.Time,
+	},
+}
+
+// List retrieves payment methods matching the list query
+func (r *PaymentMethodRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.PaymentMethod, int64, error) {
 	var paymentMethods []*models.PaymentMethod
-	err := r.db.WithContext(ctx).Preload("Payments").Limit(limit).Offset(offset).Find(&paymentMethods).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.PaymentMethod{}), q, paymentMethodListFields, &paymentMethods, "Payments")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return paymentMethods, nil
+	return paymentMethods, total, nil
 }
 
 // GetByStatus retrieves payment methods by status
@@ -112,19 +124,28 @@ func (r *PaymentRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Payment{}, id).Error
 }
 
-// List retrieves payments with pagination
-func (r *PaymentRepository) List(ctx context.Context, limit, offset int) ([]*models.Payment, error) {
+// paymentListFields are the columns payments can be filtered and sorted by
+var paymentListFields = query.Fields{
+	Key:         "payment_id",
+	DefaultSort: "-created_at",
+	Allowed: map[string]query.FieldType{
+		"transaction_id": query.Number,
+		"method_id":      query.Number,
+		"amount":         query.Number,
+		"status":         query.String,
+		"payment_date":   query.Time,
+		"created_at":     query.Time,
+	},
+}
+
+// List retrieves payments matching the list query
+func (r *PaymentRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Payment, int64, error) {
 	var payments []*models.Payment
-	err := r.db.WithContext(ctx).
-		Preload("Transaction").
-		Preload("PaymentMethod").
-		Limit(limit).
-		Offset(offset).
-		Find(&payments).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Payment{}), q, paymentListFields, &payments, "Transaction", "PaymentMethod")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return payments, nil
+	return payments, total, nil
 }
 
 // GetByTransactionID retrieves payments by transaction ID
@@ -218,18 +239,28 @@ func (r *CashFlowRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.CashFlow{}, id).Error
 }
 
-// List retrieves cash flows with pagination
-func (r *CashFlowRepository) List(ctx context.Context, limit, offset int) ([]*models.CashFlow, error) {
+// cashFlowListFields are the columns cash flows can be filtered and sorted by
+var cashFlowListFields = query.Fields{
+	Key:         "cash_flow_id",
+	DefaultSort: "-date",
+	Allowed: map[string]query.FieldType{
+		"type":       query.String,
+		"source":     query.String,
+		"amount":     query.Number,
+		"date":       query.Time,
+		"user_id":    query.Number,
+		"created_at": query.Time,
+	},
+}
+
+// List retrieves cash flows matching the list query
+func (r *CashFlowRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.CashFlow, int64, error) {
 	var cashFlows []*models.CashFlow
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Limit(limit).
-		Offset(offset).
-		Find(&cashFlows).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.CashFlow{}), q, cashFlowListFields, &cashFlows, "User")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return cashFlows, nil
+	return cashFlows, total, nil
 }
 
 // GetByUserID retrieves cash flows by user ID
@@ -295,4 +326,299 @@ func (r *CashFlowRepository) GetTotalByType(ctx context.Context, flowType models
 		return 0, err
 	}
 	return total, nil
-}
\ No newline at end of file
+}
+// AccountsPayableRepository implements the accounts payable repository interface
+type AccountsPayableRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountsPayableRepository creates a new accounts payable repository
+func NewAccountsPayableRepository(db *gorm.DB) interfaces.AccountsPayableRepository {
+	return &AccountsPayableRepository{db: db}
+}
+
+// Create creates a new accounts payable
+func (r *AccountsPayableRepository) Create(ctx context.Context, payable *models.AccountsPayable) error {
+	return r.db.WithContext(ctx).Create(payable).Error
+}
+
+// GetByID retrieves an accounts payable by ID with its supplier and payments
+func (r *AccountsPayableRepository) GetByID(ctx context.Context, id uint) (*models.AccountsPayable, error) {
+	var payable models.AccountsPayable
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Preload("PayablePayments").
+		First(&payable, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payable, nil
+}
+
+// Update updates an accounts payable
+func (r *AccountsPayableRepository) Update(ctx context.Context, payable *models.AccountsPayable) error {
+	return r.db.WithContext(ctx).Save(payable).Error
+}
+
+// Delete soft deletes an accounts payable
+func (r *AccountsPayableRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.AccountsPayable{}, id).Error
+}
+
+// accountsPayableListFields are the columns payables can be filtered and sorted by
+var accountsPayableListFields = query.Fields{
+	Key:         "payable_id",
+	DefaultSort: "due_date",
+	Allowed: map[string]query.FieldType{
+		"purchase_order_id": query.Number,
+		"supplier_id":       query.Number,
+		"total_amount":      query.Number,
+		"amount_paid":       query.Number,
+		"due_date":          query.Time,
+		"status":            query.String,
+		"overdue_at":        query.Time,
+		"created_at":        query.Time,
+	},
+}
+
+// List retrieves accounts payable matching the list query
+func (r *AccountsPayableRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.AccountsPayable, int64, error) {
+	var payables []*models.AccountsPayable
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.AccountsPayable{}), q, accountsPayableListFields, &payables, "Supplier")
+	if err != nil {
+		return nil, 0, err
+	}
+	return payables, total, nil
+}
+
+// GetByPurchaseOrderID retrieves accounts payable by purchase order ID
+func (r *AccountsPayableRepository) GetByPurchaseOrderID(ctx context.Context, purchaseOrderID uint) ([]*models.AccountsPayable, error) {
+	var payables []*models.AccountsPayable
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Where("purchase_order_id = ?", purchaseOrderID).
+		Find(&payables).Error
+	if err != nil {
+		return nil, err
+	}
+	return payables, nil
+}
+
+// GetBySupplierID retrieves accounts payable by supplier ID
+func (r *AccountsPayableRepository) GetBySupplierID(ctx context.Context, supplierID uint) ([]*models.AccountsPayable, error) {
+	var payables []*models.AccountsPayable
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Where("supplier_id = ?", supplierID).
+		Find(&payables).Error
+	if err != nil {
+		return nil, err
+	}
+	return payables, nil
+}
+
+// GetByStatus retrieves accounts payable by status
+func (r *AccountsPayableRepository) GetByStatus(ctx context.Context, status models.APARStatus) ([]*models.AccountsPayable, error) {
+	var payables []*models.AccountsPayable
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Where("status = ?", status).
+		Find(&payables).Error
+	if err != nil {
+		return nil, err
+	}
+	return payables, nil
+}
+
+// GetOverdue retrieves unpaid accounts payable whose due date is before today
+func (r *AccountsPayableRepository) GetOverdue(ctx context.Context) ([]*models.AccountsPayable, error) {
+	var payables []*models.AccountsPayable
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Where("status = ? AND due_date < ?", models.APARStatusBelumLunas, time.Now().Format("2006-01-02")).
+		Order("due_date ASC").
+		Find(&payables).Error
+	if err != nil {
+		return nil, err
+	}
+	return payables, nil
+}
+
+// UpdateAmountPaid adds a payment to an accounts payable and settles it once fully paid
+func (r *AccountsPayableRepository) UpdateAmountPaid(ctx context.Context, id uint, amount float64) error {
+	return r.db.WithContext(ctx).
+		Model(&models.AccountsPayable{}).
+		Where("payable_id = ?", id).
+		Updates(map[string]interface{}{
+			"amount_paid": gorm.Expr("amount_paid + ?", amount),
+			"status": gorm.Expr("CASE WHEN amount_paid + ? >= total_amount THEN ? ELSE ? END",
+				amount, models.APARStatusLunas, models.APARStatusBelumLunas),
+		}).Error
+}
+
+// MarkOverdue flags the given accounts payable as overdue
+func (r *AccountsPayableRepository) MarkOverdue(ctx context.Context, ids []uint, at time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).
+		Model(&models.AccountsPayable{}).
+		Where("payable_id IN ? AND overdue_at IS NULL", ids).
+		Update("overdue_at", at)
+	return result.RowsAffected, result.Error
+}
+
+// AccountsReceivableRepository implements the accounts receivable repository interface
+type AccountsReceivableRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountsReceivableRepository creates a new accounts receivable repository
+func NewAccountsReceivableRepository(db *gorm.DB) interfaces.AccountsReceivableRepository {
+	return &AccountsReceivableRepository{db: db}
+}
+
+// Create creates a new accounts receivable
+func (r *AccountsReceivableRepository) Create(ctx context.Context, receivable *models.AccountsReceivable) error {
+	return r.db.WithContext(ctx).Create(receivable).Error
+}
+
+// GetByID retrieves an accounts receivable by ID with its customer and payments
+func (r *AccountsReceivableRepository) GetByID(ctx context.Context, id uint) (*models.AccountsReceivable, error) {
+	var receivable models.AccountsReceivable
+	err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Preload("ReceivablePayments").
+		First(&receivable, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &receivable, nil
+}
+
+// Update updates an accounts receivable
+func (r *AccountsReceivableRepository) Update(ctx context.Context, receivable *models.AccountsReceivable) error {
+	return r.db.WithContext(ctx).Save(receivable).Error
+}
+
+// Delete soft deletes an accounts receivable
+func (r *AccountsReceivableRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.AccountsReceivable{}, id).Error
+}
+
+// accountsReceivableListFields are the columns receivables can be filtered and sorted by
+var accountsReceivableListFields = query.Fields{
+	Key:         "receivable_id",
+	DefaultSort: "due_date",
+	Allowed: map[string]query.FieldType{
+		"transaction_id": query.Number,
+		"customer_id":    query.Number,
+		"total_amount":   query.Number,
+		"amount_paid":    query.Number,
+		"due_date":       query.Time,
+		"status":         query.String,
+		"overdue_at":     query.Time,
+		"created_at":     query.Time,
+	},
+}
+
+// List retrieves accounts receivable matching the list query
+func (r *AccountsReceivableRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.AccountsReceivable, int64, error) {
+	var receivables []*models.AccountsReceivable
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.AccountsReceivable{}), q, accountsReceivableListFields, &receivables, "Customer")
+	if err != nil {
+		return nil, 0, err
+	}
+	return receivables, total, nil
+}
+
+// GetByTransactionID retrieves accounts receivable by transaction ID
+func (r *AccountsReceivableRepository) GetByTransactionID(ctx context.Context, transactionID uint) ([]*models.AccountsReceivable, error) {
+	var receivables []*models.AccountsReceivable
+	err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Where("transaction_id = ?", transactionID).
+		Find(&receivables).Error
+	if err != nil {
+		return nil, err
+	}
+	return receivables, nil
+}
+
+// GetByCustomerID retrieves accounts receivable by customer ID
+func (r *AccountsReceivableRepository) GetByCustomerID(ctx context.Context, customerID uint) ([]*models.AccountsReceivable, error) {
+	var receivables []*models.AccountsReceivable
+	err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Where("customer_id = ?", customerID).
+		Find(&receivables).Error
+	if err != nil {
+		return nil, err
+	}
+	return receivables, nil
+}
+
+// GetByStatus retrieves accounts receivable by status
+func (r *AccountsReceivableRepository) GetByStatus(ctx context.Context, status models.APARStatus) ([]*models.AccountsReceivable, error) {
+	var receivables []*models.AccountsReceivable
+	err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Where("status = ?", status).
+		Find(&receivables).Error
+	if err != nil {
+		return nil, err
+	}
+	return receivables, nil
+}
+
+// GetOverdue retrieves unpaid accounts receivable whose due date is before today
+func (r *AccountsReceivableRepository) GetOverdue(ctx context.Context) ([]*models.AccountsReceivable, error) {
+	var receivables []*models.AccountsReceivable
+	err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Where("status = ? AND due_date < ?", models.APARStatusBelumLunas, time.Now().Format("2006-01-02")).
+		Order("due_date ASC").
+		Find(&receivables).Error
+	if err != nil {
+		return nil, err
+	}
+	return receivables, nil
+}
+
+// GetDueBetween retrieves unpaid receivables due in [from, to)
+func (r *AccountsReceivableRepository) GetDueBetween(ctx context.Context, from, to string) ([]*models.AccountsReceivable, error) {
+	var receivables []*models.AccountsReceivable
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND due_date >= ? AND due_date < ?", models.APARStatusBelumLunas, from, to).
+		Order("due_date ASC").
+		Order("receivable_id ASC").
+		Find(&receivables).Error
+	if err != nil {
+		return nil, err
+	}
+	return receivables, nil
+}
+
+// UpdateAmountPaid adds a payment to an accounts receivable and settles it once fully paid
+func (r *AccountsReceivableRepository) UpdateAmountPaid(ctx context.Context, id uint, amount float64) error {
+	return r.db.WithContext(ctx).
+		Model(&models.AccountsReceivable{}).
+		Where("receivable_id = ?", id).
+		Updates(map[string]interface{}{
+			"amount_paid": gorm.Expr("amount_paid + ?", amount),
+			"status": gorm.Expr("CASE WHEN amount_paid + ? >= total_amount THEN ? ELSE ? END",
+				amount, models.APARStatusLunas, models.APARStatusBelumLunas),
+		}).Error
+}
+
+// MarkOverdue flags the given accounts receivable as overdue
+func (r *AccountsReceivableRepository) MarkOverdue(ctx context.Context, ids []uint, at time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).
+		Model(&models.AccountsReceivable{}).
+		Where("receivable_id IN ? AND overdue_at IS NULL", ids).
+		Update("overdue_at", at)
+	return result.RowsAffected, result.Error
+}
@@ -3,6 +3,7 @@ package implementations
 import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
 	"context"
 
 	"gorm.io/gorm"
@@ -48,10 +49,22 @@ func (r *UserRepositoryImpl) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.User{}, id).Error
 }
 
-func (r *UserRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
+// userListFields are the columns users can be filtered and sorted by
+var userListFields = query.Fields{
+	Key:         "user_id",
+	DefaultSort: "name",
+	Allowed: map[string]query.FieldType{
+		"name":       query.String,
+		"email":      query.String,
+		"outlet_id":  query.Number,
+		"created_at": query.Time,
+	},
+}
+
+func (r *UserRepositoryImpl) List(ctx context.Context, q *query.ListQuery) ([]*models.User, int64, error) {
 	var users []*models.User
-	err := r.db.WithContext(ctx).Preload("Outlet").Limit(limit).Offset(offset).Find(&users).Error
-	return users, err
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.User{}), q, userListFields, &users, "Outlet")
+	return users, total, err
 }
 
 func (r *UserRepositoryImpl) GetByOutletID(ctx context.Context, outletID uint) ([]*models.User, error) {
@@ -60,6 +73,24 @@ func (r *UserRepositoryImpl) GetByOutletID(ctx context.Context, outletID uint) (
 	return users, err
 }
 
+func (r *UserRepositoryImpl) AttachRoles(ctx context.Context, userID uint, roleIDs []uint) error {
+	user := models.User{UserID: userID}
+	var roles []models.Role
+	for _, id := range roleIDs {
+		roles = append(roles, models.Role{ID: id})
+	}
+	return r.db.WithContext(ctx).Model(&user).Association("Roles").Append(roles)
+}
+
+func (r *UserRepositoryImpl) DetachRoles(ctx context.Context, userID uint, roleIDs []uint) error {
+	user := models.User{UserID: userID}
+	var roles []models.Role
+	for _, id := range roleIDs {
+		roles = append(roles, models.Role{ID: id})
+	}
+	return r.db.WithContext(ctx).Model(&user).Association("Roles").Delete(roles)
+}
+
 // OutletRepositoryImpl implements OutletRepository interface
 type OutletRepositoryImpl struct {
 	db *gorm.DB
@@ -91,10 +122,23 @@ func (r *OutletRepositoryImpl) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Outlet{}, id).Error
 }
 
-func (r *OutletRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*models.Outlet, error) {
+// outletListFields are the columns outlets can be filtered and sorted by
+var outletListFields = query.Fields{
+	Key:         "outlet_id",
+	DefaultSort: "outlet_name",
+	Allowed: map[string]query.FieldType{
+		"outlet_name": query.String,
+		"branch_type": query.String,
+		"city":        query.String,
+		"status":      query.String,
+		"created_at":  query.Time,
+	},
+}
+
+func (r *OutletRepositoryImpl) List(ctx context.Context, q *query.ListQuery) ([]*models.Outlet, int64, error) {
 	var outlets []*models.Outlet
-	err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&outlets).Error
-	return outlets, err
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Outlet{}), q, outletListFields, &outlets)
+	return outlets, total, err
 }
 
 func (r *OutletRepositoryImpl) GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Outlet, error) {
@@ -143,10 +187,20 @@ func (r *RoleRepositoryImpl) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Role{}, id).Error
 }
 
-func (r *RoleRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*models.Role, error) {
+// roleListFields are the columns roles can be filtered and sorted by
+var roleListFields = query.Fields{
+	Key:         "id",
+	DefaultSort: "name",
+	Allowed: map[string]query.FieldType{
+		"name":       query.String,
+		"created_at": query.Time,
+	},
+}
+
+func (r *RoleRepositoryImpl) List(ctx context.Context, q *query.ListQuery) ([]*models.Role, int64, error) {
 	var roles []*models.Role
-	err := r.db.WithContext(ctx).Preload("Permissions").Limit(limit).Offset(offset).Find(&roles).Error
-	return roles, err
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Role{}), q, roleListFields, &roles, "Permissions")
+	return roles, total, err
 }
 
 func (r *RoleRepositoryImpl) AttachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
@@ -207,10 +261,20 @@ func (r *PermissionRepositoryImpl) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Permission{}, id).Error
 }
 
-func (r *PermissionRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*models.Permission, error) {
+// permissionListFields are the columns permissions can be filtered and sorted by
+var permissionListFields = query.Fields{
+	Key:         "id",
+	DefaultSort: "name",
+	Allowed: map[string]query.FieldType{
+		"name":       query.String,
+		"created_at": query.Time,
+	},
+}
+
+func (r *PermissionRepositoryImpl) List(ctx context.Context, q *query.ListQuery) ([]*models.Permission, int64, error) {
 	var permissions []*models.Permission
-	err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&permissions).Error
-	return permissions, err
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Permission{}), q, permissionListFields, &permissions)
+	return permissions, total, err
 }
 
 func (r *PermissionRepositoryImpl) GetByRoleID(ctx context.Context, roleID uint) ([]*models.Permission, error) {
@@ -3,6 +3,7 @@ package implementations
 import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
 	"context"
 
 	"gorm.io/gorm"
@@ -72,7 +73,18 @@ func (r *ProductRepository) GetByBarcode(ctx context.Context, barcode string) (*
 
 // Update updates a product
 func (r *ProductRepository) Update(ctx context.Context, product *models.Product) error {
-	return r.db.WithContext(ctx).Save(product).Error
+	// Stock and cost method only change through the stock ledger, cost price through SetCostPrice
+	return r.db.WithContext(ctx).Omit("stock", "cost_method", "cost_price").Save(product).Error
+}
+
+// SetCostPrice sets the cost price of a product without stock and reports whether it did. Once there is
+// stock the cost price is the moving average of its receipts and is left alone.
+func (r *ProductRepository) SetCostPrice(ctx context.Context, productID uint, costPrice float64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("product_id = ? AND stock = 0", productID).
+		Update("cost_price", costPrice)
+	return result.RowsAffected > 0, result.Error
 }
 
 // Delete soft deletes a product
@@ -80,21 +92,36 @@ func (r *ProductRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Product{}, id).Error
 }
 
-// List retrieves products with pagination
-func (r *ProductRepository) List(ctx context.Context, limit, offset int) ([]*models.Product, error) {
+// productListFields are the columns products can be filtered and sorted by
+var productListFields = query.Fields{
+	Key:         "product_id",
+	DefaultSort: "product_name",
+	Allowed: map[string]query.FieldType{
+		"product_name":      query.String,
+		"sku":               query.String,
+		"barcode":           query.String,
+		"cost_price":        query.Number,
+		"selling_price":     query.Number,
+		"stock":             query.Number,
+		"has_serial_number": query.Bool,
+		"shelf_location":    query.String,
+		"usage_status":      query.String,
+		"is_active":         query.Bool,
+		"category_id":       query.Number,
+		"supplier_id":       query.Number,
+		"unit_type_id":      query.Number,
+		"created_at":        query.Time,
+	},
+}
+
+// List retrieves products matching the list query
+func (r *ProductRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Product, int64, error) {
 	var products []*models.Product
-	err := r.db.WithContext(ctx).
-		Preload("Category").
-		Preload("Supplier").
-		Preload("UnitType").
-		Preload("SerialNumbers").
-		Limit(limit).
-		Offset(offset).
-		Find(&products).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Product{}), q, productListFields, &products, "Category", "Supplier", "UnitType", "SerialNumbers")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return products, nil
+	return products, total, nil
 }
 
 // GetByCategoryID retrieves products by category ID
@@ -129,22 +156,6 @@ func (r *ProductRepository) GetBySupplierID(ctx context.Context, supplierID uint
 	return products, nil
 }
 
-// GetByUsageStatus retrieves products by usage status
-func (r *ProductRepository) GetByUsageStatus(ctx context.Context, status models.ProductUsageStatus) ([]*models.Product, error) {
-	var products []*models.Product
-	err := r.db.WithContext(ctx).
-		Preload("Category").
-		Preload("Supplier").
-		Preload("UnitType").
-		Preload("SerialNumbers").
-		Where("usage_status = ?", status).
-		Find(&products).Error
-	if err != nil {
-		return nil, err
-	}
-	return products, nil
-}
-
 // Search searches products by name, description, or SKU
 func (r *ProductRepository) Search(ctx context.Context, query string, limit, offset int) ([]*models.Product, error) {
 	var products []*models.Product
@@ -174,22 +185,21 @@ func (r *ProductRepository) UpdateStock(ctx context.Context, productID uint, qua
 		Update("stock", gorm.Expr("stock + ?", quantity)).Error
 }
 
-// GetLowStock retrieves products with stock below threshold
-func (r *ProductRepository) GetLowStock(ctx context.Context, threshold int) ([]*models.Product, error) {
+// GetLowStock retrieves the products matching the list query with stock at or below their min stock,
+// or the threshold when they have none
+func (r *ProductRepository) GetLowStock(ctx context.Context, threshold int, q *query.ListQuery) ([]*models.Product, int64, error) {
 	var products []*models.Product
-	err := r.db.WithContext(ctx).
-		Preload("Category").
-		Preload("Supplier").
-		Preload("UnitType").
-		Preload("SerialNumbers").
-		Where("stock <= ?", threshold).
-		Find(&products).Error
+	db := r.db.WithContext(ctx).Model(&models.Product{}).Where(lowStockCondition, threshold)
+	total, err := query.Find(db, q, productListFields, &products, "Category", "Supplier", "UnitType", "SerialNumbers")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return products, nil
+	return products, total, nil
 }
 
+// lowStockCondition compares the stock with the product's min stock, falling back to the threshold argument
+const lowStockCondition = "stock <= CASE WHEN min_stock > 0 THEN min_stock ELSE ? END"
+
 // ProductSerialNumberRepository implements the product serial number repository interface
 type ProductSerialNumberRepository struct {
 	db *gorm.DB
@@ -235,14 +245,26 @@ func (r *ProductSerialNumberRepository) Delete(ctx context.Context, id uint) err
 	return r.db.WithContext(ctx).Delete(&models.ProductSerialNumber{}, id).Error
 }
 
-// List retrieves product serial numbers with pagination
-func (r *ProductSerialNumberRepository) List(ctx context.Context, limit, offset int) ([]*models.ProductSerialNumber, error) {
+// productSerialNumberListFields are the columns product serial numbers can be filtered and sorted by
+var productSerialNumberListFields = query.Fields{
+	Key:         "serial_number_id",
+	DefaultSort: "serial_number",
+	Allowed: map[string]query.FieldType{
+		"product_id":    query.Number,
+		"serial_number": query.String,
+		"status":        query.String,
+		"created_at":    query.Time,
+	},
+}
+
+// List retrieves product serial numbers matching the list query
+func (r *ProductSerialNumberRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.ProductSerialNumber, int64, error) {
 	var serialNumbers []*models.ProductSerialNumber
-	err := r.db.WithContext(ctx).Preload("Product").Limit(limit).Offset(offset).Find(&serialNumbers).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.ProductSerialNumber{}), q, productSerialNumberListFields, &serialNumbers, "Product")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return serialNumbers, nil
+	return serialNumbers, total, nil
 }
 
 // GetByProductID retrieves product serial numbers by product ID
@@ -318,14 +340,25 @@ func (r *CategoryRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Category{}, id).Error
 }
 
-// List retrieves categories with pagination
-func (r *CategoryRepository) List(ctx context.Context, limit, offset int) ([]*models.Category, error) {
+// categoryListFields are the columns categories can be filtered and sorted by
+var categoryListFields = query.Fields{
+	Key:         "category_id",
+	DefaultSort: "name",
+	Allowed: map[string]query.FieldType{
+		"name":       query.String,
+		"status":     query.String,
+		"created_at": query.Time,
+	},
+}
+
+// List retrieves categories matching the list query
+func (r *CategoryRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Category, int64, error) {
 	var categories []*models.Category
-	err := r.db.WithContext(ctx).Preload("Products").Limit(limit).Offset(offset).Find(&categories).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Category{}), q, categoryListFields, &categories, "Products")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return categories, nil
+	return categories, total, nil
 }
 
 // GetByStatus retrieves categories by status
@@ -383,14 +416,27 @@ func (r *SupplierRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Supplier{}, id).Error
 }
 
-// List retrieves suppliers with pagination
-func (r *SupplierRepository) List(ctx context.Context, limit, offset int) ([]*models.Supplier, error) {
+// supplierListFields are the columns suppliers can be filtered and sorted by
+var supplierListFields = query.Fields{
+	Key:         "supplier_id",
+	DefaultSort: "supplier_name",
+	Allowed: map[string]query.FieldType{
+		"supplier_name":       query.String,
+		"contact_person_name": query.String,
+		"phone_number":        query.String,
+		"status":              query.String,
+		"created_at":          query.Time,
+	},
+}
+
+// List retrieves suppliers matching the list query
+func (r *SupplierRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Supplier, int64, error) {
 	var suppliers []*models.Supplier
-	err := r.db.WithContext(ctx).Preload("Products").Limit(limit).Offset(offset).Find(&suppliers).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Supplier{}), q, supplierListFields, &suppliers, "Products")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return suppliers, nil
+	return suppliers, total, nil
 }
 
 // GetByStatus retrieves suppliers by status
@@ -466,14 +512,25 @@ func (r *UnitTypeRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.UnitType{}, id).Error
 }
 
-// List retrieves unit types with pagination
-func (r *UnitTypeRepository) List(ctx context.Context, limit, offset int) ([]*models.UnitType, error) {
+// unitTypeListFields are the columns unit types can be filtered and sorted by
+var unitTypeListFields = query.Fields{
+	Key:         "unit_type_id",
+	DefaultSort: "name",
+	Allowed: map[string]query.FieldType{
+		"name":       query.String,
+		"status":     query.String,
+		"created_at": query.Time,
+	},
+}
+
+// List retrieves unit types matching the list query
+func (r *UnitTypeRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.UnitType, int64, error) {
 	var unitTypes []*models.UnitType
-	err := r.db.WithContext(ctx).Preload("Products").Limit(limit).Offset(offset).Find(&unitTypes).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.UnitType{}), q, unitTypeListFields, &unitTypes, "Products")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return unitTypes, nil
+	return unitTypes, total, nil
 }
 
 // GetByStatus retrieves unit types by status
@@ -484,4 +541,91 @@ func (r *UnitTypeRepository) GetByStatus(ctx context.Context, status models.Stat
 		return nil, err
 	}
 	return unitTypes, nil
-}
\ No newline at end of file
+}
+// StockMovementRepository implements the stock movement repository interface
+type StockMovementRepository struct {
+	db *gorm.DB
+}
+
+// NewStockMovementRepository creates a new stock movement repository
+func NewStockMovementRepository(db *gorm.DB) interfaces.StockMovementRepository {
+	return &StockMovementRepository{db: db}
+}
+
+// Record stores a stock movement and applies its quantity to the product stock atomically
+func (r *StockMovementRepository) Record(ctx context.Context, movement *models.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(movement).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Product{}).
+			Where("product_id = ?", movement.ProductID).
+			Update("stock", gorm.Expr("stock + ?", movement.Quantity)).Error
+	})
+}
+
+// GetByID retrieves a stock movement by ID
+func (r *StockMovementRepository) GetByID(ctx context.Context, id uint) (*models.StockMovement, error) {
+	var movement models.StockMovement
+	err := r.db.WithContext(ctx).Preload("Product").First(&movement, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &movement, nil
+}
+
+// GetByProductID retrieves the stock movements of a product, newest first
+func (r *StockMovementRepository) GetByProductID(ctx context.Context, productID uint, limit, offset int) ([]*models.StockMovement, error) {
+	var movements []*models.StockMovement
+	err := r.db.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Order("created_at DESC").Order("movement_id DESC").
+		Limit(limit).Offset(offset).
+		Find(&movements).Error
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// GetStockTotals sums the movement quantities per product
+func (r *StockMovementRepository) GetStockTotals(ctx context.Context) (map[uint]int, error) {
+	var rows []struct {
+		ProductID uint
+		Total     int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.StockMovement{}).
+		Select("product_id, COALESCE(SUM(quantity), 0) AS total").
+		Group("product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		totals[row.ProductID] = row.Total
+	}
+	return totals, nil
+}
+
+// GetOpeningBalances returns the products with an opening entry: the balance seeded when the ledger was created
+// or the opening stock of a new product
+func (r *StockMovementRepository) GetOpeningBalances(ctx context.Context) (map[uint]bool, error) {
+	var productIDs []uint
+	err := r.db.WithContext(ctx).
+		Model(&models.StockMovement{}).
+		Where("reference_type = ?", models.StockReferenceOpening).
+		Distinct("product_id").
+		Pluck("product_id", &productIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	opening := make(map[uint]bool, len(productIDs))
+	for _, productID := range productIDs {
+		opening[productID] = true
+	}
+	return opening, nil
+}
@@ -0,0 +1,118 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LabourRepository implements the labour repository interface
+type LabourRepository struct {
+	db *gorm.DB
+}
+
+// NewLabourRepository creates a new labour repository
+func NewLabourRepository(db *gorm.DB) interfaces.LabourRepository {
+	return &LabourRepository{db: db}
+}
+
+// Create stores a labour entry
+func (r *LabourRepository) Create(ctx context.Context, entry *models.LabourEntry) error {
+	return r.db.WithContext(ctx).Omit("Technician").Create(entry).Error
+}
+
+// Close ends the entry unless another request ended it first
+func (r *LabourRepository) Close(ctx context.Context, entry *models.LabourEntry) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.LabourEntry{}).
+		Where("entry_id = ? AND ended_at IS NULL", entry.EntryID).
+		Updates(map[string]interface{}{
+			"ended_at": entry.EndedAt,
+			"finished": entry.Finished,
+			"notes":    entry.Notes,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ClosePauses ends the open pauses of the job, or of the line and the whole job
+func (r *LabourRepository) ClosePauses(ctx context.Context, serviceJobID uint, detailID *uint, at time.Time) error {
+	db := r.db.WithContext(ctx).
+		Model(&models.LabourEntry{}).
+		Where("service_job_id = ? AND kind = ? AND ended_at IS NULL", serviceJobID, models.LabourEntryJeda)
+	if detailID != nil {
+		db = db.Where("(detail_id = ? OR detail_id IS NULL)", *detailID)
+	}
+	return db.Update("ended_at", at).Error
+}
+
+// GetOpenWork retrieves the open Kerja entry of a technician
+func (r *LabourRepository) GetOpenWork(ctx context.Context, technicianID uint) (*models.LabourEntry, error) {
+	var entry models.LabourEntry
+	err := r.db.WithContext(ctx).
+		Where("technician_id = ? AND kind = ? AND ended_at IS NULL", technicianID, models.LabourEntryKerja).
+		First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetByServiceJobIDs retrieves the entries of the jobs with their technician, by start
+func (r *LabourRepository) GetByServiceJobIDs(ctx context.Context, serviceJobIDs []uint) ([]*models.LabourEntry, error) {
+	var entries []*models.LabourEntry
+	if len(serviceJobIDs) == 0 {
+		return entries, nil
+	}
+	err := r.db.WithContext(ctx).
+		Preload("Technician").
+		Where("service_job_id IN ?", serviceJobIDs).
+		Order("started_at ASC").
+		Order("entry_id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetStartedBetween retrieves the entries started in [from, to) with their technician, of the jobs of an outlet
+func (r *LabourRepository) GetStartedBetween(ctx context.Context, from, to time.Time, outletID *uint) ([]*models.LabourEntry, error) {
+	db := r.db.WithContext(ctx).
+		Preload("Technician").
+		Where("labour_entries.started_at >= ? AND labour_entries.started_at < ?", from, to)
+	if outletID != nil {
+		db = db.Joins("JOIN service_jobs j ON j.service_job_id = labour_entries.service_job_id").
+			Where("j.outlet_id = ?", *outletID)
+	}
+
+	var entries []*models.LabourEntry
+	if err := db.Order("labour_entries.started_at ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetServiceLines retrieves the service lines of the jobs with the standard time of their service
+func (r *LabourRepository) GetServiceLines(ctx context.Context, serviceJobIDs []uint) ([]interfaces.LabourLineRow, error) {
+	var rows []interfaces.LabourLineRow
+	if len(serviceJobIDs) == 0 {
+		return rows, nil
+	}
+	err := r.db.WithContext(ctx).
+		Table("service_details sd").
+		Select("sd.detail_id, sd.service_job_id, s.service_id, s.name AS service_name, sd.description, sd.quantity, s.standard_minutes").
+		Joins("JOIN services s ON s.service_id = sd.item_id").
+		Where("sd.item_type = ? AND sd.service_job_id IN ?", "service", serviceJobIDs).
+		Order("sd.detail_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,196 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRepository implements the notification repository interface
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) interfaces.NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create queues a notification, a notification with the same dedupe key is left as it is
+func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) (bool, error) {
+	db := r.db.WithContext(ctx)
+	if notification.DedupeKey != nil {
+		db = db.Clauses(clause.OnConflict{DoNothing: true})
+	}
+	result := db.Create(notification)
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetByID retrieves a notification by ID with its customer
+func (r *NotificationRepository) GetByID(ctx context.Context, id uint) (*models.Notification, error) {
+	var notification models.Notification
+	err := r.db.WithContext(ctx).Preload("Customer").First(&notification, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// notificationListFields are the columns notifications can be filtered and sorted by
+var notificationListFields = query.Fields{
+	Key:         "notification_id",
+	DefaultSort: "-created_at",
+	Allowed: map[string]query.FieldType{
+		"customer_id":    query.Number,
+		"channel":        query.String,
+		"event":          query.String,
+		"recipient":      query.String,
+		"status":         query.String,
+		"attempts":       query.Number,
+		"service_job_id": query.Number,
+		"receivable_id":  query.Number,
+		"sent_at":        query.Time,
+		"created_at":     query.Time,
+	},
+}
+
+// List retrieves notifications matching the list query
+func (r *NotificationRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Notification, int64, error) {
+	var notifications []*models.Notification
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Notification{}), q, notificationListFields, &notifications, "Customer")
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// ClaimNext moves the oldest due Pending notification to Diproses and counts the attempt.
+// The status condition on the update makes the claim safe between workers and instances.
+func (r *NotificationRepository) ClaimNext(ctx context.Context, now time.Time) (*models.Notification, error) {
+	for i := 0; i < 3; i++ {
+		// Find instead of First, an empty queue is the normal case and not worth a "record not found" log line
+		var notifications []models.Notification
+		err := r.db.WithContext(ctx).
+			Where("status = ?", models.NotificationStatusPending).
+			Where("(next_attempt_at IS NULL OR next_attempt_at <= ?)", now.UTC()).
+			Order("created_at ASC").
+			Order("notification_id ASC").
+			Limit(1).
+			Find(&notifications).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(notifications) == 0 {
+			return nil, nil
+		}
+		notification := notifications[0]
+
+		result := r.db.WithContext(ctx).
+			Model(&models.Notification{}).
+			Where("notification_id = ? AND status = ?", notification.NotificationID, models.NotificationStatusPending).
+			Updates(map[string]interface{}{
+				"status":   models.NotificationStatusDiproses,
+				"attempts": gorm.Expr("attempts + 1"),
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			notification.Status = models.NotificationStatusDiproses
+			notification.Attempts++
+			return &notification, nil
+		}
+		// Claimed by another worker in between, try the next one
+	}
+	return nil, nil
+}
+
+// MarkSent records the delivery of a notification
+func (r *NotificationRepository) MarkSent(ctx context.Context, id uint, providerMessageID string, sentAt time.Time) error {
+	updates := map[string]interface{}{
+		"status":          models.NotificationStatusTerkirim,
+		"sent_at":         sentAt,
+		"error_message":   nil,
+		"next_attempt_at": nil,
+	}
+	if providerMessageID != "" {
+		updates["provider_message_id"] = providerMessageID
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("notification_id = ?", id).
+		Updates(updates).Error
+}
+
+// MarkFailed records a failed attempt: with retryAt the notification is queued again, otherwise it fails for good
+func (r *NotificationRepository) MarkFailed(ctx context.Context, id uint, message string, retryAt *time.Time) error {
+	status := models.NotificationStatusGagal
+	if retryAt != nil {
+		status = models.NotificationStatusPending
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("notification_id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"error_message":   message,
+			"next_attempt_at": utcTime(retryAt),
+		}).Error
+}
+
+// Requeue puts a Gagal notification back into the queue with fresh attempts
+func (r *NotificationRepository) Requeue(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("notification_id = ? AND status = ?", id, models.NotificationStatusGagal).
+		Updates(map[string]interface{}{
+			"status":          models.NotificationStatusPending,
+			"attempts":        0,
+			"next_attempt_at": nil,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// RequeueStale puts notifications left in Diproses since before, e.g. by a crashed worker, back into the queue
+func (r *NotificationRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("status = ? AND updated_at < ?", models.NotificationStatusDiproses, before.UTC()).
+		Update("status", models.NotificationStatusPending)
+	return result.RowsAffected, result.Error
+}
+
+// GetOptOuts retrieves the channels a customer opted out of
+func (r *NotificationRepository) GetOptOuts(ctx context.Context, customerID uint) ([]models.NotificationOptOut, error) {
+	var optOuts []models.NotificationOptOut
+	err := r.db.WithContext(ctx).
+		Where("customer_id = ?", customerID).
+		Order("channel ASC").
+		Find(&optOuts).Error
+	if err != nil {
+		return nil, err
+	}
+	return optOuts, nil
+}
+
+// SetOptOut adds or removes the opt-out of a customer from a channel, setting the current state again changes nothing
+func (r *NotificationRepository) SetOptOut(ctx context.Context, customerID uint, channel models.NotificationChannel, optOut bool, by *uint) error {
+	if !optOut {
+		return r.db.WithContext(ctx).
+			Where("customer_id = ? AND channel = ?", customerID, channel).
+			Delete(&models.NotificationOptOut{}).Error
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.NotificationOptOut{
+			CustomerID: customerID,
+			Channel:    channel,
+			CreatedAt:  time.Now(),
+			CreatedBy:  by,
+		}).Error
+}
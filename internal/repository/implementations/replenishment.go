@@ -0,0 +1,159 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReplenishmentRepository implements the replenishment repository interface. Products with stock levels are
+// planned per outlet from the stock ledger, the others against Product.Stock at their own min stock.
+type ReplenishmentRepository struct {
+	db *gorm.DB
+}
+
+// NewReplenishmentRepository creates a new replenishment repository
+func NewReplenishmentRepository(db *gorm.DB) interfaces.ReplenishmentRepository {
+	return &ReplenishmentRepository{db: db}
+}
+
+// Candidates retrieves the active products that have a reorder point, ordered by product and outlet
+func (r *ReplenishmentRepository) Candidates(ctx context.Context, outletID *uint) ([]interfaces.ReplenishmentCandidate, error) {
+	ledger := r.db.
+		Table("stock_movements").
+		Select("product_id, outlet_id, SUM(quantity) AS quantity").
+		Where("outlet_id IS NOT NULL").
+		Group("product_id, outlet_id")
+
+	db := r.db.WithContext(ctx).
+		Table("product_stock_levels AS l").
+		Select("l.outlet_id, p.product_id, p.product_name, p.sku, p.supplier_id, s.supplier_name, p.cost_price, "+
+			"l.min_stock, l.reorder_quantity, COALESCE(m.quantity, 0) AS on_hand").
+		Joins("JOIN products p ON p.product_id = l.product_id").
+		Joins("LEFT JOIN suppliers s ON s.supplier_id = p.supplier_id").
+		Joins("LEFT JOIN (?) m ON m.product_id = l.product_id AND m.outlet_id = l.outlet_id", ledger).
+		Where("p.deleted_at IS NULL AND p.is_active = ? AND l.min_stock > 0", true)
+	if outletID != nil {
+		db = db.Where("l.outlet_id = ?", *outletID)
+	}
+
+	var candidates []interfaces.ReplenishmentCandidate
+	if err := db.Order("p.product_id").Order("l.outlet_id").Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+	if outletID != nil {
+		return candidates, nil
+	}
+
+	// Products without stock levels are planned against their total stock
+	var global []interfaces.ReplenishmentCandidate
+	err := r.db.WithContext(ctx).
+		Table("products AS p").
+		Select("p.product_id, p.product_name, p.sku, p.supplier_id, s.supplier_name, p.cost_price, "+
+			"p.min_stock, p.reorder_quantity, p.stock AS on_hand").
+		Joins("LEFT JOIN suppliers s ON s.supplier_id = p.supplier_id").
+		Where("p.deleted_at IS NULL AND p.is_active = ? AND p.min_stock > 0", true).
+		Where("NOT EXISTS (SELECT 1 FROM product_stock_levels l WHERE l.product_id = p.product_id)").
+		Order("p.product_id").
+		Scan(&global).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(candidates, global...), nil
+}
+
+// Consumption sums the sale and service movements since the given time; issues are negative in the ledger and
+// returns positive, so the net sum is negated
+func (r *ReplenishmentRepository) Consumption(ctx context.Context, since time.Time, outletID *uint) ([]interfaces.ProductOutletQuantity, error) {
+	db := r.db.WithContext(ctx).
+		Table("stock_movements").
+		Select("product_id, outlet_id, -SUM(quantity) AS quantity").
+		Where("movement_type IN ? AND created_at >= ?",
+			[]models.StockMovementType{models.StockMovementSale, models.StockMovementService}, since)
+	if outletID != nil {
+		db = db.Where("outlet_id = ?", *outletID)
+	}
+
+	var rows []interfaces.ProductOutletQuantity
+	if err := db.Group("product_id, outlet_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// OnOrder sums the details of the draft and pending purchase orders
+func (r *ReplenishmentRepository) OnOrder(ctx context.Context, outletID *uint) ([]interfaces.ProductOutletQuantity, error) {
+	db := r.db.WithContext(ctx).
+		Table("purchase_order_details AS d").
+		Select("d.product_id, po.outlet_id, SUM(d.quantity) AS quantity").
+		Joins("JOIN purchase_orders po ON po.purchase_order_id = d.purchase_order_id").
+		Where("po.status IN ?", []models.PurchaseStatus{models.PurchaseStatusDraft, models.PurchaseStatusPending})
+	if outletID != nil {
+		db = db.Where("po.outlet_id = ?", *outletID)
+	}
+
+	var rows []interfaces.ProductOutletQuantity
+	if err := db.Group("d.product_id, po.outlet_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Committed sums the special orders on order or reserved for service jobs
+func (r *ReplenishmentRepository) Committed(ctx context.Context, outletID *uint) ([]interfaces.ProductOutletQuantity, error) {
+	db := r.db.WithContext(ctx).
+		Table("special_orders").
+		Select("product_id, outlet_id, SUM(quantity) AS quantity").
+		Where("status IN ?", []models.SpecialOrderStatus{models.SpecialOrderDipesan, models.SpecialOrderDicadangkan})
+	if outletID != nil {
+		db = db.Where("outlet_id = ?", *outletID)
+	}
+
+	var rows []interfaces.ProductOutletQuantity
+	if err := db.Group("product_id, outlet_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetStockLevels retrieves the stock levels of a product ordered by outlet
+func (r *ReplenishmentRepository) GetStockLevels(ctx context.Context, productID uint) ([]*models.ProductStockLevel, error) {
+	var levels []*models.ProductStockLevel
+	err := r.db.WithContext(ctx).
+		Preload("Outlet").
+		Where("product_id = ?", productID).
+		Order("outlet_id").
+		Find(&levels).Error
+	if err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
+
+// SaveStockLevel upserts the stock level on its product and outlet
+func (r *ReplenishmentRepository) SaveStockLevel(ctx context.Context, level *models.ProductStockLevel) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}, {Name: "outlet_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"min_stock", "reorder_quantity", "updated_at"}),
+		}).
+		Create(level).Error
+}
+
+// DeleteStockLevel deletes the stock level of a product at an outlet
+func (r *ReplenishmentRepository) DeleteStockLevel(ctx context.Context, productID, outletID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("product_id = ? AND outlet_id = ?", productID, outletID).
+		Delete(&models.ProductStockLevel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
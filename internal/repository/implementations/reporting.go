@@ -0,0 +1,409 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportRepository implements the report repository interface
+type ReportRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRepository creates a new report repository
+func NewReportRepository(db *gorm.DB) interfaces.ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// Create creates a new report
+func (r *ReportRepository) Create(ctx context.Context, report *models.Report) error {
+	return r.db.WithContext(ctx).Create(report).Error
+}
+
+// GetByID retrieves a report by ID
+func (r *ReportRepository) GetByID(ctx context.Context, id uint) (*models.Report, error) {
+	var report models.Report
+	err := r.db.WithContext(ctx).
+		Preload("Outlet").
+		Preload("User").
+		First(&report, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetByName retrieves a report by name
+func (r *ReportRepository) GetByName(ctx context.Context, name string) (*models.Report, error) {
+	var report models.Report
+	err := r.db.WithContext(ctx).Where("report_name = ?", name).First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Update updates a report
+func (r *ReportRepository) Update(ctx context.Context, report *models.Report) error {
+	return r.db.WithContext(ctx).Save(report).Error
+}
+
+// Delete deletes a report
+func (r *ReportRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Report{}, id).Error
+}
+
+// reportListFields are the columns reports can be filtered and sorted by
+var reportListFields = query.Fields{
+	Key:         "report_id",
+	DefaultSort: "-created_at",
+	Allowed: map[string]query.FieldType{
+		"report_name": query.String,
+		"report_type": query.String,
+		"format":      query.String,
+		"status":      query.String,
+		"start_date":  query.Time,
+		"end_date":    query.Time,
+		"outlet_id":   query.Number,
+		"user_id":     query.Number,
+		"created_at":  query.Time,
+	},
+}
+
+// List retrieves reports matching the list query
+func (r *ReportRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Report, int64, error) {
+	var reports []*models.Report
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Report{}), q, reportListFields, &reports, "Outlet")
+	return reports, total, err
+}
+
+// GetByUserID retrieves the reports requested by a user
+func (r *ReportRepository) GetByUserID(ctx context.Context, userID uint) ([]*models.Report, error) {
+	var reports []*models.Report
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetByOutletID retrieves the reports of an outlet
+func (r *ReportRepository) GetByOutletID(ctx context.Context, outletID uint) ([]*models.Report, error) {
+	var reports []*models.Report
+	err := r.db.WithContext(ctx).Where("outlet_id = ?", outletID).Order("created_at DESC").Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetByType retrieves reports by type
+func (r *ReportRepository) GetByType(ctx context.Context, reportType models.ReportTypeEnum) ([]*models.Report, error) {
+	var reports []*models.Report
+	err := r.db.WithContext(ctx).Where("report_type = ?", reportType).Order("created_at DESC").Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetByStatus retrieves reports by status
+func (r *ReportRepository) GetByStatus(ctx context.Context, status models.ReportStatus) ([]*models.Report, error) {
+	var reports []*models.Report
+	err := r.db.WithContext(ctx).Where("status = ?", status).Order("created_at ASC").Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetByDateRange retrieves reports whose period overlaps the date range
+func (r *ReportRepository) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Report, error) {
+	var reports []*models.Report
+	err := r.db.WithContext(ctx).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Order("created_at DESC").
+		Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// UpdateStatus updates the status of a report
+func (r *ReportRepository) UpdateStatus(ctx context.Context, id uint, status models.ReportStatus) error {
+	return r.db.WithContext(ctx).Model(&models.Report{}).Where("report_id = ?", id).Update("status", status).Error
+}
+
+// UpdateFilePath updates the generated file path of a report
+func (r *ReportRepository) UpdateFilePath(ctx context.Context, id uint, filePath string) error {
+	return r.db.WithContext(ctx).Model(&models.Report{}).Where("report_id = ?", id).Update("generated_file_path", filePath).Error
+}
+
+// ClaimNext moves the oldest due Pending report to Diproses and counts the attempt.
+// The status condition on the update makes the claim safe between workers and instances;
+// it returns nil when no report is due.
+func (r *ReportRepository) ClaimNext(ctx context.Context, now time.Time) (*models.Report, error) {
+	for i := 0; i < 3; i++ {
+		// Find instead of First, an empty queue is the normal case and not worth a "record not found" log line
+		var reports []models.Report
+		err := r.db.WithContext(ctx).
+			Where("status = ?", models.ReportStatusPending).
+			Where("(next_attempt_at IS NULL OR next_attempt_at <= ?)", now).
+			Order("created_at ASC").
+			Order("report_id ASC").
+			Limit(1).
+			Find(&reports).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(reports) == 0 {
+			return nil, nil
+		}
+		report := reports[0]
+
+		result := r.db.WithContext(ctx).
+			Model(&models.Report{}).
+			Where("report_id = ? AND status = ?", report.ReportID, models.ReportStatusPending).
+			Updates(map[string]interface{}{
+				"status":   models.ReportStatusDiproses,
+				"attempts": gorm.Expr("attempts + 1"),
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			report.Status = models.ReportStatusDiproses
+			report.Attempts++
+			return &report, nil
+		}
+		// Claimed by another worker in between, try the next one
+	}
+	return nil, nil
+}
+
+// MarkCompleted stores the generated file and finishes the report
+func (r *ReportRepository) MarkCompleted(ctx context.Context, id uint, filePath string, completedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Report{}).
+		Where("report_id = ?", id).
+		Updates(map[string]interface{}{
+			"status":              models.ReportStatusSelesai,
+			"generated_file_path": filePath,
+			"completed_at":        completedAt,
+			"error_message":       nil,
+			"next_attempt_at":     nil,
+		}).Error
+}
+
+// MarkFailed records a failed attempt: with retryAt the report is queued again, otherwise it fails for good
+func (r *ReportRepository) MarkFailed(ctx context.Context, id uint, message string, retryAt *time.Time) error {
+	status := models.ReportStatusGagal
+	if retryAt != nil {
+		status = models.ReportStatusPending
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.Report{}).
+		Where("report_id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"error_message":   message,
+			"next_attempt_at": retryAt,
+		}).Error
+}
+
+// RequeueStale puts reports left in Diproses since before, e.g. by a crashed worker, back into the queue
+func (r *ReportRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Report{}).
+		Where("status = ? AND updated_at < ?", models.ReportStatusDiproses, before).
+		Update("status", models.ReportStatusPending)
+	return result.RowsAffected, result.Error
+}
+
+// SalesRows retrieves the transactions of the period with their item count and total
+func (r *ReportRepository) SalesRows(ctx context.Context, filter interfaces.ReportFilter) ([]interfaces.SalesReportRow, error) {
+	var rows []interfaces.SalesReportRow
+	db := r.db.WithContext(ctx).
+		Table("transactions AS t").
+		Select(`t.transaction_id, t.invoice_number, t.transaction_date, t.transaction_type, t.status,
+			COALESCE(o.outlet_name, '') AS outlet_name,
+			COALESCE(u.name, '') AS cashier_name,
+			COALESCE(c.name, '') AS customer_name,
+			COALESCE(SUM(d.quantity), 0) AS item_count,
+			COALESCE(SUM(d.total_price), 0) AS total`).
+		Joins("LEFT JOIN outlets o ON o.outlet_id = t.outlet_id").
+		Joins("LEFT JOIN users u ON u.user_id = t.user_id").
+		Joins("LEFT JOIN customers c ON c.customer_id = t.customer_id").
+		Joins("LEFT JOIN transaction_details d ON d.transaction_id = t.transaction_id AND d.deleted_at IS NULL").
+		Where("t.deleted_at IS NULL").
+		Where("t.transaction_date >= ? AND t.transaction_date < ?", filter.StartDate, filter.EndDate)
+	if filter.OutletID != nil {
+		db = db.Where("t.outlet_id = ?", *filter.OutletID)
+	}
+	err := db.
+		Group("t.transaction_id, t.invoice_number, t.transaction_date, t.transaction_type, t.status, o.outlet_name, u.name, c.name").
+		Order("t.transaction_date, t.transaction_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CashFlowRows retrieves the cash flows of the period; cash flows belong to an outlet through the recording user
+func (r *ReportRepository) CashFlowRows(ctx context.Context, filter interfaces.ReportFilter) ([]interfaces.CashFlowReportRow, error) {
+	var rows []interfaces.CashFlowReportRow
+	db := r.db.WithContext(ctx).
+		Table("cash_flows AS cf").
+		Select(`cf.cash_flow_id, cf.date, cf.type, cf.source, COALESCE(cf.notes, '') AS notes,
+			COALESCE(u.name, '') AS user_name, cf.amount`).
+		Joins("LEFT JOIN users u ON u.user_id = cf.user_id").
+		Where("cf.deleted_at IS NULL").
+		Where("cf.date >= ? AND cf.date < ?", filter.StartDate, filter.EndDate)
+	if filter.OutletID != nil {
+		db = db.Where("u.outlet_id = ?", *filter.OutletID)
+	}
+	err := db.Order("cf.date, cf.cash_flow_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// InventoryRows retrieves the stock of every product with the ledger quantities moved in and out during the period
+func (r *ReportRepository) InventoryRows(ctx context.Context, filter interfaces.ReportFilter) ([]interfaces.InventoryReportRow, error) {
+	movements := r.db.
+		Table("stock_movements").
+		Select(`product_id,
+			SUM(CASE WHEN quantity > 0 THEN quantity ELSE 0 END) AS quantity_in,
+			SUM(CASE WHEN quantity < 0 THEN -quantity ELSE 0 END) AS quantity_out`).
+		Where("created_at >= ? AND created_at < ?", filter.StartDate, filter.EndDate)
+	if filter.OutletID != nil {
+		movements = movements.Where("outlet_id = ?", *filter.OutletID)
+	}
+	movements = movements.Group("product_id")
+
+	var rows []interfaces.InventoryReportRow
+	err := r.db.WithContext(ctx).
+		Table("products AS p").
+		Select(`p.product_id, p.product_name, COALESCE(p.sku, '') AS sku, COALESCE(cat.name, '') AS category_name,
+			p.stock, p.cost_price, p.selling_price,
+			COALESCE(m.quantity_in, 0) AS quantity_in, COALESCE(m.quantity_out, 0) AS quantity_out`).
+		Joins("LEFT JOIN categories cat ON cat.category_id = p.category_id").
+		Joins("LEFT JOIN (?) AS m ON m.product_id = p.product_id", movements).
+		Where("p.deleted_at IS NULL").
+		Order("p.product_name, p.product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// PromotionRepository implements the promotion repository interface
+type PromotionRepository struct {
+	db *gorm.DB
+}
+
+// NewPromotionRepository creates a new promotion repository
+func NewPromotionRepository(db *gorm.DB) interfaces.PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+// Create creates a new promotion
+func (r *PromotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	return r.db.WithContext(ctx).Create(promotion).Error
+}
+
+// GetByID retrieves a promotion by ID
+func (r *PromotionRepository) GetByID(ctx context.Context, id uint) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.db.WithContext(ctx).First(&promotion, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// GetByName retrieves a promotion by name
+func (r *PromotionRepository) GetByName(ctx context.Context, name string) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.db.WithContext(ctx).Where("promotion_name = ?", name).First(&promotion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// Update updates a promotion
+func (r *PromotionRepository) Update(ctx context.Context, promotion *models.Promotion) error {
+	return r.db.WithContext(ctx).Save(promotion).Error
+}
+
+// Delete soft deletes a promotion
+func (r *PromotionRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Promotion{}, id).Error
+}
+
+// promotionListFields are the columns promotions can be filtered and sorted by
+var promotionListFields = query.Fields{
+	Key:         "promotion_id",
+	DefaultSort: "-start_date",
+	Allowed: map[string]query.FieldType{
+		"promotion_name": query.String,
+		"type":           query.String,
+		"value":          query.Number,
+		"start_date":     query.Time,
+		"end_date":       query.Time,
+		"created_at":     query.Time,
+	},
+}
+
+// List retrieves promotions matching the list query
+func (r *PromotionRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Promotion, int64, error) {
+	var promotions []*models.Promotion
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Promotion{}), q, promotionListFields, &promotions)
+	if err != nil {
+		return nil, 0, err
+	}
+	return promotions, total, nil
+}
+
+// GetActive retrieves the promotions running now
+func (r *PromotionRepository) GetActive(ctx context.Context) ([]*models.Promotion, error) {
+	now := time.Now().UTC()
+	var promotions []*models.Promotion
+	err := r.db.WithContext(ctx).
+		Where("start_date <= ? AND end_date >= ?", now, now).
+		Find(&promotions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// GetByDateRange retrieves promotions running at some point of the date range
+func (r *PromotionRepository) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Promotion, error) {
+	var promotions []*models.Promotion
+	err := r.db.WithContext(ctx).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Find(&promotions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// ExpireEnded soft deletes the promotions that ended before the given time
+func (r *PromotionRepository) ExpireEnded(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("end_date < ?", before).
+		Delete(&models.Promotion{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,166 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SchedulerRepository implements the scheduler repository interface
+type SchedulerRepository struct {
+	db *gorm.DB
+}
+
+// NewSchedulerRepository creates a new scheduler repository
+func NewSchedulerRepository(db *gorm.DB) interfaces.SchedulerRepository {
+	return &SchedulerRepository{db: db}
+}
+
+// AcquireLock takes the lock row of a job. The conditions are part of the insert or update, so of several
+// instances trying at once exactly one succeeds.
+func (r *SchedulerRepository) AcquireLock(ctx context.Context, jobName, owner string, slot *time.Time, until time.Time) (bool, error) {
+	now := time.Now().UTC()
+	lock := &models.JobLock{
+		JobName:         jobName,
+		LockedBy:        owner,
+		LockedUntil:     until.UTC(),
+		LastScheduledAt: utcTime(slot),
+		UpdatedAt:       now,
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(lock)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+
+	updates := map[string]interface{}{
+		"locked_by":    owner,
+		"locked_until": until.UTC(),
+		"updated_at":   now,
+	}
+	tx := r.db.WithContext(ctx).
+		Model(&models.JobLock{}).
+		Where("job_name = ? AND locked_until < ?", jobName, now)
+	if slot != nil {
+		tx = tx.Where("(last_scheduled_at IS NULL OR last_scheduled_at < ?)", slot.UTC())
+		updates["last_scheduled_at"] = slot.UTC()
+	}
+	result = tx.Updates(updates)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReleaseLock expires the lock of a job held by owner
+func (r *SchedulerRepository) ReleaseLock(ctx context.Context, jobName, owner string) error {
+	now := time.Now().UTC()
+	return r.db.WithContext(ctx).
+		Model(&models.JobLock{}).
+		Where("job_name = ? AND locked_by = ?", jobName, owner).
+		Updates(map[string]interface{}{
+			"locked_until": now,
+			"updated_at":   now,
+		}).Error
+}
+
+// CreateRun records the start of a job run
+func (r *SchedulerRepository) CreateRun(ctx context.Context, run *models.JobRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// UpdateRun records the outcome of a job run
+func (r *SchedulerRepository) UpdateRun(ctx context.Context, run *models.JobRun) error {
+	return r.db.WithContext(ctx).Save(run).Error
+}
+
+// GetRun retrieves a job run by ID
+func (r *SchedulerRepository) GetRun(ctx context.Context, id uint) (*models.JobRun, error) {
+	var run models.JobRun
+	err := r.db.WithContext(ctx).Preload("User").First(&run, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// jobRunListFields are the columns job runs can be filtered and sorted by
+var jobRunListFields = query.Fields{
+	Key:         "run_id",
+	DefaultSort: "-started_at",
+	Allowed: map[string]query.FieldType{
+		"job_name":     query.String,
+		"trigger_type": query.String,
+		"status":       query.String,
+		"instance":     query.String,
+		"started_at":   query.Time,
+		"finished_at":  query.Time,
+		"duration_ms":  query.Number,
+		"triggered_by": query.Number,
+	},
+}
+
+// ListRuns retrieves job runs matching the list query
+func (r *SchedulerRepository) ListRuns(ctx context.Context, q *query.ListQuery) ([]*models.JobRun, int64, error) {
+	var runs []*models.JobRun
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.JobRun{}), q, jobRunListFields, &runs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+// LastRuns retrieves the latest run of every job
+func (r *SchedulerRepository) LastRuns(ctx context.Context) (map[string]*models.JobRun, error) {
+	var runs []*models.JobRun
+	err := r.db.WithContext(ctx).
+		Where("run_id IN (?)", r.db.Model(&models.JobRun{}).Select("MAX(run_id)").Group("job_name")).
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	last := make(map[string]*models.JobRun, len(runs))
+	for _, run := range runs {
+		last[run.JobName] = run
+	}
+	return last, nil
+}
+
+// LastSuccess retrieves the latest successful run of a job
+func (r *SchedulerRepository) LastSuccess(ctx context.Context, jobName string) (*models.JobRun, error) {
+	var runs []*models.JobRun
+	err := r.db.WithContext(ctx).
+		Where("job_name = ? AND status = ?", jobName, models.JobRunStatusSelesai).
+		Order("started_at DESC").
+		Limit(1).
+		Find(&runs).Error
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	return runs[0], nil
+}
+
+// FailStale fails the runs still in Diproses that started before the given time, e.g. of a crashed instance
+func (r *SchedulerRepository) FailStale(ctx context.Context, before time.Time, message string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.JobRun{}).
+		Where("status = ? AND started_at < ?", models.JobRunStatusDiproses, before.UTC()).
+		Updates(map[string]interface{}{
+			"status":        models.JobRunStatusGagal,
+			"error_message": message,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,44 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SequenceRepository implements the sequence repository interface
+type SequenceRepository struct {
+	db *gorm.DB
+}
+
+// NewSequenceRepository creates a new sequence repository
+func NewSequenceRepository(db *gorm.DB) interfaces.SequenceRepository {
+	return &SequenceRepository{db: db}
+}
+
+// Next increments the counter in a single upsert returning the new value; the database serializes concurrent
+// increments of the same row, so no two callers see the same value, also across instances
+func (r *SequenceRepository) Next(ctx context.Context, name, scope string) (int64, error) {
+	now := time.Now()
+	sequence := models.DocumentSequence{Name: name, Scope: scope, LastValue: 1, UpdatedAt: now}
+	err := r.db.WithContext(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "name"}, {Name: "scope"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"last_value": gorm.Expr("document_sequences.last_value + 1"),
+					"updated_at": now,
+				}),
+			},
+			clause.Returning{Columns: []clause.Column{{Name: "last_value"}}},
+		).
+		Create(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+	return sequence.LastValue, nil
+}
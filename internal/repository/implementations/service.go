@@ -3,9 +3,12 @@ package implementations
 import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
 	"context"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ServiceRepository implements the service repository interface
@@ -53,14 +56,28 @@ func (r *ServiceRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Service{}, id).Error
 }
 
-// List retrieves services with pagination
-func (r *ServiceRepository) List(ctx context.Context, limit, offset int) ([]*models.Service, error) {
+// serviceListFields are the columns services can be filtered and sorted by
+var serviceListFields = query.Fields{
+	Key:         "service_id",
+	DefaultSort: "name",
+	Allowed: map[string]query.FieldType{
+		"service_code":        query.String,
+		"name":                query.String,
+		"service_category_id": query.Number,
+		"fee":                 query.Number,
+		"status":              query.String,
+		"created_at":          query.Time,
+	},
+}
+
+// List retrieves services matching the list query
+func (r *ServiceRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Service, int64, error) {
 	var services []*models.Service
-	err := r.db.WithContext(ctx).Preload("ServiceCategory").Limit(limit).Offset(offset).Find(&services).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Service{}), q, serviceListFields, &services, "ServiceCategory")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return services, nil
+	return services, total, nil
 }
 
 // GetByCategoryID retrieves services by category ID
@@ -146,14 +163,25 @@ func (r *ServiceCategoryRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.ServiceCategory{}, id).Error
 }
 
-// List retrieves service categories with pagination
-func (r *ServiceCategoryRepository) List(ctx context.Context, limit, offset int) ([]*models.ServiceCategory, error) {
+// serviceCategoryListFields are the columns service categories can be filtered and sorted by
+var serviceCategoryListFields = query.Fields{
+	Key:         "service_category_id",
+	DefaultSort: "name",
+	Allowed: map[string]query.FieldType{
+		"name":       query.String,
+		"status":     query.String,
+		"created_at": query.Time,
+	},
+}
+
+// List retrieves service categories matching the list query
+func (r *ServiceCategoryRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.ServiceCategory, int64, error) {
 	var categories []*models.ServiceCategory
-	err := r.db.WithContext(ctx).Preload("Services").Limit(limit).Offset(offset).Find(&categories).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.ServiceCategory{}), q, serviceCategoryListFields, &categories, "Services")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return categories, nil
+	return categories, total, nil
 }
 
 // GetByStatus retrieves service categories by status
@@ -199,6 +227,20 @@ func (r *ServiceJobRepository) GetByID(ctx context.Context, id uint) (*models.Se
 	return &serviceJob, nil
 }
 
+// GetByTrackingToken retrieves a service job by its tracking token with details and histories
+func (r *ServiceJobRepository) GetByTrackingToken(ctx context.Context, token string) (*models.ServiceJob, error) {
+	var serviceJob models.ServiceJob
+	err := r.db.WithContext(ctx).
+		Preload("ServiceDetails").
+		Preload("Histories").
+		Where("tracking_token = ?", token).
+		First(&serviceJob).Error
+	if err != nil {
+		return nil, err
+	}
+	return &serviceJob, nil
+}
+
 // GetByServiceCode retrieves a service job by service code
 func (r *ServiceJobRepository) GetByServiceCode(ctx context.Context, serviceCode string) (*models.ServiceJob, error) {
 	var serviceJob models.ServiceJob
@@ -218,9 +260,9 @@ func (r *ServiceJobRepository) GetByServiceCode(ctx context.Context, serviceCode
 	return &serviceJob, nil
 }
 
-// Update updates a service job
+// Update updates a service job; the preloaded relations are not saved, they would reset the changed IDs
 func (r *ServiceJobRepository) Update(ctx context.Context, serviceJob *models.ServiceJob) error {
-	return r.db.WithContext(ctx).Save(serviceJob).Error
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(serviceJob).Error
 }
 
 // Delete soft deletes a service job
@@ -228,24 +270,38 @@ func (r *ServiceJobRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.ServiceJob{}, id).Error
 }
 
-// List retrieves service jobs with pagination
-func (r *ServiceJobRepository) List(ctx context.Context, limit, offset int) ([]*models.ServiceJob, error) {
+// serviceJobListFields are the columns service jobs can be filtered and sorted by
+var serviceJobListFields = query.Fields{
+	Key:         "service_job_id",
+	DefaultSort: "-service_in_date",
+	Allowed: map[string]query.FieldType{
+		"service_code":               query.String,
+		"queue_number":               query.Number,
+		"priority":                   query.Number,
+		"customer_id":                query.Number,
+		"vehicle_id":                 query.Number,
+		"technician_id":              query.Number,
+		"received_by_user_id":        query.Number,
+		"outlet_id":                  query.Number,
+		"status":                     query.String,
+		"service_in_date":            query.Time,
+		"picked_up_date":             query.Time,
+		"warranty_expires_at":        query.Time,
+		"next_service_reminder_date": query.Time,
+		"estimated_completion_at":    query.Time,
+		"grand_total":                query.Number,
+		"created_at":                 query.Time,
+	},
+}
+
+// List retrieves service jobs matching the list query
+func (r *ServiceJobRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.ServiceJob, int64, error) {
 	var serviceJobs []*models.ServiceJob
-	err := r.db.WithContext(ctx).
-		Preload("Customer").
-		Preload("Vehicle").
-		Preload("Technician").
-		Preload("ReceivedByUser").
-		Preload("Outlet").
-		Preload("ServiceDetails").
-		Preload("Histories").
-		Limit(limit).
-		Offset(offset).
-		Find(&serviceJobs).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.ServiceJob{}), q, serviceJobListFields, &serviceJobs, "Customer", "Vehicle", "Technician", "ReceivedByUser", "Outlet", "ServiceDetails", "Histories")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return serviceJobs, nil
+	return serviceJobs, total, nil
 }
 
 // GetByCustomerID retrieves service jobs by customer ID
@@ -305,7 +361,7 @@ func (r *ServiceJobRepository) GetByTechnicianID(ctx context.Context, technician
 	return serviceJobs, nil
 }
 
-// GetByOutletID retrieves service jobs by outlet ID
+// GetByOutletID retrieves service jobs by outlet ID in queue order, jobs with priority first
 func (r *ServiceJobRepository) GetByOutletID(ctx context.Context, outletID uint) ([]*models.ServiceJob, error) {
 	var serviceJobs []*models.ServiceJob
 	err := r.db.WithContext(ctx).
@@ -317,6 +373,8 @@ func (r *ServiceJobRepository) GetByOutletID(ctx context.Context, outletID uint)
 		Preload("ServiceDetails").
 		Preload("Histories").
 		Where("outlet_id = ?", outletID).
+		Order("priority DESC").
+		Order("queue_number ASC").
 		Find(&serviceJobs).Error
 	if err != nil {
 		return nil, err
@@ -324,7 +382,7 @@ func (r *ServiceJobRepository) GetByOutletID(ctx context.Context, outletID uint)
 	return serviceJobs, nil
 }
 
-// GetByStatus retrieves service jobs by status
+// GetByStatus retrieves service jobs by status in queue order, jobs with priority first
 func (r *ServiceJobRepository) GetByStatus(ctx context.Context, status models.ServiceStatusEnum) ([]*models.ServiceJob, error) {
 	var serviceJobs []*models.ServiceJob
 	err := r.db.WithContext(ctx).
@@ -336,6 +394,29 @@ func (r *ServiceJobRepository) GetByStatus(ctx context.Context, status models.Se
 		Preload("ServiceDetails").
 		Preload("Histories").
 		Where("status = ?", status).
+		Order("priority DESC").
+		Order("queue_number ASC").
+		Find(&serviceJobs).Error
+	if err != nil {
+		return nil, err
+	}
+	return serviceJobs, nil
+}
+
+// GetBoard retrieves the jobs on the board of the outlet with customer, vehicle and technician, jobs with priority
+// first
+func (r *ServiceJobRepository) GetBoard(ctx context.Context, outletID uint, finishedSince time.Time) ([]*models.ServiceJob, error) {
+	var serviceJobs []*models.ServiceJob
+	err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Preload("Vehicle").
+		Preload("Technician").
+		Where("outlet_id = ?", outletID).
+		Where("(status IN ? OR (status = ? AND updated_at >= ?))",
+			[]models.ServiceStatusEnum{models.ServiceStatusAntri, models.ServiceStatusMenungguSparepart, models.ServiceStatusDikerjakan, models.ServiceStatusKomplain},
+			models.ServiceStatusSelesai, finishedSince.UTC()).
+		Order("priority DESC").
+		Order("queue_number ASC").
 		Find(&serviceJobs).Error
 	if err != nil {
 		return nil, err
@@ -351,18 +432,48 @@ func (r *ServiceJobRepository) UpdateStatus(ctx context.Context, id uint, status
 		Update("status", status).Error
 }
 
-// GetQueueNumber gets the next queue number for an outlet
-func (r *ServiceJobRepository) GetQueueNumber(ctx context.Context, outletID uint) (int, error) {
-	var maxQueue int
+// GetDueReminders retrieves jobs whose next service reminder date is in [from, to) with customer and vehicle.
+// Dates are compared as days, which also holds for SQLite where they are stored as text.
+func (r *ServiceJobRepository) GetDueReminders(ctx context.Context, from, to string) ([]*models.ServiceJob, error) {
+	var serviceJobs []*models.ServiceJob
 	err := r.db.WithContext(ctx).
-		Model(&models.ServiceJob{}).
-		Where("outlet_id = ? AND DATE(service_in_date) = CURRENT_DATE", outletID).
-		Select("COALESCE(MAX(queue_number), 0)").
-		Scan(&maxQueue).Error
+		Preload("Customer").
+		Preload("Vehicle").
+		Preload("Outlet").
+		Where("next_service_reminder_date >= ? AND next_service_reminder_date < ?", from, to).
+		Order("next_service_reminder_date ASC").
+		Order("service_job_id ASC").
+		Find(&serviceJobs).Error
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return maxQueue + 1, nil
+	return serviceJobs, nil
+}
+
+// GetAwaitingPickup retrieves Selesai jobs not picked up yet that were finished before the given time.
+// The finish is the last change to Selesai in the history, the last update for jobs
+// without one.
+func (r *ServiceJobRepository) GetAwaitingPickup(ctx context.Context, finishedBefore time.Time) ([]*models.ServiceJob, error) {
+	before := finishedBefore.UTC()
+	finished := r.db.Model(&models.ServiceJobHistory{}).
+		Select("service_job_id").
+		Where("status = ?", models.ServiceStatusSelesai).
+		Group("service_job_id").
+		Having("MAX(changed_at) < ?", before)
+	withHistory := r.db.Model(&models.ServiceJobHistory{}).
+		Select("service_job_id").
+		Where("status = ?", models.ServiceStatusSelesai)
+
+	var serviceJobs []*models.ServiceJob
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND picked_up_date IS NULL", models.ServiceStatusSelesai).
+		Where("(service_job_id IN (?) OR (service_job_id NOT IN (?) AND updated_at < ?))", finished, withHistory, before).
+		Order("service_job_id ASC").
+		Find(&serviceJobs).Error
+	if err != nil {
+		return nil, err
+	}
+	return serviceJobs, nil
 }
 
 // ServiceDetailRepository implements the service detail repository interface
@@ -400,14 +511,25 @@ func (r *ServiceDetailRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.ServiceDetail{}, id).Error
 }
 
-// List retrieves service details with pagination
-func (r *ServiceDetailRepository) List(ctx context.Context, limit, offset int) ([]*models.ServiceDetail, error) {
+// serviceDetailListFields are the columns service details can be filtered and sorted by
+var serviceDetailListFields = query.Fields{
+	Key:         "detail_id",
+	Allowed: map[string]query.FieldType{
+		"service_job_id": query.Number,
+		"item_id":        query.Number,
+		"item_type":      query.String,
+		"quantity":       query.Number,
+	},
+}
+
+// List retrieves service details matching the list query
+func (r *ServiceDetailRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.ServiceDetail, int64, error) {
 	var details []*models.ServiceDetail
-	err := r.db.WithContext(ctx).Preload("ServiceJob").Limit(limit).Offset(offset).Find(&details).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.ServiceDetail{}), q, serviceDetailListFields, &details, "ServiceJob")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return details, nil
+	return details, total, nil
 }
 
 // GetByServiceJobID retrieves service details by service job ID
@@ -450,14 +572,25 @@ func (r *ServiceJobHistoryRepository) GetByID(ctx context.Context, id uint) (*mo
 	return &history, nil
 }
 
-// List retrieves service job histories with pagination
-func (r *ServiceJobHistoryRepository) List(ctx context.Context, limit, offset int) ([]*models.ServiceJobHistory, error) {
+// serviceJobHistoryListFields are the columns service job histories can be filtered and sorted by
+var serviceJobHistoryListFields = query.Fields{
+	Key:         "history_id",
+	DefaultSort: "-changed_at",
+	Allowed: map[string]query.FieldType{
+		"service_job_id": query.Number,
+		"user_id":        query.Number,
+		"changed_at":     query.Time,
+	},
+}
+
+// List retrieves service job histories matching the list query
+func (r *ServiceJobHistoryRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.ServiceJobHistory, int64, error) {
 	var histories []*models.ServiceJobHistory
-	err := r.db.WithContext(ctx).Preload("ServiceJob").Preload("User").Limit(limit).Offset(offset).Find(&histories).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.ServiceJobHistory{}), q, serviceJobHistoryListFields, &histories, "ServiceJob", "User")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return histories, nil
+	return histories, total, nil
 }
 
 // GetByServiceJobID retrieves service job histories by service job ID
@@ -0,0 +1,170 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SpecialOrderRepository implements the special order repository interface
+type SpecialOrderRepository struct {
+	db *gorm.DB
+}
+
+// NewSpecialOrderRepository creates a new special order repository
+func NewSpecialOrderRepository(db *gorm.DB) interfaces.SpecialOrderRepository {
+	return &SpecialOrderRepository{db: db}
+}
+
+// Create stores the orders in one transaction
+func (r *SpecialOrderRepository) Create(ctx context.Context, orders []*models.SpecialOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Omit("Product", "ServiceJob").Create(&orders).Error
+	})
+}
+
+// GetByID retrieves a special order with its product
+func (r *SpecialOrderRepository) GetByID(ctx context.Context, id uint) (*models.SpecialOrder, error) {
+	var order models.SpecialOrder
+	err := r.db.WithContext(ctx).
+		Preload("Product").
+		Where("special_order_id = ?", id).
+		First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// List retrieves the orders with their product and job, oldest first
+func (r *SpecialOrderRepository) List(ctx context.Context, filter interfaces.SpecialOrderFilter) ([]*models.SpecialOrder, error) {
+	db := r.db.WithContext(ctx).
+		Preload("Product").
+		Preload("ServiceJob")
+	if filter.ServiceJobID != 0 {
+		db = db.Where("service_job_id = ?", filter.ServiceJobID)
+	}
+	if filter.OutletID != 0 {
+		db = db.Where("outlet_id = ?", filter.OutletID)
+	}
+	if filter.PurchaseOrderID != 0 {
+		db = db.Where("purchase_order_id = ?", filter.PurchaseOrderID)
+	}
+	if len(filter.Statuses) > 0 {
+		db = db.Where("status IN ?", filter.Statuses)
+	}
+
+	var orders []*models.SpecialOrder
+	if err := db.Order("created_at ASC").Order("special_order_id ASC").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// SetStatus moves the orders still in one of the from statuses, so a concurrent change is not overwritten
+func (r *SpecialOrderRepository) SetStatus(ctx context.Context, orders []*models.SpecialOrder, from ...models.SpecialOrderStatus) (int64, error) {
+	var moved int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, order := range orders {
+			result := tx.Model(&models.SpecialOrder{}).
+				Where("special_order_id = ? AND status IN ?", order.SpecialOrderID, from).
+				Updates(map[string]interface{}{
+					"status":            order.Status,
+					"purchase_order_id": order.PurchaseOrderID,
+					"detail_id":         order.DetailID,
+					"received_at":       order.ReceivedAt,
+					"updated_at":        time.Now(),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			moved += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+// UseReserved marks up to quantity of the product reserved for the job as used by the part line, oldest orders first
+func (r *SpecialOrderRepository) UseReserved(ctx context.Context, serviceJobID, productID, detailID uint, quantity int, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var orders []*models.SpecialOrder
+		err := tx.Where("service_job_id = ? AND product_id = ? AND status = ?", serviceJobID, productID, models.SpecialOrderDicadangkan).
+			Order("created_at ASC").Order("special_order_id ASC").
+			Find(&orders).Error
+		if err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			if quantity <= 0 {
+				break
+			}
+			if order.Quantity > quantity {
+				// The rest of the order stays reserved, the used part becomes an order of its own
+				used := *order
+				used.SpecialOrderID = 0
+				used.Quantity = quantity
+				used.Status = models.SpecialOrderTerpakai
+				used.DetailID = &detailID
+				used.UpdatedAt = at
+				if err := tx.Omit("Product", "ServiceJob").Create(&used).Error; err != nil {
+					return err
+				}
+				err := tx.Model(&models.SpecialOrder{}).
+					Where("special_order_id = ?", order.SpecialOrderID).
+					Updates(map[string]interface{}{"quantity": order.Quantity - quantity, "updated_at": at}).Error
+				if err != nil {
+					return err
+				}
+				break
+			}
+
+			err := tx.Model(&models.SpecialOrder{}).
+				Where("special_order_id = ?", order.SpecialOrderID).
+				Updates(map[string]interface{}{
+					"status":     models.SpecialOrderTerpakai,
+					"detail_id":  detailID,
+					"updated_at": at,
+				}).Error
+			if err != nil {
+				return err
+			}
+			quantity -= order.Quantity
+		}
+		return nil
+	})
+}
+
+// ReturnUsed reserves the orders used by the part line again, for a line that is deleted or issued again
+func (r *SpecialOrderRepository) ReturnUsed(ctx context.Context, detailID uint, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.SpecialOrder{}).
+		Where("detail_id = ? AND status = ?", detailID, models.SpecialOrderTerpakai).
+		Updates(map[string]interface{}{
+			"status":     models.SpecialOrderDicadangkan,
+			"detail_id":  nil,
+			"updated_at": at,
+		}).Error
+}
+
+// Release puts the orders still on the purchase order back to Diminta, for a discarded draft
+func (r *SpecialOrderRepository) Release(ctx context.Context, purchaseOrderID uint) error {
+	return r.db.WithContext(ctx).
+		Model(&models.SpecialOrder{}).
+		Where("purchase_order_id = ? AND status = ?", purchaseOrderID, models.SpecialOrderDipesan).
+		Updates(map[string]interface{}{
+			"status":            models.SpecialOrderDiminta,
+			"purchase_order_id": nil,
+			"updated_at":        time.Now(),
+		}).Error
+}
@@ -0,0 +1,146 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository/interfaces"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TechnicianRepository implements the technician repository interface
+type TechnicianRepository struct {
+	db *gorm.DB
+}
+
+// NewTechnicianRepository creates a new technician repository
+func NewTechnicianRepository(db *gorm.DB) interfaces.TechnicianRepository {
+	return &TechnicianRepository{db: db}
+}
+
+// Save upserts the profile on its user and replaces its skills and shifts in one transaction
+func (r *TechnicianRepository) Save(ctx context.Context, profile *models.TechnicianProfile) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"is_active", "notes", "updated_at"}),
+			}).
+			Create(profile).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", profile.UserID).Delete(&models.TechnicianSkill{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", profile.UserID).Delete(&models.TechnicianShift{}).Error; err != nil {
+			return err
+		}
+		for i := range profile.Skills {
+			profile.Skills[i].UserID = profile.UserID
+		}
+		for i := range profile.Shifts {
+			profile.Shifts[i].UserID = profile.UserID
+		}
+		if len(profile.Skills) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&profile.Skills).Error; err != nil {
+				return err
+			}
+		}
+		if len(profile.Shifts) > 0 {
+			if err := tx.Create(&profile.Shifts).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetByUserID retrieves the profile of a user with the user, skills and shifts
+func (r *TechnicianRepository) GetByUserID(ctx context.Context, userID uint) (*models.TechnicianProfile, error) {
+	var profile models.TechnicianProfile
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Skills.ServiceCategory").
+		Preload("Shifts", func(db *gorm.DB) *gorm.DB { return db.Order("weekday") }).
+		Where("user_id = ?", userID).
+		First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// List retrieves the profiles of users that are not deleted with their user, skills and shifts
+func (r *TechnicianRepository) List(ctx context.Context, filter interfaces.TechnicianFilter) ([]*models.TechnicianProfile, error) {
+	db := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Skills.ServiceCategory").
+		Preload("Shifts", func(db *gorm.DB) *gorm.DB { return db.Order("weekday") }).
+		Joins("JOIN users u ON u.user_id = technician_profiles.user_id AND u.deleted_at IS NULL")
+	if filter.OutletID > 0 {
+		db = db.Where("(u.outlet_id = ? OR u.outlet_id IS NULL)", filter.OutletID)
+	}
+	if filter.Active != nil {
+		db = db.Where("technician_profiles.is_active = ?", *filter.Active)
+	}
+
+	var profiles []*models.TechnicianProfile
+	if err := db.Order("technician_profiles.user_id").Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// GetOpenJobs retrieves the Antri, Dikerjakan and Komplain jobs assigned to the technicians in queue order
+func (r *TechnicianRepository) GetOpenJobs(ctx context.Context, technicianIDs []uint) ([]*models.ServiceJob, error) {
+	var serviceJobs []*models.ServiceJob
+	if len(technicianIDs) == 0 {
+		return serviceJobs, nil
+	}
+	err := r.db.WithContext(ctx).
+		Preload("Vehicle").
+		Where("technician_id IN ?", technicianIDs).
+		Where("status IN ?", []models.ServiceStatusEnum{
+			models.ServiceStatusAntri, models.ServiceStatusMenungguSparepart, models.ServiceStatusDikerjakan, models.ServiceStatusKomplain,
+		}).
+		Order("priority DESC").
+		Order("queue_number ASC").
+		Find(&serviceJobs).Error
+	if err != nil {
+		return nil, err
+	}
+	return serviceJobs, nil
+}
+
+// GetJobServices retrieves the services on the service lines of the jobs and those booked with their appointments
+func (r *TechnicianRepository) GetJobServices(ctx context.Context, serviceJobIDs []uint) ([]interfaces.JobServiceRow, error) {
+	var rows []interfaces.JobServiceRow
+	if len(serviceJobIDs) == 0 {
+		return rows, nil
+	}
+	err := r.db.WithContext(ctx).
+		Table("service_details sd").
+		Select("sd.service_job_id, s.service_id, s.service_category_id, s.standard_minutes, sd.quantity, FALSE AS from_appointment").
+		Joins("JOIN services s ON s.service_id = sd.item_id").
+		Where("sd.item_type = ? AND sd.service_job_id IN ?", "service", serviceJobIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var booked []interfaces.JobServiceRow
+	err = r.db.WithContext(ctx).
+		Table("appointment_services aps").
+		Select("a.service_job_id, s.service_id, s.service_category_id, aps.standard_minutes, 1 AS quantity, TRUE AS from_appointment").
+		Joins("JOIN appointments a ON a.appointment_id = aps.appointment_id").
+		Joins("JOIN services s ON s.service_id = aps.service_id").
+		Where("a.service_job_id IN ?", serviceJobIDs).
+		Scan(&booked).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(rows, booked...), nil
+}
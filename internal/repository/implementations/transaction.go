@@ -3,10 +3,12 @@ package implementations
 import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository/interfaces"
+	"boilerplate/pkg/query"
 	"context"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TransactionRepository implements the transaction repository interface
@@ -67,8 +69,35 @@ func (r *TransactionRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.Transaction{}, id).Error
 }
 
-// List retrieves transactions with pagination
-func (r *TransactionRepository) List(ctx context.Context, limit, offset int) ([]*models.Transaction, error) {
+// transactionListFields are the columns transactions can be filtered and sorted by
+var transactionListFields = query.Fields{
+	Key:         "transaction_id",
+	DefaultSort: "-transaction_date",
+	Allowed: map[string]query.FieldType{
+		"invoice_number":   query.String,
+		"transaction_date": query.Time,
+		"user_id":          query.Number,
+		"customer_id":      query.Number,
+		"outlet_id":        query.Number,
+		"transaction_type": query.String,
+		"status":           query.String,
+		"created_at":       query.Time,
+	},
+}
+
+// List retrieves transactions matching the list query
+func (r *TransactionRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.Transaction, int64, error) {
+	var transactions []*models.Transaction
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.Transaction{}), q, transactionListFields, &transactions, "User", "Customer", "Outlet", "TransactionDetails", "Payments")
+	if err != nil {
+		return nil, 0, err
+	}
+	return transactions, total, nil
+}
+
+// ListByCursor retrieves transactions newest first using keyset pagination and returns the next cursor,
+// which is empty on the last page
+func (r *TransactionRepository) ListByCursor(ctx context.Context, q *query.ListQuery) ([]*models.Transaction, string, error) {
 	var transactions []*models.Transaction
 	err := r.db.WithContext(ctx).
 		Preload("User").
@@ -76,13 +105,18 @@ func (r *TransactionRepository) List(ctx context.Context, limit, offset int) ([]
 		Preload("Outlet").
 		Preload("TransactionDetails").
 		Preload("Payments").
-		Limit(limit).
-		Offset(offset).
+		Scopes(query.Filtered(q, transactionListFields), query.After(q, transactionListFields)).
 		Find(&transactions).Error
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return transactions, nil
+
+	nextCursor := ""
+	if len(transactions) > q.Limit {
+		transactions = transactions[:q.Limit]
+		nextCursor = query.EncodeCursor(transactions[len(transactions)-1].TransactionID)
+	}
+	return transactions, nextCursor, nil
 }
 
 // GetByCustomerID retrieves transactions by customer ID
@@ -209,20 +243,27 @@ func (r *TransactionDetailRepository) Delete(ctx context.Context, id uint) error
 	return r.db.WithContext(ctx).Delete(&models.TransactionDetail{}, id).Error
 }
 
-// List retrieves transaction details with pagination
-func (r *TransactionDetailRepository) List(ctx context.Context, limit, offset int) ([]*models.TransactionDetail, error) {
+// transactionDetailListFields are the columns transaction details can be filtered and sorted by
+var transactionDetailListFields = query.Fields{
+	Key:         "detail_id",
+	Allowed: map[string]query.FieldType{
+		"transaction_id":   query.Number,
+		"product_id":       query.Number,
+		"serial_number_id": query.Number,
+		"quantity":         query.Number,
+		"total_price":      query.Number,
+		"created_at":       query.Time,
+	},
+}
+
+// List retrieves transaction details matching the list query
+func (r *TransactionDetailRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.TransactionDetail, int64, error) {
 	var details []*models.TransactionDetail
-	err := r.db.WithContext(ctx).
-		Preload("Transaction").
-		Preload("Product").
-		Preload("SerialNumber").
-		Limit(limit).
-		Offset(offset).
-		Find(&details).Error
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.TransactionDetail{}), q, transactionDetailListFields, &details, "Transaction", "Product", "SerialNumber")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return details, nil
+	return details, total, nil
 }
 
 // GetByTransactionID retrieves transaction details by transaction ID
@@ -260,4 +301,277 @@ func (r *TransactionDetailRepository) DeleteByTransactionID(ctx context.Context,
 	return r.db.WithContext(ctx).
 		Where("transaction_id = ?", transactionID).
 		Delete(&models.TransactionDetail{}).Error
-}
\ No newline at end of file
+}
+// PurchaseOrderRepository implements the purchase order repository interface
+type PurchaseOrderRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchaseOrderRepository creates a new purchase order repository
+func NewPurchaseOrderRepository(db *gorm.DB) interfaces.PurchaseOrderRepository {
+	return &PurchaseOrderRepository{db: db}
+}
+
+// Create creates a new purchase order, its PurchaseOrderDetails are created with it
+func (r *PurchaseOrderRepository) Create(ctx context.Context, purchaseOrder *models.PurchaseOrder) error {
+	return r.db.WithContext(ctx).Create(purchaseOrder).Error
+}
+
+// GetByID retrieves a purchase order by ID
+func (r *PurchaseOrderRepository) GetByID(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	var purchaseOrder models.PurchaseOrder
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Preload("Outlet").
+		Preload("PurchaseOrderDetails.Product").
+		First(&purchaseOrder, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &purchaseOrder, nil
+}
+
+// GetByPOCode retrieves a purchase order by PO code
+func (r *PurchaseOrderRepository) GetByPOCode(ctx context.Context, poCode string) (*models.PurchaseOrder, error) {
+	var purchaseOrder models.PurchaseOrder
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Preload("Outlet").
+		Preload("PurchaseOrderDetails.Product").
+		Where("po_code = ?", poCode).
+		First(&purchaseOrder).Error
+	if err != nil {
+		return nil, err
+	}
+	return &purchaseOrder, nil
+}
+
+// Update updates a purchase order
+func (r *PurchaseOrderRepository) Update(ctx context.Context, purchaseOrder *models.PurchaseOrder) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(purchaseOrder).Error
+}
+
+// UpdateStatus moves the purchase order only when it is still in the from status
+func (r *PurchaseOrderRepository) UpdateStatus(ctx context.Context, id uint, from, to models.PurchaseStatus) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.PurchaseOrder{}).
+		Where("purchase_order_id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{
+			"status":     to,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateWithDetails updates a purchase order and replaces its details
+func (r *PurchaseOrderRepository) UpdateWithDetails(ctx context.Context, purchaseOrder *models.PurchaseOrder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(purchaseOrder).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("purchase_order_id = ?", purchaseOrder.PurchaseOrderID).Delete(&models.PurchaseOrderDetail{}).Error; err != nil {
+			return err
+		}
+		for i := range purchaseOrder.PurchaseOrderDetails {
+			detail := &purchaseOrder.PurchaseOrderDetails[i]
+			detail.DetailID = 0
+			detail.PurchaseOrderID = purchaseOrder.PurchaseOrderID
+			if err := tx.Omit(clause.Associations).Create(detail).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete deletes a purchase order and its details
+func (r *PurchaseOrderRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("purchase_order_id = ?", id).Delete(&models.PurchaseOrderDetail{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.PurchaseOrder{}, id).Error
+	})
+}
+
+// purchaseOrderListFields are the columns purchase orders can be filtered and sorted by
+var purchaseOrderListFields = query.Fields{
+	Key:         "purchase_order_id",
+	DefaultSort: "-po_date",
+	Allowed: map[string]query.FieldType{
+		"po_code":      query.String,
+		"supplier_id":  query.Number,
+		"outlet_id":    query.Number,
+		"po_date":      query.Time,
+		"total_amount": query.Number,
+		"payment_type": query.String,
+		"status":       query.String,
+		"created_at":   query.Time,
+	},
+}
+
+// List retrieves purchase orders matching the list query
+func (r *PurchaseOrderRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.PurchaseOrder, int64, error) {
+	var purchaseOrders []*models.PurchaseOrder
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.PurchaseOrder{}), q, purchaseOrderListFields, &purchaseOrders, "Supplier", "Outlet", "PurchaseOrderDetails.Product")
+	if err != nil {
+		return nil, 0, err
+	}
+	return purchaseOrders, total, nil
+}
+
+// GetBySupplierID retrieves purchase orders by supplier ID
+func (r *PurchaseOrderRepository) GetBySupplierID(ctx context.Context, supplierID uint) ([]*models.PurchaseOrder, error) {
+	var purchaseOrders []*models.PurchaseOrder
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Preload("Outlet").
+		Preload("PurchaseOrderDetails.Product").
+		Where("supplier_id = ?", supplierID).
+		Find(&purchaseOrders).Error
+	if err != nil {
+		return nil, err
+	}
+	return purchaseOrders, nil
+}
+
+// GetByOutletID retrieves purchase orders by outlet ID
+func (r *PurchaseOrderRepository) GetByOutletID(ctx context.Context, outletID uint) ([]*models.PurchaseOrder, error) {
+	var purchaseOrders []*models.PurchaseOrder
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Preload("Outlet").
+		Preload("PurchaseOrderDetails.Product").
+		Where("outlet_id = ?", outletID).
+		Find(&purchaseOrders).Error
+	if err != nil {
+		return nil, err
+	}
+	return purchaseOrders, nil
+}
+
+// GetByStatus retrieves purchase orders by status
+func (r *PurchaseOrderRepository) GetByStatus(ctx context.Context, status models.PurchaseStatus) ([]*models.PurchaseOrder, error) {
+	var purchaseOrders []*models.PurchaseOrder
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Preload("Outlet").
+		Preload("PurchaseOrderDetails.Product").
+		Where("status = ?", status).
+		Find(&purchaseOrders).Error
+	if err != nil {
+		return nil, err
+	}
+	return purchaseOrders, nil
+}
+
+// GetByDateRange retrieves purchase orders by date range
+func (r *PurchaseOrderRepository) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.PurchaseOrder, error) {
+	var purchaseOrders []*models.PurchaseOrder
+	err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Preload("Outlet").
+		Preload("PurchaseOrderDetails.Product").
+		Where("po_date BETWEEN ? AND ?", startDate, endDate).
+		Find(&purchaseOrders).Error
+	if err != nil {
+		return nil, err
+	}
+	return purchaseOrders, nil
+}
+
+// PurchaseOrderDetailRepository implements the purchase order detail repository interface
+type PurchaseOrderDetailRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchaseOrderDetailRepository creates a new purchase order detail repository
+func NewPurchaseOrderDetailRepository(db *gorm.DB) interfaces.PurchaseOrderDetailRepository {
+	return &PurchaseOrderDetailRepository{db: db}
+}
+
+// Create creates a new purchase order detail
+func (r *PurchaseOrderDetailRepository) Create(ctx context.Context, detail *models.PurchaseOrderDetail) error {
+	return r.db.WithContext(ctx).Create(detail).Error
+}
+
+// GetByID retrieves a purchase order detail by ID
+func (r *PurchaseOrderDetailRepository) GetByID(ctx context.Context, id uint) (*models.PurchaseOrderDetail, error) {
+	var detail models.PurchaseOrderDetail
+	err := r.db.WithContext(ctx).
+		Preload("PurchaseOrder").
+		Preload("Product").
+		First(&detail, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+// Update updates a purchase order detail
+func (r *PurchaseOrderDetailRepository) Update(ctx context.Context, detail *models.PurchaseOrderDetail) error {
+	return r.db.WithContext(ctx).Save(detail).Error
+}
+
+// Delete deletes a purchase order detail
+func (r *PurchaseOrderDetailRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.PurchaseOrderDetail{}, id).Error
+}
+
+// purchaseOrderDetailListFields are the columns purchase order details can be filtered and sorted by
+var purchaseOrderDetailListFields = query.Fields{
+	Key: "detail_id",
+	Allowed: map[string]query.FieldType{
+		"purchase_order_id": query.Number,
+		"product_id":        query.Number,
+		"quantity":          query.Number,
+		"cost_price":        query.Number,
+	},
+}
+
+// List retrieves purchase order details matching the list query
+func (r *PurchaseOrderDetailRepository) List(ctx context.Context, q *query.ListQuery) ([]*models.PurchaseOrderDetail, int64, error) {
+	var details []*models.PurchaseOrderDetail
+	total, err := query.Find(r.db.WithContext(ctx).Model(&models.PurchaseOrderDetail{}), q, purchaseOrderDetailListFields, &details, "PurchaseOrder", "Product")
+	if err != nil {
+		return nil, 0, err
+	}
+	return details, total, nil
+}
+
+// GetByPurchaseOrderID retrieves purchase order details by purchase order ID
+func (r *PurchaseOrderDetailRepository) GetByPurchaseOrderID(ctx context.Context, purchaseOrderID uint) ([]*models.PurchaseOrderDetail, error) {
+	var details []*models.PurchaseOrderDetail
+	err := r.db.WithContext(ctx).
+		Preload("Product").
+		Where("purchase_order_id = ?", purchaseOrderID).
+		Find(&details).Error
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// GetByProductID retrieves purchase order details by product ID
+func (r *PurchaseOrderDetailRepository) GetByProductID(ctx context.Context, productID uint) ([]*models.PurchaseOrderDetail, error) {
+	var details []*models.PurchaseOrderDetail
+	err := r.db.WithContext(ctx).
+		Preload("PurchaseOrder").
+		Preload("Product").
+		Where("product_id = ?", productID).
+		Find(&details).Error
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// DeleteByPurchaseOrderID deletes purchase order details by purchase order ID
+func (r *PurchaseOrderDetailRepository) DeleteByPurchaseOrderID(ctx context.Context, purchaseOrderID uint) error {
+	return r.db.WithContext(ctx).
+		Where("purchase_order_id = ?", purchaseOrderID).
+		Delete(&models.PurchaseOrderDetail{}).Error
+}
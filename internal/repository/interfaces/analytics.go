@@ -0,0 +1,120 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// AnalyticsRepository interface for aggregated sales figures
+type AnalyticsRepository interface {
+	SalesSummary(ctx context.Context, filter AnalyticsFilter, period AnalyticsPeriod, dimension AnalyticsDimension) ([]SalesSummaryRow, error)
+	TopProducts(ctx context.Context, filter AnalyticsFilter, rank AnalyticsRank, limit int) ([]TopItemRow, error)
+	TopServices(ctx context.Context, filter AnalyticsFilter, rank AnalyticsRank, limit int) ([]TopItemRow, error)
+	CompletedServiceJobs(ctx context.Context, filter AnalyticsFilter) ([]*models.ServiceJob, error)
+	VehicleVisits(ctx context.Context, vehicleIDs []uint, since time.Time) ([]*models.ServiceJob, error)
+	StockProducts(ctx context.Context) ([]StockProductRow, error)
+	ProductConsumption(ctx context.Context, filter AnalyticsFilter) ([]ProductConsumptionRow, error)
+}
+
+// AnalyticsPeriod buckets figures by time, AnalyticsPeriodNone keeps the whole range in one bucket
+type AnalyticsPeriod string
+
+const (
+	AnalyticsPeriodNone  AnalyticsPeriod = ""
+	AnalyticsPeriodDay   AnalyticsPeriod = "day"
+	AnalyticsPeriodWeek  AnalyticsPeriod = "week"
+	AnalyticsPeriodMonth AnalyticsPeriod = "month"
+)
+
+func (p AnalyticsPeriod) IsValid() bool {
+	switch p {
+	case AnalyticsPeriodNone, AnalyticsPeriodDay, AnalyticsPeriodWeek, AnalyticsPeriodMonth:
+		return true
+	}
+	return false
+}
+
+// AnalyticsDimension splits figures by outlet, cashier or payment method, AnalyticsDimensionNone does not split
+type AnalyticsDimension string
+
+const (
+	AnalyticsDimensionNone          AnalyticsDimension = ""
+	AnalyticsDimensionOutlet        AnalyticsDimension = "outlet"
+	AnalyticsDimensionCashier       AnalyticsDimension = "cashier"
+	AnalyticsDimensionPaymentMethod AnalyticsDimension = "payment_method"
+)
+
+func (d AnalyticsDimension) IsValid() bool {
+	switch d {
+	case AnalyticsDimensionNone, AnalyticsDimensionOutlet, AnalyticsDimensionCashier, AnalyticsDimensionPaymentMethod:
+		return true
+	}
+	return false
+}
+
+// AnalyticsRank orders top sellers by sold quantity or by gross margin
+type AnalyticsRank string
+
+const (
+	AnalyticsRankQuantity AnalyticsRank = "quantity"
+	AnalyticsRankMargin   AnalyticsRank = "margin"
+)
+
+func (r AnalyticsRank) IsValid() bool {
+	return r == AnalyticsRankQuantity || r == AnalyticsRankMargin
+}
+
+// AnalyticsFilter selects [StartDate, EndDate) and optionally one outlet.
+// UTCOffset is the offset in seconds of the shop's time zone, days, weeks and months are bucketed in it.
+type AnalyticsFilter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	OutletID  *uint
+	UTCOffset int
+}
+
+// SalesSummaryRow holds the figures of one period and dimension bucket.
+// Period is the first day of the bucket ("2024-01-01", months as "2024-01"), empty without a period.
+type SalesSummaryRow struct {
+	Period           string
+	DimensionID      *uint
+	DimensionName    string
+	Revenue          float64
+	COGS             float64
+	TransactionCount int64
+}
+
+// TopItemRow is a product or service with its sold quantity, revenue and cost
+type TopItemRow struct {
+	ItemID   uint
+	Name     string
+	Quantity int64
+	Revenue  float64
+	COGS     float64
+}
+
+// StockProductRow is a product with its stock, cost and the names of its category and supplier
+type StockProductRow struct {
+	ProductID    uint
+	ProductName  string
+	SKU          *string
+	IsActive     bool
+	CategoryID   *uint
+	CategoryName *string
+	SupplierID   *uint
+	SupplierName *string
+	Stock        int64
+	CostPrice    float64
+	CreatedAt    time.Time
+}
+
+// ProductConsumptionRow is the quantity of a product sold and used as a part in the filter's range and its value at
+// the cost snapshotted on the lines. LastMoved is the last day ("2024-01-31") before the end of the range the product
+// was sold or used at all, empty when it never was.
+type ProductConsumptionRow struct {
+	ProductID uint
+	Quantity  int64
+	Value     float64
+	LastMoved string
+}
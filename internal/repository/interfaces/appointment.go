@@ -0,0 +1,49 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+)
+
+// AppointmentRepository interface for the appointments booked into outlets
+type AppointmentRepository interface {
+	// Create stores the appointment with its services
+	Create(ctx context.Context, appointment *models.Appointment) error
+	GetByID(ctx context.Context, id uint) (*models.Appointment, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Appointment, int64, error)
+	// Update stores the appointment and replaces its services with appointment.Services
+	Update(ctx context.Context, appointment *models.Appointment) error
+	// Transition stores the status and its timestamps set on the appointment when its status is still from,
+	// false when it is not
+	Transition(ctx context.Context, appointment *models.Appointment, from models.AppointmentStatus) (bool, error)
+
+	// LockOutlet reads the outlet and locks it until the transaction ends, so the bookings of an outlet are checked
+	// and written one at a time
+	LockOutlet(ctx context.Context, outletID uint) (*models.Outlet, error)
+	// GetScheduledByOutlet retrieves the Dijadwalkan appointments of an outlet overlapping [from, to)
+	GetScheduledByOutlet(ctx context.Context, outletID uint, from, to time.Time) ([]*models.Appointment, error)
+	// GetScheduledByTechnician retrieves the Dijadwalkan appointments of a technician overlapping [from, to)
+	GetScheduledByTechnician(ctx context.Context, technicianID uint, from, to time.Time) ([]*models.Appointment, error)
+	// GetByOutletBetween retrieves the appointments of an outlet starting in [from, to) in any status, by start
+	GetByOutletBetween(ctx context.Context, outletID uint, from, to time.Time) ([]*models.Appointment, error)
+	// GetDueNoShows retrieves the Dijadwalkan appointments that started before the given time
+	GetDueNoShows(ctx context.Context, startedBefore time.Time) ([]*models.Appointment, error)
+	// CountNoShows counts the no-shows of a customer with appointments starting since the given time
+	CountNoShows(ctx context.Context, customerID uint, since time.Time) (int64, error)
+}
+
+// OutletScheduleRepository interface for the opening hours and holidays appointments are booked within
+type OutletScheduleRepository interface {
+	GetOpeningHours(ctx context.Context, outletID uint) ([]models.OutletOpeningHour, error)
+	// ReplaceOpeningHours replaces the opening hours of an outlet, days left out are closed
+	ReplaceOpeningHours(ctx context.Context, outletID uint, hours []models.OutletOpeningHour) error
+
+	CreateHoliday(ctx context.Context, holiday *models.OutletHoliday) error
+	GetHolidayByID(ctx context.Context, id uint) (*models.OutletHoliday, error)
+	// GetHolidays retrieves the holidays in [from, to) ("2006-01-02") of an outlet and of all outlets,
+	// every outlet's holidays when outletID is 0
+	GetHolidays(ctx context.Context, outletID uint, from, to string) ([]*models.OutletHoliday, error)
+	DeleteHoliday(ctx context.Context, id uint) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+)
+
+// AuditLogRepository interface for audit log operations (append-only)
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *models.AuditLog) error
+	GetByID(ctx context.Context, id uint) (*models.AuditLog, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.AuditLog, int64, error)
+}
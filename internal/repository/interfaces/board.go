@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// ServiceJobEventRepository interface for the event log of the live boards
+type ServiceJobEventRepository interface {
+	Create(ctx context.Context, event *models.ServiceJobEvent) error
+	// ListAfter retrieves up to limit events of the outlet with an ID above afterID, oldest first
+	ListAfter(ctx context.Context, outletID uint, afterID uint, limit int) ([]*models.ServiceJobEvent, error)
+	// Bounds returns the lowest and highest event ID kept of all outlets, zeros without events
+	Bounds(ctx context.Context) (first, last uint, err error)
+	// DeleteBefore deletes the events created before the given time and returns how many
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
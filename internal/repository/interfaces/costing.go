@@ -0,0 +1,45 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrStockReserved is returned by Issue when a sale or service movement would take stock reserved for the special
+// orders of other jobs
+var ErrStockReserved = errors.New("costing: stock is reserved for special orders")
+
+// ErrInsufficientStock is returned by Issue when the stock on hand does not cover the issued quantity
+var ErrInsufficientStock = errors.New("costing: insufficient stock")
+
+// CostingRepository interface for stock movements that move product cost along with the stock.
+// Every call runs in one database transaction with the product row locked.
+type CostingRepository interface {
+	// Receive records inbound movements at their UnitCost, updates the moving average cost and opens a cost layer for each
+	Receive(ctx context.Context, movements ...*models.StockMovement) error
+	// Issue records an outbound movement, consumes cost layers oldest first and sets the movement's UnitCost
+	// from the product's cost method. The stock on hand must cover the movement; sales and service parts leave
+	// the stock reserved for other jobs' special orders, a service movement referencing the job may use its own.
+	Issue(ctx context.Context, movement *models.StockMovement) error
+	// Available returns the stock of the product a part line of the job may use: the stock on hand less the stock
+	// reserved for other jobs' special orders
+	Available(ctx context.Context, productID, serviceJobID uint) (int, error)
+	// ChangeCostMethod switches the product's cost method and revalues its stock on hand, returning the revaluation entries
+	ChangeCostMethod(ctx context.Context, productID uint, method models.CostMethod, createdBy *uint) ([]*models.StockMovement, error)
+	GetOpenLayers(ctx context.Context, productID uint) ([]*models.CostLayer, error)
+	Valuation(ctx context.Context, asOf time.Time, outletID *uint) ([]ValuationRow, error)
+}
+
+// ValuationRow is the stock of a product at an outlet, OutletID is nil for movements not booked to an outlet
+type ValuationRow struct {
+	OutletID    *uint
+	OutletName  string
+	ProductID   uint
+	ProductName string
+	SKU         *string
+	CostMethod  models.CostMethod
+	Quantity    int64
+	Value       float64
+}
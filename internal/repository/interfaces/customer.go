@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 )
 
@@ -12,7 +13,7 @@ type CustomerRepository interface {
 	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*models.Customer, error)
 	Update(ctx context.Context, customer *models.Customer) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Customer, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Customer, int64, error)
 	GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Customer, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.Customer, error)
 }
@@ -26,7 +27,7 @@ type CustomerVehicleRepository interface {
 	GetByEngineNumber(ctx context.Context, engineNumber string) (*models.CustomerVehicle, error)
 	Update(ctx context.Context, vehicle *models.CustomerVehicle) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.CustomerVehicle, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.CustomerVehicle, int64, error)
 	GetByCustomerID(ctx context.Context, customerID uint) ([]*models.CustomerVehicle, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.CustomerVehicle, error)
 }
\ No newline at end of file
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// DashboardRepository interface for the per-outlet figures of the owner dashboard
+type DashboardRepository interface {
+	ServiceRevenue(ctx context.Context, filter AnalyticsFilter) ([]OutletAmountRow, error)
+	ServiceJobStatuses(ctx context.Context, filter AnalyticsFilter) ([]OutletStatusRow, error)
+	CashFlowTotals(ctx context.Context, startDate, endDate time.Time, outletID *uint) ([]OutletCashFlowRow, error)
+	OverduePayables(ctx context.Context, asOf time.Time, outletID *uint) ([]OutletAmountRow, error)
+	OverdueReceivables(ctx context.Context, asOf time.Time, outletID *uint) ([]OutletAmountRow, error)
+	LowStockCount(ctx context.Context, threshold int) (int64, error)
+}
+
+// OutletAmountRow is a count and amount of one outlet, OutletID is nil for records not tied to an outlet
+type OutletAmountRow struct {
+	OutletID *uint
+	Count    int64
+	Amount   float64
+}
+
+// OutletStatusRow counts the service jobs of one outlet in one status
+type OutletStatusRow struct {
+	OutletID uint
+	Status   string
+	Count    int64
+}
+
+// OutletCashFlowRow sums the cash flows of one type recorded by the users of an outlet
+type OutletCashFlowRow struct {
+	OutletID *uint
+	Type     string
+	Amount   float64
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+)
+
+// ServiceEstimateRepository interface for the estimate revisions of service jobs
+type ServiceEstimateRepository interface {
+	// CreateRevision stores the estimate with its items as the next version of its job and marks the revisions
+	// still waiting for the customer Digantikan
+	CreateRevision(ctx context.Context, estimate *models.ServiceEstimate) error
+	GetByID(ctx context.Context, id uint) (*models.ServiceEstimate, error)
+	// GetByServiceJobID retrieves the revisions of a job, newest first
+	GetByServiceJobID(ctx context.Context, serviceJobID uint) ([]*models.ServiceEstimate, error)
+	// GetLatestApproved retrieves the newest approved revision of a job, nil when none is approved
+	GetLatestApproved(ctx context.Context, serviceJobID uint) (*models.ServiceEstimate, error)
+	// Decide stores the decision set on the estimate, false when it no longer waits for the customer
+	Decide(ctx context.Context, estimate *models.ServiceEstimate) (bool, error)
+}
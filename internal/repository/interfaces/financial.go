@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 	"time"
 )
@@ -13,7 +14,7 @@ type PaymentMethodRepository interface {
 	GetByName(ctx context.Context, name string) (*models.PaymentMethod, error)
 	Update(ctx context.Context, method *models.PaymentMethod) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.PaymentMethod, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.PaymentMethod, int64, error)
 	GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.PaymentMethod, error)
 }
 
@@ -23,7 +24,7 @@ type PaymentRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.Payment, error)
 	Update(ctx context.Context, payment *models.Payment) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Payment, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Payment, int64, error)
 	GetByTransactionID(ctx context.Context, transactionID uint) ([]*models.Payment, error)
 	GetByMethodID(ctx context.Context, methodID uint) ([]*models.Payment, error)
 	GetByStatus(ctx context.Context, status models.TransactionStatus) ([]*models.Payment, error)
@@ -36,12 +37,14 @@ type AccountsPayableRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.AccountsPayable, error)
 	Update(ctx context.Context, payable *models.AccountsPayable) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.AccountsPayable, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.AccountsPayable, int64, error)
 	GetByPurchaseOrderID(ctx context.Context, purchaseOrderID uint) ([]*models.AccountsPayable, error)
 	GetBySupplierID(ctx context.Context, supplierID uint) ([]*models.AccountsPayable, error)
 	GetByStatus(ctx context.Context, status models.APARStatus) ([]*models.AccountsPayable, error)
 	GetOverdue(ctx context.Context) ([]*models.AccountsPayable, error)
 	UpdateAmountPaid(ctx context.Context, id uint, amount float64) error
+	// MarkOverdue flags the given entries as overdue at, entries flagged before keep their time
+	MarkOverdue(ctx context.Context, ids []uint, at time.Time) (int64, error)
 }
 
 // PayablePaymentRepository interface for payable payment operations
@@ -50,7 +53,7 @@ type PayablePaymentRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.PayablePayment, error)
 	Update(ctx context.Context, payment *models.PayablePayment) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.PayablePayment, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.PayablePayment, int64, error)
 	GetByPayableID(ctx context.Context, payableID uint) ([]*models.PayablePayment, error)
 	GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.PayablePayment, error)
 }
@@ -61,12 +64,16 @@ type AccountsReceivableRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.AccountsReceivable, error)
 	Update(ctx context.Context, receivable *models.AccountsReceivable) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.AccountsReceivable, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.AccountsReceivable, int64, error)
 	GetByTransactionID(ctx context.Context, transactionID uint) ([]*models.AccountsReceivable, error)
 	GetByCustomerID(ctx context.Context, customerID uint) ([]*models.AccountsReceivable, error)
 	GetByStatus(ctx context.Context, status models.APARStatus) ([]*models.AccountsReceivable, error)
 	GetOverdue(ctx context.Context) ([]*models.AccountsReceivable, error)
+	// GetDueBetween retrieves unpaid entries due in [from, to) with customer and transaction, days as YYYY-MM-DD
+	GetDueBetween(ctx context.Context, from, to string) ([]*models.AccountsReceivable, error)
 	UpdateAmountPaid(ctx context.Context, id uint, amount float64) error
+	// MarkOverdue flags the given entries as overdue at, entries flagged before keep their time
+	MarkOverdue(ctx context.Context, ids []uint, at time.Time) (int64, error)
 }
 
 // ReceivablePaymentRepository interface for receivable payment operations
@@ -75,7 +82,7 @@ type ReceivablePaymentRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.ReceivablePayment, error)
 	Update(ctx context.Context, payment *models.ReceivablePayment) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.ReceivablePayment, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.ReceivablePayment, int64, error)
 	GetByReceivableID(ctx context.Context, receivableID uint) ([]*models.ReceivablePayment, error)
 	GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.ReceivablePayment, error)
 }
@@ -86,7 +93,7 @@ type CashFlowRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.CashFlow, error)
 	Update(ctx context.Context, cashFlow *models.CashFlow) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.CashFlow, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.CashFlow, int64, error)
 	GetByUserID(ctx context.Context, userID uint) ([]*models.CashFlow, error)
 	GetByType(ctx context.Context, cashFlowType models.CashFlowType) ([]*models.CashFlow, error)
 	GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.CashFlow, error)
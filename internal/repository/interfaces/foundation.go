@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 )
 
@@ -12,8 +13,10 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.User, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.User, int64, error)
 	GetByOutletID(ctx context.Context, outletID uint) ([]*models.User, error)
+	AttachRoles(ctx context.Context, userID uint, roleIDs []uint) error
+	DetachRoles(ctx context.Context, userID uint, roleIDs []uint) error
 }
 
 // OutletRepository interface for outlet operations
@@ -22,7 +25,7 @@ type OutletRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.Outlet, error)
 	Update(ctx context.Context, outlet *models.Outlet) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Outlet, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Outlet, int64, error)
 	GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Outlet, error)
 }
 
@@ -33,7 +36,7 @@ type RoleRepository interface {
 	GetByName(ctx context.Context, name string) (*models.Role, error)
 	Update(ctx context.Context, role *models.Role) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Role, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Role, int64, error)
 	AttachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
 	DetachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
 }
@@ -45,6 +48,6 @@ type PermissionRepository interface {
 	GetByName(ctx context.Context, name string) (*models.Permission, error)
 	Update(ctx context.Context, permission *models.Permission) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Permission, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Permission, int64, error)
 	GetByRoleID(ctx context.Context, roleID uint) ([]*models.Permission, error)
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 )
 
@@ -13,13 +14,13 @@ type ProductRepository interface {
 	GetByBarcode(ctx context.Context, barcode string) (*models.Product, error)
 	Update(ctx context.Context, product *models.Product) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Product, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Product, int64, error)
 	GetByCategoryID(ctx context.Context, categoryID uint) ([]*models.Product, error)
 	GetBySupplierID(ctx context.Context, supplierID uint) ([]*models.Product, error)
-	GetByUsageStatus(ctx context.Context, status models.ProductUsageStatus) ([]*models.Product, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.Product, error)
 	UpdateStock(ctx context.Context, productID uint, quantity int) error
-	GetLowStock(ctx context.Context, threshold int) ([]*models.Product, error)
+	SetCostPrice(ctx context.Context, productID uint, costPrice float64) (bool, error)
+	GetLowStock(ctx context.Context, threshold int, q *query.ListQuery) ([]*models.Product, int64, error)
 }
 
 // ProductSerialNumberRepository interface for product serial number operations
@@ -29,7 +30,7 @@ type ProductSerialNumberRepository interface {
 	GetBySerialNumber(ctx context.Context, serialNumber string) (*models.ProductSerialNumber, error)
 	Update(ctx context.Context, serialNumber *models.ProductSerialNumber) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.ProductSerialNumber, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.ProductSerialNumber, int64, error)
 	GetByProductID(ctx context.Context, productID uint) ([]*models.ProductSerialNumber, error)
 	GetByStatus(ctx context.Context, status models.SNStatus) ([]*models.ProductSerialNumber, error)
 	UpdateStatus(ctx context.Context, id uint, status models.SNStatus) error
@@ -42,7 +43,7 @@ type CategoryRepository interface {
 	GetByName(ctx context.Context, name string) (*models.Category, error)
 	Update(ctx context.Context, category *models.Category) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Category, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Category, int64, error)
 	GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Category, error)
 }
 
@@ -53,7 +54,7 @@ type SupplierRepository interface {
 	GetByName(ctx context.Context, name string) (*models.Supplier, error)
 	Update(ctx context.Context, supplier *models.Supplier) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Supplier, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Supplier, int64, error)
 	GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Supplier, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.Supplier, error)
 }
@@ -65,6 +66,15 @@ type UnitTypeRepository interface {
 	GetByName(ctx context.Context, name string) (*models.UnitType, error)
 	Update(ctx context.Context, unitType *models.UnitType) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.UnitType, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.UnitType, int64, error)
 	GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.UnitType, error)
-}
\ No newline at end of file
+}
+// StockMovementRepository interface for stock ledger operations
+type StockMovementRepository interface {
+	Record(ctx context.Context, movement *models.StockMovement) error
+	GetByID(ctx context.Context, id uint) (*models.StockMovement, error)
+	GetByProductID(ctx context.Context, productID uint, limit, offset int) ([]*models.StockMovement, error)
+	GetStockTotals(ctx context.Context) (map[uint]int, error)
+	// GetOpeningBalances returns the products whose ledger starts from an opening entry, one referencing "opening"
+	GetOpeningBalances(ctx context.Context) (map[uint]bool, error)
+}
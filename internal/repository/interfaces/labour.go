@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// LabourLineRow is a service line of a job with the standard time of its service
+type LabourLineRow struct {
+	DetailID        uint
+	ServiceJobID    uint
+	ServiceID       uint
+	ServiceName     string
+	Description     string
+	Quantity        int
+	StandardMinutes int
+}
+
+// LabourRepository interface for the clocked time of service jobs
+type LabourRepository interface {
+	// Create stores an entry; a second open Kerja entry of a technician fails on the open work index
+	Create(ctx context.Context, entry *models.LabourEntry) error
+	// Close stores the EndedAt, Finished and Notes set on the entry when it is still open, false when it is not
+	Close(ctx context.Context, entry *models.LabourEntry) (bool, error)
+	// ClosePauses ends the open Jeda entries of the job at the given time; with a line only those of the line and
+	// of the whole job
+	ClosePauses(ctx context.Context, serviceJobID uint, detailID *uint, at time.Time) error
+	// GetOpenWork retrieves the open Kerja entry of a technician
+	GetOpenWork(ctx context.Context, technicianID uint) (*models.LabourEntry, error)
+	// GetByServiceJobIDs retrieves the entries of the jobs with their technician, by start
+	GetByServiceJobIDs(ctx context.Context, serviceJobIDs []uint) ([]*models.LabourEntry, error)
+	// GetStartedBetween retrieves the entries started in [from, to) of the jobs of an outlet, of all outlets when nil
+	GetStartedBetween(ctx context.Context, from, to time.Time, outletID *uint) ([]*models.LabourEntry, error)
+	// GetServiceLines retrieves the service lines of the jobs with the standard time of their service
+	GetServiceLines(ctx context.Context, serviceJobIDs []uint) ([]LabourLineRow, error)
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+)
+
+// NotificationRepository interface for the notification queue and the customers' channel opt-outs
+type NotificationRepository interface {
+	// Create queues a notification; when one with the same dedupe key exists nothing is stored and it reports false
+	Create(ctx context.Context, notification *models.Notification) (bool, error)
+	GetByID(ctx context.Context, id uint) (*models.Notification, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Notification, int64, error)
+	// ClaimNext moves the oldest due Pending notification to Diproses and counts the attempt, nil when none is due
+	ClaimNext(ctx context.Context, now time.Time) (*models.Notification, error)
+	MarkSent(ctx context.Context, id uint, providerMessageID string, sentAt time.Time) error
+	// MarkFailed records a failed attempt: with retryAt the notification is queued again, otherwise it fails for good
+	MarkFailed(ctx context.Context, id uint, message string, retryAt *time.Time) error
+	// Requeue puts a Gagal notification back into the queue with fresh attempts, false when it is not Gagal
+	Requeue(ctx context.Context, id uint) (bool, error)
+	// RequeueStale puts notifications left in Diproses since before back into the queue
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+
+	GetOptOuts(ctx context.Context, customerID uint) ([]models.NotificationOptOut, error)
+	// SetOptOut adds or removes the opt-out of a customer from a channel
+	SetOptOut(ctx context.Context, customerID uint, channel models.NotificationChannel, optOut bool, by *uint) error
+}
@@ -0,0 +1,46 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// ReplenishmentRepository interface for the reorder points and stock figures replenishment is planned from
+type ReplenishmentRepository interface {
+	// Candidates returns the products with a reorder point at the outlets that set one, and without outletID also the
+	// products planned against their total stock at their own reorder point
+	Candidates(ctx context.Context, outletID *uint) ([]ReplenishmentCandidate, error)
+	// Consumption sums the stock sold and used by service jobs since the given time per product and outlet
+	Consumption(ctx context.Context, since time.Time, outletID *uint) ([]ProductOutletQuantity, error)
+	// OnOrder sums the quantities on draft and pending purchase orders per product and outlet
+	OnOrder(ctx context.Context, outletID *uint) ([]ProductOutletQuantity, error)
+	// Committed sums the special orders on order or reserved for service jobs per product and outlet
+	Committed(ctx context.Context, outletID *uint) ([]ProductOutletQuantity, error)
+	GetStockLevels(ctx context.Context, productID uint) ([]*models.ProductStockLevel, error)
+	// SaveStockLevel creates the stock level of the product at the outlet or updates the existing one
+	SaveStockLevel(ctx context.Context, level *models.ProductStockLevel) error
+	DeleteStockLevel(ctx context.Context, productID, outletID uint) error
+}
+
+// ReplenishmentCandidate is a product with its reorder point at one outlet, OutletID is nil for products planned
+// against their total stock; OnHand is the stock of the outlet or the total stock
+type ReplenishmentCandidate struct {
+	OutletID        *uint
+	ProductID       uint
+	ProductName     string
+	SKU             *string
+	SupplierID      *uint
+	SupplierName    *string
+	CostPrice       float64
+	MinStock        int
+	ReorderQuantity int
+	OnHand          int64
+}
+
+// ProductOutletQuantity is a quantity of one product at one outlet, OutletID is nil for stock not booked to an outlet
+type ProductOutletQuantity struct {
+	ProductID uint
+	OutletID  *uint
+	Quantity  int64
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 	"time"
 )
@@ -13,7 +14,7 @@ type ReportRepository interface {
 	GetByName(ctx context.Context, name string) (*models.Report, error)
 	Update(ctx context.Context, report *models.Report) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Report, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Report, int64, error)
 	GetByUserID(ctx context.Context, userID uint) ([]*models.Report, error)
 	GetByOutletID(ctx context.Context, outletID uint) ([]*models.Report, error)
 	GetByType(ctx context.Context, reportType models.ReportTypeEnum) ([]*models.Report, error)
@@ -21,6 +22,62 @@ type ReportRepository interface {
 	GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Report, error)
 	UpdateStatus(ctx context.Context, id uint, status models.ReportStatus) error
 	UpdateFilePath(ctx context.Context, id uint, filePath string) error
+
+	// Generation queue
+	ClaimNext(ctx context.Context, now time.Time) (*models.Report, error)
+	MarkCompleted(ctx context.Context, id uint, filePath string, completedAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, message string, retryAt *time.Time) error
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+
+	// Report data
+	SalesRows(ctx context.Context, filter ReportFilter) ([]SalesReportRow, error)
+	CashFlowRows(ctx context.Context, filter ReportFilter) ([]CashFlowReportRow, error)
+	InventoryRows(ctx context.Context, filter ReportFilter) ([]InventoryReportRow, error)
+}
+
+// ReportFilter selects the data of a report: [StartDate, EndDate) and optionally one outlet
+type ReportFilter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	OutletID  *uint
+}
+
+// SalesReportRow is one transaction with its totals
+type SalesReportRow struct {
+	TransactionID   uint
+	InvoiceNumber   string
+	TransactionDate time.Time
+	TransactionType string
+	Status          string
+	OutletName      string
+	CashierName     string
+	CustomerName    string
+	ItemCount       int
+	Total           float64
+}
+
+// CashFlowReportRow is one cash flow entry with the name of the user who recorded it
+type CashFlowReportRow struct {
+	CashFlowID uint
+	Date       time.Time
+	Type       string
+	Source     string
+	Notes      string
+	UserName   string
+	Amount     float64
+}
+
+// InventoryReportRow is the current stock of a product with its movements in the report period
+type InventoryReportRow struct {
+	ProductID    uint
+	ProductName  string
+	SKU          string
+	CategoryName string
+	Stock        int
+	CostPrice    float64
+	SellingPrice float64
+	QuantityIn   int
+	QuantityOut  int
 }
 
 // PromotionRepository interface for promotion operations
@@ -30,7 +87,9 @@ type PromotionRepository interface {
 	GetByName(ctx context.Context, name string) (*models.Promotion, error)
 	Update(ctx context.Context, promotion *models.Promotion) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Promotion, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Promotion, int64, error)
 	GetActive(ctx context.Context) ([]*models.Promotion, error)
 	GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Promotion, error)
+	// ExpireEnded soft deletes the promotions that ended before the given time
+	ExpireEnded(ctx context.Context, before time.Time) (int64, error)
 }
\ No newline at end of file
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+)
+
+// SchedulerRepository interface for scheduled job locks and run history
+type SchedulerRepository interface {
+	// AcquireLock takes the lock of a job for owner until the given time. It fails while another owner holds an
+	// unexpired lock and, for a scheduled run, when the slot was already run; it reports whether the lock was taken.
+	AcquireLock(ctx context.Context, jobName, owner string, slot *time.Time, until time.Time) (bool, error)
+	// ReleaseLock frees the lock of a job if owner still holds it
+	ReleaseLock(ctx context.Context, jobName, owner string) error
+
+	CreateRun(ctx context.Context, run *models.JobRun) error
+	UpdateRun(ctx context.Context, run *models.JobRun) error
+	GetRun(ctx context.Context, id uint) (*models.JobRun, error)
+	ListRuns(ctx context.Context, q *query.ListQuery) ([]*models.JobRun, int64, error)
+	// LastRuns retrieves the latest run of every job
+	LastRuns(ctx context.Context) (map[string]*models.JobRun, error)
+	// LastSuccess retrieves the latest successful run of a job, nil when it never succeeded
+	LastSuccess(ctx context.Context, jobName string) (*models.JobRun, error)
+	// FailStale fails the runs still in Diproses that started before the given time
+	FailStale(ctx context.Context, before time.Time, message string) (int64, error)
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "context"
+
+// SequenceRepository interface for the counters document numbers are made from
+type SequenceRepository interface {
+	// Next increments the counter of the document type and scope, starting at 1, and returns its new value.
+	// Concurrent calls never get the same value.
+	Next(ctx context.Context, name, scope string) (int64, error)
+}
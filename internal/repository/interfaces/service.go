@@ -2,7 +2,9 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
+	"time"
 )
 
 // ServiceRepository interface for service operations
@@ -12,7 +14,7 @@ type ServiceRepository interface {
 	GetByServiceCode(ctx context.Context, serviceCode string) (*models.Service, error)
 	Update(ctx context.Context, service *models.Service) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Service, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Service, int64, error)
 	GetByCategoryID(ctx context.Context, categoryID uint) ([]*models.Service, error)
 	GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Service, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.Service, error)
@@ -25,7 +27,7 @@ type ServiceCategoryRepository interface {
 	GetByName(ctx context.Context, name string) (*models.ServiceCategory, error)
 	Update(ctx context.Context, category *models.ServiceCategory) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.ServiceCategory, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.ServiceCategory, int64, error)
 	GetByStatus(ctx context.Context, status models.StatusUmum) ([]*models.ServiceCategory, error)
 }
 
@@ -34,16 +36,23 @@ type ServiceJobRepository interface {
 	Create(ctx context.Context, serviceJob *models.ServiceJob) error
 	GetByID(ctx context.Context, id uint) (*models.ServiceJob, error)
 	GetByServiceCode(ctx context.Context, serviceCode string) (*models.ServiceJob, error)
+	GetByTrackingToken(ctx context.Context, token string) (*models.ServiceJob, error)
 	Update(ctx context.Context, serviceJob *models.ServiceJob) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.ServiceJob, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.ServiceJob, int64, error)
 	GetByCustomerID(ctx context.Context, customerID uint) ([]*models.ServiceJob, error)
 	GetByVehicleID(ctx context.Context, vehicleID uint) ([]*models.ServiceJob, error)
 	GetByTechnicianID(ctx context.Context, technicianID uint) ([]*models.ServiceJob, error)
 	GetByOutletID(ctx context.Context, outletID uint) ([]*models.ServiceJob, error)
 	GetByStatus(ctx context.Context, status models.ServiceStatusEnum) ([]*models.ServiceJob, error)
 	UpdateStatus(ctx context.Context, id uint, status models.ServiceStatusEnum) error
-	GetQueueNumber(ctx context.Context, outletID uint) (int, error)
+	// GetDueReminders retrieves jobs whose next service reminder date is in [from, to), days as YYYY-MM-DD
+	GetDueReminders(ctx context.Context, from, to string) ([]*models.ServiceJob, error)
+	// GetAwaitingPickup retrieves Selesai jobs not picked up that were finished before the given time
+	GetAwaitingPickup(ctx context.Context, finishedBefore time.Time) ([]*models.ServiceJob, error)
+	// GetBoard retrieves the jobs on the board of the outlet in queue order: queued, in progress and complained
+	// about, and finished since the given time
+	GetBoard(ctx context.Context, outletID uint, finishedSince time.Time) ([]*models.ServiceJob, error)
 }
 
 // ServiceDetailRepository interface for service detail operations
@@ -52,7 +61,7 @@ type ServiceDetailRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.ServiceDetail, error)
 	Update(ctx context.Context, detail *models.ServiceDetail) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.ServiceDetail, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.ServiceDetail, int64, error)
 	GetByServiceJobID(ctx context.Context, serviceJobID uint) ([]*models.ServiceDetail, error)
 	DeleteByServiceJobID(ctx context.Context, serviceJobID uint) error
 }
@@ -61,7 +70,7 @@ type ServiceDetailRepository interface {
 type ServiceJobHistoryRepository interface {
 	Create(ctx context.Context, history *models.ServiceJobHistory) error
 	GetByID(ctx context.Context, id uint) (*models.ServiceJobHistory, error)
-	List(ctx context.Context, limit, offset int) ([]*models.ServiceJobHistory, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.ServiceJobHistory, int64, error)
 	GetByServiceJobID(ctx context.Context, serviceJobID uint) ([]*models.ServiceJobHistory, error)
 	GetByUserID(ctx context.Context, userID uint) ([]*models.ServiceJobHistory, error)
 }
\ No newline at end of file
@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// SpecialOrderFilter narrows the special orders listed, zero values do not filter
+type SpecialOrderFilter struct {
+	ServiceJobID    uint
+	OutletID        uint
+	PurchaseOrderID uint
+	Statuses        []models.SpecialOrderStatus
+}
+
+// SpecialOrderRepository interface for the parts ordered for service jobs
+type SpecialOrderRepository interface {
+	// Create stores the orders in one transaction
+	Create(ctx context.Context, orders []*models.SpecialOrder) error
+	GetByID(ctx context.Context, id uint) (*models.SpecialOrder, error)
+	// List retrieves the orders with their product and job, oldest first
+	List(ctx context.Context, filter SpecialOrderFilter) ([]*models.SpecialOrder, error)
+	// SetStatus moves the orders still in one of the from statuses to the given status, set on the orders, with their
+	// PurchaseOrderID, DetailID and ReceivedAt; it returns the number of orders moved
+	SetStatus(ctx context.Context, orders []*models.SpecialOrder, from ...models.SpecialOrderStatus) (int64, error)
+	// UseReserved marks up to quantity of the product reserved for the job as used by the part line, oldest orders
+	// first; an order only partly used is split so the rest stays reserved
+	UseReserved(ctx context.Context, serviceJobID, productID, detailID uint, quantity int, at time.Time) error
+	// ReturnUsed reserves the orders used by the part line again, for a line that is deleted or issued again
+	ReturnUsed(ctx context.Context, detailID uint, at time.Time) error
+	// Release puts the orders still on the purchase order back to Diminta, for a discarded draft
+	Release(ctx context.Context, purchaseOrderID uint) error
+}
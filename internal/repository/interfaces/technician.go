@@ -0,0 +1,39 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+)
+
+// TechnicianFilter narrows the technician profiles listed
+type TechnicianFilter struct {
+	// OutletID keeps the technicians of the outlet and those without outlet, 0 for all
+	OutletID uint
+	Active   *bool
+}
+
+// JobServiceRow is a service requested for a service job: a service line of the job, or a service booked with the
+// appointment the job was checked in from
+type JobServiceRow struct {
+	ServiceJobID      uint
+	ServiceID         uint
+	ServiceCategoryID uint
+	StandardMinutes   int
+	Quantity          int
+	FromAppointment   bool
+}
+
+// TechnicianRepository interface for the technician profiles with their skills and shifts
+type TechnicianRepository interface {
+	// Save creates or updates the profile and replaces its skills and shifts with profile.Skills and profile.Shifts
+	Save(ctx context.Context, profile *models.TechnicianProfile) error
+	// GetByUserID retrieves the profile of a user with the user, skills and shifts
+	GetByUserID(ctx context.Context, userID uint) (*models.TechnicianProfile, error)
+	// List retrieves the profiles of users that are not deleted, by user ID
+	List(ctx context.Context, filter TechnicianFilter) ([]*models.TechnicianProfile, error)
+
+	// GetOpenJobs retrieves the Antri, Menunggu Sparepart, Dikerjakan and Komplain jobs assigned to the technicians
+	GetOpenJobs(ctx context.Context, technicianIDs []uint) ([]*models.ServiceJob, error)
+	// GetJobServices retrieves the services requested for the jobs, from their service lines and appointments
+	GetJobServices(ctx context.Context, serviceJobIDs []uint) ([]JobServiceRow, error)
+}
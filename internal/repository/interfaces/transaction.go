@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 	"time"
 )
@@ -13,7 +14,8 @@ type TransactionRepository interface {
 	GetByInvoiceNumber(ctx context.Context, invoiceNumber string) (*models.Transaction, error)
 	Update(ctx context.Context, transaction *models.Transaction) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.Transaction, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.Transaction, int64, error)
+	ListByCursor(ctx context.Context, q *query.ListQuery) ([]*models.Transaction, string, error)
 	GetByCustomerID(ctx context.Context, customerID uint) ([]*models.Transaction, error)
 	GetByUserID(ctx context.Context, userID uint) ([]*models.Transaction, error)
 	GetByOutletID(ctx context.Context, outletID uint) ([]*models.Transaction, error)
@@ -27,7 +29,7 @@ type TransactionDetailRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.TransactionDetail, error)
 	Update(ctx context.Context, detail *models.TransactionDetail) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.TransactionDetail, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.TransactionDetail, int64, error)
 	GetByTransactionID(ctx context.Context, transactionID uint) ([]*models.TransactionDetail, error)
 	GetByProductID(ctx context.Context, productID uint) ([]*models.TransactionDetail, error)
 	DeleteByTransactionID(ctx context.Context, transactionID uint) error
@@ -39,8 +41,13 @@ type PurchaseOrderRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.PurchaseOrder, error)
 	GetByPOCode(ctx context.Context, poCode string) (*models.PurchaseOrder, error)
 	Update(ctx context.Context, purchaseOrder *models.PurchaseOrder) error
+	// UpdateStatus moves the purchase order from one status to another, false when it is no longer in the from status
+	UpdateStatus(ctx context.Context, id uint, from, to models.PurchaseStatus) (bool, error)
+	// UpdateWithDetails saves the purchase order and replaces its details with PurchaseOrderDetails in one transaction
+	UpdateWithDetails(ctx context.Context, purchaseOrder *models.PurchaseOrder) error
+	// Delete deletes a purchase order together with its details
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.PurchaseOrder, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.PurchaseOrder, int64, error)
 	GetBySupplierID(ctx context.Context, supplierID uint) ([]*models.PurchaseOrder, error)
 	GetByOutletID(ctx context.Context, outletID uint) ([]*models.PurchaseOrder, error)
 	GetByStatus(ctx context.Context, status models.PurchaseStatus) ([]*models.PurchaseOrder, error)
@@ -53,7 +60,7 @@ type PurchaseOrderDetailRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.PurchaseOrderDetail, error)
 	Update(ctx context.Context, detail *models.PurchaseOrderDetail) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.PurchaseOrderDetail, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.PurchaseOrderDetail, int64, error)
 	GetByPurchaseOrderID(ctx context.Context, purchaseOrderID uint) ([]*models.PurchaseOrderDetail, error)
 	GetByProductID(ctx context.Context, productID uint) ([]*models.PurchaseOrderDetail, error)
 	DeleteByPurchaseOrderID(ctx context.Context, purchaseOrderID uint) error
@@ -66,7 +73,7 @@ type VehiclePurchaseRepository interface {
 	GetByPurchaseCode(ctx context.Context, purchaseCode string) (*models.VehiclePurchase, error)
 	Update(ctx context.Context, purchase *models.VehiclePurchase) error
 	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, limit, offset int) ([]*models.VehiclePurchase, error)
+	List(ctx context.Context, q *query.ListQuery) ([]*models.VehiclePurchase, int64, error)
 	GetByCustomerID(ctx context.Context, customerID uint) ([]*models.VehiclePurchase, error)
 	GetByUserID(ctx context.Context, userID uint) ([]*models.VehiclePurchase, error)
 	GetByOutletID(ctx context.Context, outletID uint) ([]*models.VehiclePurchase, error)
@@ -3,6 +3,7 @@ package repository
 import (
 	"boilerplate/internal/repository/implementations"
 	"boilerplate/internal/repository/interfaces"
+	"context"
 
 	"gorm.io/gorm"
 )
@@ -25,6 +26,9 @@ type RepositoryManager struct {
 	Category            interfaces.CategoryRepository
 	Supplier            interfaces.SupplierRepository
 	UnitType            interfaces.UnitTypeRepository
+	StockMovement       interfaces.StockMovementRepository
+	Costing             interfaces.CostingRepository
+	Replenishment       interfaces.ReplenishmentRepository
 
 	// Services
 	Service         interfaces.ServiceRepository
@@ -52,6 +56,41 @@ type RepositoryManager struct {
 	// Reporting & Promotions
 	Report    interfaces.ReportRepository
 	Promotion interfaces.PromotionRepository
+	Analytics interfaces.AnalyticsRepository
+	Dashboard interfaces.DashboardRepository
+
+	// Audit
+	AuditLog interfaces.AuditLogRepository
+
+	// Scheduler
+	Scheduler interfaces.SchedulerRepository
+
+	// Notifications
+	Notification interfaces.NotificationRepository
+
+	// Estimates
+	ServiceEstimate interfaces.ServiceEstimateRepository
+
+	// Appointments
+	Appointment    interfaces.AppointmentRepository
+	OutletSchedule interfaces.OutletScheduleRepository
+
+	// Document numbering
+	Sequence interfaces.SequenceRepository
+
+	// Live board
+	ServiceJobEvent interfaces.ServiceJobEventRepository
+
+	// Technicians
+	Technician interfaces.TechnicianRepository
+
+	// Labour time
+	Labour interfaces.LabourRepository
+
+	// Special orders
+	SpecialOrder interfaces.SpecialOrderRepository
+
+	db *gorm.DB
 }
 
 // NewRepositoryManager creates a new repository manager with all repositories
@@ -73,6 +112,9 @@ func NewRepositoryManager(db *gorm.DB) *RepositoryManager {
 		Category:            implementations.NewCategoryRepository(db),
 		Supplier:            implementations.NewSupplierRepository(db),
 		UnitType:            implementations.NewUnitTypeRepository(db),
+		StockMovement:       implementations.NewStockMovementRepository(db),
+		Costing:             implementations.NewCostingRepository(db),
+		Replenishment:       implementations.NewReplenishmentRepository(db),
 
 		// Services
 		Service:           implementations.NewServiceRepository(db),
@@ -84,12 +126,63 @@ func NewRepositoryManager(db *gorm.DB) *RepositoryManager {
 		// Transactions
 		Transaction:           implementations.NewTransactionRepository(db),
 		TransactionDetail:     implementations.NewTransactionDetailRepository(db),
+		PurchaseOrder:         implementations.NewPurchaseOrderRepository(db),
+		PurchaseOrderDetail:   implementations.NewPurchaseOrderDetailRepository(db),
 
 		// Financial
 		PaymentMethod:       implementations.NewPaymentMethodRepository(db),
 		Payment:             implementations.NewPaymentRepository(db),
 		CashFlow:            implementations.NewCashFlowRepository(db),
+		AccountsPayable:     implementations.NewAccountsPayableRepository(db),
+		AccountsReceivable:  implementations.NewAccountsReceivableRepository(db),
+
+		// Reporting
+		Report:    implementations.NewReportRepository(db),
+		Analytics: implementations.NewAnalyticsRepository(db),
+		Dashboard: implementations.NewDashboardRepository(db),
+		Promotion: implementations.NewPromotionRepository(db),
+
+		// Audit
+		AuditLog: implementations.NewAuditLogRepository(db),
+
+		// Scheduler
+		Scheduler: implementations.NewSchedulerRepository(db),
+
+		// Notifications
+		Notification: implementations.NewNotificationRepository(db),
+
+		// Estimates
+		ServiceEstimate: implementations.NewServiceEstimateRepository(db),
+
+		// Appointments
+		Appointment:    implementations.NewAppointmentRepository(db),
+		OutletSchedule: implementations.NewOutletScheduleRepository(db),
+
+		// Document numbering
+		Sequence: implementations.NewSequenceRepository(db),
+
+		// Live board
+		ServiceJobEvent: implementations.NewServiceJobEventRepository(db),
+
+		// Technicians
+		Technician: implementations.NewTechnicianRepository(db),
+
+		// Labour time
+		Labour: implementations.NewLabourRepository(db),
+
+		// Special orders
+		SpecialOrder: implementations.NewSpecialOrderRepository(db),
 
 		// Add other repositories as they are implemented
+
+		db: db,
 	}
+}
+
+// Atomic runs fn with repositories bound to one database transaction, which commits when fn returns nil and rolls
+// back otherwise. Repositories that open their own transaction run it as a savepoint of this one.
+func (m *RepositoryManager) Atomic(ctx context.Context, fn func(tx *RepositoryManager) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositoryManager(tx))
+	})
 }
\ No newline at end of file
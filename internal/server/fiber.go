@@ -6,10 +6,15 @@ import (
 	"boilerplate/internal/middleware"
 	"boilerplate/internal/repository"
 	"boilerplate/internal/usecase"
+	"boilerplate/internal/worker"
 	"boilerplate/internal/wrapper/handler"
 	repo_wrapper "boilerplate/internal/wrapper/repository"
 	usecase_wrapper "boilerplate/internal/wrapper/usecase"
 	"boilerplate/pkg/infra/db"
+	"boilerplate/pkg/notify"
+	"boilerplate/pkg/storage"
+	"boilerplate/pkg/utils"
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -23,6 +28,8 @@ func Run(conf *config.Config, dbList *db.DatabaseList, appLoger *logrus.Logger)
 
 	//* Initial Engine
 	engine := html.New("./views", ".html")
+	engine.AddFunc("rupiah", utils.FormatRupiah)
+	engine.AddFunc("tanggal", utils.FormatTanggal)
 
 	//* Initial Fiber App
 	app := fiber.New(fiber.Config{
@@ -30,6 +37,7 @@ func Run(conf *config.Config, dbList *db.DatabaseList, appLoger *logrus.Logger)
 		ServerHeader: "Go Fiber",
 		Views:        engine,
 		BodyLimit:    conf.App.BodyLimit * 1024 * 1024,
+		ErrorHandler: middleware.ErrorHandler,
 	})
 
 	//* Initial Data Middleware
@@ -38,6 +46,7 @@ func Run(conf *config.Config, dbList *db.DatabaseList, appLoger *logrus.Logger)
 	//* General Middleware
 	middleware.CORSMiddleware()
 	middleware.DefaultLimitterMiddleware()
+	middleware.ActorMiddleware()
 	//middleware.RecoverMiddleware()
 
 	//* Initial New Architecture (Repository -> Usecase -> Handler)
@@ -45,15 +54,59 @@ func Run(conf *config.Config, dbList *db.DatabaseList, appLoger *logrus.Logger)
 	// Initialize new repository manager
 	repoManager := repository.NewRepositoryManager(dbList.DatabaseApp)
 	
+	// Initialize file storage (local disk or GCS, see CloudStorage.Driver)
+	store, err := storage.FromConfig(context.Background(), conf)
+	if err != nil {
+		log.Fatalf("storage: %v", err)
+	}
+
+	// Initialize the senders of the notification channels (WhatsApp, SMS, email)
+	senders, err := notify.FromConfig(conf.Notification)
+	if err != nil {
+		log.Fatalf("notify: %v", err)
+	}
+
 	// Initialize new usecase manager
-	usecaseManager := usecase.NewUsecaseManager(repoManager)
+	usecaseManager := usecase.NewUsecaseManager(repoManager, store, senders, conf)
+
+	// Start the background report workers
+	worker.NewReportWorker(usecaseManager.Report, conf.Report, appLoger).Start(context.Background())
+
+	// Start the replenishment job that drafts purchase orders for low stock
+	worker.NewReplenishmentWorker(usecaseManager.Replenishment, conf.Replenishment, appLoger).Start(context.Background())
+
+	// Start the scheduler of the timed jobs (overdue accounts, reminders, daily reports, ...)
+	worker.NewSchedulerWorker(usecaseManager.Scheduler, conf.Scheduler, appLoger).Start(context.Background())
+
+	// Start the delivery of queued customer notifications
+	worker.NewNotificationWorker(usecaseManager.Notification, conf.Notification, appLoger).Start(context.Background())
 	
 	// Setup new routes
 	routes.SetupFoundationRoutes(app, usecaseManager)
 	routes.SetupCustomerRoutes(app, usecaseManager)
 	routes.SetupInventoryRoutes(app, usecaseManager)
+	routes.SetupCostingRoutes(app, usecaseManager)
+	routes.SetupReplenishmentRoutes(app, usecaseManager)
 	routes.SetupServiceRoutes(app, usecaseManager)
 	routes.SetupFinancialRoutes(app, usecaseManager)
+	routes.SetupAuditRoutes(app, usecaseManager)
+	routes.SetupReportRoutes(app, usecaseManager)
+	routes.SetupAnalyticsRoutes(app, usecaseManager)
+	routes.SetupDashboardRoutes(app, usecaseManager)
+	routes.SetupSchedulerRoutes(app, usecaseManager)
+	routes.SetupNotificationRoutes(app, usecaseManager)
+	routes.SetupTrackingRoutes(app, usecaseManager)
+	routes.SetupEstimateRoutes(app, usecaseManager)
+	routes.SetupAppointmentRoutes(app, usecaseManager)
+	routes.SetupBoardRoutes(app, usecaseManager)
+	routes.SetupTechnicianRoutes(app, usecaseManager)
+	routes.SetupLabourRoutes(app, usecaseManager)
+	routes.SetupSpecialOrderRoutes(app, usecaseManager)
+
+	// Serve public files of the local storage, GCS serves them from the bucket
+	if local, ok := store.(*storage.Local); ok {
+		app.Static("/files", local.PublicDir())
+	}
 	
 	// Health check endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -0,0 +1,292 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultTopItemsLimit is the length of a top sellers ranking when no limit is requested
+const defaultTopItemsLimit = 10
+
+// AnalyticsUsecase implements the analytics usecase interface
+type AnalyticsUsecase struct {
+	repo   *repository.RepositoryManager
+	labour config.LabourAccount
+}
+
+// NewAnalyticsUsecase creates a new analytics usecase, labour commission is paid as configured in labour
+func NewAnalyticsUsecase(repo *repository.RepositoryManager, labour config.LabourAccount) interfaces.AnalyticsUsecase {
+	return &AnalyticsUsecase{repo: repo, labour: labourConfig(labour)}
+}
+
+// SalesSummary returns revenue, COGS, margin, transaction count and average ticket per bucket with the totals
+func (u *AnalyticsUsecase) SalesSummary(ctx context.Context, req interfaces.SalesAnalyticsRequest) (*interfaces.SalesAnalytics, error) {
+	filter, err := u.analyticsFilter(ctx, req.StartDate, req.EndDate, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	period := repoInterfaces.AnalyticsPeriod(req.Period)
+	dimension := repoInterfaces.AnalyticsDimension(req.GroupBy)
+	rows, err := u.repo.Analytics.SalesSummary(ctx, filter, period, dimension)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &interfaces.SalesAnalytics{
+		StartDate: filter.StartDate.Local().Format("2006-01-02"),
+		EndDate:   filter.EndDate.Local().AddDate(0, 0, -1).Format("2006-01-02"),
+		Period:    req.Period,
+		GroupBy:   req.GroupBy,
+		Rows:      make([]interfaces.SalesFigures, 0, len(rows)),
+	}
+	for _, row := range rows {
+		result.Rows = append(result.Rows, salesFigures(row))
+	}
+
+	// A transaction paid with several methods appears in several rows, so the totals are queried on their own
+	if period == repoInterfaces.AnalyticsPeriodNone && dimension == repoInterfaces.AnalyticsDimensionNone {
+		if len(rows) == 1 {
+			result.Totals = result.Rows[0]
+		}
+		return result, nil
+	}
+	totals, err := u.repo.Analytics.SalesSummary(ctx, filter, repoInterfaces.AnalyticsPeriodNone, repoInterfaces.AnalyticsDimensionNone)
+	if err != nil {
+		return nil, err
+	}
+	if len(totals) == 1 {
+		result.Totals = salesFigures(totals[0])
+	}
+	return result, nil
+}
+
+// TopProducts ranks the products sold in the date range
+func (u *AnalyticsUsecase) TopProducts(ctx context.Context, req interfaces.TopItemsRequest) (*interfaces.TopItems, error) {
+	return u.topItems(ctx, req, u.repo.Analytics.TopProducts)
+}
+
+// TopServices ranks the services performed in the date range
+func (u *AnalyticsUsecase) TopServices(ctx context.Context, req interfaces.TopItemsRequest) (*interfaces.TopItems, error) {
+	return u.topItems(ctx, req, u.repo.Analytics.TopServices)
+}
+
+// TechnicianPerformance returns jobs completed, queue and repair times, comebacks, revenue and commission
+// per technician or outlet
+func (u *AnalyticsUsecase) TechnicianPerformance(ctx context.Context, req interfaces.TechnicianPerformanceRequest) (*interfaces.TechnicianPerformance, error) {
+	filter, err := u.analyticsFilter(ctx, req.StartDate, req.EndDate, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = performanceByTechnician
+	}
+	rows, totals, err := workshopPerformance(ctx, u.repo, filter, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.TechnicianPerformance{
+		StartDate: filter.StartDate.Local().Format("2006-01-02"),
+		EndDate:   filter.EndDate.Local().AddDate(0, 0, -1).Format("2006-01-02"),
+		GroupBy:   groupBy,
+		Rows:      rows,
+		Totals:    totals,
+	}, nil
+}
+
+// LabourEfficiency returns the clocked and paused time, the standard time of the finished lines and the time-based
+// commission per technician or service
+func (u *AnalyticsUsecase) LabourEfficiency(ctx context.Context, req interfaces.LabourEfficiencyRequest) (*interfaces.LabourEfficiency, error) {
+	filter, err := u.analyticsFilter(ctx, req.StartDate, req.EndDate, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = labourByTechnician
+	}
+	rows, totals, err := labourEfficiency(ctx, u.repo, filter, groupBy, u.labour)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.LabourEfficiency{
+		StartDate:       filter.StartDate.Local().Format("2006-01-02"),
+		EndDate:         filter.EndDate.Local().AddDate(0, 0, -1).Format("2006-01-02"),
+		GroupBy:         groupBy,
+		CommissionBasis: u.labour.CommissionBasis,
+		Rows:            rows,
+		Totals:          totals,
+	}, nil
+}
+
+// StockAnalysis classifies products as fast, slow or dead moving and by ABC, with the capital tied up per
+// category and supplier
+func (u *AnalyticsUsecase) StockAnalysis(ctx context.Context, req interfaces.StockAnalysisRequest) (*interfaces.StockAnalysis, error) {
+	startDate := req.StartDate
+	if startDate == "" {
+		end := truncateDay(time.Now())
+		if req.EndDate != "" {
+			parsed, err := parseDate("end_date", req.EndDate)
+			if err != nil {
+				return nil, err
+			}
+			end = parsed
+		}
+		startDate = end.AddDate(0, 0, 1-defaultStockAnalysisDays).Format("2006-01-02")
+	}
+	filter, err := u.analyticsFilter(ctx, startDate, req.EndDate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	deadDays := req.DeadDays
+	if deadDays <= 0 {
+		deadDays = defaultDeadStockDays
+	}
+	minTurnover := req.MinTurnover
+	if minTurnover <= 0 {
+		minTurnover = defaultMinTurnover
+	}
+
+	result, err := stockAnalysis(ctx, u.repo, filter, deadDays, minTurnover)
+	if err != nil {
+		return nil, err
+	}
+	if req.Class != "" || req.ABC != "" {
+		products := result.Products[:0]
+		for _, product := range result.Products {
+			if (req.Class == "" || product.Class == req.Class) && (req.ABC == "" || product.ABC == req.ABC) {
+				products = append(products, product)
+			}
+		}
+		result.Products = products
+	}
+	return result, nil
+}
+
+func (u *AnalyticsUsecase) topItems(ctx context.Context, req interfaces.TopItemsRequest,
+	find func(context.Context, repoInterfaces.AnalyticsFilter, repoInterfaces.AnalyticsRank, int) ([]repoInterfaces.TopItemRow, error)) (*interfaces.TopItems, error) {
+	filter, err := u.analyticsFilter(ctx, req.StartDate, req.EndDate, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	rank := repoInterfaces.AnalyticsRank(req.By)
+	if rank == "" {
+		rank = repoInterfaces.AnalyticsRankQuantity
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTopItemsLimit
+	}
+
+	rows, err := find(ctx, filter, rank, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &interfaces.TopItems{
+		StartDate: filter.StartDate.Local().Format("2006-01-02"),
+		EndDate:   filter.EndDate.Local().AddDate(0, 0, -1).Format("2006-01-02"),
+		By:        string(rank),
+		Items:     make([]interfaces.TopItem, 0, len(rows)),
+	}
+	for i, row := range rows {
+		margin := row.Revenue - row.COGS
+		result.Items = append(result.Items, interfaces.TopItem{
+			Rank:          i + 1,
+			ItemID:        row.ItemID,
+			Name:          row.Name,
+			Quantity:      row.Quantity,
+			Revenue:       roundAmount(row.Revenue),
+			COGS:          roundAmount(row.COGS),
+			GrossMargin:   roundAmount(margin),
+			MarginPercent: percent(margin, row.Revenue),
+		})
+	}
+	return result, nil
+}
+
+// analyticsFilter turns the requested days into a half-open range in local time, by default the current month
+func (u *AnalyticsUsecase) analyticsFilter(ctx context.Context, startDate, endDate string, outletID *uint) (repoInterfaces.AnalyticsFilter, error) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	end := truncateDay(now)
+
+	var err error
+	if startDate != "" {
+		if start, err = parseDate("start_date", startDate); err != nil {
+			return repoInterfaces.AnalyticsFilter{}, err
+		}
+	}
+	if endDate != "" {
+		if end, err = parseDate("end_date", endDate); err != nil {
+			return repoInterfaces.AnalyticsFilter{}, err
+		}
+	}
+	if end.Before(start) {
+		return repoInterfaces.AnalyticsFilter{}, interfaces.ErrAnalyticsDateRange
+	}
+
+	if outletID != nil {
+		if _, err := u.repo.Outlet.GetByID(ctx, *outletID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repoInterfaces.AnalyticsFilter{}, interfaces.ErrOutletNotFound
+			}
+			return repoInterfaces.AnalyticsFilter{}, err
+		}
+	}
+
+	// Bounds are passed in UTC, SQLite compares timestamps as text
+	_, offset := start.Zone()
+	return repoInterfaces.AnalyticsFilter{
+		StartDate: start.UTC(),
+		EndDate:   end.AddDate(0, 0, 1).UTC(),
+		OutletID:  outletID,
+		UTCOffset: offset,
+	}, nil
+}
+
+func salesFigures(row repoInterfaces.SalesSummaryRow) interfaces.SalesFigures {
+	margin := row.Revenue - row.COGS
+	figures := interfaces.SalesFigures{
+		Period:           row.Period,
+		DimensionID:      row.DimensionID,
+		DimensionName:    row.DimensionName,
+		Revenue:          roundAmount(row.Revenue),
+		COGS:             roundAmount(row.COGS),
+		GrossMargin:      roundAmount(margin),
+		MarginPercent:    percent(margin, row.Revenue),
+		TransactionCount: row.TransactionCount,
+	}
+	if row.TransactionCount > 0 {
+		figures.AverageTicket = roundAmount(row.Revenue / float64(row.TransactionCount))
+	}
+	return figures
+}
+
+// roundAmount rounds to cents, payment splits leave fractions behind
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// percent returns part as a percentage of whole with two decimals, 0 when whole is 0
+func percent(part, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(part/whole*10000) / 100
+}
@@ -0,0 +1,841 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Defaults used when the Appointment config leaves a value at zero
+const (
+	defaultAppointmentSlotMinutes  = 30
+	defaultAppointmentMinutes      = 60
+	defaultAppointmentOpensAt      = "08:00"
+	defaultAppointmentClosesAt     = "17:00"
+	defaultAppointmentMaxDaysAhead = 60
+	defaultNoShowGraceMinutes      = 30
+	defaultNoShowLookbackDays      = 90
+)
+
+// maxCalendarDays is the longest range the calendar returns at once
+const maxCalendarDays = 62
+
+// AppointmentUsecase implements the appointment usecase interface. Times are taken in the server's local time zone,
+// the one the outlets' opening hours are in.
+type AppointmentUsecase struct {
+	repo       *repository.RepositoryManager
+	serviceJob interfaces.ServiceJobUsecase
+	conf       config.AppointmentAccount
+}
+
+// NewAppointmentUsecase creates the appointment usecase; checked in appointments become jobs through serviceJob
+func NewAppointmentUsecase(repo *repository.RepositoryManager, serviceJob interfaces.ServiceJobUsecase, conf config.AppointmentAccount) interfaces.AppointmentUsecase {
+	if conf.SlotMinutes <= 0 {
+		conf.SlotMinutes = defaultAppointmentSlotMinutes
+	}
+	if conf.DefaultMinutes <= 0 {
+		conf.DefaultMinutes = defaultAppointmentMinutes
+	}
+	if _, err := time.Parse("15:04", conf.OpensAt); err != nil {
+		conf.OpensAt = defaultAppointmentOpensAt
+	}
+	if _, err := time.Parse("15:04", conf.ClosesAt); err != nil {
+		conf.ClosesAt = defaultAppointmentClosesAt
+	}
+	if conf.MaxDaysAhead <= 0 {
+		conf.MaxDaysAhead = defaultAppointmentMaxDaysAhead
+	}
+	if conf.NoShowGraceMinutes <= 0 {
+		conf.NoShowGraceMinutes = defaultNoShowGraceMinutes
+	}
+	if conf.NoShowLookbackDays <= 0 {
+		conf.NoShowLookbackDays = defaultNoShowLookbackDays
+	}
+	return &AppointmentUsecase{repo: repo, serviceJob: serviceJob, conf: conf}
+}
+
+// CreateAppointment books the vehicle into a slot of the outlet where a bay, and the technician when asked for, is free
+func (u *AppointmentUsecase) CreateAppointment(ctx context.Context, req interfaces.CreateAppointmentRequest) (*models.Appointment, error) {
+	if _, err := u.repo.Outlet.GetByID(ctx, req.OutletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+	if _, err := u.repo.Customer.GetByID(ctx, req.CustomerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrCustomerNotFound
+		}
+		return nil, err
+	}
+	if err := u.checkVehicle(ctx, req.VehicleID, req.CustomerID); err != nil {
+		return nil, err
+	}
+	if err := u.checkTechnician(ctx, req.TechnicianID); err != nil {
+		return nil, err
+	}
+	if err := u.checkNoShows(ctx, req.CustomerID); err != nil {
+		return nil, err
+	}
+	services, err := u.appointmentServices(ctx, req.ServiceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment := &models.Appointment{
+		OutletID:        req.OutletID,
+		CustomerID:      req.CustomerID,
+		VehicleID:       req.VehicleID,
+		TechnicianID:    req.TechnicianID,
+		StartAt:         req.StartAt,
+		DurationMinutes: u.duration(req.DurationMinutes, services),
+		Status:          models.AppointmentStatusDijadwalkan,
+		Notes:           req.Notes,
+		Services:        services,
+	}
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		appointment.CreatedBy = &actor.UserID
+	}
+
+	err = u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if err := u.checkSlot(ctx, tx, appointment, true); err != nil {
+			return err
+		}
+		return tx.Appointment.Create(ctx, appointment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// GetAppointment returns an appointment with its services
+func (u *AppointmentUsecase) GetAppointment(ctx context.Context, id uint) (*models.Appointment, error) {
+	appointment, err := u.repo.Appointment.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrAppointmentNotFound
+		}
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// ListAppointments lists appointments matching the list query
+func (u *AppointmentUsecase) ListAppointments(ctx context.Context, q *query.ListQuery) ([]*models.Appointment, int64, error) {
+	return u.repo.Appointment.List(ctx, q)
+}
+
+// UpdateAppointment reschedules a Dijadwalkan appointment; the new slot is checked as for a new booking
+func (u *AppointmentUsecase) UpdateAppointment(ctx context.Context, id uint, req interfaces.UpdateAppointmentRequest) (*models.Appointment, error) {
+	appointment, err := u.GetAppointment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status != models.AppointmentStatusDijadwalkan {
+		return nil, interfaces.ErrAppointmentNotScheduled
+	}
+
+	if req.VehicleID != nil {
+		if err := u.checkVehicle(ctx, *req.VehicleID, appointment.CustomerID); err != nil {
+			return nil, err
+		}
+		appointment.VehicleID = *req.VehicleID
+	}
+	if req.TechnicianID != nil {
+		if err := u.checkTechnician(ctx, req.TechnicianID); err != nil {
+			return nil, err
+		}
+		appointment.TechnicianID = req.TechnicianID
+	}
+	if req.ServiceIDs != nil {
+		services, err := u.appointmentServices(ctx, *req.ServiceIDs)
+		if err != nil {
+			return nil, err
+		}
+		appointment.Services = services
+		if req.DurationMinutes == nil {
+			appointment.DurationMinutes = u.duration(nil, services)
+		}
+	}
+	if req.DurationMinutes != nil {
+		appointment.DurationMinutes = u.duration(req.DurationMinutes, appointment.Services)
+	}
+	if req.Notes != nil {
+		appointment.Notes = req.Notes
+	}
+
+	// An appointment kept at its start may have begun already, only a new start must lie ahead
+	moved := req.StartAt != nil && !req.StartAt.Equal(appointment.StartAt)
+	if moved {
+		appointment.StartAt = *req.StartAt
+	}
+	err = u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if err := u.checkSlot(ctx, tx, appointment, moved); err != nil {
+			return err
+		}
+		return tx.Appointment.Update(ctx, appointment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// CancelAppointment cancels a Dijadwalkan appointment, freeing its slot
+func (u *AppointmentUsecase) CancelAppointment(ctx context.Context, id uint, req interfaces.CancelAppointmentRequest) (*models.Appointment, error) {
+	appointment, err := u.GetAppointment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status != models.AppointmentStatusDijadwalkan {
+		return nil, interfaces.ErrAppointmentNotScheduled
+	}
+
+	now := time.Now()
+	appointment.Status = models.AppointmentStatusDibatalkan
+	appointment.CancelledAt = &now
+	appointment.CancelReason = &req.Reason
+	if err := u.transition(ctx, appointment, models.AppointmentStatusDijadwalkan); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// MarkNoShow marks a Dijadwalkan appointment Tidak Datang once its start and the grace period passed
+func (u *AppointmentUsecase) MarkNoShow(ctx context.Context, id uint) (*models.Appointment, error) {
+	appointment, err := u.GetAppointment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status != models.AppointmentStatusDijadwalkan {
+		return nil, interfaces.ErrAppointmentNotScheduled
+	}
+	now := time.Now()
+	if now.Before(appointment.StartAt.Add(u.grace())) {
+		return nil, interfaces.ErrAppointmentNotDue
+	}
+
+	appointment.Status = models.AppointmentStatusTidakDatang
+	appointment.NoShowAt = &now
+	if err := u.transition(ctx, appointment, models.AppointmentStatusDijadwalkan); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// CheckIn marks the appointment Datang and creates its service job with appointment priority, so it is served ahead
+// of the walk-ins queued that day. The appointment goes back to Dijadwalkan when the job cannot be created.
+func (u *AppointmentUsecase) CheckIn(ctx context.Context, id uint, req interfaces.CheckInAppointmentRequest) (*models.Appointment, error) {
+	appointment, err := u.GetAppointment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status != models.AppointmentStatusDijadwalkan {
+		return nil, interfaces.ErrAppointmentNotScheduled
+	}
+	// A job whose booked technician is no longer active is checked in unassigned, to be assigned from the suggestions
+	technicianID := appointment.TechnicianID
+	if technicianID != nil {
+		if err := checkActiveTechnician(ctx, u.repo, *technicianID); err != nil {
+			if !errors.Is(err, interfaces.ErrNotATechnician) && !errors.Is(err, interfaces.ErrTechnicianNotFound) {
+				return nil, err
+			}
+			technicianID = nil
+		}
+	}
+
+	// Claiming the appointment first keeps two desks from creating two jobs for it
+	now := time.Now()
+	appointment.Status = models.AppointmentStatusDatang
+	appointment.ArrivedAt = &now
+	if err := u.transition(ctx, appointment, models.AppointmentStatusDijadwalkan); err != nil {
+		return nil, err
+	}
+
+	estimatedCompletion := now.Add(time.Duration(appointment.DurationMinutes) * time.Minute)
+	jobReq := interfaces.CreateServiceJobRequest{
+		CustomerID:            appointment.CustomerID,
+		VehicleID:             appointment.VehicleID,
+		TechnicianID:          technicianID,
+		ReceivedByUserID:      req.ReceivedByUserID,
+		OutletID:              appointment.OutletID,
+		ProblemDescription:    appointmentProblem(appointment),
+		ServiceInDate:         now,
+		EstimatedCompletionAt: &estimatedCompletion,
+		Priority:              models.ServiceJobPriorityAppointment,
+	}
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		jobReq.CreatedBy = &actor.UserID
+	}
+	job, err := u.serviceJob.CreateServiceJob(ctx, jobReq)
+	if err != nil {
+		appointment.Status = models.AppointmentStatusDijadwalkan
+		appointment.ArrivedAt = nil
+		if _, revertErr := u.repo.Appointment.Transition(ctx, appointment, models.AppointmentStatusDatang); revertErr != nil {
+			return nil, fmt.Errorf("%w (returning the appointment to Dijadwalkan failed: %v)", err, revertErr)
+		}
+		return nil, err
+	}
+
+	appointment.ServiceJobID = &job.ServiceJobID
+	if err := u.transition(ctx, appointment, models.AppointmentStatusDatang); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// appointmentProblem describes the job of a checked in appointment: its notes, or the services booked
+func appointmentProblem(appointment *models.Appointment) string {
+	if appointment.Notes != nil && len(strings.TrimSpace(*appointment.Notes)) >= 10 {
+		return strings.TrimSpace(*appointment.Notes)
+	}
+	names := make([]string, 0, len(appointment.Services))
+	for _, service := range appointment.Services {
+		names = append(names, service.Name)
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("Booking servis #%d", appointment.AppointmentID)
+	}
+	return "Booking servis: " + strings.Join(names, ", ")
+}
+
+// GetAvailableSlots lists the slots of an outlet's day an appointment of the asked duration could start in
+func (u *AppointmentUsecase) GetAvailableSlots(ctx context.Context, outletID uint, q interfaces.AvailableSlotsQuery) (*interfaces.AppointmentDay, error) {
+	outlet, err := u.repo.Outlet.GetByID(ctx, outletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+	date, err := parseDate("date", q.Date)
+	if err != nil {
+		return nil, err
+	}
+	if q.TechnicianID != nil {
+		if err := u.checkTechnician(ctx, q.TechnicianID); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule, err := u.loadSchedule(ctx, u.repo, outletID, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	day, opens, closes := schedule.day(date)
+	day.BayCount = outlet.BayCount
+	if !day.Open {
+		return &day, nil
+	}
+
+	duration := time.Duration(u.duration(&q.DurationMinutes, nil)) * time.Minute
+	booked, err := u.repo.Appointment.GetScheduledByOutlet(ctx, outletID, opens, closes)
+	if err != nil {
+		return nil, err
+	}
+	var technicianBooked []*models.Appointment
+	if q.TechnicianID != nil {
+		if technicianBooked, err = u.repo.Appointment.GetScheduledByTechnician(ctx, *q.TechnicianID, opens, closes); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	lastDay := truncateDay(now).AddDate(0, 0, u.conf.MaxDaysAhead+1)
+	day.Slots = []interfaces.AppointmentSlot{}
+	for start := opens; !start.Add(duration).After(closes); start = start.Add(u.slot()) {
+		end := start.Add(duration)
+		slot := interfaces.AppointmentSlot{
+			StartAt:  start,
+			EndAt:    end,
+			FreeBays: outlet.BayCount - busyBays(booked, start, end, 0),
+		}
+		if slot.FreeBays < 0 {
+			slot.FreeBays = 0
+		}
+		slot.Available = slot.FreeBays > 0 && !start.Before(now) && start.Before(lastDay) &&
+			busyBays(technicianBooked, start, end, 0) == 0
+		day.Slots = append(day.Slots, slot)
+	}
+	return &day, nil
+}
+
+// GetCalendar lists the days of an outlet in [from, to] with their hours and appointments in any status
+func (u *AppointmentUsecase) GetCalendar(ctx context.Context, q interfaces.AppointmentCalendarQuery) ([]interfaces.AppointmentDay, error) {
+	from, err := parseDate("from", q.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate("to", q.To)
+	if err != nil {
+		return nil, err
+	}
+	end := to.AddDate(0, 0, 1)
+	if to.Before(from) || end.After(from.AddDate(0, 0, maxCalendarDays)) {
+		return nil, interfaces.ErrCalendarRangeInvalid
+	}
+	outlet, err := u.repo.Outlet.GetByID(ctx, q.OutletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+
+	schedule, err := u.loadSchedule(ctx, u.repo, q.OutletID, from, end)
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := u.repo.Appointment.GetByOutletBetween(ctx, q.OutletID, from, end)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string][]*models.Appointment)
+	for _, appointment := range appointments {
+		date := appointment.StartAt.In(time.Local).Format("2006-01-02")
+		byDate[date] = append(byDate[date], appointment)
+	}
+
+	var days []interfaces.AppointmentDay
+	for date := from; date.Before(end); date = date.AddDate(0, 0, 1) {
+		day, _, _ := schedule.day(date)
+		day.BayCount = outlet.BayCount
+		day.Appointments = byDate[day.Date]
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// GetOpeningHours returns the hours an outlet takes appointments, the default hours when none are set
+func (u *AppointmentUsecase) GetOpeningHours(ctx context.Context, outletID uint) ([]models.OutletOpeningHour, error) {
+	if _, err := u.repo.Outlet.GetByID(ctx, outletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+	hours, err := u.repo.OutletSchedule.GetOpeningHours(ctx, outletID)
+	if err != nil {
+		return nil, err
+	}
+	if len(hours) == 0 {
+		hours = u.defaultHours(outletID)
+	}
+	return hours, nil
+}
+
+// SetOpeningHours replaces the opening hours of an outlet; booked appointments are kept
+func (u *AppointmentUsecase) SetOpeningHours(ctx context.Context, outletID uint, req interfaces.SetOpeningHoursRequest) ([]models.OutletOpeningHour, error) {
+	if _, err := u.repo.Outlet.GetByID(ctx, outletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+
+	hours := make([]models.OutletOpeningHour, 0, len(req.Hours))
+	seen := make(map[int]bool)
+	for _, item := range req.Hours {
+		// "HH:MM" strings order as the times they stand for
+		if seen[item.Weekday] || item.ClosesAt <= item.OpensAt {
+			return nil, interfaces.ErrOpeningHoursInvalid.WithDetails(map[string]interface{}{"weekday": item.Weekday})
+		}
+		seen[item.Weekday] = true
+		hours = append(hours, models.OutletOpeningHour{
+			OutletID: outletID,
+			Weekday:  item.Weekday,
+			OpensAt:  item.OpensAt,
+			ClosesAt: item.ClosesAt,
+		})
+	}
+
+	if err := u.repo.OutletSchedule.ReplaceOpeningHours(ctx, outletID, hours); err != nil {
+		return nil, err
+	}
+	return u.GetOpeningHours(ctx, outletID)
+}
+
+// ListHolidays lists the holidays of an outlet and of all outlets, every holiday without outlet
+func (u *AppointmentUsecase) ListHolidays(ctx context.Context, q interfaces.HolidayQuery) ([]*models.OutletHoliday, error) {
+	from := truncateDay(time.Now())
+	to := from.AddDate(1, 0, 0)
+	var err error
+	if q.From != "" {
+		if from, err = parseDate("from", q.From); err != nil {
+			return nil, err
+		}
+	}
+	if q.To != "" {
+		if to, err = parseDate("to", q.To); err != nil {
+			return nil, err
+		}
+	}
+	return u.repo.OutletSchedule.GetHolidays(ctx, q.OutletID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+}
+
+// CreateHoliday closes a day for an outlet, or for all outlets; booked appointments are kept
+func (u *AppointmentUsecase) CreateHoliday(ctx context.Context, req interfaces.CreateHolidayRequest) (*models.OutletHoliday, error) {
+	if req.OutletID != nil {
+		if _, err := u.repo.Outlet.GetByID(ctx, *req.OutletID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrOutletNotFound
+			}
+			return nil, err
+		}
+	}
+	date, err := parseDate("date", req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	holiday := &models.OutletHoliday{
+		OutletID: req.OutletID,
+		Date:     date,
+		Name:     req.Name,
+	}
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		holiday.CreatedBy = &actor.UserID
+	}
+	if err := u.repo.OutletSchedule.CreateHoliday(ctx, holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+// DeleteHoliday opens a holiday again
+func (u *AppointmentUsecase) DeleteHoliday(ctx context.Context, id uint) error {
+	if _, err := u.repo.OutletSchedule.GetHolidayByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrHolidayNotFound
+		}
+		return err
+	}
+	return u.repo.OutletSchedule.DeleteHoliday(ctx, id)
+}
+
+// MarkNoShows marks the Dijadwalkan appointments whose start and grace period passed before now as Tidak Datang;
+// appointments checked in or cancelled meanwhile are left alone
+func (u *AppointmentUsecase) MarkNoShows(ctx context.Context, now time.Time) (*interfaces.NoShowSummary, error) {
+	due, err := u.repo.Appointment.GetDueNoShows(ctx, now.Add(-u.grace()))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &interfaces.NoShowSummary{}
+	for _, appointment := range due {
+		appointment.Status = models.AppointmentStatusTidakDatang
+		appointment.NoShowAt = &now
+		ok, err := u.repo.Appointment.Transition(ctx, appointment, models.AppointmentStatusDijadwalkan)
+		if err != nil {
+			return summary, err
+		}
+		if !ok {
+			continue
+		}
+		summary.Marked++
+		summary.Items = append(summary.Items, fmt.Sprintf("#%d %s customer %d",
+			appointment.AppointmentID, appointment.StartAt.In(time.Local).Format("2006-01-02 15:04"), appointment.CustomerID))
+	}
+	return summary, nil
+}
+
+// transition stores the status set on the appointment when it is still from
+func (u *AppointmentUsecase) transition(ctx context.Context, appointment *models.Appointment, from models.AppointmentStatus) error {
+	ok, err := u.repo.Appointment.Transition(ctx, appointment, from)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return interfaces.ErrAppointmentNotScheduled
+	}
+	return nil
+}
+
+// checkVehicle checks that the vehicle exists and belongs to the customer
+func (u *AppointmentUsecase) checkVehicle(ctx context.Context, vehicleID, customerID uint) error {
+	vehicle, err := u.repo.CustomerVehicle.GetByID(ctx, vehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrVehicleNotFound
+		}
+		return err
+	}
+	if vehicle.CustomerID != customerID {
+		return interfaces.ErrVehicleNotOwnedByCustomer
+	}
+	return nil
+}
+
+// checkTechnician checks that the technician asked for is an active technician
+func (u *AppointmentUsecase) checkTechnician(ctx context.Context, technicianID *uint) error {
+	if technicianID == nil {
+		return nil
+	}
+	return checkActiveTechnician(ctx, u.repo, *technicianID)
+}
+
+// checkNoShows refuses customers who missed MaxNoShows appointments within the lookback days
+func (u *AppointmentUsecase) checkNoShows(ctx context.Context, customerID uint) error {
+	if u.conf.MaxNoShows <= 0 {
+		return nil
+	}
+	since := time.Now().AddDate(0, 0, -u.conf.NoShowLookbackDays)
+	count, err := u.repo.Appointment.CountNoShows(ctx, customerID, since)
+	if err != nil {
+		return err
+	}
+	if count >= int64(u.conf.MaxNoShows) {
+		return interfaces.ErrAppointmentNoShowLimit.WithDetails(map[string]interface{}{
+			"no_shows":      count,
+			"lookback_days": u.conf.NoShowLookbackDays,
+		})
+	}
+	return nil
+}
+
+// appointmentServices loads the services booked with their name and standard time
+func (u *AppointmentUsecase) appointmentServices(ctx context.Context, serviceIDs []uint) ([]models.AppointmentService, error) {
+	services := make([]models.AppointmentService, 0, len(serviceIDs))
+	for _, id := range serviceIDs {
+		service, err := u.repo.Service.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrServiceNotFound
+			}
+			return nil, err
+		}
+		services = append(services, models.AppointmentService{
+			ServiceID:       service.ServiceID,
+			Name:            service.Name,
+			StandardMinutes: service.StandardMinutes,
+		})
+	}
+	return services, nil
+}
+
+// duration is the minutes asked for, else the standard time of the services, else the default, rounded up to whole
+// slots
+func (u *AppointmentUsecase) duration(minutes *int, services []models.AppointmentService) int {
+	total := 0
+	if minutes != nil && *minutes > 0 {
+		total = *minutes
+	} else {
+		for _, service := range services {
+			total += service.StandardMinutes
+		}
+	}
+	if total <= 0 {
+		total = u.conf.DefaultMinutes
+	}
+	slot := u.conf.SlotMinutes
+	return (total + slot - 1) / slot * slot
+}
+
+func (u *AppointmentUsecase) slot() time.Duration {
+	return time.Duration(u.conf.SlotMinutes) * time.Minute
+}
+
+func (u *AppointmentUsecase) grace() time.Duration {
+	return time.Duration(u.conf.NoShowGraceMinutes) * time.Minute
+}
+
+// checkSlot sets the end of the appointment and checks that it starts on a slot of an open day, ends by closing time
+// and that a bay and its technician are free throughout; checkStart also keeps it within the booking window. repo is
+// the transaction the appointment is written in, the outlet stays locked until it ends so that concurrent bookings
+// cannot both take the last bay.
+func (u *AppointmentUsecase) checkSlot(ctx context.Context, repo *repository.RepositoryManager, appointment *models.Appointment, checkStart bool) error {
+	outlet, err := repo.Appointment.LockOutlet(ctx, appointment.OutletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrOutletNotFound
+		}
+		return err
+	}
+
+	start := appointment.StartAt.In(time.Local)
+	end := start.Add(time.Duration(appointment.DurationMinutes) * time.Minute)
+	appointment.StartAt = start
+	appointment.EndAt = end
+
+	if checkStart {
+		now := time.Now()
+		if start.Before(now) {
+			return interfaces.ErrAppointmentInPast
+		}
+		if !start.Before(truncateDay(now).AddDate(0, 0, u.conf.MaxDaysAhead+1)) {
+			return interfaces.ErrAppointmentTooFarAhead.WithDetails(map[string]interface{}{"max_days_ahead": u.conf.MaxDaysAhead})
+		}
+	}
+
+	date := truncateDay(start)
+	schedule, err := u.loadSchedule(ctx, repo, outlet.OutletID, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	day, opens, closes := schedule.day(date)
+	if !day.Open {
+		if day.HolidayName != "" {
+			return interfaces.ErrOutletClosed.WithDetails(map[string]interface{}{"date": day.Date, "holiday": day.HolidayName})
+		}
+		return interfaces.ErrOutletClosed.WithDetails(map[string]interface{}{"date": day.Date})
+	}
+	if start.Before(opens) || end.After(closes) {
+		return interfaces.ErrAppointmentOutsideHours.WithDetails(map[string]interface{}{"opens_at": day.OpensAt, "closes_at": day.ClosesAt})
+	}
+	if start.Sub(opens)%u.slot() != 0 {
+		return interfaces.ErrAppointmentSlotInvalid.WithDetails(map[string]interface{}{"slot_minutes": u.conf.SlotMinutes})
+	}
+
+	booked, err := repo.Appointment.GetScheduledByOutlet(ctx, outlet.OutletID, start, end)
+	if err != nil {
+		return err
+	}
+	if busyBays(booked, start, end, appointment.AppointmentID) >= outlet.BayCount {
+		return interfaces.ErrAppointmentSlotFull.WithDetails(map[string]interface{}{"bay_count": outlet.BayCount})
+	}
+	if appointment.TechnicianID != nil {
+		booked, err := repo.Appointment.GetScheduledByTechnician(ctx, *appointment.TechnicianID, start, end)
+		if err != nil {
+			return err
+		}
+		if busyBays(booked, start, end, appointment.AppointmentID) > 0 {
+			return interfaces.ErrTechnicianUnavailable
+		}
+	}
+	return nil
+}
+
+// busyBays is the most appointments running at the same moment within [from, to), leaving out exceptID
+func busyBays(appointments []*models.Appointment, from, to time.Time, exceptID uint) int {
+	type event struct {
+		at    time.Time
+		delta int
+	}
+	var events []event
+	for _, appointment := range appointments {
+		if appointment.AppointmentID == exceptID && exceptID != 0 {
+			continue
+		}
+		start, end := appointment.StartAt, appointment.EndAt
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !start.Before(end) {
+			continue
+		}
+		events = append(events, event{start, 1}, event{end, -1})
+	}
+	// An appointment ending frees its bay for one starting at the same time
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at.Equal(events[j].at) {
+			return events[i].delta < events[j].delta
+		}
+		return events[i].at.Before(events[j].at)
+	})
+
+	busy, most := 0, 0
+	for _, e := range events {
+		busy += e.delta
+		if busy > most {
+			most = busy
+		}
+	}
+	return most
+}
+
+// outletSchedule is the opening hours of an outlet and its holidays over a range of days
+type outletSchedule struct {
+	hours    map[int]models.OutletOpeningHour
+	holidays map[string]string // holiday name by "2006-01-02"
+}
+
+// loadSchedule loads the opening hours of an outlet, the default hours when none are set, and its holidays in
+// [from, to)
+func (u *AppointmentUsecase) loadSchedule(ctx context.Context, repo *repository.RepositoryManager, outletID uint, from, to time.Time) (*outletSchedule, error) {
+	hours, err := repo.OutletSchedule.GetOpeningHours(ctx, outletID)
+	if err != nil {
+		return nil, err
+	}
+	if len(hours) == 0 {
+		hours = u.defaultHours(outletID)
+	}
+	holidays, err := repo.OutletSchedule.GetHolidays(ctx, outletID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &outletSchedule{
+		hours:    make(map[int]models.OutletOpeningHour, len(hours)),
+		holidays: make(map[string]string, len(holidays)),
+	}
+	for _, hour := range hours {
+		schedule.hours[hour.Weekday] = hour
+	}
+	for _, holiday := range holidays {
+		schedule.holidays[holiday.Date.Format("2006-01-02")] = holiday.Name
+	}
+	return schedule, nil
+}
+
+// defaultHours are the config hours from Monday to Saturday
+func (u *AppointmentUsecase) defaultHours(outletID uint) []models.OutletOpeningHour {
+	hours := make([]models.OutletOpeningHour, 0, 6)
+	for weekday := time.Monday; weekday <= time.Saturday; weekday++ {
+		hours = append(hours, models.OutletOpeningHour{
+			OutletID: outletID,
+			Weekday:  int(weekday),
+			OpensAt:  u.conf.OpensAt,
+			ClosesAt: u.conf.ClosesAt,
+		})
+	}
+	return hours
+}
+
+// day describes a day of the schedule with its opening and closing time, zero times when the outlet is closed
+func (s *outletSchedule) day(date time.Time) (interfaces.AppointmentDay, time.Time, time.Time) {
+	day := interfaces.AppointmentDay{Date: date.Format("2006-01-02")}
+	if name, ok := s.holidays[day.Date]; ok {
+		day.HolidayName = name
+		return day, time.Time{}, time.Time{}
+	}
+	hours, ok := s.hours[int(date.Weekday())]
+	if !ok {
+		return day, time.Time{}, time.Time{}
+	}
+	opens, err := clockOn(date, hours.OpensAt)
+	if err != nil {
+		return day, time.Time{}, time.Time{}
+	}
+	closes, err := clockOn(date, hours.ClosesAt)
+	if err != nil || !closes.After(opens) {
+		return day, time.Time{}, time.Time{}
+	}
+	day.Open = true
+	day.OpensAt = hours.OpensAt
+	day.ClosesAt = hours.ClosesAt
+	return day, opens, closes
+}
+
+// clockOn is the time of day "15:04" on the date
+func clockOn(date time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, date.Location()), nil
+}
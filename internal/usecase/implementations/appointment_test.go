@@ -0,0 +1,164 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/exception"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestBusyBays(t *testing.T) {
+	at := func(clock string) time.Time {
+		at, _ := clockOn(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.Local), clock)
+		return at
+	}
+	booked := func(id uint, from, to string) *models.Appointment {
+		return &models.Appointment{AppointmentID: id, StartAt: at(from), EndAt: at(to)}
+	}
+	appointments := []*models.Appointment{
+		booked(1, "08:00", "09:00"),
+		booked(2, "08:30", "10:00"),
+		booked(3, "09:00", "10:00"),
+		booked(4, "11:00", "12:00"),
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		exceptID uint
+		want     int
+	}{
+		{"overlap of the first two", "08:00", "09:00", 0, 2},
+		{"one ending frees its bay for one starting", "09:00", "10:00", 0, 2},
+		{"whole morning", "08:00", "12:00", 0, 2},
+		{"the appointment itself is left out", "08:00", "09:00", 2, 1},
+		{"free in between", "10:00", "11:00", 0, 0},
+		{"ending when the range starts", "12:00", "13:00", 0, 0},
+	}
+	for _, tt := range tests {
+		if got := busyBays(appointments, at(tt.from), at(tt.to), tt.exceptID); got != tt.want {
+			t.Errorf("%s: got %d busy bays, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// newAppointmentTestUsecase opens a database with an outlet of two bays, open at the default hours
+func newAppointmentTestUsecase(t *testing.T) (*AppointmentUsecase, *repository.RepositoryManager, *gorm.DB, *models.Outlet) {
+	t.Helper()
+	repo, db := newTestRepository(t)
+	outlet := &models.Outlet{OutletName: "Bengkel Pusat", BranchType: "Pusat", City: "Bandung", BayCount: 2, Status: models.StatusAktif}
+	if err := db.Create(outlet).Error; err != nil {
+		t.Fatalf("create outlet: %v", err)
+	}
+	u := NewAppointmentUsecase(repo, nil, config.AppointmentAccount{}).(*AppointmentUsecase)
+	return u, repo, db, outlet
+}
+
+// nextMonday is a Monday at least a week ahead, within the booking window
+func nextMonday() time.Time {
+	day := truncateDay(time.Now()).AddDate(0, 0, 7)
+	for day.Weekday() != time.Monday {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+func TestCheckSlot(t *testing.T) {
+	u, repo, db, outlet := newAppointmentTestUsecase(t)
+	ctx := context.Background()
+	monday := nextMonday()
+	at := func(day time.Time, hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	technicianID := uint(5)
+	otherTechnicianID := uint(6)
+
+	book := func(start time.Time, minutes int, technicianID *uint) error {
+		appointment := &models.Appointment{
+			OutletID:        outlet.OutletID,
+			CustomerID:      1,
+			VehicleID:       1,
+			TechnicianID:    technicianID,
+			StartAt:         start,
+			DurationMinutes: minutes,
+			Status:          models.AppointmentStatusDijadwalkan,
+		}
+		return repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+			if err := u.checkSlot(ctx, tx, appointment, true); err != nil {
+				return err
+			}
+			return tx.Appointment.Create(ctx, appointment)
+		})
+	}
+
+	holiday := &models.OutletHoliday{OutletID: &outlet.OutletID, Date: monday.AddDate(0, 0, 1), Name: "Libur"}
+	if err := db.Create(holiday).Error; err != nil {
+		t.Fatalf("create holiday: %v", err)
+	}
+
+	steps := []struct {
+		name       string
+		start      time.Time
+		minutes    int
+		technician *uint
+		want       error
+	}{
+		{"first bay", at(monday, 9, 0), 60, &technicianID, nil},
+		{"second bay", at(monday, 9, 30), 60, nil, nil},
+		{"both bays taken", at(monday, 9, 30), 30, nil, interfaces.ErrAppointmentSlotFull},
+		{"the first bay is free when its appointment ends", at(monday, 10, 0), 60, nil, nil},
+		{"the technician is booked", at(monday, 8, 30), 60, &technicianID, interfaces.ErrTechnicianUnavailable},
+		{"another technician", at(monday, 8, 0), 60, &otherTechnicianID, nil},
+		{"not on a slot", at(monday, 13, 10), 30, nil, interfaces.ErrAppointmentSlotInvalid},
+		{"ends after closing", at(monday, 16, 30), 60, nil, interfaces.ErrAppointmentOutsideHours},
+		{"before opening", at(monday, 7, 30), 60, nil, interfaces.ErrAppointmentOutsideHours},
+		{"holiday", at(monday.AddDate(0, 0, 1), 9, 0), 60, nil, interfaces.ErrOutletClosed},
+		{"sunday", at(monday.AddDate(0, 0, -1), 9, 0), 60, nil, interfaces.ErrOutletClosed},
+		{"in the past", at(truncateDay(time.Now()).AddDate(0, 0, -1), 9, 0), 60, nil, interfaces.ErrAppointmentInPast},
+		{"beyond the booking window", at(monday.AddDate(0, 0, 70), 9, 0), 60, nil, interfaces.ErrAppointmentTooFarAhead},
+	}
+	for _, step := range steps {
+		err := book(step.start, step.minutes, step.technician)
+		if step.want == nil && err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if step.want != nil && !errors.Is(err, step.want) {
+			t.Fatalf("%s: got %v, want %v", step.name, err, step.want)
+		}
+	}
+}
+
+func TestAppointmentDatesMalformed(t *testing.T) {
+	u, _, _, outlet := newAppointmentTestUsecase(t)
+	ctx := context.Background()
+
+	check := func(name string, err error) {
+		t.Helper()
+		if !errors.Is(err, interfaces.ErrDateInvalid) || !errors.Is(err, exception.ErrValidation) {
+			t.Fatalf("%s: got %v, want %v", name, err, interfaces.ErrDateInvalid)
+		}
+	}
+	_, err := u.GetAvailableSlots(ctx, outlet.OutletID, interfaces.AvailableSlotsQuery{Date: "2024-02-30"})
+	check("available slots", err)
+	_, err = u.GetCalendar(ctx, interfaces.AppointmentCalendarQuery{OutletID: outlet.OutletID, From: "2024-01-01", To: "next week"})
+	check("calendar", err)
+	_, err = u.CreateHoliday(ctx, interfaces.CreateHolidayRequest{OutletID: &outlet.OutletID, Date: "17/08/2024", Name: "Kemerdekaan"})
+	check("holiday", err)
+	_, err = u.ListHolidays(ctx, interfaces.HolidayQuery{OutletID: outlet.OutletID, From: "2024-13-01"})
+	check("holidays", err)
+
+	day, err := u.GetAvailableSlots(ctx, outlet.OutletID, interfaces.AvailableSlotsQuery{Date: nextMonday().Format("2006-01-02")})
+	if err != nil {
+		t.Fatalf("available slots: %v", err)
+	}
+	if !day.Open || len(day.Slots) == 0 {
+		t.Fatalf("got %+v, want the open slots of a Monday", day)
+	}
+}
@@ -0,0 +1,39 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogUsecase implements the audit log usecase interface
+type AuditLogUsecase struct {
+	repo *repository.RepositoryManager
+}
+
+// NewAuditLogUsecase creates a new audit log usecase
+func NewAuditLogUsecase(repo *repository.RepositoryManager) interfaces.AuditLogUsecase {
+	return &AuditLogUsecase{repo: repo}
+}
+
+// GetAuditLog retrieves an audit log entry by ID
+func (u *AuditLogUsecase) GetAuditLog(ctx context.Context, id uint) (*models.AuditLog, error) {
+	log, err := u.repo.AuditLog.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrAuditLogNotFound
+		}
+		return nil, err
+	}
+	return log, nil
+}
+
+// ListAuditLogs retrieves audit log entries matching the list query
+func (u *AuditLogUsecase) ListAuditLogs(ctx context.Context, q *query.ListQuery) ([]*models.AuditLog, int64, error) {
+	return u.repo.AuditLog.List(ctx, q)
+}
@@ -0,0 +1,342 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultBoardPollSeconds      = 2
+	defaultBoardHeartbeatSeconds = 15
+	defaultBoardRetentionHours   = 24
+	// boardEventBatch is how many events a stream reads at a time
+	boardEventBatch = 100
+)
+
+// boardSignal wakes the streams of this instance when an event is written; streams also poll, for events written
+// by other instances
+type boardSignal struct {
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+// wait returns a channel closed at the next event
+func (s *boardSignal) wait() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.changed
+}
+
+func (s *boardSignal) notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// BoardUsecase implements the board usecase interface
+type BoardUsecase struct {
+	repo      *repository.RepositoryManager
+	signal    *boardSignal
+	poll      time.Duration
+	heartbeat time.Duration
+	retention time.Duration
+}
+
+// NewBoardUsecase creates the board usecase, the service job usecase publishes its events to it
+func NewBoardUsecase(repo *repository.RepositoryManager, conf config.BoardAccount) interfaces.BoardUsecase {
+	u := &BoardUsecase{
+		repo:      repo,
+		signal:    &boardSignal{changed: make(chan struct{})},
+		poll:      time.Duration(conf.PollSeconds) * time.Second,
+		heartbeat: time.Duration(conf.HeartbeatSeconds) * time.Second,
+		retention: time.Duration(conf.RetentionHours) * time.Hour,
+	}
+	if u.poll <= 0 {
+		u.poll = defaultBoardPollSeconds * time.Second
+	}
+	if u.heartbeat <= 0 {
+		u.heartbeat = defaultBoardHeartbeatSeconds * time.Second
+	}
+	if u.retention <= 0 {
+		u.retention = defaultBoardRetentionHours * time.Hour
+	}
+	return u
+}
+
+// PublishServiceJobEvent writes the event with the job as the board shows it now and wakes the streams
+func (u *BoardUsecase) PublishServiceJobEvent(ctx context.Context, event interfaces.ServiceJobEvent) error {
+	job := interfaces.BoardJob{ServiceJobID: event.ServiceJobID}
+	if event.Type != models.ServiceJobEventRemoved {
+		serviceJob, err := u.repo.ServiceJob.GetByID(ctx, event.ServiceJobID)
+		if err != nil {
+			return err
+		}
+		job = toBoardJob(serviceJob)
+	}
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repo.ServiceJobEvent.Create(ctx, &models.ServiceJobEvent{
+		OutletID:     event.OutletID,
+		ServiceJobID: event.ServiceJobID,
+		Type:         event.Type,
+		Payload:      string(payload),
+		CreatedAt:    time.Now(),
+	}); err != nil {
+		return err
+	}
+	u.signal.notify()
+	return nil
+}
+
+// GetBoard returns the jobs on the board of the outlet, finished jobs only of today
+func (u *BoardUsecase) GetBoard(ctx context.Context, outletID uint) ([]interfaces.BoardJob, error) {
+	if _, err := u.repo.Outlet.GetByID(ctx, outletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+	return u.board(ctx, outletID)
+}
+
+func (u *BoardUsecase) board(ctx context.Context, outletID uint) ([]interfaces.BoardJob, error) {
+	serviceJobs, err := u.repo.ServiceJob.GetBoard(ctx, outletID, truncateDay(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]interfaces.BoardJob, 0, len(serviceJobs))
+	for _, serviceJob := range serviceJobs {
+		jobs = append(jobs, toBoardJob(serviceJob))
+	}
+	return jobs, nil
+}
+
+// Subscribe checks the outlet and starts the stream
+func (u *BoardUsecase) Subscribe(ctx context.Context, outletID uint, lastEventID uint) (<-chan interfaces.BoardEvent, error) {
+	if _, err := u.repo.Outlet.GetByID(ctx, outletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+	events := make(chan interfaces.BoardEvent)
+	go u.stream(ctx, outletID, lastEventID, events)
+	return events, nil
+}
+
+// stream sends the events following the cursor, waking on events of this instance and polling for those of others
+func (u *BoardUsecase) stream(ctx context.Context, outletID uint, cursor uint, events chan<- interfaces.BoardEvent) {
+	defer close(events)
+
+	send := func(event interfaces.BoardEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	resume, last, err := u.resumable(ctx, cursor)
+	if err != nil {
+		fmt.Printf("Failed to start board stream of outlet %d: %v\n", outletID, err)
+		return
+	}
+	if !resume {
+		// Events written while the snapshot is read are sent after it, applying them again changes nothing
+		jobs, err := u.board(ctx, outletID)
+		if err != nil {
+			fmt.Printf("Failed to read board of outlet %d: %v\n", outletID, err)
+			return
+		}
+		if !send(interfaces.BoardEvent{ID: last, Type: interfaces.BoardSnapshot, OutletID: outletID, Jobs: jobs, At: time.Now()}) {
+			return
+		}
+		cursor = last
+	}
+
+	poll := time.NewTicker(u.poll)
+	defer poll.Stop()
+	idleSince := time.Now()
+	for {
+		// Wait for the signal before reading, so an event written during the read is not missed
+		changed := u.signal.wait()
+		stored, err := u.repo.ServiceJobEvent.ListAfter(ctx, outletID, cursor, boardEventBatch)
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Printf("Failed to read board events of outlet %d: %v\n", outletID, err)
+			}
+			return
+		}
+		for _, event := range stored {
+			boardEvent, err := toBoardEvent(event)
+			if err != nil {
+				fmt.Printf("Skipping board event %d: %v\n", event.EventID, err)
+			} else if !send(boardEvent) {
+				return
+			}
+			cursor = event.EventID
+			idleSince = time.Now()
+		}
+		if len(stored) == boardEventBatch {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		case <-poll.C:
+			if time.Since(idleSince) >= u.heartbeat {
+				if !send(interfaces.BoardEvent{Type: interfaces.BoardHeartbeat, OutletID: outletID, At: time.Now()}) {
+					return
+				}
+				idleSince = time.Now()
+			}
+		}
+	}
+}
+
+// resumable tells whether all events following the client's last event are still kept, and returns the last event
+// ID to start a snapshot from
+func (u *BoardUsecase) resumable(ctx context.Context, lastEventID uint) (bool, uint, error) {
+	first, last, err := u.repo.ServiceJobEvent.Bounds(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+	// Without events nothing tells whether the client missed some that were pruned, an ID above the last one is
+	// from before the log was reset
+	if lastEventID == 0 || first == 0 || lastEventID+1 < first || lastEventID > last {
+		return false, last, nil
+	}
+	return true, last, nil
+}
+
+// PruneEvents deletes the events older than Board.RetentionHours, clients offline longer get a snapshot
+func (u *BoardUsecase) PruneEvents(ctx context.Context, now time.Time) (int64, error) {
+	return u.repo.ServiceJobEvent.DeleteBefore(ctx, now.Add(-u.retention))
+}
+
+func toBoardEvent(event *models.ServiceJobEvent) (interfaces.BoardEvent, error) {
+	var job interfaces.BoardJob
+	if err := json.Unmarshal([]byte(event.Payload), &job); err != nil {
+		return interfaces.BoardEvent{}, err
+	}
+	return interfaces.BoardEvent{
+		ID:       event.EventID,
+		Type:     event.Type,
+		OutletID: event.OutletID,
+		Job:      &job,
+		At:       event.CreatedAt,
+	}, nil
+}
+
+func toBoardJob(serviceJob *models.ServiceJob) interfaces.BoardJob {
+	job := interfaces.BoardJob{
+		ServiceJobID:          serviceJob.ServiceJobID,
+		ServiceCode:           serviceJob.ServiceCode,
+		QueueNumber:           serviceJob.QueueNumber,
+		Priority:              serviceJob.Priority,
+		Status:                serviceJob.Status,
+		TechnicianID:          serviceJob.TechnicianID,
+		ServiceInDate:         serviceJob.ServiceInDate,
+		EstimatedCompletionAt: serviceJob.EstimatedCompletionAt,
+	}
+	if serviceJob.Vehicle != nil {
+		job.PlateNumber = serviceJob.Vehicle.PlateNumber
+		job.Vehicle = strings.TrimSpace(serviceJob.Vehicle.Brand + " " + serviceJob.Vehicle.Model)
+	}
+	if serviceJob.Customer != nil {
+		job.CustomerName = serviceJob.Customer.Name
+	}
+	if serviceJob.Technician != nil {
+		job.TechnicianName = serviceJob.Technician.Name
+	}
+	return job
+}
+
+// GetPublicBoard returns the board of the outlet as the public queue display shows it
+func (u *BoardUsecase) GetPublicBoard(ctx context.Context, outletID uint) ([]interfaces.PublicBoardJob, error) {
+	jobs, err := u.GetBoard(ctx, outletID)
+	if err != nil {
+		return nil, err
+	}
+	public := make([]interfaces.PublicBoardJob, 0, len(jobs))
+	for _, job := range jobs {
+		public = append(public, toPublicBoardJob(job))
+	}
+	return public, nil
+}
+
+// SubscribePublic streams the board like Subscribe, projected onto the public queue display
+func (u *BoardUsecase) SubscribePublic(ctx context.Context, outletID uint, lastEventID uint) (<-chan interfaces.PublicBoardEvent, error) {
+	events, err := u.Subscribe(ctx, outletID, lastEventID)
+	if err != nil {
+		return nil, err
+	}
+	public := make(chan interfaces.PublicBoardEvent)
+	go func() {
+		defer close(public)
+		for event := range events {
+			projected, ok := toPublicBoardEvent(event)
+			if !ok {
+				continue
+			}
+			select {
+			case public <- projected:
+			case <-ctx.Done():
+				// Drain until the stream notices, it closes events
+				for range events {
+				}
+				return
+			}
+		}
+	}()
+	return public, nil
+}
+
+// toPublicBoardEvent projects a board event onto the public queue display: queue number, masked plate and status.
+// It returns false for events that change nothing the display shows.
+func toPublicBoardEvent(event interfaces.BoardEvent) (interfaces.PublicBoardEvent, bool) {
+	public := interfaces.PublicBoardEvent{ID: event.ID, Type: event.Type}
+	switch event.Type {
+	case models.ServiceJobEventAssigned, models.ServiceJobEventPartsArrived:
+		return public, false
+	case interfaces.BoardSnapshot:
+		public.Jobs = make([]interfaces.PublicBoardJob, 0, len(event.Jobs))
+		for _, job := range event.Jobs {
+			public.Jobs = append(public.Jobs, toPublicBoardJob(job))
+		}
+	default:
+		if event.Job != nil {
+			job := toPublicBoardJob(*event.Job)
+			public.Job = &job
+		}
+	}
+	return public, true
+}
+
+func toPublicBoardJob(job interfaces.BoardJob) interfaces.PublicBoardJob {
+	return interfaces.PublicBoardJob{
+		ID:          job.ServiceJobID,
+		QueueNumber: job.QueueNumber,
+		PlateNumber: utils.GenerateMaskPlateNumber(job.PlateNumber),
+		Status:      job.Status,
+	}
+}
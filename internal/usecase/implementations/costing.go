@@ -0,0 +1,247 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CostingUsecase implements the costing usecase interface
+type CostingUsecase struct {
+	repo *repository.RepositoryManager
+}
+
+// NewCostingUsecase creates a new costing usecase
+func NewCostingUsecase(repo *repository.RepositoryManager) interfaces.CostingUsecase {
+	return &CostingUsecase{repo: repo}
+}
+
+// ReceiveStock books purchased stock into an outlet, every line updates the moving average cost and opens a cost layer
+func (u *CostingUsecase) ReceiveStock(ctx context.Context, req interfaces.StockReceiptRequest) (*interfaces.StockReceipt, error) {
+	if _, err := u.repo.Outlet.GetByID(ctx, req.OutletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+	if req.SupplierID != nil {
+		if _, err := u.repo.Supplier.GetByID(ctx, *req.SupplierID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrSupplierNotFound
+			}
+			return nil, err
+		}
+	}
+
+	notes := "stock receipt"
+	if req.Reference != nil {
+		notes = fmt.Sprintf("stock receipt %s", *req.Reference)
+	}
+	var referenceType *string
+	if req.SupplierID != nil {
+		supplier := "supplier"
+		referenceType = &supplier
+	}
+
+	now := time.Now()
+	movements := make([]*models.StockMovement, 0, len(req.Items))
+	for _, item := range req.Items {
+		if _, err := productUnitCost(ctx, u.repo, item.ProductID); err != nil {
+			return nil, err
+		}
+		movements = append(movements, &models.StockMovement{
+			ProductID:     item.ProductID,
+			OutletID:      &req.OutletID,
+			MovementType:  models.StockMovementPurchase,
+			Quantity:      item.Quantity,
+			UnitCost:      item.UnitCost,
+			ReferenceType: referenceType,
+			ReferenceID:   req.SupplierID,
+			Notes:         &notes,
+			CreatedAt:     now,
+			CreatedBy:     req.CreatedBy,
+		})
+	}
+	if err := u.repo.Costing.Receive(ctx, movements...); err != nil {
+		return nil, err
+	}
+
+	receipt := &interfaces.StockReceipt{
+		OutletID:   req.OutletID,
+		SupplierID: req.SupplierID,
+		Reference:  req.Reference,
+		Lines:      make([]interfaces.StockReceiptLine, 0, len(movements)),
+	}
+	for _, movement := range movements {
+		product, err := u.repo.Product.GetByID(ctx, movement.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		receipt.Lines = append(receipt.Lines, interfaces.StockReceiptLine{
+			MovementID:  movement.MovementID,
+			ProductID:   product.ProductID,
+			ProductName: product.ProductName,
+			Quantity:    movement.Quantity,
+			UnitCost:    movement.UnitCost,
+			Stock:       product.Stock,
+			AverageCost: product.CostPrice,
+		})
+	}
+	return receipt, nil
+}
+
+// ChangeCostMethod switches a product between moving average and FIFO and books the revaluation of its stock
+func (u *CostingUsecase) ChangeCostMethod(ctx context.Context, productID uint, req interfaces.ChangeCostMethodRequest) (*interfaces.CostMethodChange, error) {
+	product, err := u.repo.Product.GetByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrProductNotFound
+		}
+		return nil, err
+	}
+	if product.CostMethod == req.CostMethod {
+		return nil, interfaces.ErrCostMethodUnchanged
+	}
+
+	movements, err := u.repo.Costing.ChangeCostMethod(ctx, productID, req.CostMethod, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &interfaces.CostMethodChange{
+		ProductID: productID,
+		From:      product.CostMethod,
+		To:        req.CostMethod,
+		Movements: movements,
+	}
+	if change.Movements == nil {
+		change.Movements = []*models.StockMovement{}
+	}
+	for _, movement := range movements {
+		change.Adjustment += float64(movement.Quantity) * movement.UnitCost
+	}
+	change.Adjustment = roundAmount(change.Adjustment)
+	return change, nil
+}
+
+// GetCostLayers returns the cost layers of a product that still hold stock, oldest first
+func (u *CostingUsecase) GetCostLayers(ctx context.Context, productID uint) ([]*models.CostLayer, error) {
+	if _, err := productUnitCost(ctx, u.repo, productID); err != nil {
+		return nil, err
+	}
+	return u.repo.Costing.GetOpenLayers(ctx, productID)
+}
+
+// InventoryValuation values the stock of every outlet at the end of a day from the stock ledger
+func (u *CostingUsecase) InventoryValuation(ctx context.Context, req interfaces.InventoryValuationRequest) (*interfaces.InventoryValuation, error) {
+	asOf := truncateDay(time.Now())
+	if req.AsOf != "" {
+		var err error
+		if asOf, err = parseDate("as_of", req.AsOf); err != nil {
+			return nil, err
+		}
+	}
+	if req.OutletID != nil {
+		if _, err := u.repo.Outlet.GetByID(ctx, *req.OutletID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrOutletNotFound
+			}
+			return nil, err
+		}
+	}
+
+	// The bound is passed in UTC, SQLite compares timestamps as text
+	rows, err := u.repo.Costing.Valuation(ctx, asOf.AddDate(0, 0, 1).UTC(), req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation := &interfaces.InventoryValuation{
+		AsOf:    asOf.Format("2006-01-02"),
+		Outlets: []interfaces.OutletValuation{},
+	}
+	for _, row := range rows {
+		last := len(valuation.Outlets) - 1
+		if last < 0 || !sameOutlet(valuation.Outlets[last].OutletID, row.OutletID) {
+			valuation.Outlets = append(valuation.Outlets, interfaces.OutletValuation{
+				OutletID:   row.OutletID,
+				OutletName: row.OutletName,
+			})
+			last++
+		}
+
+		line := interfaces.ValuationLine{
+			ProductID:   row.ProductID,
+			ProductName: row.ProductName,
+			SKU:         row.SKU,
+			CostMethod:  row.CostMethod,
+			Quantity:    row.Quantity,
+			Value:       roundAmount(row.Value),
+		}
+		if row.Quantity != 0 {
+			line.UnitCost = roundAmount(row.Value / float64(row.Quantity))
+		}
+
+		outlet := &valuation.Outlets[last]
+		outlet.Products = append(outlet.Products, line)
+		outlet.Quantity += row.Quantity
+		outlet.Value = roundAmount(outlet.Value + line.Value)
+		valuation.Quantity += row.Quantity
+		valuation.Value = roundAmount(valuation.Value + line.Value)
+	}
+	return valuation, nil
+}
+
+func sameOutlet(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// productUnitCost returns the moving average cost of a product, stock added by hand is valued at it
+func productUnitCost(ctx context.Context, repo *repository.RepositoryManager, productID uint) (float64, error) {
+	product, err := repo.Product.GetByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, interfaces.ErrProductNotFound
+		}
+		return 0, err
+	}
+	return product.CostPrice, nil
+}
+
+// issueStock takes stock out through the costing engine and returns the unit cost it left at
+func issueStock(ctx context.Context, repo *repository.RepositoryManager, movement *models.StockMovement) (float64, error) {
+	if err := repo.Costing.Issue(ctx, movement); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, interfaces.ErrProductNotFound
+		}
+		if errors.Is(err, repoInterfaces.ErrStockReserved) {
+			return 0, interfaces.ErrStockReserved
+		}
+		if errors.Is(err, repoInterfaces.ErrInsufficientStock) {
+			return 0, interfaces.ErrInsufficientStock
+		}
+		return 0, err
+	}
+	return movement.UnitCost, nil
+}
+
+// receiveStock puts stock back in at the movement's unit cost, issued stock returns at the cost it left at
+func receiveStock(ctx context.Context, repo *repository.RepositoryManager, movement *models.StockMovement) error {
+	if err := repo.Costing.Receive(ctx, movement); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrProductNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package implementations
+
+import (
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/exception"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestInventoryValuationRejectsMalformedDate(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	u := NewCostingUsecase(repo)
+
+	_, err := u.InventoryValuation(context.Background(), interfaces.InventoryValuationRequest{AsOf: "31-01-2024"})
+	if !errors.Is(err, interfaces.ErrDateInvalid) || !errors.Is(err, exception.ErrValidation) {
+		t.Fatalf("got %v, want %v", err, interfaces.ErrDateInvalid)
+	}
+	if _, err := u.InventoryValuation(context.Background(), interfaces.InventoryValuationRequest{AsOf: "2024-01-31"}); err != nil {
+		t.Fatalf("valuation as of 2024-01-31: %v", err)
+	}
+}
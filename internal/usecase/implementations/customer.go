@@ -4,6 +4,7 @@ import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
 	"context"
 	"errors"
 	"time"
@@ -26,7 +27,7 @@ func (u *CustomerUsecase) CreateCustomer(ctx context.Context, req interfaces.Cre
 	// Check if phone number already exists
 	existingCustomer, err := u.repo.Customer.GetByPhoneNumber(ctx, req.PhoneNumber)
 	if err == nil && existingCustomer != nil {
-		return nil, errors.New("customer with this phone number already exists")
+		return nil, interfaces.ErrCustomerPhoneExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -41,6 +42,7 @@ func (u *CustomerUsecase) CreateCustomer(ctx context.Context, req interfaces.Cre
 	customer := &models.Customer{
 		Name:        req.Name,
 		PhoneNumber: req.PhoneNumber,
+		Email:       req.Email,
 		Address:     req.Address,
 		Status:      status,
 		CreatedBy:   req.CreatedBy,
@@ -60,7 +62,7 @@ func (u *CustomerUsecase) GetCustomer(ctx context.Context, id uint) (*models.Cus
 	customer, err := u.repo.Customer.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer not found")
+			return nil, interfaces.ErrCustomerNotFound
 		}
 		return nil, err
 	}
@@ -72,7 +74,7 @@ func (u *CustomerUsecase) GetCustomerByPhoneNumber(ctx context.Context, phoneNum
 	customer, err := u.repo.Customer.GetByPhoneNumber(ctx, phoneNumber)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer not found")
+			return nil, interfaces.ErrCustomerNotFound
 		}
 		return nil, err
 	}
@@ -84,7 +86,7 @@ func (u *CustomerUsecase) UpdateCustomer(ctx context.Context, id uint, req inter
 	customer, err := u.repo.Customer.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer not found")
+			return nil, interfaces.ErrCustomerNotFound
 		}
 		return nil, err
 	}
@@ -93,7 +95,7 @@ func (u *CustomerUsecase) UpdateCustomer(ctx context.Context, id uint, req inter
 	if req.PhoneNumber != nil && *req.PhoneNumber != customer.PhoneNumber {
 		existingCustomer, err := u.repo.Customer.GetByPhoneNumber(ctx, *req.PhoneNumber)
 		if err == nil && existingCustomer != nil && existingCustomer.CustomerID != id {
-			return nil, errors.New("customer with this phone number already exists")
+			return nil, interfaces.ErrCustomerPhoneExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -107,6 +109,9 @@ func (u *CustomerUsecase) UpdateCustomer(ctx context.Context, id uint, req inter
 	if req.PhoneNumber != nil {
 		customer.PhoneNumber = *req.PhoneNumber
 	}
+	if req.Email != nil {
+		customer.Email = req.Email
+	}
 	if req.Address != nil {
 		customer.Address = req.Address
 	}
@@ -127,7 +132,7 @@ func (u *CustomerUsecase) DeleteCustomer(ctx context.Context, id uint) error {
 	_, err := u.repo.Customer.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("customer not found")
+			return interfaces.ErrCustomerNotFound
 		}
 		return err
 	}
@@ -138,22 +143,16 @@ func (u *CustomerUsecase) DeleteCustomer(ctx context.Context, id uint) error {
 		return err
 	}
 	if len(vehicles) > 0 {
-		return errors.New("cannot delete customer with existing vehicles")
+		return interfaces.ErrCustomerHasVehicles
 	}
 
 	return u.repo.Customer.Delete(ctx, id)
 }
 
 // ListCustomers retrieves customers with pagination
-func (u *CustomerUsecase) ListCustomers(ctx context.Context, limit, offset int) ([]*models.Customer, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *CustomerUsecase) ListCustomers(ctx context.Context, q *query.ListQuery) ([]*models.Customer, int64, error) {
 
-	return u.repo.Customer.List(ctx, limit, offset)
+	return u.repo.Customer.List(ctx, q)
 }
 
 // GetCustomersByStatus retrieves customers by status
@@ -189,7 +188,7 @@ func (u *CustomerVehicleUsecase) CreateCustomerVehicle(ctx context.Context, req
 	_, err := u.repo.Customer.GetByID(ctx, req.CustomerID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer not found")
+			return nil, interfaces.ErrCustomerNotFound
 		}
 		return nil, err
 	}
@@ -197,7 +196,7 @@ func (u *CustomerVehicleUsecase) CreateCustomerVehicle(ctx context.Context, req
 	// Check if plate number already exists
 	existingVehicle, err := u.repo.CustomerVehicle.GetByPlateNumber(ctx, req.PlateNumber)
 	if err == nil && existingVehicle != nil {
-		return nil, errors.New("vehicle with this plate number already exists")
+		return nil, interfaces.ErrVehiclePlateExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -206,7 +205,7 @@ func (u *CustomerVehicleUsecase) CreateCustomerVehicle(ctx context.Context, req
 	// Check if chassis number already exists
 	existingVehicle, err = u.repo.CustomerVehicle.GetByChassisNumber(ctx, req.ChassisNumber)
 	if err == nil && existingVehicle != nil {
-		return nil, errors.New("vehicle with this chassis number already exists")
+		return nil, interfaces.ErrVehicleChassisExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -215,7 +214,7 @@ func (u *CustomerVehicleUsecase) CreateCustomerVehicle(ctx context.Context, req
 	// Check if engine number already exists
 	existingVehicle, err = u.repo.CustomerVehicle.GetByEngineNumber(ctx, req.EngineNumber)
 	if err == nil && existingVehicle != nil {
-		return nil, errors.New("vehicle with this engine number already exists")
+		return nil, interfaces.ErrVehicleEngineExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -249,7 +248,7 @@ func (u *CustomerVehicleUsecase) GetCustomerVehicle(ctx context.Context, id uint
 	vehicle, err := u.repo.CustomerVehicle.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer vehicle not found")
+			return nil, interfaces.ErrCustomerVehicleNotFound
 		}
 		return nil, err
 	}
@@ -261,7 +260,7 @@ func (u *CustomerVehicleUsecase) GetCustomerVehicleByPlateNumber(ctx context.Con
 	vehicle, err := u.repo.CustomerVehicle.GetByPlateNumber(ctx, plateNumber)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer vehicle not found")
+			return nil, interfaces.ErrCustomerVehicleNotFound
 		}
 		return nil, err
 	}
@@ -273,7 +272,7 @@ func (u *CustomerVehicleUsecase) GetCustomerVehicleByChassisNumber(ctx context.C
 	vehicle, err := u.repo.CustomerVehicle.GetByChassisNumber(ctx, chassisNumber)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer vehicle not found")
+			return nil, interfaces.ErrCustomerVehicleNotFound
 		}
 		return nil, err
 	}
@@ -285,7 +284,7 @@ func (u *CustomerVehicleUsecase) GetCustomerVehicleByEngineNumber(ctx context.Co
 	vehicle, err := u.repo.CustomerVehicle.GetByEngineNumber(ctx, engineNumber)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer vehicle not found")
+			return nil, interfaces.ErrCustomerVehicleNotFound
 		}
 		return nil, err
 	}
@@ -297,7 +296,7 @@ func (u *CustomerVehicleUsecase) UpdateCustomerVehicle(ctx context.Context, id u
 	vehicle, err := u.repo.CustomerVehicle.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer vehicle not found")
+			return nil, interfaces.ErrCustomerVehicleNotFound
 		}
 		return nil, err
 	}
@@ -307,7 +306,7 @@ func (u *CustomerVehicleUsecase) UpdateCustomerVehicle(ctx context.Context, id u
 		_, err := u.repo.Customer.GetByID(ctx, *req.CustomerID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("customer not found")
+				return nil, interfaces.ErrCustomerNotFound
 			}
 			return nil, err
 		}
@@ -317,7 +316,7 @@ func (u *CustomerVehicleUsecase) UpdateCustomerVehicle(ctx context.Context, id u
 	if req.PlateNumber != nil && *req.PlateNumber != vehicle.PlateNumber {
 		existingVehicle, err := u.repo.CustomerVehicle.GetByPlateNumber(ctx, *req.PlateNumber)
 		if err == nil && existingVehicle != nil && existingVehicle.VehicleID != id {
-			return nil, errors.New("vehicle with this plate number already exists")
+			return nil, interfaces.ErrVehiclePlateExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -327,7 +326,7 @@ func (u *CustomerVehicleUsecase) UpdateCustomerVehicle(ctx context.Context, id u
 	if req.ChassisNumber != nil && *req.ChassisNumber != vehicle.ChassisNumber {
 		existingVehicle, err := u.repo.CustomerVehicle.GetByChassisNumber(ctx, *req.ChassisNumber)
 		if err == nil && existingVehicle != nil && existingVehicle.VehicleID != id {
-			return nil, errors.New("vehicle with this chassis number already exists")
+			return nil, interfaces.ErrVehicleChassisExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -337,7 +336,7 @@ func (u *CustomerVehicleUsecase) UpdateCustomerVehicle(ctx context.Context, id u
 	if req.EngineNumber != nil && *req.EngineNumber != vehicle.EngineNumber {
 		existingVehicle, err := u.repo.CustomerVehicle.GetByEngineNumber(ctx, *req.EngineNumber)
 		if err == nil && existingVehicle != nil && existingVehicle.VehicleID != id {
-			return nil, errors.New("vehicle with this engine number already exists")
+			return nil, interfaces.ErrVehicleEngineExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -389,7 +388,7 @@ func (u *CustomerVehicleUsecase) DeleteCustomerVehicle(ctx context.Context, id u
 	_, err := u.repo.CustomerVehicle.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("customer vehicle not found")
+			return interfaces.ErrCustomerVehicleNotFound
 		}
 		return err
 	}
@@ -400,15 +399,9 @@ func (u *CustomerVehicleUsecase) DeleteCustomerVehicle(ctx context.Context, id u
 }
 
 // ListCustomerVehicles retrieves customer vehicles with pagination
-func (u *CustomerVehicleUsecase) ListCustomerVehicles(ctx context.Context, limit, offset int) ([]*models.CustomerVehicle, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *CustomerVehicleUsecase) ListCustomerVehicles(ctx context.Context, q *query.ListQuery) ([]*models.CustomerVehicle, int64, error) {
 
-	return u.repo.CustomerVehicle.List(ctx, limit, offset)
+	return u.repo.CustomerVehicle.List(ctx, q)
 }
 
 // GetCustomerVehiclesByCustomerID retrieves customer vehicles by customer ID
@@ -0,0 +1,406 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// Defaults used when the Dashboard config leaves a value at zero
+const (
+	defaultDashboardCacheTTL          = 30 * time.Second
+	defaultDashboardLowStockThreshold = 5
+)
+
+// DashboardUsecase implements the dashboard usecase interface. Computed dashboards are kept in memory per outlet
+// filter until the cache TTL passes; clients polling the same filter at the same time wait for a single computation
+// instead of each querying the database, while the other filters are served on their own.
+type DashboardUsecase struct {
+	repo              *repository.RepositoryManager
+	ttl               time.Duration
+	lowStockThreshold int
+
+	group singleflight.Group
+	mu    sync.Mutex
+	cache map[string]*dashboardCacheEntry
+}
+
+type dashboardCacheEntry struct {
+	dashboard *interfaces.Dashboard
+	expires   time.Time
+}
+
+// NewDashboardUsecase creates a new dashboard usecase from the Dashboard config
+func NewDashboardUsecase(repo *repository.RepositoryManager, conf config.DashboardAccount) interfaces.DashboardUsecase {
+	u := &DashboardUsecase{
+		repo:              repo,
+		ttl:               time.Duration(conf.CacheSeconds) * time.Second,
+		lowStockThreshold: conf.LowStockThreshold,
+		cache:             make(map[string]*dashboardCacheEntry),
+	}
+	if u.ttl <= 0 {
+		u.ttl = defaultDashboardCacheTTL
+	}
+	if u.lowStockThreshold <= 0 {
+		u.lowStockThreshold = defaultDashboardLowStockThreshold
+	}
+	return u
+}
+
+// GetDashboard returns today's and this month's figures per outlet compared with the previous day and month
+func (u *DashboardUsecase) GetDashboard(ctx context.Context, req interfaces.DashboardRequest) (*interfaces.Dashboard, error) {
+	key := "all"
+	if req.OutletID != nil {
+		key = fmt.Sprintf("outlet:%d", *req.OutletID)
+	}
+
+	if dashboard, ok := u.cached(key, time.Now()); ok {
+		return dashboard, nil
+	}
+
+	result, err, _ := u.group.Do(key, func() (interface{}, error) {
+		// The computation this call waited for may just have filled the cache
+		now := time.Now()
+		if dashboard, ok := u.cached(key, now); ok {
+			return dashboard, nil
+		}
+		dashboard, err := u.buildDashboard(ctx, req.OutletID, now)
+		if err != nil {
+			return nil, err
+		}
+		u.mu.Lock()
+		u.cache[key] = &dashboardCacheEntry{dashboard: dashboard, expires: now.Add(u.ttl)}
+		u.mu.Unlock()
+		return dashboard, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*interfaces.Dashboard), nil
+}
+
+// cached returns the dashboard of the key computed within the cache TTL
+func (u *DashboardUsecase) cached(key string, now time.Time) (*interfaces.Dashboard, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	entry, ok := u.cache[key]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	return entry.dashboard, true
+}
+
+// dashboardRange is a period with the period it is compared with. Timestamps are compared up to the same time of the
+// previous day or month; cash flows only carry a date, so for them the previous period ends on the same day instead.
+type dashboardRange struct {
+	interfaces.DashboardRange
+	days, previousDays [2]time.Time
+}
+
+// dashboardRanges returns today compared with yesterday and this month compared with the same days of last month
+func dashboardRanges(now time.Time) (today, month dashboardRange) {
+	day := truncateDay(now)
+	elapsed := now.Sub(day)
+	today.DashboardRange = interfaces.DashboardRange{
+		Start:         day,
+		End:           now,
+		PreviousStart: day.AddDate(0, 0, -1),
+		PreviousEnd:   day.AddDate(0, 0, -1).Add(elapsed),
+	}
+	today.days = [2]time.Time{day, day.AddDate(0, 0, 1)}
+	today.previousDays = [2]time.Time{day.AddDate(0, 0, -1), day}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	previousStart := monthStart.AddDate(0, -1, 0)
+	// A shorter previous month is compared as a whole
+	previousEnd := previousStart.Add(now.Sub(monthStart))
+	previousDayEnd := previousStart.AddDate(0, 0, now.Day())
+	if previousEnd.After(monthStart) {
+		previousEnd = monthStart
+	}
+	if previousDayEnd.After(monthStart) {
+		previousDayEnd = monthStart
+	}
+	month.DashboardRange = interfaces.DashboardRange{
+		Start:         monthStart,
+		End:           now,
+		PreviousStart: previousStart,
+		PreviousEnd:   previousEnd,
+	}
+	month.days = [2]time.Time{monthStart, day.AddDate(0, 0, 1)}
+	month.previousDays = [2]time.Time{previousStart, previousDayEnd}
+	return today, month
+}
+
+func (u *DashboardUsecase) buildDashboard(ctx context.Context, outletID *uint, now time.Time) (*interfaces.Dashboard, error) {
+	outlets, err := u.dashboardOutlets(ctx, outletID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*interfaces.OutletDashboard, len(outlets))
+	for _, outlet := range outlets {
+		id := outlet.OutletID
+		byID[id] = &interfaces.OutletDashboard{OutletID: &id, OutletName: outlet.OutletName}
+	}
+	// Outlet 0 collects figures not tied to an outlet, it is only listed when it has any
+	bucket := func(id *uint) *interfaces.OutletDashboard {
+		key := uint(0)
+		if id != nil {
+			key = *id
+		}
+		if entry, ok := byID[key]; ok {
+			return entry
+		}
+		entry := &interfaces.OutletDashboard{}
+		if id != nil {
+			outletID := *id
+			entry.OutletID = &outletID
+		}
+		byID[key] = entry
+		return entry
+	}
+
+	today, month := dashboardRanges(now)
+	periods := []struct {
+		rng    dashboardRange
+		period func(*interfaces.OutletDashboard) *interfaces.DashboardPeriod
+	}{
+		{today, func(o *interfaces.OutletDashboard) *interfaces.DashboardPeriod { return &o.Today }},
+		{month, func(o *interfaces.OutletDashboard) *interfaces.DashboardPeriod { return &o.Month }},
+	}
+	for _, p := range periods {
+		period := p.period
+		err := u.addFigures(ctx, p.rng.Start, p.rng.End, p.rng.days, outletID, func(id *uint) *interfaces.DashboardFigures {
+			return &period(bucket(id)).Current
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = u.addFigures(ctx, p.rng.PreviousStart, p.rng.PreviousEnd, p.rng.previousDays, outletID, func(id *uint) *interfaces.DashboardFigures {
+			return &period(bucket(id)).Previous
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Payables and receivables are overdue once their due date has passed
+	day := truncateDay(now)
+	payables, err := u.repo.Dashboard.OverduePayables(ctx, day, outletID)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range payables {
+		total := &bucket(row.OutletID).OverduePayables
+		total.Count += row.Count
+		total.Amount = roundAmount(total.Amount + row.Amount)
+	}
+	receivables, err := u.repo.Dashboard.OverdueReceivables(ctx, day, outletID)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range receivables {
+		total := &bucket(row.OutletID).OverdueReceivables
+		total.Count += row.Count
+		total.Amount = roundAmount(total.Amount + row.Amount)
+	}
+
+	// Stock is kept per product, not per outlet
+	lowStock, err := u.repo.Dashboard.LowStockCount(ctx, u.lowStockThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	// Figures can belong to an outlet that is no longer active
+	for _, outlet := range byID {
+		if outlet.OutletID != nil && outlet.OutletName == "" {
+			found, err := u.repo.Outlet.GetByID(ctx, *outlet.OutletID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			if found != nil {
+				outlet.OutletName = found.OutletName
+			}
+		}
+	}
+
+	dashboard := &interfaces.Dashboard{
+		GeneratedAt:       now,
+		Today:             today.DashboardRange,
+		Month:             month.DashboardRange,
+		LowStockCount:     lowStock,
+		LowStockThreshold: u.lowStockThreshold,
+		Outlets:           make([]interfaces.OutletDashboard, 0, len(byID)),
+	}
+	dashboard.Totals.Today = newDashboardPeriod()
+	dashboard.Totals.Month = newDashboardPeriod()
+	for _, outlet := range byID {
+		finishDashboardPeriod(&outlet.Today)
+		finishDashboardPeriod(&outlet.Month)
+		dashboard.Outlets = append(dashboard.Outlets, *outlet)
+
+		addDashboardPeriod(&dashboard.Totals.Today, outlet.Today)
+		addDashboardPeriod(&dashboard.Totals.Month, outlet.Month)
+		dashboard.Totals.OverduePayables.Count += outlet.OverduePayables.Count
+		dashboard.Totals.OverduePayables.Amount = roundAmount(dashboard.Totals.OverduePayables.Amount + outlet.OverduePayables.Amount)
+		dashboard.Totals.OverdueReceivables.Count += outlet.OverdueReceivables.Count
+		dashboard.Totals.OverdueReceivables.Amount = roundAmount(dashboard.Totals.OverdueReceivables.Amount + outlet.OverdueReceivables.Amount)
+	}
+	finishDashboardPeriod(&dashboard.Totals.Today)
+	finishDashboardPeriod(&dashboard.Totals.Month)
+
+	// Outlets by name, figures without an outlet last
+	sort.Slice(dashboard.Outlets, func(i, j int) bool {
+		a, b := dashboard.Outlets[i], dashboard.Outlets[j]
+		if (a.OutletID == nil) != (b.OutletID == nil) {
+			return b.OutletID == nil
+		}
+		if a.OutletName != b.OutletName {
+			return a.OutletName < b.OutletName
+		}
+		return a.OutletID != nil && *a.OutletID < *b.OutletID
+	})
+	return dashboard, nil
+}
+
+// dashboardOutlets returns the requested outlet or all active outlets
+func (u *DashboardUsecase) dashboardOutlets(ctx context.Context, outletID *uint) ([]*models.Outlet, error) {
+	if outletID == nil {
+		return u.repo.Outlet.GetByStatus(ctx, models.StatusAktif)
+	}
+	outlet, err := u.repo.Outlet.GetByID(ctx, *outletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+	return []*models.Outlet{outlet}, nil
+}
+
+// addFigures queries the figures of [start, end), cash flows of the days in [days[0], days[1]), into the figures of
+// each outlet
+func (u *DashboardUsecase) addFigures(ctx context.Context, start, end time.Time, days [2]time.Time, outletID *uint,
+	figures func(outletID *uint) *interfaces.DashboardFigures) error {
+	// Bounds are passed in UTC, SQLite compares timestamps as text
+	_, offset := start.Zone()
+	filter := repoInterfaces.AnalyticsFilter{
+		StartDate: start.UTC(),
+		EndDate:   end.UTC(),
+		OutletID:  outletID,
+		UTCOffset: offset,
+	}
+
+	sales, err := u.repo.Analytics.SalesSummary(ctx, filter, repoInterfaces.AnalyticsPeriodNone, repoInterfaces.AnalyticsDimensionOutlet)
+	if err != nil {
+		return err
+	}
+	for _, row := range sales {
+		f := figures(row.DimensionID)
+		f.Sales = roundAmount(f.Sales + row.Revenue)
+		f.Transactions += row.TransactionCount
+	}
+
+	services, err := u.repo.Dashboard.ServiceRevenue(ctx, filter)
+	if err != nil {
+		return err
+	}
+	for _, row := range services {
+		f := figures(row.OutletID)
+		f.ServiceRevenue = roundAmount(f.ServiceRevenue + row.Amount)
+		f.JobsCompleted += row.Count
+	}
+
+	statuses, err := u.repo.Dashboard.ServiceJobStatuses(ctx, filter)
+	if err != nil {
+		return err
+	}
+	for _, row := range statuses {
+		id := row.OutletID
+		f := figures(&id)
+		if f.ServiceJobs == nil {
+			f.ServiceJobs = make(map[models.ServiceStatusEnum]int64)
+		}
+		f.ServiceJobs[models.ServiceStatusEnum(row.Status)] += row.Count
+	}
+
+	cashFlows, err := u.repo.Dashboard.CashFlowTotals(ctx, days[0], days[1], outletID)
+	if err != nil {
+		return err
+	}
+	for _, row := range cashFlows {
+		f := figures(row.OutletID)
+		if row.Type == string(models.CashFlowTypePengeluaran) {
+			f.CashOut = roundAmount(f.CashOut + row.Amount)
+		} else {
+			f.CashIn = roundAmount(f.CashIn + row.Amount)
+		}
+	}
+	return nil
+}
+
+func newDashboardPeriod() interfaces.DashboardPeriod {
+	return interfaces.DashboardPeriod{
+		Current:  interfaces.DashboardFigures{ServiceJobs: make(map[models.ServiceStatusEnum]int64)},
+		Previous: interfaces.DashboardFigures{ServiceJobs: make(map[models.ServiceStatusEnum]int64)},
+	}
+}
+
+func addDashboardPeriod(total *interfaces.DashboardPeriod, period interfaces.DashboardPeriod) {
+	for _, pair := range []struct{ total, add *interfaces.DashboardFigures }{
+		{&total.Current, &period.Current}, {&total.Previous, &period.Previous},
+	} {
+		pair.total.Sales = roundAmount(pair.total.Sales + pair.add.Sales)
+		pair.total.Transactions += pair.add.Transactions
+		pair.total.ServiceRevenue = roundAmount(pair.total.ServiceRevenue + pair.add.ServiceRevenue)
+		pair.total.JobsCompleted += pair.add.JobsCompleted
+		pair.total.CashIn = roundAmount(pair.total.CashIn + pair.add.CashIn)
+		pair.total.CashOut = roundAmount(pair.total.CashOut + pair.add.CashOut)
+		for status, count := range pair.add.ServiceJobs {
+			pair.total.ServiceJobs[status] += count
+		}
+	}
+}
+
+// finishDashboardPeriod lists every service status and computes the changes
+func finishDashboardPeriod(period *interfaces.DashboardPeriod) {
+	for _, figures := range []*interfaces.DashboardFigures{&period.Current, &period.Previous} {
+		if figures.ServiceJobs == nil {
+			figures.ServiceJobs = make(map[models.ServiceStatusEnum]int64)
+		}
+		for _, status := range []models.ServiceStatusEnum{
+			models.ServiceStatusAntri, models.ServiceStatusDikerjakan, models.ServiceStatusSelesai,
+			models.ServiceStatusDiambil, models.ServiceStatusKomplain, models.ServiceStatusMenungguSparepart,
+		} {
+			if _, ok := figures.ServiceJobs[status]; !ok {
+				figures.ServiceJobs[status] = 0
+			}
+		}
+	}
+	period.Change = interfaces.DashboardChange{
+		Sales:          changePercent(period.Current.Sales, period.Previous.Sales),
+		ServiceRevenue: changePercent(period.Current.ServiceRevenue, period.Previous.ServiceRevenue),
+		CashIn:         changePercent(period.Current.CashIn, period.Previous.CashIn),
+		CashOut:        changePercent(period.Current.CashOut, period.Previous.CashOut),
+	}
+}
+
+// changePercent returns the change from previous to current in percent, nil when there is nothing to compare with
+func changePercent(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := percent(current-previous, previous)
+	return &change
+}
@@ -0,0 +1,371 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/utils"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultEstimateLinkValidDays is used when Estimate.LinkValidDays is not set
+const defaultEstimateLinkValidDays = 7
+
+// estimateTolerance absorbs rounding when the job's lines are compared with the approved total
+const estimateTolerance = 0.005
+
+// EstimateUsecase implements the estimate usecase interface
+type EstimateUsecase struct {
+	repo         *repository.RepositoryManager
+	notification interfaces.NotificationUsecase
+	conf         config.EstimateAccount
+	baseURL      string
+	signingKey   []byte
+}
+
+// NewEstimateUsecase creates the estimate usecase; approval links start with baseURL and are signed with signingKey
+func NewEstimateUsecase(repo *repository.RepositoryManager, notification interfaces.NotificationUsecase, conf config.EstimateAccount, baseURL, signingKey string) interfaces.EstimateUsecase {
+	if conf.LinkValidDays <= 0 {
+		conf.LinkValidDays = defaultEstimateLinkValidDays
+	}
+	return &EstimateUsecase{
+		repo:         repo,
+		notification: notification,
+		conf:         conf,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		signingKey:   []byte(signingKey),
+	}
+}
+
+// CreateEstimate stores the items as the next revision of the job's estimate, replacing the revision still waiting
+// for the customer. The extra amount over the approved revision decides whether the customer must be called.
+func (u *EstimateUsecase) CreateEstimate(ctx context.Context, serviceJobID uint, req interfaces.CreateEstimateRequest) (*models.ServiceEstimate, error) {
+	job, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrServiceJobNotFound
+		}
+		return nil, err
+	}
+	if job.Status == models.ServiceStatusDiambil {
+		return nil, interfaces.ErrEstimateJobClosed
+	}
+
+	estimate := &models.ServiceEstimate{
+		ServiceJobID:  serviceJobID,
+		Status:        models.EstimateStatusMenunggu,
+		Notes:         req.Notes,
+		LinkExpiresAt: time.Now().AddDate(0, 0, u.conf.LinkValidDays).Truncate(time.Second),
+	}
+	for _, item := range req.Items {
+		if item.ItemType == "service" {
+			if _, err := u.repo.Service.GetByID(ctx, item.ItemID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, interfaces.ErrServiceNotFound
+				}
+				return nil, err
+			}
+		} else {
+			if _, err := u.repo.Product.GetByID(ctx, item.ItemID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, interfaces.ErrProductNotFound
+				}
+				return nil, err
+			}
+		}
+
+		subtotal := item.PricePerItem * float64(item.Quantity)
+		if item.ItemType == "service" {
+			estimate.LabourTotal += subtotal
+		} else {
+			estimate.PartsTotal += subtotal
+		}
+		estimate.Items = append(estimate.Items, models.ServiceEstimateItem{
+			ItemType:     item.ItemType,
+			ItemID:       item.ItemID,
+			Description:  item.Description,
+			Quantity:     item.Quantity,
+			PricePerItem: item.PricePerItem,
+			Subtotal:     subtotal,
+		})
+	}
+	estimate.Total = estimate.PartsTotal + estimate.LabourTotal
+
+	approved, err := u.repo.ServiceEstimate.GetLatestApproved(ctx, serviceJobID)
+	if err != nil {
+		return nil, err
+	}
+	estimate.ExtraAmount = estimate.Total
+	if approved != nil {
+		// Only extra work on top of an approved estimate needs a call, the first estimate is approved by link
+		estimate.ExtraAmount = estimate.Total - approved.Total
+		estimate.RequiresCall = u.conf.CallThreshold > 0 && estimate.ExtraAmount > u.conf.CallThreshold
+	}
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		estimate.CreatedBy = &actor.UserID
+	}
+
+	if err := u.repo.ServiceEstimate.CreateRevision(ctx, estimate); err != nil {
+		return nil, err
+	}
+	u.setApprovalURL(estimate)
+
+	// Like status notifications, a notification that cannot be queued does not fail the revision
+	if u.conf.Notify && !estimate.RequiresCall && u.notification != nil {
+		if err := u.notification.NotifyEstimate(ctx, estimate); err != nil {
+			fmt.Printf("Failed to queue estimate notification: %v\n", err)
+		}
+	}
+	return estimate, nil
+}
+
+// ListEstimates retrieves the revisions of a job, newest first
+func (u *EstimateUsecase) ListEstimates(ctx context.Context, serviceJobID uint) ([]*models.ServiceEstimate, error) {
+	if _, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrServiceJobNotFound
+		}
+		return nil, err
+	}
+	estimates, err := u.repo.ServiceEstimate.GetByServiceJobID(ctx, serviceJobID)
+	if err != nil {
+		return nil, err
+	}
+	for _, estimate := range estimates {
+		u.setApprovalURL(estimate)
+	}
+	return estimates, nil
+}
+
+// GetEstimate retrieves an estimate by ID
+func (u *EstimateUsecase) GetEstimate(ctx context.Context, id uint) (*models.ServiceEstimate, error) {
+	estimate, err := u.repo.ServiceEstimate.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrEstimateNotFound
+		}
+		return nil, err
+	}
+	u.setApprovalURL(estimate)
+	return estimate, nil
+}
+
+// DecideEstimate records the decision the customer gave at the counter or by phone, with the user recording it
+func (u *EstimateUsecase) DecideEstimate(ctx context.Context, id uint, req interfaces.EstimateDecisionRequest) (*models.ServiceEstimate, error) {
+	estimate, err := u.GetEstimate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := req.Channel
+	estimate.DecisionChannel = &channel
+	estimate.DecidedByName = &req.CustomerName
+	estimate.DecisionNote = req.Note
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		estimate.DecidedByUserID = &actor.UserID
+	}
+	if err := u.decide(ctx, estimate, req.Decision); err != nil {
+		return nil, err
+	}
+	return u.GetEstimate(ctx, id)
+}
+
+// GetPublicEstimate retrieves the customer view of the estimate the signed link points to
+func (u *EstimateUsecase) GetPublicEstimate(ctx context.Context, id uint, link interfaces.EstimateLink) (*interfaces.PublicEstimate, error) {
+	estimate, err := u.linkedEstimate(ctx, id, link)
+	if err != nil {
+		return nil, err
+	}
+	return u.publicEstimate(ctx, estimate)
+}
+
+// DecidePublicEstimate records the customer's decision through the signed link, with the name they signed with and
+// their IP. A revision that requires a call can be rejected but not approved through the link.
+func (u *EstimateUsecase) DecidePublicEstimate(ctx context.Context, id uint, link interfaces.EstimateLink, req interfaces.PublicEstimateDecisionRequest, ip string) (*interfaces.PublicEstimate, error) {
+	estimate, err := u.linkedEstimate(ctx, id, link)
+	if err != nil {
+		return nil, err
+	}
+	if req.Decision == interfaces.EstimateDecisionApprove && estimate.RequiresCall {
+		return nil, interfaces.ErrEstimateRequiresCall
+	}
+
+	channel := models.EstimateChannelLink
+	estimate.DecisionChannel = &channel
+	estimate.DecidedByName = &req.Name
+	estimate.DecisionNote = req.Note
+	if ip != "" {
+		estimate.DecisionIP = &ip
+	}
+	if err := u.decide(ctx, estimate, req.Decision); err != nil {
+		return nil, err
+	}
+	return u.GetPublicEstimate(ctx, id, link)
+}
+
+// CheckWorkStart fails while the job has no approved estimate
+func (u *EstimateUsecase) CheckWorkStart(ctx context.Context, serviceJobID uint) error {
+	if !u.conf.Required {
+		return nil
+	}
+	approved, err := u.repo.ServiceEstimate.GetLatestApproved(ctx, serviceJobID)
+	if err != nil {
+		return err
+	}
+	if approved == nil {
+		return interfaces.ErrEstimateApprovalRequired
+	}
+	return nil
+}
+
+// CheckWorkCovered fails when the job's lines, with the line excludeDetailID replaced by a line of lineTotal,
+// add up to more than the approved estimate
+func (u *EstimateUsecase) CheckWorkCovered(ctx context.Context, serviceJobID uint, excludeDetailID uint, lineTotal float64) error {
+	if !u.conf.Required {
+		return nil
+	}
+	approved, err := u.repo.ServiceEstimate.GetLatestApproved(ctx, serviceJobID)
+	if err != nil {
+		return err
+	}
+	if approved == nil {
+		return interfaces.ErrEstimateApprovalRequired
+	}
+
+	details, err := u.repo.ServiceDetail.GetByServiceJobID(ctx, serviceJobID)
+	if err != nil {
+		return err
+	}
+	total := lineTotal
+	for _, detail := range details {
+		if detail.DetailID != excludeDetailID {
+			total += detail.PricePerItem * float64(detail.Quantity)
+		}
+	}
+	if total > approved.Total+estimateTolerance {
+		return interfaces.ErrEstimateExceeded.WithDetails(map[string]interface{}{
+			"approved_estimate_id": approved.EstimateID,
+			"approved_version":     approved.Version,
+			"approved_total":       approved.Total,
+			"work_total":           total,
+		})
+	}
+	return nil
+}
+
+// decide stores the decision on a revision still waiting for the customer
+func (u *EstimateUsecase) decide(ctx context.Context, estimate *models.ServiceEstimate, decision string) error {
+	if estimate.Status != models.EstimateStatusMenunggu {
+		return interfaces.ErrEstimateNotPending
+	}
+	estimate.Status = models.EstimateStatusDisetujui
+	if decision == interfaces.EstimateDecisionReject {
+		estimate.Status = models.EstimateStatusDitolak
+	}
+	now := time.Now()
+	estimate.DecidedAt = &now
+
+	decided, err := u.repo.ServiceEstimate.Decide(ctx, estimate)
+	if err != nil {
+		return err
+	}
+	if !decided {
+		return interfaces.ErrEstimateNotPending
+	}
+	return nil
+}
+
+// linkedEstimate retrieves the estimate of a signed link; unknown estimates, wrong signatures and expired links
+// give the same error
+func (u *EstimateUsecase) linkedEstimate(ctx context.Context, id uint, link interfaces.EstimateLink) (*models.ServiceEstimate, error) {
+	estimate, err := u.repo.ServiceEstimate.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrEstimateLinkInvalid
+		}
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(link.Signature)
+	if err != nil || !hmac.Equal(signature, u.sign(estimate, link.Expires)) ||
+		link.Expires != estimate.LinkExpiresAt.Unix() || time.Now().Unix() > link.Expires {
+		return nil, interfaces.ErrEstimateLinkInvalid
+	}
+	return estimate, nil
+}
+
+// sign signs the estimate revision with the link's expiry, a link of an older revision does not match a newer one
+func (u *EstimateUsecase) sign(estimate *models.ServiceEstimate, expires int64) []byte {
+	mac := hmac.New(sha256.New, u.signingKey)
+	fmt.Fprintf(mac, "estimate:%d:%d:%d", estimate.EstimateID, estimate.Version, expires)
+	return mac.Sum(nil)
+}
+
+// setApprovalURL sets the signed link of a revision waiting for the customer
+func (u *EstimateUsecase) setApprovalURL(estimate *models.ServiceEstimate) {
+	if estimate.Status != models.EstimateStatusMenunggu {
+		return
+	}
+	expires := estimate.LinkExpiresAt.Unix()
+	estimate.ApprovalURL = fmt.Sprintf("%s/estimates/%d?expires=%d&signature=%s",
+		u.baseURL, estimate.EstimateID, expires, hex.EncodeToString(u.sign(estimate, expires)))
+}
+
+// publicEstimate builds the customer view of an estimate, the job's parties are loaded by their IDs
+func (u *EstimateUsecase) publicEstimate(ctx context.Context, estimate *models.ServiceEstimate) (*interfaces.PublicEstimate, error) {
+	view := &interfaces.PublicEstimate{
+		EstimateID:      estimate.EstimateID,
+		Version:         estimate.Version,
+		Status:          estimate.Status,
+		Items:           []interfaces.PublicEstimateItem{},
+		PartsTotal:      estimate.PartsTotal,
+		LabourTotal:     estimate.LabourTotal,
+		Total:           estimate.Total,
+		ApprovedTotal:   estimate.Total - estimate.ExtraAmount,
+		ExtraAmount:     estimate.ExtraAmount,
+		RequiresCall:    estimate.RequiresCall,
+		Notes:           estimate.Notes,
+		LinkExpiresAt:   estimate.LinkExpiresAt,
+		DecidedAt:       estimate.DecidedAt,
+		DecisionChannel: estimate.DecisionChannel,
+		DecidedByName:   estimate.DecidedByName,
+	}
+	for _, item := range estimate.Items {
+		view.Items = append(view.Items, interfaces.PublicEstimateItem{
+			ItemType:     item.ItemType,
+			Description:  item.Description,
+			Quantity:     item.Quantity,
+			PricePerItem: item.PricePerItem,
+			Subtotal:     item.Subtotal,
+		})
+	}
+
+	job, err := u.repo.ServiceJob.GetByID(ctx, estimate.ServiceJobID)
+	if err != nil {
+		return nil, err
+	}
+	view.ServiceCode = job.ServiceCode
+	if vehicle, err := u.repo.CustomerVehicle.GetByID(ctx, job.VehicleID); err == nil {
+		view.PlateNumber = vehicle.PlateNumber
+		view.Vehicle = strings.TrimSpace(vehicle.Brand + " " + vehicle.Model)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if outlet, err := u.repo.Outlet.GetByID(ctx, job.OutletID); err == nil {
+		view.OutletName = outlet.OutletName
+		view.OutletPhone = stringValue(outlet.PhoneNumber)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return view, nil
+}
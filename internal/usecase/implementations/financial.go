@@ -4,6 +4,7 @@ import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
 	"context"
 	"time"
 )
@@ -20,6 +21,12 @@ func NewPaymentMethodUsecase(repo *repository.RepositoryManager) interfaces.Paym
 
 // CreatePaymentMethod creates a new payment method
 func (u *PaymentMethodUsecase) CreatePaymentMethod(ctx context.Context, req interfaces.CreatePaymentMethodRequest) (*models.PaymentMethod, error) {
+	// Check if payment method name already exists
+	existingPaymentMethod, err := u.repo.PaymentMethod.GetByName(ctx, req.Name)
+	if err == nil && existingPaymentMethod != nil {
+		return nil, interfaces.ErrPaymentMethodNameExists
+	}
+
 	paymentMethod := &models.PaymentMethod{
 		Name:      req.Name,
 		Status:    req.Status,
@@ -30,7 +37,7 @@ func (u *PaymentMethodUsecase) CreatePaymentMethod(ctx context.Context, req inte
 		paymentMethod.Status = models.StatusAktif
 	}
 
-	err := u.repo.PaymentMethod.Create(ctx, paymentMethod)
+	err = u.repo.PaymentMethod.Create(ctx, paymentMethod)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +62,11 @@ func (u *PaymentMethodUsecase) UpdatePaymentMethod(ctx context.Context, id uint,
 		return nil, err
 	}
 
-	if req.Name != nil {
+	if req.Name != nil && *req.Name != paymentMethod.Name {
+		existingPaymentMethod, err := u.repo.PaymentMethod.GetByName(ctx, *req.Name)
+		if err == nil && existingPaymentMethod != nil && existingPaymentMethod.MethodID != id {
+			return nil, interfaces.ErrPaymentMethodNameExists
+		}
 		paymentMethod.Name = *req.Name
 	}
 	if req.Status != nil {
@@ -76,8 +87,8 @@ func (u *PaymentMethodUsecase) DeletePaymentMethod(ctx context.Context, id uint)
 }
 
 // ListPaymentMethods lists payment methods with pagination
-func (u *PaymentMethodUsecase) ListPaymentMethods(ctx context.Context, limit, offset int) ([]*models.PaymentMethod, error) {
-	return u.repo.PaymentMethod.List(ctx, limit, offset)
+func (u *PaymentMethodUsecase) ListPaymentMethods(ctx context.Context, q *query.ListQuery) ([]*models.PaymentMethod, int64, error) {
+	return u.repo.PaymentMethod.List(ctx, q)
 }
 
 // GetPaymentMethodsByStatus retrieves payment methods by status
@@ -160,8 +171,8 @@ func (u *PaymentUsecase) DeletePayment(ctx context.Context, id uint) error {
 }
 
 // ListPayments lists payments with pagination
-func (u *PaymentUsecase) ListPayments(ctx context.Context, limit, offset int) ([]*models.Payment, error) {
-	return u.repo.Payment.List(ctx, limit, offset)
+func (u *PaymentUsecase) ListPayments(ctx context.Context, q *query.ListQuery) ([]*models.Payment, int64, error) {
+	return u.repo.Payment.List(ctx, q)
 }
 
 // GetPaymentsByTransaction retrieves payments by transaction ID
@@ -255,8 +266,8 @@ func (u *CashFlowUsecase) DeleteCashFlow(ctx context.Context, id uint) error {
 }
 
 // ListCashFlows lists cash flows with pagination
-func (u *CashFlowUsecase) ListCashFlows(ctx context.Context, limit, offset int) ([]*models.CashFlow, error) {
-	return u.repo.CashFlow.List(ctx, limit, offset)
+func (u *CashFlowUsecase) ListCashFlows(ctx context.Context, q *query.ListQuery) ([]*models.CashFlow, int64, error) {
+	return u.repo.CashFlow.List(ctx, q)
 }
 
 // GetCashFlowsByUser retrieves cash flows by user ID
@@ -268,12 +279,8 @@ func (u *CashFlowUsecase) GetCashFlowsByUser(ctx context.Context, userID uint) (
 func (u *CashFlowUsecase) GetCashFlowsByOutlet(ctx context.Context, outletID uint) ([]*models.CashFlow, error) {
 	// Since CashFlow model doesn't have OutletID, we'll return all cash flows
 	// This could be enhanced to filter by user's outlet if needed
-	return u.repo.CashFlow.List(ctx, 100, 0)
-}
-
-// GetCashFlowsByType retrieves cash flows by type
-func (u *CashFlowUsecase) GetCashFlowsByType(ctx context.Context, flowType models.CashFlowType) ([]*models.CashFlow, error) {
-	return u.repo.CashFlow.GetByType(ctx, flowType)
+	cashFlows, _, err := u.repo.CashFlow.List(ctx, query.New(query.MaxPerPage, 0))
+	return cashFlows, err
 }
 
 // GetCashFlowsByDateRange retrieves cash flows by date range
@@ -288,12 +295,13 @@ func (u *CashFlowUsecase) GetTotalByTypeAndDateRange(ctx context.Context, flowTy
 
 // TransactionUsecase implements the transaction usecase interface
 type TransactionUsecase struct {
-	repo *repository.RepositoryManager
+	repo     *repository.RepositoryManager
+	sequence interfaces.SequenceUsecase
 }
 
-// NewTransactionUsecase creates a new transaction usecase
-func NewTransactionUsecase(repo *repository.RepositoryManager) interfaces.TransactionUsecase {
-	return &TransactionUsecase{repo: repo}
+// NewTransactionUsecase creates a new transaction usecase, invoice numbers not given come from sequence
+func NewTransactionUsecase(repo *repository.RepositoryManager, sequence interfaces.SequenceUsecase) interfaces.TransactionUsecase {
+	return &TransactionUsecase{repo: repo, sequence: sequence}
 }
 
 // CreateTransaction creates a new transaction
@@ -313,6 +321,15 @@ func (u *TransactionUsecase) CreateTransaction(ctx context.Context, req interfac
 		transaction.Status = models.TransactionStatusSukses
 	}
 
+	// Number the invoice by the outlet and the transaction date
+	if transaction.InvoiceNumber == "" {
+		invoiceNumber, _, err := u.sequence.Next(ctx, interfaces.SequenceInvoice, req.OutletID, req.TransactionDate)
+		if err != nil {
+			return nil, err
+		}
+		transaction.InvoiceNumber = invoiceNumber
+	}
+
 	err := u.repo.Transaction.Create(ctx, transaction)
 	if err != nil {
 		return nil, err
@@ -374,13 +391,13 @@ func (u *TransactionUsecase) DeleteTransaction(ctx context.Context, id uint) err
 }
 
 // ListTransactions lists transactions with pagination
-func (u *TransactionUsecase) ListTransactions(ctx context.Context, limit, offset int) ([]*models.Transaction, error) {
-	return u.repo.Transaction.List(ctx, limit, offset)
+func (u *TransactionUsecase) ListTransactions(ctx context.Context, q *query.ListQuery) ([]*models.Transaction, int64, error) {
+	return u.repo.Transaction.List(ctx, q)
 }
 
-// GetTransactionsByCustomer retrieves transactions by customer ID
-func (u *TransactionUsecase) GetTransactionsByCustomer(ctx context.Context, customerID uint) ([]*models.Transaction, error) {
-	return u.repo.Transaction.GetByCustomerID(ctx, customerID)
+// ListTransactionsByCursor lists transactions newest first using cursor pagination
+func (u *TransactionUsecase) ListTransactionsByCursor(ctx context.Context, q *query.ListQuery) ([]*models.Transaction, string, error) {
+	return u.repo.Transaction.ListByCursor(ctx, q)
 }
 
 // GetTransactionsByUser retrieves transactions by user ID
@@ -388,21 +405,6 @@ func (u *TransactionUsecase) GetTransactionsByUser(ctx context.Context, userID u
 	return u.repo.Transaction.GetByUserID(ctx, userID)
 }
 
-// GetTransactionsByOutlet retrieves transactions by outlet ID
-func (u *TransactionUsecase) GetTransactionsByOutlet(ctx context.Context, outletID uint) ([]*models.Transaction, error) {
-	return u.repo.Transaction.GetByOutletID(ctx, outletID)
-}
-
-// GetTransactionsByStatus retrieves transactions by status
-func (u *TransactionUsecase) GetTransactionsByStatus(ctx context.Context, status models.TransactionStatus) ([]*models.Transaction, error) {
-	return u.repo.Transaction.GetByStatus(ctx, status)
-}
-
-// GetTransactionsByDateRange retrieves transactions by date range
-func (u *TransactionUsecase) GetTransactionsByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Transaction, error) {
-	return u.repo.Transaction.GetByDateRange(ctx, startDate, endDate)
-}
-
 // TransactionDetailUsecase implements the transaction detail usecase interface
 type TransactionDetailUsecase struct {
 	repo *repository.RepositoryManager
@@ -426,7 +428,18 @@ func (u *TransactionDetailUsecase) CreateTransactionDetail(ctx context.Context,
 		CreatedBy:       req.CreatedBy,
 	}
 
-	err := u.repo.TransactionDetail.Create(ctx, transactionDetail)
+	// Product lines take their stock out of the transaction's outlet and keep the cost it left at; the stock only
+	// moves together with the line
+	err := u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if req.ProductID != nil {
+			unitCost, err := u.issueLine(ctx, tx, req.TransactionID, *req.ProductID, req.Quantity)
+			if err != nil {
+				return err
+			}
+			transactionDetail.UnitCost = unitCost
+		}
+		return tx.TransactionDetail.Create(ctx, transactionDetail)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -446,6 +459,12 @@ func (u *TransactionDetailUsecase) UpdateTransactionDetail(ctx context.Context,
 		return nil, err
 	}
 
+	// Moving the line, switching its product or changing its quantity returns the old stock and issues it again
+	restock := (req.TransactionID != nil && *req.TransactionID != transactionDetail.TransactionID) ||
+		(req.ProductID != nil && (transactionDetail.ProductID == nil || *transactionDetail.ProductID != *req.ProductID)) ||
+		(req.Quantity != nil && *req.Quantity != transactionDetail.Quantity)
+	previous := *transactionDetail
+
 	if req.TransactionType != nil {
 		transactionDetail.TransactionType = *req.TransactionType
 	}
@@ -468,7 +487,21 @@ func (u *TransactionDetailUsecase) UpdateTransactionDetail(ctx context.Context,
 		transactionDetail.TotalPrice = *req.TotalPrice
 	}
 
-	err = u.repo.TransactionDetail.Update(ctx, transactionDetail)
+	err = u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if restock {
+			if err := u.returnLine(ctx, tx, &previous, "transaction line changed"); err != nil {
+				return err
+			}
+			if transactionDetail.ProductID != nil {
+				unitCost, err := u.issueLine(ctx, tx, transactionDetail.TransactionID, *transactionDetail.ProductID, transactionDetail.Quantity)
+				if err != nil {
+					return err
+				}
+				transactionDetail.UnitCost = unitCost
+			}
+		}
+		return tx.TransactionDetail.Update(ctx, transactionDetail)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -478,12 +511,21 @@ func (u *TransactionDetailUsecase) UpdateTransactionDetail(ctx context.Context,
 
 // DeleteTransactionDetail deletes a transaction detail
 func (u *TransactionDetailUsecase) DeleteTransactionDetail(ctx context.Context, id uint) error {
-	return u.repo.TransactionDetail.Delete(ctx, id)
+	transactionDetail, err := u.repo.TransactionDetail.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if err := u.returnLine(ctx, tx, transactionDetail, "transaction line deleted"); err != nil {
+			return err
+		}
+		return tx.TransactionDetail.Delete(ctx, id)
+	})
 }
 
 // ListTransactionDetails lists transaction details with pagination
-func (u *TransactionDetailUsecase) ListTransactionDetails(ctx context.Context, limit, offset int) ([]*models.TransactionDetail, error) {
-	return u.repo.TransactionDetail.List(ctx, limit, offset)
+func (u *TransactionDetailUsecase) ListTransactionDetails(ctx context.Context, q *query.ListQuery) ([]*models.TransactionDetail, int64, error) {
+	return u.repo.TransactionDetail.List(ctx, q)
 }
 
 // GetTransactionDetailsByTransaction retrieves transaction details by transaction ID
@@ -498,5 +540,60 @@ func (u *TransactionDetailUsecase) GetTransactionDetailsByProduct(ctx context.Co
 
 // DeleteTransactionDetailsByTransaction deletes transaction details by transaction ID
 func (u *TransactionDetailUsecase) DeleteTransactionDetailsByTransaction(ctx context.Context, transactionID uint) error {
-	return u.repo.TransactionDetail.DeleteByTransactionID(ctx, transactionID)
+	transactionDetails, err := u.repo.TransactionDetail.GetByTransactionID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	return u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		for _, transactionDetail := range transactionDetails {
+			if err := u.returnLine(ctx, tx, transactionDetail, "transaction line deleted"); err != nil {
+				return err
+			}
+		}
+		return tx.TransactionDetail.DeleteByTransactionID(ctx, transactionID)
+	})
+}
+
+// issueLine takes the stock of a product line out of the transaction's outlet and returns the unit cost it left at,
+// repo is the transaction the line is written in
+func (u *TransactionDetailUsecase) issueLine(ctx context.Context, repo *repository.RepositoryManager, transactionID, productID uint, quantity int) (float64, error) {
+	transaction, err := repo.Transaction.GetByID(ctx, transactionID)
+	if err != nil {
+		return 0, err
+	}
+
+	referenceType := "transaction"
+	return issueStock(ctx, repo, &models.StockMovement{
+		ProductID:     productID,
+		OutletID:      &transaction.OutletID,
+		MovementType:  models.StockMovementSale,
+		Quantity:      -quantity,
+		ReferenceType: &referenceType,
+		ReferenceID:   &transaction.TransactionID,
+		CreatedAt:     time.Now(),
+	})
+}
+
+// returnLine puts the stock of a product line back into the transaction's outlet at the cost it was sold at
+func (u *TransactionDetailUsecase) returnLine(ctx context.Context, repo *repository.RepositoryManager, transactionDetail *models.TransactionDetail, notes string) error {
+	if transactionDetail.ProductID == nil {
+		return nil
+	}
+	transaction, err := repo.Transaction.GetByID(ctx, transactionDetail.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	referenceType := "transaction"
+	return receiveStock(ctx, repo, &models.StockMovement{
+		ProductID:     *transactionDetail.ProductID,
+		OutletID:      &transaction.OutletID,
+		MovementType:  models.StockMovementSale,
+		Quantity:      transactionDetail.Quantity,
+		UnitCost:      transactionDetail.UnitCost,
+		ReferenceType: &referenceType,
+		ReferenceID:   &transaction.TransactionID,
+		Notes:         &notes,
+		CreatedAt:     time.Now(),
+	})
 }
\ No newline at end of file
@@ -4,11 +4,13 @@ import (
 	"boilerplate/internal/repository"
 	"boilerplate/internal/models"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
 	"context"
 	"errors"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 // UserUsecaseImpl implements UserUsecase interface
@@ -25,7 +27,7 @@ func (u *UserUsecaseImpl) CreateUser(ctx context.Context, req interfaces.CreateU
 	// Check if email already exists
 	existingUser, err := u.repo.User.GetByEmail(ctx, req.Email)
 	if err == nil && existingUser != nil {
-		return nil, errors.New("email already exists")
+		return nil, interfaces.ErrEmailExists
 	}
 
 	// Hash password
@@ -72,7 +74,7 @@ func (u *UserUsecaseImpl) UpdateUser(ctx context.Context, id uint, req interface
 		// Check if email is already taken by another user
 		existingUser, err := u.repo.User.GetByEmail(ctx, *req.Email)
 		if err == nil && existingUser != nil && existingUser.UserID != id {
-			return nil, errors.New("email already exists")
+			return nil, interfaces.ErrEmailExists
 		}
 		user.Email = *req.Email
 	}
@@ -94,8 +96,8 @@ func (u *UserUsecaseImpl) DeleteUser(ctx context.Context, id uint) error {
 	return u.repo.User.Delete(ctx, id)
 }
 
-func (u *UserUsecaseImpl) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
-	return u.repo.User.List(ctx, limit, offset)
+func (u *UserUsecaseImpl) ListUsers(ctx context.Context, q *query.ListQuery) ([]*models.User, int64, error) {
+	return u.repo.User.List(ctx, q)
 }
 
 func (u *UserUsecaseImpl) GetUsersByOutlet(ctx context.Context, outletID uint) ([]*models.User, error) {
@@ -111,7 +113,7 @@ func (u *UserUsecaseImpl) ChangePassword(ctx context.Context, userID uint, oldPa
 	// Verify old password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword))
 	if err != nil {
-		return errors.New("invalid old password")
+		return interfaces.ErrInvalidOldPassword
 	}
 
 	// Hash new password
@@ -126,6 +128,26 @@ func (u *UserUsecaseImpl) ChangePassword(ctx context.Context, userID uint, oldPa
 	return u.repo.User.Update(ctx, user)
 }
 
+func (u *UserUsecaseImpl) AssignRoles(ctx context.Context, userID uint, roleIDs []uint) error {
+	if _, err := u.repo.User.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrUserNotFound
+		}
+		return err
+	}
+
+	for _, roleID := range roleIDs {
+		if _, err := u.repo.Role.GetByID(ctx, roleID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return interfaces.ErrRoleNotFound
+			}
+			return err
+		}
+	}
+
+	return u.repo.User.AttachRoles(ctx, userID, roleIDs)
+}
+
 // OutletUsecaseImpl implements OutletUsecase interface
 type OutletUsecaseImpl struct {
 	repo *repository.RepositoryManager
@@ -143,6 +165,7 @@ func (u *OutletUsecaseImpl) CreateOutlet(ctx context.Context, req interfaces.Cre
 		City:        req.City,
 		Address:     req.Address,
 		PhoneNumber: req.PhoneNumber,
+		BayCount:    req.BayCount,
 		Status:      req.Status,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -152,6 +175,9 @@ func (u *OutletUsecaseImpl) CreateOutlet(ctx context.Context, req interfaces.Cre
 	if outlet.Status == "" {
 		outlet.Status = models.StatusAktif
 	}
+	if outlet.BayCount == 0 {
+		outlet.BayCount = 1
+	}
 
 	err := u.repo.Outlet.Create(ctx, outlet)
 	if err != nil {
@@ -186,6 +212,9 @@ func (u *OutletUsecaseImpl) UpdateOutlet(ctx context.Context, id uint, req inter
 	if req.PhoneNumber != nil {
 		outlet.PhoneNumber = req.PhoneNumber
 	}
+	if req.BayCount != nil {
+		outlet.BayCount = *req.BayCount
+	}
 	if req.Status != nil {
 		outlet.Status = *req.Status
 	}
@@ -204,8 +233,8 @@ func (u *OutletUsecaseImpl) DeleteOutlet(ctx context.Context, id uint) error {
 	return u.repo.Outlet.Delete(ctx, id)
 }
 
-func (u *OutletUsecaseImpl) ListOutlets(ctx context.Context, limit, offset int) ([]*models.Outlet, error) {
-	return u.repo.Outlet.List(ctx, limit, offset)
+func (u *OutletUsecaseImpl) ListOutlets(ctx context.Context, q *query.ListQuery) ([]*models.Outlet, int64, error) {
+	return u.repo.Outlet.List(ctx, q)
 }
 
 func (u *OutletUsecaseImpl) GetActiveOutlets(ctx context.Context) ([]*models.Outlet, error) {
@@ -225,7 +254,7 @@ func (u *RoleUsecaseImpl) CreateRole(ctx context.Context, req interfaces.CreateR
 // Check if role name already exists
 existingRole, err := u.repo.Role.GetByName(ctx, req.Name)
 if err == nil && existingRole != nil {
-return nil, errors.New("role with this name already exists")
+return nil, interfaces.ErrRoleNameExists
 }
 
 role := &models.Role{
@@ -260,7 +289,7 @@ if req.Name != nil {
 // Check if new name already exists
 existingRole, err := u.repo.Role.GetByName(ctx, *req.Name)
 if err == nil && existingRole != nil && existingRole.ID != id {
-return nil, errors.New("role with this name already exists")
+return nil, interfaces.ErrRoleNameExists
 }
 role.Name = *req.Name
 }
@@ -279,8 +308,8 @@ func (u *RoleUsecaseImpl) DeleteRole(ctx context.Context, id uint) error {
 return u.repo.Role.Delete(ctx, id)
 }
 
-func (u *RoleUsecaseImpl) ListRoles(ctx context.Context, limit, offset int) ([]*models.Role, error) {
-return u.repo.Role.List(ctx, limit, offset)
+func (u *RoleUsecaseImpl) ListRoles(ctx context.Context, q *query.ListQuery) ([]*models.Role, int64, error) {
+return u.repo.Role.List(ctx, q)
 }
 
 func (u *RoleUsecaseImpl) AttachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
@@ -305,7 +334,7 @@ func (u *PermissionUsecaseImpl) CreatePermission(ctx context.Context, req interf
 // Check if permission name already exists
 existingPermission, err := u.repo.Permission.GetByName(ctx, req.Name)
 if err == nil && existingPermission != nil {
-return nil, errors.New("permission with this name already exists")
+return nil, interfaces.ErrPermissionNameExists
 }
 
 permission := &models.Permission{
@@ -340,7 +369,7 @@ if req.Name != nil {
 // Check if new name already exists
 existingPermission, err := u.repo.Permission.GetByName(ctx, *req.Name)
 if err == nil && existingPermission != nil && existingPermission.ID != id {
-return nil, errors.New("permission with this name already exists")
+return nil, interfaces.ErrPermissionNameExists
 }
 permission.Name = *req.Name
 }
@@ -359,8 +388,8 @@ func (u *PermissionUsecaseImpl) DeletePermission(ctx context.Context, id uint) e
 return u.repo.Permission.Delete(ctx, id)
 }
 
-func (u *PermissionUsecaseImpl) ListPermissions(ctx context.Context, limit, offset int) ([]*models.Permission, error) {
-return u.repo.Permission.List(ctx, limit, offset)
+func (u *PermissionUsecaseImpl) ListPermissions(ctx context.Context, q *query.ListQuery) ([]*models.Permission, int64, error) {
+return u.repo.Permission.List(ctx, q)
 }
 
 
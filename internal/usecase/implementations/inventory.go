@@ -4,8 +4,16 @@ import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/storage"
+	"boilerplate/pkg/utils"
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
@@ -13,12 +21,14 @@ import (
 
 // ProductUsecase implements the product usecase interface
 type ProductUsecase struct {
-	repo *repository.RepositoryManager
+	repo         *repository.RepositoryManager
+	store        storage.Storage
+	maxImageSize int64
 }
 
-// NewProductUsecase creates a new product usecase
-func NewProductUsecase(repo *repository.RepositoryManager) interfaces.ProductUsecase {
-	return &ProductUsecase{repo: repo}
+// NewProductUsecase creates a new product usecase, product images up to maxImageSize bytes are kept in store
+func NewProductUsecase(repo *repository.RepositoryManager, store storage.Storage, maxImageSize int64) interfaces.ProductUsecase {
+	return &ProductUsecase{repo: repo, store: store, maxImageSize: maxImageSize}
 }
 
 // CreateProduct creates a new product
@@ -28,7 +38,7 @@ func (u *ProductUsecase) CreateProduct(ctx context.Context, req interfaces.Creat
 		_, err := u.repo.Category.GetByID(ctx, *req.CategoryID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("category not found")
+				return nil, interfaces.ErrCategoryNotFound
 			}
 			return nil, err
 		}
@@ -39,7 +49,7 @@ func (u *ProductUsecase) CreateProduct(ctx context.Context, req interfaces.Creat
 		_, err := u.repo.Supplier.GetByID(ctx, *req.SupplierID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("supplier not found")
+				return nil, interfaces.ErrSupplierNotFound
 			}
 			return nil, err
 		}
@@ -50,7 +60,7 @@ func (u *ProductUsecase) CreateProduct(ctx context.Context, req interfaces.Creat
 		_, err := u.repo.UnitType.GetByID(ctx, *req.UnitTypeID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("unit type not found")
+				return nil, interfaces.ErrUnitTypeNotFound
 			}
 			return nil, err
 		}
@@ -60,7 +70,7 @@ func (u *ProductUsecase) CreateProduct(ctx context.Context, req interfaces.Creat
 	if req.SKU != nil {
 		existingProduct, err := u.repo.Product.GetBySKU(ctx, *req.SKU)
 		if err == nil && existingProduct != nil {
-			return nil, errors.New("product with this SKU already exists")
+			return nil, interfaces.ErrProductSKUExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -71,20 +81,27 @@ func (u *ProductUsecase) CreateProduct(ctx context.Context, req interfaces.Creat
 	if req.Barcode != nil {
 		existingProduct, err := u.repo.Product.GetByBarcode(ctx, *req.Barcode)
 		if err == nil && existingProduct != nil {
-			return nil, errors.New("product with this barcode already exists")
+			return nil, interfaces.ErrProductBarcodeExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
 	}
 
+	costMethod := req.CostMethod
+	if costMethod == "" {
+		costMethod = models.CostMethodAverage
+	}
+
 	product := &models.Product{
 		ProductName:        req.ProductName,
 		ProductDescription: req.ProductDescription,
 		ProductImage:       req.ProductImage,
 		CostPrice:          req.CostPrice,
+		CostMethod:         costMethod,
 		SellingPrice:       req.SellingPrice,
-		Stock:              req.Stock,
+		MinStock:           req.MinStock,
+		ReorderQuantity:    req.ReorderQuantity,
 		SKU:                req.SKU,
 		Barcode:            req.Barcode,
 		HasSerialNumber:    req.HasSerialNumber,
@@ -99,9 +116,17 @@ func (u *ProductUsecase) CreateProduct(ctx context.Context, req interfaces.Creat
 		UpdatedAt:          time.Now(),
 	}
 
-	if err := u.repo.Product.Create(ctx, product); err != nil {
+	// The ledger opens together with the product, also without stock, so it can always be recalculated from
+	err := u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if err := tx.Product.Create(ctx, product); err != nil {
+			return err
+		}
+		return openStockLedger(ctx, tx, product, req.Stock)
+	})
+	if err != nil {
 		return nil, err
 	}
+	product.Stock = req.Stock
 
 	return product, nil
 }
@@ -111,7 +136,7 @@ func (u *ProductUsecase) GetProduct(ctx context.Context, id uint) (*models.Produ
 	product, err := u.repo.Product.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product not found")
+			return nil, interfaces.ErrProductNotFound
 		}
 		return nil, err
 	}
@@ -123,7 +148,7 @@ func (u *ProductUsecase) GetProductBySKU(ctx context.Context, sku string) (*mode
 	product, err := u.repo.Product.GetBySKU(ctx, sku)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product not found")
+			return nil, interfaces.ErrProductNotFound
 		}
 		return nil, err
 	}
@@ -135,7 +160,7 @@ func (u *ProductUsecase) GetProductByBarcode(ctx context.Context, barcode string
 	product, err := u.repo.Product.GetByBarcode(ctx, barcode)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product not found")
+			return nil, interfaces.ErrProductNotFound
 		}
 		return nil, err
 	}
@@ -147,7 +172,7 @@ func (u *ProductUsecase) UpdateProduct(ctx context.Context, id uint, req interfa
 	product, err := u.repo.Product.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product not found")
+			return nil, interfaces.ErrProductNotFound
 		}
 		return nil, err
 	}
@@ -157,7 +182,7 @@ func (u *ProductUsecase) UpdateProduct(ctx context.Context, id uint, req interfa
 		_, err := u.repo.Category.GetByID(ctx, *req.CategoryID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("category not found")
+				return nil, interfaces.ErrCategoryNotFound
 			}
 			return nil, err
 		}
@@ -168,7 +193,7 @@ func (u *ProductUsecase) UpdateProduct(ctx context.Context, id uint, req interfa
 		_, err := u.repo.Supplier.GetByID(ctx, *req.SupplierID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("supplier not found")
+				return nil, interfaces.ErrSupplierNotFound
 			}
 			return nil, err
 		}
@@ -179,7 +204,7 @@ func (u *ProductUsecase) UpdateProduct(ctx context.Context, id uint, req interfa
 		_, err := u.repo.UnitType.GetByID(ctx, *req.UnitTypeID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("unit type not found")
+				return nil, interfaces.ErrUnitTypeNotFound
 			}
 			return nil, err
 		}
@@ -189,7 +214,7 @@ func (u *ProductUsecase) UpdateProduct(ctx context.Context, id uint, req interfa
 	if req.SKU != nil && (product.SKU == nil || *req.SKU != *product.SKU) {
 		existingProduct, err := u.repo.Product.GetBySKU(ctx, *req.SKU)
 		if err == nil && existingProduct != nil && existingProduct.ProductID != id {
-			return nil, errors.New("product with this SKU already exists")
+			return nil, interfaces.ErrProductSKUExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -200,7 +225,7 @@ func (u *ProductUsecase) UpdateProduct(ctx context.Context, id uint, req interfa
 	if req.Barcode != nil && (product.Barcode == nil || *req.Barcode != *product.Barcode) {
 		existingProduct, err := u.repo.Product.GetByBarcode(ctx, *req.Barcode)
 		if err == nil && existingProduct != nil && existingProduct.ProductID != id {
-			return nil, errors.New("product with this barcode already exists")
+			return nil, interfaces.ErrProductBarcodeExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -217,14 +242,29 @@ func (u *ProductUsecase) UpdateProduct(ctx context.Context, id uint, req interfa
 	if req.ProductImage != nil {
 		product.ProductImage = req.ProductImage
 	}
-	if req.CostPrice != nil {
+	// Once there is stock the cost price is the moving average of its receipts, a receipt landing meanwhile
+	// makes SetCostPrice refuse it too
+	if req.CostPrice != nil && *req.CostPrice != product.CostPrice {
+		if product.Stock != 0 {
+			return nil, interfaces.ErrProductCostPriceManaged
+		}
+		set, err := u.repo.Product.SetCostPrice(ctx, product.ProductID, *req.CostPrice)
+		if err != nil {
+			return nil, err
+		}
+		if !set {
+			return nil, interfaces.ErrProductCostPriceManaged
+		}
 		product.CostPrice = *req.CostPrice
 	}
 	if req.SellingPrice != nil {
 		product.SellingPrice = *req.SellingPrice
 	}
-	if req.Stock != nil {
-		product.Stock = *req.Stock
+	if req.MinStock != nil {
+		product.MinStock = *req.MinStock
+	}
+	if req.ReorderQuantity != nil {
+		product.ReorderQuantity = *req.ReorderQuantity
 	}
 	if req.SKU != nil {
 		product.SKU = req.SKU
@@ -259,6 +299,14 @@ func (u *ProductUsecase) UpdateProduct(ctx context.Context, id uint, req interfa
 		return nil, err
 	}
 
+	// A changed stock level is recorded as an adjustment in the stock ledger
+	if req.Stock != nil && *req.Stock != product.Stock {
+		if err := u.recordStockMovement(ctx, product.ProductID, *req.Stock-product.Stock, "stock corrected on product update"); err != nil {
+			return nil, err
+		}
+		product.Stock = *req.Stock
+	}
+
 	return product, nil
 }
 
@@ -267,7 +315,7 @@ func (u *ProductUsecase) DeleteProduct(ctx context.Context, id uint) error {
 	_, err := u.repo.Product.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("product not found")
+			return interfaces.ErrProductNotFound
 		}
 		return err
 	}
@@ -277,31 +325,103 @@ func (u *ProductUsecase) DeleteProduct(ctx context.Context, id uint) error {
 	return u.repo.Product.Delete(ctx, id)
 }
 
-// ListProducts retrieves products with pagination
-func (u *ProductUsecase) ListProducts(ctx context.Context, limit, offset int) ([]*models.Product, error) {
-	if limit <= 0 {
-		limit = 10
+// productImageTypes are the accepted image types, detected from the content, with their file extension
+var productImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// UploadProductImage stores a new product image and replaces the previous one
+func (u *ProductUsecase) UploadProductImage(ctx context.Context, id uint, upload interfaces.ProductImageUpload) (*models.Product, error) {
+	product, err := u.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if offset < 0 {
-		offset = 0
+
+	if upload.Size > u.maxImageSize {
+		return nil, interfaces.ErrProductImageTooLarge
+	}
+
+	// The declared content type is not trusted, the type is sniffed from the first bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(upload.Body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if errors.Is(err, io.EOF) {
+			return nil, interfaces.ErrProductImageType
+		}
+		return nil, err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	ext, ok := productImageTypes[contentType]
+	if !ok {
+		return nil, interfaces.ErrProductImageType
+	}
+
+	name, err := utils.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%sproducts/%d/%s%s", storage.PublicPrefix, id, name, ext)
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head), upload.Body), u.maxImageSize)
+	if err := u.store.Put(ctx, key, body, contentType); err != nil {
+		return nil, err
+	}
+	url, err := u.store.URL(ctx, key)
+	if err != nil {
+		u.store.Delete(ctx, key)
+		return nil, err
+	}
+
+	previous := product.ProductImage
+	product.ProductImage = &url
+	product.UpdatedAt = time.Now()
+	if err := u.repo.Product.Update(ctx, product); err != nil {
+		u.store.Delete(ctx, key)
+		return nil, err
 	}
 
-	return u.repo.Product.List(ctx, limit, offset)
+	u.deleteStoredImage(ctx, previous)
+	return product, nil
 }
 
-// GetProductsByCategory retrieves products by category
-func (u *ProductUsecase) GetProductsByCategory(ctx context.Context, categoryID uint) ([]*models.Product, error) {
-	return u.repo.Product.GetByCategoryID(ctx, categoryID)
+// DeleteProductImage removes the product image
+func (u *ProductUsecase) DeleteProductImage(ctx context.Context, id uint) (*models.Product, error) {
+	product, err := u.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if product.ProductImage == nil {
+		return product, nil
+	}
+
+	previous := product.ProductImage
+	product.ProductImage = nil
+	product.UpdatedAt = time.Now()
+	if err := u.repo.Product.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	u.deleteStoredImage(ctx, previous)
+	return product, nil
 }
 
-// GetProductsBySupplier retrieves products by supplier
-func (u *ProductUsecase) GetProductsBySupplier(ctx context.Context, supplierID uint) ([]*models.Product, error) {
-	return u.repo.Product.GetBySupplierID(ctx, supplierID)
+// deleteStoredImage removes a replaced image from the store. Images linked from elsewhere are left alone
+// and a failed delete only leaves an orphaned file, so it does not fail the request.
+func (u *ProductUsecase) deleteStoredImage(ctx context.Context, url *string) {
+	if url == nil {
+		return
+	}
+	if key, ok := u.store.Key(*url); ok {
+		u.store.Delete(ctx, key)
+	}
 }
 
-// GetProductsByUsageStatus retrieves products by usage status
-func (u *ProductUsecase) GetProductsByUsageStatus(ctx context.Context, status models.ProductUsageStatus) ([]*models.Product, error) {
-	return u.repo.Product.GetByUsageStatus(ctx, status)
+// ListProducts retrieves products with pagination
+func (u *ProductUsecase) ListProducts(ctx context.Context, q *query.ListQuery) ([]*models.Product, int64, error) {
+
+	return u.repo.Product.List(ctx, q)
 }
 
 // SearchProducts searches products
@@ -321,17 +441,113 @@ func (u *ProductUsecase) UpdateProductStock(ctx context.Context, productID uint,
 	_, err := u.repo.Product.GetByID(ctx, productID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("product not found")
+			return interfaces.ErrProductNotFound
 		}
 		return err
 	}
 
-	return u.repo.Product.UpdateStock(ctx, productID, quantity)
+	return u.recordStockMovement(ctx, productID, quantity, "manual stock adjustment")
+}
+
+// RecalculateStock compares each product's stock with the sum of its stock
+// movements and, when apply is set, overwrites the stock with the ledger value.
+// Products without any recorded movement are left untouched, as are those whose
+// ledger has no opening entry and so only holds the changes since it was created.
+func (u *ProductUsecase) RecalculateStock(ctx context.Context, apply bool) ([]*interfaces.StockRecalculation, error) {
+	totals, err := u.repo.StockMovement.GetStockTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opening, err := u.repo.StockMovement.GetOpeningBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*interfaces.StockRecalculation
+	for productID, ledgerStock := range totals {
+		product, err := u.repo.Product.GetByID(ctx, productID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if product.Stock == ledgerStock {
+			continue
+		}
+
+		result := &interfaces.StockRecalculation{
+			ProductID:       product.ProductID,
+			ProductName:     product.ProductName,
+			CurrentStock:    product.Stock,
+			CalculatedStock: ledgerStock,
+			NoOpeningBalance: !opening[productID],
+		}
+		if apply && !result.NoOpeningBalance {
+			if err := u.repo.Product.UpdateStock(ctx, product.ProductID, ledgerStock-product.Stock); err != nil {
+				return results, err
+			}
+			result.Applied = true
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ProductID < results[j].ProductID
+	})
+	return results, nil
 }
 
-// GetLowStockProducts retrieves products with low stock
-func (u *ProductUsecase) GetLowStockProducts(ctx context.Context, threshold int) ([]*models.Product, error) {
-	return u.repo.Product.GetLowStock(ctx, threshold)
+// openStockLedger records the opening entry of a new product's ledger, repo is the transaction the product is created
+// in. Opening stock is valued at the product's cost price.
+func openStockLedger(ctx context.Context, repo *repository.RepositoryManager, product *models.Product, quantity int) error {
+	referenceType := models.StockReferenceOpening
+	notes := "opening stock"
+	movement := &models.StockMovement{
+		ProductID:     product.ProductID,
+		MovementType:  models.StockMovementAdjustment,
+		Quantity:      quantity,
+		UnitCost:      product.CostPrice,
+		ReferenceType: &referenceType,
+		ReferenceID:   &product.ProductID,
+		Notes:         &notes,
+		CreatedAt:     time.Now(),
+	}
+	if quantity == 0 {
+		return repo.StockMovement.Record(ctx, movement)
+	}
+	return receiveStock(ctx, repo, movement)
+}
+
+// recordStockMovement records a manual stock adjustment in the stock ledger. Stock added is valued at
+// the current cost, stock taken out is costed by the product's cost method.
+func (u *ProductUsecase) recordStockMovement(ctx context.Context, productID uint, quantity int, notes string) error {
+	if quantity == 0 {
+		return nil
+	}
+	movement := &models.StockMovement{
+		ProductID:    productID,
+		MovementType: models.StockMovementAdjustment,
+		Quantity:     quantity,
+		Notes:        &notes,
+		CreatedAt:    time.Now(),
+	}
+	if quantity < 0 {
+		_, err := issueStock(ctx, u.repo, movement)
+		return err
+	}
+
+	unitCost, err := productUnitCost(ctx, u.repo, productID)
+	if err != nil {
+		return err
+	}
+	movement.UnitCost = unitCost
+	return receiveStock(ctx, u.repo, movement)
+}
+
+// GetLowStockProducts lists the products with low stock matching the list query
+func (u *ProductUsecase) GetLowStockProducts(ctx context.Context, threshold int, q *query.ListQuery) ([]*models.Product, int64, error) {
+	return u.repo.Product.GetLowStock(ctx, threshold, q)
 }
 
 // CategoryUsecase implements the category usecase interface
@@ -349,7 +565,7 @@ func (u *CategoryUsecase) CreateCategory(ctx context.Context, req interfaces.Cre
 	// Check if category name already exists
 	existingCategory, err := u.repo.Category.GetByName(ctx, req.Name)
 	if err == nil && existingCategory != nil {
-		return nil, errors.New("category with this name already exists")
+		return nil, interfaces.ErrCategoryNameExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -381,7 +597,7 @@ func (u *CategoryUsecase) GetCategory(ctx context.Context, id uint) (*models.Cat
 	category, err := u.repo.Category.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("category not found")
+			return nil, interfaces.ErrCategoryNotFound
 		}
 		return nil, err
 	}
@@ -393,7 +609,7 @@ func (u *CategoryUsecase) GetCategoryByName(ctx context.Context, name string) (*
 	category, err := u.repo.Category.GetByName(ctx, name)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("category not found")
+			return nil, interfaces.ErrCategoryNotFound
 		}
 		return nil, err
 	}
@@ -405,7 +621,7 @@ func (u *CategoryUsecase) UpdateCategory(ctx context.Context, id uint, req inter
 	category, err := u.repo.Category.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("category not found")
+			return nil, interfaces.ErrCategoryNotFound
 		}
 		return nil, err
 	}
@@ -414,7 +630,7 @@ func (u *CategoryUsecase) UpdateCategory(ctx context.Context, id uint, req inter
 	if req.Name != nil && *req.Name != category.Name {
 		existingCategory, err := u.repo.Category.GetByName(ctx, *req.Name)
 		if err == nil && existingCategory != nil && existingCategory.CategoryID != id {
-			return nil, errors.New("category with this name already exists")
+			return nil, interfaces.ErrCategoryNameExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -442,7 +658,7 @@ func (u *CategoryUsecase) DeleteCategory(ctx context.Context, id uint) error {
 	_, err := u.repo.Category.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("category not found")
+			return interfaces.ErrCategoryNotFound
 		}
 		return err
 	}
@@ -453,22 +669,16 @@ func (u *CategoryUsecase) DeleteCategory(ctx context.Context, id uint) error {
 		return err
 	}
 	if len(products) > 0 {
-		return errors.New("cannot delete category with existing products")
+		return interfaces.ErrCategoryHasProducts
 	}
 
 	return u.repo.Category.Delete(ctx, id)
 }
 
 // ListCategories retrieves categories with pagination
-func (u *CategoryUsecase) ListCategories(ctx context.Context, limit, offset int) ([]*models.Category, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *CategoryUsecase) ListCategories(ctx context.Context, q *query.ListQuery) ([]*models.Category, int64, error) {
 
-	return u.repo.Category.List(ctx, limit, offset)
+	return u.repo.Category.List(ctx, q)
 }
 
 // GetCategoriesByStatus retrieves categories by status
@@ -491,7 +701,7 @@ func (u *SupplierUsecase) CreateSupplier(ctx context.Context, req interfaces.Cre
 	// Check if supplier name already exists
 	existingSupplier, err := u.repo.Supplier.GetByName(ctx, req.SupplierName)
 	if err == nil && existingSupplier != nil {
-		return nil, errors.New("supplier with this name already exists")
+		return nil, interfaces.ErrSupplierNameExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -526,7 +736,7 @@ func (u *SupplierUsecase) GetSupplier(ctx context.Context, id uint) (*models.Sup
 	supplier, err := u.repo.Supplier.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("supplier not found")
+			return nil, interfaces.ErrSupplierNotFound
 		}
 		return nil, err
 	}
@@ -538,7 +748,7 @@ func (u *SupplierUsecase) GetSupplierByName(ctx context.Context, name string) (*
 	supplier, err := u.repo.Supplier.GetByName(ctx, name)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("supplier not found")
+			return nil, interfaces.ErrSupplierNotFound
 		}
 		return nil, err
 	}
@@ -550,7 +760,7 @@ func (u *SupplierUsecase) UpdateSupplier(ctx context.Context, id uint, req inter
 	supplier, err := u.repo.Supplier.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("supplier not found")
+			return nil, interfaces.ErrSupplierNotFound
 		}
 		return nil, err
 	}
@@ -559,7 +769,7 @@ func (u *SupplierUsecase) UpdateSupplier(ctx context.Context, id uint, req inter
 	if req.SupplierName != nil && *req.SupplierName != supplier.SupplierName {
 		existingSupplier, err := u.repo.Supplier.GetByName(ctx, *req.SupplierName)
 		if err == nil && existingSupplier != nil && existingSupplier.SupplierID != id {
-			return nil, errors.New("supplier with this name already exists")
+			return nil, interfaces.ErrSupplierNameExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -596,7 +806,7 @@ func (u *SupplierUsecase) DeleteSupplier(ctx context.Context, id uint) error {
 	_, err := u.repo.Supplier.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("supplier not found")
+			return interfaces.ErrSupplierNotFound
 		}
 		return err
 	}
@@ -607,22 +817,16 @@ func (u *SupplierUsecase) DeleteSupplier(ctx context.Context, id uint) error {
 		return err
 	}
 	if len(products) > 0 {
-		return errors.New("cannot delete supplier with existing products")
+		return interfaces.ErrSupplierHasProducts
 	}
 
 	return u.repo.Supplier.Delete(ctx, id)
 }
 
 // ListSuppliers retrieves suppliers with pagination
-func (u *SupplierUsecase) ListSuppliers(ctx context.Context, limit, offset int) ([]*models.Supplier, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *SupplierUsecase) ListSuppliers(ctx context.Context, q *query.ListQuery) ([]*models.Supplier, int64, error) {
 
-	return u.repo.Supplier.List(ctx, limit, offset)
+	return u.repo.Supplier.List(ctx, q)
 }
 
 // GetSuppliersByStatus retrieves suppliers by status
@@ -657,7 +861,7 @@ func (u *UnitTypeUsecase) CreateUnitType(ctx context.Context, req interfaces.Cre
 	// Check if unit type name already exists
 	existingUnitType, err := u.repo.UnitType.GetByName(ctx, req.Name)
 	if err == nil && existingUnitType != nil {
-		return nil, errors.New("unit type with this name already exists")
+		return nil, interfaces.ErrUnitTypeNameExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -689,7 +893,7 @@ func (u *UnitTypeUsecase) GetUnitType(ctx context.Context, id uint) (*models.Uni
 	unitType, err := u.repo.UnitType.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("unit type not found")
+			return nil, interfaces.ErrUnitTypeNotFound
 		}
 		return nil, err
 	}
@@ -701,7 +905,7 @@ func (u *UnitTypeUsecase) GetUnitTypeByName(ctx context.Context, name string) (*
 	unitType, err := u.repo.UnitType.GetByName(ctx, name)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("unit type not found")
+			return nil, interfaces.ErrUnitTypeNotFound
 		}
 		return nil, err
 	}
@@ -713,7 +917,7 @@ func (u *UnitTypeUsecase) UpdateUnitType(ctx context.Context, id uint, req inter
 	unitType, err := u.repo.UnitType.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("unit type not found")
+			return nil, interfaces.ErrUnitTypeNotFound
 		}
 		return nil, err
 	}
@@ -722,7 +926,7 @@ func (u *UnitTypeUsecase) UpdateUnitType(ctx context.Context, id uint, req inter
 	if req.Name != nil && *req.Name != unitType.Name {
 		existingUnitType, err := u.repo.UnitType.GetByName(ctx, *req.Name)
 		if err == nil && existingUnitType != nil && existingUnitType.UnitTypeID != id {
-			return nil, errors.New("unit type with this name already exists")
+			return nil, interfaces.ErrUnitTypeNameExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -750,7 +954,7 @@ func (u *UnitTypeUsecase) DeleteUnitType(ctx context.Context, id uint) error {
 	_, err := u.repo.UnitType.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("unit type not found")
+			return interfaces.ErrUnitTypeNotFound
 		}
 		return err
 	}
@@ -761,15 +965,9 @@ func (u *UnitTypeUsecase) DeleteUnitType(ctx context.Context, id uint) error {
 }
 
 // ListUnitTypes retrieves unit types with pagination
-func (u *UnitTypeUsecase) ListUnitTypes(ctx context.Context, limit, offset int) ([]*models.UnitType, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *UnitTypeUsecase) ListUnitTypes(ctx context.Context, q *query.ListQuery) ([]*models.UnitType, int64, error) {
 
-	return u.repo.UnitType.List(ctx, limit, offset)
+	return u.repo.UnitType.List(ctx, q)
 }
 
 // GetUnitTypesByStatus retrieves unit types by status
@@ -793,7 +991,7 @@ func (u *ProductSerialNumberUsecase) CreateProductSerialNumber(ctx context.Conte
 	_, err := u.repo.Product.GetByID(ctx, req.ProductID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product not found")
+			return nil, interfaces.ErrProductNotFound
 		}
 		return nil, err
 	}
@@ -801,7 +999,7 @@ func (u *ProductSerialNumberUsecase) CreateProductSerialNumber(ctx context.Conte
 	// Check if serial number already exists
 	existingSerial, err := u.repo.ProductSerialNumber.GetBySerialNumber(ctx, req.SerialNumber)
 	if err == nil && existingSerial != nil {
-		return nil, errors.New("serial number already exists")
+		return nil, interfaces.ErrSerialNumberExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -834,7 +1032,7 @@ func (u *ProductSerialNumberUsecase) GetProductSerialNumber(ctx context.Context,
 	serialNumber, err := u.repo.ProductSerialNumber.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product serial number not found")
+			return nil, interfaces.ErrProductSerialNumberNotFound
 		}
 		return nil, err
 	}
@@ -846,7 +1044,7 @@ func (u *ProductSerialNumberUsecase) GetProductSerialNumberBySerial(ctx context.
 	sn, err := u.repo.ProductSerialNumber.GetBySerialNumber(ctx, serialNumber)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product serial number not found")
+			return nil, interfaces.ErrProductSerialNumberNotFound
 		}
 		return nil, err
 	}
@@ -858,7 +1056,7 @@ func (u *ProductSerialNumberUsecase) UpdateProductSerialNumber(ctx context.Conte
 	serialNumber, err := u.repo.ProductSerialNumber.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product serial number not found")
+			return nil, interfaces.ErrProductSerialNumberNotFound
 		}
 		return nil, err
 	}
@@ -868,7 +1066,7 @@ func (u *ProductSerialNumberUsecase) UpdateProductSerialNumber(ctx context.Conte
 		_, err := u.repo.Product.GetByID(ctx, *req.ProductID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("product not found")
+				return nil, interfaces.ErrProductNotFound
 			}
 			return nil, err
 		}
@@ -878,7 +1076,7 @@ func (u *ProductSerialNumberUsecase) UpdateProductSerialNumber(ctx context.Conte
 	if req.SerialNumber != nil && *req.SerialNumber != serialNumber.SerialNumber {
 		existingSerial, err := u.repo.ProductSerialNumber.GetBySerialNumber(ctx, *req.SerialNumber)
 		if err == nil && existingSerial != nil && existingSerial.SerialNumberID != id {
-			return nil, errors.New("serial number already exists")
+			return nil, interfaces.ErrSerialNumberExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -909,7 +1107,7 @@ func (u *ProductSerialNumberUsecase) DeleteProductSerialNumber(ctx context.Conte
 	_, err := u.repo.ProductSerialNumber.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("product serial number not found")
+			return interfaces.ErrProductSerialNumberNotFound
 		}
 		return err
 	}
@@ -920,15 +1118,9 @@ func (u *ProductSerialNumberUsecase) DeleteProductSerialNumber(ctx context.Conte
 }
 
 // ListProductSerialNumbers retrieves product serial numbers with pagination
-func (u *ProductSerialNumberUsecase) ListProductSerialNumbers(ctx context.Context, limit, offset int) ([]*models.ProductSerialNumber, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *ProductSerialNumberUsecase) ListProductSerialNumbers(ctx context.Context, q *query.ListQuery) ([]*models.ProductSerialNumber, int64, error) {
 
-	return u.repo.ProductSerialNumber.List(ctx, limit, offset)
+	return u.repo.ProductSerialNumber.List(ctx, q)
 }
 
 // GetProductSerialNumbersByProduct retrieves product serial numbers by product
@@ -946,7 +1138,7 @@ func (u *ProductSerialNumberUsecase) UpdateProductSerialNumberStatus(ctx context
 	_, err := u.repo.ProductSerialNumber.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("product serial number not found")
+			return interfaces.ErrProductSerialNumberNotFound
 		}
 		return err
 	}
@@ -0,0 +1,82 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"testing"
+)
+
+func TestRecalculateStockCorrectsProductCreatedWithoutStock(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+	u := NewProductUsecase(repo, nil, 0)
+
+	product, err := u.CreateProduct(ctx, interfaces.CreateProductRequest{
+		ProductName:  "Oil filter",
+		CostPrice:    20000,
+		SellingPrice: 35000,
+		UsageStatus:  models.ProductUsageJual,
+		IsActive:     true,
+	})
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	if err := u.UpdateProductStock(ctx, product.ProductID, 5); err != nil {
+		t.Fatalf("adjust stock: %v", err)
+	}
+
+	// The stock drifts from the ledger and the opening entry's notes are edited
+	if err := db.Model(&models.Product{}).Where("product_id = ?", product.ProductID).Update("stock", 9).Error; err != nil {
+		t.Fatalf("drift stock: %v", err)
+	}
+	err = db.Model(&models.StockMovement{}).
+		Where("product_id = ? AND reference_type = ?", product.ProductID, models.StockReferenceOpening).
+		Update("notes", "new product").Error
+	if err != nil {
+		t.Fatalf("edit notes: %v", err)
+	}
+
+	results, err := u.RecalculateStock(ctx, true)
+	if err != nil {
+		t.Fatalf("recalculate: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d products that differ, want 1", len(results))
+	}
+	result := results[0]
+	if result.NoOpeningBalance || !result.Applied || result.CalculatedStock != 5 {
+		t.Fatalf("got %+v, want the stock corrected to 5", *result)
+	}
+
+	reloaded, err := repo.Product.GetByID(ctx, product.ProductID)
+	if err != nil {
+		t.Fatalf("reload product: %v", err)
+	}
+	if reloaded.Stock != 5 {
+		t.Fatalf("stock after recalculation: got %d, want 5", reloaded.Stock)
+	}
+}
+
+func TestRecalculateStockLeavesLedgerWithoutOpening(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+	u := NewProductUsecase(repo, nil, 0)
+
+	// A product from before the ledger: its stock was never opened in it
+	product := createTestProduct(t, db, "Spark plug", 15000, models.CostMethodAverage)
+	if err := db.Model(product).Update("stock", 10).Error; err != nil {
+		t.Fatalf("set stock: %v", err)
+	}
+	if err := u.UpdateProductStock(ctx, product.ProductID, -2); err != nil {
+		t.Fatalf("adjust stock: %v", err)
+	}
+
+	results, err := u.RecalculateStock(ctx, true)
+	if err != nil {
+		t.Fatalf("recalculate: %v", err)
+	}
+	if len(results) != 1 || !results[0].NoOpeningBalance || results[0].Applied {
+		t.Fatalf("got %+v, want the product reported without an opening balance and left alone", results)
+	}
+}
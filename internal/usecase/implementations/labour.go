@@ -0,0 +1,569 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/utils"
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Labour efficiency dimensions and commission bases
+const (
+	labourByTechnician  = "technician"
+	labourByService     = "service"
+	labourBasisStandard = "standard"
+	labourBasisClocked  = "clocked"
+)
+
+// LabourUsecase implements the labour usecase interface
+type LabourUsecase struct {
+	repo       *repository.RepositoryManager
+	serviceJob interfaces.ServiceJobUsecase
+	conf       config.LabourAccount
+}
+
+// NewLabourUsecase creates the labour usecase, a queued job is started through the service job usecase so the
+// estimate approval is enforced and the change reaches the live boards
+func NewLabourUsecase(repo *repository.RepositoryManager, serviceJob interfaces.ServiceJobUsecase, conf config.LabourAccount) interfaces.LabourUsecase {
+	return &LabourUsecase{repo: repo, serviceJob: serviceJob, conf: labourConfig(conf)}
+}
+
+// labourConfig falls back to the standard time basis when the configured one is unknown
+func labourConfig(conf config.LabourAccount) config.LabourAccount {
+	if conf.CommissionBasis != labourBasisClocked {
+		conf.CommissionBasis = labourBasisStandard
+	}
+	return conf
+}
+
+// StartWork clocks the technician in on the job or one of its service lines
+func (u *LabourUsecase) StartWork(ctx context.Context, serviceJobID uint, req interfaces.StartLabourRequest) (*models.LabourEntry, error) {
+	technicianID, err := u.technician(ctx, req.TechnicianID)
+	if err != nil {
+		return nil, err
+	}
+	serviceJob, err := u.getServiceJob(ctx, serviceJobID)
+	if err != nil {
+		return nil, err
+	}
+	if !openServiceJob(serviceJob.Status) {
+		return nil, interfaces.ErrServiceJobClosed
+	}
+
+	if req.DetailID != nil {
+		detail, err := u.repo.ServiceDetail.GetByID(ctx, *req.DetailID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err != nil || detail.ServiceJobID != serviceJobID || detail.ItemType != "service" {
+			return nil, interfaces.ErrLabourLineInvalid.WithDetails(map[string]interface{}{"detail_id": *req.DetailID})
+		}
+		// A finished line is only worked on again for a complaint
+		if serviceJob.Status != models.ServiceStatusKomplain {
+			entries, err := u.repo.Labour.GetByServiceJobIDs(ctx, []uint{serviceJobID})
+			if err != nil {
+				return nil, err
+			}
+			if line := tallyLabourLines(entries, time.Now())[*req.DetailID]; line != nil && line.finished != nil {
+				return nil, interfaces.ErrLabourLineFinished.WithDetails(map[string]interface{}{"detail_id": *req.DetailID})
+			}
+		}
+	}
+
+	if err := u.checkNotClockedIn(ctx, technicianID); err != nil {
+		return nil, err
+	}
+
+	// Work on a queued job, or on one whose parts arrived, starts it
+	if serviceJob.Status == models.ServiceStatusAntri || serviceJob.Status == models.ServiceStatusMenungguSparepart {
+		if err := u.serviceJob.UpdateServiceJobStatus(ctx, serviceJobID, models.ServiceStatusDikerjakan, technicianID, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if err := u.repo.Labour.ClosePauses(ctx, serviceJobID, req.DetailID, now); err != nil {
+		return nil, err
+	}
+	entry := &models.LabourEntry{
+		ServiceJobID: serviceJobID,
+		DetailID:     req.DetailID,
+		TechnicianID: technicianID,
+		Kind:         models.LabourEntryKerja,
+		StartedAt:    now,
+		Notes:        req.Notes,
+	}
+	if err := u.repo.Labour.Create(ctx, entry); err != nil {
+		// The open work index refuses a second clock in made at the same time
+		if clockedErr := u.checkNotClockedIn(ctx, technicianID); clockedErr != nil {
+			return nil, clockedErr
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// PauseWork clocks the technician out and opens a pause on the same job or line
+func (u *LabourUsecase) PauseWork(ctx context.Context, serviceJobID uint, req interfaces.PauseLabourRequest) (*models.LabourEntry, error) {
+	technicianID, err := u.technician(ctx, req.TechnicianID)
+	if err != nil {
+		return nil, err
+	}
+	work, err := u.openWork(ctx, serviceJobID, technicianID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	work.EndedAt = &now
+	closed, err := u.repo.Labour.Close(ctx, work)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, interfaces.ErrLabourNotClockedIn
+	}
+
+	reason := req.Reason
+	pause := &models.LabourEntry{
+		ServiceJobID: serviceJobID,
+		DetailID:     work.DetailID,
+		TechnicianID: technicianID,
+		Kind:         models.LabourEntryJeda,
+		PauseReason:  &reason,
+		StartedAt:    now,
+		Notes:        req.Notes,
+	}
+	if err := u.repo.Labour.Create(ctx, pause); err != nil {
+		return nil, err
+	}
+	return pause, nil
+}
+
+// FinishWork clocks the technician out with the work done
+func (u *LabourUsecase) FinishWork(ctx context.Context, serviceJobID uint, req interfaces.FinishLabourRequest) (*models.LabourEntry, error) {
+	technicianID, err := u.technician(ctx, req.TechnicianID)
+	if err != nil {
+		return nil, err
+	}
+	work, err := u.openWork(ctx, serviceJobID, technicianID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	work.EndedAt = &now
+	work.Finished = true
+	if req.Notes != nil {
+		work.Notes = req.Notes
+	}
+	closed, err := u.repo.Labour.Close(ctx, work)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, interfaces.ErrLabourNotClockedIn
+	}
+	return work, nil
+}
+
+// GetJobLabour returns the clocked time of the job against the standard time of its service lines
+func (u *LabourUsecase) GetJobLabour(ctx context.Context, serviceJobID uint) (*interfaces.JobLabour, error) {
+	serviceJob, err := u.getServiceJob(ctx, serviceJobID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := u.repo.Labour.GetByServiceJobIDs(ctx, []uint{serviceJobID})
+	if err != nil {
+		return nil, err
+	}
+	lines, err := u.repo.Labour.GetServiceLines(ctx, []uint{serviceJobID})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tallies := tallyLabourLines(entries, now)
+	result := &interfaces.JobLabour{
+		ServiceJobID:  serviceJobID,
+		Status:        serviceJob.Status,
+		PausedMinutes: make(map[string]float64),
+		Lines:         make([]interfaces.LabourLine, 0, len(lines)),
+		Technicians:   []interfaces.LabourTechnician{},
+		Entries:       entries,
+		At:            now,
+	}
+	if result.Entries == nil {
+		result.Entries = []*models.LabourEntry{}
+	}
+
+	technicians := make(map[uint]*interfaces.LabourTechnician)
+	var order []uint
+	for _, entry := range entries {
+		minutes := labourMinutes(entry, now)
+		if entry.Kind == models.LabourEntryJeda {
+			if entry.PauseReason != nil {
+				result.PausedMinutes[string(*entry.PauseReason)] += minutes
+			}
+			continue
+		}
+		result.ClockedMinutes += minutes
+		technician := technicians[entry.TechnicianID]
+		if technician == nil {
+			technician = &interfaces.LabourTechnician{TechnicianID: entry.TechnicianID}
+			if entry.Technician != nil {
+				technician.Name = entry.Technician.Name
+			}
+			technicians[entry.TechnicianID] = technician
+			order = append(order, entry.TechnicianID)
+		}
+		technician.ClockedMinutes += minutes
+		if entry.EndedAt == nil {
+			technician.Working = true
+		}
+	}
+
+	var finishedStandard, finishedActual float64
+	for _, row := range lines {
+		line := interfaces.LabourLine{
+			DetailID:        row.DetailID,
+			ServiceID:       row.ServiceID,
+			Description:     row.Description,
+			Quantity:        row.Quantity,
+			StandardMinutes: float64(row.StandardMinutes * row.Quantity),
+		}
+		if tally := tallies[row.DetailID]; tally != nil {
+			line.ActualMinutes = tally.minutes
+			if tally.finished != nil {
+				line.Finished = true
+				line.EfficiencyPercent = percent(line.StandardMinutes, tally.minutes)
+				finishedStandard += line.StandardMinutes
+				finishedActual += tally.minutes
+				for technicianID, share := range tally.standardShares(line.StandardMinutes) {
+					if technician := technicians[technicianID]; technician != nil {
+						technician.StandardMinutes += share
+					}
+				}
+			}
+		}
+		result.StandardMinutes += line.StandardMinutes
+		line.ActualMinutes = roundAmount(line.ActualMinutes)
+		result.Lines = append(result.Lines, line)
+	}
+	result.EfficiencyPercent = percent(finishedStandard, finishedActual)
+
+	for _, technicianID := range order {
+		technician := technicians[technicianID]
+		technician.Commission = labourCommission(u.conf, technician.StandardMinutes, technician.ClockedMinutes)
+		technician.ClockedMinutes = roundAmount(technician.ClockedMinutes)
+		technician.StandardMinutes = roundAmount(technician.StandardMinutes)
+		result.Technicians = append(result.Technicians, *technician)
+	}
+	result.ClockedMinutes = roundAmount(result.ClockedMinutes)
+	for reason, minutes := range result.PausedMinutes {
+		result.PausedMinutes[reason] = roundAmount(minutes)
+	}
+	return result, nil
+}
+
+// technician is the technician asked for, the authenticated user without one; it must be an active technician
+func (u *LabourUsecase) technician(ctx context.Context, technicianID *uint) (uint, error) {
+	if technicianID == nil {
+		actor, ok := utils.ActorFromContext(ctx)
+		if !ok {
+			return 0, interfaces.ErrLabourTechnicianRequired
+		}
+		technicianID = &actor.UserID
+	}
+	if err := checkActiveTechnician(ctx, u.repo, *technicianID); err != nil {
+		return 0, err
+	}
+	return *technicianID, nil
+}
+
+func (u *LabourUsecase) getServiceJob(ctx context.Context, serviceJobID uint) (*models.ServiceJob, error) {
+	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrServiceJobNotFound
+		}
+		return nil, err
+	}
+	return serviceJob, nil
+}
+
+// checkNotClockedIn fails when the technician has open work, a technician works on one job at a time
+func (u *LabourUsecase) checkNotClockedIn(ctx context.Context, technicianID uint) error {
+	open, err := u.repo.Labour.GetOpenWork(ctx, technicianID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return interfaces.ErrLabourClockedIn.WithDetails(map[string]interface{}{
+		"entry_id":       open.EntryID,
+		"service_job_id": open.ServiceJobID,
+		"detail_id":      open.DetailID,
+	})
+}
+
+// openWork retrieves the open work of the technician, which must be on the job
+func (u *LabourUsecase) openWork(ctx context.Context, serviceJobID, technicianID uint) (*models.LabourEntry, error) {
+	if _, err := u.getServiceJob(ctx, serviceJobID); err != nil {
+		return nil, err
+	}
+	work, err := u.repo.Labour.GetOpenWork(ctx, technicianID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrLabourNotClockedIn
+		}
+		return nil, err
+	}
+	if work.ServiceJobID != serviceJobID {
+		return nil, interfaces.ErrLabourNotClockedIn.WithDetails(map[string]interface{}{"service_job_id": work.ServiceJobID})
+	}
+	return work, nil
+}
+
+// labourMinutes is the length of an entry in minutes, an open entry lasts until now
+func labourMinutes(entry *models.LabourEntry, now time.Time) float64 {
+	end := now
+	if entry.EndedAt != nil {
+		end = *entry.EndedAt
+	}
+	if minutes := end.Sub(entry.StartedAt).Minutes(); minutes > 0 {
+		return minutes
+	}
+	return 0
+}
+
+// labourCommission pays the configured rate per hour of the standard or the clocked time
+func labourCommission(conf config.LabourAccount, standardMinutes, clockedMinutes float64) float64 {
+	minutes := standardMinutes
+	if conf.CommissionBasis == labourBasisClocked {
+		minutes = clockedMinutes
+	}
+	return roundAmount(conf.CommissionPerHour * minutes / 60)
+}
+
+// labourLine is the work clocked on a service line. The line is finished when its last work entry was finished,
+// finished holds that entry.
+type labourLine struct {
+	minutes      float64
+	byTechnician map[uint]float64
+	last         *models.LabourEntry
+	finished     *models.LabourEntry
+}
+
+// standardShares splits the standard time of the line among its technicians by their clocked time, all to the
+// technician who finished it when no time was clocked
+func (l *labourLine) standardShares(standard float64) map[uint]float64 {
+	shares := make(map[uint]float64, len(l.byTechnician))
+	if l.minutes == 0 {
+		if l.finished != nil {
+			shares[l.finished.TechnicianID] = standard
+		}
+		return shares
+	}
+	for technicianID, minutes := range l.byTechnician {
+		shares[technicianID] = standard * minutes / l.minutes
+	}
+	return shares
+}
+
+// tallyLabourLines sums the work entries per service line; entries must be ordered by start
+func tallyLabourLines(entries []*models.LabourEntry, now time.Time) map[uint]*labourLine {
+	lines := make(map[uint]*labourLine)
+	for _, entry := range entries {
+		if entry.Kind != models.LabourEntryKerja || entry.DetailID == nil {
+			continue
+		}
+		line := lines[*entry.DetailID]
+		if line == nil {
+			line = &labourLine{byTechnician: make(map[uint]float64)}
+			lines[*entry.DetailID] = line
+		}
+		minutes := labourMinutes(entry, now)
+		line.minutes += minutes
+		line.byTechnician[entry.TechnicianID] += minutes
+		line.last = entry
+	}
+	for _, line := range lines {
+		if line.last.Finished && line.last.EndedAt != nil {
+			line.finished = line.last
+		}
+	}
+	return lines
+}
+
+// labourBucket accumulates the labour of one technician or service
+type labourBucket struct {
+	figures interfaces.LabourFigures
+}
+
+func newLabourBucket(id *uint, name string) *labourBucket {
+	return &labourBucket{figures: interfaces.LabourFigures{DimensionID: id, DimensionName: name, PausedMinutes: make(map[string]float64)}}
+}
+
+func (b *labourBucket) result(conf config.LabourAccount) interfaces.LabourFigures {
+	figures := b.figures
+	figures.Commission = labourCommission(conf, figures.StandardMinutes, figures.ClockedMinutes)
+	figures.EfficiencyPercent = percent(figures.StandardMinutes, figures.LineMinutes)
+	figures.ClockedMinutes = roundAmount(figures.ClockedMinutes)
+	figures.LineMinutes = roundAmount(figures.LineMinutes)
+	figures.StandardMinutes = roundAmount(figures.StandardMinutes)
+	paused := make(map[string]float64, len(figures.PausedMinutes))
+	for reason, minutes := range figures.PausedMinutes {
+		paused[reason] = roundAmount(minutes)
+	}
+	figures.PausedMinutes = paused
+	return figures
+}
+
+// labourEfficiency measures the labour clocked in the filter's range per technician or service, rows are ordered by
+// clocked time and name. Time not clocked on a service line has no service and only counts in the totals when
+// grouped by service.
+func labourEfficiency(ctx context.Context, repo *repository.RepositoryManager, filter repoInterfaces.AnalyticsFilter,
+	groupBy string, conf config.LabourAccount) ([]interfaces.LabourFigures, interfaces.LabourFigures, error) {
+	started, err := repo.Labour.GetStartedBetween(ctx, filter.StartDate, filter.EndDate, filter.OutletID)
+	if err != nil {
+		return nil, interfaces.LabourFigures{}, err
+	}
+
+	// The lines finished in the range need all the time clocked on them, also before it
+	var jobIDs []uint
+	seenJobs := make(map[uint]bool)
+	for _, entry := range started {
+		if !seenJobs[entry.ServiceJobID] {
+			seenJobs[entry.ServiceJobID] = true
+			jobIDs = append(jobIDs, entry.ServiceJobID)
+		}
+	}
+	var entries []*models.LabourEntry
+	lines := make(map[uint]repoInterfaces.LabourLineRow)
+	if len(jobIDs) > 0 {
+		if entries, err = repo.Labour.GetByServiceJobIDs(ctx, jobIDs); err != nil {
+			return nil, interfaces.LabourFigures{}, err
+		}
+		rows, err := repo.Labour.GetServiceLines(ctx, jobIDs)
+		if err != nil {
+			return nil, interfaces.LabourFigures{}, err
+		}
+		for _, row := range rows {
+			lines[row.DetailID] = row
+		}
+	}
+
+	buckets := make(map[uint]*labourBucket)
+	bucketOf := func(entry *models.LabourEntry, detailID *uint) *labourBucket {
+		if groupBy == labourByService {
+			if detailID == nil {
+				return nil
+			}
+			line, ok := lines[*detailID]
+			if !ok {
+				return nil
+			}
+			bucket := buckets[line.ServiceID]
+			if bucket == nil {
+				serviceID := line.ServiceID
+				bucket = newLabourBucket(&serviceID, line.ServiceName)
+				buckets[line.ServiceID] = bucket
+			}
+			return bucket
+		}
+		bucket := buckets[entry.TechnicianID]
+		if bucket == nil {
+			technicianID := entry.TechnicianID
+			name := ""
+			if entry.Technician != nil {
+				name = entry.Technician.Name
+			}
+			bucket = newLabourBucket(&technicianID, name)
+			buckets[entry.TechnicianID] = bucket
+		}
+		return bucket
+	}
+
+	now := time.Now()
+	total := newLabourBucket(nil, "")
+	for _, entry := range started {
+		minutes := labourMinutes(entry, now)
+		bucket := bucketOf(entry, entry.DetailID)
+		if entry.Kind == models.LabourEntryJeda {
+			if entry.PauseReason == nil {
+				continue
+			}
+			reason := string(*entry.PauseReason)
+			total.figures.PausedMinutes[reason] += minutes
+			if bucket != nil {
+				bucket.figures.PausedMinutes[reason] += minutes
+			}
+			continue
+		}
+		total.figures.ClockedMinutes += minutes
+		if bucket != nil {
+			bucket.figures.ClockedMinutes += minutes
+		}
+	}
+
+	for detailID, tally := range tallyLabourLines(entries, now) {
+		line, ok := lines[detailID]
+		finished := tally.finished
+		if !ok || finished == nil || finished.EndedAt.Before(filter.StartDate) || !finished.EndedAt.Before(filter.EndDate) {
+			continue
+		}
+		standard := float64(line.StandardMinutes * line.Quantity)
+		total.figures.LinesFinished++
+		total.figures.LineMinutes += tally.minutes
+		total.figures.StandardMinutes += standard
+
+		if groupBy == labourByService {
+			bucket := bucketOf(finished, &detailID)
+			bucket.figures.LinesFinished++
+			bucket.figures.LineMinutes += tally.minutes
+			bucket.figures.StandardMinutes += standard
+			continue
+		}
+		shares := tally.standardShares(standard)
+		for _, entry := range entries {
+			if entry.Kind != models.LabourEntryKerja || entry.DetailID == nil || *entry.DetailID != detailID {
+				continue
+			}
+			share, ok := shares[entry.TechnicianID]
+			if !ok {
+				continue
+			}
+			// Each technician of the line is credited once, with the first of their entries
+			delete(shares, entry.TechnicianID)
+			bucket := bucketOf(entry, &detailID)
+			bucket.figures.LinesFinished++
+			bucket.figures.LineMinutes += tally.byTechnician[entry.TechnicianID]
+			bucket.figures.StandardMinutes += share
+		}
+	}
+
+	rows := make([]interfaces.LabourFigures, 0, len(buckets))
+	for _, bucket := range buckets {
+		rows = append(rows, bucket.result(conf))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].ClockedMinutes != rows[j].ClockedMinutes {
+			return rows[i].ClockedMinutes > rows[j].ClockedMinutes
+		}
+		if rows[i].DimensionName != rows[j].DimensionName {
+			return rows[i].DimensionName < rows[j].DimensionName
+		}
+		return *rows[i].DimensionID < *rows[j].DimensionID
+	})
+	return rows, total.result(conf), nil
+}
@@ -0,0 +1,628 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/notify"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Defaults used when the Notification config leaves a value at zero
+const (
+	defaultDueReminderDays    = 3
+	defaultPickupReminderDays = 2
+	defaultNotifyMaxAttempts  = 5
+	defaultNotifyRetryDelay   = 5 * time.Minute
+	// notificationSendTimeout bounds a single delivery attempt
+	notificationSendTimeout = 30 * time.Second
+)
+
+// errNoSender fails notifications of a channel without sender for good
+var errNoSender = errors.New("no sender for the channel")
+
+// defaultNotificationChannels are used when Notification.Channels is empty
+var defaultNotificationChannels = []string{string(models.NotificationChannelWhatsApp)}
+
+// notificationTemplates are the built-in messages per event, Notification.Templates replaces them by event
+var notificationTemplates = map[models.NotificationEvent]string{
+	models.NotificationEventJobStatus: "Halo {{.CustomerName}}, status servis {{.ServiceCode}} untuk kendaraan {{.PlateNumber}} " +
+		"sekarang: {{.Status}}. Terima kasih, {{.OutletName}}.",
+	models.NotificationEventJobCompleted: "Halo {{.CustomerName}}, servis {{.ServiceCode}} untuk kendaraan {{.PlateNumber}} sudah selesai " +
+		"dan siap diambil di {{.OutletName}}.{{if .GrandTotal}} Total biaya {{.GrandTotal}}.{{end}} Terima kasih.",
+	models.NotificationEventServiceReminder: "Halo {{.CustomerName}}, kendaraan {{.PlateNumber}} sudah waktunya servis berkala " +
+		"pada {{.ReminderDate}}. Yuk jadwalkan servis di {{.OutletName}}{{if .OutletPhone}} ({{.OutletPhone}}){{end}}.",
+	models.NotificationEventReceivableDue: "Halo {{.CustomerName}}, tagihan {{.InvoiceNumber}} sebesar {{.Amount}} jatuh tempo " +
+		"pada {{.DueDate}}. Mohon lakukan pembayaran sebelum jatuh tempo. Terima kasih, {{.OutletName}}.",
+	models.NotificationEventPickupReminder: "Halo {{.CustomerName}}, kendaraan {{.PlateNumber}} (servis {{.ServiceCode}}) sudah selesai " +
+		"dan menunggu diambil di {{.OutletName}}{{if .OutletPhone}} ({{.OutletPhone}}){{end}}. Terima kasih.",
+	models.NotificationEventEstimateReady: "Halo {{.CustomerName}}, estimasi biaya servis {{.ServiceCode}} untuk kendaraan {{.PlateNumber}} " +
+		"sebesar {{.Amount}}. Mohon setujui atau tolak melalui {{.Link}} sebelum {{.DueDate}}. Terima kasih, {{.OutletName}}.",
+	models.NotificationEventPartsArrived: "Halo {{.CustomerName}}, sparepart untuk servis {{.ServiceCode}} kendaraan {{.PlateNumber}} " +
+		"sudah tiba di {{.OutletName}}; pengerjaan segera dilanjutkan. Terima kasih.",
+}
+
+// notificationSubjects are the subjects of email notifications per event
+var notificationSubjects = map[models.NotificationEvent]string{
+	models.NotificationEventJobStatus:       "Status servis {{.ServiceCode}}",
+	models.NotificationEventJobCompleted:    "Servis {{.ServiceCode}} selesai",
+	models.NotificationEventServiceReminder: "Pengingat servis berkala {{.PlateNumber}}",
+	models.NotificationEventReceivableDue:   "Tagihan {{.InvoiceNumber}} jatuh tempo",
+	models.NotificationEventPickupReminder:  "Kendaraan {{.PlateNumber}} menunggu diambil",
+	models.NotificationEventEstimateReady:   "Estimasi biaya servis {{.ServiceCode}}",
+	models.NotificationEventPartsArrived:    "Sparepart servis {{.ServiceCode}} sudah tiba",
+}
+
+// notificationData holds the values the templates can use
+type notificationData struct {
+	CustomerName  string
+	ServiceCode   string
+	PlateNumber   string
+	Vehicle       string
+	Status        string
+	OutletName    string
+	OutletPhone   string
+	GrandTotal    string
+	ReminderDate  string
+	InvoiceNumber string
+	Amount        string
+	DueDate       string
+	Link          string
+}
+
+// notificationMessage is a rendered notification waiting to be queued for the customer's channels
+type notificationMessage struct {
+	customer     *models.Customer
+	event        models.NotificationEvent
+	data         notificationData
+	serviceJobID *uint
+	receivableID *uint
+	dedupeKey    string // reminders are queued once per key and channel, empty for status changes
+}
+
+// NotificationUsecase implements the notification usecase interface
+type NotificationUsecase struct {
+	repo           *repository.RepositoryManager
+	senders        map[string]notify.Sender
+	conf           config.NotificationAccount
+	channels       []models.NotificationChannel
+	notifyStatuses map[models.ServiceStatusEnum]bool
+	templates      map[models.NotificationEvent]*template.Template
+	subjects       map[models.NotificationEvent]*template.Template
+	maxAttempts    int
+	retryDelay     time.Duration
+	// confErr is returned when queueing while the config holds an unknown channel or an invalid template
+	confErr error
+}
+
+// NewNotificationUsecase creates the notification usecase delivering through senders, one per channel
+func NewNotificationUsecase(repo *repository.RepositoryManager, senders map[string]notify.Sender, conf config.NotificationAccount) interfaces.NotificationUsecase {
+	u := &NotificationUsecase{
+		repo:           repo,
+		senders:        senders,
+		conf:           conf,
+		notifyStatuses: map[models.ServiceStatusEnum]bool{},
+		templates:      map[models.NotificationEvent]*template.Template{},
+		subjects:       map[models.NotificationEvent]*template.Template{},
+		maxAttempts:    conf.MaxAttempts,
+		retryDelay:     time.Duration(conf.RetryMinutes) * time.Minute,
+	}
+	if u.maxAttempts <= 0 {
+		u.maxAttempts = defaultNotifyMaxAttempts
+	}
+	if u.retryDelay <= 0 {
+		u.retryDelay = defaultNotifyRetryDelay
+	}
+
+	channels := conf.Channels
+	if len(channels) == 0 {
+		channels = defaultNotificationChannels
+	}
+	for _, channel := range channels {
+		if !models.NotificationChannel(channel).IsValid() {
+			u.confErr = fmt.Errorf("invalid channel %q in Notification.Channels", channel)
+			continue
+		}
+		u.channels = append(u.channels, models.NotificationChannel(channel))
+	}
+	for _, status := range conf.NotifyStatuses {
+		if !models.ServiceStatusEnum(status).IsValid() {
+			u.confErr = fmt.Errorf("invalid status %q in Notification.NotifyStatuses", status)
+			continue
+		}
+		u.notifyStatuses[models.ServiceStatusEnum(status)] = true
+	}
+	u.notifyStatuses[models.ServiceStatusSelesai] = true
+
+	for event, text := range notificationTemplates {
+		if override, ok := conf.Templates[string(event)]; ok && override != "" {
+			text = override
+		}
+		tmpl, err := template.New(string(event)).Parse(text)
+		if err != nil {
+			u.confErr = fmt.Errorf("Notification.Templates.%s: %w", event, err)
+			tmpl = template.Must(template.New(string(event)).Parse(notificationTemplates[event]))
+		}
+		u.templates[event] = tmpl
+		u.subjects[event] = template.Must(template.New(string(event)).Parse(notificationSubjects[event]))
+	}
+	return u
+}
+
+// ListNotifications retrieves notifications matching the list query
+func (u *NotificationUsecase) ListNotifications(ctx context.Context, q *query.ListQuery) ([]*models.Notification, int64, error) {
+	return u.repo.Notification.List(ctx, q)
+}
+
+// GetNotification retrieves a notification by ID
+func (u *NotificationUsecase) GetNotification(ctx context.Context, id uint) (*models.Notification, error) {
+	notification, err := u.repo.Notification.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	return notification, nil
+}
+
+// RetryNotification queues a Gagal notification again with fresh attempts
+func (u *NotificationUsecase) RetryNotification(ctx context.Context, id uint) (*models.Notification, error) {
+	if _, err := u.GetNotification(ctx, id); err != nil {
+		return nil, err
+	}
+	requeued, err := u.repo.Notification.Requeue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !requeued {
+		return nil, interfaces.ErrNotificationNotFailed
+	}
+	return u.GetNotification(ctx, id)
+}
+
+// GetPreferences tells per channel whether the customer receives notifications through it
+func (u *NotificationUsecase) GetPreferences(ctx context.Context, customerID uint) (*interfaces.NotificationPreferences, error) {
+	if _, err := u.repo.Customer.GetByID(ctx, customerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrCustomerNotFound
+		}
+		return nil, err
+	}
+	optOuts, err := u.repo.Notification.GetOptOuts(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := &interfaces.NotificationPreferences{
+		CustomerID: customerID,
+		Channels: map[models.NotificationChannel]bool{
+			models.NotificationChannelWhatsApp: true,
+			models.NotificationChannelSMS:      true,
+			models.NotificationChannelEmail:    true,
+		},
+	}
+	for _, optOut := range optOuts {
+		preferences.Channels[optOut.Channel] = false
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences opts the customer in or out of the given channels
+func (u *NotificationUsecase) UpdatePreferences(ctx context.Context, customerID uint, req interfaces.UpdateNotificationPreferencesRequest) (*interfaces.NotificationPreferences, error) {
+	if _, err := u.GetPreferences(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	var by *uint
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		by = &actor.UserID
+	}
+	for channel, enabled := range req.Channels {
+		if err := u.repo.Notification.SetOptOut(ctx, customerID, channel, !enabled, by); err != nil {
+			return nil, err
+		}
+	}
+	return u.GetPreferences(ctx, customerID)
+}
+
+// NotifyStatusChange queues the completion message when a job is Selesai and a status message for the
+// statuses in Notification.NotifyStatuses
+func (u *NotificationUsecase) NotifyStatusChange(ctx context.Context, serviceJobID uint, status models.ServiceStatusEnum) error {
+	if !u.notifyStatuses[status] {
+		return nil
+	}
+	job, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID)
+	if err != nil {
+		return err
+	}
+	if err := u.loadServiceJobParties(ctx, job); err != nil {
+		return err
+	}
+
+	event := models.NotificationEventJobStatus
+	if status == models.ServiceStatusSelesai {
+		event = models.NotificationEventJobCompleted
+	}
+	data := serviceJobData(job)
+	data.Status = string(status)
+	_, err = u.queue(ctx, notificationMessage{
+		customer:     job.Customer,
+		event:        event,
+		data:         data,
+		serviceJobID: &job.ServiceJobID,
+	})
+	return err
+}
+
+// NotifyEstimate queues the estimate with its approval link, once per estimate
+func (u *NotificationUsecase) NotifyEstimate(ctx context.Context, estimate *models.ServiceEstimate) error {
+	job, err := u.repo.ServiceJob.GetByID(ctx, estimate.ServiceJobID)
+	if err != nil {
+		return err
+	}
+	if err := u.loadServiceJobParties(ctx, job); err != nil {
+		return err
+	}
+
+	data := serviceJobData(job)
+	data.Amount = utils.FormatRupiah(estimate.Total)
+	data.DueDate = utils.FormatTanggal(estimate.LinkExpiresAt)
+	data.Link = estimate.ApprovalURL
+	_, err = u.queue(ctx, notificationMessage{
+		customer:     job.Customer,
+		event:        models.NotificationEventEstimateReady,
+		data:         data,
+		serviceJobID: &job.ServiceJobID,
+		dedupeKey:    fmt.Sprintf("%s:%d", models.NotificationEventEstimateReady, estimate.EstimateID),
+	})
+	return err
+}
+
+// NotifyPartsArrived queues the arrival of the parts of a job, once per job and purchase order
+func (u *NotificationUsecase) NotifyPartsArrived(ctx context.Context, serviceJobID, purchaseOrderID uint) error {
+	job, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID)
+	if err != nil {
+		return err
+	}
+	if err := u.loadServiceJobParties(ctx, job); err != nil {
+		return err
+	}
+
+	_, err = u.queue(ctx, notificationMessage{
+		customer:     job.Customer,
+		event:        models.NotificationEventPartsArrived,
+		data:         serviceJobData(job),
+		serviceJobID: &job.ServiceJobID,
+		dedupeKey:    fmt.Sprintf("%s:%d:%d", models.NotificationEventPartsArrived, job.ServiceJobID, purchaseOrderID),
+	})
+	return err
+}
+
+// SendServiceReminders queues a reminder per job whose next service reminder date is in [from, to)
+func (u *NotificationUsecase) SendServiceReminders(ctx context.Context, from, to time.Time) (*interfaces.NotificationSummary, error) {
+	jobs, err := u.repo.ServiceJob.GetDueReminders(ctx, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &interfaces.NotificationSummary{Due: len(jobs)}
+	for _, job := range jobs {
+		if err := u.loadServiceJobParties(ctx, job); err != nil {
+			return nil, err
+		}
+		data := serviceJobData(job)
+		data.ReminderDate = utils.FormatTanggal(*job.NextServiceReminderDate)
+		queued, err := u.queue(ctx, notificationMessage{
+			customer:     job.Customer,
+			event:        models.NotificationEventServiceReminder,
+			data:         data,
+			serviceJobID: &job.ServiceJobID,
+			dedupeKey:    fmt.Sprintf("%s:%d:%s", models.NotificationEventServiceReminder, job.ServiceJobID, job.NextServiceReminderDate.Format("2006-01-02")),
+		})
+		if err != nil {
+			return nil, err
+		}
+		summary.Queued += queued
+		summary.Items = append(summary.Items, serviceJobLabel(job))
+	}
+	return summary, nil
+}
+
+// SendReceivableReminders queues a reminder per unpaid receivable due from today up to the reminder days ahead;
+// every due date is reminded of once
+func (u *NotificationUsecase) SendReceivableReminders(ctx context.Context, today time.Time) (*interfaces.NotificationSummary, error) {
+	days := u.conf.DueReminderDays
+	if days <= 0 {
+		days = defaultDueReminderDays
+	}
+	today = truncateDay(today)
+	receivables, err := u.repo.AccountsReceivable.GetDueBetween(ctx, today.Format("2006-01-02"), today.AddDate(0, 0, days+1).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &interfaces.NotificationSummary{Due: len(receivables)}
+	for _, receivable := range receivables {
+		if err := u.loadReceivableParties(ctx, receivable); err != nil {
+			return nil, err
+		}
+		data := notificationData{
+			Amount:  utils.FormatRupiah(receivable.TotalAmount - receivable.AmountPaid),
+			DueDate: utils.FormatTanggal(receivable.DueDate),
+		}
+		if receivable.Customer != nil {
+			data.CustomerName = receivable.Customer.Name
+		}
+		label := fmt.Sprintf("#%d", receivable.ReceivableID)
+		if receivable.Transaction != nil {
+			data.InvoiceNumber = receivable.Transaction.InvoiceNumber
+			label = receivable.Transaction.InvoiceNumber
+			if receivable.Transaction.Outlet != nil {
+				data.OutletName = receivable.Transaction.Outlet.OutletName
+				data.OutletPhone = stringValue(receivable.Transaction.Outlet.PhoneNumber)
+			}
+		}
+		queued, err := u.queue(ctx, notificationMessage{
+			customer:     receivable.Customer,
+			event:        models.NotificationEventReceivableDue,
+			data:         data,
+			receivableID: &receivable.ReceivableID,
+			dedupeKey:    fmt.Sprintf("%s:%d:%s", models.NotificationEventReceivableDue, receivable.ReceivableID, receivable.DueDate.Format("2006-01-02")),
+		})
+		if err != nil {
+			return nil, err
+		}
+		summary.Queued += queued
+		summary.Items = append(summary.Items, label)
+	}
+	return summary, nil
+}
+
+// SendPickupReminders queues one reminder per finished job that is still waiting for pickup after the reminder days
+func (u *NotificationUsecase) SendPickupReminders(ctx context.Context, now time.Time) (*interfaces.NotificationSummary, error) {
+	days := u.conf.PickupReminderDays
+	if days <= 0 {
+		days = defaultPickupReminderDays
+	}
+	jobs, err := u.repo.ServiceJob.GetAwaitingPickup(ctx, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &interfaces.NotificationSummary{Due: len(jobs)}
+	for _, job := range jobs {
+		if err := u.loadServiceJobParties(ctx, job); err != nil {
+			return nil, err
+		}
+		queued, err := u.queue(ctx, notificationMessage{
+			customer:     job.Customer,
+			event:        models.NotificationEventPickupReminder,
+			data:         serviceJobData(job),
+			serviceJobID: &job.ServiceJobID,
+			dedupeKey:    fmt.Sprintf("%s:%d", models.NotificationEventPickupReminder, job.ServiceJobID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		summary.Queued += queued
+		summary.Items = append(summary.Items, serviceJobLabel(job))
+	}
+	return summary, nil
+}
+
+// ProcessNextNotification claims the oldest due notification and sends it through its channel.
+// A failed attempt is retried later until the max attempts are reached.
+func (u *NotificationUsecase) ProcessNextNotification(ctx context.Context) (bool, error) {
+	notification, err := u.repo.Notification.ClaimNext(ctx, time.Now())
+	if err != nil || notification == nil {
+		return false, err
+	}
+
+	providerID, sendErr := u.send(ctx, notification)
+	if sendErr == nil {
+		return true, u.repo.Notification.MarkSent(ctx, notification.NotificationID, providerID, time.Now())
+	}
+
+	// A message without recipient or sender will not get one by trying again
+	permanent := errors.Is(sendErr, notify.ErrNoRecipient) || errors.Is(sendErr, errNoSender)
+	var retryAt *time.Time
+	if notification.Attempts < u.maxAttempts && !permanent {
+		at := time.Now().Add(time.Duration(notification.Attempts) * u.retryDelay)
+		retryAt = &at
+	}
+	if err := u.repo.Notification.MarkFailed(ctx, notification.NotificationID, sendErr.Error(), retryAt); err != nil {
+		return true, err
+	}
+	return true, fmt.Errorf("notification %d attempt %d/%d: %w", notification.NotificationID, notification.Attempts, u.maxAttempts, sendErr)
+}
+
+// RequeueStaleNotifications returns notifications abandoned in Diproses to the queue
+func (u *NotificationUsecase) RequeueStaleNotifications(ctx context.Context, olderThan time.Duration) (int64, error) {
+	return u.repo.Notification.RequeueStale(ctx, time.Now().Add(-olderThan))
+}
+
+func (u *NotificationUsecase) send(ctx context.Context, notification *models.Notification) (string, error) {
+	sender, ok := u.senders[string(notification.Channel)]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", notification.Channel, errNoSender)
+	}
+	sendCtx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+	defer cancel()
+	return sender.Send(sendCtx, notify.Message{
+		To:      notification.Recipient,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+	})
+}
+
+// queue renders the message and stores it for every configured channel the customer has an address for and did not
+// opt out of; it returns how many notifications were queued
+func (u *NotificationUsecase) queue(ctx context.Context, msg notificationMessage) (int, error) {
+	if u.confErr != nil {
+		return 0, u.confErr
+	}
+	if msg.customer == nil {
+		return 0, nil
+	}
+
+	optOuts, err := u.repo.Notification.GetOptOuts(ctx, msg.customer.CustomerID)
+	if err != nil {
+		return 0, err
+	}
+	optedOut := make(map[models.NotificationChannel]bool, len(optOuts))
+	for _, optOut := range optOuts {
+		optedOut[optOut.Channel] = true
+	}
+
+	if msg.data.CustomerName == "" {
+		msg.data.CustomerName = msg.customer.Name
+	}
+	var body, subject strings.Builder
+	if err := u.templates[msg.event].Execute(&body, msg.data); err != nil {
+		return 0, fmt.Errorf("template %s: %w", msg.event, err)
+	}
+	if err := u.subjects[msg.event].Execute(&subject, msg.data); err != nil {
+		return 0, fmt.Errorf("subject %s: %w", msg.event, err)
+	}
+
+	var by *uint
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		by = &actor.UserID
+	}
+
+	queued := 0
+	for _, channel := range u.channels {
+		recipient := msg.customer.PhoneNumber
+		if channel == models.NotificationChannelEmail {
+			recipient = stringValue(msg.customer.Email)
+		}
+		if optedOut[channel] || recipient == "" {
+			continue
+		}
+
+		notification := &models.Notification{
+			CustomerID:   msg.customer.CustomerID,
+			Channel:      channel,
+			Event:        msg.event,
+			Recipient:    recipient,
+			Subject:      subject.String(),
+			Body:         body.String(),
+			Status:       models.NotificationStatusPending,
+			ServiceJobID: msg.serviceJobID,
+			ReceivableID: msg.receivableID,
+			CreatedBy:    by,
+		}
+		if msg.dedupeKey != "" {
+			key := fmt.Sprintf("%s:%s", msg.dedupeKey, channel)
+			notification.DedupeKey = &key
+		}
+		created, err := u.repo.Notification.Create(ctx, notification)
+		if err != nil {
+			return queued, err
+		}
+		if created {
+			queued++
+		}
+	}
+	return queued, nil
+}
+
+// loadServiceJobParties loads customer, vehicle and outlet of a job by their IDs. The preloads of these
+// relations are not used as gorm resolves their foreignKey tags against the job's own primary key.
+func (u *NotificationUsecase) loadServiceJobParties(ctx context.Context, job *models.ServiceJob) error {
+	customer, err := u.repo.Customer.GetByID(ctx, job.CustomerID)
+	if err != nil {
+		return err
+	}
+	job.Customer = customer
+	job.Vehicle, job.Outlet = nil, nil
+	if vehicle, err := u.repo.CustomerVehicle.GetByID(ctx, job.VehicleID); err == nil {
+		job.Vehicle = vehicle
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if outlet, err := u.repo.Outlet.GetByID(ctx, job.OutletID); err == nil {
+		job.Outlet = outlet
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// loadReceivableParties loads customer, transaction and its outlet of a receivable by their IDs, see loadServiceJobParties
+func (u *NotificationUsecase) loadReceivableParties(ctx context.Context, receivable *models.AccountsReceivable) error {
+	customer, err := u.repo.Customer.GetByID(ctx, receivable.CustomerID)
+	if err != nil {
+		return err
+	}
+	receivable.Customer = customer
+	receivable.Transaction = nil
+	transaction, err := u.repo.Transaction.GetByID(ctx, receivable.TransactionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	transaction.Outlet = nil
+	if outlet, err := u.repo.Outlet.GetByID(ctx, transaction.OutletID); err == nil {
+		transaction.Outlet = outlet
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	receivable.Transaction = transaction
+	return nil
+}
+
+// serviceJobData fills the template values of a service job, see loadServiceJobParties
+func serviceJobData(job *models.ServiceJob) notificationData {
+	data := notificationData{
+		ServiceCode: job.ServiceCode,
+		Status:      string(job.Status),
+	}
+	if job.GrandTotal > 0 {
+		data.GrandTotal = utils.FormatRupiah(job.GrandTotal)
+	}
+	if job.Customer != nil {
+		data.CustomerName = job.Customer.Name
+	}
+	if job.Vehicle != nil {
+		data.PlateNumber = job.Vehicle.PlateNumber
+		data.Vehicle = strings.TrimSpace(job.Vehicle.Brand + " " + job.Vehicle.Model)
+	}
+	if job.Outlet != nil {
+		data.OutletName = job.Outlet.OutletName
+		data.OutletPhone = stringValue(job.Outlet.PhoneNumber)
+	}
+	return data
+}
+
+// serviceJobLabel names a job in run summaries, e.g. "SJ-001 Budi B 1234 XY"
+func serviceJobLabel(job *models.ServiceJob) string {
+	label := job.ServiceCode
+	if job.Customer != nil {
+		label += " " + job.Customer.Name
+	}
+	if job.Vehicle != nil {
+		label += " " + job.Vehicle.PlateNumber
+	}
+	return label
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
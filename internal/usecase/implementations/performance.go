@@ -0,0 +1,207 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"sort"
+	"time"
+)
+
+// Workshop performance dimensions
+const (
+	performanceByTechnician = "technician"
+	performanceByOutlet     = "outlet"
+)
+
+// jobTimeline holds when a service job reached each status, taken from the first history entry of the status
+type jobTimeline struct {
+	queuedAt    time.Time
+	startedAt   *time.Time
+	completedAt time.Time
+	complained  *time.Time
+}
+
+func newJobTimeline(job *models.ServiceJob) jobTimeline {
+	timeline := jobTimeline{queuedAt: job.ServiceInDate}
+	var queued, completed bool
+	for i := range job.Histories {
+		history := &job.Histories[i]
+		if history.Status == nil {
+			continue
+		}
+		switch *history.Status {
+		case models.ServiceStatusAntri:
+			if !queued {
+				timeline.queuedAt, queued = history.ChangedAt, true
+			}
+		case models.ServiceStatusDikerjakan:
+			if timeline.startedAt == nil {
+				timeline.startedAt = &history.ChangedAt
+			}
+		case models.ServiceStatusSelesai:
+			if !completed {
+				timeline.completedAt, completed = history.ChangedAt, true
+			}
+		case models.ServiceStatusKomplain:
+			if timeline.complained == nil {
+				timeline.complained = &history.ChangedAt
+			}
+		}
+	}
+	if job.ComplainDate != nil {
+		timeline.complained = job.ComplainDate
+	}
+	return timeline
+}
+
+// performanceBucket accumulates the jobs of one dimension; the durations are summed for the averages
+type performanceBucket struct {
+	figures          interfaces.PerformanceFigures
+	queue, repair    time.Duration
+	queued, repaired int64
+}
+
+func (b *performanceBucket) add(job *models.ServiceJob, timeline jobTimeline, comeback bool) {
+	b.figures.JobsCompleted++
+	b.figures.Revenue += job.GrandTotal
+	b.figures.Commission += job.TechnicianCommission
+	if comeback {
+		b.figures.Comebacks++
+	}
+	if timeline.startedAt != nil {
+		if wait := timeline.startedAt.Sub(timeline.queuedAt); wait >= 0 {
+			b.queue += wait
+			b.queued++
+		}
+		if repair := timeline.completedAt.Sub(*timeline.startedAt); repair >= 0 {
+			b.repair += repair
+			b.repaired++
+		}
+	}
+}
+
+func (b *performanceBucket) result() interfaces.PerformanceFigures {
+	figures := b.figures
+	figures.Revenue = roundAmount(figures.Revenue)
+	figures.Commission = roundAmount(figures.Commission)
+	figures.ComebackRate = percent(float64(figures.Comebacks), float64(figures.JobsCompleted))
+	if b.queued > 0 {
+		figures.AvgQueueMinutes = roundAmount(b.queue.Minutes() / float64(b.queued))
+	}
+	if b.repaired > 0 {
+		figures.AvgRepairMinutes = roundAmount(b.repair.Minutes() / float64(b.repaired))
+	}
+	return figures
+}
+
+// workshopPerformance measures the service jobs completed in the filter's range per technician or outlet,
+// rows are ordered by jobs completed and name
+func workshopPerformance(ctx context.Context, repo *repository.RepositoryManager, filter repoInterfaces.AnalyticsFilter,
+	groupBy string) ([]interfaces.PerformanceFigures, interfaces.PerformanceFigures, error) {
+	jobs, err := repo.Analytics.CompletedServiceJobs(ctx, filter)
+	if err != nil {
+		return nil, interfaces.PerformanceFigures{}, err
+	}
+
+	// Comebacks are found among the later visits of the same vehicles
+	visits := make(map[uint][]*models.ServiceJob)
+	if len(jobs) > 0 {
+		since := jobs[0].ServiceInDate
+		vehicleIDs := make([]uint, 0, len(jobs))
+		for _, job := range jobs {
+			if job.ServiceInDate.Before(since) {
+				since = job.ServiceInDate
+			}
+			if _, ok := visits[job.VehicleID]; !ok {
+				visits[job.VehicleID] = nil
+				vehicleIDs = append(vehicleIDs, job.VehicleID)
+			}
+		}
+		later, err := repo.Analytics.VehicleVisits(ctx, vehicleIDs, since)
+		if err != nil {
+			return nil, interfaces.PerformanceFigures{}, err
+		}
+		for _, visit := range later {
+			visits[visit.VehicleID] = append(visits[visit.VehicleID], visit)
+		}
+	}
+
+	buckets := make(map[uint]*performanceBucket)
+	var unassigned *performanceBucket
+	var total performanceBucket
+	for _, job := range jobs {
+		timeline := newJobTimeline(job)
+		comeback := isComeback(job, timeline, visits[job.VehicleID])
+
+		var bucket *performanceBucket
+		if groupBy == performanceByOutlet {
+			if bucket = buckets[job.OutletID]; bucket == nil {
+				bucket = &performanceBucket{}
+				bucket.figures.DimensionID = &job.OutletID
+				if job.Outlet != nil {
+					bucket.figures.DimensionName = job.Outlet.OutletName
+				}
+				buckets[job.OutletID] = bucket
+			}
+		} else if job.TechnicianID == nil {
+			if unassigned == nil {
+				unassigned = &performanceBucket{}
+			}
+			bucket = unassigned
+		} else {
+			if bucket = buckets[*job.TechnicianID]; bucket == nil {
+				bucket = &performanceBucket{}
+				bucket.figures.DimensionID = job.TechnicianID
+				if job.Technician != nil {
+					bucket.figures.DimensionName = job.Technician.Name
+				}
+				buckets[*job.TechnicianID] = bucket
+			}
+		}
+		bucket.add(job, timeline, comeback)
+		total.add(job, timeline, comeback)
+	}
+
+	rows := make([]interfaces.PerformanceFigures, 0, len(buckets)+1)
+	for _, bucket := range buckets {
+		rows = append(rows, bucket.result())
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].JobsCompleted != rows[j].JobsCompleted {
+			return rows[i].JobsCompleted > rows[j].JobsCompleted
+		}
+		if rows[i].DimensionName != rows[j].DimensionName {
+			return rows[i].DimensionName < rows[j].DimensionName
+		}
+		return *rows[i].DimensionID < *rows[j].DimensionID
+	})
+	// Jobs nobody was assigned to come last
+	if unassigned != nil {
+		rows = append(rows, unassigned.result())
+	}
+	return rows, total.result(), nil
+}
+
+// isComeback reports whether a job was complained about or the vehicle came back before the job's warranty
+// expired; jobs without a warranty have no comebacks
+func isComeback(job *models.ServiceJob, timeline jobTimeline, visits []*models.ServiceJob) bool {
+	if job.WarrantyExpiresAt == nil {
+		return false
+	}
+	// The warranty covers its last day
+	expires := *job.WarrantyExpiresAt
+	end := time.Date(expires.Year(), expires.Month(), expires.Day()+1, 0, 0, 0, 0, time.Local)
+
+	if timeline.complained != nil && timeline.complained.Before(end) {
+		return true
+	}
+	for _, visit := range visits {
+		if visit.ServiceJobID != job.ServiceJobID && visit.ServiceInDate.After(job.ServiceInDate) && visit.ServiceInDate.Before(end) {
+			return true
+		}
+	}
+	return false
+}
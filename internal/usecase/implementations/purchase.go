@@ -0,0 +1,218 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PurchaseOrderUsecase implements the purchase order usecase interface
+type PurchaseOrderUsecase struct {
+	repo          *repository.RepositoryManager
+	specialOrders interfaces.SpecialOrderUsecase
+}
+
+// NewPurchaseOrderUsecase creates a new purchase order usecase, received orders reserve their parts through
+// specialOrders
+func NewPurchaseOrderUsecase(repo *repository.RepositoryManager, specialOrders interfaces.SpecialOrderUsecase) interfaces.PurchaseOrderUsecase {
+	return &PurchaseOrderUsecase{repo: repo, specialOrders: specialOrders}
+}
+
+// ListPurchaseOrders lists purchase orders matching the list query
+func (u *PurchaseOrderUsecase) ListPurchaseOrders(ctx context.Context, q *query.ListQuery) ([]*models.PurchaseOrder, int64, error) {
+	return u.repo.PurchaseOrder.List(ctx, q)
+}
+
+// GetPurchaseOrder retrieves a purchase order with its supplier, outlet and lines
+func (u *PurchaseOrderUsecase) GetPurchaseOrder(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	purchaseOrder, err := u.repo.PurchaseOrder.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return purchaseOrder, nil
+}
+
+// UpdateDraftPurchaseOrder lets a buyer review a draft before confirming it; replaced lines recompute the total
+func (u *PurchaseOrderUsecase) UpdateDraftPurchaseOrder(ctx context.Context, id uint, req interfaces.UpdatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	purchaseOrder, err := u.draft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SupplierID != nil {
+		if _, err := u.repo.Supplier.GetByID(ctx, *req.SupplierID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrSupplierNotFound
+			}
+			return nil, err
+		}
+		purchaseOrder.SupplierID = *req.SupplierID
+	}
+	if req.PaymentType != nil {
+		purchaseOrder.PaymentType = *req.PaymentType
+	}
+	if req.Notes != nil {
+		purchaseOrder.Notes = req.Notes
+	}
+	purchaseOrder.UpdatedAt = time.Now()
+
+	if req.Items == nil {
+		if err := u.repo.PurchaseOrder.Update(ctx, purchaseOrder); err != nil {
+			return nil, err
+		}
+		return u.GetPurchaseOrder(ctx, id)
+	}
+
+	details := make([]models.PurchaseOrderDetail, 0, len(req.Items))
+	total := 0.0
+	for _, item := range req.Items {
+		if _, err := u.repo.Product.GetByID(ctx, item.ProductID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrProductNotFound
+			}
+			return nil, err
+		}
+		details = append(details, models.PurchaseOrderDetail{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			CostPrice: item.CostPrice,
+		})
+		total += float64(item.Quantity) * item.CostPrice
+	}
+	purchaseOrder.PurchaseOrderDetails = details
+	purchaseOrder.TotalAmount = roundAmount(total)
+
+	if err := u.repo.PurchaseOrder.UpdateWithDetails(ctx, purchaseOrder); err != nil {
+		return nil, err
+	}
+	return u.GetPurchaseOrder(ctx, id)
+}
+
+// ConfirmPurchaseOrder marks a reviewed draft as pending
+func (u *PurchaseOrderUsecase) ConfirmPurchaseOrder(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	if _, err := u.draft(ctx, id); err != nil {
+		return nil, err
+	}
+
+	var purchaseOrder *models.PurchaseOrder
+	err := u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		// The draft is claimed, so a draft deleted or confirmed in the meantime is not confirmed again
+		claimed, err := tx.PurchaseOrder.UpdateStatus(ctx, id, models.PurchaseStatusDraft, models.PurchaseStatusPending)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return interfaces.ErrPurchaseOrderNotDraft
+		}
+		purchaseOrder, err = tx.PurchaseOrder.GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purchaseOrder, nil
+}
+
+// DeleteDraftPurchaseOrder discards a draft, confirmed orders are kept; its special orders are requested again
+func (u *PurchaseOrderUsecase) DeleteDraftPurchaseOrder(ctx context.Context, id uint) error {
+	if _, err := u.draft(ctx, id); err != nil {
+		return err
+	}
+	return u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		// Claiming the draft without changing its status holds off a concurrent confirmation until it is deleted
+		claimed, err := tx.PurchaseOrder.UpdateStatus(ctx, id, models.PurchaseStatusDraft, models.PurchaseStatusDraft)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return interfaces.ErrPurchaseOrderNotDraft
+		}
+		if err := tx.SpecialOrder.Release(ctx, id); err != nil {
+			return err
+		}
+		return tx.PurchaseOrder.Delete(ctx, id)
+	})
+}
+
+// ReceivePurchaseOrder books every line of a pending order into its outlet at the ordered cost and completes the
+// order, then reserves the special-ordered parts for their jobs
+func (u *PurchaseOrderUsecase) ReceivePurchaseOrder(ctx context.Context, id uint) (*interfaces.PurchaseOrderReceipt, error) {
+	purchaseOrder, err := u.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBy *uint
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		createdBy = &actor.UserID
+	}
+	notes := fmt.Sprintf("purchase order %s", purchaseOrder.POCode)
+	referenceType := "purchase_order"
+	now := time.Now()
+	movements := make([]*models.StockMovement, 0, len(purchaseOrder.PurchaseOrderDetails))
+	for _, detail := range purchaseOrder.PurchaseOrderDetails {
+		movements = append(movements, &models.StockMovement{
+			ProductID:     detail.ProductID,
+			OutletID:      &purchaseOrder.OutletID,
+			MovementType:  models.StockMovementPurchase,
+			Quantity:      detail.Quantity,
+			UnitCost:      detail.CostPrice,
+			ReferenceType: &referenceType,
+			ReferenceID:   &purchaseOrder.PurchaseOrderID,
+			Notes:         &notes,
+			CreatedAt:     now,
+			CreatedBy:     createdBy,
+		})
+	}
+
+	// The goods, the order's status and the reservations of its special orders are booked together, so a failed
+	// receipt leaves the order Pending to be received again
+	var reserved []*models.SpecialOrder
+	err = u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		// Claiming the order first keeps a second receipt from booking the goods twice
+		claimed, err := tx.PurchaseOrder.UpdateStatus(ctx, id, models.PurchaseStatusPending, models.PurchaseStatusSelesai)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return interfaces.ErrPurchaseOrderNotPending
+		}
+		if err := tx.Costing.Receive(ctx, movements...); err != nil {
+			return err
+		}
+		reserved, err = reserveSpecialOrders(ctx, tx, purchaseOrder)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.specialOrders.PartsArrived(ctx, purchaseOrder.PurchaseOrderID, reserved)
+
+	if purchaseOrder, err = u.GetPurchaseOrder(ctx, id); err != nil {
+		return nil, err
+	}
+	return &interfaces.PurchaseOrderReceipt{PurchaseOrder: purchaseOrder, Reserved: reserved}, nil
+}
+
+// draft retrieves a purchase order that is still a draft
+func (u *PurchaseOrderUsecase) draft(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	purchaseOrder, err := u.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if purchaseOrder.Status != models.PurchaseStatusDraft {
+		return nil, interfaces.ErrPurchaseOrderNotDraft
+	}
+	return purchaseOrder, nil
+}
@@ -0,0 +1,167 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// newPurchaseTestUsecases wires the purchase order usecase to a special order usecase without notifications
+func newPurchaseTestUsecases(repo *repository.RepositoryManager) (interfaces.PurchaseOrderUsecase, interfaces.SpecialOrderUsecase) {
+	specialOrders := NewSpecialOrderUsecase(repo, nil, nil, NewSequenceUsecase(repo, config.SequenceAccount{}), nil)
+	return NewPurchaseOrderUsecase(repo, specialOrders), specialOrders
+}
+
+// requestTestParts stores a part request of the job for a product of the supplier
+func requestTestParts(t *testing.T, db *gorm.DB, serviceJob *models.ServiceJob, product *models.Product, quantity int) {
+	t.Helper()
+	now := time.Now()
+	order := &models.SpecialOrder{
+		ServiceJobID: serviceJob.ServiceJobID,
+		OutletID:     serviceJob.OutletID,
+		ProductID:    product.ProductID,
+		Quantity:     quantity,
+		Status:       models.SpecialOrderDiminta,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := db.Omit("Product", "ServiceJob").Create(order).Error; err != nil {
+		t.Fatalf("request parts: %v", err)
+	}
+}
+
+// generateTestDraft rolls the requests into one draft purchase order
+func generateTestDraft(t *testing.T, specialOrders interfaces.SpecialOrderUsecase) *models.PurchaseOrder {
+	t.Helper()
+	drafts, err := specialOrders.GenerateDraftOrders(context.Background(), interfaces.SpecialOrderDraftRequest{})
+	if err != nil {
+		t.Fatalf("generate drafts: %v", err)
+	}
+	if len(drafts.Orders) != 1 || len(drafts.Unassigned) != 0 {
+		t.Fatalf("got %d drafts and %d unassigned requests, want 1 and 0", len(drafts.Orders), len(drafts.Unassigned))
+	}
+	return drafts.Orders[0]
+}
+
+// newSpecialOrderFixture stores a job at outlet 1 and a product bought from supplier 1
+func newSpecialOrderFixture(t *testing.T) (*repository.RepositoryManager, *gorm.DB, *models.ServiceJob, *models.Product) {
+	t.Helper()
+	repo, db := newTestRepository(t)
+	product := createTestProduct(t, db, "Clutch plate", 120000, models.CostMethodAverage)
+	supplierID := uint(1)
+	if err := db.Model(product).Update("supplier_id", supplierID).Error; err != nil {
+		t.Fatalf("set supplier: %v", err)
+	}
+	product.SupplierID = &supplierID
+	return repo, db, createTestServiceJob(t, db, "SJ-1", 1), product
+}
+
+func TestSpecialOrdersReservedOnReceipt(t *testing.T) {
+	repo, db, serviceJob, product := newSpecialOrderFixture(t)
+	ctx := context.Background()
+	purchaseOrders, specialOrders := newPurchaseTestUsecases(repo)
+	requestTestParts(t, db, serviceJob, product, 2)
+	requestTestParts(t, db, serviceJob, product, 1)
+
+	draft := generateTestDraft(t, specialOrders)
+	if len(draft.PurchaseOrderDetails) != 1 || draft.PurchaseOrderDetails[0].Quantity != 3 {
+		t.Fatalf("got draft lines %+v, want one line of 3", draft.PurchaseOrderDetails)
+	}
+	if quantities := specialOrderQuantities(t, db, serviceJob.ServiceJobID); quantities[models.SpecialOrderDipesan] != 3 {
+		t.Fatalf("got %v, want 3 on order", quantities)
+	}
+	// The requests are on order, a second run finds nothing to order
+	drafts, err := specialOrders.GenerateDraftOrders(ctx, interfaces.SpecialOrderDraftRequest{})
+	if err != nil {
+		t.Fatalf("generate drafts again: %v", err)
+	}
+	if len(drafts.Orders) != 0 {
+		t.Fatalf("second run created %d drafts, want none", len(drafts.Orders))
+	}
+
+	if _, err := purchaseOrders.ReceivePurchaseOrder(ctx, draft.PurchaseOrderID); !errors.Is(err, interfaces.ErrPurchaseOrderNotPending) {
+		t.Fatalf("receive a draft: got %v, want %v", err, interfaces.ErrPurchaseOrderNotPending)
+	}
+	if _, err := purchaseOrders.ConfirmPurchaseOrder(ctx, draft.PurchaseOrderID); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	if _, err := purchaseOrders.ConfirmPurchaseOrder(ctx, draft.PurchaseOrderID); !errors.Is(err, interfaces.ErrPurchaseOrderNotDraft) {
+		t.Fatalf("confirm twice: got %v, want %v", err, interfaces.ErrPurchaseOrderNotDraft)
+	}
+
+	receipt, err := purchaseOrders.ReceivePurchaseOrder(ctx, draft.PurchaseOrderID)
+	if err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	if len(receipt.Reserved) != 2 || receipt.PurchaseOrder.Status != models.PurchaseStatusSelesai {
+		t.Fatalf("got %d orders reserved with the order %s, want 2 and %s", len(receipt.Reserved), receipt.PurchaseOrder.Status, models.PurchaseStatusSelesai)
+	}
+	if quantities := specialOrderQuantities(t, db, serviceJob.ServiceJobID); quantities[models.SpecialOrderDicadangkan] != 3 {
+		t.Fatalf("got %v, want 3 reserved", quantities)
+	}
+	if _, err := purchaseOrders.ReceivePurchaseOrder(ctx, draft.PurchaseOrderID); !errors.Is(err, interfaces.ErrPurchaseOrderNotPending) {
+		t.Fatalf("receive twice: got %v, want %v", err, interfaces.ErrPurchaseOrderNotPending)
+	}
+
+	// The received parts are held for the job and only its part line can use them
+	walkIn := &models.StockMovement{
+		ProductID:    product.ProductID,
+		OutletID:     &serviceJob.OutletID,
+		MovementType: models.StockMovementSale,
+		Quantity:     -1,
+	}
+	if _, err := issueStock(ctx, repo, walkIn); !errors.Is(err, interfaces.ErrStockReserved) {
+		t.Fatalf("walk-in sale: got %v, want %v", err, interfaces.ErrStockReserved)
+	}
+	_, err = NewServiceDetailUsecase(repo, nil, nil, nil).CreateServiceDetail(ctx, interfaces.CreateServiceDetailRequest{
+		ServiceJobID: serviceJob.ServiceJobID,
+		ItemID:       product.ProductID,
+		ItemType:     "product",
+		Description:  "Clutch plate",
+		Quantity:     3,
+		PricePerItem: 200000,
+	})
+	if err != nil {
+		t.Fatalf("part line: %v", err)
+	}
+	if quantities := specialOrderQuantities(t, db, serviceJob.ServiceJobID); quantities[models.SpecialOrderTerpakai] != 3 {
+		t.Fatalf("got %v, want 3 used", quantities)
+	}
+}
+
+func TestDeleteDraftPurchaseOrderRequestsPartsAgain(t *testing.T) {
+	repo, db, serviceJob, product := newSpecialOrderFixture(t)
+	ctx := context.Background()
+	purchaseOrders, specialOrders := newPurchaseTestUsecases(repo)
+	requestTestParts(t, db, serviceJob, product, 2)
+
+	draft := generateTestDraft(t, specialOrders)
+	if err := purchaseOrders.DeleteDraftPurchaseOrder(ctx, draft.PurchaseOrderID); err != nil {
+		t.Fatalf("delete draft: %v", err)
+	}
+	if quantities := specialOrderQuantities(t, db, serviceJob.ServiceJobID); quantities[models.SpecialOrderDiminta] != 2 {
+		t.Fatalf("got %v, want 2 requested again", quantities)
+	}
+	if _, err := purchaseOrders.GetPurchaseOrder(ctx, draft.PurchaseOrderID); !errors.Is(err, interfaces.ErrPurchaseOrderNotFound) {
+		t.Fatalf("get deleted draft: got %v, want %v", err, interfaces.ErrPurchaseOrderNotFound)
+	}
+
+	// A confirmed order is kept together with its special orders
+	draft = generateTestDraft(t, specialOrders)
+	if _, err := purchaseOrders.ConfirmPurchaseOrder(ctx, draft.PurchaseOrderID); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	if err := purchaseOrders.DeleteDraftPurchaseOrder(ctx, draft.PurchaseOrderID); !errors.Is(err, interfaces.ErrPurchaseOrderNotDraft) {
+		t.Fatalf("delete confirmed order: got %v, want %v", err, interfaces.ErrPurchaseOrderNotDraft)
+	}
+	if quantities := specialOrderQuantities(t, db, serviceJob.ServiceJobID); quantities[models.SpecialOrderDipesan] != 2 {
+		t.Fatalf("got %v, want 2 still on order", quantities)
+	}
+}
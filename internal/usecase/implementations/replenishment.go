@@ -0,0 +1,340 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Defaults used when the Replenishment config leaves a value at zero
+const (
+	defaultReplenishmentLookbackDays = 30
+	defaultReplenishmentCoverDays    = 14
+)
+
+// ReplenishmentUsecase implements the replenishment usecase interface
+type ReplenishmentUsecase struct {
+	repo         *repository.RepositoryManager
+	sequence     interfaces.SequenceUsecase
+	lookbackDays int
+	coverDays    int
+	outletID     uint
+}
+
+// NewReplenishmentUsecase creates a new replenishment usecase from the Replenishment config, the draft orders are
+// numbered by sequence
+func NewReplenishmentUsecase(repo *repository.RepositoryManager, sequence interfaces.SequenceUsecase, conf config.ReplenishmentAccount) interfaces.ReplenishmentUsecase {
+	u := &ReplenishmentUsecase{
+		repo:         repo,
+		sequence:     sequence,
+		lookbackDays: conf.LookbackDays,
+		coverDays:    conf.CoverDays,
+		outletID:     conf.OutletID,
+	}
+	if u.lookbackDays <= 0 {
+		u.lookbackDays = defaultReplenishmentLookbackDays
+	}
+	if u.coverDays <= 0 {
+		u.coverDays = defaultReplenishmentCoverDays
+	}
+	return u
+}
+
+// productOutlet keys the quantities of a product at an outlet, outletID is 0 for stock not booked to an outlet
+type productOutlet struct {
+	productID uint
+	outletID  uint
+}
+
+// productQuantities indexes ledger quantities per product and outlet and totals them per product
+type productQuantities struct {
+	byOutlet map[productOutlet]int64
+	total    map[uint]int64
+}
+
+func newProductQuantities(rows []repoInterfaces.ProductOutletQuantity) productQuantities {
+	q := productQuantities{byOutlet: make(map[productOutlet]int64), total: make(map[uint]int64)}
+	for _, row := range rows {
+		key := productOutlet{productID: row.ProductID}
+		if row.OutletID != nil {
+			key.outletID = *row.OutletID
+		}
+		q.byOutlet[key] += row.Quantity
+		q.total[row.ProductID] += row.Quantity
+	}
+	return q
+}
+
+// of returns the quantity of a product at an outlet, or its total when outletID is nil
+func (q productQuantities) of(productID uint, outletID *uint) int64 {
+	if outletID == nil {
+		return q.total[productID]
+	}
+	return q.byOutlet[productOutlet{productID: productID, outletID: *outletID}]
+}
+
+// Suggestions lists the products whose available stock reached their min stock with the quantity to order. The
+// order tops the stock up to the min stock plus the expected usage of the cover days and is at least the reorder
+// quantity; the expected usage is the average daily sales and service usage of the lookback days.
+func (u *ReplenishmentUsecase) Suggestions(ctx context.Context, req interfaces.ReplenishmentRequest) (*interfaces.ReplenishmentPlan, error) {
+	if req.OutletID != nil {
+		if _, err := u.repo.Outlet.GetByID(ctx, *req.OutletID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrOutletNotFound
+			}
+			return nil, err
+		}
+	}
+	lookbackDays, coverDays := req.LookbackDays, req.CoverDays
+	if lookbackDays <= 0 {
+		lookbackDays = u.lookbackDays
+	}
+	if coverDays <= 0 {
+		coverDays = u.coverDays
+	}
+
+	now := time.Now()
+	candidates, err := u.repo.Replenishment.Candidates(ctx, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+	consumed, err := u.repo.Replenishment.Consumption(ctx, now.AddDate(0, 0, -lookbackDays).UTC(), req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+	ordered, err := u.repo.Replenishment.OnOrder(ctx, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+	committed, err := u.repo.Replenishment.Committed(ctx, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+	consumption, onOrder, reserved := newProductQuantities(consumed), newProductQuantities(ordered), newProductQuantities(committed)
+
+	plan := &interfaces.ReplenishmentPlan{
+		GeneratedAt:  now,
+		LookbackDays: lookbackDays,
+		CoverDays:    coverDays,
+		Suggestions:  []interfaces.ReplenishmentSuggestion{},
+	}
+	for _, candidate := range candidates {
+		suggestion := interfaces.ReplenishmentSuggestion{
+			OutletID:        candidate.OutletID,
+			ProductID:       candidate.ProductID,
+			ProductName:     candidate.ProductName,
+			SKU:             candidate.SKU,
+			SupplierID:      candidate.SupplierID,
+			SupplierName:    candidate.SupplierName,
+			MinStock:        candidate.MinStock,
+			ReorderQuantity: candidate.ReorderQuantity,
+			OnHand:          candidate.OnHand,
+			OnOrder:         onOrder.of(candidate.ProductID, candidate.OutletID),
+			Reserved:        reserved.of(candidate.ProductID, candidate.OutletID),
+			CostPrice:       candidate.CostPrice,
+		}
+		// Special orders are on order or in stock for their jobs only
+		available := suggestion.OnHand + suggestion.OnOrder - suggestion.Reserved
+		if available > int64(candidate.MinStock) {
+			continue
+		}
+
+		// Returns can outweigh what was used in the lookback days
+		avgDailyUsage := math.Max(float64(consumption.of(candidate.ProductID, candidate.OutletID))/float64(lookbackDays), 0)
+		quantity := int64(candidate.MinStock) + int64(math.Ceil(avgDailyUsage*float64(coverDays))) - available
+		if quantity < int64(candidate.ReorderQuantity) {
+			quantity = int64(candidate.ReorderQuantity)
+		}
+		if quantity < 1 {
+			quantity = 1
+		}
+
+		suggestion.AvgDailyUsage = math.Round(avgDailyUsage*1000) / 1000
+		suggestion.SuggestedQuantity = quantity
+		suggestion.EstimatedCost = roundAmount(float64(quantity) * candidate.CostPrice)
+		plan.EstimatedCost += suggestion.EstimatedCost
+		plan.Suggestions = append(plan.Suggestions, suggestion)
+	}
+	plan.EstimatedCost = roundAmount(plan.EstimatedCost)
+
+	// Outlet by outlet, the products planned against their total stock last
+	sort.SliceStable(plan.Suggestions, func(i, j int) bool {
+		a, b := plan.Suggestions[i], plan.Suggestions[j]
+		if (a.OutletID == nil) != (b.OutletID == nil) {
+			return b.OutletID == nil
+		}
+		if a.OutletID != nil && *a.OutletID != *b.OutletID {
+			return *a.OutletID < *b.OutletID
+		}
+		return a.ProductName < b.ProductName
+	})
+	return plan, nil
+}
+
+// GenerateDraftOrders turns the suggestions into draft purchase orders, one per outlet and preferred supplier.
+// Products planned against their total stock are ordered for the configured outlet. Draft orders count as on order,
+// so running it again only orders what is still missing.
+func (u *ReplenishmentUsecase) GenerateDraftOrders(ctx context.Context, req interfaces.ReplenishmentRequest) (*interfaces.DraftOrders, error) {
+	plan, err := u.Suggestions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &interfaces.DraftOrders{
+		Orders:     []*models.PurchaseOrder{},
+		Unassigned: []interfaces.ReplenishmentSuggestion{},
+	}
+	type orderKey struct{ outletID, supplierID uint }
+	var keys []orderKey
+	orders := make(map[orderKey]*models.PurchaseOrder)
+	var receivingOutletID uint
+	for _, suggestion := range plan.Suggestions {
+		if suggestion.SupplierID == nil {
+			result.Unassigned = append(result.Unassigned, suggestion)
+			continue
+		}
+
+		key := orderKey{supplierID: *suggestion.SupplierID}
+		if suggestion.OutletID != nil {
+			key.outletID = *suggestion.OutletID
+		} else {
+			if receivingOutletID == 0 {
+				if receivingOutletID, err = u.receivingOutlet(ctx); err != nil {
+					return nil, err
+				}
+			}
+			key.outletID = receivingOutletID
+		}
+
+		order := orders[key]
+		if order == nil {
+			notes := "generated by replenishment"
+			order = &models.PurchaseOrder{
+				SupplierID:  key.supplierID,
+				OutletID:    key.outletID,
+				PODate:      truncateDay(plan.GeneratedAt),
+				PaymentType: models.PaymentTypeTunai,
+				Status:      models.PurchaseStatusDraft,
+				Notes:       &notes,
+				CreatedAt:   plan.GeneratedAt,
+				UpdatedAt:   plan.GeneratedAt,
+			}
+			orders[key] = order
+			keys = append(keys, key)
+		}
+		order.PurchaseOrderDetails = append(order.PurchaseOrderDetails, models.PurchaseOrderDetail{
+			ProductID: suggestion.ProductID,
+			Quantity:  int(suggestion.SuggestedQuantity),
+			CostPrice: suggestion.CostPrice,
+		})
+		order.TotalAmount = roundAmount(order.TotalAmount + suggestion.EstimatedCost)
+	}
+
+	for _, key := range keys {
+		if orders[key].POCode, _, err = u.sequence.Next(ctx, interfaces.SequencePurchaseOrder, key.outletID, plan.GeneratedAt); err != nil {
+			return nil, err
+		}
+		if err := u.repo.PurchaseOrder.Create(ctx, orders[key]); err != nil {
+			return nil, err
+		}
+		order, err := u.repo.PurchaseOrder.GetByID(ctx, orders[key].PurchaseOrderID)
+		if err != nil {
+			return nil, err
+		}
+		result.Orders = append(result.Orders, order)
+	}
+	return result, nil
+}
+
+// receivingOutlet returns the outlet that receives the orders of products planned against their total stock
+func (u *ReplenishmentUsecase) receivingOutlet(ctx context.Context) (uint, error) {
+	if u.outletID != 0 {
+		return u.outletID, nil
+	}
+	outlets, err := u.repo.Outlet.GetByStatus(ctx, models.StatusAktif)
+	if err != nil {
+		return 0, err
+	}
+	if len(outlets) == 0 {
+		return 0, interfaces.ErrOutletNotFound
+	}
+	first := outlets[0].OutletID
+	for _, outlet := range outlets[1:] {
+		if outlet.OutletID < first {
+			first = outlet.OutletID
+		}
+	}
+	return first, nil
+}
+
+// GetStockLevels retrieves the outlet stock levels of a product
+func (u *ReplenishmentUsecase) GetStockLevels(ctx context.Context, productID uint) ([]*models.ProductStockLevel, error) {
+	if _, err := u.repo.Product.GetByID(ctx, productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrProductNotFound
+		}
+		return nil, err
+	}
+	return u.repo.Replenishment.GetStockLevels(ctx, productID)
+}
+
+// SetStockLevel sets the reorder point of a product at an outlet, from then on the product is planned per outlet
+func (u *ReplenishmentUsecase) SetStockLevel(ctx context.Context, productID, outletID uint, req interfaces.SetStockLevelRequest) (*models.ProductStockLevel, error) {
+	if _, err := u.repo.Product.GetByID(ctx, productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrProductNotFound
+		}
+		return nil, err
+	}
+	if _, err := u.repo.Outlet.GetByID(ctx, outletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	level := &models.ProductStockLevel{
+		ProductID:       productID,
+		OutletID:        outletID,
+		MinStock:        req.MinStock,
+		ReorderQuantity: req.ReorderQuantity,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := u.repo.Replenishment.SaveStockLevel(ctx, level); err != nil {
+		return nil, err
+	}
+
+	// An updated level keeps its ID and creation time
+	levels, err := u.repo.Replenishment.GetStockLevels(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, saved := range levels {
+		if saved.OutletID == outletID {
+			return saved, nil
+		}
+	}
+	return level, nil
+}
+
+// DeleteStockLevel removes the reorder point of a product at an outlet
+func (u *ReplenishmentUsecase) DeleteStockLevel(ctx context.Context, productID, outletID uint) error {
+	if err := u.repo.Replenishment.DeleteStockLevel(ctx, productID, outletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrStockLevelNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,498 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/storage"
+	"boilerplate/pkg/tabular"
+	"boilerplate/pkg/utils"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// reportMaxAttempts is how often a report is tried before it is marked Gagal
+	reportMaxAttempts = 3
+	// reportRetryDelay is multiplied by the attempt number to space out retries
+	reportRetryDelay = time.Minute
+	// reportMaxPeriod limits the date range of a single report
+	reportMaxPeriod = 366 * 24 * time.Hour
+)
+
+// ReportUsecase implements the report usecase interface
+type ReportUsecase struct {
+	repo   *repository.RepositoryManager
+	store  storage.Storage
+	labour config.LabourAccount
+}
+
+// NewReportUsecase creates a new report usecase storing generated files in store
+func NewReportUsecase(repo *repository.RepositoryManager, store storage.Storage, labour config.LabourAccount) interfaces.ReportUsecase {
+	return &ReportUsecase{repo: repo, store: store, labour: labourConfig(labour)}
+}
+
+// RequestReport queues a report for the background workers
+func (u *ReportUsecase) RequestReport(ctx context.Context, req interfaces.CreateReportRequest) (*models.Report, error) {
+	var userID uint
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		userID = actor.UserID
+	} else if req.UserID != nil {
+		userID = *req.UserID
+	} else {
+		return nil, interfaces.ErrReportUserRequired
+	}
+
+	startDate := truncateDay(req.StartDate)
+	endDate := truncateDay(req.EndDate)
+	if endDate.Sub(startDate) > reportMaxPeriod {
+		return nil, interfaces.ErrReportPeriodTooLong
+	}
+
+	if req.OutletID != nil {
+		// Stock is kept for all outlets together, the turnover report cannot be split per outlet
+		if req.ReportType == models.ReportTypePerputaran {
+			return nil, interfaces.ErrReportOutletNotSupported
+		}
+		if _, err := u.repo.Outlet.GetByID(ctx, *req.OutletID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrOutletNotFound
+			}
+			return nil, err
+		}
+	}
+
+	format := req.Format
+	if format == "" {
+		format = models.ReportFormatCSV
+	}
+
+	name := fmt.Sprintf("%s %s s/d %s (%s)", req.ReportType, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"),
+		time.Now().Format("20060102-150405.000"))
+	if req.ReportName != nil {
+		name = *req.ReportName
+		if existing, err := u.repo.Report.GetByName(ctx, name); err == nil && existing != nil {
+			return nil, interfaces.ErrReportNameExists
+		}
+	}
+
+	report := &models.Report{
+		ReportName: name,
+		ReportType: req.ReportType,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		OutletID:   req.OutletID,
+		UserID:     userID,
+		Format:     format,
+		Status:     models.ReportStatusPending,
+	}
+	if err := u.repo.Report.Create(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetReport retrieves a report by ID
+func (u *ReportUsecase) GetReport(ctx context.Context, id uint) (*models.Report, error) {
+	report, err := u.repo.Report.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrReportNotFound
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// ListReports lists reports matching the list query
+func (u *ReportUsecase) ListReports(ctx context.Context, q *query.ListQuery) ([]*models.Report, int64, error) {
+	return u.repo.Report.List(ctx, q)
+}
+
+// DownloadReport opens the generated file of a finished report
+func (u *ReportUsecase) DownloadReport(ctx context.Context, id uint) (*interfaces.ReportFile, error) {
+	report, err := u.GetReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != models.ReportStatusSelesai || report.GeneratedFilePath == nil {
+		return nil, interfaces.ErrReportNotReady
+	}
+
+	key := *report.GeneratedFilePath
+	body, err := u.store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, interfaces.ErrReportFileNotFound
+		}
+		return nil, err
+	}
+
+	format := tabular.Format(strings.TrimPrefix(path.Ext(key), "."))
+	return &interfaces.ReportFile{
+		Name:        slug(report.ReportName) + format.Extension(),
+		ContentType: format.ContentType(),
+		Body:        body,
+	}, nil
+}
+
+// RetryReport queues a failed report again with a fresh set of attempts
+func (u *ReportUsecase) RetryReport(ctx context.Context, id uint) (*models.Report, error) {
+	report, err := u.GetReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != models.ReportStatusGagal {
+		return nil, interfaces.ErrReportNotFailed
+	}
+
+	report.Status = models.ReportStatusPending
+	report.Attempts = 0
+	report.NextAttemptAt = nil
+	if err := u.repo.Report.Update(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ProcessNextReport claims the oldest due report, builds its file and stores it.
+// A failed attempt is retried later until reportMaxAttempts is reached.
+func (u *ReportUsecase) ProcessNextReport(ctx context.Context) (bool, error) {
+	report, err := u.repo.Report.ClaimNext(ctx, time.Now())
+	if err != nil || report == nil {
+		return false, err
+	}
+
+	key, genErr := u.generate(ctx, report)
+	if genErr == nil {
+		return true, u.repo.Report.MarkCompleted(ctx, report.ReportID, key, time.Now())
+	}
+
+	var retryAt *time.Time
+	if report.Attempts < reportMaxAttempts {
+		at := time.Now().Add(time.Duration(report.Attempts) * reportRetryDelay)
+		retryAt = &at
+	}
+	if err := u.repo.Report.MarkFailed(ctx, report.ReportID, genErr.Error(), retryAt); err != nil {
+		return true, err
+	}
+	return true, fmt.Errorf("report %d attempt %d/%d: %w", report.ReportID, report.Attempts, reportMaxAttempts, genErr)
+}
+
+// RequeueStaleReports returns reports abandoned in Diproses to the queue
+func (u *ReportUsecase) RequeueStaleReports(ctx context.Context, olderThan time.Duration) (int64, error) {
+	return u.repo.Report.RequeueStale(ctx, time.Now().Add(-olderThan))
+}
+
+// generate renders the report in its format and stores it, returning the storage key
+func (u *ReportUsecase) generate(ctx context.Context, report *models.Report) (string, error) {
+	table, err := u.buildTable(ctx, report)
+	if err != nil {
+		return "", err
+	}
+
+	format := tabular.Format(report.Format)
+	if format == "" {
+		format = tabular.CSV
+	}
+	var buf bytes.Buffer
+	if err := tabular.Write(&buf, format, table); err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("reports/%s/%d-%s%s", report.CreatedAt.Format("2006/01"), report.ReportID, slug(report.ReportName), format.Extension())
+	if err := u.store.Put(ctx, key, &buf, format.ContentType()); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (u *ReportUsecase) buildTable(ctx context.Context, report *models.Report) (*tabular.Table, error) {
+	filter := repoInterfaces.ReportFilter{
+		StartDate: report.StartDate,
+		EndDate:   report.EndDate.AddDate(0, 0, 1),
+		OutletID:  report.OutletID,
+	}
+
+	outletName := "Semua outlet"
+	if report.OutletID != nil {
+		outlet, err := u.repo.Outlet.GetByID(ctx, *report.OutletID)
+		if err != nil {
+			return nil, err
+		}
+		outletName = outlet.OutletName
+	}
+	meta := [][2]string{
+		{"Periode", report.StartDate.Format("2006-01-02") + " s/d " + report.EndDate.Format("2006-01-02")},
+		{"Outlet", outletName},
+		{"Dibuat", time.Now().Format("2006-01-02 15:04")},
+	}
+
+	switch report.ReportType {
+	case models.ReportTypePenjualan:
+		return u.salesTable(ctx, filter, meta)
+	case models.ReportTypeKeuangan:
+		return u.cashFlowTable(ctx, filter, meta)
+	case models.ReportTypeInventory:
+		return u.inventoryTable(ctx, filter, meta)
+	case models.ReportTypeTeknisi:
+		return u.technicianTable(ctx, filter, meta)
+	case models.ReportTypePerputaran:
+		return u.stockTurnoverTable(ctx, filter, meta)
+	case models.ReportTypeEfisiensi:
+		return u.efficiencyTable(ctx, filter, meta)
+	}
+	return nil, fmt.Errorf("unknown report type %q", report.ReportType)
+}
+
+func (u *ReportUsecase) salesTable(ctx context.Context, filter repoInterfaces.ReportFilter, meta [][2]string) (*tabular.Table, error) {
+	rows, err := u.repo.Report.SalesRows(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &tabular.Table{
+		Title:   "Laporan Penjualan",
+		Meta:    meta,
+		Columns: []string{"No. Invoice", "Tanggal", "Jenis", "Status", "Outlet", "Kasir", "Pelanggan", "Jumlah Item", "Total"},
+	}
+	items, total := 0, 0.0
+	for _, row := range rows {
+		table.Rows = append(table.Rows, []interface{}{
+			row.InvoiceNumber, row.TransactionDate, row.TransactionType, row.Status,
+			row.OutletName, row.CashierName, row.CustomerName, row.ItemCount, row.Total,
+		})
+		// Only successful transactions count towards the revenue
+		if row.Status == string(models.TransactionStatusSukses) {
+			items += row.ItemCount
+			total += row.Total
+		}
+	}
+	table.Totals = []interface{}{"Total (sukses)", "", "", "", "", "", "", items, total}
+	return table, nil
+}
+
+func (u *ReportUsecase) cashFlowTable(ctx context.Context, filter repoInterfaces.ReportFilter, meta [][2]string) (*tabular.Table, error) {
+	rows, err := u.repo.Report.CashFlowRows(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &tabular.Table{
+		Title:   "Laporan Keuangan",
+		Columns: []string{"Tanggal", "Jenis", "Sumber", "Keterangan", "Dicatat Oleh", "Pemasukan", "Pengeluaran"},
+	}
+	income, expense := 0.0, 0.0
+	for _, row := range rows {
+		var in, out interface{}
+		if row.Type == string(models.CashFlowTypePengeluaran) {
+			out = row.Amount
+			expense += row.Amount
+		} else {
+			in = row.Amount
+			income += row.Amount
+		}
+		table.Rows = append(table.Rows, []interface{}{row.Date, row.Type, row.Source, row.Notes, row.UserName, in, out})
+	}
+	table.Meta = append(meta, [2]string{"Saldo bersih", fmt.Sprintf("%.2f", income-expense)})
+	table.Totals = []interface{}{"Total", "", "", "", "", income, expense}
+	return table, nil
+}
+
+func (u *ReportUsecase) inventoryTable(ctx context.Context, filter repoInterfaces.ReportFilter, meta [][2]string) (*tabular.Table, error) {
+	rows, err := u.repo.Report.InventoryRows(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &tabular.Table{
+		Title:   "Laporan Inventory",
+		Meta:    meta,
+		Columns: []string{"Produk", "SKU", "Kategori", "Masuk", "Keluar", "Stok Saat Ini", "Harga Pokok", "Harga Jual", "Nilai Stok"},
+	}
+	stock, value := 0, 0.0
+	for _, row := range rows {
+		rowValue := float64(row.Stock) * row.CostPrice
+		table.Rows = append(table.Rows, []interface{}{
+			row.ProductName, row.SKU, row.CategoryName, row.QuantityIn, row.QuantityOut,
+			row.Stock, row.CostPrice, row.SellingPrice, rowValue,
+		})
+		stock += row.Stock
+		value += rowValue
+	}
+	table.Totals = []interface{}{"Total", "", "", "", "", stock, "", "", value}
+	return table, nil
+}
+
+func (u *ReportUsecase) technicianTable(ctx context.Context, filter repoInterfaces.ReportFilter, meta [][2]string) (*tabular.Table, error) {
+	rows, totals, err := workshopPerformance(ctx, u.repo, repoInterfaces.AnalyticsFilter{
+		StartDate: filter.StartDate.UTC(),
+		EndDate:   filter.EndDate.UTC(),
+		OutletID:  filter.OutletID,
+	}, performanceByTechnician)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &tabular.Table{
+		Title: "Laporan Kinerja Teknisi",
+		Meta:  meta,
+		Columns: []string{"Teknisi", "Servis Selesai", "Rata-rata Antri (menit)", "Rata-rata Pengerjaan (menit)",
+			"Komplain/Kembali", "Tingkat Komplain (%)", "Pendapatan", "Komisi"},
+	}
+	for _, row := range rows {
+		name := row.DimensionName
+		if row.DimensionID == nil {
+			name = "Tanpa teknisi"
+		}
+		table.Rows = append(table.Rows, []interface{}{
+			name, row.JobsCompleted, row.AvgQueueMinutes, row.AvgRepairMinutes,
+			row.Comebacks, row.ComebackRate, row.Revenue, row.Commission,
+		})
+	}
+	table.Totals = []interface{}{
+		"Total", totals.JobsCompleted, totals.AvgQueueMinutes, totals.AvgRepairMinutes,
+		totals.Comebacks, totals.ComebackRate, totals.Revenue, totals.Commission,
+	}
+	return table, nil
+}
+
+func (u *ReportUsecase) efficiencyTable(ctx context.Context, filter repoInterfaces.ReportFilter, meta [][2]string) (*tabular.Table, error) {
+	rows, totals, err := labourEfficiency(ctx, u.repo, repoInterfaces.AnalyticsFilter{
+		StartDate: filter.StartDate.UTC(),
+		EndDate:   filter.EndDate.UTC(),
+		OutletID:  filter.OutletID,
+	}, labourByTechnician, u.labour)
+	if err != nil {
+		return nil, err
+	}
+
+	basis := "waktu standar"
+	if u.labour.CommissionBasis == labourBasisClocked {
+		basis = "waktu kerja"
+	}
+	table := &tabular.Table{
+		Title: "Laporan Efisiensi Teknisi",
+		Meta:  append(meta, [2]string{"Dasar komisi", fmt.Sprintf("%s, %.2f per jam", basis, u.labour.CommissionPerHour)}),
+		Columns: []string{"Teknisi", "Waktu Kerja (menit)", "Jeda (menit)", "Baris Selesai", "Waktu Baris Selesai (menit)",
+			"Waktu Standar (menit)", "Efisiensi (%)", "Komisi"},
+	}
+	for _, row := range rows {
+		table.Rows = append(table.Rows, []interface{}{
+			row.DimensionName, row.ClockedMinutes, pausedTotal(row.PausedMinutes), row.LinesFinished, row.LineMinutes,
+			row.StandardMinutes, row.EfficiencyPercent, row.Commission,
+		})
+	}
+	table.Totals = []interface{}{
+		"Total", totals.ClockedMinutes, pausedTotal(totals.PausedMinutes), totals.LinesFinished, totals.LineMinutes,
+		totals.StandardMinutes, totals.EfficiencyPercent, totals.Commission,
+	}
+	return table, nil
+}
+
+// pausedTotal sums the paused minutes of all reasons
+func pausedTotal(paused map[string]float64) float64 {
+	total := 0.0
+	for _, minutes := range paused {
+		total += minutes
+	}
+	return roundAmount(total)
+}
+
+func (u *ReportUsecase) stockTurnoverTable(ctx context.Context, filter repoInterfaces.ReportFilter, meta [][2]string) (*tabular.Table, error) {
+	analysis, err := stockAnalysis(ctx, u.repo, repoInterfaces.AnalyticsFilter{
+		StartDate: filter.StartDate.UTC(),
+		EndDate:   filter.EndDate.UTC(),
+	}, defaultDeadStockDays, defaultMinTurnover)
+	if err != nil {
+		return nil, err
+	}
+
+	classes := map[string]string{
+		interfaces.StockClassFast: "Cepat",
+		interfaces.StockClassSlow: "Lambat",
+		interfaces.StockClassDead: "Mati",
+	}
+	table := &tabular.Table{
+		Title: "Laporan Perputaran Stok",
+		Meta: append(meta,
+			[2]string{"Batas stok mati (hari)", fmt.Sprintf("%d", analysis.DeadDays)},
+			[2]string{"Perputaran minimum per tahun", fmt.Sprintf("%.2f", analysis.MinTurnover)},
+			[2]string{"Modal stok lambat", fmt.Sprintf("%.2f", analysis.Totals.SlowCapital)},
+			[2]string{"Modal stok mati", fmt.Sprintf("%.2f", analysis.Totals.DeadCapital)},
+		),
+		Columns: []string{"Produk", "SKU", "Kategori", "Supplier", "Stok", "Harga Pokok", "Modal Tertahan",
+			"Terakhir Bergerak", "Hari Tanpa Gerak", "Jumlah Terpakai", "Nilai Pemakaian", "Perputaran per Tahun",
+			"Hari Persediaan", "Kelas", "ABC"},
+	}
+	for _, product := range analysis.Products {
+		category, supplier := "Tanpa kategori", "Tanpa supplier"
+		if product.CategoryName != nil {
+			category = *product.CategoryName
+		}
+		if product.SupplierName != nil {
+			supplier = *product.SupplierName
+		}
+		var turnover, daysOnHand interface{}
+		if product.Turnover != nil {
+			turnover = *product.Turnover
+		}
+		if product.DaysOnHand != nil {
+			daysOnHand = *product.DaysOnHand
+		}
+		table.Rows = append(table.Rows, []interface{}{
+			product.ProductName, product.SKU, category, supplier, product.Stock, product.CostPrice, product.CapitalTiedUp,
+			product.LastMoved, product.DaysSinceMoved, product.QuantityConsumed, product.ConsumptionValue, turnover,
+			daysOnHand, classes[product.Class], product.ABC,
+		})
+	}
+	var daysOnHand interface{}
+	if analysis.Totals.DaysOnHand != nil {
+		daysOnHand = *analysis.Totals.DaysOnHand
+	}
+	table.Totals = []interface{}{
+		"Total", "", "", "", analysis.Totals.Stock, "", analysis.Totals.CapitalTiedUp,
+		"", "", "", analysis.Totals.ConsumptionValue, "", daysOnHand, "", "",
+	}
+	return table, nil
+}
+
+// truncateDay drops the clock time, report periods are whole days
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// parseDate parses a YYYY-MM-DD date of the request in local time, a malformed one fails naming its field
+func parseDate(field, value string) (time.Time, error) {
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, interfaces.ErrDateInvalid.Wrap(err).WithDetails(map[string]interface{}{field: value})
+	}
+	return date, nil
+}
+
+// slug turns a report name into a file name, e.g. "Penjualan 2024-01-01 s/d 2024-01-31" to "penjualan-2024-01-01-s-d-2024-01-31"
+func slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
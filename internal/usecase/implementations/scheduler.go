@@ -0,0 +1,459 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/cron"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultJobLockMinutes is used when the Scheduler config leaves LockMinutes at zero
+const defaultJobLockMinutes = 30
+
+// defaultDailyReportTypes are requested by the daily reports job when Scheduler.ReportTypes is empty
+var defaultDailyReportTypes = []string{string(models.ReportTypePenjualan), string(models.ReportTypeKeuangan)}
+
+// schedulerJob is a job with its schedule; run returns a summary of what it did for the run history
+type schedulerJob struct {
+	name          string
+	description   string
+	expr          string
+	schedule      *cron.Schedule
+	scheduleError string
+	disabled      bool
+	run           func(ctx context.Context, lastSuccess *models.JobRun) (string, error)
+}
+
+// SchedulerUsecase implements the scheduler usecase interface
+type SchedulerUsecase struct {
+	repo         *repository.RepositoryManager
+	report       interfaces.ReportUsecase
+	product      interfaces.ProductUsecase
+	notification interfaces.NotificationUsecase
+	appointment  interfaces.AppointmentUsecase
+	board        interfaces.BoardUsecase
+	conf         config.SchedulerAccount
+	instance     string
+	lockFor      time.Duration
+	jobs         []*schedulerJob
+}
+
+// NewSchedulerUsecase creates the scheduler with its jobs; schedules from the Scheduler config replace the defaults
+func NewSchedulerUsecase(repo *repository.RepositoryManager, report interfaces.ReportUsecase, product interfaces.ProductUsecase,
+	notification interfaces.NotificationUsecase, appointment interfaces.AppointmentUsecase, board interfaces.BoardUsecase, conf config.SchedulerAccount) interfaces.SchedulerUsecase {
+	u := &SchedulerUsecase{
+		repo:         repo,
+		report:       report,
+		product:      product,
+		notification: notification,
+		appointment:  appointment,
+		board:        board,
+		conf:         conf,
+		instance:     instanceName(),
+		lockFor:      time.Duration(conf.LockMinutes) * time.Minute,
+	}
+	if u.lockFor <= 0 {
+		u.lockFor = defaultJobLockMinutes * time.Minute
+	}
+
+	u.addJob("overdue-accounts", "Flag unpaid payables and receivables past their due date", "0 1 * * *", u.overdueAccounts)
+	u.addJob("service-reminders", "Remind customers whose next service reminder date fell due", "0 8 * * *", u.serviceReminders)
+	u.addJob("receivable-reminders", "Remind customers of unpaid receivables falling due", "0 9 * * *", u.receivableReminders)
+	u.addJob("pickup-reminders", "Remind customers of finished jobs waiting for pickup", "0 10 * * *", u.pickupReminders)
+	u.addJob("expire-promotions", "Remove promotions that have ended", "5 0 * * *", u.expirePromotions)
+	u.addJob("daily-reports", "Request yesterday's reports", "30 0 * * *", u.dailyReports)
+	u.addJob("stock-reconciliation", "Compare product stock with the stock ledger", "0 2 * * *", u.stockReconciliation)
+	u.addJob("appointment-no-shows", "Mark appointments not checked in after their grace period as no-shows", "*/15 * * * *", u.appointmentNoShows)
+	u.addJob("board-event-cleanup", "Delete live board events older than the retention", "15 3 * * *", u.boardEventCleanup)
+	return u
+}
+
+func (u *SchedulerUsecase) addJob(name, description, expr string, run func(context.Context, *models.JobRun) (string, error)) {
+	job := &schedulerJob{name: name, description: description, expr: expr, run: run}
+	if override, ok := u.conf.Jobs[name]; ok {
+		if override.Schedule != "" {
+			job.expr = override.Schedule
+		}
+		job.disabled = override.Disabled
+	}
+	schedule, err := cron.Parse(job.expr)
+	if err != nil {
+		job.scheduleError = err.Error()
+	}
+	job.schedule = schedule
+	u.jobs = append(u.jobs, job)
+}
+
+// instanceName identifies this process in locks and run history
+func instanceName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+func (u *SchedulerUsecase) job(name string) (*schedulerJob, error) {
+	for _, job := range u.jobs {
+		if job.name == name {
+			return job, nil
+		}
+	}
+	return nil, interfaces.ErrJobNotFound
+}
+
+// ListJobs lists the jobs with their next scheduled time and latest run
+func (u *SchedulerUsecase) ListJobs(ctx context.Context) ([]interfaces.ScheduledJob, error) {
+	last, err := u.repo.Scheduler.LastRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	jobs := make([]interfaces.ScheduledJob, 0, len(u.jobs))
+	for _, job := range u.jobs {
+		item := interfaces.ScheduledJob{
+			Name:          job.name,
+			Description:   job.description,
+			Schedule:      job.expr,
+			ScheduleError: job.scheduleError,
+			Enabled:       !job.disabled && job.schedule != nil,
+			LastRun:       last[job.name],
+		}
+		if item.Enabled {
+			if next := job.schedule.Next(now); !next.IsZero() {
+				item.NextRunAt = &next
+			}
+		}
+		jobs = append(jobs, item)
+	}
+	return jobs, nil
+}
+
+// TriggerJob runs a job now, also when it is disabled, and returns its run while it is still in Diproses
+func (u *SchedulerUsecase) TriggerJob(ctx context.Context, name string) (*models.JobRun, error) {
+	job, err := u.job(name)
+	if err != nil {
+		return nil, err
+	}
+
+	run, owner, err := u.start(ctx, job, nil)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, interfaces.ErrJobRunning
+	}
+
+	// The run outlives the request
+	started := *run
+	go u.execute(context.Background(), job, run, owner)
+	return &started, nil
+}
+
+// ListRuns lists the run history matching the list query
+func (u *SchedulerUsecase) ListRuns(ctx context.Context, q *query.ListQuery) ([]*models.JobRun, int64, error) {
+	return u.repo.Scheduler.ListRuns(ctx, q)
+}
+
+// GetRun retrieves a run by ID
+func (u *SchedulerUsecase) GetRun(ctx context.Context, id uint) (*models.JobRun, error) {
+	run, err := u.repo.Scheduler.GetRun(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrJobRunNotFound
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+// DueJobs returns the enabled jobs whose schedule fires in the minute of now
+func (u *SchedulerUsecase) DueJobs(now time.Time) []string {
+	var names []string
+	for _, job := range u.jobs {
+		if !job.disabled && job.schedule != nil && job.schedule.Matches(now) {
+			names = append(names, job.name)
+		}
+	}
+	return names
+}
+
+// RunScheduled runs a job for its schedule slot, the slot is recorded with the lock so every slot runs once
+func (u *SchedulerUsecase) RunScheduled(ctx context.Context, name string, slot time.Time) (*models.JobRun, error) {
+	job, err := u.job(name)
+	if err != nil {
+		return nil, err
+	}
+
+	slot = slot.Truncate(time.Minute)
+	run, owner, err := u.start(ctx, job, &slot)
+	if err != nil || run == nil {
+		return nil, err
+	}
+	u.execute(ctx, job, run, owner)
+	return run, nil
+}
+
+// FailStaleRuns fails runs that are still in Diproses after the lock time, their instance stopped while running them
+func (u *SchedulerUsecase) FailStaleRuns(ctx context.Context) (int64, error) {
+	return u.repo.Scheduler.FailStale(ctx, time.Now().Add(-u.lockFor), "run abandoned, its instance stopped before it finished")
+}
+
+// start takes the job's lock and records the run; it returns no run when the lock is held or the slot already ran
+func (u *SchedulerUsecase) start(ctx context.Context, job *schedulerJob, slot *time.Time) (*models.JobRun, string, error) {
+	now := time.Now()
+	owner := fmt.Sprintf("%s/%d", u.instance, now.UnixNano())
+	acquired, err := u.repo.Scheduler.AcquireLock(ctx, job.name, owner, slot, now.Add(u.lockFor))
+	if err != nil || !acquired {
+		return nil, "", err
+	}
+
+	trigger := models.JobTriggerManual
+	if slot != nil {
+		trigger = models.JobTriggerSchedule
+	}
+	run := &models.JobRun{
+		JobName:     job.name,
+		Trigger:     trigger,
+		Status:      models.JobRunStatusDiproses,
+		Instance:    u.instance,
+		ScheduledAt: slot,
+		StartedAt:   now,
+	}
+	if actor, ok := utils.ActorFromContext(ctx); ok && slot == nil {
+		run.TriggeredBy = &actor.UserID
+	}
+	if err := u.repo.Scheduler.CreateRun(ctx, run); err != nil {
+		_ = u.repo.Scheduler.ReleaseLock(ctx, job.name, owner)
+		return nil, "", err
+	}
+	return run, owner, nil
+}
+
+// execute runs the job within the lock time and records the outcome; a panicking job fails its run
+func (u *SchedulerUsecase) execute(ctx context.Context, job *schedulerJob, run *models.JobRun, owner string) {
+	runCtx, cancel := context.WithTimeout(ctx, u.lockFor)
+	defer cancel()
+
+	var result string
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		lastSuccess, err := u.repo.Scheduler.LastSuccess(runCtx, job.name)
+		if err != nil {
+			return err
+		}
+		result, err = job.run(runCtx, lastSuccess)
+		return err
+	}()
+
+	// The outcome is stored even when the run timed out or the server is stopping
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+	run.Status = models.JobRunStatusSelesai
+	if result != "" {
+		run.Result = &result
+	}
+	if err != nil {
+		message := err.Error()
+		run.Status = models.JobRunStatusGagal
+		run.ErrorMessage = &message
+	}
+	_ = u.repo.Scheduler.UpdateRun(context.Background(), run)
+	_ = u.repo.Scheduler.ReleaseLock(context.Background(), job.name, owner)
+}
+
+// overdueAccounts flags unpaid payables and receivables whose due date passed
+func (u *SchedulerUsecase) overdueAccounts(ctx context.Context, _ *models.JobRun) (string, error) {
+	now := time.Now()
+
+	payables, err := u.repo.AccountsPayable.GetOverdue(ctx)
+	if err != nil {
+		return "", err
+	}
+	payableIDs := make([]uint, 0, len(payables))
+	for _, payable := range payables {
+		payableIDs = append(payableIDs, payable.PayableID)
+	}
+	flaggedPayables, err := u.repo.AccountsPayable.MarkOverdue(ctx, payableIDs, now)
+	if err != nil {
+		return "", err
+	}
+
+	receivables, err := u.repo.AccountsReceivable.GetOverdue(ctx)
+	if err != nil {
+		return "", err
+	}
+	receivableIDs := make([]uint, 0, len(receivables))
+	for _, receivable := range receivables {
+		receivableIDs = append(receivableIDs, receivable.ReceivableID)
+	}
+	flaggedReceivables, err := u.repo.AccountsReceivable.MarkOverdue(ctx, receivableIDs, now)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d overdue payables (%d newly flagged), %d overdue receivables (%d newly flagged)",
+		len(payables), flaggedPayables, len(receivables), flaggedReceivables), nil
+}
+
+// serviceReminders notifies the customers of the jobs whose reminder date fell due since the last successful run,
+// today only on the first run; a missed day is picked up by the next run
+func (u *SchedulerUsecase) serviceReminders(ctx context.Context, lastSuccess *models.JobRun) (string, error) {
+	today := truncateDay(time.Now())
+	from := today
+	if lastSuccess != nil {
+		from = truncateDay(lastSuccess.StartedAt.Local()).AddDate(0, 0, 1)
+	}
+	to := today.AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return "reminders up to today were already collected", nil
+	}
+
+	sent, err := u.notification.SendServiceReminders(ctx, from, to)
+	if err != nil {
+		return "", err
+	}
+	return notificationSummary(fmt.Sprintf("service reminders due from %s to %s", from.Format("2006-01-02"), today.Format("2006-01-02")), sent), nil
+}
+
+// receivableReminders notifies the customers of unpaid receivables falling due in the next days
+func (u *SchedulerUsecase) receivableReminders(ctx context.Context, _ *models.JobRun) (string, error) {
+	sent, err := u.notification.SendReceivableReminders(ctx, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return notificationSummary("receivables falling due", sent), nil
+}
+
+// pickupReminders notifies the customers of finished jobs that were not picked up
+func (u *SchedulerUsecase) pickupReminders(ctx context.Context, _ *models.JobRun) (string, error) {
+	sent, err := u.notification.SendPickupReminders(ctx, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return notificationSummary("jobs waiting for pickup", sent), nil
+}
+
+// notificationSummary describes a reminder run, e.g. "2 jobs waiting for pickup, 1 notifications queued: ..."
+func notificationSummary(what string, sent *interfaces.NotificationSummary) string {
+	summary := fmt.Sprintf("%d %s, %d notifications queued", sent.Due, what, sent.Queued)
+	if len(sent.Items) > 0 {
+		summary += ": " + strings.Join(sent.Items, "; ")
+	}
+	return summary
+}
+
+// appointmentNoShows marks the appointments whose customer did not arrive within the grace period
+func (u *SchedulerUsecase) appointmentNoShows(ctx context.Context, _ *models.JobRun) (string, error) {
+	marked, err := u.appointment.MarkNoShows(ctx, time.Now())
+	if err != nil {
+		return "", err
+	}
+	summary := fmt.Sprintf("%d appointments marked as no-show", marked.Marked)
+	if len(marked.Items) > 0 {
+		summary += ": " + strings.Join(marked.Items, "; ")
+	}
+	return summary, nil
+}
+
+// boardEventCleanup deletes the board events no reconnecting client resumes from any more
+func (u *SchedulerUsecase) boardEventCleanup(ctx context.Context, _ *models.JobRun) (string, error) {
+	deleted, err := u.board.PruneEvents(ctx, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d board events deleted", deleted), nil
+}
+
+// expirePromotions removes the promotions whose end date passed
+func (u *SchedulerUsecase) expirePromotions(ctx context.Context, _ *models.JobRun) (string, error) {
+	expired, err := u.repo.Promotion.ExpireEnded(ctx, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d promotions expired", expired), nil
+}
+
+// dailyReports requests yesterday's reports for all outlets; reports requested before keep their name and are skipped
+func (u *SchedulerUsecase) dailyReports(ctx context.Context, _ *models.JobRun) (string, error) {
+	if u.conf.ReportUserID == 0 {
+		return "", errors.New("Scheduler.ReportUserID is not configured")
+	}
+	types := u.conf.ReportTypes
+	if len(types) == 0 {
+		types = defaultDailyReportTypes
+	}
+
+	day := truncateDay(time.Now()).AddDate(0, 0, -1)
+	var requested, skipped []string
+	for _, reportType := range types {
+		if !models.ReportTypeEnum(reportType).IsValid() {
+			return "", fmt.Errorf("invalid report type %q in Scheduler.ReportTypes", reportType)
+		}
+		name := fmt.Sprintf("%s harian %s", reportType, day.Format("2006-01-02"))
+		_, err := u.report.RequestReport(ctx, interfaces.CreateReportRequest{
+			ReportType: models.ReportTypeEnum(reportType),
+			ReportName: &name,
+			StartDate:  day,
+			EndDate:    day,
+			UserID:     &u.conf.ReportUserID,
+		})
+		if errors.Is(err, interfaces.ErrReportNameExists) {
+			skipped = append(skipped, name)
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		requested = append(requested, name)
+	}
+
+	summary := fmt.Sprintf("%d reports requested", len(requested))
+	if len(requested) > 0 {
+		summary += ": " + strings.Join(requested, ", ")
+	}
+	if len(skipped) > 0 {
+		summary += fmt.Sprintf("; %d already requested: %s", len(skipped), strings.Join(skipped, ", "))
+	}
+	return summary, nil
+}
+
+// stockReconciliation reports products whose stock differs from their stock ledger, and corrects them with ApplyStock
+func (u *SchedulerUsecase) stockReconciliation(ctx context.Context, _ *models.JobRun) (string, error) {
+	results, err := u.product.RecalculateStock(ctx, u.conf.ApplyStock)
+	if err != nil {
+		return "", err
+	}
+	if len(results) == 0 {
+		return "all product stock matches the stock ledger", nil
+	}
+
+	lines := make([]string, 0, len(results))
+	for _, result := range results {
+		line := fmt.Sprintf("%s (#%d) stock %d ledger %d", result.ProductName, result.ProductID, result.CurrentStock, result.CalculatedStock)
+		if result.Applied {
+			line += " corrected"
+		} else if result.NoOpeningBalance {
+			line += " (no opening balance)"
+		}
+		lines = append(lines, line)
+	}
+	return fmt.Sprintf("%d products differ from the stock ledger: %s", len(results), strings.Join(lines, "; ")), nil
+}
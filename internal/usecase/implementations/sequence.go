@@ -0,0 +1,72 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/sequence"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultSequencePatterns are used for the document types the Sequence config sets no pattern for
+var defaultSequencePatterns = map[string]string{
+	interfaces.SequenceServiceCode:     "SJ-{OUTLET}-{YYYY}{MM}{DD}-{SEQ:3}",
+	interfaces.SequenceQueueNumber:     "{OUTLET}/{YYYY}{MM}{DD}/{SEQ}",
+	interfaces.SequenceInvoice:         "INV/{OUTLET}/{YYYY}/{MM}/{SEQ:5}",
+	interfaces.SequencePurchaseOrder:   "PO/{OUTLET}/{YYYY}/{MM}/{SEQ:4}",
+	interfaces.SequenceVehiclePurchase: "VP/{OUTLET}/{YYYY}/{MM}/{SEQ:4}",
+}
+
+// SequenceUsecase implements the sequence usecase interface
+type SequenceUsecase struct {
+	repo     *repository.RepositoryManager
+	patterns map[string]*sequence.Pattern
+	errs     map[string]error
+}
+
+// NewSequenceUsecase creates the sequence usecase; patterns from the Sequence config replace the defaults, an invalid
+// one makes every number of its document type fail until it is fixed
+func NewSequenceUsecase(repo *repository.RepositoryManager, conf config.SequenceAccount) interfaces.SequenceUsecase {
+	u := &SequenceUsecase{
+		repo:     repo,
+		patterns: make(map[string]*sequence.Pattern),
+		errs:     make(map[string]error),
+	}
+	patterns := make(map[string]string, len(defaultSequencePatterns))
+	for document, pattern := range defaultSequencePatterns {
+		patterns[document] = pattern
+	}
+	for document, pattern := range conf.Patterns {
+		patterns[strings.ToLower(document)] = pattern
+	}
+	for document, pattern := range patterns {
+		parsed, err := sequence.Parse(pattern)
+		if err != nil {
+			u.errs[document] = fmt.Errorf("Sequence.Patterns.%s: %w", document, err)
+			continue
+		}
+		u.patterns[document] = parsed
+	}
+	return u
+}
+
+// Next increments the counter of the document's scope at the given time in local time and formats the number
+func (u *SequenceUsecase) Next(ctx context.Context, document string, outletID uint, at time.Time) (string, int64, error) {
+	if err, ok := u.errs[document]; ok {
+		return "", 0, err
+	}
+	pattern, ok := u.patterns[document]
+	if !ok {
+		return "", 0, fmt.Errorf("no numbering pattern for document type %q", document)
+	}
+
+	at = at.In(time.Local)
+	value, err := u.repo.Sequence.Next(ctx, document, pattern.Scope(outletID, at))
+	if err != nil {
+		return "", 0, err
+	}
+	return pattern.Format(outletID, at, value), value, nil
+}
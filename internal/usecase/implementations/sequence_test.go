@@ -0,0 +1,147 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newSequenceTestUsecase opens a file database, so that concurrent callers use separate connections as they would
+// against a server
+func newSequenceTestUsecase(t *testing.T, conf config.SequenceAccount) interfaces.SequenceUsecase {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "sequence.db") + "?_busy_timeout=10000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.DocumentSequence{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("database handle: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(8)
+	t.Cleanup(func() { sqlDB.Close() })
+	return NewSequenceUsecase(repository.NewRepositoryManager(db), conf)
+}
+
+func TestSequenceNextConcurrentIsUnique(t *testing.T) {
+	u := newSequenceTestUsecase(t, config.SequenceAccount{})
+	at := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.Local)
+
+	const workers, perWorker = 16, 25
+	type number struct {
+		outletID uint
+		code     string
+		value    int64
+	}
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		numbers []number
+		errs    []error
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// Two outlets draw at the same time, each has its own counter
+			outletID := uint(1 + w%2)
+			for i := 0; i < perWorker; i++ {
+				code, value, err := u.Next(context.Background(), interfaces.SequenceInvoice, outletID, at)
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err)
+				} else {
+					numbers = append(numbers, number{outletID, code, value})
+				}
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		t.Fatalf("%d of %d calls failed, first: %v", len(errs), workers*perWorker, errs[0])
+	}
+	codes := make(map[string]bool)
+	values := map[uint]map[int64]bool{1: {}, 2: {}}
+	for _, n := range numbers {
+		if codes[n.code] {
+			t.Fatalf("number %s handed out twice", n.code)
+		}
+		codes[n.code] = true
+		if values[n.outletID][n.value] {
+			t.Fatalf("counter value %d of outlet %d handed out twice", n.value, n.outletID)
+		}
+		values[n.outletID][n.value] = true
+		if want := fmt.Sprintf("INV/%d/2024/01/%05d", n.outletID, n.value); n.code != want {
+			t.Fatalf("got number %s, want %s", n.code, want)
+		}
+	}
+	perOutlet := int64(workers * perWorker / 2)
+	for outletID, seen := range values {
+		for v := int64(1); v <= perOutlet; v++ {
+			if !seen[v] {
+				t.Fatalf("outlet %d: value %d missing, the counter skipped", outletID, v)
+			}
+		}
+	}
+}
+
+func TestSequenceNextRestartsPerScope(t *testing.T) {
+	u := newSequenceTestUsecase(t, config.SequenceAccount{Patterns: map[string]string{
+		interfaces.SequenceQueueNumber: "{OUTLET}/{YYYY}{MM}{DD}/{SEQ}",
+		interfaces.SequenceServiceCode: "SJ{YY}{MM}-{SEQ:4}",
+	}})
+	ctx := context.Background()
+	day := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.Local)
+
+	next := func(document string, outletID uint, at time.Time) (string, int64) {
+		t.Helper()
+		code, value, err := u.Next(ctx, document, outletID, at)
+		if err != nil {
+			t.Fatalf("next %s: %v", document, err)
+		}
+		return code, value
+	}
+
+	next(interfaces.SequenceQueueNumber, 1, day)
+	if _, value := next(interfaces.SequenceQueueNumber, 1, day.Add(time.Hour)); value != 2 {
+		t.Fatalf("second queue number of the day: got %d, want 2", value)
+	}
+	if _, value := next(interfaces.SequenceQueueNumber, 1, day.AddDate(0, 0, 1)); value != 1 {
+		t.Fatalf("first queue number of the next day: got %d, want 1", value)
+	}
+	if _, value := next(interfaces.SequenceQueueNumber, 2, day); value != 1 {
+		t.Fatalf("first queue number of another outlet: got %d, want 1", value)
+	}
+
+	// Without {OUTLET} all outlets share the counter
+	next(interfaces.SequenceServiceCode, 1, day)
+	if code, _ := next(interfaces.SequenceServiceCode, 2, day); code != "SJ2401-0002" {
+		t.Fatalf("service code: got %s, want SJ2401-0002", code)
+	}
+}
+
+func TestSequenceInvalidPattern(t *testing.T) {
+	u := newSequenceTestUsecase(t, config.SequenceAccount{Patterns: map[string]string{
+		interfaces.SequenceInvoice: "INV/{YYYY}/{NUMBER}",
+	}})
+	if _, _, err := u.Next(context.Background(), interfaces.SequenceInvoice, 1, time.Now()); err == nil {
+		t.Fatal("expected an error for a pattern without {SEQ}")
+	}
+}
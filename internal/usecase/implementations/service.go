@@ -4,6 +4,8 @@ import (
 	"boilerplate/internal/models"
 	"boilerplate/internal/repository"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/query"
+	"boilerplate/pkg/utils"
 	"context"
 	"errors"
 	"fmt"
@@ -28,7 +30,7 @@ func (u *ServiceUsecase) CreateService(ctx context.Context, req interfaces.Creat
 	_, err := u.repo.ServiceCategory.GetByID(ctx, req.ServiceCategoryID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service category not found")
+			return nil, interfaces.ErrServiceCategoryNotFound
 		}
 		return nil, err
 	}
@@ -36,7 +38,7 @@ func (u *ServiceUsecase) CreateService(ctx context.Context, req interfaces.Creat
 	// Check if service code already exists
 	existingService, err := u.repo.Service.GetByServiceCode(ctx, req.ServiceCode)
 	if err == nil && existingService != nil {
-		return nil, errors.New("service with this service code already exists")
+		return nil, interfaces.ErrServiceCodeExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -53,6 +55,7 @@ func (u *ServiceUsecase) CreateService(ctx context.Context, req interfaces.Creat
 		Name:              req.Name,
 		ServiceCategoryID: req.ServiceCategoryID,
 		Fee:               req.Fee,
+		StandardMinutes:   req.StandardMinutes,
 		Status:            status,
 		CreatedBy:         req.CreatedBy,
 		CreatedAt:         time.Now(),
@@ -71,7 +74,7 @@ func (u *ServiceUsecase) GetService(ctx context.Context, id uint) (*models.Servi
 	service, err := u.repo.Service.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service not found")
+			return nil, interfaces.ErrServiceNotFound
 		}
 		return nil, err
 	}
@@ -83,7 +86,7 @@ func (u *ServiceUsecase) GetServiceByServiceCode(ctx context.Context, serviceCod
 	service, err := u.repo.Service.GetByServiceCode(ctx, serviceCode)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service not found")
+			return nil, interfaces.ErrServiceNotFound
 		}
 		return nil, err
 	}
@@ -95,7 +98,7 @@ func (u *ServiceUsecase) UpdateService(ctx context.Context, id uint, req interfa
 	service, err := u.repo.Service.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service not found")
+			return nil, interfaces.ErrServiceNotFound
 		}
 		return nil, err
 	}
@@ -105,7 +108,7 @@ func (u *ServiceUsecase) UpdateService(ctx context.Context, id uint, req interfa
 		_, err := u.repo.ServiceCategory.GetByID(ctx, *req.ServiceCategoryID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("service category not found")
+				return nil, interfaces.ErrServiceCategoryNotFound
 			}
 			return nil, err
 		}
@@ -115,7 +118,7 @@ func (u *ServiceUsecase) UpdateService(ctx context.Context, id uint, req interfa
 	if req.ServiceCode != nil && *req.ServiceCode != service.ServiceCode {
 		existingService, err := u.repo.Service.GetByServiceCode(ctx, *req.ServiceCode)
 		if err == nil && existingService != nil && existingService.ServiceID != id {
-			return nil, errors.New("service with this service code already exists")
+			return nil, interfaces.ErrServiceCodeExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -135,6 +138,9 @@ func (u *ServiceUsecase) UpdateService(ctx context.Context, id uint, req interfa
 	if req.Fee != nil {
 		service.Fee = *req.Fee
 	}
+	if req.StandardMinutes != nil {
+		service.StandardMinutes = *req.StandardMinutes
+	}
 	if req.Status != nil {
 		service.Status = *req.Status
 	}
@@ -152,7 +158,7 @@ func (u *ServiceUsecase) DeleteService(ctx context.Context, id uint) error {
 	_, err := u.repo.Service.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("service not found")
+			return interfaces.ErrServiceNotFound
 		}
 		return err
 	}
@@ -163,15 +169,9 @@ func (u *ServiceUsecase) DeleteService(ctx context.Context, id uint) error {
 }
 
 // ListServices retrieves services with pagination
-func (u *ServiceUsecase) ListServices(ctx context.Context, limit, offset int) ([]*models.Service, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *ServiceUsecase) ListServices(ctx context.Context, q *query.ListQuery) ([]*models.Service, int64, error) {
 
-	return u.repo.Service.List(ctx, limit, offset)
+	return u.repo.Service.List(ctx, q)
 }
 
 // GetServicesByCategory retrieves services by category
@@ -211,7 +211,7 @@ func (u *ServiceCategoryUsecase) CreateServiceCategory(ctx context.Context, req
 	// Check if category name already exists
 	existingCategory, err := u.repo.ServiceCategory.GetByName(ctx, req.Name)
 	if err == nil && existingCategory != nil {
-		return nil, errors.New("service category with this name already exists")
+		return nil, interfaces.ErrServiceCategoryNameExists
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
@@ -243,7 +243,7 @@ func (u *ServiceCategoryUsecase) GetServiceCategory(ctx context.Context, id uint
 	category, err := u.repo.ServiceCategory.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service category not found")
+			return nil, interfaces.ErrServiceCategoryNotFound
 		}
 		return nil, err
 	}
@@ -255,7 +255,7 @@ func (u *ServiceCategoryUsecase) GetServiceCategoryByName(ctx context.Context, n
 	category, err := u.repo.ServiceCategory.GetByName(ctx, name)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service category not found")
+			return nil, interfaces.ErrServiceCategoryNotFound
 		}
 		return nil, err
 	}
@@ -267,7 +267,7 @@ func (u *ServiceCategoryUsecase) UpdateServiceCategory(ctx context.Context, id u
 	category, err := u.repo.ServiceCategory.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service category not found")
+			return nil, interfaces.ErrServiceCategoryNotFound
 		}
 		return nil, err
 	}
@@ -276,7 +276,7 @@ func (u *ServiceCategoryUsecase) UpdateServiceCategory(ctx context.Context, id u
 	if req.Name != nil && *req.Name != category.Name {
 		existingCategory, err := u.repo.ServiceCategory.GetByName(ctx, *req.Name)
 		if err == nil && existingCategory != nil && existingCategory.ServiceCategoryID != id {
-			return nil, errors.New("service category with this name already exists")
+			return nil, interfaces.ErrServiceCategoryNameExists
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -304,7 +304,7 @@ func (u *ServiceCategoryUsecase) DeleteServiceCategory(ctx context.Context, id u
 	_, err := u.repo.ServiceCategory.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("service category not found")
+			return interfaces.ErrServiceCategoryNotFound
 		}
 		return err
 	}
@@ -315,22 +315,16 @@ func (u *ServiceCategoryUsecase) DeleteServiceCategory(ctx context.Context, id u
 		return err
 	}
 	if len(services) > 0 {
-		return errors.New("cannot delete service category with existing services")
+		return interfaces.ErrServiceCategoryHasServices
 	}
 
 	return u.repo.ServiceCategory.Delete(ctx, id)
 }
 
 // ListServiceCategories retrieves service categories with pagination
-func (u *ServiceCategoryUsecase) ListServiceCategories(ctx context.Context, limit, offset int) ([]*models.ServiceCategory, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *ServiceCategoryUsecase) ListServiceCategories(ctx context.Context, q *query.ListQuery) ([]*models.ServiceCategory, int64, error) {
 
-	return u.repo.ServiceCategory.List(ctx, limit, offset)
+	return u.repo.ServiceCategory.List(ctx, q)
 }
 
 // GetServiceCategoriesByStatus retrieves service categories by status
@@ -340,12 +334,18 @@ func (u *ServiceCategoryUsecase) GetServiceCategoriesByStatus(ctx context.Contex
 
 // ServiceJobUsecase implements the service job usecase interface
 type ServiceJobUsecase struct {
-	repo *repository.RepositoryManager
+	repo         *repository.RepositoryManager
+	notification interfaces.NotificationUsecase
+	estimate     interfaces.EstimateUsecase
+	sequence     interfaces.SequenceUsecase
+	events       interfaces.ServiceJobEventPublisher
 }
 
-// NewServiceJobUsecase creates a new service job usecase
-func NewServiceJobUsecase(repo *repository.RepositoryManager) interfaces.ServiceJobUsecase {
-	return &ServiceJobUsecase{repo: repo}
+// NewServiceJobUsecase creates a new service job usecase, status changes are passed on to the notifications
+// and work only starts within an approved estimate; service codes and queue numbers come from sequence and
+// changes to jobs are published as events to the live boards
+func NewServiceJobUsecase(repo *repository.RepositoryManager, notification interfaces.NotificationUsecase, estimate interfaces.EstimateUsecase, sequence interfaces.SequenceUsecase, events interfaces.ServiceJobEventPublisher) interfaces.ServiceJobUsecase {
+	return &ServiceJobUsecase{repo: repo, notification: notification, estimate: estimate, sequence: sequence, events: events}
 }
 
 // CreateServiceJob creates a new service job
@@ -354,7 +354,7 @@ func (u *ServiceJobUsecase) CreateServiceJob(ctx context.Context, req interfaces
 	_, err := u.repo.Customer.GetByID(ctx, req.CustomerID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer not found")
+			return nil, interfaces.ErrCustomerNotFound
 		}
 		return nil, err
 	}
@@ -363,18 +363,14 @@ func (u *ServiceJobUsecase) CreateServiceJob(ctx context.Context, req interfaces
 	_, err = u.repo.CustomerVehicle.GetByID(ctx, req.VehicleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
+			return nil, interfaces.ErrVehicleNotFound
 		}
 		return nil, err
 	}
 
-	// Validate technician exists if provided
+	// Validate technician is an active technician if provided
 	if req.TechnicianID != nil {
-		_, err := u.repo.User.GetByID(ctx, *req.TechnicianID)
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("technician not found")
-			}
+		if err := checkActiveTechnician(ctx, u.repo, *req.TechnicianID); err != nil {
 			return nil, err
 		}
 	}
@@ -383,7 +379,7 @@ func (u *ServiceJobUsecase) CreateServiceJob(ctx context.Context, req interfaces
 	_, err = u.repo.User.GetByID(ctx, req.ReceivedByUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("received by user not found")
+			return nil, interfaces.ErrReceivedByUserNotFound
 		}
 		return nil, err
 	}
@@ -392,16 +388,26 @@ func (u *ServiceJobUsecase) CreateServiceJob(ctx context.Context, req interfaces
 	_, err = u.repo.Outlet.GetByID(ctx, req.OutletID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("outlet not found")
+			return nil, interfaces.ErrOutletNotFound
 		}
 		return nil, err
 	}
 
 	// Generate service code
-	serviceCode := fmt.Sprintf("SJ-%d-%d", req.OutletID, time.Now().Unix())
+	now := time.Now()
+	serviceCode, _, err := u.sequence.Next(ctx, interfaces.SequenceServiceCode, req.OutletID, now)
+	if err != nil {
+		return nil, err
+	}
 
-	// Get queue number
-	queueNumber, err := u.repo.ServiceJob.GetQueueNumber(ctx, req.OutletID)
+	// Generate the token of the public tracking page, unlike the service code it cannot be guessed
+	trackingToken, err := utils.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get queue number, counted per outlet and day by the queue_number pattern
+	_, queueNumber, err := u.sequence.Next(ctx, interfaces.SequenceQueueNumber, req.OutletID, now)
 	if err != nil {
 		return nil, err
 	}
@@ -411,10 +417,17 @@ func (u *ServiceJobUsecase) CreateServiceJob(ctx context.Context, req interfaces
 	if status == "" {
 		status = models.ServiceStatusAntri
 	}
+	// A new job has no approved estimate yet
+	if status == models.ServiceStatusDikerjakan {
+		if err := u.checkWorkStart(ctx, 0); err != nil {
+			return nil, err
+		}
+	}
 
 	serviceJob := &models.ServiceJob{
 		ServiceCode:             serviceCode,
-		QueueNumber:             queueNumber,
+		QueueNumber:             int(queueNumber),
+		Priority:                req.Priority,
 		CustomerID:              req.CustomerID,
 		VehicleID:               req.VehicleID,
 		TechnicianID:            req.TechnicianID,
@@ -426,6 +439,8 @@ func (u *ServiceJobUsecase) CreateServiceJob(ctx context.Context, req interfaces
 		ServiceInDate:           req.ServiceInDate,
 		WarrantyExpiresAt:       req.WarrantyExpiresAt,
 		NextServiceReminderDate: req.NextServiceReminderDate,
+		EstimatedCompletionAt:   req.EstimatedCompletionAt,
+		TrackingToken:           trackingToken,
 		DownPayment:             req.DownPayment,
 		CreatedBy:               req.CreatedBy,
 		CreatedAt:               time.Now(),
@@ -442,22 +457,24 @@ func (u *ServiceJobUsecase) CreateServiceJob(ctx context.Context, req interfaces
 		UserID:       req.ReceivedByUserID,
 		Notes:        &req.ProblemDescription,
 	}
-	_, err = u.createServiceJobHistory(ctx, historyReq)
+	_, err = u.createServiceJobHistory(ctx, historyReq, &serviceJob.Status)
 	if err != nil {
 		// Don't fail the entire operation for history creation failure
 		// but log it
 		fmt.Printf("Failed to create service job history: %v\n", err)
 	}
+	u.publish(ctx, models.ServiceJobEventCreated, serviceJob.ServiceJobID, serviceJob.OutletID)
 
 	return serviceJob, nil
 }
 
-// CreateServiceJobHistory creates a service job history entry
-func (u *ServiceJobUsecase) createServiceJobHistory(ctx context.Context, req interfaces.CreateServiceJobHistoryRequest) (*models.ServiceJobHistory, error) {
+// CreateServiceJobHistory creates a service job history entry, status is the status the job moved to
+func (u *ServiceJobUsecase) createServiceJobHistory(ctx context.Context, req interfaces.CreateServiceJobHistoryRequest, status *models.ServiceStatusEnum) (*models.ServiceJobHistory, error) {
 	history := &models.ServiceJobHistory{
 		ServiceJobID: req.ServiceJobID,
 		UserID:       req.UserID,
 		Notes:        req.Notes,
+		Status:       status,
 		ChangedAt:    time.Now(),
 	}
 
@@ -473,7 +490,7 @@ func (u *ServiceJobUsecase) GetServiceJob(ctx context.Context, id uint) (*models
 	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service job not found")
+			return nil, interfaces.ErrServiceJobNotFound
 		}
 		return nil, err
 	}
@@ -485,7 +502,7 @@ func (u *ServiceJobUsecase) GetServiceJobByServiceCode(ctx context.Context, serv
 	serviceJob, err := u.repo.ServiceJob.GetByServiceCode(ctx, serviceCode)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service job not found")
+			return nil, interfaces.ErrServiceJobNotFound
 		}
 		return nil, err
 	}
@@ -497,7 +514,7 @@ func (u *ServiceJobUsecase) UpdateServiceJob(ctx context.Context, id uint, req i
 	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service job not found")
+			return nil, interfaces.ErrServiceJobNotFound
 		}
 		return nil, err
 	}
@@ -507,7 +524,7 @@ func (u *ServiceJobUsecase) UpdateServiceJob(ctx context.Context, id uint, req i
 		_, err := u.repo.Customer.GetByID(ctx, *req.CustomerID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("customer not found")
+				return nil, interfaces.ErrCustomerNotFound
 			}
 			return nil, err
 		}
@@ -517,18 +534,14 @@ func (u *ServiceJobUsecase) UpdateServiceJob(ctx context.Context, id uint, req i
 		_, err := u.repo.CustomerVehicle.GetByID(ctx, *req.VehicleID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("vehicle not found")
+				return nil, interfaces.ErrVehicleNotFound
 			}
 			return nil, err
 		}
 	}
 
 	if req.TechnicianID != nil && (serviceJob.TechnicianID == nil || *req.TechnicianID != *serviceJob.TechnicianID) {
-		_, err := u.repo.User.GetByID(ctx, *req.TechnicianID)
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("technician not found")
-			}
+		if err := checkActiveTechnician(ctx, u.repo, *req.TechnicianID); err != nil {
 			return nil, err
 		}
 	}
@@ -537,7 +550,7 @@ func (u *ServiceJobUsecase) UpdateServiceJob(ctx context.Context, id uint, req i
 		_, err := u.repo.User.GetByID(ctx, *req.ReceivedByUserID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("received by user not found")
+				return nil, interfaces.ErrReceivedByUserNotFound
 			}
 			return nil, err
 		}
@@ -547,13 +560,15 @@ func (u *ServiceJobUsecase) UpdateServiceJob(ctx context.Context, id uint, req i
 		_, err := u.repo.Outlet.GetByID(ctx, *req.OutletID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("outlet not found")
+				return nil, interfaces.ErrOutletNotFound
 			}
 			return nil, err
 		}
 	}
 
 	// Update fields if provided
+	previousOutletID := serviceJob.OutletID
+	previousTechnicianID := serviceJob.TechnicianID
 	if req.CustomerID != nil {
 		serviceJob.CustomerID = *req.CustomerID
 	}
@@ -575,6 +590,7 @@ func (u *ServiceJobUsecase) UpdateServiceJob(ctx context.Context, id uint, req i
 	if req.TechnicianNotes != nil {
 		serviceJob.TechnicianNotes = req.TechnicianNotes
 	}
+	previousStatus := serviceJob.Status
 	if req.Status != nil {
 		serviceJob.Status = *req.Status
 	}
@@ -593,6 +609,9 @@ func (u *ServiceJobUsecase) UpdateServiceJob(ctx context.Context, id uint, req i
 	if req.NextServiceReminderDate != nil {
 		serviceJob.NextServiceReminderDate = req.NextServiceReminderDate
 	}
+	if req.EstimatedCompletionAt != nil {
+		serviceJob.EstimatedCompletionAt = req.EstimatedCompletionAt
+	}
 	if req.DownPayment != nil {
 		serviceJob.DownPayment = *req.DownPayment
 	}
@@ -607,43 +626,76 @@ func (u *ServiceJobUsecase) UpdateServiceJob(ctx context.Context, id uint, req i
 	}
 	serviceJob.UpdatedAt = time.Now()
 
+	if serviceJob.Status == models.ServiceStatusDikerjakan && previousStatus != models.ServiceStatusDikerjakan {
+		if err := u.checkWorkStart(ctx, serviceJob.ServiceJobID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := u.repo.ServiceJob.Update(ctx, serviceJob); err != nil {
 		return nil, err
 	}
 
+	// A status changed here is recorded like one changed through UpdateServiceJobStatus
+	if serviceJob.Status != previousStatus {
+		userID := serviceJob.ReceivedByUserID
+		if actor, ok := utils.ActorFromContext(ctx); ok {
+			userID = actor.UserID
+		}
+		notes := fmt.Sprintf("status changed from %s to %s", previousStatus, serviceJob.Status)
+		historyReq := interfaces.CreateServiceJobHistoryRequest{
+			ServiceJobID: serviceJob.ServiceJobID,
+			UserID:       userID,
+			Notes:        &notes,
+		}
+		if _, err := u.createServiceJobHistory(ctx, historyReq, &serviceJob.Status); err != nil {
+			fmt.Printf("Failed to create service job history: %v\n", err)
+		}
+		u.notifyStatusChange(ctx, serviceJob.ServiceJobID, serviceJob.Status)
+	}
+
+	// A job moved to another outlet leaves the board of the old one and appears on the new one
+	switch {
+	case serviceJob.OutletID != previousOutletID:
+		u.publish(ctx, models.ServiceJobEventRemoved, serviceJob.ServiceJobID, previousOutletID)
+		u.publish(ctx, models.ServiceJobEventCreated, serviceJob.ServiceJobID, serviceJob.OutletID)
+	case serviceJob.Status != previousStatus:
+		u.publish(ctx, models.ServiceJobEventStatusChanged, serviceJob.ServiceJobID, serviceJob.OutletID)
+		if !sameTechnician(previousTechnicianID, serviceJob.TechnicianID) {
+			u.publish(ctx, models.ServiceJobEventAssigned, serviceJob.ServiceJobID, serviceJob.OutletID)
+		}
+	case !sameTechnician(previousTechnicianID, serviceJob.TechnicianID):
+		u.publish(ctx, models.ServiceJobEventAssigned, serviceJob.ServiceJobID, serviceJob.OutletID)
+	default:
+		u.publish(ctx, models.ServiceJobEventUpdated, serviceJob.ServiceJobID, serviceJob.OutletID)
+	}
+
 	return serviceJob, nil
 }
 
 // DeleteServiceJob deletes a service job
 func (u *ServiceJobUsecase) DeleteServiceJob(ctx context.Context, id uint) error {
-	_, err := u.repo.ServiceJob.GetByID(ctx, id)
+	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("service job not found")
+			return interfaces.ErrServiceJobNotFound
 		}
 		return err
 	}
 
 	// TODO: Add business logic checks (e.g., can't delete if status is completed)
 
-	return u.repo.ServiceJob.Delete(ctx, id)
+	if err := u.repo.ServiceJob.Delete(ctx, id); err != nil {
+		return err
+	}
+	u.publish(ctx, models.ServiceJobEventRemoved, id, serviceJob.OutletID)
+	return nil
 }
 
 // ListServiceJobs retrieves service jobs with pagination
-func (u *ServiceJobUsecase) ListServiceJobs(ctx context.Context, limit, offset int) ([]*models.ServiceJob, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *ServiceJobUsecase) ListServiceJobs(ctx context.Context, q *query.ListQuery) ([]*models.ServiceJob, int64, error) {
 
-	return u.repo.ServiceJob.List(ctx, limit, offset)
-}
-
-// GetServiceJobsByCustomer retrieves service jobs by customer
-func (u *ServiceJobUsecase) GetServiceJobsByCustomer(ctx context.Context, customerID uint) ([]*models.ServiceJob, error) {
-	return u.repo.ServiceJob.GetByCustomerID(ctx, customerID)
+	return u.repo.ServiceJob.List(ctx, q)
 }
 
 // GetServiceJobsByVehicle retrieves service jobs by vehicle
@@ -661,18 +713,13 @@ func (u *ServiceJobUsecase) GetServiceJobsByOutlet(ctx context.Context, outletID
 	return u.repo.ServiceJob.GetByOutletID(ctx, outletID)
 }
 
-// GetServiceJobsByStatus retrieves service jobs by status
-func (u *ServiceJobUsecase) GetServiceJobsByStatus(ctx context.Context, status models.ServiceStatusEnum) ([]*models.ServiceJob, error) {
-	return u.repo.ServiceJob.GetByStatus(ctx, status)
-}
-
 // UpdateServiceJobStatus updates service job status and creates history
 func (u *ServiceJobUsecase) UpdateServiceJobStatus(ctx context.Context, id uint, status models.ServiceStatusEnum, userID uint, notes *string) error {
 	// Validate service job exists
-	_, err := u.repo.ServiceJob.GetByID(ctx, id)
+	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("service job not found")
+			return interfaces.ErrServiceJobNotFound
 		}
 		return err
 	}
@@ -681,11 +728,17 @@ func (u *ServiceJobUsecase) UpdateServiceJobStatus(ctx context.Context, id uint,
 	_, err = u.repo.User.GetByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+			return interfaces.ErrUserNotFound
 		}
 		return err
 	}
 
+	if status == models.ServiceStatusDikerjakan && serviceJob.Status != models.ServiceStatusDikerjakan {
+		if err := u.checkWorkStart(ctx, id); err != nil {
+			return err
+		}
+	}
+
 	// Update status
 	if err := u.repo.ServiceJob.UpdateStatus(ctx, id, status); err != nil {
 		return err
@@ -697,22 +750,71 @@ func (u *ServiceJobUsecase) UpdateServiceJobStatus(ctx context.Context, id uint,
 		UserID:       userID,
 		Notes:        notes,
 	}
-	_, err = u.createServiceJobHistory(ctx, historyReq)
+	_, err = u.createServiceJobHistory(ctx, historyReq, &status)
 	if err != nil {
 		// Don't fail the entire operation for history creation failure
 		fmt.Printf("Failed to create service job history: %v\n", err)
 	}
+	u.notifyStatusChange(ctx, id, status)
+	u.publish(ctx, models.ServiceJobEventStatusChanged, id, serviceJob.OutletID)
 
 	return nil
 }
 
+// notifyStatusChange queues the customer notification of a status change; like the history it does not fail the change
+func (u *ServiceJobUsecase) notifyStatusChange(ctx context.Context, id uint, status models.ServiceStatusEnum) {
+	queueStatusNotification(ctx, u.notification, id, status)
+}
+
+// publish sends a change of a job to the live boards; like the notifications it does not fail the change
+func (u *ServiceJobUsecase) publish(ctx context.Context, eventType string, id uint, outletID uint) {
+	publishServiceJobEvent(ctx, u.events, eventType, id, outletID)
+}
+
+// queueStatusNotification queues the customer notification of a status change through notification, if any
+func queueStatusNotification(ctx context.Context, notification interfaces.NotificationUsecase, id uint, status models.ServiceStatusEnum) {
+	if notification == nil {
+		return
+	}
+	if err := notification.NotifyStatusChange(ctx, id, status); err != nil {
+		fmt.Printf("Failed to queue service job notification: %v\n", err)
+	}
+}
+
+// publishServiceJobEvent sends a change of a job to events, if any
+func publishServiceJobEvent(ctx context.Context, events interfaces.ServiceJobEventPublisher, eventType string, id uint, outletID uint) {
+	if events == nil {
+		return
+	}
+	event := interfaces.ServiceJobEvent{Type: eventType, ServiceJobID: id, OutletID: outletID}
+	if err := events.PublishServiceJobEvent(ctx, event); err != nil {
+		fmt.Printf("Failed to publish service job event: %v\n", err)
+	}
+}
+
+// sameTechnician tells whether two technician assignments are the same, both unassigned included
+func sameTechnician(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// checkWorkStart fails when work on the job cannot start before the customer approved an estimate
+func (u *ServiceJobUsecase) checkWorkStart(ctx context.Context, id uint) error {
+	if u.estimate == nil {
+		return nil
+	}
+	return u.estimate.CheckWorkStart(ctx, id)
+}
+
 // CalculateServiceJobTotals calculates and updates service job totals
 func (u *ServiceJobUsecase) CalculateServiceJobTotals(ctx context.Context, serviceJobID uint) error {
 	// Get service job
 	_, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("service job not found")
+			return interfaces.ErrServiceJobNotFound
 		}
 		return err
 	}
@@ -755,21 +857,26 @@ func (u *ServiceJobUsecase) CalculateServiceJobTotals(ctx context.Context, servi
 
 // ServiceDetailUsecase implements the service detail usecase interface
 type ServiceDetailUsecase struct {
-	repo *repository.RepositoryManager
+	repo         *repository.RepositoryManager
+	estimate     interfaces.EstimateUsecase
+	notification interfaces.NotificationUsecase
+	events       interfaces.ServiceJobEventPublisher
 }
 
-// NewServiceDetailUsecase creates a new service detail usecase
-func NewServiceDetailUsecase(repo *repository.RepositoryManager) interfaces.ServiceDetailUsecase {
-	return &ServiceDetailUsecase{repo: repo}
+// NewServiceDetailUsecase creates a new service detail usecase, the lines of a job stay within its approved estimate;
+// a job left waiting for parts is told to the customer by notification and to the boards by events
+func NewServiceDetailUsecase(repo *repository.RepositoryManager, estimate interfaces.EstimateUsecase, notification interfaces.NotificationUsecase, events interfaces.ServiceJobEventPublisher) interfaces.ServiceDetailUsecase {
+	return &ServiceDetailUsecase{repo: repo, estimate: estimate, notification: notification, events: events}
 }
 
-// CreateServiceDetail creates a new service detail
-func (u *ServiceDetailUsecase) CreateServiceDetail(ctx context.Context, req interfaces.CreateServiceDetailRequest) (*models.ServiceDetail, error) {
+// CreateServiceDetail creates a new service detail. A part short in stock adds no line: the shortfall is requested
+// as a special order and the job waits for it as Menunggu Sparepart.
+func (u *ServiceDetailUsecase) CreateServiceDetail(ctx context.Context, req interfaces.CreateServiceDetailRequest) (*interfaces.CreatedServiceDetail, error) {
 	// Validate service job exists
-	_, err := u.repo.ServiceJob.GetByID(ctx, req.ServiceJobID)
+	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, req.ServiceJobID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service job not found")
+			return nil, interfaces.ErrServiceJobNotFound
 		}
 		return nil, err
 	}
@@ -779,16 +886,12 @@ func (u *ServiceDetailUsecase) CreateServiceDetail(ctx context.Context, req inte
 		_, err := u.repo.Service.GetByID(ctx, req.ItemID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("service not found")
+				return nil, interfaces.ErrServiceNotFound
 			}
 			return nil, err
 		}
 	} else if req.ItemType == "product" {
-		_, err := u.repo.Product.GetByID(ctx, req.ItemID)
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("product not found")
-			}
+		if _, err := productUnitCost(ctx, u.repo, req.ItemID); err != nil {
 			return nil, err
 		}
 	}
@@ -798,12 +901,17 @@ func (u *ServiceDetailUsecase) CreateServiceDetail(ctx context.Context, req inte
 		_, err := u.repo.ProductSerialNumber.GetBySerialNumber(ctx, *req.SerialNumberUsed)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("serial number not found")
+				return nil, interfaces.ErrSerialNumberNotFound
 			}
 			return nil, err
 		}
 	}
 
+	// Work found on the way needs a new estimate revision the customer approved
+	if err := u.checkWorkCovered(ctx, req.ServiceJobID, 0, req.PricePerItem*float64(req.Quantity)); err != nil {
+		return nil, err
+	}
+
 	serviceDetail := &models.ServiceDetail{
 		ServiceJobID:     req.ServiceJobID,
 		ItemID:           req.ItemID,
@@ -815,11 +923,104 @@ func (u *ServiceDetailUsecase) CreateServiceDetail(ctx context.Context, req inte
 		CostPerItem:      req.CostPerItem,
 	}
 
-	if err := u.repo.ServiceDetail.Create(ctx, serviceDetail); err != nil {
+	// Parts used take their stock out of the job's outlet at the cost the costing engine gives them,
+	// instead of the cost sent by the client; the stock only moves together with the line
+	var specialOrder *models.SpecialOrder
+	err = u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if req.ItemType == "product" {
+			unitCost, err := u.issuePart(ctx, tx, serviceJob, req.ItemID, req.Quantity)
+			if errors.Is(err, interfaces.ErrInsufficientStock) || errors.Is(err, interfaces.ErrStockReserved) {
+				specialOrder, err = u.orderShortfall(ctx, tx, serviceJob, req, err)
+				return err
+			}
+			if err != nil {
+				return err
+			}
+			serviceDetail.CostPerItem = unitCost
+		}
+		if err := tx.ServiceDetail.Create(ctx, serviceDetail); err != nil {
+			return err
+		}
+		// The part line uses the parts special-ordered for the job, which stop holding stock for it
+		if req.ItemType == "product" {
+			return tx.SpecialOrder.UseReserved(ctx, req.ServiceJobID, req.ItemID, serviceDetail.DetailID, req.Quantity, time.Now())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if specialOrder != nil {
+		if serviceJob.Status != models.ServiceStatusMenungguSparepart {
+			queueStatusNotification(ctx, u.notification, serviceJob.ServiceJobID, models.ServiceStatusMenungguSparepart)
+			publishServiceJobEvent(ctx, u.events, models.ServiceJobEventStatusChanged, serviceJob.ServiceJobID, serviceJob.OutletID)
+		}
+		return &interfaces.CreatedServiceDetail{SpecialOrder: specialOrder}, nil
+	}
+	return &interfaces.CreatedServiceDetail{ServiceDetail: serviceDetail}, nil
+}
+
+// orderShortfall requests the part the stock cannot cover as a special order in tx and moves the job to Menunggu
+// Sparepart; issueErr is returned when the job is closed or the stock covers the part after all
+func (u *ServiceDetailUsecase) orderShortfall(ctx context.Context, tx *repository.RepositoryManager, serviceJob *models.ServiceJob, req interfaces.CreateServiceDetailRequest, issueErr error) (*models.SpecialOrder, error) {
+	if !openServiceJob(serviceJob.Status) {
+		return nil, issueErr
+	}
+	available, err := tx.Costing.Available(ctx, req.ItemID, serviceJob.ServiceJobID)
+	if err != nil {
 		return nil, err
 	}
+	if available < 0 {
+		available = 0
+	}
+	if available >= req.Quantity {
+		return nil, issueErr
+	}
 
-	return serviceDetail, nil
+	// The history of the status change is recorded for the job's receiver when the request is not authenticated
+	userID := serviceJob.ReceivedByUserID
+	var createdBy *uint
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		userID = actor.UserID
+		createdBy = &actor.UserID
+	}
+
+	now := time.Now()
+	notes := req.Description
+	order := &models.SpecialOrder{
+		ServiceJobID: serviceJob.ServiceJobID,
+		OutletID:     serviceJob.OutletID,
+		ProductID:    req.ItemID,
+		Quantity:     req.Quantity - available,
+		Status:       models.SpecialOrderDiminta,
+		Notes:        &notes,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		CreatedBy:    createdBy,
+	}
+	if err := tx.SpecialOrder.Create(ctx, []*models.SpecialOrder{order}); err != nil {
+		return nil, err
+	}
+
+	if serviceJob.Status == models.ServiceStatusMenungguSparepart {
+		return order, nil
+	}
+	status := models.ServiceStatusMenungguSparepart
+	if err := tx.ServiceJob.UpdateStatus(ctx, serviceJob.ServiceJobID, status); err != nil {
+		return nil, err
+	}
+	history := &models.ServiceJobHistory{
+		ServiceJobID: serviceJob.ServiceJobID,
+		UserID:       userID,
+		Notes:        &notes,
+		Status:       &status,
+		ChangedAt:    now,
+	}
+	if err := tx.ServiceJobHistory.Create(ctx, history); err != nil {
+		return nil, err
+	}
+	return order, nil
 }
 
 // GetServiceDetail retrieves a service detail by ID
@@ -827,7 +1028,7 @@ func (u *ServiceDetailUsecase) GetServiceDetail(ctx context.Context, id uint) (*
 	serviceDetail, err := u.repo.ServiceDetail.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service detail not found")
+			return nil, interfaces.ErrServiceDetailNotFound
 		}
 		return nil, err
 	}
@@ -839,7 +1040,7 @@ func (u *ServiceDetailUsecase) UpdateServiceDetail(ctx context.Context, id uint,
 	serviceDetail, err := u.repo.ServiceDetail.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service detail not found")
+			return nil, interfaces.ErrServiceDetailNotFound
 		}
 		return nil, err
 	}
@@ -849,7 +1050,7 @@ func (u *ServiceDetailUsecase) UpdateServiceDetail(ctx context.Context, id uint,
 		_, err := u.repo.ServiceJob.GetByID(ctx, *req.ServiceJobID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("service job not found")
+				return nil, interfaces.ErrServiceJobNotFound
 			}
 			return nil, err
 		}
@@ -861,7 +1062,7 @@ func (u *ServiceDetailUsecase) UpdateServiceDetail(ctx context.Context, id uint,
 			_, err := u.repo.Service.GetByID(ctx, *req.ItemID)
 			if err != nil {
 				if errors.Is(err, gorm.ErrRecordNotFound) {
-					return nil, errors.New("service not found")
+					return nil, interfaces.ErrServiceNotFound
 				}
 				return nil, err
 			}
@@ -869,7 +1070,7 @@ func (u *ServiceDetailUsecase) UpdateServiceDetail(ctx context.Context, id uint,
 			_, err := u.repo.Product.GetByID(ctx, *req.ItemID)
 			if err != nil {
 				if errors.Is(err, gorm.ErrRecordNotFound) {
-					return nil, errors.New("product not found")
+					return nil, interfaces.ErrProductNotFound
 				}
 				return nil, err
 			}
@@ -881,12 +1082,34 @@ func (u *ServiceDetailUsecase) UpdateServiceDetail(ctx context.Context, id uint,
 		_, err := u.repo.ProductSerialNumber.GetBySerialNumber(ctx, *req.SerialNumberUsed)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("serial number not found")
+				return nil, interfaces.ErrSerialNumberNotFound
 			}
 			return nil, err
 		}
 	}
 
+	// The line as it will be must stay within the approved estimate of the job it will be on
+	serviceJobID, quantity, pricePerItem := serviceDetail.ServiceJobID, serviceDetail.Quantity, serviceDetail.PricePerItem
+	if req.ServiceJobID != nil {
+		serviceJobID = *req.ServiceJobID
+	}
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+	if req.PricePerItem != nil {
+		pricePerItem = *req.PricePerItem
+	}
+	if err := u.checkWorkCovered(ctx, serviceJobID, serviceDetail.DetailID, pricePerItem*float64(quantity)); err != nil {
+		return nil, err
+	}
+
+	// Moving a part to another job, switching the item or changing the quantity returns the old stock and issues it again
+	restock := (req.ServiceJobID != nil && *req.ServiceJobID != serviceDetail.ServiceJobID) ||
+		(req.ItemID != nil && *req.ItemID != serviceDetail.ItemID) ||
+		(req.ItemType != nil && *req.ItemType != serviceDetail.ItemType) ||
+		(req.Quantity != nil && *req.Quantity != serviceDetail.Quantity)
+	previous := *serviceDetail
+
 	// Update fields if provided
 	if req.ServiceJobID != nil {
 		serviceDetail.ServiceJobID = *req.ServiceJobID
@@ -909,11 +1132,38 @@ func (u *ServiceDetailUsecase) UpdateServiceDetail(ctx context.Context, id uint,
 	if req.PricePerItem != nil {
 		serviceDetail.PricePerItem = *req.PricePerItem
 	}
-	if req.CostPerItem != nil {
+	// The cost of a part comes from its stock issue, only service lines take a cost from the client
+	if serviceDetail.ItemType != "product" && req.CostPerItem != nil {
 		serviceDetail.CostPerItem = *req.CostPerItem
 	}
 
-	if err := u.repo.ServiceDetail.Update(ctx, serviceDetail); err != nil {
+	err = u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if restock {
+			if err := u.returnPart(ctx, tx, &previous, "service part changed"); err != nil {
+				return err
+			}
+			if serviceDetail.ItemType == "product" {
+				serviceJob, err := tx.ServiceJob.GetByID(ctx, serviceDetail.ServiceJobID)
+				if err != nil {
+					return err
+				}
+				unitCost, err := u.issuePart(ctx, tx, serviceJob, serviceDetail.ItemID, serviceDetail.Quantity)
+				if err != nil {
+					return err
+				}
+				serviceDetail.CostPerItem = unitCost
+			}
+		}
+		if err := tx.ServiceDetail.Update(ctx, serviceDetail); err != nil {
+			return err
+		}
+		// The line issued again uses the parts special-ordered for its job up to its new quantity
+		if restock && serviceDetail.ItemType == "product" {
+			return tx.SpecialOrder.UseReserved(ctx, serviceDetail.ServiceJobID, serviceDetail.ItemID, serviceDetail.DetailID, serviceDetail.Quantity, time.Now())
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -922,27 +1172,25 @@ func (u *ServiceDetailUsecase) UpdateServiceDetail(ctx context.Context, id uint,
 
 // DeleteServiceDetail deletes a service detail
 func (u *ServiceDetailUsecase) DeleteServiceDetail(ctx context.Context, id uint) error {
-	_, err := u.repo.ServiceDetail.GetByID(ctx, id)
+	serviceDetail, err := u.repo.ServiceDetail.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("service detail not found")
+			return interfaces.ErrServiceDetailNotFound
 		}
 		return err
 	}
-
-	return u.repo.ServiceDetail.Delete(ctx, id)
+	return u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		if err := u.returnPart(ctx, tx, serviceDetail, "service part deleted"); err != nil {
+			return err
+		}
+		return tx.ServiceDetail.Delete(ctx, id)
+	})
 }
 
 // ListServiceDetails retrieves service details with pagination
-func (u *ServiceDetailUsecase) ListServiceDetails(ctx context.Context, limit, offset int) ([]*models.ServiceDetail, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *ServiceDetailUsecase) ListServiceDetails(ctx context.Context, q *query.ListQuery) ([]*models.ServiceDetail, int64, error) {
 
-	return u.repo.ServiceDetail.List(ctx, limit, offset)
+	return u.repo.ServiceDetail.List(ctx, q)
 }
 
 // GetServiceDetailsByServiceJob retrieves service details by service job
@@ -952,7 +1200,70 @@ func (u *ServiceDetailUsecase) GetServiceDetailsByServiceJob(ctx context.Context
 
 // DeleteServiceDetailsByServiceJob deletes service details by service job
 func (u *ServiceDetailUsecase) DeleteServiceDetailsByServiceJob(ctx context.Context, serviceJobID uint) error {
-	return u.repo.ServiceDetail.DeleteByServiceJobID(ctx, serviceJobID)
+	serviceDetails, err := u.repo.ServiceDetail.GetByServiceJobID(ctx, serviceJobID)
+	if err != nil {
+		return err
+	}
+	return u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+		for _, serviceDetail := range serviceDetails {
+			if err := u.returnPart(ctx, tx, serviceDetail, "service part deleted"); err != nil {
+				return err
+			}
+		}
+		return tx.ServiceDetail.DeleteByServiceJobID(ctx, serviceJobID)
+	})
+}
+
+// checkWorkCovered fails when the job's lines, with the line excludeDetailID replaced by one of lineTotal,
+// exceed the approved estimate
+func (u *ServiceDetailUsecase) checkWorkCovered(ctx context.Context, serviceJobID, excludeDetailID uint, lineTotal float64) error {
+	if u.estimate == nil {
+		return nil
+	}
+	return u.estimate.CheckWorkCovered(ctx, serviceJobID, excludeDetailID, lineTotal)
+}
+
+// issuePart takes a part used on a service job out of the job's outlet and returns the unit cost it left at, repo is
+// the transaction the line is written in
+func (u *ServiceDetailUsecase) issuePart(ctx context.Context, repo *repository.RepositoryManager, serviceJob *models.ServiceJob, productID uint, quantity int) (float64, error) {
+	referenceType := "service_job"
+	return issueStock(ctx, repo, &models.StockMovement{
+		ProductID:     productID,
+		OutletID:      &serviceJob.OutletID,
+		MovementType:  models.StockMovementService,
+		Quantity:      -quantity,
+		ReferenceType: &referenceType,
+		ReferenceID:   &serviceJob.ServiceJobID,
+		CreatedAt:     time.Now(),
+	})
+}
+
+// returnPart puts a part back into the job's outlet at the cost it was used at, the special orders the line used are
+// reserved for the job again; service lines hold no stock
+func (u *ServiceDetailUsecase) returnPart(ctx context.Context, repo *repository.RepositoryManager, serviceDetail *models.ServiceDetail, notes string) error {
+	if serviceDetail.ItemType != "product" {
+		return nil
+	}
+	if err := repo.SpecialOrder.ReturnUsed(ctx, serviceDetail.DetailID, time.Now()); err != nil {
+		return err
+	}
+	serviceJob, err := repo.ServiceJob.GetByID(ctx, serviceDetail.ServiceJobID)
+	if err != nil {
+		return err
+	}
+
+	referenceType := "service_job"
+	return receiveStock(ctx, repo, &models.StockMovement{
+		ProductID:     serviceDetail.ItemID,
+		OutletID:      &serviceJob.OutletID,
+		MovementType:  models.StockMovementService,
+		Quantity:      serviceDetail.Quantity,
+		UnitCost:      serviceDetail.CostPerItem,
+		ReferenceType: &referenceType,
+		ReferenceID:   &serviceJob.ServiceJobID,
+		Notes:         &notes,
+		CreatedAt:     time.Now(),
+	})
 }
 
 // ServiceJobHistoryUsecase implements the service job history usecase interface
@@ -971,7 +1282,7 @@ func (u *ServiceJobHistoryUsecase) CreateServiceJobHistory(ctx context.Context,
 	_, err := u.repo.ServiceJob.GetByID(ctx, req.ServiceJobID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service job not found")
+			return nil, interfaces.ErrServiceJobNotFound
 		}
 		return nil, err
 	}
@@ -980,7 +1291,7 @@ func (u *ServiceJobHistoryUsecase) CreateServiceJobHistory(ctx context.Context,
 	_, err = u.repo.User.GetByID(ctx, req.UserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, interfaces.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -1004,7 +1315,7 @@ func (u *ServiceJobHistoryUsecase) GetServiceJobHistory(ctx context.Context, id
 	history, err := u.repo.ServiceJobHistory.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("service job history not found")
+			return nil, interfaces.ErrServiceJobHistoryNotFound
 		}
 		return nil, err
 	}
@@ -1012,15 +1323,9 @@ func (u *ServiceJobHistoryUsecase) GetServiceJobHistory(ctx context.Context, id
 }
 
 // ListServiceJobHistories retrieves service job histories with pagination
-func (u *ServiceJobHistoryUsecase) ListServiceJobHistories(ctx context.Context, limit, offset int) ([]*models.ServiceJobHistory, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (u *ServiceJobHistoryUsecase) ListServiceJobHistories(ctx context.Context, q *query.ListQuery) ([]*models.ServiceJobHistory, int64, error) {
 
-	return u.repo.ServiceJobHistory.List(ctx, limit, offset)
+	return u.repo.ServiceJobHistory.List(ctx, q)
 }
 
 // GetServiceJobHistoriesByServiceJob retrieves service job histories by service job
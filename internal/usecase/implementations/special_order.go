@@ -0,0 +1,351 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SpecialOrderUsecase implements the special order usecase interface
+type SpecialOrderUsecase struct {
+	repo         *repository.RepositoryManager
+	serviceJob   interfaces.ServiceJobUsecase
+	notification interfaces.NotificationUsecase
+	sequence     interfaces.SequenceUsecase
+	events       interfaces.ServiceJobEventPublisher
+}
+
+// NewSpecialOrderUsecase creates a new special order usecase; jobs wait for their parts through serviceJob, the draft
+// orders are numbered by sequence and arrivals are told to the customers by notification and to the boards by events
+func NewSpecialOrderUsecase(repo *repository.RepositoryManager, serviceJob interfaces.ServiceJobUsecase, notification interfaces.NotificationUsecase, sequence interfaces.SequenceUsecase, events interfaces.ServiceJobEventPublisher) interfaces.SpecialOrderUsecase {
+	return &SpecialOrderUsecase{
+		repo:         repo,
+		serviceJob:   serviceJob,
+		notification: notification,
+		sequence:     sequence,
+		events:       events,
+	}
+}
+
+// RequestParts requests the parts for the job; a job not yet waiting moves to Menunggu Sparepart
+func (u *SpecialOrderUsecase) RequestParts(ctx context.Context, serviceJobID uint, req interfaces.RequestPartsRequest) ([]*models.SpecialOrder, error) {
+	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrServiceJobNotFound
+		}
+		return nil, err
+	}
+	if !openServiceJob(serviceJob.Status) {
+		return nil, interfaces.ErrSpecialOrderJobClosed
+	}
+
+	// The history of the status change is recorded for the job's receiver when the request is not authenticated
+	userID := serviceJob.ReceivedByUserID
+	var createdBy *uint
+	if actor, ok := utils.ActorFromContext(ctx); ok {
+		userID = actor.UserID
+		createdBy = &actor.UserID
+	}
+
+	now := time.Now()
+	orders := make([]*models.SpecialOrder, 0, len(req.Items))
+	for _, item := range req.Items {
+		if _, err := u.repo.Product.GetByID(ctx, item.ProductID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrProductNotFound
+			}
+			return nil, err
+		}
+		notes := item.Notes
+		if notes == nil {
+			notes = req.Notes
+		}
+		orders = append(orders, &models.SpecialOrder{
+			ServiceJobID: serviceJobID,
+			OutletID:     serviceJob.OutletID,
+			ProductID:    item.ProductID,
+			Quantity:     item.Quantity,
+			Status:       models.SpecialOrderDiminta,
+			Notes:        notes,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+			CreatedBy:    createdBy,
+		})
+	}
+	if err := u.repo.SpecialOrder.Create(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	if serviceJob.Status != models.ServiceStatusMenungguSparepart {
+		if err := u.serviceJob.UpdateServiceJobStatus(ctx, serviceJobID, models.ServiceStatusMenungguSparepart, userID, req.Notes); err != nil {
+			return nil, err
+		}
+	}
+	return orders, nil
+}
+
+// ListSpecialOrders lists the special orders matching the query, oldest first
+func (u *SpecialOrderUsecase) ListSpecialOrders(ctx context.Context, q interfaces.SpecialOrderQuery) ([]*models.SpecialOrder, error) {
+	var filter repoInterfaces.SpecialOrderFilter
+	if q.ServiceJobID != nil {
+		if _, err := u.repo.ServiceJob.GetByID(ctx, *q.ServiceJobID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrServiceJobNotFound
+			}
+			return nil, err
+		}
+		filter.ServiceJobID = *q.ServiceJobID
+	}
+	if q.OutletID != nil {
+		filter.OutletID = *q.OutletID
+	}
+	if q.Status != nil {
+		filter.Statuses = []models.SpecialOrderStatus{*q.Status}
+	}
+	return u.repo.SpecialOrder.List(ctx, filter)
+}
+
+// CancelSpecialOrder cancels a requested order, or a reserved one whose parts then stay in stock
+func (u *SpecialOrderUsecase) CancelSpecialOrder(ctx context.Context, id uint) (*models.SpecialOrder, error) {
+	order, err := u.getSpecialOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.SpecialOrderDiminta && order.Status != models.SpecialOrderDicadangkan {
+		return nil, interfaces.ErrSpecialOrderNotCancellable
+	}
+
+	from := order.Status
+	order.Status = models.SpecialOrderDibatalkan
+	moved, err := u.repo.SpecialOrder.SetStatus(ctx, []*models.SpecialOrder{order}, from)
+	if err != nil {
+		return nil, err
+	}
+	// Ordered or used in the meantime
+	if moved == 0 {
+		return nil, interfaces.ErrSpecialOrderNotCancellable
+	}
+	return u.getSpecialOrder(ctx, id)
+}
+
+// GenerateDraftOrders rolls the requested parts into draft purchase orders, one per outlet and preferred supplier
+// with one line per product at its cost price; the requests are then on order
+func (u *SpecialOrderUsecase) GenerateDraftOrders(ctx context.Context, req interfaces.SpecialOrderDraftRequest) (*interfaces.SpecialOrderDrafts, error) {
+	filter := repoInterfaces.SpecialOrderFilter{Statuses: []models.SpecialOrderStatus{models.SpecialOrderDiminta}}
+	if req.OutletID != nil {
+		if _, err := u.repo.Outlet.GetByID(ctx, *req.OutletID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrOutletNotFound
+			}
+			return nil, err
+		}
+		filter.OutletID = *req.OutletID
+	}
+	requested, err := u.repo.SpecialOrder.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &interfaces.SpecialOrderDrafts{
+		Orders:     []*models.PurchaseOrder{},
+		Unassigned: []*models.SpecialOrder{},
+	}
+	type orderKey struct{ outletID, supplierID uint }
+	var keys []orderKey
+	requests := make(map[orderKey][]*models.SpecialOrder)
+	for _, request := range requested {
+		if request.Product == nil || request.Product.SupplierID == nil {
+			result.Unassigned = append(result.Unassigned, request)
+			continue
+		}
+		key := orderKey{outletID: request.OutletID, supplierID: *request.Product.SupplierID}
+		if _, ok := requests[key]; !ok {
+			keys = append(keys, key)
+		}
+		requests[key] = append(requests[key], request)
+	}
+
+	now := time.Now()
+	for _, key := range keys {
+		// The number is taken first, it is skipped when a concurrent run claimed all the requests
+		poCode, _, err := u.sequence.Next(ctx, interfaces.SequencePurchaseOrder, key.outletID, now)
+		if err != nil {
+			return nil, err
+		}
+
+		var order *models.PurchaseOrder
+		err = u.repo.Atomic(ctx, func(tx *repository.RepositoryManager) error {
+			// The requests are claimed before the order is written, so a concurrent run orders each of them once
+			var claimed []*models.SpecialOrder
+			for _, request := range requests[key] {
+				request.Status = models.SpecialOrderDipesan
+				moved, err := tx.SpecialOrder.SetStatus(ctx, []*models.SpecialOrder{request}, models.SpecialOrderDiminta)
+				if err != nil {
+					return err
+				}
+				if moved > 0 {
+					claimed = append(claimed, request)
+				}
+			}
+			if len(claimed) == 0 {
+				return nil
+			}
+
+			draft := draftPurchaseOrder(key.outletID, key.supplierID, claimed, now)
+			draft.POCode = poCode
+			if err := tx.PurchaseOrder.Create(ctx, draft); err != nil {
+				return err
+			}
+			for _, request := range claimed {
+				request.PurchaseOrderID = &draft.PurchaseOrderID
+			}
+			if _, err := tx.SpecialOrder.SetStatus(ctx, claimed, models.SpecialOrderDipesan); err != nil {
+				return err
+			}
+
+			var err error
+			order, err = tx.PurchaseOrder.GetByID(ctx, draft.PurchaseOrderID)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if order != nil {
+			result.Orders = append(result.Orders, order)
+		}
+	}
+	return result, nil
+}
+
+// draftPurchaseOrder builds the draft order of a supplier for the outlet's requests, the requests of several jobs
+// for the same product share one line
+func draftPurchaseOrder(outletID, supplierID uint, requests []*models.SpecialOrder, now time.Time) *models.PurchaseOrder {
+	notes := "generated from special orders"
+	order := &models.PurchaseOrder{
+		SupplierID:  supplierID,
+		OutletID:    outletID,
+		PODate:      truncateDay(now),
+		PaymentType: models.PaymentTypeTunai,
+		Status:      models.PurchaseStatusDraft,
+		Notes:       &notes,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	lines := make(map[uint]int)
+	for _, request := range requests {
+		line, ok := lines[request.ProductID]
+		if !ok {
+			line = len(order.PurchaseOrderDetails)
+			lines[request.ProductID] = line
+			order.PurchaseOrderDetails = append(order.PurchaseOrderDetails, models.PurchaseOrderDetail{
+				ProductID: request.ProductID,
+				CostPrice: request.Product.CostPrice,
+			})
+		}
+		order.PurchaseOrderDetails[line].Quantity += request.Quantity
+		order.TotalAmount = roundAmount(order.TotalAmount + float64(request.Quantity)*request.Product.CostPrice)
+	}
+	return order
+}
+
+// reserveSpecialOrders reserves the delivered quantities of a received purchase order for the orders on it, oldest
+// first; repo is the transaction the goods are received in. A buyer can cut a line of the draft, the orders it no
+// longer covers are requested again.
+func reserveSpecialOrders(ctx context.Context, repo *repository.RepositoryManager, purchaseOrder *models.PurchaseOrder) ([]*models.SpecialOrder, error) {
+	ordered, err := repo.SpecialOrder.List(ctx, repoInterfaces.SpecialOrderFilter{
+		PurchaseOrderID: purchaseOrder.PurchaseOrderID,
+		Statuses:        []models.SpecialOrderStatus{models.SpecialOrderDipesan},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivered := make(map[uint]int)
+	for _, detail := range purchaseOrder.PurchaseOrderDetails {
+		delivered[detail.ProductID] += detail.Quantity
+	}
+
+	now := time.Now()
+	reserved := []*models.SpecialOrder{}
+	var released []*models.SpecialOrder
+	for _, order := range ordered {
+		if delivered[order.ProductID] >= order.Quantity {
+			delivered[order.ProductID] -= order.Quantity
+			order.Status = models.SpecialOrderDicadangkan
+			order.ReceivedAt = &now
+			reserved = append(reserved, order)
+			continue
+		}
+		order.Status = models.SpecialOrderDiminta
+		order.PurchaseOrderID = nil
+		released = append(released, order)
+	}
+	if _, err := repo.SpecialOrder.SetStatus(ctx, reserved, models.SpecialOrderDipesan); err != nil {
+		return nil, err
+	}
+	if _, err := repo.SpecialOrder.SetStatus(ctx, released, models.SpecialOrderDipesan); err != nil {
+		return nil, err
+	}
+	return reserved, nil
+}
+
+// PartsArrived tells every job with reserved orders on the purchase order once, if it has all its parts
+func (u *SpecialOrderUsecase) PartsArrived(ctx context.Context, purchaseOrderID uint, reserved []*models.SpecialOrder) {
+	told := make(map[uint]bool)
+	for _, order := range reserved {
+		if told[order.ServiceJobID] {
+			continue
+		}
+		told[order.ServiceJobID] = true
+		u.partsArrived(ctx, order, purchaseOrderID)
+	}
+}
+
+// partsArrived tells the customer and the boards that the job has all its parts once none of its orders is
+// outstanding; the goods are already received, so a failed check or notification is logged and does not fail the receipt
+func (u *SpecialOrderUsecase) partsArrived(ctx context.Context, order *models.SpecialOrder, purchaseOrderID uint) {
+	outstanding, err := u.repo.SpecialOrder.List(ctx, repoInterfaces.SpecialOrderFilter{
+		ServiceJobID: order.ServiceJobID,
+		Statuses:     []models.SpecialOrderStatus{models.SpecialOrderDiminta, models.SpecialOrderDipesan},
+	})
+	if err != nil {
+		fmt.Printf("Failed to check outstanding special orders: %v\n", err)
+		return
+	}
+	if len(outstanding) > 0 {
+		return
+	}
+
+	if u.notification != nil {
+		if err := u.notification.NotifyPartsArrived(ctx, order.ServiceJobID, purchaseOrderID); err != nil {
+			fmt.Printf("Failed to queue parts arrival notification: %v\n", err)
+		}
+	}
+	if u.events != nil {
+		event := interfaces.ServiceJobEvent{Type: models.ServiceJobEventPartsArrived, ServiceJobID: order.ServiceJobID, OutletID: order.OutletID}
+		if err := u.events.PublishServiceJobEvent(ctx, event); err != nil {
+			fmt.Printf("Failed to publish service job event: %v\n", err)
+		}
+	}
+}
+
+// getSpecialOrder retrieves a special order
+func (u *SpecialOrderUsecase) getSpecialOrder(ctx context.Context, id uint) (*models.SpecialOrder, error) {
+	order, err := u.repo.SpecialOrder.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrSpecialOrderNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
@@ -0,0 +1,238 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestRepository opens a file database with the schema of every model
+func newTestRepository(t *testing.T) (*repository.RepositoryManager, *gorm.DB) {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=10000"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(models.GetAllModels()...); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("database handle: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return repository.NewRepositoryManager(db), db
+}
+
+// createTestProduct stores an active product with no stock
+func createTestProduct(t *testing.T, db *gorm.DB, name string, costPrice float64, method models.CostMethod) *models.Product {
+	t.Helper()
+	product := &models.Product{
+		ProductName:  name,
+		CostPrice:    costPrice,
+		CostMethod:   method,
+		SellingPrice: costPrice * 2,
+		UsageStatus:  models.ProductUsageJual,
+		IsActive:     true,
+	}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	return product
+}
+
+// createTestServiceJob stores a job in progress at the outlet
+func createTestServiceJob(t *testing.T, db *gorm.DB, code string, outletID uint) *models.ServiceJob {
+	t.Helper()
+	serviceJob := &models.ServiceJob{
+		ServiceCode:        code,
+		QueueNumber:        1,
+		CustomerID:         1,
+		VehicleID:          1,
+		ReceivedByUserID:   1,
+		OutletID:           outletID,
+		ProblemDescription: "brake pads worn",
+		Status:             models.ServiceStatusDikerjakan,
+		ServiceInDate:      time.Now(),
+		TrackingToken:      code,
+	}
+	if err := db.Create(serviceJob).Error; err != nil {
+		t.Fatalf("create service job: %v", err)
+	}
+	return serviceJob
+}
+
+// specialOrderQuantities sums the job's special orders per status
+func specialOrderQuantities(t *testing.T, db *gorm.DB, serviceJobID uint) map[models.SpecialOrderStatus]int {
+	t.Helper()
+	var orders []models.SpecialOrder
+	if err := db.Where("service_job_id = ?", serviceJobID).Find(&orders).Error; err != nil {
+		t.Fatalf("list special orders: %v", err)
+	}
+	quantities := make(map[models.SpecialOrderStatus]int)
+	for _, order := range orders {
+		quantities[order.Status] += order.Quantity
+	}
+	return quantities
+}
+
+func TestServiceDetailKeepsSpecialOrderReservation(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+	const outletID = 1
+	product := createTestProduct(t, db, "Brake pad", 50000, models.CostMethodAverage)
+	serviceJob := createTestServiceJob(t, db, "SJ-1", outletID)
+
+	// Three pads arrived for the job and are held for it
+	outlet := uint(outletID)
+	err := repo.Costing.Receive(ctx, &models.StockMovement{
+		ProductID:    product.ProductID,
+		OutletID:     &outlet,
+		MovementType: models.StockMovementPurchase,
+		Quantity:     3,
+		UnitCost:     50000,
+	})
+	if err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	now := time.Now()
+	err = repo.SpecialOrder.Create(ctx, []*models.SpecialOrder{{
+		ServiceJobID: serviceJob.ServiceJobID,
+		OutletID:     outletID,
+		ProductID:    product.ProductID,
+		Quantity:     3,
+		Status:       models.SpecialOrderDicadangkan,
+		ReceivedAt:   &now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}})
+	if err != nil {
+		t.Fatalf("create special order: %v", err)
+	}
+
+	u := NewServiceDetailUsecase(repo, nil, nil, nil)
+	check := func(step string, reserved, used int) {
+		t.Helper()
+		quantities := specialOrderQuantities(t, db, serviceJob.ServiceJobID)
+		if quantities[models.SpecialOrderDicadangkan] != reserved || quantities[models.SpecialOrderTerpakai] != used {
+			t.Fatalf("%s: got %d reserved and %d used, want %d and %d", step, quantities[models.SpecialOrderDicadangkan],
+				quantities[models.SpecialOrderTerpakai], reserved, used)
+		}
+	}
+
+	created, err := u.CreateServiceDetail(ctx, interfaces.CreateServiceDetailRequest{
+		ServiceJobID: serviceJob.ServiceJobID,
+		ItemID:       product.ProductID,
+		ItemType:     "product",
+		Description:  "Brake pad",
+		Quantity:     2,
+		PricePerItem: 100000,
+	})
+	if err != nil {
+		t.Fatalf("create part line: %v", err)
+	}
+	check("part line of 2", 1, 2)
+
+	quantity := 1
+	if _, err := u.UpdateServiceDetail(ctx, created.ServiceDetail.DetailID, interfaces.UpdateServiceDetailRequest{Quantity: &quantity}); err != nil {
+		t.Fatalf("reduce part line: %v", err)
+	}
+	check("part line reduced to 1", 2, 1)
+
+	// The two pads still held for the job cannot go to a walk-in customer
+	sale := &models.StockMovement{
+		ProductID:    product.ProductID,
+		OutletID:     &outlet,
+		MovementType: models.StockMovementSale,
+		Quantity:     -1,
+	}
+	if err := repo.Costing.Issue(ctx, sale); !errors.Is(err, repoInterfaces.ErrStockReserved) {
+		t.Fatalf("sale of reserved stock: got %v, want %v", err, repoInterfaces.ErrStockReserved)
+	}
+
+	if err := u.DeleteServiceDetail(ctx, created.ServiceDetail.DetailID); err != nil {
+		t.Fatalf("delete part line: %v", err)
+	}
+	check("part line deleted", 3, 0)
+	if err := db.First(product, product.ProductID).Error; err != nil {
+		t.Fatalf("reload product: %v", err)
+	}
+	if product.Stock != 3 {
+		t.Fatalf("stock after the line was deleted: got %d, want 3", product.Stock)
+	}
+}
+
+func TestServiceDetailShortfallWaitsForParts(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+	const outletID = 1
+	product := createTestProduct(t, db, "Clutch plate", 120000, models.CostMethodAverage)
+	serviceJob := createTestServiceJob(t, db, "SJ-1", outletID)
+	outlet := uint(outletID)
+	err := repo.Costing.Receive(ctx, &models.StockMovement{
+		ProductID:    product.ProductID,
+		OutletID:     &outlet,
+		MovementType: models.StockMovementPurchase,
+		Quantity:     1,
+		UnitCost:     120000,
+	})
+	if err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+
+	u := NewServiceDetailUsecase(repo, nil, nil, nil)
+	req := interfaces.CreateServiceDetailRequest{
+		ServiceJobID: serviceJob.ServiceJobID,
+		ItemID:       product.ProductID,
+		ItemType:     "product",
+		Description:  "Clutch plate",
+		Quantity:     3,
+		PricePerItem: 200000,
+	}
+	created, err := u.CreateServiceDetail(ctx, req)
+	if err != nil {
+		t.Fatalf("create part line: %v", err)
+	}
+
+	// The one plate in stock leaves two to order, no line is added and no stock moves
+	order := created.SpecialOrder
+	if created.ServiceDetail != nil || order == nil || order.Quantity != 2 || order.Status != models.SpecialOrderDiminta {
+		t.Fatalf("got %+v, want a request for 2 and no line", created)
+	}
+	if err := db.First(serviceJob, serviceJob.ServiceJobID).Error; err != nil {
+		t.Fatalf("reload service job: %v", err)
+	}
+	if serviceJob.Status != models.ServiceStatusMenungguSparepart {
+		t.Fatalf("got status %s, want %s", serviceJob.Status, models.ServiceStatusMenungguSparepart)
+	}
+	var histories int64
+	db.Model(&models.ServiceJobHistory{}).Where("service_job_id = ?", serviceJob.ServiceJobID).Count(&histories)
+	if histories != 1 {
+		t.Fatalf("got %d history entries, want the status change", histories)
+	}
+	if err := db.First(product, product.ProductID).Error; err != nil {
+		t.Fatalf("reload product: %v", err)
+	}
+	if product.Stock != 1 {
+		t.Fatalf("got stock %d, want 1", product.Stock)
+	}
+
+	// Parts are not ordered for a job that is done
+	if err := db.Model(serviceJob).Update("status", models.ServiceStatusSelesai).Error; err != nil {
+		t.Fatalf("finish service job: %v", err)
+	}
+	if _, err := u.CreateServiceDetail(ctx, req); !errors.Is(err, interfaces.ErrInsufficientStock) {
+		t.Fatalf("part line of a finished job: got %v, want %v", err, interfaces.ErrInsufficientStock)
+	}
+}
@@ -0,0 +1,234 @@
+package implementations
+
+import (
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// Defaults of the stock analysis
+const (
+	defaultStockAnalysisDays = 90
+	defaultDeadStockDays     = 180
+	defaultMinTurnover       = 4.0
+)
+
+// Cumulative shares of the consumption value that close the A and B classes, in percent
+const (
+	abcShareA = 80.0
+	abcShareB = 95.0
+)
+
+// stockGroup accumulates the products of a category or supplier
+type stockGroup struct {
+	figures interfaces.StockGroupFigures
+}
+
+func (g *stockGroup) add(product *interfaces.ProductStockAnalysis) {
+	g.figures.Products++
+	g.figures.Stock += product.Stock
+	g.figures.CapitalTiedUp += product.CapitalTiedUp
+	g.figures.ConsumptionValue += product.ConsumptionValue
+	switch product.Class {
+	case interfaces.StockClassFast:
+		g.figures.Fast++
+	case interfaces.StockClassSlow:
+		g.figures.Slow++
+		g.figures.SlowCapital += product.CapitalTiedUp
+	case interfaces.StockClassDead:
+		g.figures.Dead++
+		g.figures.DeadCapital += product.CapitalTiedUp
+	}
+}
+
+// result rounds the sums; days on hand of a group is its capital over its average daily consumption value
+func (g *stockGroup) result(days float64) interfaces.StockGroupFigures {
+	figures := g.figures
+	if figures.ConsumptionValue > 0 {
+		daysOnHand := math.Round(figures.CapitalTiedUp/(figures.ConsumptionValue/days)*10) / 10
+		figures.DaysOnHand = &daysOnHand
+	}
+	figures.CapitalTiedUp = roundAmount(figures.CapitalTiedUp)
+	figures.SlowCapital = roundAmount(figures.SlowCapital)
+	figures.DeadCapital = roundAmount(figures.DeadCapital)
+	figures.ConsumptionValue = roundAmount(figures.ConsumptionValue)
+	return figures
+}
+
+// stockAnalysis classifies every product by its last movement and turnover in the filter's range and ranks them
+// by consumption value; stock and capital tied up are the current ones, days since moved count to the range's end
+func stockAnalysis(ctx context.Context, repo *repository.RepositoryManager, filter repoInterfaces.AnalyticsFilter,
+	deadDays int, minTurnover float64) (*interfaces.StockAnalysis, error) {
+	products, err := repo.Analytics.StockProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	consumed, err := repo.Analytics.ProductConsumption(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	consumption := make(map[uint]repoInterfaces.ProductConsumptionRow, len(consumed))
+	for _, row := range consumed {
+		consumption[row.ProductID] = row
+	}
+
+	start := truncateDay(filter.StartDate.Local())
+	lastDay := truncateDay(filter.EndDate.Local().AddDate(0, 0, -1))
+	days := math.Round(lastDay.Sub(start).Hours()/24) + 1
+
+	result := &interfaces.StockAnalysis{
+		StartDate:   start.Format("2006-01-02"),
+		EndDate:     lastDay.Format("2006-01-02"),
+		DeadDays:    deadDays,
+		MinTurnover: minTurnover,
+		Categories:  []interfaces.StockGroupFigures{},
+		Suppliers:   []interfaces.StockGroupFigures{},
+		Products:    make([]interfaces.ProductStockAnalysis, 0, len(products)),
+	}
+	totalValue := 0.0
+	for _, product := range products {
+		row := consumption[product.ProductID]
+		analysis := interfaces.ProductStockAnalysis{
+			ProductID:        product.ProductID,
+			ProductName:      product.ProductName,
+			SKU:              product.SKU,
+			IsActive:         product.IsActive,
+			CategoryID:       product.CategoryID,
+			CategoryName:     product.CategoryName,
+			SupplierID:       product.SupplierID,
+			SupplierName:     product.SupplierName,
+			Stock:            product.Stock,
+			CostPrice:        product.CostPrice,
+			QuantityConsumed: row.Quantity,
+			ConsumptionValue: roundAmount(row.Value),
+		}
+		if product.Stock > 0 {
+			analysis.CapitalTiedUp = roundAmount(float64(product.Stock) * product.CostPrice)
+		}
+
+		lastMoved := truncateDay(product.CreatedAt.Local())
+		if row.LastMoved != "" {
+			if moved, err := time.ParseInLocation("2006-01-02", row.LastMoved, time.Local); err == nil {
+				lastMoved = moved
+			}
+		}
+		analysis.LastMoved = lastMoved.Format("2006-01-02")
+		if since := int(math.Round(lastDay.Sub(lastMoved).Hours() / 24)); since > 0 {
+			analysis.DaysSinceMoved = since
+		}
+
+		if analysis.CapitalTiedUp > 0 {
+			turnover := math.Round(analysis.ConsumptionValue/analysis.CapitalTiedUp*365/days*100) / 100
+			analysis.Turnover = &turnover
+		}
+		if row.Quantity > 0 {
+			daysOnHand := math.Round(math.Max(float64(product.Stock), 0)/(float64(row.Quantity)/days)*10) / 10
+			analysis.DaysOnHand = &daysOnHand
+		}
+
+		switch {
+		case analysis.DaysSinceMoved >= deadDays:
+			analysis.Class = interfaces.StockClassDead
+		case analysis.Turnover != nil && *analysis.Turnover < minTurnover:
+			analysis.Class = interfaces.StockClassSlow
+		default:
+			analysis.Class = interfaces.StockClassFast
+		}
+
+		if analysis.ConsumptionValue > 0 {
+			totalValue += analysis.ConsumptionValue
+		}
+		result.Products = append(result.Products, analysis)
+	}
+
+	// ABC: a product belongs to the class its cumulative share starts in
+	ranked := make([]*interfaces.ProductStockAnalysis, len(result.Products))
+	for i := range result.Products {
+		ranked[i] = &result.Products[i]
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].ConsumptionValue > ranked[j].ConsumptionValue
+	})
+	cumulative := 0.0
+	for _, product := range ranked {
+		share := percent(cumulative, totalValue)
+		switch {
+		case product.ConsumptionValue <= 0:
+			product.ABC = "C"
+		case share < abcShareA:
+			product.ABC = "A"
+		case share < abcShareB:
+			product.ABC = "B"
+		default:
+			product.ABC = "C"
+		}
+		if product.ConsumptionValue > 0 {
+			cumulative += product.ConsumptionValue
+		}
+	}
+
+	var total stockGroup
+	categories := make(map[uint]*stockGroup)
+	suppliers := make(map[uint]*stockGroup)
+	var noCategory, noSupplier *stockGroup
+	for i := range result.Products {
+		product := &result.Products[i]
+		total.add(product)
+		groupOf(categories, &noCategory, product.CategoryID, product.CategoryName).add(product)
+		groupOf(suppliers, &noSupplier, product.SupplierID, product.SupplierName).add(product)
+	}
+	result.Totals = total.result(days)
+	result.Categories = stockGroupResults(categories, noCategory, days)
+	result.Suppliers = stockGroupResults(suppliers, noSupplier, days)
+
+	sort.SliceStable(result.Products, func(i, j int) bool {
+		a, b := result.Products[i], result.Products[j]
+		if a.CapitalTiedUp != b.CapitalTiedUp {
+			return a.CapitalTiedUp > b.CapitalTiedUp
+		}
+		return a.ProductName < b.ProductName
+	})
+	return result, nil
+}
+
+// groupOf returns the group of a category or supplier, creating it on first use; products without one share none
+func groupOf(groups map[uint]*stockGroup, none **stockGroup, id *uint, name *string) *stockGroup {
+	if id == nil {
+		if *none == nil {
+			*none = &stockGroup{}
+		}
+		return *none
+	}
+	group := groups[*id]
+	if group == nil {
+		group = &stockGroup{}
+		group.figures.ID = id
+		if name != nil {
+			group.figures.Name = *name
+		}
+		groups[*id] = group
+	}
+	return group
+}
+
+// stockGroupResults orders the groups by capital tied up, the group without category or supplier last
+func stockGroupResults(groups map[uint]*stockGroup, none *stockGroup, days float64) []interfaces.StockGroupFigures {
+	rows := make([]interfaces.StockGroupFigures, 0, len(groups)+1)
+	for _, group := range groups {
+		rows = append(rows, group.result(days))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].CapitalTiedUp != rows[j].CapitalTiedUp {
+			return rows[i].CapitalTiedUp > rows[j].CapitalTiedUp
+		}
+		return *rows[i].ID < *rows[j].ID
+	})
+	if none != nil {
+		rows = append(rows, none.result(days))
+	}
+	return rows
+}
@@ -0,0 +1,438 @@
+package implementations
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	repoInterfaces "boilerplate/internal/repository/interfaces"
+	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/utils"
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultJobMinutes = 60
+
+// TechnicianUsecase implements the technician usecase interface
+type TechnicianUsecase struct {
+	repo       *repository.RepositoryManager
+	serviceJob interfaces.ServiceJobUsecase
+	jobMinutes int
+}
+
+// NewTechnicianUsecase creates the technician usecase, assignments go through the service job usecase so they are
+// published to the live boards like any other change
+func NewTechnicianUsecase(repo *repository.RepositoryManager, serviceJob interfaces.ServiceJobUsecase, conf config.TechnicianAccount) interfaces.TechnicianUsecase {
+	u := &TechnicianUsecase{repo: repo, serviceJob: serviceJob, jobMinutes: conf.DefaultJobMinutes}
+	if u.jobMinutes <= 0 {
+		u.jobMinutes = defaultJobMinutes
+	}
+	return u
+}
+
+// SaveTechnician creates or replaces the profile of a user with the skills and shifts asked for
+func (u *TechnicianUsecase) SaveTechnician(ctx context.Context, userID uint, req interfaces.SaveTechnicianRequest) (*models.TechnicianProfile, error) {
+	if _, err := u.repo.User.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	profile := &models.TechnicianProfile{UserID: userID, IsActive: true, Notes: req.Notes}
+	existing, err := u.repo.Technician.GetByUserID(ctx, userID)
+	switch {
+	case err == nil:
+		profile.IsActive = existing.IsActive
+		profile.CreatedAt = existing.CreatedAt
+		profile.CreatedBy = existing.CreatedBy
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if actor, ok := utils.ActorFromContext(ctx); ok {
+			profile.CreatedBy = &actor.UserID
+		}
+	default:
+		return nil, err
+	}
+	if req.IsActive != nil {
+		profile.IsActive = *req.IsActive
+	}
+
+	seenCategories := make(map[uint]bool)
+	for _, categoryID := range req.ServiceCategoryIDs {
+		if seenCategories[categoryID] {
+			continue
+		}
+		seenCategories[categoryID] = true
+		if _, err := u.repo.ServiceCategory.GetByID(ctx, categoryID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.ErrServiceCategoryNotFound.WithDetails(map[string]interface{}{"service_category_id": categoryID})
+			}
+			return nil, err
+		}
+		profile.Skills = append(profile.Skills, models.TechnicianSkill{UserID: userID, ServiceCategoryID: categoryID})
+	}
+
+	seenDays := make(map[int]bool)
+	for _, item := range req.Shifts {
+		// "HH:MM" strings order as the times they stand for
+		if seenDays[item.Weekday] || item.EndsAt <= item.StartsAt {
+			return nil, interfaces.ErrTechnicianShiftsInvalid.WithDetails(map[string]interface{}{"weekday": item.Weekday})
+		}
+		seenDays[item.Weekday] = true
+		profile.Shifts = append(profile.Shifts, models.TechnicianShift{
+			UserID:   userID,
+			Weekday:  item.Weekday,
+			StartsAt: item.StartsAt,
+			EndsAt:   item.EndsAt,
+		})
+	}
+
+	if err := u.repo.Technician.Save(ctx, profile); err != nil {
+		return nil, err
+	}
+	return u.repo.Technician.GetByUserID(ctx, userID)
+}
+
+// GetTechnician retrieves the profile of a technician
+func (u *TechnicianUsecase) GetTechnician(ctx context.Context, userID uint) (*models.TechnicianProfile, error) {
+	profile, err := u.repo.Technician.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrTechnicianNotFound
+		}
+		return nil, err
+	}
+	return profile, nil
+}
+
+// ListTechnicians lists the technician profiles
+func (u *TechnicianUsecase) ListTechnicians(ctx context.Context, q interfaces.TechnicianQuery) ([]*models.TechnicianProfile, error) {
+	return u.repo.Technician.List(ctx, repoInterfaces.TechnicianFilter{OutletID: q.OutletID, Active: q.Active})
+}
+
+// GetWorkload lists the technicians of the outlet with their open jobs, the least loaded first
+func (u *TechnicianUsecase) GetWorkload(ctx context.Context, q interfaces.TechnicianWorkloadQuery) ([]interfaces.TechnicianWorkload, error) {
+	if _, err := u.repo.Outlet.GetByID(ctx, q.OutletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrOutletNotFound
+		}
+		return nil, err
+	}
+	profiles, err := u.repo.Technician.List(ctx, repoInterfaces.TechnicianFilter{OutletID: q.OutletID})
+	if err != nil {
+		return nil, err
+	}
+	workloads, err := u.workloads(ctx, profiles, 0, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interfaces.TechnicianWorkload, 0, len(workloads))
+	for _, workload := range workloads {
+		// Inactive technicians are listed while they still hold jobs to hand over
+		if workload.IsActive || workload.OpenJobs > 0 {
+			result = append(result, workload)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return lessLoaded(result[i], result[j]) })
+	return result, nil
+}
+
+// SuggestTechnicians ranks the active technicians of the job's outlet for its requested services
+func (u *TechnicianUsecase) SuggestTechnicians(ctx context.Context, serviceJobID uint) (*interfaces.TechnicianSuggestion, error) {
+	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrServiceJobNotFound
+		}
+		return nil, err
+	}
+	return u.suggest(ctx, serviceJob, time.Now())
+}
+
+func (u *TechnicianUsecase) suggest(ctx context.Context, serviceJob *models.ServiceJob, now time.Time) (*interfaces.TechnicianSuggestion, error) {
+	services, err := u.repo.Technician.GetJobServices(ctx, []uint{serviceJob.ServiceJobID})
+	if err != nil {
+		return nil, err
+	}
+	requested := requestedServices(services)
+	suggestion := &interfaces.TechnicianSuggestion{
+		ServiceJobID:       serviceJob.ServiceJobID,
+		ServiceCategoryIDs: serviceCategories(requested),
+		EstimatedMinutes:   u.estimate(requested),
+		Candidates:         []interfaces.TechnicianCandidate{},
+	}
+
+	active := true
+	profiles, err := u.repo.Technician.List(ctx, repoInterfaces.TechnicianFilter{OutletID: serviceJob.OutletID, Active: &active})
+	if err != nil {
+		return nil, err
+	}
+	// The job itself is left out, reassigning it takes it off its technician
+	workloads, err := u.workloads(ctx, profiles, serviceJob.ServiceJobID, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, workload := range workloads {
+		missing := missingCategories(suggestion.ServiceCategoryIDs, workload.ServiceCategoryIDs)
+		suggestion.Candidates = append(suggestion.Candidates, interfaces.TechnicianCandidate{
+			TechnicianWorkload:        workload,
+			Qualified:                 len(missing) == 0,
+			MissingServiceCategoryIDs: missing,
+		})
+	}
+	sort.SliceStable(suggestion.Candidates, func(i, j int) bool {
+		a, b := suggestion.Candidates[i], suggestion.Candidates[j]
+		if a.Qualified != b.Qualified {
+			return a.Qualified
+		}
+		if a.OnShift != b.OnShift {
+			return a.OnShift
+		}
+		return lessLoaded(a.TechnicianWorkload, b.TechnicianWorkload)
+	})
+	if len(suggestion.Candidates) > 0 && suggestion.Candidates[0].Qualified {
+		suggestion.SuggestedID = &suggestion.Candidates[0].UserID
+	}
+	return suggestion, nil
+}
+
+// AssignTechnician assigns an open job to the technician asked for when qualified, or to the suggested technician
+func (u *TechnicianUsecase) AssignTechnician(ctx context.Context, serviceJobID uint, req interfaces.AssignTechnicianRequest) (*models.ServiceJob, error) {
+	serviceJob, err := u.repo.ServiceJob.GetByID(ctx, serviceJobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrServiceJobNotFound
+		}
+		return nil, err
+	}
+	if !openServiceJob(serviceJob.Status) {
+		return nil, interfaces.ErrServiceJobClosed
+	}
+	if req.TechnicianID != nil {
+		if err := checkActiveTechnician(ctx, u.repo, *req.TechnicianID); err != nil {
+			return nil, err
+		}
+	}
+
+	suggestion, err := u.suggest(ctx, serviceJob, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	technicianID := suggestion.SuggestedID
+	if req.TechnicianID != nil {
+		technicianID = nil
+		for _, candidate := range suggestion.Candidates {
+			if candidate.UserID != *req.TechnicianID {
+				continue
+			}
+			if !candidate.Qualified {
+				return nil, interfaces.ErrTechnicianNotQualified.WithDetails(map[string]interface{}{
+					"missing_service_category_ids": candidate.MissingServiceCategoryIDs,
+				})
+			}
+			technicianID = req.TechnicianID
+		}
+		// Active technicians of the outlet are all candidates
+		if technicianID == nil {
+			return nil, interfaces.ErrTechnicianOtherOutlet
+		}
+	}
+	if technicianID == nil {
+		return nil, interfaces.ErrNoTechnicianAvailable.WithDetails(map[string]interface{}{
+			"service_category_ids": suggestion.ServiceCategoryIDs,
+		})
+	}
+
+	return u.serviceJob.UpdateServiceJob(ctx, serviceJobID, interfaces.UpdateServiceJobRequest{TechnicianID: technicianID})
+}
+
+// workloads builds the workload of each technician from the open jobs assigned, leaving out exceptJobID
+func (u *TechnicianUsecase) workloads(ctx context.Context, profiles []*models.TechnicianProfile, exceptJobID uint, now time.Time) ([]interfaces.TechnicianWorkload, error) {
+	technicianIDs := make([]uint, 0, len(profiles))
+	for _, profile := range profiles {
+		technicianIDs = append(technicianIDs, profile.UserID)
+	}
+	serviceJobs, err := u.repo.Technician.GetOpenJobs(ctx, technicianIDs)
+	if err != nil {
+		return nil, err
+	}
+	jobIDs := make([]uint, 0, len(serviceJobs))
+	for _, serviceJob := range serviceJobs {
+		jobIDs = append(jobIDs, serviceJob.ServiceJobID)
+	}
+	rows, err := u.repo.Technician.GetJobServices(ctx, jobIDs)
+	if err != nil {
+		return nil, err
+	}
+	servicesByJob := make(map[uint][]repoInterfaces.JobServiceRow)
+	for _, row := range rows {
+		servicesByJob[row.ServiceJobID] = append(servicesByJob[row.ServiceJobID], row)
+	}
+	jobsByTechnician := make(map[uint][]*models.ServiceJob)
+	for _, serviceJob := range serviceJobs {
+		if serviceJob.ServiceJobID == exceptJobID || serviceJob.TechnicianID == nil {
+			continue
+		}
+		jobsByTechnician[*serviceJob.TechnicianID] = append(jobsByTechnician[*serviceJob.TechnicianID], serviceJob)
+	}
+
+	workloads := make([]interfaces.TechnicianWorkload, 0, len(profiles))
+	for _, profile := range profiles {
+		workload := interfaces.TechnicianWorkload{
+			UserID:             profile.UserID,
+			IsActive:           profile.IsActive,
+			ServiceCategoryIDs: make([]uint, 0, len(profile.Skills)),
+			Jobs:               []interfaces.TechnicianJob{},
+		}
+		if profile.User != nil {
+			workload.Name = profile.User.Name
+			workload.OutletID = profile.User.OutletID
+		}
+		for _, skill := range profile.Skills {
+			workload.ServiceCategoryIDs = append(workload.ServiceCategoryIDs, skill.ServiceCategoryID)
+		}
+		workload.Shift, workload.OnShift = shiftAt(profile.Shifts, now)
+
+		for _, serviceJob := range jobsByTechnician[profile.UserID] {
+			job := interfaces.TechnicianJob{
+				ServiceJobID:     serviceJob.ServiceJobID,
+				ServiceCode:      serviceJob.ServiceCode,
+				QueueNumber:      serviceJob.QueueNumber,
+				OutletID:         serviceJob.OutletID,
+				Status:           serviceJob.Status,
+				EstimatedMinutes: u.estimate(requestedServices(servicesByJob[serviceJob.ServiceJobID])),
+			}
+			if serviceJob.Vehicle != nil {
+				job.PlateNumber = serviceJob.Vehicle.PlateNumber
+			}
+			workload.Jobs = append(workload.Jobs, job)
+			workload.OpenJobs++
+			if serviceJob.Status == models.ServiceStatusDikerjakan {
+				workload.InProgressJobs++
+			}
+			workload.EstimatedMinutes += job.EstimatedMinutes
+		}
+		workload.EstimatedHours = math.Round(float64(workload.EstimatedMinutes)/60*10) / 10
+		workloads = append(workloads, workload)
+	}
+	return workloads, nil
+}
+
+// estimate is the standard time of the services, the configured default when none has a standard time
+func (u *TechnicianUsecase) estimate(services []repoInterfaces.JobServiceRow) int {
+	minutes := 0
+	for _, service := range services {
+		minutes += service.StandardMinutes * service.Quantity
+	}
+	if minutes <= 0 {
+		return u.jobMinutes
+	}
+	return minutes
+}
+
+// requestedServices returns the service lines of a job, or the services booked with its appointment before the
+// lines are entered
+func requestedServices(services []repoInterfaces.JobServiceRow) []repoInterfaces.JobServiceRow {
+	var lines, booked []repoInterfaces.JobServiceRow
+	for _, service := range services {
+		if service.FromAppointment {
+			booked = append(booked, service)
+		} else {
+			lines = append(lines, service)
+		}
+	}
+	if len(lines) > 0 {
+		return lines
+	}
+	return booked
+}
+
+// serviceCategories returns the distinct categories of the services in order
+func serviceCategories(services []repoInterfaces.JobServiceRow) []uint {
+	categories := []uint{}
+	seen := make(map[uint]bool)
+	for _, service := range services {
+		if !seen[service.ServiceCategoryID] {
+			seen[service.ServiceCategoryID] = true
+			categories = append(categories, service.ServiceCategoryID)
+		}
+	}
+	return categories
+}
+
+// missingCategories returns the required categories that are not among the skills
+func missingCategories(required, skills []uint) []uint {
+	has := make(map[uint]bool, len(skills))
+	for _, categoryID := range skills {
+		has[categoryID] = true
+	}
+	missing := []uint{}
+	for _, categoryID := range required {
+		if !has[categoryID] {
+			missing = append(missing, categoryID)
+		}
+	}
+	return missing
+}
+
+// shiftAt returns the shift of the day and whether now is within it; without shifts a technician is always on shift
+func shiftAt(shifts []models.TechnicianShift, now time.Time) (*models.TechnicianShift, bool) {
+	if len(shifts) == 0 {
+		return nil, true
+	}
+	clock := now.Format("15:04")
+	for i := range shifts {
+		if shifts[i].Weekday == int(now.Weekday()) {
+			return &shifts[i], shifts[i].StartsAt <= clock && clock < shifts[i].EndsAt
+		}
+	}
+	return nil, false
+}
+
+// lessLoaded orders technicians by estimated work, then open jobs, then user ID
+func lessLoaded(a, b interfaces.TechnicianWorkload) bool {
+	if a.EstimatedMinutes != b.EstimatedMinutes {
+		return a.EstimatedMinutes < b.EstimatedMinutes
+	}
+	if a.OpenJobs != b.OpenJobs {
+		return a.OpenJobs < b.OpenJobs
+	}
+	return a.UserID < b.UserID
+}
+
+// openServiceJob tells whether a job in the status still has work for a technician
+func openServiceJob(status models.ServiceStatusEnum) bool {
+	switch status {
+	case models.ServiceStatusAntri, models.ServiceStatusMenungguSparepart, models.ServiceStatusDikerjakan, models.ServiceStatusKomplain:
+		return true
+	}
+	return false
+}
+
+// checkActiveTechnician checks that the user exists and has an active technician profile
+func checkActiveTechnician(ctx context.Context, repo *repository.RepositoryManager, userID uint) error {
+	if _, err := repo.User.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrTechnicianNotFound
+		}
+		return err
+	}
+	profile, err := repo.Technician.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrNotATechnician
+		}
+		return err
+	}
+	if !profile.IsActive {
+		return interfaces.ErrNotATechnician
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package implementations
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/internal/repository"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TrackingUsecase implements the public job tracking
+type TrackingUsecase struct {
+	repo *repository.RepositoryManager
+}
+
+// NewTrackingUsecase creates a new tracking usecase
+func NewTrackingUsecase(repo *repository.RepositoryManager) interfaces.TrackingUsecase {
+	return &TrackingUsecase{repo: repo}
+}
+
+// TrackByToken retrieves the tracking view of the job with the tracking token
+func (u *TrackingUsecase) TrackByToken(ctx context.Context, token string) (*interfaces.ServiceJobTracking, error) {
+	job, err := u.repo.ServiceJob.GetByTrackingToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrTrackingNotFound
+		}
+		return nil, err
+	}
+	return u.tracking(ctx, job)
+}
+
+// TrackByServiceCode retrieves the tracking view of the job with the service code when the customer's phone number
+// ends with the given digits. An unknown code and a wrong number give the same error.
+func (u *TrackingUsecase) TrackByServiceCode(ctx context.Context, req interfaces.TrackServiceJobRequest) (*interfaces.ServiceJobTracking, error) {
+	job, err := u.repo.ServiceJob.GetByServiceCode(ctx, req.ServiceCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrTrackingNotFound
+		}
+		return nil, err
+	}
+
+	// The customer is loaded by its ID, the preloaded relation does not follow customer_id
+	customer, err := u.repo.Customer.GetByID(ctx, job.CustomerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrTrackingNotFound
+		}
+		return nil, err
+	}
+	if !phoneEndsWith(customer.PhoneNumber, req.Phone) {
+		return nil, interfaces.ErrTrackingNotFound
+	}
+	return u.tracking(ctx, job)
+}
+
+// tracking builds the customer view of a job loaded with its details and histories
+func (u *TrackingUsecase) tracking(ctx context.Context, job *models.ServiceJob) (*interfaces.ServiceJobTracking, error) {
+	tracking := &interfaces.ServiceJobTracking{
+		ServiceCode:           job.ServiceCode,
+		TrackingToken:         job.TrackingToken,
+		Status:                job.Status,
+		QueueNumber:           job.QueueNumber,
+		ServiceInDate:         job.ServiceInDate,
+		EstimatedCompletionAt: job.EstimatedCompletionAt,
+		PickedUpDate:          job.PickedUpDate,
+		DownPayment:           job.DownPayment,
+		Timeline:              []interfaces.TrackingStatus{},
+		Items:                 []interfaces.TrackingItem{},
+	}
+
+	vehicle, err := u.repo.CustomerVehicle.GetByID(ctx, job.VehicleID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if vehicle != nil {
+		tracking.PlateNumber = vehicle.PlateNumber
+		tracking.Vehicle = strings.TrimSpace(vehicle.Brand + " " + vehicle.Model)
+	}
+	outlet, err := u.repo.Outlet.GetByID(ctx, job.OutletID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if outlet != nil {
+		tracking.OutletName = outlet.OutletName
+		tracking.OutletPhone = stringValue(outlet.PhoneNumber)
+	}
+
+	histories := make([]models.ServiceJobHistory, 0, len(job.Histories))
+	for _, history := range job.Histories {
+		// Entries without a status only carry staff notes
+		if history.Status != nil {
+			histories = append(histories, history)
+		}
+	}
+	sort.SliceStable(histories, func(i, j int) bool {
+		if !histories[i].ChangedAt.Equal(histories[j].ChangedAt) {
+			return histories[i].ChangedAt.Before(histories[j].ChangedAt)
+		}
+		return histories[i].HistoryID < histories[j].HistoryID
+	})
+	for _, history := range histories {
+		tracking.Timeline = append(tracking.Timeline, interfaces.TrackingStatus{Status: *history.Status, ChangedAt: history.ChangedAt})
+	}
+	if len(tracking.Timeline) == 0 {
+		tracking.Timeline = append(tracking.Timeline, interfaces.TrackingStatus{Status: job.Status, ChangedAt: job.ServiceInDate})
+	}
+
+	var itemsTotal float64
+	for _, detail := range job.ServiceDetails {
+		subtotal := detail.PricePerItem * float64(detail.Quantity)
+		itemsTotal += subtotal
+		tracking.Items = append(tracking.Items, interfaces.TrackingItem{
+			Description:  detail.Description,
+			Quantity:     detail.Quantity,
+			PricePerItem: detail.PricePerItem,
+			Subtotal:     subtotal,
+		})
+	}
+
+	// The grand total is set once the totals are calculated, until then the lines are what is charged
+	tracking.GrandTotal = job.GrandTotal
+	if tracking.GrandTotal == 0 {
+		tracking.GrandTotal = itemsTotal
+	}
+	if balance := tracking.GrandTotal - job.DownPayment; balance > 0 {
+		tracking.BalanceDue = balance
+	}
+	return tracking, nil
+}
+
+// phoneEndsWith reports whether the digits of phone end with digits, compared in constant time
+func phoneEndsWith(phone, digits string) bool {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	number := b.String()
+	if len(digits) == 0 || len(number) < len(digits) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(number[len(number)-len(digits):]), []byte(digits)) == 1
+}
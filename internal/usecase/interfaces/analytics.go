@@ -0,0 +1,216 @@
+package interfaces
+
+import (
+	"context"
+)
+
+// Analytics request structures, dates are whole days (YYYY-MM-DD) in the shop's time zone and both inclusive.
+// Without dates the current month up to today is used.
+type SalesAnalyticsRequest struct {
+	StartDate string `query:"start_date" json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `query:"end_date" json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	OutletID  *uint  `query:"outlet_id" json:"outlet_id"`
+	Period    string `query:"period" json:"period" validate:"omitempty,oneof=day week month"`
+	GroupBy   string `query:"group_by" json:"group_by" validate:"omitempty,oneof=outlet cashier payment_method"`
+}
+
+type TopItemsRequest struct {
+	StartDate string `query:"start_date" json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `query:"end_date" json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	OutletID  *uint  `query:"outlet_id" json:"outlet_id"`
+	By        string `query:"by" json:"by" validate:"omitempty,oneof=quantity margin"`
+	Limit     int    `query:"limit" json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// TechnicianPerformanceRequest groups the service jobs completed in the range by technician (default) or outlet
+type TechnicianPerformanceRequest struct {
+	StartDate string `query:"start_date" json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `query:"end_date" json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	OutletID  *uint  `query:"outlet_id" json:"outlet_id"`
+	GroupBy   string `query:"group_by" json:"group_by" validate:"omitempty,oneof=technician outlet"`
+}
+
+// LabourEfficiencyRequest groups the clocked labour of the range by technician (default) or service
+type LabourEfficiencyRequest struct {
+	StartDate string `query:"start_date" json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `query:"end_date" json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	OutletID  *uint  `query:"outlet_id" json:"outlet_id"`
+	GroupBy   string `query:"group_by" json:"group_by" validate:"omitempty,oneof=technician service"`
+}
+
+// StockAnalysisRequest analyses the stock against the consumption of the range, the last 90 days when no dates are
+// given. Products are dead when they were not sold or used for DeadDays (default 180) and slow when their yearly
+// turnover is below MinTurnover (default 4); Class and ABC only filter the listed products.
+type StockAnalysisRequest struct {
+	StartDate   string  `query:"start_date" json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate     string  `query:"end_date" json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	DeadDays    int     `query:"dead_days" json:"dead_days" validate:"omitempty,min=1,max=3650"`
+	MinTurnover float64 `query:"min_turnover" json:"min_turnover" validate:"omitempty,gt=0"`
+	Class       string  `query:"class" json:"class" validate:"omitempty,oneof=fast slow dead"`
+	ABC         string  `query:"abc" json:"abc" validate:"omitempty,oneof=A B C"`
+}
+
+// SalesFigures are the figures of one bucket; Period and the dimension are only set when grouped by them
+type SalesFigures struct {
+	Period           string  `json:"period,omitempty"`
+	DimensionID      *uint   `json:"dimension_id,omitempty"`
+	DimensionName    string  `json:"dimension_name,omitempty"`
+	Revenue          float64 `json:"revenue"`
+	COGS             float64 `json:"cogs"`
+	GrossMargin      float64 `json:"gross_margin"`
+	MarginPercent    float64 `json:"margin_percent"`
+	TransactionCount int64   `json:"transaction_count"`
+	AverageTicket    float64 `json:"average_ticket"`
+}
+
+// SalesAnalytics is the sales summary of a date range; Totals cover all successful transactions of the range
+type SalesAnalytics struct {
+	StartDate string         `json:"start_date"`
+	EndDate   string         `json:"end_date"`
+	Period    string         `json:"period,omitempty"`
+	GroupBy   string         `json:"group_by,omitempty"`
+	Rows      []SalesFigures `json:"rows"`
+	Totals    SalesFigures   `json:"totals"`
+}
+
+// TopItem is a product or service in a top sellers ranking
+type TopItem struct {
+	Rank          int     `json:"rank"`
+	ItemID        uint    `json:"item_id"`
+	Name          string  `json:"name"`
+	Quantity      int64   `json:"quantity"`
+	Revenue       float64 `json:"revenue"`
+	COGS          float64 `json:"cogs"`
+	GrossMargin   float64 `json:"gross_margin"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+// TopItems is a top sellers ranking of a date range
+type TopItems struct {
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+	By        string    `json:"by"`
+	Items     []TopItem `json:"items"`
+}
+
+// PerformanceFigures are the workshop figures of one technician or outlet; jobs without a technician have no
+// dimension. Queue and repair times are averaged in minutes over the jobs that recorded both statuses, a comeback
+// is a complaint or another visit of the vehicle before the warranty of the job expired.
+type PerformanceFigures struct {
+	DimensionID      *uint   `json:"dimension_id,omitempty"`
+	DimensionName    string  `json:"dimension_name,omitempty"`
+	JobsCompleted    int64   `json:"jobs_completed"`
+	AvgQueueMinutes  float64 `json:"avg_queue_minutes"`
+	AvgRepairMinutes float64 `json:"avg_repair_minutes"`
+	Comebacks        int64   `json:"comebacks"`
+	ComebackRate     float64 `json:"comeback_rate"`
+	Revenue          float64 `json:"revenue"`
+	Commission       float64 `json:"commission"`
+}
+
+// TechnicianPerformance is the workshop performance of a date range, a job counts in the range it was first completed in
+type TechnicianPerformance struct {
+	StartDate string               `json:"start_date"`
+	EndDate   string               `json:"end_date"`
+	GroupBy   string               `json:"group_by"`
+	Rows      []PerformanceFigures `json:"rows"`
+	Totals    PerformanceFigures   `json:"totals"`
+}
+
+// LabourFigures are the labour figures of one technician or service in minutes. Clocked and paused time count in
+// the range the entry started in, pauses by reason; a service line counts in the range it was finished in, with all
+// the time clocked on it and its standard time shared among its technicians by their clocked time. Efficiency is
+// the standard time as a percentage of the time clocked on the finished lines.
+type LabourFigures struct {
+	DimensionID       *uint              `json:"dimension_id,omitempty"`
+	DimensionName     string             `json:"dimension_name,omitempty"`
+	ClockedMinutes    float64            `json:"clocked_minutes"`
+	PausedMinutes     map[string]float64 `json:"paused_minutes"`
+	LinesFinished     int64              `json:"lines_finished"`
+	LineMinutes       float64            `json:"line_minutes"`
+	StandardMinutes   float64            `json:"standard_minutes"`
+	EfficiencyPercent float64            `json:"efficiency_percent"`
+	Commission        float64            `json:"commission"`
+}
+
+// LabourEfficiency is the clocked labour of a date range against the standard time of the services
+type LabourEfficiency struct {
+	StartDate       string          `json:"start_date"`
+	EndDate         string          `json:"end_date"`
+	GroupBy         string          `json:"group_by"`
+	CommissionBasis string          `json:"commission_basis"`
+	Rows            []LabourFigures `json:"rows"`
+	Totals          LabourFigures   `json:"totals"`
+}
+
+// Stock movement classes
+const (
+	StockClassFast = "fast"
+	StockClassSlow = "slow"
+	StockClassDead = "dead"
+)
+
+// ProductStockAnalysis is the stock of a product against its consumption, sales and parts used on service jobs, in
+// the range. LastMoved is the last day it was sold or used, its creation day when it never was. Turnover is the
+// yearly consumption value over the capital tied up and DaysOnHand the days the stock lasts at the average daily
+// consumption; both are nil when they cannot be computed. ABC ranks the products by consumption value: A covers the
+// first 80% of it, B the next 15% and C the rest.
+type ProductStockAnalysis struct {
+	ProductID        uint     `json:"product_id"`
+	ProductName      string   `json:"product_name"`
+	SKU              *string  `json:"sku"`
+	IsActive         bool     `json:"is_active"`
+	CategoryID       *uint    `json:"category_id"`
+	CategoryName     *string  `json:"category_name"`
+	SupplierID       *uint    `json:"supplier_id"`
+	SupplierName     *string  `json:"supplier_name"`
+	Stock            int64    `json:"stock"`
+	CostPrice        float64  `json:"cost_price"`
+	CapitalTiedUp    float64  `json:"capital_tied_up"`
+	LastMoved        string   `json:"last_moved"`
+	DaysSinceMoved   int      `json:"days_since_moved"`
+	QuantityConsumed int64    `json:"quantity_consumed"`
+	ConsumptionValue float64  `json:"consumption_value"`
+	Turnover         *float64 `json:"turnover"`
+	DaysOnHand       *float64 `json:"days_on_hand"`
+	Class            string   `json:"class"`
+	ABC              string   `json:"abc"`
+}
+
+// StockGroupFigures sum the products of a category or supplier, the group has no ID for products without one
+type StockGroupFigures struct {
+	ID               *uint    `json:"id,omitempty"`
+	Name             string   `json:"name,omitempty"`
+	Products         int64    `json:"products"`
+	Fast             int64    `json:"fast"`
+	Slow             int64    `json:"slow"`
+	Dead             int64    `json:"dead"`
+	Stock            int64    `json:"stock"`
+	CapitalTiedUp    float64  `json:"capital_tied_up"`
+	SlowCapital      float64  `json:"slow_capital"`
+	DeadCapital      float64  `json:"dead_capital"`
+	ConsumptionValue float64  `json:"consumption_value"`
+	DaysOnHand       *float64 `json:"days_on_hand"`
+}
+
+// StockAnalysis classifies the products by movement and consumption value; Products is sorted by capital tied up
+type StockAnalysis struct {
+	StartDate   string                 `json:"start_date"`
+	EndDate     string                 `json:"end_date"`
+	DeadDays    int                    `json:"dead_days"`
+	MinTurnover float64                `json:"min_turnover"`
+	Totals      StockGroupFigures      `json:"totals"`
+	Categories  []StockGroupFigures    `json:"categories"`
+	Suppliers   []StockGroupFigures    `json:"suppliers"`
+	Products    []ProductStockAnalysis `json:"products"`
+}
+
+// Usecase interfaces
+type AnalyticsUsecase interface {
+	SalesSummary(ctx context.Context, req SalesAnalyticsRequest) (*SalesAnalytics, error)
+	TopProducts(ctx context.Context, req TopItemsRequest) (*TopItems, error)
+	TopServices(ctx context.Context, req TopItemsRequest) (*TopItems, error)
+	TechnicianPerformance(ctx context.Context, req TechnicianPerformanceRequest) (*TechnicianPerformance, error)
+	LabourEfficiency(ctx context.Context, req LabourEfficiencyRequest) (*LabourEfficiency, error)
+	StockAnalysis(ctx context.Context, req StockAnalysisRequest) (*StockAnalysis, error)
+}
@@ -0,0 +1,136 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+)
+
+// CreateAppointmentRequest books a vehicle into an outlet; without DurationMinutes the appointment takes the standard
+// time of its services
+type CreateAppointmentRequest struct {
+	OutletID        uint      `json:"outlet_id" validate:"required"`
+	CustomerID      uint      `json:"customer_id" validate:"required"`
+	VehicleID       uint      `json:"vehicle_id" validate:"required"`
+	TechnicianID    *uint     `json:"technician_id,omitempty"`
+	StartAt         time.Time `json:"start_at" validate:"required"`
+	ServiceIDs      []uint    `json:"service_ids,omitempty" validate:"omitempty,max=20,dive,required"`
+	DurationMinutes *int      `json:"duration_minutes,omitempty" validate:"omitempty,min=1,max=1440"`
+	Notes           *string   `json:"notes,omitempty" validate:"omitempty,max=2000"`
+}
+
+// UpdateAppointmentRequest reschedules a Dijadwalkan appointment, fields left out keep their value. Changing the
+// services without a duration recomputes it from their standard time.
+type UpdateAppointmentRequest struct {
+	VehicleID       *uint      `json:"vehicle_id,omitempty"`
+	TechnicianID    *uint      `json:"technician_id,omitempty"`
+	StartAt         *time.Time `json:"start_at,omitempty"`
+	ServiceIDs      *[]uint    `json:"service_ids,omitempty" validate:"omitempty,max=20,dive,required"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty" validate:"omitempty,min=1,max=1440"`
+	Notes           *string    `json:"notes,omitempty" validate:"omitempty,max=2000"`
+}
+
+// CancelAppointmentRequest cancels a Dijadwalkan appointment
+type CancelAppointmentRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=1000"`
+}
+
+// CheckInAppointmentRequest turns an appointment into a service job when the customer arrives
+type CheckInAppointmentRequest struct {
+	ReceivedByUserID uint `json:"received_by_user_id" validate:"required"`
+}
+
+// AvailableSlotsQuery asks for the free slots of an outlet on a day; without duration the config default is used
+type AvailableSlotsQuery struct {
+	Date            string `query:"date" json:"date" validate:"required,datetime=2006-01-02"`
+	DurationMinutes int    `query:"duration" json:"duration" validate:"omitempty,min=1,max=1440"`
+	TechnicianID    *uint  `query:"technician_id" json:"technician_id"`
+}
+
+// AppointmentCalendarQuery asks for the days of an outlet in [from, to], at most 62 days
+type AppointmentCalendarQuery struct {
+	OutletID uint   `query:"outlet_id" json:"outlet_id" validate:"required"`
+	From     string `query:"from" json:"from" validate:"required,datetime=2006-01-02"`
+	To       string `query:"to" json:"to" validate:"required,datetime=2006-01-02"`
+}
+
+// AppointmentSlot is a slot an appointment of the asked duration could start in
+type AppointmentSlot struct {
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	FreeBays  int       `json:"free_bays"` // bays free for the whole duration
+	Available bool      `json:"available"` // a bay and the asked technician are free
+}
+
+// AppointmentDay is a day of an outlet: its hours, its slots or its appointments
+type AppointmentDay struct {
+	Date         string                `json:"date"`
+	Open         bool                  `json:"open"`
+	HolidayName  string                `json:"holiday_name,omitempty"`
+	OpensAt      string                `json:"opens_at,omitempty"`
+	ClosesAt     string                `json:"closes_at,omitempty"`
+	BayCount     int                   `json:"bay_count"`
+	Slots        []AppointmentSlot     `json:"slots,omitempty"`
+	Appointments []*models.Appointment `json:"appointments,omitempty"`
+}
+
+// OpeningHourRequest is the hours of a day of the week, 0 is Sunday
+type OpeningHourRequest struct {
+	Weekday  int    `json:"weekday" validate:"min=0,max=6"`
+	OpensAt  string `json:"opens_at" validate:"required,datetime=15:04"`
+	ClosesAt string `json:"closes_at" validate:"required,datetime=15:04"`
+}
+
+// SetOpeningHoursRequest replaces the opening hours of an outlet, days left out are closed; no hours at all
+// restore the default hours
+type SetOpeningHoursRequest struct {
+	Hours []OpeningHourRequest `json:"hours" validate:"max=7,dive"`
+}
+
+// CreateHolidayRequest closes a day for an outlet, or for all outlets without outlet_id
+type CreateHolidayRequest struct {
+	OutletID *uint  `json:"outlet_id,omitempty"`
+	Date     string `json:"date" validate:"required,datetime=2006-01-02"`
+	Name     string `json:"name" validate:"required,min=2,max=255"`
+}
+
+// HolidayQuery filters holidays; from and to default to today and a year ahead
+type HolidayQuery struct {
+	OutletID uint   `query:"outlet_id" json:"outlet_id"`
+	From     string `query:"from" json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To       string `query:"to" json:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// NoShowSummary tells what a no-show run marked
+type NoShowSummary struct {
+	Marked int      // appointments marked Tidak Datang
+	Items  []string // e.g. "#12 2024-01-02 09:00 customer 3"
+}
+
+// Usecase interfaces
+type AppointmentUsecase interface {
+	CreateAppointment(ctx context.Context, req CreateAppointmentRequest) (*models.Appointment, error)
+	GetAppointment(ctx context.Context, id uint) (*models.Appointment, error)
+	ListAppointments(ctx context.Context, q *query.ListQuery) ([]*models.Appointment, int64, error)
+	UpdateAppointment(ctx context.Context, id uint, req UpdateAppointmentRequest) (*models.Appointment, error)
+	CancelAppointment(ctx context.Context, id uint, req CancelAppointmentRequest) (*models.Appointment, error)
+	// MarkNoShow marks a Dijadwalkan appointment Tidak Datang once its start and the grace period passed
+	MarkNoShow(ctx context.Context, id uint) (*models.Appointment, error)
+	// CheckIn marks the appointment Datang and creates its service job ahead of the walk-ins in the queue
+	CheckIn(ctx context.Context, id uint, req CheckInAppointmentRequest) (*models.Appointment, error)
+
+	// GetAvailableSlots lists the slots of an outlet's day with the bays free in them
+	GetAvailableSlots(ctx context.Context, outletID uint, q AvailableSlotsQuery) (*AppointmentDay, error)
+	// GetCalendar lists the days of an outlet with their appointments
+	GetCalendar(ctx context.Context, q AppointmentCalendarQuery) ([]AppointmentDay, error)
+
+	GetOpeningHours(ctx context.Context, outletID uint) ([]models.OutletOpeningHour, error)
+	SetOpeningHours(ctx context.Context, outletID uint, req SetOpeningHoursRequest) ([]models.OutletOpeningHour, error)
+	ListHolidays(ctx context.Context, q HolidayQuery) ([]*models.OutletHoliday, error)
+	CreateHoliday(ctx context.Context, req CreateHolidayRequest) (*models.OutletHoliday, error)
+	DeleteHoliday(ctx context.Context, id uint) error
+
+	// MarkNoShows marks the Dijadwalkan appointments whose start and grace period passed before now
+	MarkNoShows(ctx context.Context, now time.Time) (*NoShowSummary, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+)
+
+// Usecase interfaces
+type AuditLogUsecase interface {
+	GetAuditLog(ctx context.Context, id uint) (*models.AuditLog, error)
+	ListAuditLogs(ctx context.Context, q *query.ListQuery) ([]*models.AuditLog, int64, error)
+}
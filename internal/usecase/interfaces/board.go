@@ -0,0 +1,87 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// Board stream event types besides the models.ServiceJobEvent* types of job changes
+const (
+	// BoardSnapshot carries the whole board, sent first unless the stream resumes after the client's last event
+	BoardSnapshot = "board.snapshot"
+	// BoardHeartbeat is sent on an idle stream to keep it open, it has no ID and no data
+	BoardHeartbeat = "heartbeat"
+)
+
+// ServiceJobEvent is a domain event of the service job usecase: a job of the outlet was created, changed or removed
+type ServiceJobEvent struct {
+	Type         string // one of the models.ServiceJobEvent* types
+	ServiceJobID uint
+	OutletID     uint
+}
+
+// ServiceJobEventPublisher receives the domain events of service jobs
+type ServiceJobEventPublisher interface {
+	PublishServiceJobEvent(ctx context.Context, event ServiceJobEvent) error
+}
+
+// BoardJob is a job as the workshop board shows it to the staff
+type BoardJob struct {
+	ServiceJobID          uint                     `json:"service_job_id"`
+	ServiceCode           string                   `json:"service_code"`
+	QueueNumber           int                      `json:"queue_number"`
+	Priority              int                      `json:"priority"`
+	Status                models.ServiceStatusEnum `json:"status"`
+	PlateNumber           string                   `json:"plate_number"`
+	Vehicle               string                   `json:"vehicle"`
+	CustomerName          string                   `json:"customer_name"`
+	TechnicianID          *uint                    `json:"technician_id"`
+	TechnicianName        string                   `json:"technician_name"`
+	ServiceInDate         time.Time                `json:"service_in_date"`
+	EstimatedCompletionAt *time.Time               `json:"estimated_completion_at"`
+}
+
+// BoardEvent is an event of the board stream of an outlet. Job is the job after the change, of a removed job only
+// its ID; a snapshot carries all Jobs in queue order. Clients pass the ID of the last event they saw to resume.
+type BoardEvent struct {
+	ID       uint       `json:"id"`
+	Type     string     `json:"type"`
+	OutletID uint       `json:"outlet_id"`
+	Job      *BoardJob  `json:"job,omitempty"`
+	Jobs     []BoardJob `json:"jobs,omitempty"`
+	At       time.Time  `json:"at"`
+}
+
+// PublicBoardJob is what the public queue display shows of a job: the queue number, the masked plate number and
+// the status. The ID only tells rows apart.
+type PublicBoardJob struct {
+	ID          uint                     `json:"id"`
+	QueueNumber int                      `json:"queue_number"`
+	PlateNumber string                   `json:"plate_number"`
+	Status      models.ServiceStatusEnum `json:"status"`
+}
+
+// PublicBoardEvent is a board event as the public queue display receives it
+type PublicBoardEvent struct {
+	ID   uint             `json:"id"`
+	Type string           `json:"type"`
+	Job  *PublicBoardJob  `json:"job,omitempty"`
+	Jobs []PublicBoardJob `json:"jobs,omitempty"`
+}
+
+// BoardUsecase keeps the live workshop boards of the outlets, fed by the service job events
+type BoardUsecase interface {
+	ServiceJobEventPublisher
+	// GetBoard returns the jobs on the board of the outlet in queue order
+	GetBoard(ctx context.Context, outletID uint) ([]BoardJob, error)
+	// Subscribe streams the board events of the outlet following lastEventID until ctx is done or the channel is
+	// closed on an error. Without lastEventID, or when the events following it are no longer kept, it starts with a
+	// snapshot.
+	Subscribe(ctx context.Context, outletID uint, lastEventID uint) (<-chan BoardEvent, error)
+	// GetPublicBoard and SubscribePublic are GetBoard and Subscribe for the public queue display
+	GetPublicBoard(ctx context.Context, outletID uint) ([]PublicBoardJob, error)
+	SubscribePublic(ctx context.Context, outletID uint, lastEventID uint) (<-chan PublicBoardEvent, error)
+	// PruneEvents deletes the events older than the retention and returns how many
+	PruneEvents(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,95 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+)
+
+// Costing request structures
+type StockReceiptRequest struct {
+	OutletID   uint               `json:"outlet_id" validate:"required"`
+	SupplierID *uint              `json:"supplier_id,omitempty"`
+	Reference  *string            `json:"reference,omitempty" validate:"omitempty,max=100"`
+	CreatedBy  *uint              `json:"created_by,omitempty"`
+	Items      []StockReceiptItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type StockReceiptItem struct {
+	ProductID uint    `json:"product_id" validate:"required"`
+	Quantity  int     `json:"quantity" validate:"required,min=1"`
+	UnitCost  float64 `json:"unit_cost" validate:"min=0"`
+}
+
+type ChangeCostMethodRequest struct {
+	CostMethod models.CostMethod `json:"cost_method" validate:"required,enum"`
+	CreatedBy  *uint             `json:"created_by,omitempty"`
+}
+
+// InventoryValuationRequest values the stock at the end of AsOf (YYYY-MM-DD), today when empty
+type InventoryValuationRequest struct {
+	AsOf     string `query:"as_of" json:"as_of" validate:"omitempty,datetime=2006-01-02"`
+	OutletID *uint  `query:"outlet_id" json:"outlet_id"`
+}
+
+// StockReceipt is a recorded receipt; Stock and AverageCost are the product's figures after the whole receipt
+type StockReceipt struct {
+	OutletID   uint               `json:"outlet_id"`
+	SupplierID *uint              `json:"supplier_id,omitempty"`
+	Reference  *string            `json:"reference,omitempty"`
+	Lines      []StockReceiptLine `json:"lines"`
+}
+
+type StockReceiptLine struct {
+	MovementID  uint    `json:"movement_id"`
+	ProductID   uint    `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    int     `json:"quantity"`
+	UnitCost    float64 `json:"unit_cost"`
+	Stock       int     `json:"stock"`
+	AverageCost float64 `json:"average_cost"`
+}
+
+// CostMethodChange is a switched cost method with the revaluation entries booked for it;
+// Adjustment is the change in value of the stock on hand
+type CostMethodChange struct {
+	ProductID  uint                    `json:"product_id"`
+	From       models.CostMethod       `json:"from"`
+	To         models.CostMethod       `json:"to"`
+	Adjustment float64                 `json:"adjustment"`
+	Movements  []*models.StockMovement `json:"movements"`
+}
+
+// ValuationLine is the stock of one product, UnitCost is its value per unit on hand
+type ValuationLine struct {
+	ProductID   uint              `json:"product_id"`
+	ProductName string            `json:"product_name"`
+	SKU         *string           `json:"sku"`
+	CostMethod  models.CostMethod `json:"cost_method"`
+	Quantity    int64             `json:"quantity"`
+	UnitCost    float64           `json:"unit_cost"`
+	Value       float64           `json:"value"`
+}
+
+// OutletValuation is the stock of one outlet, OutletID is nil for stock not booked to an outlet
+type OutletValuation struct {
+	OutletID   *uint           `json:"outlet_id"`
+	OutletName string          `json:"outlet_name"`
+	Quantity   int64           `json:"quantity"`
+	Value      float64         `json:"value"`
+	Products   []ValuationLine `json:"products"`
+}
+
+type InventoryValuation struct {
+	AsOf     string            `json:"as_of"`
+	Quantity int64             `json:"quantity"`
+	Value    float64           `json:"value"`
+	Outlets  []OutletValuation `json:"outlets"`
+}
+
+// Usecase interfaces
+type CostingUsecase interface {
+	ReceiveStock(ctx context.Context, req StockReceiptRequest) (*StockReceipt, error)
+	ChangeCostMethod(ctx context.Context, productID uint, req ChangeCostMethodRequest) (*CostMethodChange, error)
+	GetCostLayers(ctx context.Context, productID uint) ([]*models.CostLayer, error)
+	InventoryValuation(ctx context.Context, req InventoryValuationRequest) (*InventoryValuation, error)
+}
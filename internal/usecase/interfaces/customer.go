@@ -2,30 +2,33 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 )
 
 // CreateCustomerRequest represents the request to create a customer
 type CreateCustomerRequest struct {
 	Name        string             `json:"name" validate:"required,min=2,max=255"`
-	PhoneNumber string             `json:"phone_number" validate:"required,min=10,max=20"`
+	PhoneNumber string             `json:"phone_number" validate:"required,phone_id"`
+	Email       *string            `json:"email,omitempty" validate:"omitempty,email,max=255"`
 	Address     *string            `json:"address,omitempty"`
-	Status      models.StatusUmum  `json:"status,omitempty"`
+	Status      models.StatusUmum  `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy   *uint              `json:"created_by,omitempty"`
 }
 
 // UpdateCustomerRequest represents the request to update a customer
 type UpdateCustomerRequest struct {
 	Name        *string            `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
-	PhoneNumber *string            `json:"phone_number,omitempty" validate:"omitempty,min=10,max=20"`
+	PhoneNumber *string            `json:"phone_number,omitempty" validate:"omitempty,phone_id"`
+	Email       *string            `json:"email,omitempty" validate:"omitempty,email,max=255"`
 	Address     *string            `json:"address,omitempty"`
-	Status      *models.StatusUmum `json:"status,omitempty"`
+	Status      *models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // CreateCustomerVehicleRequest represents the request to create a customer vehicle
 type CreateCustomerVehicleRequest struct {
 	CustomerID     uint    `json:"customer_id" validate:"required"`
-	PlateNumber    string  `json:"plate_number" validate:"required,min=3,max=20"`
+	PlateNumber    string  `json:"plate_number" validate:"required,plate_id"`
 	Brand          string  `json:"brand" validate:"required,min=2,max=100"`
 	Model          string  `json:"model" validate:"required,min=2,max=100"`
 	Type           string  `json:"type" validate:"required,min=2,max=100"`
@@ -40,7 +43,7 @@ type CreateCustomerVehicleRequest struct {
 // UpdateCustomerVehicleRequest represents the request to update a customer vehicle
 type UpdateCustomerVehicleRequest struct {
 	CustomerID     *uint   `json:"customer_id,omitempty"`
-	PlateNumber    *string `json:"plate_number,omitempty" validate:"omitempty,min=3,max=20"`
+	PlateNumber    *string `json:"plate_number,omitempty" validate:"omitempty,plate_id"`
 	Brand          *string `json:"brand,omitempty" validate:"omitempty,min=2,max=100"`
 	Model          *string `json:"model,omitempty" validate:"omitempty,min=2,max=100"`
 	Type           *string `json:"type,omitempty" validate:"omitempty,min=2,max=100"`
@@ -58,7 +61,7 @@ type CustomerUsecase interface {
 	GetCustomerByPhoneNumber(ctx context.Context, phoneNumber string) (*models.Customer, error)
 	UpdateCustomer(ctx context.Context, id uint, req UpdateCustomerRequest) (*models.Customer, error)
 	DeleteCustomer(ctx context.Context, id uint) error
-	ListCustomers(ctx context.Context, limit, offset int) ([]*models.Customer, error)
+	ListCustomers(ctx context.Context, q *query.ListQuery) ([]*models.Customer, int64, error)
 	GetCustomersByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Customer, error)
 	SearchCustomers(ctx context.Context, query string, limit, offset int) ([]*models.Customer, error)
 }
@@ -72,7 +75,7 @@ type CustomerVehicleUsecase interface {
 	GetCustomerVehicleByEngineNumber(ctx context.Context, engineNumber string) (*models.CustomerVehicle, error)
 	UpdateCustomerVehicle(ctx context.Context, id uint, req UpdateCustomerVehicleRequest) (*models.CustomerVehicle, error)
 	DeleteCustomerVehicle(ctx context.Context, id uint) error
-	ListCustomerVehicles(ctx context.Context, limit, offset int) ([]*models.CustomerVehicle, error)
+	ListCustomerVehicles(ctx context.Context, q *query.ListQuery) ([]*models.CustomerVehicle, int64, error)
 	GetCustomerVehiclesByCustomerID(ctx context.Context, customerID uint) ([]*models.CustomerVehicle, error)
 	SearchCustomerVehicles(ctx context.Context, query string, limit, offset int) ([]*models.CustomerVehicle, error)
 }
\ No newline at end of file
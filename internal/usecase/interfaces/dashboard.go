@@ -0,0 +1,80 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// DashboardRequest limits the dashboard to one outlet, all outlets when OutletID is nil
+type DashboardRequest struct {
+	OutletID *uint `query:"outlet_id" json:"outlet_id"`
+}
+
+// DashboardFigures are the figures of one outlet in one period. Sales are the lines of successful transactions,
+// service revenue the grand total of the service jobs completed in the period and ServiceJobs counts the jobs
+// received in the period by their current status.
+type DashboardFigures struct {
+	Sales          float64                            `json:"sales"`
+	Transactions   int64                              `json:"transactions"`
+	ServiceRevenue float64                            `json:"service_revenue"`
+	JobsCompleted  int64                              `json:"jobs_completed"`
+	ServiceJobs    map[models.ServiceStatusEnum]int64 `json:"service_jobs"`
+	CashIn         float64                            `json:"cash_in"`
+	CashOut        float64                            `json:"cash_out"`
+}
+
+// DashboardChange is the change against the previous period in percent, nil when the previous figure is 0
+type DashboardChange struct {
+	Sales          *float64 `json:"sales"`
+	ServiceRevenue *float64 `json:"service_revenue"`
+	CashIn         *float64 `json:"cash_in"`
+	CashOut        *float64 `json:"cash_out"`
+}
+
+type DashboardPeriod struct {
+	Current  DashboardFigures `json:"current"`
+	Previous DashboardFigures `json:"previous"`
+	Change   DashboardChange  `json:"change"`
+}
+
+// OutstandingTotal counts overdue payables or receivables and sums the amount still open
+type OutstandingTotal struct {
+	Count  int64   `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+// OutletDashboard holds the figures of one outlet, OutletID is nil for cash flows recorded by users without an outlet
+// and for the totals
+type OutletDashboard struct {
+	OutletID           *uint            `json:"outlet_id,omitempty"`
+	OutletName         string           `json:"outlet_name,omitempty"`
+	Today              DashboardPeriod  `json:"today"`
+	Month              DashboardPeriod  `json:"month"`
+	OverduePayables    OutstandingTotal `json:"overdue_payables"`
+	OverdueReceivables OutstandingTotal `json:"overdue_receivables"`
+}
+
+// DashboardRange is a period of the dashboard and the period it is compared with
+type DashboardRange struct {
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	PreviousStart time.Time `json:"previous_start"`
+	PreviousEnd   time.Time `json:"previous_end"`
+}
+
+// Dashboard is the owner dashboard; figures are cached for a short time, GeneratedAt tells when they were computed
+type Dashboard struct {
+	GeneratedAt       time.Time         `json:"generated_at"`
+	Today             DashboardRange    `json:"today"`
+	Month             DashboardRange    `json:"month"`
+	LowStockCount     int64             `json:"low_stock_count"`
+	LowStockThreshold int               `json:"low_stock_threshold"`
+	Outlets           []OutletDashboard `json:"outlets"`
+	Totals            OutletDashboard   `json:"totals"`
+}
+
+// Usecase interfaces
+type DashboardUsecase interface {
+	GetDashboard(ctx context.Context, req DashboardRequest) (*Dashboard, error)
+}
@@ -0,0 +1,128 @@
+package interfaces
+
+import "boilerplate/pkg/exception"
+
+// Domain errors returned by the usecases; handlers answer them through the central error handler
+
+// Not found
+var (
+	ErrAuditLogNotFound            = exception.NotFound("AUDIT_LOG_NOT_FOUND", "audit log not found", "log audit tidak ditemukan")
+	ErrUserNotFound                = exception.NotFound("USER_NOT_FOUND", "user not found", "pengguna tidak ditemukan")
+	ErrRoleNotFound                = exception.NotFound("ROLE_NOT_FOUND", "role not found", "role tidak ditemukan")
+	ErrOutletNotFound              = exception.NotFound("OUTLET_NOT_FOUND", "outlet not found", "outlet tidak ditemukan")
+	ErrCustomerNotFound            = exception.NotFound("CUSTOMER_NOT_FOUND", "customer not found", "pelanggan tidak ditemukan")
+	ErrCustomerVehicleNotFound     = exception.NotFound("CUSTOMER_VEHICLE_NOT_FOUND", "customer vehicle not found", "kendaraan pelanggan tidak ditemukan")
+	ErrVehicleNotFound             = exception.NotFound("VEHICLE_NOT_FOUND", "vehicle not found", "kendaraan tidak ditemukan")
+	ErrProductNotFound             = exception.NotFound("PRODUCT_NOT_FOUND", "product not found", "produk tidak ditemukan")
+	ErrProductSerialNumberNotFound = exception.NotFound("PRODUCT_SERIAL_NUMBER_NOT_FOUND", "product serial number not found", "nomor seri produk tidak ditemukan")
+	ErrSerialNumberNotFound        = exception.NotFound("SERIAL_NUMBER_NOT_FOUND", "serial number not found", "nomor seri tidak ditemukan")
+	ErrCategoryNotFound            = exception.NotFound("CATEGORY_NOT_FOUND", "category not found", "kategori tidak ditemukan")
+	ErrSupplierNotFound            = exception.NotFound("SUPPLIER_NOT_FOUND", "supplier not found", "supplier tidak ditemukan")
+	ErrUnitTypeNotFound            = exception.NotFound("UNIT_TYPE_NOT_FOUND", "unit type not found", "satuan tidak ditemukan")
+	ErrServiceNotFound             = exception.NotFound("SERVICE_NOT_FOUND", "service not found", "jasa servis tidak ditemukan")
+	ErrServiceCategoryNotFound     = exception.NotFound("SERVICE_CATEGORY_NOT_FOUND", "service category not found", "kategori servis tidak ditemukan")
+	ErrServiceJobNotFound          = exception.NotFound("SERVICE_JOB_NOT_FOUND", "service job not found", "pekerjaan servis tidak ditemukan")
+	ErrServiceDetailNotFound       = exception.NotFound("SERVICE_DETAIL_NOT_FOUND", "service detail not found", "detail servis tidak ditemukan")
+	ErrServiceJobHistoryNotFound   = exception.NotFound("SERVICE_JOB_HISTORY_NOT_FOUND", "service job history not found", "riwayat servis tidak ditemukan")
+	ErrTechnicianNotFound          = exception.NotFound("TECHNICIAN_NOT_FOUND", "technician not found", "teknisi tidak ditemukan")
+	ErrReceivedByUserNotFound      = exception.NotFound("RECEIVED_BY_USER_NOT_FOUND", "received by user not found", "pengguna penerima tidak ditemukan")
+	ErrReportNotFound              = exception.NotFound("REPORT_NOT_FOUND", "report not found", "laporan tidak ditemukan")
+	ErrReportFileNotFound          = exception.NotFound("REPORT_FILE_NOT_FOUND", "generated report file not found", "file laporan tidak ditemukan")
+	ErrPurchaseOrderNotFound       = exception.NotFound("PURCHASE_ORDER_NOT_FOUND", "purchase order not found", "purchase order tidak ditemukan")
+	ErrStockLevelNotFound          = exception.NotFound("STOCK_LEVEL_NOT_FOUND", "stock level of the product at this outlet not found", "stok minimum produk di outlet ini tidak ditemukan")
+	ErrJobNotFound                 = exception.NotFound("JOB_NOT_FOUND", "scheduled job not found", "job terjadwal tidak ditemukan")
+	ErrJobRunNotFound              = exception.NotFound("JOB_RUN_NOT_FOUND", "job run not found", "riwayat job tidak ditemukan")
+	ErrNotificationNotFound        = exception.NotFound("NOTIFICATION_NOT_FOUND", "notification not found", "notifikasi tidak ditemukan")
+	ErrTrackingNotFound            = exception.NotFound("TRACKING_NOT_FOUND", "no service job matches the tracking details", "data servis tidak ditemukan, periksa kembali kode servis dan nomor telepon")
+	ErrEstimateNotFound            = exception.NotFound("ESTIMATE_NOT_FOUND", "estimate not found", "estimasi biaya tidak ditemukan")
+	ErrAppointmentNotFound         = exception.NotFound("APPOINTMENT_NOT_FOUND", "appointment not found", "booking servis tidak ditemukan")
+	ErrHolidayNotFound             = exception.NotFound("HOLIDAY_NOT_FOUND", "holiday not found", "hari libur tidak ditemukan")
+	ErrSpecialOrderNotFound        = exception.NotFound("SPECIAL_ORDER_NOT_FOUND", "special order not found", "pesanan khusus sparepart tidak ditemukan")
+)
+
+// Conflicts
+var (
+	ErrEmailExists               = exception.Conflict("EMAIL_EXISTS", "email already exists", "email sudah terdaftar")
+	ErrRoleNameExists            = exception.Conflict("ROLE_NAME_EXISTS", "role with this name already exists", "role dengan nama ini sudah ada")
+	ErrPermissionNameExists      = exception.Conflict("PERMISSION_NAME_EXISTS", "permission with this name already exists", "permission dengan nama ini sudah ada")
+	ErrJobRunning                = exception.Conflict("JOB_RUNNING", "job is already running", "job sedang berjalan")
+	ErrCustomerPhoneExists       = exception.Conflict("CUSTOMER_PHONE_EXISTS", "customer with this phone number already exists", "pelanggan dengan nomor telepon ini sudah ada")
+	ErrVehiclePlateExists        = exception.Conflict("VEHICLE_PLATE_EXISTS", "vehicle with this plate number already exists", "kendaraan dengan nomor polisi ini sudah ada")
+	ErrVehicleChassisExists      = exception.Conflict("VEHICLE_CHASSIS_EXISTS", "vehicle with this chassis number already exists", "kendaraan dengan nomor rangka ini sudah ada")
+	ErrVehicleEngineExists       = exception.Conflict("VEHICLE_ENGINE_EXISTS", "vehicle with this engine number already exists", "kendaraan dengan nomor mesin ini sudah ada")
+	ErrProductSKUExists          = exception.Conflict("PRODUCT_SKU_EXISTS", "product with this SKU already exists", "produk dengan SKU ini sudah ada")
+	ErrProductBarcodeExists      = exception.Conflict("PRODUCT_BARCODE_EXISTS", "product with this barcode already exists", "produk dengan barcode ini sudah ada")
+	ErrSerialNumberExists        = exception.Conflict("SERIAL_NUMBER_EXISTS", "serial number already exists", "nomor seri sudah ada")
+	ErrCategoryNameExists        = exception.Conflict("CATEGORY_NAME_EXISTS", "category with this name already exists", "kategori dengan nama ini sudah ada")
+	ErrSupplierNameExists        = exception.Conflict("SUPPLIER_NAME_EXISTS", "supplier with this name already exists", "supplier dengan nama ini sudah ada")
+	ErrUnitTypeNameExists        = exception.Conflict("UNIT_TYPE_NAME_EXISTS", "unit type with this name already exists", "satuan dengan nama ini sudah ada")
+	ErrServiceCodeExists         = exception.Conflict("SERVICE_CODE_EXISTS", "service with this service code already exists", "jasa servis dengan kode ini sudah ada")
+	ErrServiceCategoryNameExists = exception.Conflict("SERVICE_CATEGORY_NAME_EXISTS", "service category with this name already exists", "kategori servis dengan nama ini sudah ada")
+	ErrPaymentMethodNameExists   = exception.Conflict("PAYMENT_METHOD_NAME_EXISTS", "payment method with this name already exists", "metode pembayaran dengan nama ini sudah ada")
+	ErrReportNameExists          = exception.Conflict("REPORT_NAME_EXISTS", "report with this name already exists", "laporan dengan nama ini sudah ada")
+	ErrAppointmentSlotFull       = exception.Conflict("APPOINTMENT_SLOT_FULL", "no bay of the outlet is free at this time", "tidak ada bay kosong di outlet pada waktu ini")
+	ErrTechnicianUnavailable     = exception.Conflict("TECHNICIAN_UNAVAILABLE", "the technician has another appointment at this time", "teknisi sudah memiliki booking lain pada waktu ini")
+	ErrLabourClockedIn           = exception.Conflict("LABOUR_CLOCKED_IN", "the technician is clocked in on another job", "teknisi sedang mengerjakan servis lain")
+)
+
+// Validation
+var (
+	ErrInvalidOldPassword        = exception.Validation("INVALID_OLD_PASSWORD", "invalid old password", "password lama tidak sesuai")
+	ErrReportUserRequired        = exception.Validation("REPORT_USER_REQUIRED", "user_id is required when the request is not authenticated", "user_id wajib diisi jika permintaan tidak terautentikasi")
+	ErrReportPeriodTooLong       = exception.Validation("REPORT_PERIOD_TOO_LONG", "report period cannot be longer than one year", "periode laporan tidak boleh lebih dari satu tahun")
+	ErrProductImageTooLarge      = exception.Validation("PRODUCT_IMAGE_TOO_LARGE", "product image exceeds the maximum upload size", "gambar produk melebihi ukuran unggahan maksimum")
+	ErrAnalyticsDateRange        = exception.Validation("ANALYTICS_DATE_RANGE", "end_date cannot be before start_date", "end_date tidak boleh sebelum start_date")
+	ErrReportOutletNotSupported  = exception.Validation("REPORT_OUTLET_NOT_SUPPORTED", "this report type covers all outlets and cannot be filtered by outlet", "jenis laporan ini mencakup semua outlet dan tidak dapat difilter per outlet")
+	ErrProductImageType          = exception.Validation("PRODUCT_IMAGE_TYPE", "product image must be a JPEG, PNG or WebP image", "gambar produk harus berupa gambar JPEG, PNG atau WebP")
+	ErrAppointmentInPast         = exception.Validation("APPOINTMENT_IN_PAST", "appointments cannot start in the past", "booking tidak dapat dibuat untuk waktu yang sudah lewat")
+	ErrAppointmentTooFarAhead    = exception.Validation("APPOINTMENT_TOO_FAR_AHEAD", "the appointment is further ahead than bookings are taken", "booking melebihi batas hari pemesanan ke depan")
+	ErrAppointmentSlotInvalid    = exception.Validation("APPOINTMENT_SLOT_INVALID", "appointments start at the beginning of a slot", "booking harus dimulai di awal slot waktu")
+	ErrVehicleNotOwnedByCustomer = exception.Validation("VEHICLE_NOT_OWNED_BY_CUSTOMER", "the vehicle does not belong to the customer", "kendaraan bukan milik pelanggan ini")
+	ErrOpeningHoursInvalid       = exception.Validation("OPENING_HOURS_INVALID", "opening hours must be HH:MM with the closing time after the opening time, once per day", "jam buka harus berformat HH:MM dengan jam tutup setelah jam buka, satu kali per hari")
+	ErrCalendarRangeInvalid      = exception.Validation("CALENDAR_RANGE_INVALID", "to must be after from and the range cannot be longer than 62 days", "to harus setelah from dan rentang tidak boleh lebih dari 62 hari")
+	ErrTechnicianShiftsInvalid   = exception.Validation("TECHNICIAN_SHIFTS_INVALID", "shifts must be HH:MM with the end after the start, once per day", "shift harus berformat HH:MM dengan jam selesai setelah jam mulai, satu kali per hari")
+	ErrLabourTechnicianRequired  = exception.Validation("LABOUR_TECHNICIAN_REQUIRED", "technician_id is required when the request is not authenticated", "technician_id wajib diisi jika permintaan tidak terautentikasi")
+	ErrDateInvalid               = exception.Validation("DATE_INVALID", "dates must be valid dates written as YYYY-MM-DD", "tanggal harus berupa tanggal yang valid dengan format YYYY-MM-DD")
+	ErrLabourLineInvalid         = exception.Validation("LABOUR_LINE_INVALID", "detail_id is not a service line of the job", "detail_id bukan baris jasa dari servis ini")
+)
+
+// Business rules
+var (
+	ErrCustomerHasVehicles        = exception.BusinessRule("CUSTOMER_HAS_VEHICLES", "cannot delete customer with existing vehicles", "pelanggan yang masih memiliki kendaraan tidak dapat dihapus")
+	ErrCategoryHasProducts        = exception.BusinessRule("CATEGORY_HAS_PRODUCTS", "cannot delete category with existing products", "kategori yang masih memiliki produk tidak dapat dihapus")
+	ErrSupplierHasProducts        = exception.BusinessRule("SUPPLIER_HAS_PRODUCTS", "cannot delete supplier with existing products", "supplier yang masih memiliki produk tidak dapat dihapus")
+	ErrServiceCategoryHasServices = exception.BusinessRule("SERVICE_CATEGORY_HAS_SERVICES", "cannot delete service category with existing services", "kategori servis yang masih memiliki jasa servis tidak dapat dihapus")
+	ErrReportNotReady             = exception.BusinessRule("REPORT_NOT_READY", "report has not been generated yet", "laporan belum selesai dibuat")
+	ErrReportNotFailed            = exception.BusinessRule("REPORT_NOT_FAILED", "only failed reports can be retried", "hanya laporan yang gagal yang dapat diulang")
+	ErrProductCostPriceManaged    = exception.BusinessRule("PRODUCT_COST_PRICE_MANAGED", "cost price of a product with stock follows its stock receipts", "harga pokok produk yang memiliki stok mengikuti penerimaan stok")
+	ErrCostMethodUnchanged        = exception.BusinessRule("COST_METHOD_UNCHANGED", "product already uses this cost method", "produk sudah menggunakan metode biaya ini")
+	ErrPurchaseOrderNotDraft      = exception.BusinessRule("PURCHASE_ORDER_NOT_DRAFT", "only draft purchase orders can be changed", "hanya purchase order draft yang dapat diubah")
+	ErrNotificationNotFailed      = exception.BusinessRule("NOTIFICATION_NOT_FAILED", "only failed notifications can be retried", "hanya notifikasi yang gagal yang dapat diulang")
+	ErrEstimateJobClosed          = exception.BusinessRule("ESTIMATE_JOB_CLOSED", "estimates cannot be added to a job that was picked up", "estimasi tidak dapat ditambahkan ke servis yang sudah diambil")
+	ErrEstimateNotPending         = exception.BusinessRule("ESTIMATE_NOT_PENDING", "estimate was already decided or replaced by a newer revision", "estimasi sudah diputuskan atau digantikan revisi yang lebih baru")
+	ErrEstimateRequiresCall       = exception.BusinessRule("ESTIMATE_REQUIRES_CALL", "the extra work exceeds the call threshold, the customer's approval must be recorded by phone or at the counter", "tambahan pekerjaan melebihi batas, persetujuan pelanggan harus dicatat melalui telepon atau di kasir")
+	ErrEstimateApprovalRequired   = exception.BusinessRule("ESTIMATE_APPROVAL_REQUIRED", "work cannot start before the customer approved an estimate", "pekerjaan tidak dapat dimulai sebelum pelanggan menyetujui estimasi biaya")
+	ErrEstimateExceeded           = exception.BusinessRule("ESTIMATE_EXCEEDED", "the work exceeds the approved estimate, create a new revision for the customer to approve", "pekerjaan melebihi estimasi yang disetujui, buat revisi baru untuk disetujui pelanggan")
+	ErrOutletClosed               = exception.BusinessRule("OUTLET_CLOSED", "the outlet is closed on this day", "outlet tutup pada hari ini")
+	ErrAppointmentOutsideHours    = exception.BusinessRule("APPOINTMENT_OUTSIDE_HOURS", "the appointment does not fit within the outlet's opening hours", "booking tidak sesuai dengan jam buka outlet")
+	ErrAppointmentNotScheduled    = exception.BusinessRule("APPOINTMENT_NOT_SCHEDULED", "only scheduled appointments can be changed, checked in or cancelled", "hanya booking yang masih dijadwalkan yang dapat diubah, diproses atau dibatalkan")
+	ErrAppointmentNoShowLimit     = exception.BusinessRule("APPOINTMENT_NO_SHOW_LIMIT", "the customer missed too many recent appointments to book another", "pelanggan terlalu sering tidak datang sehingga belum dapat booking lagi")
+	ErrAppointmentNotDue          = exception.BusinessRule("APPOINTMENT_NOT_DUE", "an appointment is a no-show only after its start and the grace period", "booking baru dapat dinyatakan tidak datang setelah waktu mulai dan masa tenggang")
+	ErrNotATechnician             = exception.BusinessRule("NOT_A_TECHNICIAN", "the user is not an active technician", "pengguna bukan teknisi aktif")
+	ErrTechnicianNotQualified     = exception.BusinessRule("TECHNICIAN_NOT_QUALIFIED", "the technician has no skill for some requested services", "teknisi tidak memiliki keahlian untuk sebagian jasa yang diminta")
+	ErrTechnicianOtherOutlet      = exception.BusinessRule("TECHNICIAN_OTHER_OUTLET", "the technician works at another outlet", "teknisi bekerja di outlet lain")
+	ErrNoTechnicianAvailable      = exception.BusinessRule("NO_TECHNICIAN_AVAILABLE", "no active technician of the outlet is qualified for the requested services", "tidak ada teknisi aktif di outlet yang memiliki keahlian untuk jasa yang diminta")
+	ErrServiceJobClosed           = exception.BusinessRule("SERVICE_JOB_CLOSED", "technicians are only assigned to and work on jobs that are queued, waiting for parts, in progress or under complaint", "teknisi hanya dapat ditugaskan ke dan mengerjakan servis yang mengantri, menunggu sparepart, dikerjakan atau dikomplain")
+	ErrLabourNotClockedIn         = exception.BusinessRule("LABOUR_NOT_CLOCKED_IN", "the technician is not clocked in on this job", "teknisi tidak sedang mengerjakan servis ini")
+	ErrLabourLineFinished         = exception.BusinessRule("LABOUR_LINE_FINISHED", "the work on this line was finished, only a complaint reopens it", "pekerjaan baris ini sudah selesai, hanya komplain yang dapat membukanya kembali")
+	ErrSpecialOrderJobClosed      = exception.BusinessRule("SPECIAL_ORDER_JOB_CLOSED", "parts are only ordered for jobs that are not finished yet", "sparepart hanya dapat dipesan untuk servis yang belum selesai")
+	ErrSpecialOrderNotCancellable = exception.BusinessRule("SPECIAL_ORDER_NOT_CANCELLABLE", "only requested or reserved special orders can be cancelled, ordered parts follow their purchase order", "hanya pesanan khusus yang diminta atau dicadangkan yang dapat dibatalkan, sparepart yang sudah dipesan mengikuti purchase order-nya")
+	ErrInsufficientStock          = exception.BusinessRule("INSUFFICIENT_STOCK", "the stock on hand does not cover the quantity", "stok yang tersedia tidak mencukupi jumlah yang diminta")
+	ErrStockReserved              = exception.BusinessRule("STOCK_RESERVED", "the stock is reserved for the special orders of other service jobs", "stok sudah dicadangkan untuk pesanan khusus servis lain")
+	ErrPurchaseOrderNotPending    = exception.BusinessRule("PURCHASE_ORDER_NOT_PENDING", "only confirmed purchase orders waiting for delivery can be received", "hanya purchase order yang sudah dikonfirmasi dan menunggu pengiriman yang dapat diterima")
+)
+
+// Forbidden
+var (
+	ErrEstimateLinkInvalid = exception.Forbidden("ESTIMATE_LINK_INVALID", "the estimate link is invalid or has expired", "link estimasi tidak valid atau sudah kedaluwarsa")
+)
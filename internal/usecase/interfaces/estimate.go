@@ -0,0 +1,101 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// Decisions on an estimate
+const (
+	EstimateDecisionApprove = "approve"
+	EstimateDecisionReject  = "reject"
+)
+
+// EstimateItemRequest is a parts (product) or labour (service) line of an estimate
+type EstimateItemRequest struct {
+	ItemType     string  `json:"item_type" validate:"required,oneof=service product"`
+	ItemID       uint    `json:"item_id" validate:"required"`
+	Description  string  `json:"description" validate:"required,min=2,max=255"`
+	Quantity     int     `json:"quantity" validate:"required,min=1"`
+	PricePerItem float64 `json:"price_per_item" validate:"required,gt=0"`
+}
+
+// CreateEstimateRequest creates the next revision of a job's estimate
+type CreateEstimateRequest struct {
+	Items []EstimateItemRequest `json:"items" validate:"required,min=1,dive"`
+	Notes *string               `json:"notes,omitempty" validate:"omitempty,max=2000"`
+}
+
+// EstimateDecisionRequest records a decision the customer gave at the counter or by phone
+type EstimateDecisionRequest struct {
+	Decision     string                 `json:"decision" validate:"required,oneof=approve reject"`
+	Channel      models.EstimateChannel `json:"channel" validate:"required,oneof=counter phone"`
+	CustomerName string                 `json:"customer_name" validate:"required,min=2,max=255"`
+	Note         *string                `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+// EstimateLink is the signature of an approval link
+type EstimateLink struct {
+	Expires   int64  `query:"expires" form:"expires" json:"expires" validate:"required"`
+	Signature string `query:"signature" form:"signature" json:"signature" validate:"required,hexadecimal,len=64"`
+}
+
+// PublicEstimateDecisionRequest is the customer's decision through the approval link, Name is the signature
+type PublicEstimateDecisionRequest struct {
+	Decision string  `json:"decision" form:"decision" validate:"required,oneof=approve reject"`
+	Name     string  `json:"name" form:"name" validate:"required,min=2,max=255"`
+	Note     *string `json:"note,omitempty" form:"note" validate:"omitempty,max=1000"`
+}
+
+// PublicEstimateItem is an estimate line as the customer sees it
+type PublicEstimateItem struct {
+	ItemType     string  `json:"item_type"`
+	Description  string  `json:"description"`
+	Quantity     int     `json:"quantity"`
+	PricePerItem float64 `json:"price_per_item"`
+	Subtotal     float64 `json:"subtotal"`
+}
+
+// PublicEstimate is what the approval link shows: the revision, its job and its decision, no costs or staff
+type PublicEstimate struct {
+	EstimateID      uint                    `json:"estimate_id"`
+	Version         int                     `json:"version"`
+	Status          models.EstimateStatus   `json:"status"`
+	ServiceCode     string                  `json:"service_code"`
+	PlateNumber     string                  `json:"plate_number"`
+	Vehicle         string                  `json:"vehicle"`
+	OutletName      string                  `json:"outlet_name"`
+	OutletPhone     string                  `json:"outlet_phone"`
+	Items           []PublicEstimateItem    `json:"items"`
+	PartsTotal      float64                 `json:"parts_total"`
+	LabourTotal     float64                 `json:"labour_total"`
+	Total           float64                 `json:"total"`
+	ApprovedTotal   float64                 `json:"approved_total"` // approved before this revision, 0 for the first
+	ExtraAmount     float64                 `json:"extra_amount"`
+	RequiresCall    bool                    `json:"requires_call"`
+	Notes           *string                 `json:"notes"`
+	LinkExpiresAt   time.Time               `json:"link_expires_at"`
+	DecidedAt       *time.Time              `json:"decided_at"`
+	DecisionChannel *models.EstimateChannel `json:"decision_channel"`
+	DecidedByName   *string                 `json:"decided_by_name"`
+}
+
+// EstimateUsecase manages the estimate revisions customers approve before work on a job starts or grows
+type EstimateUsecase interface {
+	CreateEstimate(ctx context.Context, serviceJobID uint, req CreateEstimateRequest) (*models.ServiceEstimate, error)
+	ListEstimates(ctx context.Context, serviceJobID uint) ([]*models.ServiceEstimate, error)
+	GetEstimate(ctx context.Context, id uint) (*models.ServiceEstimate, error)
+	// DecideEstimate records a decision given at the counter or by phone
+	DecideEstimate(ctx context.Context, id uint, req EstimateDecisionRequest) (*models.ServiceEstimate, error)
+
+	// GetPublicEstimate and DecidePublicEstimate serve the signed approval link, callers are not authenticated
+	GetPublicEstimate(ctx context.Context, id uint, link EstimateLink) (*PublicEstimate, error)
+	DecidePublicEstimate(ctx context.Context, id uint, link EstimateLink, req PublicEstimateDecisionRequest, ip string) (*PublicEstimate, error)
+
+	// CheckWorkStart fails while the job has no approved estimate, when Estimate.Required is set
+	CheckWorkStart(ctx context.Context, serviceJobID uint) error
+	// CheckWorkCovered fails when the job's lines, with the line excludeDetailID replaced by a line of lineTotal,
+	// exceed the approved estimate, when Estimate.Required is set
+	CheckWorkCovered(ctx context.Context, serviceJobID uint, excludeDetailID uint, lineTotal float64) error
+}
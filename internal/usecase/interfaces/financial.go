@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 	"time"
 )
@@ -11,21 +12,21 @@ import (
 // PaymentMethod request structures
 type CreatePaymentMethodRequest struct {
 	Name      string            `json:"name" validate:"required,min=2,max=100"`
-	Status    models.StatusUmum `json:"status,omitempty"`
+	Status    models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy *uint             `json:"created_by,omitempty"`
 }
 
 type UpdatePaymentMethodRequest struct {
 	Name   *string            `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
-	Status *models.StatusUmum `json:"status,omitempty"`
+	Status *models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // Payment request structures
 type CreatePaymentRequest struct {
 	TransactionID uint                      `json:"transaction_id" validate:"required"`
 	MethodID      uint                      `json:"method_id" validate:"required"`
-	Amount        float64                   `json:"amount" validate:"required,min=0"`
-	Status        models.TransactionStatus  `json:"status,omitempty"`
+	Amount        float64                   `json:"amount" validate:"required,gt=0"`
+	Status        models.TransactionStatus  `json:"status,omitempty" validate:"omitempty,enum"`
 	PaymentDate   *time.Time                `json:"payment_date,omitempty"`
 	CreatedBy     *uint                     `json:"created_by,omitempty"`
 }
@@ -33,8 +34,8 @@ type CreatePaymentRequest struct {
 type UpdatePaymentRequest struct {
 	TransactionID *uint                      `json:"transaction_id,omitempty"`
 	MethodID      *uint                      `json:"method_id,omitempty"`
-	Amount        *float64                   `json:"amount,omitempty" validate:"omitempty,min=0"`
-	Status        *models.TransactionStatus  `json:"status,omitempty"`
+	Amount        *float64                   `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	Status        *models.TransactionStatus  `json:"status,omitempty" validate:"omitempty,enum"`
 	PaymentDate   *time.Time                 `json:"payment_date,omitempty"`
 }
 
@@ -42,8 +43,8 @@ type UpdatePaymentRequest struct {
 type CreateCashFlowRequest struct {
 	UserID      uint                `json:"user_id" validate:"required"`
 	OutletID    uint                `json:"outlet_id" validate:"required"`
-	FlowType    models.CashFlowType `json:"flow_type" validate:"required"`
-	Amount      float64             `json:"amount" validate:"required,min=0"`
+	FlowType    models.CashFlowType `json:"flow_type" validate:"required,enum"`
+	Amount      float64             `json:"amount" validate:"required,gt=0"`
 	Description string              `json:"description" validate:"required,min=2,max=255"`
 	FlowDate    time.Time           `json:"flow_date" validate:"required"`
 	CreatedBy   *uint               `json:"created_by,omitempty"`
@@ -52,21 +53,22 @@ type CreateCashFlowRequest struct {
 type UpdateCashFlowRequest struct {
 	UserID      *uint                `json:"user_id,omitempty"`
 	OutletID    *uint                `json:"outlet_id,omitempty"`
-	FlowType    *models.CashFlowType `json:"flow_type,omitempty"`
-	Amount      *float64             `json:"amount,omitempty" validate:"omitempty,min=0"`
+	FlowType    *models.CashFlowType `json:"flow_type,omitempty" validate:"omitempty,enum"`
+	Amount      *float64             `json:"amount,omitempty" validate:"omitempty,gt=0"`
 	Description *string              `json:"description,omitempty" validate:"omitempty,min=2,max=255"`
 	FlowDate    *time.Time           `json:"flow_date,omitempty"`
 }
 
 // Transaction request structures
 type CreateTransactionRequest struct {
-	InvoiceNumber   string                   `json:"invoice_number" validate:"required,min=3,max=255"`
+	// InvoiceNumber is numbered by the invoice pattern when left out
+	InvoiceNumber   string                   `json:"invoice_number,omitempty" validate:"omitempty,min=3,max=255"`
 	TransactionDate time.Time                `json:"transaction_date" validate:"required"`
 	UserID          uint                     `json:"user_id" validate:"required"`
 	CustomerID      *uint                    `json:"customer_id,omitempty"`
 	OutletID        uint                     `json:"outlet_id" validate:"required"`
 	TransactionType string                   `json:"transaction_type" validate:"required"`
-	Status          models.TransactionStatus `json:"status,omitempty"`
+	Status          models.TransactionStatus `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy       *uint                    `json:"created_by,omitempty"`
 }
 
@@ -77,7 +79,7 @@ type UpdateTransactionRequest struct {
 	CustomerID      *uint                     `json:"customer_id,omitempty"`
 	OutletID        *uint                     `json:"outlet_id,omitempty"`
 	TransactionType *string                   `json:"transaction_type,omitempty"`
-	Status          *models.TransactionStatus `json:"status,omitempty"`
+	Status          *models.TransactionStatus `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // Transaction Detail request structures
@@ -87,8 +89,8 @@ type CreateTransactionDetailRequest struct {
 	ProductID       *uint   `json:"product_id,omitempty"`
 	SerialNumberID  *uint   `json:"serial_number_id,omitempty"`
 	Quantity        int     `json:"quantity" validate:"required,min=1"`
-	UnitPrice       float64 `json:"unit_price" validate:"required,min=0"`
-	TotalPrice      float64 `json:"total_price" validate:"required,min=0"`
+	UnitPrice       float64 `json:"unit_price" validate:"required,gt=0"`
+	TotalPrice      float64 `json:"total_price" validate:"required,gt=0"`
 	CreatedBy       *uint   `json:"created_by,omitempty"`
 }
 
@@ -98,8 +100,8 @@ type UpdateTransactionDetailRequest struct {
 	ProductID       *uint    `json:"product_id,omitempty"`
 	SerialNumberID  *uint    `json:"serial_number_id,omitempty"`
 	Quantity        *int     `json:"quantity,omitempty" validate:"omitempty,min=1"`
-	UnitPrice       *float64 `json:"unit_price,omitempty" validate:"omitempty,min=0"`
-	TotalPrice      *float64 `json:"total_price,omitempty" validate:"omitempty,min=0"`
+	UnitPrice       *float64 `json:"unit_price,omitempty" validate:"omitempty,gt=0"`
+	TotalPrice      *float64 `json:"total_price,omitempty" validate:"omitempty,gt=0"`
 }
 
 // Usecase interfaces
@@ -109,7 +111,7 @@ type PaymentMethodUsecase interface {
 	GetPaymentMethodByName(ctx context.Context, name string) (*models.PaymentMethod, error)
 	UpdatePaymentMethod(ctx context.Context, id uint, req UpdatePaymentMethodRequest) (*models.PaymentMethod, error)
 	DeletePaymentMethod(ctx context.Context, id uint) error
-	ListPaymentMethods(ctx context.Context, limit, offset int) ([]*models.PaymentMethod, error)
+	ListPaymentMethods(ctx context.Context, q *query.ListQuery) ([]*models.PaymentMethod, int64, error)
 	GetPaymentMethodsByStatus(ctx context.Context, status models.StatusUmum) ([]*models.PaymentMethod, error)
 }
 
@@ -118,7 +120,7 @@ type PaymentUsecase interface {
 	GetPayment(ctx context.Context, id uint) (*models.Payment, error)
 	UpdatePayment(ctx context.Context, id uint, req UpdatePaymentRequest) (*models.Payment, error)
 	DeletePayment(ctx context.Context, id uint) error
-	ListPayments(ctx context.Context, limit, offset int) ([]*models.Payment, error)
+	ListPayments(ctx context.Context, q *query.ListQuery) ([]*models.Payment, int64, error)
 	GetPaymentsByTransaction(ctx context.Context, transactionID uint) ([]*models.Payment, error)
 	GetPaymentsByMethod(ctx context.Context, methodID uint) ([]*models.Payment, error)
 	GetPaymentsByStatus(ctx context.Context, status models.TransactionStatus) ([]*models.Payment, error)
@@ -130,10 +132,9 @@ type CashFlowUsecase interface {
 	GetCashFlow(ctx context.Context, id uint) (*models.CashFlow, error)
 	UpdateCashFlow(ctx context.Context, id uint, req UpdateCashFlowRequest) (*models.CashFlow, error)
 	DeleteCashFlow(ctx context.Context, id uint) error
-	ListCashFlows(ctx context.Context, limit, offset int) ([]*models.CashFlow, error)
+	ListCashFlows(ctx context.Context, q *query.ListQuery) ([]*models.CashFlow, int64, error)
 	GetCashFlowsByUser(ctx context.Context, userID uint) ([]*models.CashFlow, error)
 	GetCashFlowsByOutlet(ctx context.Context, outletID uint) ([]*models.CashFlow, error)
-	GetCashFlowsByType(ctx context.Context, flowType models.CashFlowType) ([]*models.CashFlow, error)
 	GetCashFlowsByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.CashFlow, error)
 	GetTotalByTypeAndDateRange(ctx context.Context, flowType models.CashFlowType, startDate, endDate time.Time) (float64, error)
 }
@@ -144,12 +145,9 @@ type TransactionUsecase interface {
 	GetTransactionByInvoiceNumber(ctx context.Context, invoiceNumber string) (*models.Transaction, error)
 	UpdateTransaction(ctx context.Context, id uint, req UpdateTransactionRequest) (*models.Transaction, error)
 	DeleteTransaction(ctx context.Context, id uint) error
-	ListTransactions(ctx context.Context, limit, offset int) ([]*models.Transaction, error)
-	GetTransactionsByCustomer(ctx context.Context, customerID uint) ([]*models.Transaction, error)
+	ListTransactions(ctx context.Context, q *query.ListQuery) ([]*models.Transaction, int64, error)
+	ListTransactionsByCursor(ctx context.Context, q *query.ListQuery) ([]*models.Transaction, string, error)
 	GetTransactionsByUser(ctx context.Context, userID uint) ([]*models.Transaction, error)
-	GetTransactionsByOutlet(ctx context.Context, outletID uint) ([]*models.Transaction, error)
-	GetTransactionsByStatus(ctx context.Context, status models.TransactionStatus) ([]*models.Transaction, error)
-	GetTransactionsByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Transaction, error)
 }
 
 type TransactionDetailUsecase interface {
@@ -157,7 +155,7 @@ type TransactionDetailUsecase interface {
 	GetTransactionDetail(ctx context.Context, id uint) (*models.TransactionDetail, error)
 	UpdateTransactionDetail(ctx context.Context, id uint, req UpdateTransactionDetailRequest) (*models.TransactionDetail, error)
 	DeleteTransactionDetail(ctx context.Context, id uint) error
-	ListTransactionDetails(ctx context.Context, limit, offset int) ([]*models.TransactionDetail, error)
+	ListTransactionDetails(ctx context.Context, q *query.ListQuery) ([]*models.TransactionDetail, int64, error)
 	GetTransactionDetailsByTransaction(ctx context.Context, transactionID uint) ([]*models.TransactionDetail, error)
 	GetTransactionDetailsByProduct(ctx context.Context, productID uint) ([]*models.TransactionDetail, error)
 	DeleteTransactionDetailsByTransaction(ctx context.Context, transactionID uint) error
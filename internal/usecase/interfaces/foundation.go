@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 )
 
@@ -12,9 +13,10 @@ type UserUsecase interface {
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	UpdateUser(ctx context.Context, id uint, req UpdateUserRequest) (*models.User, error)
 	DeleteUser(ctx context.Context, id uint) error
-	ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error)
+	ListUsers(ctx context.Context, q *query.ListQuery) ([]*models.User, int64, error)
 	GetUsersByOutlet(ctx context.Context, outletID uint) ([]*models.User, error)
 	ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) error
+	AssignRoles(ctx context.Context, userID uint, roleIDs []uint) error
 }
 
 // OutletUsecase interface for outlet business logic
@@ -23,7 +25,7 @@ type OutletUsecase interface {
 	GetOutlet(ctx context.Context, id uint) (*models.Outlet, error)
 	UpdateOutlet(ctx context.Context, id uint, req UpdateOutletRequest) (*models.Outlet, error)
 	DeleteOutlet(ctx context.Context, id uint) error
-	ListOutlets(ctx context.Context, limit, offset int) ([]*models.Outlet, error)
+	ListOutlets(ctx context.Context, q *query.ListQuery) ([]*models.Outlet, int64, error)
 	GetActiveOutlets(ctx context.Context) ([]*models.Outlet, error)
 }
 
@@ -34,7 +36,7 @@ type RoleUsecase interface {
 	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
 	UpdateRole(ctx context.Context, id uint, req UpdateRoleRequest) (*models.Role, error)
 	DeleteRole(ctx context.Context, id uint) error
-	ListRoles(ctx context.Context, limit, offset int) ([]*models.Role, error)
+	ListRoles(ctx context.Context, q *query.ListQuery) ([]*models.Role, int64, error)
 	AttachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
 	DetachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
 }
@@ -46,54 +48,56 @@ type PermissionUsecase interface {
 	GetPermissionByName(ctx context.Context, name string) (*models.Permission, error)
 	UpdatePermission(ctx context.Context, id uint, req UpdatePermissionRequest) (*models.Permission, error)
 	DeletePermission(ctx context.Context, id uint) error
-	ListPermissions(ctx context.Context, limit, offset int) ([]*models.Permission, error)
+	ListPermissions(ctx context.Context, q *query.ListQuery) ([]*models.Permission, int64, error)
 	GetPermissionsByRole(ctx context.Context, roleID uint) ([]*models.Permission, error)
 }
 
 // Request structs
 type CreateUserRequest struct {
-	Name     string `json:"name" validate:"required"`
+	Name     string `json:"name" validate:"required,min=2,max=255"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required,min=6,max=72"`
 	OutletID *uint  `json:"outlet_id"`
 }
 
 type UpdateUserRequest struct {
-	Name     *string `json:"name"`
+	Name     *string `json:"name" validate:"omitempty,min=2,max=255"`
 	Email    *string `json:"email" validate:"omitempty,email"`
 	OutletID *uint   `json:"outlet_id"`
 }
 
 type CreateOutletRequest struct {
-	OutletName  string             `json:"outlet_name" validate:"required"`
-	BranchType  string             `json:"branch_type" validate:"required"`
-	City        string             `json:"city" validate:"required"`
+	OutletName  string             `json:"outlet_name" validate:"required,min=2,max=255"`
+	BranchType  string             `json:"branch_type" validate:"required,max=50"`
+	City        string             `json:"city" validate:"required,min=2,max=100"`
 	Address     *string            `json:"address"`
-	PhoneNumber *string            `json:"phone_number"`
-	Status      models.StatusUmum  `json:"status"`
+	PhoneNumber *string            `json:"phone_number" validate:"omitempty,phone_id"`
+	BayCount    int                `json:"bay_count" validate:"omitempty,min=1,max=100"`
+	Status      models.StatusUmum  `json:"status" validate:"omitempty,enum"`
 }
 
 type UpdateOutletRequest struct {
-	OutletName  *string            `json:"outlet_name"`
-	BranchType  *string            `json:"branch_type"`
-	City        *string            `json:"city"`
+	OutletName  *string            `json:"outlet_name" validate:"omitempty,min=2,max=255"`
+	BranchType  *string            `json:"branch_type" validate:"omitempty,max=50"`
+	City        *string            `json:"city" validate:"omitempty,min=2,max=100"`
 	Address     *string            `json:"address"`
-	PhoneNumber *string            `json:"phone_number"`
-	Status      *models.StatusUmum `json:"status"`
+	PhoneNumber *string            `json:"phone_number" validate:"omitempty,phone_id"`
+	BayCount    *int               `json:"bay_count" validate:"omitempty,min=1,max=100"`
+	Status      *models.StatusUmum `json:"status" validate:"omitempty,enum"`
 }
 
 type CreateRoleRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name string `json:"name" validate:"required,min=2,max=255"`
 }
 
 type UpdateRoleRequest struct {
-	Name *string `json:"name"`
+	Name *string `json:"name" validate:"omitempty,min=2,max=255"`
 }
 
 type CreatePermissionRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name string `json:"name" validate:"required,min=2,max=255"`
 }
 
 type UpdatePermissionRequest struct {
-	Name *string `json:"name"`
+	Name *string `json:"name" validate:"omitempty,min=2,max=255"`
 }
\ No newline at end of file
@@ -2,7 +2,9 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
+	"io"
 )
 
 // Product request structures
@@ -10,14 +12,17 @@ type CreateProductRequest struct {
 	ProductName        string                      `json:"product_name" validate:"required,min=2,max=255"`
 	ProductDescription *string                     `json:"product_description,omitempty"`
 	ProductImage       *string                     `json:"product_image,omitempty"`
-	CostPrice          float64                     `json:"cost_price" validate:"required,min=0"`
-	SellingPrice       float64                     `json:"selling_price" validate:"required,min=0"`
-	Stock              int                         `json:"stock" validate:"required,min=0"`
+	CostPrice          float64                     `json:"cost_price" validate:"min=0"`
+	CostMethod         models.CostMethod           `json:"cost_method,omitempty" validate:"omitempty,enum"`
+	SellingPrice       float64                     `json:"selling_price" validate:"required,gt=0"`
+	Stock              int                         `json:"stock" validate:"min=0"`
+	MinStock           int                         `json:"min_stock" validate:"min=0"`
+	ReorderQuantity    int                         `json:"reorder_quantity" validate:"min=0"`
 	SKU                *string                     `json:"sku,omitempty"`
 	Barcode            *string                     `json:"barcode,omitempty"`
 	HasSerialNumber    bool                        `json:"has_serial_number"`
 	ShelfLocation      *string                     `json:"shelf_location,omitempty"`
-	UsageStatus        models.ProductUsageStatus   `json:"usage_status" validate:"required"`
+	UsageStatus        models.ProductUsageStatus   `json:"usage_status" validate:"required,enum"`
 	IsActive           bool                        `json:"is_active"`
 	CategoryID         *uint                       `json:"category_id,omitempty"`
 	SupplierID         *uint                       `json:"supplier_id,omitempty"`
@@ -30,13 +35,15 @@ type UpdateProductRequest struct {
 	ProductDescription *string                     `json:"product_description,omitempty"`
 	ProductImage       *string                     `json:"product_image,omitempty"`
 	CostPrice          *float64                    `json:"cost_price,omitempty" validate:"omitempty,min=0"`
-	SellingPrice       *float64                    `json:"selling_price,omitempty" validate:"omitempty,min=0"`
+	SellingPrice       *float64                    `json:"selling_price,omitempty" validate:"omitempty,gt=0"`
 	Stock              *int                        `json:"stock,omitempty" validate:"omitempty,min=0"`
+	MinStock           *int                        `json:"min_stock,omitempty" validate:"omitempty,min=0"`
+	ReorderQuantity    *int                        `json:"reorder_quantity,omitempty" validate:"omitempty,min=0"`
 	SKU                *string                     `json:"sku,omitempty"`
 	Barcode            *string                     `json:"barcode,omitempty"`
 	HasSerialNumber    *bool                       `json:"has_serial_number,omitempty"`
 	ShelfLocation      *string                     `json:"shelf_location,omitempty"`
-	UsageStatus        *models.ProductUsageStatus  `json:"usage_status,omitempty"`
+	UsageStatus        *models.ProductUsageStatus  `json:"usage_status,omitempty" validate:"omitempty,enum"`
 	IsActive           *bool                       `json:"is_active,omitempty"`
 	CategoryID         *uint                       `json:"category_id,omitempty"`
 	SupplierID         *uint                       `json:"supplier_id,omitempty"`
@@ -47,56 +54,56 @@ type UpdateProductRequest struct {
 type CreateProductSerialNumberRequest struct {
 	ProductID    uint              `json:"product_id" validate:"required"`
 	SerialNumber string            `json:"serial_number" validate:"required,min=3,max=255"`
-	Status       models.SNStatus   `json:"status,omitempty"`
+	Status       models.SNStatus   `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy    *uint             `json:"created_by,omitempty"`
 }
 
 type UpdateProductSerialNumberRequest struct {
 	ProductID    *uint             `json:"product_id,omitempty"`
 	SerialNumber *string           `json:"serial_number,omitempty" validate:"omitempty,min=3,max=255"`
-	Status       *models.SNStatus  `json:"status,omitempty"`
+	Status       *models.SNStatus  `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // Category request structures
 type CreateCategoryRequest struct {
 	Name      string            `json:"name" validate:"required,min=2,max=255"`
-	Status    models.StatusUmum `json:"status,omitempty"`
+	Status    models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy *uint             `json:"created_by,omitempty"`
 }
 
 type UpdateCategoryRequest struct {
 	Name   *string            `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
-	Status *models.StatusUmum `json:"status,omitempty"`
+	Status *models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // Supplier request structures
 type CreateSupplierRequest struct {
 	SupplierName      string            `json:"supplier_name" validate:"required,min=2,max=255"`
 	ContactPersonName string            `json:"contact_person_name" validate:"required,min=2,max=255"`
-	PhoneNumber       string            `json:"phone_number" validate:"required,min=10,max=20"`
+	PhoneNumber       string            `json:"phone_number" validate:"required,phone_id"`
 	Address           *string           `json:"address,omitempty"`
-	Status            models.StatusUmum `json:"status,omitempty"`
+	Status            models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy         *uint             `json:"created_by,omitempty"`
 }
 
 type UpdateSupplierRequest struct {
 	SupplierName      *string            `json:"supplier_name,omitempty" validate:"omitempty,min=2,max=255"`
 	ContactPersonName *string            `json:"contact_person_name,omitempty" validate:"omitempty,min=2,max=255"`
-	PhoneNumber       *string            `json:"phone_number,omitempty" validate:"omitempty,min=10,max=20"`
+	PhoneNumber       *string            `json:"phone_number,omitempty" validate:"omitempty,phone_id"`
 	Address           *string            `json:"address,omitempty"`
-	Status            *models.StatusUmum `json:"status,omitempty"`
+	Status            *models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // Unit Type request structures
 type CreateUnitTypeRequest struct {
 	Name      string            `json:"name" validate:"required,min=1,max=50"`
-	Status    models.StatusUmum `json:"status,omitempty"`
+	Status    models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy *uint             `json:"created_by,omitempty"`
 }
 
 type UpdateUnitTypeRequest struct {
 	Name   *string            `json:"name,omitempty" validate:"omitempty,min=1,max=50"`
-	Status *models.StatusUmum `json:"status,omitempty"`
+	Status *models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // Usecase interfaces
@@ -107,13 +114,31 @@ type ProductUsecase interface {
 	GetProductByBarcode(ctx context.Context, barcode string) (*models.Product, error)
 	UpdateProduct(ctx context.Context, id uint, req UpdateProductRequest) (*models.Product, error)
 	DeleteProduct(ctx context.Context, id uint) error
-	ListProducts(ctx context.Context, limit, offset int) ([]*models.Product, error)
-	GetProductsByCategory(ctx context.Context, categoryID uint) ([]*models.Product, error)
-	GetProductsBySupplier(ctx context.Context, supplierID uint) ([]*models.Product, error)
-	GetProductsByUsageStatus(ctx context.Context, status models.ProductUsageStatus) ([]*models.Product, error)
+	ListProducts(ctx context.Context, q *query.ListQuery) ([]*models.Product, int64, error)
 	SearchProducts(ctx context.Context, query string, limit, offset int) ([]*models.Product, error)
 	UpdateProductStock(ctx context.Context, productID uint, quantity int) error
-	GetLowStockProducts(ctx context.Context, threshold int) ([]*models.Product, error)
+	GetLowStockProducts(ctx context.Context, threshold int, q *query.ListQuery) ([]*models.Product, int64, error)
+	RecalculateStock(ctx context.Context, apply bool) ([]*StockRecalculation, error)
+	UploadProductImage(ctx context.Context, id uint, upload ProductImageUpload) (*models.Product, error)
+	DeleteProductImage(ctx context.Context, id uint) (*models.Product, error)
+}
+
+// ProductImageUpload is an uploaded product image, Size is the size declared by the upload in bytes
+type ProductImageUpload struct {
+	FileName string
+	Size     int64
+	Body     io.Reader
+}
+
+// StockRecalculation describes a product whose stock differs from its stock ledger
+type StockRecalculation struct {
+	ProductID       uint   `json:"product_id"`
+	ProductName     string `json:"product_name"`
+	CurrentStock    int    `json:"current_stock"`
+	CalculatedStock int    `json:"calculated_stock"`
+	Applied         bool   `json:"applied"`
+	// NoOpeningBalance is set when the ledger has no opening entry for the product, its stock is then not corrected
+	NoOpeningBalance bool `json:"no_opening_balance"`
 }
 
 type ProductSerialNumberUsecase interface {
@@ -122,7 +147,7 @@ type ProductSerialNumberUsecase interface {
 	GetProductSerialNumberBySerial(ctx context.Context, serialNumber string) (*models.ProductSerialNumber, error)
 	UpdateProductSerialNumber(ctx context.Context, id uint, req UpdateProductSerialNumberRequest) (*models.ProductSerialNumber, error)
 	DeleteProductSerialNumber(ctx context.Context, id uint) error
-	ListProductSerialNumbers(ctx context.Context, limit, offset int) ([]*models.ProductSerialNumber, error)
+	ListProductSerialNumbers(ctx context.Context, q *query.ListQuery) ([]*models.ProductSerialNumber, int64, error)
 	GetProductSerialNumbersByProduct(ctx context.Context, productID uint) ([]*models.ProductSerialNumber, error)
 	GetProductSerialNumbersByStatus(ctx context.Context, status models.SNStatus) ([]*models.ProductSerialNumber, error)
 	UpdateProductSerialNumberStatus(ctx context.Context, id uint, status models.SNStatus) error
@@ -134,7 +159,7 @@ type CategoryUsecase interface {
 	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
 	UpdateCategory(ctx context.Context, id uint, req UpdateCategoryRequest) (*models.Category, error)
 	DeleteCategory(ctx context.Context, id uint) error
-	ListCategories(ctx context.Context, limit, offset int) ([]*models.Category, error)
+	ListCategories(ctx context.Context, q *query.ListQuery) ([]*models.Category, int64, error)
 	GetCategoriesByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Category, error)
 }
 
@@ -144,7 +169,7 @@ type SupplierUsecase interface {
 	GetSupplierByName(ctx context.Context, name string) (*models.Supplier, error)
 	UpdateSupplier(ctx context.Context, id uint, req UpdateSupplierRequest) (*models.Supplier, error)
 	DeleteSupplier(ctx context.Context, id uint) error
-	ListSuppliers(ctx context.Context, limit, offset int) ([]*models.Supplier, error)
+	ListSuppliers(ctx context.Context, q *query.ListQuery) ([]*models.Supplier, int64, error)
 	GetSuppliersByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Supplier, error)
 	SearchSuppliers(ctx context.Context, query string, limit, offset int) ([]*models.Supplier, error)
 }
@@ -155,6 +180,6 @@ type UnitTypeUsecase interface {
 	GetUnitTypeByName(ctx context.Context, name string) (*models.UnitType, error)
 	UpdateUnitType(ctx context.Context, id uint, req UpdateUnitTypeRequest) (*models.UnitType, error)
 	DeleteUnitType(ctx context.Context, id uint) error
-	ListUnitTypes(ctx context.Context, limit, offset int) ([]*models.UnitType, error)
+	ListUnitTypes(ctx context.Context, q *query.ListQuery) ([]*models.UnitType, int64, error)
 	GetUnitTypesByStatus(ctx context.Context, status models.StatusUmum) ([]*models.UnitType, error)
 }
\ No newline at end of file
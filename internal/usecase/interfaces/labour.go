@@ -0,0 +1,76 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// StartLabourRequest clocks a technician in on a job, on one of its service lines when DetailID is set. Without
+// TechnicianID the authenticated user clocks in.
+type StartLabourRequest struct {
+	TechnicianID *uint   `json:"technician_id,omitempty"`
+	DetailID     *uint   `json:"detail_id,omitempty"`
+	Notes        *string `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// PauseLabourRequest clocks a technician out of a job until the work can go on
+type PauseLabourRequest struct {
+	TechnicianID *uint                    `json:"technician_id,omitempty"`
+	Reason       models.LabourPauseReason `json:"reason" validate:"required,enum"`
+	Notes        *string                  `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// FinishLabourRequest clocks a technician out of a job with the work, or the work on the line, done
+type FinishLabourRequest struct {
+	TechnicianID *uint   `json:"technician_id,omitempty"`
+	Notes        *string `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// LabourLine is a service line of a job with its standard and clocked time in minutes
+type LabourLine struct {
+	DetailID          uint    `json:"detail_id"`
+	ServiceID         uint    `json:"service_id"`
+	Description       string  `json:"description"`
+	Quantity          int     `json:"quantity"`
+	StandardMinutes   float64 `json:"standard_minutes"`
+	ActualMinutes     float64 `json:"actual_minutes"`
+	Finished          bool    `json:"finished"`
+	EfficiencyPercent float64 `json:"efficiency_percent"`
+}
+
+// LabourTechnician is the labour of a technician on a job; standard minutes are the share of the finished lines
+type LabourTechnician struct {
+	TechnicianID    uint    `json:"technician_id"`
+	Name            string  `json:"name"`
+	Working         bool    `json:"working"`
+	ClockedMinutes  float64 `json:"clocked_minutes"`
+	StandardMinutes float64 `json:"standard_minutes"`
+	Commission      float64 `json:"commission"`
+}
+
+// JobLabour is the clocked time of a job against the standard time of its service lines, open entries count until
+// now. Clocked time not on a line is only in the totals.
+type JobLabour struct {
+	ServiceJobID      uint                     `json:"service_job_id"`
+	Status            models.ServiceStatusEnum `json:"status"`
+	ClockedMinutes    float64                  `json:"clocked_minutes"`
+	PausedMinutes     map[string]float64       `json:"paused_minutes"`
+	StandardMinutes   float64                  `json:"standard_minutes"`
+	EfficiencyPercent float64                  `json:"efficiency_percent"`
+	Lines             []LabourLine             `json:"lines"`
+	Technicians       []LabourTechnician       `json:"technicians"`
+	Entries           []*models.LabourEntry    `json:"entries"`
+	At                time.Time                `json:"at"`
+}
+
+// LabourUsecase clocks the work of the technicians on service jobs
+type LabourUsecase interface {
+	// StartWork clocks the technician in; a queued or waiting job starts as Dikerjakan and open pauses of the job or line end
+	StartWork(ctx context.Context, serviceJobID uint, req StartLabourRequest) (*models.LabourEntry, error)
+	// PauseWork clocks the technician out and returns the pause, which lasts until work on the job starts again
+	PauseWork(ctx context.Context, serviceJobID uint, req PauseLabourRequest) (*models.LabourEntry, error)
+	// FinishWork clocks the technician out and marks the line, if any, done
+	FinishWork(ctx context.Context, serviceJobID uint, req FinishLabourRequest) (*models.LabourEntry, error)
+	GetJobLabour(ctx context.Context, serviceJobID uint) (*JobLabour, error)
+}
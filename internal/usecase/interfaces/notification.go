@@ -0,0 +1,55 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+)
+
+// NotificationPreferences tells per channel whether a customer receives notifications through it
+type NotificationPreferences struct {
+	CustomerID uint                                `json:"customer_id"`
+	Channels   map[models.NotificationChannel]bool `json:"channels"`
+}
+
+// UpdateNotificationPreferencesRequest opts a customer in (true) or out (false) of the given channels,
+// channels left out keep their setting
+type UpdateNotificationPreferencesRequest struct {
+	Channels map[models.NotificationChannel]bool `json:"channels" validate:"required,min=1,dive,keys,enum,endkeys"`
+}
+
+// NotificationSummary tells what a reminder trigger found and queued
+type NotificationSummary struct {
+	Due    int      // entries due for a reminder
+	Queued int      // notifications queued for them, reminders queued before and opted out channels are not counted
+	Items  []string // the entries due, e.g. "SJ-001 Budi B 1234 XY"
+}
+
+// Usecase interfaces
+type NotificationUsecase interface {
+	ListNotifications(ctx context.Context, q *query.ListQuery) ([]*models.Notification, int64, error)
+	GetNotification(ctx context.Context, id uint) (*models.Notification, error)
+	// RetryNotification queues a Gagal notification again
+	RetryNotification(ctx context.Context, id uint) (*models.Notification, error)
+	GetPreferences(ctx context.Context, customerID uint) (*NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, customerID uint, req UpdateNotificationPreferencesRequest) (*NotificationPreferences, error)
+
+	// NotifyStatusChange tells the customer that the service job moved to status, for the statuses customers are told about
+	NotifyStatusChange(ctx context.Context, serviceJobID uint, status models.ServiceStatusEnum) error
+	// NotifyEstimate sends the customer the estimate with its approval link, estimate.ApprovalURL must be set
+	NotifyEstimate(ctx context.Context, estimate *models.ServiceEstimate) error
+	// NotifyPartsArrived tells the customer that the parts the job waited for arrived with the purchase order
+	NotifyPartsArrived(ctx context.Context, serviceJobID, purchaseOrderID uint) error
+	// SendServiceReminders queues reminders for the jobs whose next service reminder date is in [from, to)
+	SendServiceReminders(ctx context.Context, from, to time.Time) (*NotificationSummary, error)
+	// SendReceivableReminders queues reminders for unpaid receivables falling due within the reminder days of today
+	SendReceivableReminders(ctx context.Context, today time.Time) (*NotificationSummary, error)
+	// SendPickupReminders queues reminders for finished jobs that waited longer than the pickup reminder days
+	SendPickupReminders(ctx context.Context, now time.Time) (*NotificationSummary, error)
+
+	// ProcessNextNotification delivers the oldest due notification, it reports false when none is due
+	ProcessNextNotification(ctx context.Context) (bool, error)
+	// RequeueStaleNotifications returns notifications abandoned in Diproses to the queue
+	RequeueStaleNotifications(ctx context.Context, olderThan time.Duration) (int64, error)
+}
@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+)
+
+// UpdatePurchaseOrderRequest changes a draft purchase order, Items replace its lines when given
+type UpdatePurchaseOrderRequest struct {
+	SupplierID  *uint                   `json:"supplier_id,omitempty"`
+	PaymentType *models.PaymentTypeEnum `json:"payment_type,omitempty" validate:"omitempty,enum"`
+	Notes       *string                 `json:"notes,omitempty"`
+	Items       []PurchaseOrderItem     `json:"items,omitempty" validate:"omitempty,min=1,dive"`
+}
+
+type PurchaseOrderItem struct {
+	ProductID uint    `json:"product_id" validate:"required"`
+	Quantity  int     `json:"quantity" validate:"required,min=1"`
+	CostPrice float64 `json:"cost_price" validate:"min=0"`
+}
+
+// PurchaseOrderReceipt is a purchase order booked into stock with the special orders it reserved for service jobs
+type PurchaseOrderReceipt struct {
+	PurchaseOrder *models.PurchaseOrder  `json:"purchase_order"`
+	Reserved      []*models.SpecialOrder `json:"reserved"`
+}
+
+// Usecase interfaces
+type PurchaseOrderUsecase interface {
+	ListPurchaseOrders(ctx context.Context, q *query.ListQuery) ([]*models.PurchaseOrder, int64, error)
+	GetPurchaseOrder(ctx context.Context, id uint) (*models.PurchaseOrder, error)
+	UpdateDraftPurchaseOrder(ctx context.Context, id uint, req UpdatePurchaseOrderRequest) (*models.PurchaseOrder, error)
+	// ConfirmPurchaseOrder turns a draft into a pending order, placed with the supplier and waiting for the goods
+	ConfirmPurchaseOrder(ctx context.Context, id uint) (*models.PurchaseOrder, error)
+	// DeleteDraftPurchaseOrder discards a draft, its special orders are requested again
+	DeleteDraftPurchaseOrder(ctx context.Context, id uint) error
+	// ReceivePurchaseOrder books the goods of a pending order into its outlet and reserves the special-ordered parts
+	ReceivePurchaseOrder(ctx context.Context, id uint) (*PurchaseOrderReceipt, error)
+}
@@ -0,0 +1,65 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// ReplenishmentRequest plans the stock levels of one outlet; when OutletID is nil all outlets are planned together with
+// the products that are planned against their total stock. Zero days fall back to the Replenishment config.
+type ReplenishmentRequest struct {
+	OutletID     *uint `query:"outlet_id" json:"outlet_id"`
+	LookbackDays int   `query:"lookback_days" json:"lookback_days" validate:"omitempty,min=1,max=365"`
+	CoverDays    int   `query:"cover_days" json:"cover_days" validate:"omitempty,min=1,max=180"`
+}
+
+// SetStockLevelRequest sets the reorder point of a product at one outlet, a MinStock of 0 stops replenishing it there
+type SetStockLevelRequest struct {
+	MinStock        int `json:"min_stock" validate:"min=0"`
+	ReorderQuantity int `json:"reorder_quantity" validate:"min=0"`
+}
+
+// ReplenishmentSuggestion is a product whose available stock, on hand plus on order less the special orders reserved
+// for service jobs, reached its min stock. OutletID is nil for products planned against their total stock.
+type ReplenishmentSuggestion struct {
+	OutletID          *uint   `json:"outlet_id"`
+	ProductID         uint    `json:"product_id"`
+	ProductName       string  `json:"product_name"`
+	SKU               *string `json:"sku"`
+	SupplierID        *uint   `json:"supplier_id"`
+	SupplierName      *string `json:"supplier_name"`
+	MinStock          int     `json:"min_stock"`
+	ReorderQuantity   int     `json:"reorder_quantity"`
+	OnHand            int64   `json:"on_hand"`
+	OnOrder           int64   `json:"on_order"`
+	Reserved          int64   `json:"reserved"`
+	AvgDailyUsage     float64 `json:"avg_daily_usage"`
+	SuggestedQuantity int64   `json:"suggested_quantity"`
+	CostPrice         float64 `json:"cost_price"`
+	EstimatedCost     float64 `json:"estimated_cost"`
+}
+
+type ReplenishmentPlan struct {
+	GeneratedAt   time.Time                 `json:"generated_at"`
+	LookbackDays  int                       `json:"lookback_days"`
+	CoverDays     int                       `json:"cover_days"`
+	EstimatedCost float64                   `json:"estimated_cost"`
+	Suggestions   []ReplenishmentSuggestion `json:"suggestions"`
+}
+
+// DraftOrders are the purchase orders generated from a plan, one per outlet and supplier; suggestions for products
+// without a preferred supplier cannot be ordered and are returned as Unassigned
+type DraftOrders struct {
+	Orders     []*models.PurchaseOrder   `json:"orders"`
+	Unassigned []ReplenishmentSuggestion `json:"unassigned"`
+}
+
+// Usecase interfaces
+type ReplenishmentUsecase interface {
+	Suggestions(ctx context.Context, req ReplenishmentRequest) (*ReplenishmentPlan, error)
+	GenerateDraftOrders(ctx context.Context, req ReplenishmentRequest) (*DraftOrders, error)
+	GetStockLevels(ctx context.Context, productID uint) ([]*models.ProductStockLevel, error)
+	SetStockLevel(ctx context.Context, productID, outletID uint, req SetStockLevelRequest) (*models.ProductStockLevel, error)
+	DeleteStockLevel(ctx context.Context, productID, outletID uint) error
+}
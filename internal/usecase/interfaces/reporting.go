@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+	"io"
+	"time"
+)
+
+// Report request structures
+type CreateReportRequest struct {
+	ReportType models.ReportTypeEnum `json:"report_type" validate:"required,enum"`
+	Format     models.ReportFormat   `json:"format,omitempty" validate:"omitempty,enum"`
+	ReportName *string               `json:"report_name,omitempty" validate:"omitempty,min=3,max=255"`
+	StartDate  time.Time             `json:"start_date" validate:"required"`
+	EndDate    time.Time             `json:"end_date" validate:"required,notbefore=StartDate"`
+	OutletID   *uint                 `json:"outlet_id,omitempty"`
+	// UserID is only used when the request is not authenticated, otherwise the report belongs to the actor
+	UserID *uint `json:"user_id,omitempty"`
+}
+
+// ReportFile is a generated report opened for download, the caller closes Body
+type ReportFile struct {
+	Name        string
+	ContentType string
+	Body        io.ReadCloser
+}
+
+// Usecase interfaces
+type ReportUsecase interface {
+	RequestReport(ctx context.Context, req CreateReportRequest) (*models.Report, error)
+	GetReport(ctx context.Context, id uint) (*models.Report, error)
+	ListReports(ctx context.Context, q *query.ListQuery) ([]*models.Report, int64, error)
+	DownloadReport(ctx context.Context, id uint) (*ReportFile, error)
+	RetryReport(ctx context.Context, id uint) (*models.Report, error)
+
+	// ProcessNextReport generates the oldest due report, it reports false when the queue is empty
+	ProcessNextReport(ctx context.Context) (bool, error)
+	// RequeueStaleReports returns reports stuck in Diproses for longer than olderThan to the queue
+	RequeueStaleReports(ctx context.Context, olderThan time.Duration) (int64, error)
+}
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
+	"context"
+	"time"
+)
+
+// ScheduledJob is a job known to the scheduler with its schedule and latest run
+type ScheduledJob struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Schedule    string `json:"schedule"`
+	// ScheduleError is set when the configured schedule is not a valid cron expression, the job then only runs manually
+	ScheduleError string         `json:"schedule_error,omitempty"`
+	Enabled       bool           `json:"enabled"`
+	NextRunAt     *time.Time     `json:"next_run_at"`
+	LastRun       *models.JobRun `json:"last_run"`
+}
+
+// Usecase interfaces
+type SchedulerUsecase interface {
+	ListJobs(ctx context.Context) ([]ScheduledJob, error)
+	// TriggerJob starts a job in the background and returns its run, it fails while the job is running
+	TriggerJob(ctx context.Context, name string) (*models.JobRun, error)
+	ListRuns(ctx context.Context, q *query.ListQuery) ([]*models.JobRun, int64, error)
+	GetRun(ctx context.Context, id uint) (*models.JobRun, error)
+
+	// DueJobs returns the enabled jobs scheduled for the minute of now
+	DueJobs(now time.Time) []string
+	// RunScheduled runs a job for its schedule slot and waits for it; it returns nil when another instance
+	// holds the job or already ran the slot
+	RunScheduled(ctx context.Context, name string, slot time.Time) (*models.JobRun, error)
+	// FailStaleRuns marks runs left in Diproses by a stopped instance as failed
+	FailStaleRuns(ctx context.Context) (int64, error)
+}
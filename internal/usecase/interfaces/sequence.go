@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// Document types numbered by the sequence usecase, their patterns are set in the Sequence config
+const (
+	SequenceServiceCode     = "service_code"
+	SequenceQueueNumber     = "queue_number"
+	SequenceInvoice         = "invoice"
+	SequencePurchaseOrder   = "purchase_order"
+	SequenceVehiclePurchase = "vehicle_purchase"
+)
+
+// Usecase interfaces
+type SequenceUsecase interface {
+	// Next hands out the next number of a document type for an outlet at the given time: the formatted code and the
+	// counter value it was made from. Numbers are never handed out twice, numbers of failed saves are skipped.
+	Next(ctx context.Context, document string, outletID uint, at time.Time) (string, int64, error)
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"boilerplate/internal/models"
+	"boilerplate/pkg/query"
 	"context"
 	"time"
 )
@@ -11,8 +12,9 @@ type CreateServiceRequest struct {
 	ServiceCode       string            `json:"service_code" validate:"required,min=3,max=50"`
 	Name              string            `json:"name" validate:"required,min=2,max=255"`
 	ServiceCategoryID uint              `json:"service_category_id" validate:"required"`
-	Fee               float64           `json:"fee" validate:"required,min=0"`
-	Status            models.StatusUmum `json:"status,omitempty"`
+	Fee               float64           `json:"fee" validate:"required,gt=0"`
+	StandardMinutes   int               `json:"standard_minutes,omitempty" validate:"omitempty,min=1,max=1440"`
+	Status            models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy         *uint             `json:"created_by,omitempty"`
 }
 
@@ -20,20 +22,21 @@ type UpdateServiceRequest struct {
 	ServiceCode       *string            `json:"service_code,omitempty" validate:"omitempty,min=3,max=50"`
 	Name              *string            `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
 	ServiceCategoryID *uint              `json:"service_category_id,omitempty"`
-	Fee               *float64           `json:"fee,omitempty" validate:"omitempty,min=0"`
-	Status            *models.StatusUmum `json:"status,omitempty"`
+	Fee               *float64           `json:"fee,omitempty" validate:"omitempty,gt=0"`
+	StandardMinutes   *int               `json:"standard_minutes,omitempty" validate:"omitempty,min=0,max=1440"`
+	Status            *models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // Service Category request structures
 type CreateServiceCategoryRequest struct {
 	Name      string            `json:"name" validate:"required,min=2,max=255"`
-	Status    models.StatusUmum `json:"status,omitempty"`
+	Status    models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 	CreatedBy *uint             `json:"created_by,omitempty"`
 }
 
 type UpdateServiceCategoryRequest struct {
 	Name   *string            `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
-	Status *models.StatusUmum `json:"status,omitempty"`
+	Status *models.StatusUmum `json:"status,omitempty" validate:"omitempty,enum"`
 }
 
 // Service Job request structures
@@ -45,11 +48,14 @@ type CreateServiceJobRequest struct {
 	OutletID                   uint                      `json:"outlet_id" validate:"required"`
 	ProblemDescription         string                    `json:"problem_description" validate:"required,min=10"`
 	TechnicianNotes            *string                   `json:"technician_notes,omitempty"`
-	Status                     models.ServiceStatusEnum  `json:"status,omitempty"`
+	Status                     models.ServiceStatusEnum  `json:"status,omitempty" validate:"omitempty,enum"`
 	ServiceInDate              time.Time                 `json:"service_in_date" validate:"required"`
-	WarrantyExpiresAt          *time.Time                `json:"warranty_expires_at,omitempty"`
-	NextServiceReminderDate    *time.Time                `json:"next_service_reminder_date,omitempty"`
+	WarrantyExpiresAt          *time.Time                `json:"warranty_expires_at,omitempty" validate:"omitempty,notbefore=ServiceInDate"`
+	NextServiceReminderDate    *time.Time                `json:"next_service_reminder_date,omitempty" validate:"omitempty,notbefore=ServiceInDate"`
+	EstimatedCompletionAt      *time.Time                `json:"estimated_completion_at,omitempty" validate:"omitempty,notbefore=ServiceInDate"`
 	DownPayment                float64                   `json:"down_payment" validate:"min=0"`
+	// Priority puts the job ahead of walk-ins in the queue, 1 for appointments
+	Priority                   int                       `json:"priority,omitempty" validate:"omitempty,oneof=0 1"`
 	CreatedBy                  *uint                     `json:"created_by,omitempty"`
 }
 
@@ -61,28 +67,37 @@ type UpdateServiceJobRequest struct {
 	OutletID                   *uint                     `json:"outlet_id,omitempty"`
 	ProblemDescription         *string                   `json:"problem_description,omitempty" validate:"omitempty,min=10"`
 	TechnicianNotes            *string                   `json:"technician_notes,omitempty"`
-	Status                     *models.ServiceStatusEnum `json:"status,omitempty"`
+	Status                     *models.ServiceStatusEnum `json:"status,omitempty" validate:"omitempty,enum"`
 	ServiceInDate              *time.Time                `json:"service_in_date,omitempty"`
-	PickedUpDate               *time.Time                `json:"picked_up_date,omitempty"`
-	ComplainDate               *time.Time                `json:"complain_date,omitempty"`
-	WarrantyExpiresAt          *time.Time                `json:"warranty_expires_at,omitempty"`
-	NextServiceReminderDate    *time.Time                `json:"next_service_reminder_date,omitempty"`
+	PickedUpDate               *time.Time                `json:"picked_up_date,omitempty" validate:"omitempty,notbefore=ServiceInDate"`
+	ComplainDate               *time.Time                `json:"complain_date,omitempty" validate:"omitempty,notbefore=ServiceInDate"`
+	WarrantyExpiresAt          *time.Time                `json:"warranty_expires_at,omitempty" validate:"omitempty,notbefore=ServiceInDate"`
+	NextServiceReminderDate    *time.Time                `json:"next_service_reminder_date,omitempty" validate:"omitempty,notbefore=ServiceInDate"`
+	EstimatedCompletionAt      *time.Time                `json:"estimated_completion_at,omitempty" validate:"omitempty,notbefore=ServiceInDate"`
 	DownPayment                *float64                  `json:"down_payment,omitempty" validate:"omitempty,min=0"`
 	GrandTotal                 *float64                  `json:"grand_total,omitempty" validate:"omitempty,min=0"`
 	TechnicianCommission       *float64                  `json:"technician_commission,omitempty" validate:"omitempty,min=0"`
 	ShopProfit                 *float64                  `json:"shop_profit,omitempty" validate:"omitempty,min=0"`
 }
 
-// Service Detail request structures
+// Service Detail request structures, CostPerItem only applies to service lines; product lines
+// take the cost their stock is issued at
 type CreateServiceDetailRequest struct {
 	ServiceJobID     uint    `json:"service_job_id" validate:"required"`
 	ItemID           uint    `json:"item_id" validate:"required"`
 	ItemType         string  `json:"item_type" validate:"required,oneof=service product"`
 	Description      string  `json:"description" validate:"required,min=2,max=255"`
-	SerialNumberUsed *string `json:"serial_number_used,omitempty"`
+	SerialNumberUsed *string `json:"serial_number_used,omitempty" validate:"omitempty,max=255"`
 	Quantity         int     `json:"quantity" validate:"required,min=1"`
-	PricePerItem     float64 `json:"price_per_item" validate:"required,min=0"`
-	CostPerItem      float64 `json:"cost_per_item" validate:"required,min=0"`
+	PricePerItem     float64 `json:"price_per_item" validate:"required,gt=0"`
+	CostPerItem      float64 `json:"cost_per_item" validate:"min=0"`
+}
+
+// CreatedServiceDetail is the line added to a job, or for a part short in stock no line but the special order
+// requesting the shortfall while the job waits for it
+type CreatedServiceDetail struct {
+	ServiceDetail *models.ServiceDetail
+	SpecialOrder  *models.SpecialOrder
 }
 
 type UpdateServiceDetailRequest struct {
@@ -90,9 +105,9 @@ type UpdateServiceDetailRequest struct {
 	ItemID           *uint    `json:"item_id,omitempty"`
 	ItemType         *string  `json:"item_type,omitempty" validate:"omitempty,oneof=service product"`
 	Description      *string  `json:"description,omitempty" validate:"omitempty,min=2,max=255"`
-	SerialNumberUsed *string  `json:"serial_number_used,omitempty"`
+	SerialNumberUsed *string  `json:"serial_number_used,omitempty" validate:"omitempty,max=255"`
 	Quantity         *int     `json:"quantity,omitempty" validate:"omitempty,min=1"`
-	PricePerItem     *float64 `json:"price_per_item,omitempty" validate:"omitempty,min=0"`
+	PricePerItem     *float64 `json:"price_per_item,omitempty" validate:"omitempty,gt=0"`
 	CostPerItem      *float64 `json:"cost_per_item,omitempty" validate:"omitempty,min=0"`
 }
 
@@ -110,7 +125,7 @@ type ServiceUsecase interface {
 	GetServiceByServiceCode(ctx context.Context, serviceCode string) (*models.Service, error)
 	UpdateService(ctx context.Context, id uint, req UpdateServiceRequest) (*models.Service, error)
 	DeleteService(ctx context.Context, id uint) error
-	ListServices(ctx context.Context, limit, offset int) ([]*models.Service, error)
+	ListServices(ctx context.Context, q *query.ListQuery) ([]*models.Service, int64, error)
 	GetServicesByCategory(ctx context.Context, categoryID uint) ([]*models.Service, error)
 	GetServicesByStatus(ctx context.Context, status models.StatusUmum) ([]*models.Service, error)
 	SearchServices(ctx context.Context, query string, limit, offset int) ([]*models.Service, error)
@@ -122,7 +137,7 @@ type ServiceCategoryUsecase interface {
 	GetServiceCategoryByName(ctx context.Context, name string) (*models.ServiceCategory, error)
 	UpdateServiceCategory(ctx context.Context, id uint, req UpdateServiceCategoryRequest) (*models.ServiceCategory, error)
 	DeleteServiceCategory(ctx context.Context, id uint) error
-	ListServiceCategories(ctx context.Context, limit, offset int) ([]*models.ServiceCategory, error)
+	ListServiceCategories(ctx context.Context, q *query.ListQuery) ([]*models.ServiceCategory, int64, error)
 	GetServiceCategoriesByStatus(ctx context.Context, status models.StatusUmum) ([]*models.ServiceCategory, error)
 }
 
@@ -132,22 +147,20 @@ type ServiceJobUsecase interface {
 	GetServiceJobByServiceCode(ctx context.Context, serviceCode string) (*models.ServiceJob, error)
 	UpdateServiceJob(ctx context.Context, id uint, req UpdateServiceJobRequest) (*models.ServiceJob, error)
 	DeleteServiceJob(ctx context.Context, id uint) error
-	ListServiceJobs(ctx context.Context, limit, offset int) ([]*models.ServiceJob, error)
-	GetServiceJobsByCustomer(ctx context.Context, customerID uint) ([]*models.ServiceJob, error)
+	ListServiceJobs(ctx context.Context, q *query.ListQuery) ([]*models.ServiceJob, int64, error)
 	GetServiceJobsByVehicle(ctx context.Context, vehicleID uint) ([]*models.ServiceJob, error)
 	GetServiceJobsByTechnician(ctx context.Context, technicianID uint) ([]*models.ServiceJob, error)
 	GetServiceJobsByOutlet(ctx context.Context, outletID uint) ([]*models.ServiceJob, error)
-	GetServiceJobsByStatus(ctx context.Context, status models.ServiceStatusEnum) ([]*models.ServiceJob, error)
 	UpdateServiceJobStatus(ctx context.Context, id uint, status models.ServiceStatusEnum, userID uint, notes *string) error
 	CalculateServiceJobTotals(ctx context.Context, serviceJobID uint) error
 }
 
 type ServiceDetailUsecase interface {
-	CreateServiceDetail(ctx context.Context, req CreateServiceDetailRequest) (*models.ServiceDetail, error)
+	CreateServiceDetail(ctx context.Context, req CreateServiceDetailRequest) (*CreatedServiceDetail, error)
 	GetServiceDetail(ctx context.Context, id uint) (*models.ServiceDetail, error)
 	UpdateServiceDetail(ctx context.Context, id uint, req UpdateServiceDetailRequest) (*models.ServiceDetail, error)
 	DeleteServiceDetail(ctx context.Context, id uint) error
-	ListServiceDetails(ctx context.Context, limit, offset int) ([]*models.ServiceDetail, error)
+	ListServiceDetails(ctx context.Context, q *query.ListQuery) ([]*models.ServiceDetail, int64, error)
 	GetServiceDetailsByServiceJob(ctx context.Context, serviceJobID uint) ([]*models.ServiceDetail, error)
 	DeleteServiceDetailsByServiceJob(ctx context.Context, serviceJobID uint) error
 }
@@ -155,7 +168,7 @@ type ServiceDetailUsecase interface {
 type ServiceJobHistoryUsecase interface {
 	CreateServiceJobHistory(ctx context.Context, req CreateServiceJobHistoryRequest) (*models.ServiceJobHistory, error)
 	GetServiceJobHistory(ctx context.Context, id uint) (*models.ServiceJobHistory, error)
-	ListServiceJobHistories(ctx context.Context, limit, offset int) ([]*models.ServiceJobHistory, error)
+	ListServiceJobHistories(ctx context.Context, q *query.ListQuery) ([]*models.ServiceJobHistory, int64, error)
 	GetServiceJobHistoriesByServiceJob(ctx context.Context, serviceJobID uint) ([]*models.ServiceJobHistory, error)
 	GetServiceJobHistoriesByUser(ctx context.Context, userID uint) ([]*models.ServiceJobHistory, error)
 }
\ No newline at end of file
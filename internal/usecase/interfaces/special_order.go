@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+)
+
+// RequestPartsRequest orders parts that are not in stock for a service job
+type RequestPartsRequest struct {
+	Items []SpecialOrderItem `json:"items" validate:"required,min=1,dive"`
+	Notes *string            `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+type SpecialOrderItem struct {
+	ProductID uint    `json:"product_id" validate:"required"`
+	Quantity  int     `json:"quantity" validate:"required,min=1"`
+	Notes     *string `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// SpecialOrderQuery filters the special orders listed
+type SpecialOrderQuery struct {
+	Status       *models.SpecialOrderStatus `query:"status" json:"status" validate:"omitempty,enum"`
+	OutletID     *uint                      `query:"outlet_id" json:"outlet_id"`
+	ServiceJobID *uint                      `query:"service_job_id" json:"service_job_id"`
+}
+
+// SpecialOrderDraftRequest rolls the requested parts of one outlet, or of all outlets when OutletID is nil, into
+// draft purchase orders
+type SpecialOrderDraftRequest struct {
+	OutletID *uint `json:"outlet_id,omitempty"`
+}
+
+// SpecialOrderDrafts are the purchase orders generated from the requested parts, one per outlet and supplier;
+// requests for products without a preferred supplier stay requested and are returned as Unassigned
+type SpecialOrderDrafts struct {
+	Orders     []*models.PurchaseOrder `json:"orders"`
+	Unassigned []*models.SpecialOrder  `json:"unassigned"`
+}
+
+// SpecialOrderUsecase orders the parts service jobs wait for and reserves them when they arrive
+type SpecialOrderUsecase interface {
+	// RequestParts requests the parts and moves the job to Menunggu Sparepart
+	RequestParts(ctx context.Context, serviceJobID uint, req RequestPartsRequest) ([]*models.SpecialOrder, error)
+	ListSpecialOrders(ctx context.Context, q SpecialOrderQuery) ([]*models.SpecialOrder, error)
+	// CancelSpecialOrder cancels a request not yet ordered or releases reserved parts to the stock
+	CancelSpecialOrder(ctx context.Context, id uint) (*models.SpecialOrder, error)
+	GenerateDraftOrders(ctx context.Context, req SpecialOrderDraftRequest) (*SpecialOrderDrafts, error)
+	// PartsArrived tells the front desk and the customers of the jobs that have all their parts once the reserved
+	// orders of a received purchase order are committed
+	PartsArrived(ctx context.Context, purchaseOrderID uint, reserved []*models.SpecialOrder)
+}
@@ -0,0 +1,98 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+)
+
+// TechnicianShiftRequest is the shift of a day of the week, 0 is Sunday
+type TechnicianShiftRequest struct {
+	Weekday  int    `json:"weekday" validate:"min=0,max=6"`
+	StartsAt string `json:"starts_at" validate:"required,datetime=15:04"`
+	EndsAt   string `json:"ends_at" validate:"required,datetime=15:04"`
+}
+
+// SaveTechnicianRequest makes a user a technician or changes the profile; the skills and shifts replace the current
+// ones. Without IsActive a new profile is active and an existing one keeps its flag.
+type SaveTechnicianRequest struct {
+	IsActive           *bool                    `json:"is_active,omitempty"`
+	ServiceCategoryIDs []uint                   `json:"service_category_ids" validate:"max=50,dive,required"`
+	Shifts             []TechnicianShiftRequest `json:"shifts" validate:"max=7,dive"`
+	Notes              *string                  `json:"notes,omitempty" validate:"omitempty,max=2000"`
+}
+
+// TechnicianQuery narrows the technicians listed; an outlet keeps its technicians and those without outlet
+type TechnicianQuery struct {
+	OutletID uint  `query:"outlet_id" json:"outlet_id"`
+	Active   *bool `query:"active" json:"active"`
+}
+
+// TechnicianWorkloadQuery asks for the workload of the technicians of an outlet
+type TechnicianWorkloadQuery struct {
+	OutletID uint `query:"outlet_id" json:"outlet_id" validate:"required"`
+}
+
+// AssignTechnicianRequest assigns a job to the technician, or to the suggested technician when left out
+type AssignTechnicianRequest struct {
+	TechnicianID *uint `json:"technician_id,omitempty"`
+}
+
+// TechnicianJob is an open job of a technician with the work it is estimated to take
+type TechnicianJob struct {
+	ServiceJobID     uint                     `json:"service_job_id"`
+	ServiceCode      string                   `json:"service_code"`
+	QueueNumber      int                      `json:"queue_number"`
+	OutletID         uint                     `json:"outlet_id"`
+	Status           models.ServiceStatusEnum `json:"status"`
+	PlateNumber      string                   `json:"plate_number"`
+	EstimatedMinutes int                      `json:"estimated_minutes"`
+}
+
+// TechnicianWorkload is a technician with the open jobs assigned, at any outlet, and the work they are estimated to
+// take from the standard time of their services
+type TechnicianWorkload struct {
+	UserID             uint                    `json:"user_id"`
+	Name               string                  `json:"name"`
+	OutletID           *uint                   `json:"outlet_id"`
+	IsActive           bool                    `json:"is_active"`
+	OnShift            bool                    `json:"on_shift"`
+	Shift              *models.TechnicianShift `json:"shift"` // today's shift, none without shifts
+	ServiceCategoryIDs []uint                  `json:"service_category_ids"`
+	OpenJobs           int                     `json:"open_jobs"`
+	InProgressJobs     int                     `json:"in_progress_jobs"`
+	EstimatedMinutes   int                     `json:"estimated_minutes"`
+	EstimatedHours     float64                 `json:"estimated_hours"`
+	Jobs               []TechnicianJob         `json:"jobs"`
+}
+
+// TechnicianCandidate is a technician considered for a job, with the requested service categories the technician
+// has no skill for
+type TechnicianCandidate struct {
+	TechnicianWorkload
+	Qualified                 bool   `json:"qualified"`
+	MissingServiceCategoryIDs []uint `json:"missing_service_category_ids"`
+}
+
+// TechnicianSuggestion lists the active technicians of the job's outlet: the qualified ones on shift first, then the
+// qualified ones off shift, the least loaded first within each. The workloads leave the job itself out.
+type TechnicianSuggestion struct {
+	ServiceJobID       uint                  `json:"service_job_id"`
+	ServiceCategoryIDs []uint                `json:"service_category_ids"`
+	EstimatedMinutes   int                   `json:"estimated_minutes"`
+	SuggestedID        *uint                 `json:"suggested_technician_id"`
+	Candidates         []TechnicianCandidate `json:"candidates"`
+}
+
+// TechnicianUsecase keeps the technician profiles and assigns jobs by skill and workload
+type TechnicianUsecase interface {
+	SaveTechnician(ctx context.Context, userID uint, req SaveTechnicianRequest) (*models.TechnicianProfile, error)
+	GetTechnician(ctx context.Context, userID uint) (*models.TechnicianProfile, error)
+	ListTechnicians(ctx context.Context, q TechnicianQuery) ([]*models.TechnicianProfile, error)
+	// GetWorkload lists the active technicians of the outlet, and the inactive ones still holding open jobs, the
+	// least loaded first
+	GetWorkload(ctx context.Context, q TechnicianWorkloadQuery) ([]TechnicianWorkload, error)
+	// SuggestTechnicians ranks the technicians for the requested services of a job
+	SuggestTechnicians(ctx context.Context, serviceJobID uint) (*TechnicianSuggestion, error)
+	// AssignTechnician assigns the job to a qualified technician of its outlet, the suggested one when none is asked
+	AssignTechnician(ctx context.Context, serviceJobID uint, req AssignTechnicianRequest) (*models.ServiceJob, error)
+}
@@ -0,0 +1,56 @@
+package interfaces
+
+import (
+	"boilerplate/internal/models"
+	"context"
+	"time"
+)
+
+// TrackingPhoneDigits is how many trailing digits of the customer's phone number must match when tracking by service code, the len rule of TrackServiceJobRequest.Phone
+const TrackingPhoneDigits = 4
+
+// TrackServiceJobRequest finds a service job by its service code and the last digits of the customer's phone number
+type TrackServiceJobRequest struct {
+	ServiceCode string `query:"service_code" json:"service_code" validate:"required,max=50"`
+	Phone       string `query:"phone" json:"phone" validate:"required,numeric,len=4"`
+}
+
+// TrackingStatus is a status change of the job timeline
+type TrackingStatus struct {
+	Status    models.ServiceStatusEnum `json:"status"`
+	ChangedAt time.Time                `json:"changed_at"`
+}
+
+// TrackingItem is a line of the job as the customer is charged for it
+type TrackingItem struct {
+	Description  string  `json:"description"`
+	Quantity     int     `json:"quantity"`
+	PricePerItem float64 `json:"price_per_item"`
+	Subtotal     float64 `json:"subtotal"`
+}
+
+// ServiceJobTracking is what a customer may see of a service job: no costs, commissions, notes or staff
+type ServiceJobTracking struct {
+	ServiceCode           string                   `json:"service_code"`
+	TrackingToken         string                   `json:"tracking_token"`
+	Status                models.ServiceStatusEnum `json:"status"`
+	QueueNumber           int                      `json:"queue_number"`
+	PlateNumber           string                   `json:"plate_number"`
+	Vehicle               string                   `json:"vehicle"`
+	OutletName            string                   `json:"outlet_name"`
+	OutletPhone           string                   `json:"outlet_phone"`
+	ServiceInDate         time.Time                `json:"service_in_date"`
+	EstimatedCompletionAt *time.Time               `json:"estimated_completion_at"`
+	PickedUpDate          *time.Time               `json:"picked_up_date"`
+	Timeline              []TrackingStatus         `json:"timeline"`
+	Items                 []TrackingItem           `json:"items"`
+	GrandTotal            float64                  `json:"grand_total"`
+	DownPayment           float64                  `json:"down_payment"`
+	BalanceDue            float64                  `json:"balance_due"`
+}
+
+// TrackingUsecase serves the public job tracking, callers are not authenticated
+type TrackingUsecase interface {
+	TrackByToken(ctx context.Context, token string) (*ServiceJobTracking, error)
+	TrackByServiceCode(ctx context.Context, req TrackServiceJobRequest) (*ServiceJobTracking, error)
+}
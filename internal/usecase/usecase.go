@@ -1,9 +1,15 @@
 package usecase
 
 import (
+	"boilerplate/config"
 	"boilerplate/internal/repository"
 	"boilerplate/internal/usecase/implementations"
 	"boilerplate/internal/usecase/interfaces"
+	"boilerplate/pkg/notify"
+	"boilerplate/pkg/storage"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 )
 
 // UsecaseManager contains all usecase interfaces
@@ -24,6 +30,8 @@ type UsecaseManager struct {
 	Category            interfaces.CategoryUsecase
 	Supplier            interfaces.SupplierUsecase
 	UnitType            interfaces.UnitTypeUsecase
+	Costing             interfaces.CostingUsecase
+	Replenishment       interfaces.ReplenishmentUsecase
 
 	// Services
 	Service           interfaces.ServiceUsecase
@@ -35,18 +43,92 @@ type UsecaseManager struct {
 	// Transactions
 	Transaction       interfaces.TransactionUsecase
 	TransactionDetail interfaces.TransactionDetailUsecase
+	PurchaseOrder     interfaces.PurchaseOrderUsecase
 
 	// Financial
 	PaymentMethod interfaces.PaymentMethodUsecase
 	Payment       interfaces.PaymentUsecase
 	CashFlow      interfaces.CashFlowUsecase
 
+	// Audit
+	AuditLog interfaces.AuditLogUsecase
+
+	// Reporting
+	Report    interfaces.ReportUsecase
+	Analytics interfaces.AnalyticsUsecase
+	Dashboard interfaces.DashboardUsecase
+
+	// Scheduler
+	Scheduler interfaces.SchedulerUsecase
+
+	// Notifications
+	Notification interfaces.NotificationUsecase
+
+	// Public job tracking
+	Tracking interfaces.TrackingUsecase
+
+	// Repair estimates
+	Estimate interfaces.EstimateUsecase
+
+	// Appointments
+	Appointment interfaces.AppointmentUsecase
+
+	// Document numbering
+	Sequence interfaces.SequenceUsecase
+
+	// Live workshop boards
+	Board interfaces.BoardUsecase
+
+	// Technicians
+	Technician interfaces.TechnicianUsecase
+
+	// Labour time
+	Labour interfaces.LabourUsecase
+
+	// Special orders
+	SpecialOrder interfaces.SpecialOrderUsecase
+
 	// Add other usecases as they are implemented
 }
 
-// NewUsecaseManager creates a new usecase manager with all usecases
-func NewUsecaseManager(repo *repository.RepositoryManager) *UsecaseManager {
-	return &UsecaseManager{
+// defaultMaxUploadSize is the upload limit in MB when CloudStorage.GoogleStorage.DefaultMaxUploadSize is not set
+const defaultMaxUploadSize = 10
+
+// estimateLinkKeyLabel names the key derived from the JWT access key for the estimate approval links
+const estimateLinkKeyLabel = "estimate-link"
+
+// deriveKey derives a sub-key for label from secret, so a signature made with it is never valid as a token signature
+func deriveKey(secret, label string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(label))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewUsecaseManager creates a new usecase manager with all usecases, uploaded and generated files are kept in store
+// and customer notifications are delivered through senders, one per channel
+func NewUsecaseManager(repo *repository.RepositoryManager, store storage.Storage, senders map[string]notify.Sender, conf *config.Config) *UsecaseManager {
+	maxUploadSize := conf.CloudStorage.GoogleStorage.DefaultMaxUploadSize
+	if maxUploadSize <= 0 {
+		maxUploadSize = defaultMaxUploadSize
+	}
+
+	// Notifications are queued by the service jobs and the scheduled jobs
+	notification := implementations.NewNotificationUsecase(repo, senders, conf.Notification)
+
+	// Estimates guard the work on service jobs, their approval links are signed with a key of their own
+	signingKey := conf.Estimate.SigningKey
+	if signingKey == "" {
+		signingKey = deriveKey(conf.Authorization.JWT.AccessTokenSecretKey, estimateLinkKeyLabel)
+	}
+	estimate := implementations.NewEstimateUsecase(repo, notification, conf.Estimate, conf.App.BaseUrl, signingKey)
+
+	// Document numbers and queue numbers are handed out from database counters
+	sequence := implementations.NewSequenceUsecase(repo, conf.Sequence)
+
+	// The live boards are fed by the events of the service jobs
+	board := implementations.NewBoardUsecase(repo, conf.Board)
+
+	m := &UsecaseManager{
 		// Foundation & Security
 		User:   implementations.NewUserUsecase(repo),
 		Outlet: implementations.NewOutletUsecase(repo),
@@ -58,21 +140,23 @@ func NewUsecaseManager(repo *repository.RepositoryManager) *UsecaseManager {
 		CustomerVehicle: implementations.NewCustomerVehicleUsecase(repo),
 
 		// Master Data & Inventory
-		Product:             implementations.NewProductUsecase(repo),
+		Product:             implementations.NewProductUsecase(repo, store, int64(maxUploadSize)<<20),
 		ProductSerialNumber: implementations.NewProductSerialNumberUsecase(repo),
 		Category:            implementations.NewCategoryUsecase(repo),
 		Supplier:            implementations.NewSupplierUsecase(repo),
 		UnitType:            implementations.NewUnitTypeUsecase(repo),
+		Costing:             implementations.NewCostingUsecase(repo),
+		Replenishment:       implementations.NewReplenishmentUsecase(repo, sequence, conf.Replenishment),
 
 		// Services
 		Service:           implementations.NewServiceUsecase(repo),
 		ServiceCategory:   implementations.NewServiceCategoryUsecase(repo),
-		ServiceJob:        implementations.NewServiceJobUsecase(repo),
-		ServiceDetail:     implementations.NewServiceDetailUsecase(repo),
+		ServiceJob:        implementations.NewServiceJobUsecase(repo, notification, estimate, sequence, board),
+		ServiceDetail:     implementations.NewServiceDetailUsecase(repo, estimate, notification, board),
 		ServiceJobHistory: implementations.NewServiceJobHistoryUsecase(repo),
 
 		// Transactions
-		Transaction:       implementations.NewTransactionUsecase(repo),
+		Transaction:       implementations.NewTransactionUsecase(repo, sequence),
 		TransactionDetail: implementations.NewTransactionDetailUsecase(repo),
 
 		// Financial
@@ -80,6 +164,46 @@ func NewUsecaseManager(repo *repository.RepositoryManager) *UsecaseManager {
 		Payment:       implementations.NewPaymentUsecase(repo),
 		CashFlow:      implementations.NewCashFlowUsecase(repo),
 
+		// Audit
+		AuditLog: implementations.NewAuditLogUsecase(repo),
+
+		// Reporting
+		Report:    implementations.NewReportUsecase(repo, store, conf.Labour),
+		Analytics: implementations.NewAnalyticsUsecase(repo, conf.Labour),
+		Dashboard: implementations.NewDashboardUsecase(repo, conf.Dashboard),
+
+		// Notifications
+		Notification: notification,
+
+		// Public job tracking
+		Tracking: implementations.NewTrackingUsecase(repo),
+
+		// Repair estimates
+		Estimate: estimate,
+
+		// Document numbering
+		Sequence: sequence,
+
+		// Live workshop boards
+		Board: board,
+
 		// Add other usecases as they are implemented
 	}
+
+	// Appointments are checked in as service jobs
+	m.Appointment = implementations.NewAppointmentUsecase(repo, m.ServiceJob, conf.Appointment)
+
+	// Technicians are assigned to service jobs through the service job usecase
+	m.Technician = implementations.NewTechnicianUsecase(repo, m.ServiceJob, conf.Technician)
+
+	// Clocking in starts queued jobs through the service job usecase
+	m.Labour = implementations.NewLabourUsecase(repo, m.ServiceJob, conf.Labour)
+
+	// Jobs wait for special-ordered parts through the service job usecase, receiving a purchase order reserves them
+	m.SpecialOrder = implementations.NewSpecialOrderUsecase(repo, m.ServiceJob, notification, sequence, board)
+	m.PurchaseOrder = implementations.NewPurchaseOrderUsecase(repo, m.SpecialOrder)
+
+	// Scheduled jobs build on the usecases above
+	m.Scheduler = implementations.NewSchedulerUsecase(repo, m.Report, m.Product, m.Notification, m.Appointment, m.Board, conf.Scheduler)
+	return m
 }
\ No newline at end of file
@@ -0,0 +1,20 @@
+package usecase
+
+import "testing"
+
+func TestDeriveKey(t *testing.T) {
+	const secret = "jwt-access-secret"
+	key := deriveKey(secret, estimateLinkKeyLabel)
+	if key == "" || key == secret {
+		t.Fatalf("got key %q, want a key other than the secret", key)
+	}
+	if again := deriveKey(secret, estimateLinkKeyLabel); again != key {
+		t.Fatalf("got %q the second time, want the same key %q", again, key)
+	}
+	if other := deriveKey(secret, "other"); other == key {
+		t.Fatalf("got the same key %q for another label", other)
+	}
+	if other := deriveKey("another-secret", estimateLinkKeyLabel); other == key {
+		t.Fatalf("got the same key %q for another secret", other)
+	}
+}
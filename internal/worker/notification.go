@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Defaults used when the Notification config leaves a value at zero
+const (
+	defaultNotificationPollInterval = 10 * time.Second
+	// notificationStaleAfter is well above the send timeout, a notification this long in Diproses was abandoned
+	notificationStaleAfter = 5 * time.Minute
+)
+
+// NotificationWorker delivers queued customer notifications in the background
+type NotificationWorker struct {
+	usecase      interfaces.NotificationUsecase
+	log          *logrus.Logger
+	pollInterval time.Duration
+	disabled     bool
+}
+
+// NewNotificationWorker creates a notification worker from the Notification config
+func NewNotificationWorker(usecase interfaces.NotificationUsecase, conf config.NotificationAccount, log *logrus.Logger) *NotificationWorker {
+	w := &NotificationWorker{
+		usecase:      usecase,
+		log:          log,
+		pollInterval: time.Duration(conf.PollInterval) * time.Second,
+		disabled:     conf.Disabled,
+	}
+	if w.pollInterval <= 0 {
+		w.pollInterval = defaultNotificationPollInterval
+	}
+	return w
+}
+
+// Start runs the worker until ctx is cancelled, the returned WaitGroup is done once it stopped
+func (w *NotificationWorker) Start(ctx context.Context) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	if w.disabled {
+		w.log.Info("notification worker: disabled, notifications stay queued")
+		return &wg
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.run(ctx)
+	}()
+
+	w.log.Info("notification worker: started")
+	return &wg
+}
+
+// run sends notifications back to back and only waits when the queue is empty;
+// abandoned notifications are queued again at start and then every notificationStaleAfter
+func (w *NotificationWorker) run(ctx context.Context) {
+	var lastRequeue time.Time
+	for {
+		if time.Since(lastRequeue) >= notificationStaleAfter {
+			count, err := w.usecase.RequeueStaleNotifications(ctx, notificationStaleAfter)
+			if err != nil {
+				w.log.Errorf("notification worker: requeue stale notifications: %v", err)
+			} else if count > 0 {
+				w.log.Warnf("notification worker: requeued %d stale notifications", count)
+			}
+			lastRequeue = time.Now()
+		}
+
+		processed, err := w.usecase.ProcessNextNotification(ctx)
+		if err != nil {
+			w.log.Errorf("notification worker: %v", err)
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
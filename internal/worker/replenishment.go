@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultReplenishmentInterval is used when the Replenishment config leaves IntervalHours at zero
+const defaultReplenishmentInterval = 24 * time.Hour
+
+// ReplenishmentWorker drafts purchase orders for all outlets at start and then at every interval
+type ReplenishmentWorker struct {
+	usecase  interfaces.ReplenishmentUsecase
+	log      *logrus.Logger
+	interval time.Duration
+	disabled bool
+}
+
+// NewReplenishmentWorker creates a replenishment worker from the Replenishment config
+func NewReplenishmentWorker(usecase interfaces.ReplenishmentUsecase, conf config.ReplenishmentAccount, log *logrus.Logger) *ReplenishmentWorker {
+	w := &ReplenishmentWorker{
+		usecase:  usecase,
+		log:      log,
+		interval: time.Duration(conf.IntervalHours) * time.Hour,
+		disabled: conf.Disabled,
+	}
+	if w.interval <= 0 {
+		w.interval = defaultReplenishmentInterval
+	}
+	return w
+}
+
+// Start runs the job until ctx is cancelled, the returned WaitGroup is done once it stopped
+func (w *ReplenishmentWorker) Start(ctx context.Context) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	if w.disabled {
+		w.log.Info("replenishment worker: disabled")
+		return &wg
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.run(ctx)
+	}()
+
+	w.log.Infof("replenishment worker: started, runs every %s", w.interval)
+	return &wg
+}
+
+// run drafts orders for what is missing; drafts count as on order, so a run only adds what earlier runs did not
+func (w *ReplenishmentWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		drafts, err := w.usecase.GenerateDraftOrders(ctx, interfaces.ReplenishmentRequest{})
+		if err != nil {
+			w.log.Errorf("replenishment worker: %v", err)
+		} else if len(drafts.Orders) > 0 || len(drafts.Unassigned) > 0 {
+			w.log.Infof("replenishment worker: drafted %d purchase orders, %d products have no supplier",
+				len(drafts.Orders), len(drafts.Unassigned))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
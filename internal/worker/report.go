@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Defaults used when the Report config leaves a value at zero
+const (
+	defaultReportWorkers      = 2
+	defaultReportPollInterval = 5 * time.Second
+	defaultReportStaleAfter   = 15 * time.Minute
+)
+
+// ReportWorker generates queued reports in the background with a pool of goroutines
+type ReportWorker struct {
+	usecase      interfaces.ReportUsecase
+	log          *logrus.Logger
+	workers      int
+	pollInterval time.Duration
+	staleAfter   time.Duration
+}
+
+// NewReportWorker creates a report worker from the Report config
+func NewReportWorker(usecase interfaces.ReportUsecase, conf config.ReportAccount, log *logrus.Logger) *ReportWorker {
+	w := &ReportWorker{
+		usecase:      usecase,
+		log:          log,
+		workers:      conf.Workers,
+		pollInterval: time.Duration(conf.PollInterval) * time.Second,
+		staleAfter:   time.Duration(conf.StaleAfter) * time.Minute,
+	}
+	if w.workers <= 0 {
+		w.workers = defaultReportWorkers
+	}
+	if w.pollInterval <= 0 {
+		w.pollInterval = defaultReportPollInterval
+	}
+	if w.staleAfter <= 0 {
+		w.staleAfter = defaultReportStaleAfter
+	}
+	return w
+}
+
+// Start runs the workers until ctx is cancelled, the returned WaitGroup is done once all of them stopped
+func (w *ReportWorker) Start(ctx context.Context) *sync.WaitGroup {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.requeueStale(ctx)
+	}()
+
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			w.run(ctx, id)
+		}(i + 1)
+	}
+
+	w.log.Infof("report worker: started %d workers", w.workers)
+	return &wg
+}
+
+// run processes reports back to back and only waits when the queue is empty
+func (w *ReportWorker) run(ctx context.Context, id int) {
+	for {
+		processed, err := w.usecase.ProcessNextReport(ctx)
+		if err != nil {
+			w.log.Errorf("report worker %d: %v", id, err)
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// requeueStale returns reports abandoned by a crashed worker to the queue, at start and then every staleAfter
+func (w *ReportWorker) requeueStale(ctx context.Context) {
+	ticker := time.NewTicker(w.staleAfter)
+	defer ticker.Stop()
+
+	for {
+		count, err := w.usecase.RequeueStaleReports(ctx, w.staleAfter)
+		if err != nil {
+			w.log.Errorf("report worker: requeue stale reports: %v", err)
+		} else if count > 0 {
+			w.log.Warnf("report worker: requeued %d stale reports", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"boilerplate/config"
+	"boilerplate/internal/usecase/interfaces"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SchedulerWorker starts the scheduled jobs due at every minute; locks in the database make sure only one
+// instance runs a job and every schedule slot runs once, however many instances are started
+type SchedulerWorker struct {
+	usecase  interfaces.SchedulerUsecase
+	log      *logrus.Logger
+	disabled bool
+}
+
+// NewSchedulerWorker creates a scheduler worker from the Scheduler config
+func NewSchedulerWorker(usecase interfaces.SchedulerUsecase, conf config.SchedulerAccount, log *logrus.Logger) *SchedulerWorker {
+	return &SchedulerWorker{
+		usecase:  usecase,
+		log:      log,
+		disabled: conf.Disabled,
+	}
+}
+
+// Start runs the scheduler until ctx is cancelled, the returned WaitGroup is done once it and its running jobs stopped
+func (w *SchedulerWorker) Start(ctx context.Context) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	if w.disabled {
+		w.log.Info("scheduler worker: disabled, jobs only run when triggered")
+		return &wg
+	}
+
+	jobs, err := w.usecase.ListJobs(ctx)
+	if err != nil {
+		w.log.Errorf("scheduler worker: %v", err)
+	}
+	for _, job := range jobs {
+		if job.ScheduleError != "" {
+			w.log.Warnf("scheduler worker: job %s is not scheduled: %s", job.Name, job.ScheduleError)
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.run(ctx, &wg)
+	}()
+
+	w.log.Infof("scheduler worker: started with %d jobs", len(jobs))
+	return &wg
+}
+
+// run wakes up at the start of every minute and starts each due job in its own goroutine
+func (w *SchedulerWorker) run(ctx context.Context, wg *sync.WaitGroup) {
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+		}
+
+		if count, err := w.usecase.FailStaleRuns(ctx); err != nil {
+			w.log.Errorf("scheduler worker: fail stale runs: %v", err)
+		} else if count > 0 {
+			w.log.Warnf("scheduler worker: failed %d abandoned runs", count)
+		}
+
+		for _, name := range w.usecase.DueJobs(next) {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				w.runJob(ctx, name, next)
+			}(name)
+		}
+	}
+}
+
+func (w *SchedulerWorker) runJob(ctx context.Context, name string, slot time.Time) {
+	run, err := w.usecase.RunScheduled(ctx, name, slot)
+	switch {
+	case err != nil:
+		w.log.Errorf("scheduler worker: job %s: %v", name, err)
+	case run == nil:
+		// Another instance runs this slot
+	case run.ErrorMessage != nil:
+		w.log.Errorf("scheduler worker: job %s failed after %dms: %s", name, run.DurationMs, *run.ErrorMessage)
+	default:
+		w.log.Infof("scheduler worker: job %s finished in %dms", name, run.DurationMs)
+	}
+}
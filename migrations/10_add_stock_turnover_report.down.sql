@@ -0,0 +1,2 @@
+-- Enum values cannot be dropped, the reports using it are removed instead
+DELETE FROM reports WHERE report_type = 'Perputaran';
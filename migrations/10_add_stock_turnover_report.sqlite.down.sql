@@ -0,0 +1 @@
+DELETE FROM reports WHERE report_type = 'Perputaran';
@@ -0,0 +1 @@
+-- SQLite variant of 10_add_stock_turnover_report.up.sql, report_type is plain text
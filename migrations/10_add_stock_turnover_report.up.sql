@@ -0,0 +1,2 @@
+-- Slow-moving and dead-stock analysis can be exported as a report
+ALTER TYPE report_type_enum ADD VALUE IF NOT EXISTS 'Perputaran';
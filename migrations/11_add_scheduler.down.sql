@@ -0,0 +1,5 @@
+ALTER TABLE accounts_receivables DROP COLUMN IF EXISTS overdue_at;
+ALTER TABLE accounts_payables DROP COLUMN IF EXISTS overdue_at;
+
+DROP TABLE IF EXISTS job_runs CASCADE;
+DROP TABLE IF EXISTS job_locks CASCADE;
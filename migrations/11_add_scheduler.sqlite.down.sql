@@ -0,0 +1,5 @@
+ALTER TABLE accounts_receivables DROP COLUMN overdue_at;
+ALTER TABLE accounts_payables DROP COLUMN overdue_at;
+
+DROP TABLE IF EXISTS job_runs;
+DROP TABLE IF EXISTS job_locks;
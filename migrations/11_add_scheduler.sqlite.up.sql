@@ -0,0 +1,30 @@
+-- SQLite variant of 11_add_scheduler.up.sql
+CREATE TABLE job_locks (
+    job_name VARCHAR(100) PRIMARY KEY,
+    locked_by VARCHAR(255) NOT NULL,
+    locked_until DATETIME NOT NULL,
+    last_scheduled_at DATETIME,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE job_runs (
+    run_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_name VARCHAR(100) NOT NULL,
+    trigger_type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    instance VARCHAR(255) NOT NULL,
+    scheduled_at DATETIME,
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    result TEXT,
+    error_message TEXT,
+    triggered_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE INDEX idx_job_runs_job_name ON job_runs(job_name);
+CREATE INDEX idx_job_runs_status ON job_runs(status);
+CREATE INDEX idx_job_runs_started_at ON job_runs(started_at);
+
+ALTER TABLE accounts_payables ADD COLUMN overdue_at DATETIME;
+ALTER TABLE accounts_receivables ADD COLUMN overdue_at DATETIME;
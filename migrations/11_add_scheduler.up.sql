@@ -0,0 +1,31 @@
+-- Scheduled jobs: a lock row per job keeps replicas from running the same job or schedule slot twice,
+-- every run is recorded. Overdue payables and receivables are flagged by the overdue job.
+CREATE TABLE job_locks (
+    job_name VARCHAR(100) PRIMARY KEY,
+    locked_by VARCHAR(255) NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_scheduled_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE job_runs (
+    run_id SERIAL PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    trigger_type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    instance VARCHAR(255) NOT NULL,
+    scheduled_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    result TEXT,
+    error_message TEXT,
+    triggered_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE INDEX idx_job_runs_job_name ON job_runs(job_name);
+CREATE INDEX idx_job_runs_status ON job_runs(status);
+CREATE INDEX idx_job_runs_started_at ON job_runs(started_at);
+
+ALTER TABLE accounts_payables ADD COLUMN overdue_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE accounts_receivables ADD COLUMN overdue_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notification_opt_outs CASCADE;
+DROP TABLE IF EXISTS notifications CASCADE;
+
+ALTER TABLE customers DROP COLUMN IF EXISTS email;
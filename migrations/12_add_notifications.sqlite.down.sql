@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notification_opt_outs;
+DROP TABLE IF EXISTS notifications;
+
+ALTER TABLE customers DROP COLUMN email;
@@ -0,0 +1,39 @@
+-- SQLite variant of 12_add_notifications.up.sql
+ALTER TABLE customers ADD COLUMN email VARCHAR(255);
+
+CREATE TABLE notifications (
+    notification_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    customer_id INTEGER NOT NULL REFERENCES customers(customer_id),
+    channel VARCHAR(20) NOT NULL,
+    event VARCHAR(30) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'Pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME,
+    sent_at DATETIME,
+    provider_message_id VARCHAR(255),
+    error_message TEXT,
+    service_job_id INTEGER REFERENCES service_jobs(service_job_id),
+    receivable_id INTEGER REFERENCES accounts_receivables(receivable_id),
+    dedupe_key VARCHAR(255),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE INDEX idx_notifications_customer_id ON notifications(customer_id);
+CREATE INDEX idx_notifications_event ON notifications(event);
+CREATE INDEX idx_notifications_status ON notifications(status);
+CREATE INDEX idx_notifications_service_job_id ON notifications(service_job_id);
+CREATE INDEX idx_notifications_receivable_id ON notifications(receivable_id);
+CREATE UNIQUE INDEX idx_notifications_dedupe_key ON notifications(dedupe_key);
+
+CREATE TABLE notification_opt_outs (
+    customer_id INTEGER NOT NULL REFERENCES customers(customer_id),
+    channel VARCHAR(20) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER REFERENCES users(user_id),
+    PRIMARY KEY (customer_id, channel)
+);
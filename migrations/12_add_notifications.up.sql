@@ -0,0 +1,40 @@
+-- Customer notifications: messages queued per channel with their delivery status, retried by the
+-- notification worker, and the channels a customer opted out of.
+ALTER TABLE customers ADD COLUMN email VARCHAR(255);
+
+CREATE TABLE notifications (
+    notification_id SERIAL PRIMARY KEY,
+    customer_id INTEGER NOT NULL REFERENCES customers(customer_id),
+    channel VARCHAR(20) NOT NULL,
+    event VARCHAR(30) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'Pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    provider_message_id VARCHAR(255),
+    error_message TEXT,
+    service_job_id INTEGER REFERENCES service_jobs(service_job_id),
+    receivable_id INTEGER REFERENCES accounts_receivables(receivable_id),
+    dedupe_key VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE INDEX idx_notifications_customer_id ON notifications(customer_id);
+CREATE INDEX idx_notifications_event ON notifications(event);
+CREATE INDEX idx_notifications_status ON notifications(status);
+CREATE INDEX idx_notifications_service_job_id ON notifications(service_job_id);
+CREATE INDEX idx_notifications_receivable_id ON notifications(receivable_id);
+CREATE UNIQUE INDEX idx_notifications_dedupe_key ON notifications(dedupe_key);
+
+CREATE TABLE notification_opt_outs (
+    customer_id INTEGER NOT NULL REFERENCES customers(customer_id),
+    channel VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by INTEGER REFERENCES users(user_id),
+    PRIMARY KEY (customer_id, channel)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_service_jobs_tracking_token;
+
+ALTER TABLE service_jobs DROP COLUMN IF EXISTS estimated_completion_at;
+ALTER TABLE service_jobs DROP COLUMN IF EXISTS tracking_token;
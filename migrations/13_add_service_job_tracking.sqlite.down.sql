@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_service_jobs_tracking_token;
+
+ALTER TABLE service_jobs DROP COLUMN estimated_completion_at;
+ALTER TABLE service_jobs DROP COLUMN tracking_token;
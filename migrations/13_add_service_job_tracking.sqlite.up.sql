@@ -0,0 +1,8 @@
+-- SQLite variant of 13_add_service_job_tracking.up.sql
+ALTER TABLE service_jobs ADD COLUMN tracking_token VARCHAR(64);
+ALTER TABLE service_jobs ADD COLUMN estimated_completion_at DATETIME;
+
+UPDATE service_jobs SET tracking_token = lower(hex(randomblob(16)))
+WHERE tracking_token IS NULL;
+
+CREATE UNIQUE INDEX idx_service_jobs_tracking_token ON service_jobs(tracking_token);
@@ -0,0 +1,10 @@
+-- Public job tracking: an unguessable token per service job for the tracking page and the completion
+-- time the customer is told. Existing jobs get a random UUID like new ones (gen_random_uuid is built in from
+-- PostgreSQL 13).
+ALTER TABLE service_jobs ADD COLUMN tracking_token VARCHAR(64);
+ALTER TABLE service_jobs ADD COLUMN estimated_completion_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE service_jobs SET tracking_token = gen_random_uuid()::text
+WHERE tracking_token IS NULL;
+
+CREATE UNIQUE INDEX idx_service_jobs_tracking_token ON service_jobs(tracking_token);
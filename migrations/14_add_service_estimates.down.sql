@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS service_estimate_items CASCADE;
+DROP TABLE IF EXISTS service_estimates CASCADE;
@@ -0,0 +1,40 @@
+-- Repair estimates: versioned quotations of a service job the customer approves or rejects through a signed
+-- link or at the counter, with who decided, when and through which channel.
+CREATE TABLE service_estimates (
+    estimate_id SERIAL PRIMARY KEY,
+    service_job_id INTEGER NOT NULL REFERENCES service_jobs(service_job_id),
+    version INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'Menunggu',
+    parts_total DECIMAL(15,2) NOT NULL DEFAULT 0,
+    labour_total DECIMAL(15,2) NOT NULL DEFAULT 0,
+    total DECIMAL(15,2) NOT NULL DEFAULT 0,
+    extra_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    requires_call BOOLEAN NOT NULL DEFAULT FALSE,
+    notes TEXT,
+    link_expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_channel VARCHAR(20),
+    decided_by_name VARCHAR(255),
+    decided_by_user_id INTEGER REFERENCES users(user_id),
+    decision_note TEXT,
+    decision_ip VARCHAR(45),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE UNIQUE INDEX idx_service_estimates_job_version ON service_estimates(service_job_id, version);
+CREATE INDEX idx_service_estimates_status ON service_estimates(status);
+
+CREATE TABLE service_estimate_items (
+    estimate_item_id SERIAL PRIMARY KEY,
+    estimate_id INTEGER NOT NULL REFERENCES service_estimates(estimate_id) ON DELETE CASCADE,
+    item_type VARCHAR(20) NOT NULL,
+    item_id INTEGER NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL,
+    price_per_item DECIMAL(15,2) NOT NULL,
+    subtotal DECIMAL(15,2) NOT NULL
+);
+
+CREATE INDEX idx_service_estimate_items_estimate_id ON service_estimate_items(estimate_id);
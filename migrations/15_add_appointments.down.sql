@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS outlet_holidays CASCADE;
+DROP TABLE IF EXISTS outlet_opening_hours CASCADE;
+DROP TABLE IF EXISTS appointment_services CASCADE;
+DROP TABLE IF EXISTS appointments CASCADE;
+
+ALTER TABLE service_jobs DROP COLUMN IF EXISTS priority;
+ALTER TABLE services DROP COLUMN IF EXISTS standard_minutes;
+ALTER TABLE outlets DROP COLUMN IF EXISTS bay_count;
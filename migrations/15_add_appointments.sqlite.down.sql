@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS outlet_holidays;
+DROP TABLE IF EXISTS outlet_opening_hours;
+DROP TABLE IF EXISTS appointment_services;
+DROP TABLE IF EXISTS appointments;
+
+ALTER TABLE service_jobs DROP COLUMN priority;
+ALTER TABLE services DROP COLUMN standard_minutes;
+ALTER TABLE outlets DROP COLUMN bay_count;
@@ -0,0 +1,64 @@
+-- Appointments: vehicles booked into an outlet for a slot within its opening hours and outside its holidays,
+-- limited by the outlet's bays and the technician's other appointments. A checked in appointment becomes a
+-- service job queued ahead of the walk-ins.
+ALTER TABLE outlets ADD COLUMN bay_count INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE services ADD COLUMN standard_minutes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE service_jobs ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE appointments (
+    appointment_id SERIAL PRIMARY KEY,
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    customer_id INTEGER NOT NULL REFERENCES customers(customer_id),
+    vehicle_id INTEGER NOT NULL REFERENCES customer_vehicles(vehicle_id),
+    technician_id INTEGER REFERENCES users(user_id),
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    duration_minutes INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'Dijadwalkan',
+    notes TEXT,
+    service_job_id INTEGER REFERENCES service_jobs(service_job_id),
+    arrived_at TIMESTAMP WITH TIME ZONE,
+    no_show_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    cancel_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE INDEX idx_appointments_outlet_start ON appointments(outlet_id, start_at);
+CREATE INDEX idx_appointments_technician_start ON appointments(technician_id, start_at);
+CREATE INDEX idx_appointments_customer_id ON appointments(customer_id);
+CREATE INDEX idx_appointments_vehicle_id ON appointments(vehicle_id);
+CREATE INDEX idx_appointments_status_start ON appointments(status, start_at);
+CREATE INDEX idx_appointments_service_job_id ON appointments(service_job_id);
+
+CREATE TABLE appointment_services (
+    appointment_service_id SERIAL PRIMARY KEY,
+    appointment_id INTEGER NOT NULL REFERENCES appointments(appointment_id) ON DELETE CASCADE,
+    service_id INTEGER NOT NULL REFERENCES services(service_id),
+    name VARCHAR(255) NOT NULL,
+    standard_minutes INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_appointment_services_appointment_id ON appointment_services(appointment_id);
+
+CREATE TABLE outlet_opening_hours (
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id) ON DELETE CASCADE,
+    weekday INTEGER NOT NULL,
+    opens_at VARCHAR(5) NOT NULL,
+    closes_at VARCHAR(5) NOT NULL,
+    PRIMARY KEY (outlet_id, weekday)
+);
+
+CREATE TABLE outlet_holidays (
+    holiday_id SERIAL PRIMARY KEY,
+    outlet_id INTEGER REFERENCES outlets(outlet_id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE INDEX idx_outlet_holidays_date ON outlet_holidays(date);
+CREATE INDEX idx_outlet_holidays_outlet_id ON outlet_holidays(outlet_id);
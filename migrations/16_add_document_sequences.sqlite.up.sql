@@ -0,0 +1,8 @@
+-- SQLite variant of 16_add_document_sequences.up.sql
+CREATE TABLE document_sequences (
+    name VARCHAR(50) NOT NULL,
+    scope VARCHAR(255) NOT NULL,
+    last_value INTEGER NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (name, scope)
+);
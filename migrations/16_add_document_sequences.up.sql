@@ -0,0 +1,9 @@
+-- Document numbering: one counter per document type and scope, the number pattern with everything but {SEQ}
+-- filled in, so a counter restarts whenever the outlet or the period in the number changes.
+CREATE TABLE document_sequences (
+    name VARCHAR(50) NOT NULL,
+    scope VARCHAR(255) NOT NULL,
+    last_value BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (name, scope)
+);
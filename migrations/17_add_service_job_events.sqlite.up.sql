@@ -0,0 +1,12 @@
+-- SQLite variant of 17_add_service_job_events.up.sql
+CREATE TABLE service_job_events (
+    event_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    service_job_id INTEGER NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    payload TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_service_job_events_outlet_id ON service_job_events(outlet_id);
+CREATE INDEX idx_service_job_events_created_at ON service_job_events(created_at);
@@ -0,0 +1,13 @@
+-- Live board events: changes to service jobs streamed to the workshop boards of their outlet. Clients resume after
+-- the last event ID they saw; events are pruned by the board-event-cleanup job.
+CREATE TABLE service_job_events (
+    event_id SERIAL PRIMARY KEY,
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    service_job_id INTEGER NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_service_job_events_outlet_id ON service_job_events(outlet_id);
+CREATE INDEX idx_service_job_events_created_at ON service_job_events(created_at);
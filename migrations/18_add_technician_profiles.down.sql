@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS technician_shifts CASCADE;
+DROP TABLE IF EXISTS technician_skills CASCADE;
+DROP TABLE IF EXISTS technician_profiles CASCADE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS technician_shifts;
+DROP TABLE IF EXISTS technician_skills;
+DROP TABLE IF EXISTS technician_profiles;
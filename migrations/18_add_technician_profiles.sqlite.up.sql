@@ -0,0 +1,28 @@
+-- SQLite variant of 18_add_technician_profiles.up.sql
+CREATE TABLE technician_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(user_id),
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE TABLE technician_skills (
+    user_id INTEGER NOT NULL REFERENCES technician_profiles(user_id) ON DELETE CASCADE,
+    service_category_id INTEGER NOT NULL REFERENCES service_categories(service_category_id),
+    PRIMARY KEY (user_id, service_category_id)
+);
+
+CREATE TABLE technician_shifts (
+    user_id INTEGER NOT NULL REFERENCES technician_profiles(user_id) ON DELETE CASCADE,
+    weekday INTEGER NOT NULL,
+    starts_at VARCHAR(5) NOT NULL,
+    ends_at VARCHAR(5) NOT NULL,
+    PRIMARY KEY (user_id, weekday)
+);
+
+INSERT INTO technician_profiles (user_id)
+SELECT technician_id FROM service_jobs WHERE technician_id IS NOT NULL
+UNION
+SELECT technician_id FROM appointments WHERE technician_id IS NOT NULL;
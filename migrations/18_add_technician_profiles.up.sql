@@ -0,0 +1,30 @@
+-- Technician profiles: the users who work on service jobs, with the service categories they are qualified for and
+-- their weekly shifts. Only active technicians are assigned to jobs and appointments, so the users already assigned
+-- as technicians get a profile.
+CREATE TABLE technician_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(user_id),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE TABLE technician_skills (
+    user_id INTEGER NOT NULL REFERENCES technician_profiles(user_id) ON DELETE CASCADE,
+    service_category_id INTEGER NOT NULL REFERENCES service_categories(service_category_id),
+    PRIMARY KEY (user_id, service_category_id)
+);
+
+CREATE TABLE technician_shifts (
+    user_id INTEGER NOT NULL REFERENCES technician_profiles(user_id) ON DELETE CASCADE,
+    weekday INTEGER NOT NULL,
+    starts_at VARCHAR(5) NOT NULL,
+    ends_at VARCHAR(5) NOT NULL,
+    PRIMARY KEY (user_id, weekday)
+);
+
+INSERT INTO technician_profiles (user_id)
+SELECT technician_id FROM service_jobs WHERE technician_id IS NOT NULL
+UNION
+SELECT technician_id FROM appointments WHERE technician_id IS NOT NULL;
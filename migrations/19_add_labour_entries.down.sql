@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS labour_entries CASCADE;
+
+-- Enum values cannot be dropped, the reports using it are removed instead
+DELETE FROM reports WHERE report_type = 'Efisiensi';
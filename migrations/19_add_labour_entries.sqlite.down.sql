@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS labour_entries;
+
+DELETE FROM reports WHERE report_type = 'Efisiensi';
@@ -0,0 +1,21 @@
+-- SQLite variant of 19_add_labour_entries.up.sql, report_type is plain text
+CREATE TABLE labour_entries (
+    entry_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    service_job_id INTEGER NOT NULL REFERENCES service_jobs(service_job_id),
+    detail_id INTEGER REFERENCES service_details(detail_id) ON DELETE SET NULL,
+    technician_id INTEGER NOT NULL REFERENCES users(user_id),
+    kind VARCHAR(10) NOT NULL,
+    pause_reason VARCHAR(30),
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME,
+    finished BOOLEAN NOT NULL DEFAULT 0,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_labour_entries_service_job_id ON labour_entries(service_job_id);
+CREATE INDEX idx_labour_entries_detail_id ON labour_entries(detail_id);
+CREATE INDEX idx_labour_entries_technician_id ON labour_entries(technician_id);
+CREATE INDEX idx_labour_entries_started_at ON labour_entries(started_at);
+-- A technician works on one job at a time
+CREATE UNIQUE INDEX idx_labour_entries_open_work ON labour_entries(technician_id) WHERE ended_at IS NULL AND kind = 'Kerja';
@@ -0,0 +1,24 @@
+-- Labour time: technicians clock their work on service jobs and service lines, with the pauses and their reasons.
+-- The efficiency of the clocked time against the services' standard time can be exported as a report.
+ALTER TYPE report_type_enum ADD VALUE IF NOT EXISTS 'Efisiensi';
+
+CREATE TABLE labour_entries (
+    entry_id SERIAL PRIMARY KEY,
+    service_job_id INTEGER NOT NULL REFERENCES service_jobs(service_job_id),
+    detail_id INTEGER REFERENCES service_details(detail_id) ON DELETE SET NULL,
+    technician_id INTEGER NOT NULL REFERENCES users(user_id),
+    kind VARCHAR(10) NOT NULL,
+    pause_reason VARCHAR(30),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    finished BOOLEAN NOT NULL DEFAULT FALSE,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_labour_entries_service_job_id ON labour_entries(service_job_id);
+CREATE INDEX idx_labour_entries_detail_id ON labour_entries(detail_id);
+CREATE INDEX idx_labour_entries_technician_id ON labour_entries(technician_id);
+CREATE INDEX idx_labour_entries_started_at ON labour_entries(started_at);
+-- A technician works on one job at a time
+CREATE UNIQUE INDEX idx_labour_entries_open_work ON labour_entries(technician_id) WHERE ended_at IS NULL AND kind = 'Kerja';
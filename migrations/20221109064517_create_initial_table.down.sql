@@ -1,4 +0,0 @@
-DROP TABLE IF EXISTS "position";
-DROP TABLE IF EXISTS "type_of_work";
-DROP TABLE IF EXISTS "location";
-DROP TABLE IF EXISTS "job";
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS special_orders CASCADE;
+
+-- Enum values cannot be dropped, waiting jobs go back to the queue instead
+UPDATE service_jobs SET status = 'Antri' WHERE status = 'Menunggu Sparepart';
+UPDATE service_job_histories SET status = NULL WHERE status = 'Menunggu Sparepart';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS special_orders;
+
+UPDATE service_jobs SET status = 'Antri' WHERE status = 'Menunggu Sparepart';
+UPDATE service_job_histories SET status = NULL WHERE status = 'Menunggu Sparepart';
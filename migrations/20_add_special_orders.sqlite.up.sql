@@ -0,0 +1,22 @@
+-- SQLite variant of 20_add_special_orders.up.sql, service job statuses are plain text
+CREATE TABLE special_orders (
+    special_order_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    service_job_id INTEGER NOT NULL REFERENCES service_jobs(service_job_id),
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    product_id INTEGER NOT NULL REFERENCES products(product_id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL,
+    purchase_order_id INTEGER REFERENCES purchase_orders(purchase_order_id) ON DELETE SET NULL,
+    detail_id INTEGER REFERENCES service_details(detail_id) ON DELETE SET NULL,
+    notes TEXT,
+    received_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE INDEX idx_special_orders_service_job_id ON special_orders(service_job_id);
+CREATE INDEX idx_special_orders_outlet_id ON special_orders(outlet_id);
+CREATE INDEX idx_special_orders_product_id ON special_orders(product_id);
+CREATE INDEX idx_special_orders_status ON special_orders(status);
+CREATE INDEX idx_special_orders_purchase_order_id ON special_orders(purchase_order_id);
@@ -0,0 +1,25 @@
+-- Special orders: service jobs wait for parts that are not in stock as Menunggu Sparepart. The parts are requested
+-- per job, rolled into draft purchase orders and reserved for the job when the order is received.
+ALTER TYPE service_status_enum ADD VALUE IF NOT EXISTS 'Menunggu Sparepart';
+
+CREATE TABLE special_orders (
+    special_order_id SERIAL PRIMARY KEY,
+    service_job_id INTEGER NOT NULL REFERENCES service_jobs(service_job_id),
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    product_id INTEGER NOT NULL REFERENCES products(product_id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL,
+    purchase_order_id INTEGER REFERENCES purchase_orders(purchase_order_id) ON DELETE SET NULL,
+    detail_id INTEGER REFERENCES service_details(detail_id) ON DELETE SET NULL,
+    notes TEXT,
+    received_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by INTEGER REFERENCES users(user_id)
+);
+
+CREATE INDEX idx_special_orders_service_job_id ON special_orders(service_job_id);
+CREATE INDEX idx_special_orders_outlet_id ON special_orders(outlet_id);
+CREATE INDEX idx_special_orders_product_id ON special_orders(product_id);
+CREATE INDEX idx_special_orders_status ON special_orders(status);
+CREATE INDEX idx_special_orders_purchase_order_id ON special_orders(purchase_order_id);
@@ -0,0 +1,3 @@
+DELETE FROM stock_movements WHERE reference_type = 'opening' AND notes = 'opening balance without stock';
+
+UPDATE stock_movements SET reference_type = NULL, reference_id = NULL WHERE reference_type = 'opening';
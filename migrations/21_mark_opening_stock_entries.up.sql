@@ -0,0 +1,12 @@
+-- Opening entries of the stock ledger are marked by their reference type instead of their notes, which can be edited
+UPDATE stock_movements
+SET reference_type = 'opening', reference_id = product_id
+WHERE movement_type = 'adjustment' AND reference_type IS NULL AND notes IN ('opening balance', 'opening stock');
+
+-- A product without an opening entry had no stock when its ledger opened, its ledger opens at zero
+INSERT INTO stock_movements (product_id, movement_type, quantity, unit_cost, reference_type, reference_id, notes, created_at)
+SELECT p.product_id, 'adjustment', 0, p.cost_price, 'opening', p.product_id, 'opening balance without stock', p.created_at
+FROM products p
+WHERE NOT EXISTS (
+    SELECT 1 FROM stock_movements m WHERE m.product_id = p.product_id AND m.reference_type = 'opening'
+);
@@ -0,0 +1,32 @@
+-- Drop tables in reverse order to handle dependencies
+DROP TABLE IF EXISTS promotions;
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS cash_flows;
+DROP TABLE IF EXISTS receivable_payments;
+DROP TABLE IF EXISTS accounts_receivables;
+DROP TABLE IF EXISTS payable_payments;
+DROP TABLE IF EXISTS accounts_payables;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS payment_methods;
+DROP TABLE IF EXISTS vehicle_purchases;
+DROP TABLE IF EXISTS purchase_order_details;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS transaction_details;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS service_job_histories;
+DROP TABLE IF EXISTS service_details;
+DROP TABLE IF EXISTS service_jobs;
+DROP TABLE IF EXISTS services;
+DROP TABLE IF EXISTS service_categories;
+DROP TABLE IF EXISTS product_serial_numbers;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS unit_types;
+DROP TABLE IF EXISTS suppliers;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS customer_vehicles;
+DROP TABLE IF EXISTS customers;
+DROP TABLE IF EXISTS role_has_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS outlets;
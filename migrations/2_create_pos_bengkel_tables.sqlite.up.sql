@@ -0,0 +1,451 @@
+-- SQLite variant of 2_create_pos_bengkel_tables.up.sql (enums are stored as TEXT)
+-- Foundation & Security Tables
+CREATE TABLE outlets (
+    outlet_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    outlet_name VARCHAR(255) NOT NULL,
+    branch_type VARCHAR(50) NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    address TEXT,
+    phone_number VARCHAR(20),
+    status TEXT NOT NULL DEFAULT 'Aktif',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE users (
+    user_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password VARCHAR(255) NOT NULL,
+    outlet_id INTEGER REFERENCES outlets(outlet_id),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE roles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE permissions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE role_has_permissions (
+    permission_id INTEGER REFERENCES permissions(id) ON DELETE CASCADE,
+    role_id INTEGER REFERENCES roles(id) ON DELETE CASCADE,
+    PRIMARY KEY (permission_id, role_id)
+);
+
+-- Customer & Vehicle Tables
+CREATE TABLE customers (
+    customer_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    phone_number VARCHAR(20) UNIQUE NOT NULL,
+    address TEXT,
+    status TEXT NOT NULL DEFAULT 'Aktif',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE customer_vehicles (
+    vehicle_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    customer_id INTEGER NOT NULL REFERENCES customers(customer_id),
+    plate_number VARCHAR(20) UNIQUE NOT NULL,
+    brand VARCHAR(100) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    type VARCHAR(100) NOT NULL,
+    production_year INTEGER NOT NULL,
+    chassis_number VARCHAR(100) UNIQUE NOT NULL,
+    engine_number VARCHAR(100) UNIQUE NOT NULL,
+    color VARCHAR(50) NOT NULL,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+-- Master Data & Inventory Tables
+CREATE TABLE categories (
+    category_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Aktif',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE suppliers (
+    supplier_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    supplier_name VARCHAR(255) NOT NULL,
+    contact_person_name VARCHAR(255) NOT NULL,
+    phone_number VARCHAR(20) NOT NULL,
+    address TEXT,
+    status TEXT NOT NULL DEFAULT 'Aktif',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE unit_types (
+    unit_type_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Aktif',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE products (
+    product_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_name VARCHAR(255) NOT NULL,
+    product_description TEXT,
+    product_image VARCHAR(255),
+    cost_price DECIMAL(15, 2) NOT NULL,
+    selling_price DECIMAL(15, 2) NOT NULL,
+    stock INTEGER NOT NULL DEFAULT 0,
+    sku VARCHAR(100) UNIQUE,
+    barcode VARCHAR(100) UNIQUE,
+    has_serial_number BOOLEAN NOT NULL DEFAULT FALSE,
+    shelf_location VARCHAR(100),
+    usage_status TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    category_id INTEGER REFERENCES categories(category_id),
+    supplier_id INTEGER REFERENCES suppliers(supplier_id),
+    unit_type_id INTEGER REFERENCES unit_types(unit_type_id),
+    sourceable_id INTEGER,
+    sourceable_type VARCHAR(255),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE product_serial_numbers (
+    serial_number_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id INTEGER NOT NULL REFERENCES products(product_id),
+    serial_number VARCHAR(255) UNIQUE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Tersedia',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+-- Service Tables
+CREATE TABLE service_categories (
+    service_category_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Aktif',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE services (
+    service_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    service_code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    service_category_id INTEGER NOT NULL REFERENCES service_categories(service_category_id),
+    fee DECIMAL(15, 2) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Aktif',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+-- Core Operations Tables
+CREATE TABLE service_jobs (
+    service_job_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    service_code VARCHAR(50) UNIQUE NOT NULL,
+    queue_number INTEGER NOT NULL,
+    customer_id INTEGER NOT NULL REFERENCES customers(customer_id),
+    vehicle_id INTEGER NOT NULL REFERENCES customer_vehicles(vehicle_id),
+    technician_id INTEGER REFERENCES users(user_id),
+    received_by_user_id INTEGER NOT NULL REFERENCES users(user_id),
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    problem_description TEXT NOT NULL,
+    technician_notes TEXT,
+    status TEXT NOT NULL,
+    service_in_date DATETIME NOT NULL,
+    picked_up_date DATETIME,
+    complain_date DATETIME,
+    warranty_expires_at DATE,
+    next_service_reminder_date DATE,
+    down_payment DECIMAL(15, 2) DEFAULT 0,
+    grand_total DECIMAL(15, 2) DEFAULT 0,
+    technician_commission DECIMAL(15, 2) DEFAULT 0,
+    shop_profit DECIMAL(15, 2) DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE service_details (
+    detail_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    service_job_id INTEGER NOT NULL REFERENCES service_jobs(service_job_id),
+    item_id INTEGER NOT NULL,
+    item_type VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    serial_number_used VARCHAR(255),
+    quantity INTEGER NOT NULL,
+    price_per_item DECIMAL(15, 2) NOT NULL,
+    cost_per_item DECIMAL(15, 2) NOT NULL
+);
+
+CREATE TABLE service_job_histories (
+    history_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    service_job_id INTEGER NOT NULL REFERENCES service_jobs(service_job_id),
+    user_id INTEGER NOT NULL REFERENCES users(user_id),
+    notes TEXT,
+    changed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Transaction Tables
+CREATE TABLE transactions (
+    transaction_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    invoice_number VARCHAR(255) UNIQUE NOT NULL,
+    transaction_date DATETIME NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(user_id),
+    customer_id INTEGER REFERENCES customers(customer_id),
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    transaction_type VARCHAR(255) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'sukses',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE transaction_details (
+    detail_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_type VARCHAR(255) NOT NULL,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(transaction_id),
+    product_id INTEGER REFERENCES products(product_id),
+    serial_number_id INTEGER REFERENCES product_serial_numbers(serial_number_id),
+    quantity INTEGER NOT NULL,
+    unit_price DECIMAL(15, 2) NOT NULL,
+    total_price DECIMAL(15, 2) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE purchase_orders (
+    purchase_order_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    po_code VARCHAR(50) UNIQUE NOT NULL,
+    supplier_id INTEGER NOT NULL REFERENCES suppliers(supplier_id),
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    po_date DATE NOT NULL,
+    total_amount DECIMAL(15, 2) NOT NULL,
+    amount_paid DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    change_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    payment_type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Selesai',
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE purchase_order_details (
+    detail_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(purchase_order_id),
+    product_id INTEGER NOT NULL REFERENCES products(product_id),
+    quantity INTEGER NOT NULL,
+    cost_price DECIMAL(15, 2) NOT NULL
+);
+
+CREATE TABLE vehicle_purchases (
+    purchase_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    purchase_code VARCHAR(50) UNIQUE NOT NULL,
+    customer_id INTEGER REFERENCES customers(customer_id),
+    user_id INTEGER NOT NULL REFERENCES users(user_id),
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    purchase_date DATE NOT NULL,
+    purchase_price DECIMAL(15, 2) NOT NULL,
+    vehicle_snapshot TEXT NOT NULL,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+-- Financial Tables
+CREATE TABLE payment_methods (
+    method_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Aktif',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE payments (
+    payment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(transaction_id),
+    method_id INTEGER NOT NULL REFERENCES payment_methods(method_id),
+    amount DECIMAL(15, 2) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'sukses',
+    payment_date DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE accounts_payables (
+    payable_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(purchase_order_id),
+    supplier_id INTEGER NOT NULL REFERENCES suppliers(supplier_id),
+    total_amount DECIMAL(15, 2) NOT NULL,
+    amount_paid DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    due_date DATE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Belum Lunas',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE payable_payments (
+    payment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    payable_id INTEGER NOT NULL REFERENCES accounts_payables(payable_id),
+    payment_date DATE NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE accounts_receivables (
+    receivable_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(transaction_id),
+    customer_id INTEGER NOT NULL REFERENCES customers(customer_id),
+    total_amount DECIMAL(15, 2) NOT NULL,
+    amount_paid DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    due_date DATE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Belum Lunas',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE receivable_payments (
+    payment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    receivable_id INTEGER NOT NULL REFERENCES accounts_receivables(receivable_id),
+    payment_date DATE NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE cash_flows (
+    cash_flow_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL,
+    source VARCHAR(255) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    date DATE NOT NULL,
+    notes TEXT,
+    user_id INTEGER NOT NULL REFERENCES users(user_id),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+-- Reporting & Promotion Tables
+CREATE TABLE reports (
+    report_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    report_name VARCHAR(255) UNIQUE NOT NULL,
+    report_type TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    outlet_id INTEGER REFERENCES outlets(outlet_id),
+    user_id INTEGER NOT NULL REFERENCES users(user_id),
+    generated_file_path VARCHAR(255),
+    status TEXT DEFAULT 'Pending',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+CREATE TABLE promotions (
+    promotion_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    promotion_name VARCHAR(255) NOT NULL,
+    start_date DATETIME NOT NULL,
+    end_date DATETIME NOT NULL,
+    type VARCHAR(50) CHECK (type IN ('percentage', 'fixed')) NOT NULL,
+    value DECIMAL(15, 2) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    created_by INTEGER
+);
+
+-- Create indexes for better performance
+CREATE INDEX idx_users_outlet_id ON users(outlet_id);
+CREATE INDEX idx_users_email ON users(email);
+CREATE INDEX idx_customers_phone_number ON customers(phone_number);
+CREATE INDEX idx_customer_vehicles_customer_id ON customer_vehicles(customer_id);
+CREATE INDEX idx_customer_vehicles_plate_number ON customer_vehicles(plate_number);
+CREATE INDEX idx_products_category_id ON products(category_id);
+CREATE INDEX idx_products_supplier_id ON products(supplier_id);
+CREATE INDEX idx_products_unit_type_id ON products(unit_type_id);
+CREATE INDEX idx_products_sku ON products(sku);
+CREATE INDEX idx_products_barcode ON products(barcode);
+CREATE INDEX idx_product_serial_numbers_product_id ON product_serial_numbers(product_id);
+CREATE INDEX idx_product_serial_numbers_serial_number ON product_serial_numbers(serial_number);
+CREATE INDEX idx_services_service_category_id ON services(service_category_id);
+CREATE INDEX idx_services_service_code ON services(service_code);
+CREATE INDEX idx_service_jobs_customer_id ON service_jobs(customer_id);
+CREATE INDEX idx_service_jobs_vehicle_id ON service_jobs(vehicle_id);
+CREATE INDEX idx_service_jobs_technician_id ON service_jobs(technician_id);
+CREATE INDEX idx_service_jobs_outlet_id ON service_jobs(outlet_id);
+CREATE INDEX idx_service_jobs_service_code ON service_jobs(service_code);
+CREATE INDEX idx_service_details_service_job_id ON service_details(service_job_id);
+CREATE INDEX idx_transactions_user_id ON transactions(user_id);
+CREATE INDEX idx_transactions_customer_id ON transactions(customer_id);
+CREATE INDEX idx_transactions_outlet_id ON transactions(outlet_id);
+CREATE INDEX idx_transaction_details_transaction_id ON transaction_details(transaction_id);
+CREATE INDEX idx_transaction_details_product_id ON transaction_details(product_id);
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders(supplier_id);
+CREATE INDEX idx_purchase_orders_outlet_id ON purchase_orders(outlet_id);
+CREATE INDEX idx_payments_transaction_id ON payments(transaction_id);
+CREATE INDEX idx_payments_method_id ON payments(method_id);
+CREATE INDEX idx_accounts_payables_purchase_order_id ON accounts_payables(purchase_order_id);
+CREATE INDEX idx_accounts_receivables_transaction_id ON accounts_receivables(transaction_id);
+CREATE INDEX idx_cash_flows_user_id ON cash_flows(user_id);
+CREATE INDEX idx_reports_outlet_id ON reports(outlet_id);
+CREATE INDEX idx_reports_user_id ON reports(user_id);
\ No newline at end of file
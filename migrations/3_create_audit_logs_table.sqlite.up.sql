@@ -0,0 +1,19 @@
+-- SQLite variant of 3_create_audit_logs_table.up.sql
+-- Append-only audit trail written by the GORM audit callbacks
+CREATE TABLE audit_logs (
+    audit_log_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity VARCHAR(100) NOT NULL,
+    entity_id VARCHAR(100),
+    action VARCHAR(20) NOT NULL,
+    user_id INTEGER,
+    outlet_id INTEGER,
+    before TEXT,
+    after TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity);
+CREATE INDEX idx_audit_logs_entity_id ON audit_logs(entity_id);
+CREATE INDEX idx_audit_logs_user_id ON audit_logs(user_id);
+CREATE INDEX idx_audit_logs_outlet_id ON audit_logs(outlet_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
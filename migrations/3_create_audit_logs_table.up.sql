@@ -0,0 +1,18 @@
+-- Append-only audit trail written by the GORM audit callbacks
+CREATE TABLE audit_logs (
+    audit_log_id SERIAL PRIMARY KEY,
+    entity VARCHAR(100) NOT NULL,
+    entity_id VARCHAR(100),
+    action VARCHAR(20) NOT NULL,
+    user_id INTEGER,
+    outlet_id INTEGER,
+    before TEXT,
+    after TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity);
+CREATE INDEX idx_audit_logs_entity_id ON audit_logs(entity_id);
+CREATE INDEX idx_audit_logs_user_id ON audit_logs(user_id);
+CREATE INDEX idx_audit_logs_outlet_id ON audit_logs(outlet_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
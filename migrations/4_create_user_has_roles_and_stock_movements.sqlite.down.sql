@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS user_has_roles;
@@ -0,0 +1,30 @@
+CREATE TABLE user_has_roles (
+    user_id INTEGER REFERENCES users(user_id) ON DELETE CASCADE,
+    role_id INTEGER REFERENCES roles(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+-- Stock ledger: every change of products.stock is recorded here
+CREATE TABLE stock_movements (
+    movement_id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(product_id),
+    outlet_id INTEGER REFERENCES outlets(outlet_id),
+    movement_type VARCHAR(20) NOT NULL,
+    quantity INTEGER NOT NULL,
+    reference_type VARCHAR(50),
+    reference_id INTEGER,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by INTEGER
+);
+
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id);
+CREATE INDEX idx_stock_movements_outlet_id ON stock_movements(outlet_id);
+CREATE INDEX idx_stock_movements_movement_type ON stock_movements(movement_type);
+CREATE INDEX idx_stock_movements_created_at ON stock_movements(created_at);
+
+-- The ledger opens with the stock on hand, also for products without stock, so the stock can be recalculated from it
+INSERT INTO stock_movements (product_id, movement_type, quantity, reference_type, reference_id, notes, created_at)
+SELECT product_id, 'adjustment', stock, 'opening', product_id, 'opening balance', NOW()
+FROM products
+WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_reports_status;
+
+ALTER TABLE reports DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE reports DROP COLUMN IF EXISTS error_message;
+ALTER TABLE reports DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE reports DROP COLUMN IF EXISTS attempts;
+ALTER TABLE reports DROP COLUMN IF EXISTS format;
+
+-- Enum values cannot be dropped, reports left in Diproses go back to the queue
+UPDATE reports SET status = 'Pending' WHERE status = 'Diproses';
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_reports_status;
+
+ALTER TABLE reports DROP COLUMN completed_at;
+ALTER TABLE reports DROP COLUMN error_message;
+ALTER TABLE reports DROP COLUMN next_attempt_at;
+ALTER TABLE reports DROP COLUMN attempts;
+ALTER TABLE reports DROP COLUMN format;
+
+UPDATE reports SET status = 'Pending' WHERE status = 'Diproses';
@@ -0,0 +1,8 @@
+-- SQLite variant of 5_add_report_generation_columns.up.sql
+ALTER TABLE reports ADD COLUMN format VARCHAR(10) NOT NULL DEFAULT 'csv';
+ALTER TABLE reports ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reports ADD COLUMN next_attempt_at DATETIME;
+ALTER TABLE reports ADD COLUMN error_message TEXT;
+ALTER TABLE reports ADD COLUMN completed_at DATETIME;
+
+CREATE INDEX idx_reports_status ON reports(status);
@@ -0,0 +1,11 @@
+-- Reports are generated by a background worker: the queue lives in the reports table
+-- (status Pending/Diproses), failed attempts are retried at next_attempt_at
+ALTER TYPE report_status ADD VALUE IF NOT EXISTS 'Diproses';
+
+ALTER TABLE reports ADD COLUMN format VARCHAR(10) NOT NULL DEFAULT 'csv';
+ALTER TABLE reports ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reports ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE reports ADD COLUMN error_message TEXT;
+ALTER TABLE reports ADD COLUMN completed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_reports_status ON reports(status);
@@ -0,0 +1,2 @@
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS unit_cost;
+ALTER TABLE transaction_details DROP COLUMN IF EXISTS unit_cost;
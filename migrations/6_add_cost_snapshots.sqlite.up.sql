@@ -0,0 +1,14 @@
+-- SQLite variant of 6_add_cost_snapshots.up.sql
+ALTER TABLE transaction_details ADD COLUMN unit_cost DECIMAL(15,2) NOT NULL DEFAULT 0;
+ALTER TABLE stock_movements ADD COLUMN unit_cost DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+UPDATE transaction_details SET unit_cost = COALESCE(
+    (SELECT p.cost_price FROM products p WHERE p.product_id = transaction_details.product_id), 0)
+WHERE product_id IS NOT NULL;
+
+UPDATE stock_movements SET unit_cost = COALESCE(
+    (SELECT p.cost_price FROM products p WHERE p.product_id = stock_movements.product_id), 0);
+
+UPDATE service_details SET cost_per_item = COALESCE(
+    (SELECT p.cost_price FROM products p WHERE p.product_id = service_details.item_id), 0)
+WHERE item_type = 'product' AND cost_per_item = 0;
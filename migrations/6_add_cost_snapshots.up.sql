@@ -0,0 +1,17 @@
+-- Sold lines and stock movements keep the product cost of their own day, margins no longer
+-- move when a cost price is edited. Existing rows take the cost price as it is now.
+ALTER TABLE transaction_details ADD COLUMN unit_cost DECIMAL(15,2) NOT NULL DEFAULT 0;
+ALTER TABLE stock_movements ADD COLUMN unit_cost DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+UPDATE transaction_details d SET unit_cost = p.cost_price
+FROM products p
+WHERE p.product_id = d.product_id;
+
+UPDATE stock_movements m SET unit_cost = p.cost_price
+FROM products p
+WHERE p.product_id = m.product_id;
+
+-- Parts used on service jobs already carry cost_per_item, fill the ones left at 0
+UPDATE service_details sd SET cost_per_item = p.cost_price
+FROM products p
+WHERE sd.item_type = 'product' AND sd.cost_per_item = 0 AND p.product_id = sd.item_id;
@@ -0,0 +1,5 @@
+DELETE FROM stock_movements WHERE notes = 'opening balance for costing';
+
+DROP TABLE IF EXISTS cost_layers CASCADE;
+
+ALTER TABLE products DROP COLUMN IF EXISTS cost_method;
@@ -0,0 +1,5 @@
+DELETE FROM stock_movements WHERE notes = 'opening balance for costing';
+
+DROP TABLE IF EXISTS cost_layers;
+
+ALTER TABLE products DROP COLUMN cost_method;
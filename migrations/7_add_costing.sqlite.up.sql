@@ -0,0 +1,30 @@
+-- SQLite variant of 7_add_costing.up.sql
+ALTER TABLE products ADD COLUMN cost_method VARCHAR(10) NOT NULL DEFAULT 'average';
+
+CREATE TABLE cost_layers (
+    layer_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id INTEGER NOT NULL REFERENCES products(product_id),
+    outlet_id INTEGER REFERENCES outlets(outlet_id),
+    movement_id INTEGER REFERENCES stock_movements(movement_id),
+    quantity INTEGER NOT NULL,
+    remaining INTEGER NOT NULL,
+    unit_cost DECIMAL(15,2) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_cost_layers_product_id ON cost_layers(product_id);
+CREATE INDEX idx_cost_layers_outlet_id ON cost_layers(outlet_id);
+CREATE INDEX idx_cost_layers_movement_id ON cost_layers(movement_id);
+CREATE INDEX idx_cost_layers_created_at ON cost_layers(created_at);
+
+INSERT INTO stock_movements (product_id, movement_type, quantity, unit_cost, notes, created_at)
+SELECT p.product_id, 'adjustment', p.stock - COALESCE(l.quantity, 0), p.cost_price, 'opening balance for costing', CURRENT_TIMESTAMP
+FROM products p
+LEFT JOIN (SELECT product_id, SUM(quantity) AS quantity FROM stock_movements GROUP BY product_id) l
+    ON l.product_id = p.product_id
+WHERE p.deleted_at IS NULL AND p.stock <> COALESCE(l.quantity, 0);
+
+INSERT INTO cost_layers (product_id, quantity, remaining, unit_cost, created_at)
+SELECT product_id, stock, stock, cost_price, CURRENT_TIMESTAMP
+FROM products
+WHERE deleted_at IS NULL AND stock > 0;
@@ -0,0 +1,7 @@
+DELETE FROM service_job_histories WHERE notes = 'status recorded by migration';
+
+DROP INDEX IF EXISTS idx_service_job_histories_status;
+
+ALTER TABLE service_job_histories DROP COLUMN status;
+
+DELETE FROM reports WHERE report_type = 'Teknisi';
@@ -0,0 +1,9 @@
+-- SQLite variant of 8_add_service_job_history_status.up.sql
+ALTER TABLE service_job_histories ADD COLUMN status VARCHAR(20);
+
+CREATE INDEX idx_service_job_histories_status ON service_job_histories(status);
+
+INSERT INTO service_job_histories (service_job_id, user_id, notes, status, changed_at)
+SELECT service_job_id, received_by_user_id, 'status recorded by migration', 'Selesai', COALESCE(updated_at, service_in_date)
+FROM service_jobs
+WHERE deleted_at IS NULL AND status IN ('Selesai', 'Diambil');
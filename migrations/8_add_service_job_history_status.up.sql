@@ -0,0 +1,12 @@
+-- Status changes of a service job are recorded in its history, workshop performance is measured
+-- from them. Jobs that are already finished get one Selesai entry at their last update.
+ALTER TABLE service_job_histories ADD COLUMN status service_status_enum;
+
+CREATE INDEX idx_service_job_histories_status ON service_job_histories(status);
+
+INSERT INTO service_job_histories (service_job_id, user_id, notes, status, changed_at)
+SELECT service_job_id, received_by_user_id, 'status recorded by migration', 'Selesai', COALESCE(updated_at, service_in_date)
+FROM service_jobs
+WHERE deleted_at IS NULL AND status IN ('Selesai', 'Diambil');
+
+ALTER TYPE report_type_enum ADD VALUE IF NOT EXISTS 'Teknisi';
@@ -0,0 +1,8 @@
+-- Enum values cannot be dropped, draft purchase orders are removed instead
+DELETE FROM purchase_order_details WHERE purchase_order_id IN (SELECT purchase_order_id FROM purchase_orders WHERE status = 'Draft');
+DELETE FROM purchase_orders WHERE status = 'Draft';
+
+DROP TABLE IF EXISTS product_stock_levels CASCADE;
+
+ALTER TABLE products DROP COLUMN IF EXISTS reorder_quantity;
+ALTER TABLE products DROP COLUMN IF EXISTS min_stock;
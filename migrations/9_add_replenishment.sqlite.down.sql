@@ -0,0 +1,7 @@
+DELETE FROM purchase_order_details WHERE purchase_order_id IN (SELECT purchase_order_id FROM purchase_orders WHERE status = 'Draft');
+DELETE FROM purchase_orders WHERE status = 'Draft';
+
+DROP TABLE IF EXISTS product_stock_levels;
+
+ALTER TABLE products DROP COLUMN reorder_quantity;
+ALTER TABLE products DROP COLUMN min_stock;
@@ -0,0 +1,16 @@
+-- SQLite variant of 9_add_replenishment.up.sql
+ALTER TABLE products ADD COLUMN min_stock INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN reorder_quantity INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE product_stock_levels (
+    stock_level_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id INTEGER NOT NULL REFERENCES products(product_id),
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    min_stock INTEGER NOT NULL DEFAULT 0,
+    reorder_quantity INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_product_stock_levels_product_outlet ON product_stock_levels(product_id, outlet_id);
+CREATE INDEX idx_product_stock_levels_outlet_id ON product_stock_levels(outlet_id);
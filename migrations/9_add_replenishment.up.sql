@@ -0,0 +1,19 @@
+-- Replenishment: products get a reorder point and reorder quantity, outlets can override both, and
+-- purchase orders generated from the suggestions wait as drafts until a buyer confirms them
+ALTER TABLE products ADD COLUMN min_stock INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN reorder_quantity INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE product_stock_levels (
+    stock_level_id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(product_id),
+    outlet_id INTEGER NOT NULL REFERENCES outlets(outlet_id),
+    min_stock INTEGER NOT NULL DEFAULT 0,
+    reorder_quantity INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_product_stock_levels_product_outlet ON product_stock_levels(product_id, outlet_id);
+CREATE INDEX idx_product_stock_levels_outlet_id ON product_stock_levels(outlet_id);
+
+ALTER TYPE purchase_status ADD VALUE IF NOT EXISTS 'Draft';
@@ -0,0 +1,127 @@
+package exception
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Kind classifies a domain error and decides its HTTP status
+type Kind string
+
+const (
+	KindNotFound     Kind = "NOT_FOUND"
+	KindConflict     Kind = "CONFLICT"
+	KindValidation   Kind = "VALIDATION"
+	KindUnauthorized Kind = "UNAUTHORIZED"
+	KindForbidden    Kind = "FORBIDDEN"
+	KindBusinessRule Kind = "BUSINESS_RULE"
+)
+
+// Sentinels for errors.Is checks on the kind only, e.g. errors.Is(err, exception.ErrNotFound)
+var (
+	ErrNotFound     = &DomainError{Kind: KindNotFound}
+	ErrConflict     = &DomainError{Kind: KindConflict}
+	ErrValidation   = &DomainError{Kind: KindValidation}
+	ErrUnauthorized = &DomainError{Kind: KindUnauthorized}
+	ErrForbidden    = &DomainError{Kind: KindForbidden}
+	ErrBusinessRule = &DomainError{Kind: KindBusinessRule}
+)
+
+// DomainError is an expected failure of a usecase with a stable error code and a bilingual message
+type DomainError struct {
+	Kind       Kind
+	Code       string
+	Message    string
+	MessageInd string
+	// Details is sent as response data, e.g. the failing fields of a validation error
+	Details interface{}
+	Err     error
+}
+
+func (e *DomainError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches another domain error with the same code, or a kind sentinel without code
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	if !ok {
+		return false
+	}
+	if t.Code == "" {
+		return e.Kind == t.Kind
+	}
+	return e.Code == t.Code
+}
+
+// Wrap returns a copy of the error carrying the underlying cause
+func (e *DomainError) Wrap(err error) *DomainError {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// WithDetails returns a copy of the error carrying details for the response
+func (e *DomainError) WithDetails(details interface{}) *DomainError {
+	detailed := *e
+	detailed.Details = details
+	return &detailed
+}
+
+// StatusCode returns the HTTP status the error is answered with
+func (e *DomainError) StatusCode() int {
+	switch e.Kind {
+	case KindNotFound:
+		return fiber.StatusNotFound
+	case KindConflict:
+		return fiber.StatusConflict
+	case KindUnauthorized:
+		return fiber.StatusUnauthorized
+	case KindForbidden:
+		return fiber.StatusForbidden
+	case KindValidation, KindBusinessRule:
+		return fiber.StatusUnprocessableEntity
+	}
+	return fiber.StatusInternalServerError
+}
+
+func NotFound(code string, message string, messageInd string) *DomainError {
+	return &DomainError{Kind: KindNotFound, Code: code, Message: message, MessageInd: messageInd}
+}
+
+func Conflict(code string, message string, messageInd string) *DomainError {
+	return &DomainError{Kind: KindConflict, Code: code, Message: message, MessageInd: messageInd}
+}
+
+func Validation(code string, message string, messageInd string) *DomainError {
+	return &DomainError{Kind: KindValidation, Code: code, Message: message, MessageInd: messageInd}
+}
+
+func Unauthorized(code string, message string, messageInd string) *DomainError {
+	return &DomainError{Kind: KindUnauthorized, Code: code, Message: message, MessageInd: messageInd}
+}
+
+func Forbidden(code string, message string, messageInd string) *DomainError {
+	return &DomainError{Kind: KindForbidden, Code: code, Message: message, MessageInd: messageInd}
+}
+
+func BusinessRule(code string, message string, messageInd string) *DomainError {
+	return &DomainError{Kind: KindBusinessRule, Code: code, Message: message, MessageInd: messageInd}
+}
+
+// AsDomainError finds a domain error in the error chain
+func AsDomainError(err error) (*DomainError, bool) {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
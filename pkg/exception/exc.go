@@ -31,6 +31,7 @@ type StatusResponseData struct {
 	Code       int    `json:"code"`
 	Name       string `json:"name"`
 	Type       string `json:"type"`
+	ErrorCode  string `json:"errorCode,omitempty"`
 	Message    string `json:"message"`
 	MessageInd string `json:"messageInd"`
 }
@@ -108,7 +109,7 @@ func DefaultLog(exc InitialExceptionCreateResponse, requestId string, code int,
 			msg,
 			msgInd,
 		))
-	} else if code == fiber.StatusBadRequest || code == fiber.StatusUnauthorized || code == fiber.StatusNotFound {
+	} else if code >= fiber.StatusBadRequest && code < fiber.StatusInternalServerError {
 		exc.Log.Warn(fmt.Sprintf(`requestId="%v" code="%v" method="%s" path="%v" msg="%s" msgInd="%s"`,
 			requestId,
 			respData.Status.Code,
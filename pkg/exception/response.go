@@ -123,6 +123,12 @@ func CreateResponse(exc InitialExceptionCreateResponse, code int, message string
 		RespData.Status.Name = "UNAUTHORIZED"
 	case fiber.StatusNotFound:
 		RespData.Status.Name = "NOT FOUND"
+	case fiber.StatusForbidden:
+		RespData.Status.Name = "FORBIDDEN"
+	case fiber.StatusConflict:
+		RespData.Status.Name = "CONFLICT"
+	case fiber.StatusUnprocessableEntity:
+		RespData.Status.Name = "UNPROCESSABLE ENTITY"
 	case fiber.StatusInternalServerError:
 		RespData.Status.Name = "INTERNAL SERVER ERROR"
 	}
@@ -162,6 +168,12 @@ func CreateResponse_Log(exc InitialExceptionCreateResponse, code int, message st
 		RespData.Status.Name = "UNAUTHORIZED"
 	case fiber.StatusNotFound:
 		RespData.Status.Name = "NOT FOUND"
+	case fiber.StatusForbidden:
+		RespData.Status.Name = "FORBIDDEN"
+	case fiber.StatusConflict:
+		RespData.Status.Name = "CONFLICT"
+	case fiber.StatusUnprocessableEntity:
+		RespData.Status.Name = "UNPROCESSABLE ENTITY"
 	case fiber.StatusInternalServerError:
 		RespData.Status.Name = "INTERNAL SERVER ERROR"
 	}
@@ -224,6 +236,12 @@ func CreateResponse_Page(exc InitialExceptionCreateResponse, code int, message s
 		RespData.Status.Name = "UNAUTHORIZED"
 	case fiber.StatusNotFound:
 		RespData.Status.Name = "NOT FOUND"
+	case fiber.StatusForbidden:
+		RespData.Status.Name = "FORBIDDEN"
+	case fiber.StatusConflict:
+		RespData.Status.Name = "CONFLICT"
+	case fiber.StatusUnprocessableEntity:
+		RespData.Status.Name = "UNPROCESSABLE ENTITY"
 	case fiber.StatusInternalServerError:
 		RespData.Status.Name = "INTERNAL SERVER ERROR"
 	}
@@ -284,6 +302,12 @@ func CreateResponse_Log_Page(exc InitialExceptionCreateResponse, code int, messa
 		RespData.Status.Name = "UNAUTHORIZED"
 	case fiber.StatusNotFound:
 		RespData.Status.Name = "NOT FOUND"
+	case fiber.StatusForbidden:
+		RespData.Status.Name = "FORBIDDEN"
+	case fiber.StatusConflict:
+		RespData.Status.Name = "CONFLICT"
+	case fiber.StatusUnprocessableEntity:
+		RespData.Status.Name = "UNPROCESSABLE ENTITY"
 	case fiber.StatusInternalServerError:
 		RespData.Status.Name = "INTERNAL SERVER ERROR"
 	}
@@ -292,3 +316,37 @@ func CreateResponse_Log_Page(exc InitialExceptionCreateResponse, code int, messa
 
 	return exc.Ctx.Status(code).JSON(RespData)
 }
+
+func CreateResponse_DomainError(exc InitialExceptionCreateResponse, domainErr *DomainError) error {
+
+	requestId := CreateRequestId(exc)
+	code := domainErr.StatusCode()
+
+	RespData := ResponseData{
+		RequestId: requestId,
+		Data:      domainErr.Details,
+		Status: StatusResponseData{
+			Code:       code,
+			Type:       "ERROR",
+			ErrorCode:  domainErr.Code,
+			Message:    domainErr.Message,
+			MessageInd: domainErr.MessageInd,
+		},
+		TimeStamp: time.Now(),
+	}
+
+	switch code {
+	case fiber.StatusNotFound:
+		RespData.Status.Name = "NOT FOUND"
+	case fiber.StatusForbidden:
+		RespData.Status.Name = "FORBIDDEN"
+	case fiber.StatusConflict:
+		RespData.Status.Name = "CONFLICT"
+	case fiber.StatusUnprocessableEntity:
+		RespData.Status.Name = "UNPROCESSABLE ENTITY"
+	}
+
+	DefaultLog(exc, requestId, code, RespData)
+
+	return exc.Ctx.Status(code).JSON(RespData)
+}
@@ -0,0 +1,325 @@
+package db
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/utils"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const auditBeforeStateKey = "audit:before_state"
+
+// auditSkipTables lists tables that are never written to the audit trail
+var auditSkipTables = map[string]bool{
+	"audit_logs": true,
+}
+
+// auditRedactedFields are stripped from audit snapshots
+var auditRedactedFields = map[string]bool{
+	"password": true,
+}
+
+// RegisterAuditCallbacks installs the GORM callbacks that stamp CreatedBy from
+// the actor in the statement context and append an audit entry for every
+// create, update and delete issued through GORM.
+func RegisterAuditCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+
+	if err := callback.Create().Before("gorm:create").Register("audit:stamp_created_by", stampCreatedBy); err != nil {
+		return err
+	}
+	if err := callback.Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").Register("audit:after_create", auditAfterCreate); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("audit:before_update", captureAuditBeforeState); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("audit:after_update", auditAfterUpdate); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("audit:before_delete", captureAuditBeforeState); err != nil {
+		return err
+	}
+	if err := callback.Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").Register("audit:after_delete", auditAfterDelete); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func isAudited(tx *gorm.DB) bool {
+	stmt := tx.Statement
+	return tx.Error == nil &&
+		stmt.Schema != nil &&
+		stmt.Schema.PrioritizedPrimaryField != nil &&
+		!auditSkipTables[stmt.Schema.Table]
+}
+
+// stampCreatedBy fills CreatedBy with the authenticated user, overriding any
+// value supplied by the client
+func stampCreatedBy(tx *gorm.DB) {
+	stmt := tx.Statement
+	if tx.Error != nil || stmt.Schema == nil {
+		return
+	}
+
+	field := stmt.Schema.LookUpField("CreatedBy")
+	if field == nil {
+		return
+	}
+
+	actor, ok := utils.ActorFromContext(stmt.Context)
+	if !ok {
+		return
+	}
+
+	userID := actor.UserID
+	eachAuditRow(stmt.ReflectValue, func(row reflect.Value) {
+		if err := field.Set(stmt.Context, row, &userID); err != nil {
+			tx.AddError(err)
+		}
+	})
+}
+
+func auditAfterCreate(tx *gorm.DB) {
+	if !isAudited(tx) {
+		return
+	}
+
+	var entries []models.AuditLog
+	eachAuditRow(tx.Statement.ReflectValue, func(row reflect.Value) {
+		entries = append(entries, newAuditLog(tx, models.AuditActionCreate, row, nil, auditSnapshot(row)))
+	})
+	writeAuditLogs(tx, entries)
+}
+
+// captureAuditBeforeState loads the rows an update or delete is about to touch
+func captureAuditBeforeState(tx *gorm.DB) {
+	if !isAudited(tx) {
+		return
+	}
+
+	stmt := tx.Statement
+	query := tx.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+
+	if ids := auditPrimaryKeys(stmt, stmt.ReflectValue); len(ids) > 0 {
+		query = query.Where(clause.IN{Column: clause.PrimaryColumn, Values: ids})
+	} else if where, ok := stmt.Clauses["WHERE"]; ok && where.Expression != nil {
+		query = query.Clauses(where.Expression)
+	} else {
+		return
+	}
+
+	rows := reflect.New(reflect.SliceOf(reflect.PtrTo(stmt.Schema.ModelType)))
+	if err := query.Find(rows.Interface()).Error; err != nil {
+		tx.AddError(err)
+		return
+	}
+	tx.InstanceSet(auditBeforeStateKey, rows.Elem())
+}
+
+func auditAfterUpdate(tx *gorm.DB) {
+	if !isAudited(tx) {
+		return
+	}
+
+	before, ok := auditBeforeState(tx)
+	if !ok || before.Len() == 0 {
+		return
+	}
+
+	stmt := tx.Statement
+	after := reflect.New(reflect.SliceOf(reflect.PtrTo(stmt.Schema.ModelType)))
+	err := tx.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Unscoped().
+		Where(clause.IN{Column: clause.PrimaryColumn, Values: auditPrimaryKeys(stmt, before)}).
+		Find(after.Interface()).Error
+	if err != nil {
+		tx.AddError(err)
+		return
+	}
+
+	afterByID := make(map[string]reflect.Value)
+	eachAuditRow(after.Elem(), func(row reflect.Value) {
+		afterByID[auditEntityID(tx, row)] = row
+	})
+
+	var entries []models.AuditLog
+	eachAuditRow(before, func(row reflect.Value) {
+		afterRow, found := afterByID[auditEntityID(tx, row)]
+		if !found {
+			return
+		}
+		oldValues, newValues := auditDiff(auditSnapshot(row), auditSnapshot(afterRow))
+		if len(newValues) == 0 {
+			return
+		}
+		entries = append(entries, newAuditLog(tx, models.AuditActionUpdate, afterRow, oldValues, newValues))
+	})
+	writeAuditLogs(tx, entries)
+}
+
+func auditAfterDelete(tx *gorm.DB) {
+	if !isAudited(tx) {
+		return
+	}
+
+	before, ok := auditBeforeState(tx)
+	if !ok {
+		return
+	}
+
+	var entries []models.AuditLog
+	eachAuditRow(before, func(row reflect.Value) {
+		entries = append(entries, newAuditLog(tx, models.AuditActionDelete, row, auditSnapshot(row), nil))
+	})
+	writeAuditLogs(tx, entries)
+}
+
+func auditBeforeState(tx *gorm.DB) (reflect.Value, bool) {
+	value, ok := tx.InstanceGet(auditBeforeStateKey)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	rows, ok := value.(reflect.Value)
+	return rows, ok
+}
+
+func newAuditLog(tx *gorm.DB, action models.AuditAction, row reflect.Value, before, after map[string]interface{}) models.AuditLog {
+	entry := models.AuditLog{
+		Entity:    tx.Statement.Schema.Table,
+		EntityID:  auditEntityID(tx, row),
+		Action:    action,
+		Before:    auditJSON(before),
+		After:     auditJSON(after),
+		CreatedAt: time.Now(),
+	}
+
+	if actor, ok := utils.ActorFromContext(tx.Statement.Context); ok {
+		userID := actor.UserID
+		entry.UserID = &userID
+		entry.OutletID = actor.OutletID
+	}
+
+	// Prefer the outlet the record belongs to over the actor's outlet
+	if field := tx.Statement.Schema.LookUpField("OutletID"); field != nil {
+		if value, zero := field.ValueOf(tx.Statement.Context, row); !zero {
+			if outletID, ok := auditUint(value); ok {
+				entry.OutletID = &outletID
+			}
+		}
+	}
+
+	return entry
+}
+
+func writeAuditLogs(tx *gorm.DB, entries []models.AuditLog) {
+	if len(entries) == 0 {
+		return
+	}
+	if err := tx.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(&entries).Error; err != nil {
+		tx.AddError(fmt.Errorf("failed to write audit log: %w", err))
+	}
+}
+
+func eachAuditRow(value reflect.Value, fn func(row reflect.Value)) {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			row := reflect.Indirect(value.Index(i))
+			if row.Kind() == reflect.Struct {
+				fn(row)
+			}
+		}
+	case reflect.Struct:
+		fn(value)
+	}
+}
+
+func auditPrimaryKeys(stmt *gorm.Statement, value reflect.Value) []interface{} {
+	var ids []interface{}
+	field := stmt.Schema.PrioritizedPrimaryField
+	eachAuditRow(value, func(row reflect.Value) {
+		if id, zero := field.ValueOf(stmt.Context, row); !zero {
+			ids = append(ids, id)
+		}
+	})
+	return ids
+}
+
+func auditEntityID(tx *gorm.DB, row reflect.Value) string {
+	id, _ := tx.Statement.Schema.PrioritizedPrimaryField.ValueOf(tx.Statement.Context, row)
+	return fmt.Sprint(id)
+}
+
+// auditSnapshot flattens a row into its JSON columns, dropping relationships
+// and redacted fields
+func auditSnapshot(row reflect.Value) map[string]interface{} {
+	raw, err := json.Marshal(row.Interface())
+	if err != nil {
+		return nil
+	}
+
+	snapshot := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil
+	}
+
+	for key, value := range snapshot {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			delete(snapshot, key)
+			continue
+		}
+		if auditRedactedFields[key] {
+			delete(snapshot, key)
+		}
+	}
+	return snapshot
+}
+
+// auditDiff returns the old and new values of the columns that changed,
+// ignoring the updated_at bookkeeping column
+func auditDiff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	oldValues := make(map[string]interface{})
+	newValues := make(map[string]interface{})
+	for key, newValue := range after {
+		if key == "updated_at" {
+			continue
+		}
+		if oldValue := before[key]; !reflect.DeepEqual(oldValue, newValue) {
+			oldValues[key] = oldValue
+			newValues[key] = newValue
+		}
+	}
+	return oldValues, newValues
+}
+
+func auditJSON(values map[string]interface{}) *string {
+	if values == nil {
+		return nil
+	}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil
+	}
+	result := string(raw)
+	return &result
+}
+
+func auditUint(value interface{}) (uint, bool) {
+	rv := reflect.Indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(rv.Uint()), rv.Uint() > 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint(rv.Int()), rv.Int() > 0
+	}
+	return 0, false
+}
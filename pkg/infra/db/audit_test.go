@@ -0,0 +1,153 @@
+package db
+
+import (
+	"boilerplate/internal/models"
+	"boilerplate/pkg/utils"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens an empty file database
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=10000"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("database handle: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// auditTestItem is the table the audit tests write to
+type auditTestItem struct {
+	ItemID    uint      `gorm:"primaryKey" json:"item_id"`
+	Name      string    `json:"name"`
+	Password  string    `json:"password"`
+	OutletID  *uint     `json:"outlet_id"`
+	CreatedBy *uint     `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newAuditTestDB opens a database with the audit callbacks registered
+func newAuditTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := newTestDB(t)
+	if err := RegisterAuditCallbacks(db); err != nil {
+		t.Fatalf("register callbacks: %v", err)
+	}
+	if err := db.AutoMigrate(&models.AuditLog{}, &auditTestItem{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// auditTestLogs lists the audit entries in the order they were written
+func auditTestLogs(t *testing.T, db *gorm.DB) []models.AuditLog {
+	t.Helper()
+	var logs []models.AuditLog
+	if err := db.Order("audit_log_id").Find(&logs).Error; err != nil {
+		t.Fatalf("list audit logs: %v", err)
+	}
+	return logs
+}
+
+// auditTestValues decodes the JSON snapshot of an audit entry
+func auditTestValues(t *testing.T, raw *string) map[string]interface{} {
+	t.Helper()
+	if raw == nil {
+		return nil
+	}
+	values := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(*raw), &values); err != nil {
+		t.Fatalf("decode %s: %v", *raw, err)
+	}
+	return values
+}
+
+func TestAuditCallbacks(t *testing.T) {
+	db := newAuditTestDB(t)
+	actorOutletID, itemOutletID := uint(1), uint(2)
+	ctx := utils.WithActor(context.Background(), &utils.Actor{UserID: 7, OutletID: &actorOutletID})
+	tx := db.WithContext(ctx)
+
+	// CreatedBy is the actor whatever the client sent
+	forged := uint(99)
+	item := &auditTestItem{Name: "Engine oil", Password: "secret", OutletID: &itemOutletID, CreatedBy: &forged}
+	if err := tx.Create(item).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if item.CreatedBy == nil || *item.CreatedBy != 7 {
+		t.Fatalf("got created by %v, want the actor 7", item.CreatedBy)
+	}
+
+	if err := tx.Model(item).Updates(map[string]interface{}{"name": "Gear oil", "password": "other"}).Error; err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	// An update changing nothing is not written
+	if err := tx.Model(item).Update("name", "Gear oil").Error; err != nil {
+		t.Fatalf("update without change: %v", err)
+	}
+	if err := tx.Delete(item).Error; err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	logs := auditTestLogs(t, db)
+	if len(logs) != 3 {
+		t.Fatalf("got %d audit entries, want 3", len(logs))
+	}
+	for i, action := range []models.AuditAction{models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete} {
+		entry := logs[i]
+		if entry.Action != action || entry.Entity != "audit_test_items" || entry.EntityID != "1" {
+			t.Fatalf("entry %d: got %s of %s %s, want %s of audit_test_items 1", i, entry.Action, entry.Entity, entry.EntityID, action)
+		}
+		// The outlet of the record wins over the actor's
+		if entry.UserID == nil || *entry.UserID != 7 || entry.OutletID == nil || *entry.OutletID != itemOutletID {
+			t.Fatalf("entry %d: got user %v at outlet %v, want 7 at %d", i, entry.UserID, entry.OutletID, itemOutletID)
+		}
+		for _, values := range []map[string]interface{}{auditTestValues(t, entry.Before), auditTestValues(t, entry.After)} {
+			if _, ok := values["password"]; ok {
+				t.Fatalf("entry %d: password written to the audit trail", i)
+			}
+		}
+	}
+
+	before, after := auditTestValues(t, logs[1].Before), auditTestValues(t, logs[1].After)
+	if len(after) != 1 || before["name"] != "Engine oil" || after["name"] != "Gear oil" {
+		t.Fatalf("update: got %v to %v, want only the name from Engine oil to Gear oil", before, after)
+	}
+	if logs[0].Before != nil || logs[2].After != nil {
+		t.Fatalf("got a before state on create or an after state on delete")
+	}
+}
+
+func TestAuditCallbacksWithoutActor(t *testing.T) {
+	db := newAuditTestDB(t)
+	item := &auditTestItem{Name: "Brake pad"}
+	if err := db.Create(item).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if item.CreatedBy != nil {
+		t.Fatalf("got created by %d without an actor", *item.CreatedBy)
+	}
+
+	// Updates by a condition are audited per row they touch
+	if err := db.Model(&auditTestItem{}).Where("name = ?", "Brake pad").Update("name", "Brake shoe").Error; err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	logs := auditTestLogs(t, db)
+	if len(logs) != 2 || logs[1].Action != models.AuditActionUpdate || logs[1].UserID != nil || logs[1].OutletID != nil {
+		t.Fatalf("got %+v, want a create and an update without user or outlet", logs)
+	}
+}
@@ -52,6 +52,11 @@ func NewGORMConnection(conf *config.DatabaseAccount, log *logrus.Logger) *gorm.D
 		log.Fatal("Failed to connect database " + dbName + ", err: " + err.Error())
 	}
 
+	//* Audit trail & CreatedBy stamping
+	if err := RegisterAuditCallbacks(db); err != nil {
+		log.Fatal("Failed to register audit callbacks, err: " + err.Error())
+	}
+
 	log.Info("Connection Opened to Database " + dbName)
 	return db
 }
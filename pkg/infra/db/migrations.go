@@ -7,24 +7,6 @@ import (
 	"gorm.io/gorm"
 )
 
-// RunMigrations runs all model migrations using GORM AutoMigrate
-func RunMigrations(db *gorm.DB) error {
-	log.Println("Starting database migrations...")
-
-	// Get all models to migrate
-	modelsToMigrate := models.GetAllModels()
-
-	// Run AutoMigrate for all models
-	err := db.AutoMigrate(modelsToMigrate...)
-	if err != nil {
-		log.Printf("Error running migrations: %v", err)
-		return err
-	}
-
-	log.Println("Database migrations completed successfully")
-	return nil
-}
-
 // DropAllTables drops all tables (useful for testing)
 func DropAllTables(db *gorm.DB) error {
 	log.Println("Dropping all tables...")
@@ -0,0 +1,361 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultMigrationsDir is where versioned SQL migrations are kept
+const DefaultMigrationsDir = "./migrations"
+
+const schemaMigrationsTable = "schema_migrations"
+
+// migrationFilePattern matches <version>_<name>[.<driver>].<up|down>.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_\-]+?)(?:\.(postgres|sqlite))?\.(up|down)\.sql$`)
+
+// Migration is a single versioned SQL migration
+type Migration struct {
+	Version   int64
+	Name      string
+	UpFile    string
+	DownFile  string
+	AppliedAt *time.Time
+}
+
+// Migrator applies and rolls back versioned SQL migrations, tracking applied
+// versions in the schema_migrations table
+type Migrator struct {
+	db     *gorm.DB
+	dir    string
+	driver string
+}
+
+// NewMigrator creates a migrator for the given connection. The driver name is
+// the one used by NewGORMConnection ("postgres", "pgx" or "sqlite") and selects
+// driver-specific files (e.g. 2_tables.sqlite.up.sql) over generic ones.
+func NewMigrator(db *gorm.DB, dir, driver string) *Migrator {
+	if driver == "pgx" {
+		driver = "postgres"
+	}
+	return &Migrator{db: db, dir: dir, driver: driver}
+}
+
+// Load reads the migration files and marks the ones already applied
+func (m *Migrator) Load() ([]*Migration, error) {
+	migrations, err := m.readFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migration := range migrations {
+		if appliedAt, ok := applied[migration.Version]; ok {
+			appliedAt := appliedAt
+			migration.AppliedAt = &appliedAt
+		}
+	}
+	return migrations, nil
+}
+
+// Pending returns the migrations that have not been applied yet
+func (m *Migrator) Pending() ([]*Migration, error) {
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Migration
+	for _, migration := range migrations {
+		if migration.AppliedAt == nil {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies all pending migrations in version order and returns the applied ones
+func (m *Migrator) Up() ([]*Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, migration := range pending {
+		if migration.UpFile == "" {
+			return pending[:i], fmt.Errorf("migration %d_%s has no up file for driver %s", migration.Version, migration.Name, m.driver)
+		}
+		err := m.run(migration.UpFile, func(tx *gorm.DB) error {
+			return tx.Exec("INSERT INTO "+schemaMigrationsTable+" (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now()).Error
+		})
+		if err != nil {
+			return pending[:i], fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// Down rolls back the last n applied migrations, newest first
+func (m *Migrator) Down(n int) ([]*Migration, error) {
+	if n <= 0 {
+		return nil, errors.New("number of migrations to roll back must be positive")
+	}
+
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var rolledBack []*Migration
+	for i := len(migrations) - 1; i >= 0 && len(rolledBack) < n; i-- {
+		migration := migrations[i]
+		if migration.AppliedAt == nil {
+			continue
+		}
+		if migration.DownFile == "" {
+			return rolledBack, fmt.Errorf("migration %d_%s has no down file for driver %s", migration.Version, migration.Name, m.driver)
+		}
+		err := m.run(migration.DownFile, func(tx *gorm.DB) error {
+			return tx.Exec("DELETE FROM "+schemaMigrationsTable+" WHERE version = ?", migration.Version).Error
+		})
+		if err != nil {
+			return rolledBack, fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		rolledBack = append(rolledBack, migration)
+	}
+	return rolledBack, nil
+}
+
+// Create writes an empty up/down migration pair numbered after the highest version in the directory
+func (m *Migrator) Create(name string) (string, string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return "", "", errors.New("migration name is required")
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return "", "", err
+	}
+
+	version, err := m.nextVersion()
+	if err != nil {
+		return "", "", err
+	}
+	upFile := filepath.Join(m.dir, fmt.Sprintf("%d_%s.up.sql", version, name))
+	downFile := filepath.Join(m.dir, fmt.Sprintf("%d_%s.down.sql", version, name))
+
+	if err := os.WriteFile(upFile, []byte("-- "+name+" (up)\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(downFile, []byte("-- "+name+" (down)\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return upFile, downFile, nil
+}
+
+// nextVersion returns the version after the highest one in the directory, counting the files of every driver
+// so that a new migration never takes a version only tagged for another driver
+func (m *Migrator) nextVersion() (int64, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return 0, err
+	}
+	var highest int64
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if version > highest {
+			highest = version
+		}
+	}
+	return highest + 1, nil
+}
+
+// run executes a migration file and its bookkeeping statement in one transaction
+func (m *Migrator) run(file string, record func(tx *gorm.DB) error) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if sql := strings.TrimSpace(string(content)); sql != "" {
+			if err := tx.Exec(sql).Error; err != nil {
+				return err
+			}
+		}
+		return record(tx)
+	})
+}
+
+func (m *Migrator) ensureTable() error {
+	return m.db.Exec("CREATE TABLE IF NOT EXISTS " + schemaMigrationsTable + " (" +
+		"version BIGINT PRIMARY KEY, " +
+		"name VARCHAR(255) NOT NULL, " +
+		"applied_at TIMESTAMP NOT NULL)").Error
+}
+
+func (m *Migrator) applied() (map[int64]time.Time, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Version   int64
+		AppliedAt time.Time
+	}
+	if err := m.db.Raw("SELECT version, applied_at FROM " + schemaMigrationsTable).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+// readFiles groups migration files by version. A file tagged with the current
+// driver wins over an untagged one; files tagged for another driver are ignored.
+func (m *Migrator) readFiles() ([]*Migration, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	specific := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		name, driver, direction := match[2], match[3], match[4]
+		if driver != "" && driver != m.driver {
+			continue
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, migration.Name, name)
+		}
+
+		key := fmt.Sprintf("%d.%s", version, direction)
+		if specific[key] && driver == "" {
+			continue
+		}
+		if driver != "" {
+			specific[key] = true
+		}
+
+		path := filepath.Join(m.dir, entry.Name())
+		if direction == "up" {
+			migration.UpFile = path
+		} else {
+			migration.DownFile = path
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// RunMigrationCommand executes a migrate subcommand: status, up, down [N] or create NAME
+func RunMigrationCommand(m *Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate <status|up|down [N]|create NAME>")
+	}
+
+	switch args[0] {
+	case "status":
+		migrations, err := m.Load()
+		if err != nil {
+			return err
+		}
+		for _, migration := range migrations {
+			status := "pending"
+			if migration.AppliedAt != nil {
+				status = "applied " + migration.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%-16d %-45s %s\n", migration.Version, migration.Name, status)
+		}
+		return nil
+
+	case "up":
+		applied, err := m.Up()
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied  %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+		return err
+
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			parsed, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+			n = parsed
+		}
+		rolledBack, err := m.Down(n)
+		for _, migration := range rolledBack {
+			fmt.Fprintf(out, "reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+
+	case "create":
+		if len(args) < 2 {
+			return errors.New("usage: migrate create NAME")
+		}
+		upFile, downFile, err := m.Create(strings.Join(args[1:], "_"))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "created %s\ncreated %s\n", upFile, downFile)
+		return nil
+	}
+
+	return fmt.Errorf("unknown migrate command %q", args[0])
+}
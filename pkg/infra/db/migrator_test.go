@@ -0,0 +1,179 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// writeTestMigrations writes the files to a new migrations directory
+func writeTestMigrations(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// tableExists tells whether the database has the table
+func tableExists(t *testing.T, db *gorm.DB, table string) bool {
+	t.Helper()
+	return db.Migrator().HasTable(table)
+}
+
+func TestMigratorSelectsDriverFiles(t *testing.T) {
+	dir := writeTestMigrations(t, map[string]string{
+		"1_items.up.sql":                  "CREATE TABLE items (id SERIAL PRIMARY KEY);",
+		"1_items.sqlite.up.sql":           "CREATE TABLE items (id INTEGER PRIMARY KEY AUTOINCREMENT);",
+		"1_items.down.sql":                "DROP TABLE items;",
+		"2_prices.postgres.up.sql":        "CREATE TABLE prices (id SERIAL PRIMARY KEY);",
+		"2_prices.up.sql":                 "CREATE TABLE prices (id INTEGER PRIMARY KEY);",
+		"2_prices.down.sql":               "DROP TABLE prices;",
+		"3_only_postgres.postgres.up.sql": "CREATE EXTENSION pg_trgm;",
+		"README.md":                       "not a migration",
+	})
+
+	// A version only tagged for another driver is no migration at all
+	tests := []struct {
+		driver string
+		up     map[int64]string
+		down   map[int64]string
+	}{
+		{"sqlite",
+			map[int64]string{1: "1_items.sqlite.up.sql", 2: "2_prices.up.sql"},
+			map[int64]string{1: "1_items.down.sql", 2: "2_prices.down.sql"}},
+		{"pgx",
+			map[int64]string{1: "1_items.up.sql", 2: "2_prices.postgres.up.sql", 3: "3_only_postgres.postgres.up.sql"},
+			map[int64]string{1: "1_items.down.sql", 2: "2_prices.down.sql", 3: ""}},
+	}
+	for _, tt := range tests {
+		migrations, err := NewMigrator(newTestDB(t), dir, tt.driver).Load()
+		if err != nil {
+			t.Fatalf("%s: load: %v", tt.driver, err)
+		}
+		if len(migrations) != len(tt.up) {
+			t.Fatalf("%s: got %d migrations, want %d", tt.driver, len(migrations), len(tt.up))
+		}
+		for _, migration := range migrations {
+			up, down := filepath.Base(migration.UpFile), filepath.Base(migration.DownFile)
+			if migration.UpFile == "" {
+				up = ""
+			}
+			if migration.DownFile == "" {
+				down = ""
+			}
+			if up != tt.up[migration.Version] || down != tt.down[migration.Version] {
+				t.Fatalf("%s: migration %d got %q and %q, want %q and %q", tt.driver, migration.Version, up, down,
+					tt.up[migration.Version], tt.down[migration.Version])
+			}
+		}
+	}
+}
+
+func TestMigratorUpAndDown(t *testing.T) {
+	db := newTestDB(t)
+	dir := writeTestMigrations(t, map[string]string{
+		"1_items.up.sql":    "CREATE TABLE items (id INTEGER PRIMARY KEY);",
+		"1_items.down.sql":  "DROP TABLE items;",
+		"2_prices.up.sql":   "CREATE TABLE prices (id INTEGER PRIMARY KEY);",
+		"2_prices.down.sql": "DROP TABLE prices;",
+	})
+	m := NewMigrator(db, dir, "sqlite")
+
+	applied, err := m.Up()
+	if err != nil || len(applied) != 2 {
+		t.Fatalf("up: got %d applied, %v, want 2", len(applied), err)
+	}
+	if !tableExists(t, db, "items") || !tableExists(t, db, "prices") {
+		t.Fatalf("tables missing after up")
+	}
+	if applied, err := m.Up(); err != nil || len(applied) != 0 {
+		t.Fatalf("up again: got %d applied, %v, want none", len(applied), err)
+	}
+
+	rolledBack, err := m.Down(1)
+	if err != nil || len(rolledBack) != 1 || rolledBack[0].Version != 2 {
+		t.Fatalf("down: got %v, %v, want migration 2 rolled back", rolledBack, err)
+	}
+	if tableExists(t, db, "prices") || !tableExists(t, db, "items") {
+		t.Fatalf("down rolled back the wrong migration")
+	}
+	if pending, err := m.Pending(); err != nil || len(pending) != 1 || pending[0].Version != 2 {
+		t.Fatalf("pending: got %v, %v, want migration 2", pending, err)
+	}
+	if _, err := m.Down(0); err == nil {
+		t.Fatalf("down 0: got no error")
+	}
+}
+
+func TestMigratorFailedMigrationIsNotRecorded(t *testing.T) {
+	db := newTestDB(t)
+	dir := writeTestMigrations(t, map[string]string{
+		"1_items.up.sql":  "CREATE TABLE items (id INTEGER PRIMARY KEY);",
+		"2_broken.up.sql": "CREATE TABLE broken (id INTEGER PRIMARY KEY); INSERT INTO missing VALUES (1);",
+	})
+	m := NewMigrator(db, dir, "sqlite")
+
+	applied, err := m.Up()
+	if err == nil || len(applied) != 1 || !strings.Contains(err.Error(), "2_broken") {
+		t.Fatalf("up: got %d applied, %v, want 1 and the failure of 2_broken", len(applied), err)
+	}
+	if tableExists(t, db, "broken") {
+		t.Fatalf("the failed migration was not rolled back")
+	}
+	if pending, err := m.Pending(); err != nil || len(pending) != 1 || pending[0].Version != 2 {
+		t.Fatalf("pending: got %v, %v, want migration 2", pending, err)
+	}
+}
+
+func TestMigratorRejectsVersionUsedTwice(t *testing.T) {
+	dir := writeTestMigrations(t, map[string]string{
+		"1_items.up.sql":  "",
+		"1_prices.up.sql": "",
+	})
+	if _, err := NewMigrator(newTestDB(t), dir, "sqlite").Load(); err == nil {
+		t.Fatalf("load: got no error for version 1 used twice")
+	}
+}
+
+func TestMigrationsChainOnSQLite(t *testing.T) {
+	db := newTestDB(t)
+	m := NewMigrator(db, filepath.Join("..", "..", "..", "migrations"), "sqlite")
+
+	migrations, err := m.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, err := m.Up(); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if rolledBack, err := m.Down(len(migrations)); err != nil || len(rolledBack) != len(migrations) {
+		t.Fatalf("down: got %d rolled back, %v, want %d", len(rolledBack), err, len(migrations))
+	}
+	if _, err := m.Up(); err != nil {
+		t.Fatalf("up after down: %v", err)
+	}
+}
+
+func TestMigratorCreateTakesNextVersion(t *testing.T) {
+	dir := writeTestMigrations(t, map[string]string{
+		"1_items.up.sql":            "",
+		"2_prices.sqlite.up.sql":    "",
+		"3_trigram.postgres.up.sql": "",
+	})
+
+	// A version only tagged for another driver is taken as well
+	upFile, downFile, err := NewMigrator(newTestDB(t), dir, "sqlite").Create("Add Notes!")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if filepath.Base(upFile) != "4_add_notes.up.sql" || filepath.Base(downFile) != "4_add_notes.down.sql" {
+		t.Fatalf("got %s and %s, want version 4", filepath.Base(upFile), filepath.Base(downFile))
+	}
+}
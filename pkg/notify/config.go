@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"boilerplate/config"
+	"fmt"
+)
+
+// Drivers selected with Notification.<Channel>.Driver
+const (
+	DriverLog     = "log"
+	DriverFile    = "file"
+	DriverGateway = "gateway"
+	DriverSMTP    = "smtp"
+)
+
+// FromConfig creates the senders of all channels from the Notification config, channels without a driver log their messages
+func FromConfig(conf config.NotificationAccount) (map[string]Sender, error) {
+	channels := map[string]config.NotificationChannelAccount{
+		ChannelWhatsApp: conf.WhatsApp,
+		ChannelSMS:      conf.SMS,
+		ChannelEmail:    conf.Email,
+	}
+
+	senders := make(map[string]Sender, len(channels))
+	for channel, account := range channels {
+		sender, err := newSender(channel, account)
+		if err != nil {
+			return nil, err
+		}
+		senders[channel] = sender
+	}
+	return senders, nil
+}
+
+func newSender(channel string, account config.NotificationChannelAccount) (Sender, error) {
+	switch account.Driver {
+	case "", DriverLog:
+		return NewLog(channel), nil
+	case DriverFile:
+		if account.File == "" {
+			return nil, fmt.Errorf("notify: %s: file driver needs a File", channel)
+		}
+		return NewFile(channel, account.File), nil
+	case DriverGateway:
+		if channel == ChannelEmail {
+			break
+		}
+		if account.URL == "" {
+			return nil, fmt.Errorf("notify: %s: gateway driver needs a URL", channel)
+		}
+		return NewGateway(account.URL, account.Token), nil
+	case DriverSMTP:
+		if channel != ChannelEmail {
+			break
+		}
+		if account.Host == "" || account.From == "" {
+			return nil, fmt.Errorf("notify: %s: smtp driver needs a Host and From", channel)
+		}
+		return NewSMTP(account.Host, account.Port, account.Username, account.Password, account.From), nil
+	}
+	return nil, fmt.Errorf("notify: %s: unknown driver %q", channel, account.Driver)
+}
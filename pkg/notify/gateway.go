@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// gatewayTimeout bounds a single request to a gateway
+const gatewayTimeout = 15 * time.Second
+
+// Gateway posts messages to an HTTP gateway of a WhatsApp or SMS provider as
+// {"to": "...", "message": "..."} with the token as bearer authorization.
+// Any 2xx answer counts as accepted; an "id" in a JSON answer is returned as message ID.
+type Gateway struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewGateway creates a gateway sender posting to url
+func NewGateway(url, token string) *Gateway {
+	return &Gateway{url: url, token: token, client: &http.Client{Timeout: gatewayTimeout}}
+}
+
+// Send posts the message to the gateway
+func (g *Gateway) Send(ctx context.Context, msg Message) (string, error) {
+	if msg.To == "" {
+		return "", ErrNoRecipient
+	}
+	payload, err := json.Marshal(map[string]string{"to": msg.To, "message": msg.Body})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("notify: gateway answered %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	var answer struct {
+		ID interface{} `json:"id"`
+	}
+	if json.Unmarshal(body, &answer) == nil && answer.ID != nil {
+		return fmt.Sprint(answer.ID), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Writer is a development sink writing every message as a JSON line instead of delivering it
+type Writer struct {
+	channel string
+	mu      sync.Mutex
+	out     io.Writer
+	path    string
+}
+
+// NewLog writes the messages of channel to standard output
+func NewLog(channel string) *Writer {
+	return &Writer{channel: channel, out: os.Stdout}
+}
+
+// NewFile appends the messages of channel to the file at path, the file is created on first write
+func NewFile(channel, path string) *Writer {
+	return &Writer{channel: channel, path: path}
+}
+
+// Send writes the message, the returned ID is the time it was written
+func (w *Writer) Send(ctx context.Context, msg Message) (string, error) {
+	if msg.To == "" {
+		return "", ErrNoRecipient
+	}
+	now := time.Now()
+	line, err := json.Marshal(map[string]string{
+		"time":    now.Format(time.RFC3339),
+		"channel": w.channel,
+		"to":      msg.To,
+		"subject": msg.Subject,
+		"body":    msg.Body,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := w.out
+	if out == nil {
+		if err := os.MkdirAll(filepath.Dir(w.path), 0o755); err != nil {
+			return "", err
+		}
+		file, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", err
+		}
+		defer file.Close()
+		out = file
+	}
+	if _, err := fmt.Fprintf(out, "%s\n", line); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%d", w.channel, now.UnixNano()), nil
+}
@@ -0,0 +1,31 @@
+// Package notify delivers text messages to customers through a channel such as a WhatsApp or SMS gateway or email.
+// Every channel is a Sender, so development setups can write messages to the log or a file instead.
+package notify
+
+import (
+	"context"
+	"errors"
+)
+
+// Channels messages are sent through
+const (
+	ChannelWhatsApp = "whatsapp"
+	ChannelSMS      = "sms"
+	ChannelEmail    = "email"
+)
+
+// ErrNoRecipient is returned for a message without an address to send it to
+var ErrNoRecipient = errors.New("notify: message has no recipient")
+
+// Message is a single text message; To is a phone number or an email address depending on the channel
+type Message struct {
+	To      string
+	Subject string // only used by email
+	Body    string
+}
+
+// Sender delivers messages through one channel
+type Sender interface {
+	// Send delivers the message and returns the provider's message ID when it reports one
+	Send(ctx context.Context, msg Message) (string, error)
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTP sends messages as plain text email through an SMTP server, with STARTTLS when the server offers it
+type SMTP struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTP creates an email sender; without a username the server is used without authentication
+func NewSMTP(host string, port int, username, password, from string) *SMTP {
+	if port == 0 {
+		port = 587
+	}
+	s := &SMTP{addr: net.JoinHostPort(host, strconv.Itoa(port)), host: host, from: from}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s
+}
+
+// Send delivers the message; smtp.SendMail does not take a context, so ctx is only checked before sending
+func (s *SMTP) Send(ctx context.Context, msg Message) (string, error) {
+	if msg.To == "" {
+		return "", ErrNoRecipient
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	id := fmt.Sprintf("<%d@%s>", time.Now().UnixNano(), s.host)
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Message-ID: %s\r\n", id)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, []byte(b.String())); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,173 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultPerPage = 10
+	MaxPerPage     = 100
+)
+
+// ErrInvalidQuery is wrapped by every error caused by a malformed or disallowed list query
+var ErrInvalidQuery = errors.New("invalid list query")
+
+// Operator is a filter comparison operator
+type Operator string
+
+const (
+	OpEq   Operator = "eq"
+	OpNe   Operator = "ne"
+	OpGt   Operator = "gt"
+	OpGte  Operator = "gte"
+	OpLt   Operator = "lt"
+	OpLte  Operator = "lte"
+	OpLike Operator = "like"
+	OpIn   Operator = "in"
+	OpNull Operator = "null"
+)
+
+var operators = map[Operator]bool{
+	OpEq: true, OpNe: true, OpGt: true, OpGte: true, OpLt: true, OpLte: true, OpLike: true, OpIn: true, OpNull: true,
+}
+
+// Filter is a single filter[field][op]=value condition
+type Filter struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+// Sort is a single sort key, "-field" sorts descending
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery is the parsed form of the shared list query string:
+// filter[field][op]=value, sort=-field,other, page/per_page (or limit/offset) and cursor
+type ListQuery struct {
+	Filters []Filter
+	Sorts   []Sort
+	Limit   int
+	Offset  int
+	Cursor  string
+	// CursorPaging is set when the request asks for cursor pagination, i.e. passes cursor (possibly empty)
+	CursorPaging bool
+}
+
+// New returns a query for the given page window without filters or sorting
+func New(limit, offset int) *ListQuery {
+	q := &ListQuery{Limit: limit, Offset: offset}
+	q.normalize()
+	return q
+}
+
+// Page returns the 1-based page number of the query window
+func (q *ListQuery) Page() int {
+	return q.Offset/q.Limit + 1
+}
+
+// Pages returns the number of pages needed for total rows
+func (q *ListQuery) Pages(total int64) int {
+	return int((total + int64(q.Limit) - 1) / int64(q.Limit))
+}
+
+// Where appends an equality filter, used by endpoints scoped by a path parameter
+func (q *ListQuery) Where(field string, value interface{}) *ListQuery {
+	q.Filters = append(q.Filters, Filter{Field: field, Operator: OpEq, Value: fmt.Sprint(value)})
+	return q
+}
+
+var filterKeyPattern = regexp.MustCompile(`^filter\[([a-zA-Z0-9_]+)\](?:\[([a-z]+)\])?$`)
+
+// Parse builds a list query from raw query string parameters
+func Parse(params map[string]string) (*ListQuery, error) {
+	q := &ListQuery{}
+
+	for key, value := range params {
+		match := filterKeyPattern.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		op := Operator(match[2])
+		if op == "" {
+			op = OpEq
+		}
+		if !operators[op] {
+			return nil, fmt.Errorf("%w: unknown operator %q for filter %q", ErrInvalidQuery, op, match[1])
+		}
+		q.Filters = append(q.Filters, Filter{Field: match[1], Operator: op, Value: value})
+	}
+
+	if sort := strings.TrimSpace(params["sort"]); sort != "" {
+		for _, part := range strings.Split(sort, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			desc := strings.HasPrefix(part, "-")
+			q.Sorts = append(q.Sorts, Sort{Field: strings.TrimLeft(part, "+-"), Desc: desc})
+		}
+	}
+
+	perPage, err := intParam(params, "per_page", "limit")
+	if err != nil {
+		return nil, err
+	}
+	q.Limit = perPage
+
+	if params["page"] != "" {
+		page, err := intParam(params, "page")
+		if err != nil {
+			return nil, err
+		}
+		if page < 1 {
+			return nil, fmt.Errorf("%w: page must be at least 1", ErrInvalidQuery)
+		}
+		q.normalize()
+		q.Offset = (page - 1) * q.Limit
+	} else {
+		offset, err := intParam(params, "offset")
+		if err != nil {
+			return nil, err
+		}
+		q.Offset = offset
+	}
+
+	q.Cursor, q.CursorPaging = params["cursor"]
+	q.normalize()
+	return q, nil
+}
+
+func (q *ListQuery) normalize() {
+	if q.Limit <= 0 {
+		q.Limit = DefaultPerPage
+	}
+	if q.Limit > MaxPerPage {
+		q.Limit = MaxPerPage
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+}
+
+// intParam reads the first non-empty of the given parameters as an integer
+func intParam(params map[string]string, names ...string) (int, error) {
+	for _, name := range names {
+		raw := params[name]
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s must be a number", ErrInvalidQuery, name)
+		}
+		return value, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,256 @@
+package query
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse(map[string]string{
+		"filter[status]":        "Aktif",
+		"filter[stock][gte]":    "5",
+		"filter[name][like]":    "oil",
+		"filter[deleted][null]": "true",
+		"sort":                  "-created_at, name,",
+		"page":                  "3",
+		"per_page":              "20",
+		"search":                "ignored",
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	filters := make(map[string]Filter)
+	for _, filter := range q.Filters {
+		filters[filter.Field] = filter
+	}
+	want := map[string]Filter{
+		"status":  {Field: "status", Operator: OpEq, Value: "Aktif"},
+		"stock":   {Field: "stock", Operator: OpGte, Value: "5"},
+		"name":    {Field: "name", Operator: OpLike, Value: "oil"},
+		"deleted": {Field: "deleted", Operator: OpNull, Value: "true"},
+	}
+	if len(filters) != len(want) {
+		t.Fatalf("got filters %+v, want %+v", q.Filters, want)
+	}
+	for field, filter := range want {
+		if filters[field] != filter {
+			t.Fatalf("filter %s: got %+v, want %+v", field, filters[field], filter)
+		}
+	}
+
+	if len(q.Sorts) != 2 || q.Sorts[0] != (Sort{Field: "created_at", Desc: true}) || q.Sorts[1] != (Sort{Field: "name"}) {
+		t.Fatalf("got sorts %+v, want created_at descending and name", q.Sorts)
+	}
+	if q.Limit != 20 || q.Offset != 40 || q.Page() != 3 || q.CursorPaging {
+		t.Fatalf("got limit %d offset %d page %d cursor paging %v, want 20, 40, 3 and false", q.Limit, q.Offset, q.Page(), q.CursorPaging)
+	}
+}
+
+func TestParsePageWindow(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        map[string]string
+		limit, offset int
+	}{
+		{"defaults", map[string]string{}, DefaultPerPage, 0},
+		{"limit and offset", map[string]string{"limit": "5", "offset": "15"}, 5, 15},
+		{"per_page wins over limit", map[string]string{"per_page": "7", "limit": "5"}, 7, 0},
+		{"page wins over offset", map[string]string{"page": "2", "offset": "3"}, DefaultPerPage, DefaultPerPage},
+		{"capped", map[string]string{"per_page": "1000", "page": "2"}, MaxPerPage, MaxPerPage},
+		{"negative offset", map[string]string{"offset": "-4"}, DefaultPerPage, 0},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.params)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if q.Limit != tt.limit || q.Offset != tt.offset {
+			t.Fatalf("%s: got limit %d offset %d, want %d and %d", tt.name, q.Limit, q.Offset, tt.limit, tt.offset)
+		}
+	}
+
+	q, err := Parse(map[string]string{"cursor": ""})
+	if err != nil || !q.CursorPaging || q.Cursor != "" {
+		t.Fatalf("empty cursor: got %+v, %v, want cursor paging from the start", q, err)
+	}
+	if pages := New(10, 0).Pages(21); pages != 3 {
+		t.Fatalf("pages of 21 rows: got %d, want 3", pages)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, params := range []map[string]string{
+		{"filter[status][between]": "a"},
+		{"page": "0"},
+		{"page": "two"},
+		{"per_page": "ten"},
+		{"offset": "x"},
+	} {
+		if _, err := Parse(params); !errors.Is(err, ErrInvalidQuery) {
+			t.Fatalf("%v: got %v, want %v", params, err, ErrInvalidQuery)
+		}
+	}
+}
+
+func TestCursor(t *testing.T) {
+	key, err := DecodeCursor(EncodeCursor(42))
+	if err != nil || key != 42 {
+		t.Fatalf("got %d, %v, want 42", key, err)
+	}
+	for _, cursor := range []string{"!!", "YWJj"} {
+		if _, err := DecodeCursor(cursor); !errors.Is(err, ErrInvalidQuery) {
+			t.Fatalf("cursor %q: got %v, want %v", cursor, err, ErrInvalidQuery)
+		}
+	}
+}
+
+// queryTestItem is the table the scope tests list
+type queryTestItem struct {
+	ID        uint `gorm:"primaryKey"`
+	Name      string
+	Stock     int
+	Active    bool
+	Note      *string
+	CreatedAt time.Time
+}
+
+var queryTestFields = Fields{
+	Key:         "id",
+	DefaultSort: "-created_at",
+	Allowed: map[string]FieldType{
+		"name":       String,
+		"stock":      Number,
+		"active":     Bool,
+		"note":       String,
+		"created_at": Time,
+	},
+}
+
+// newQueryTestDB opens a file database holding five items, created a day apart from 1 March 2024
+func newQueryTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=10000"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&queryTestItem{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("database handle: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	note := "fragile"
+	first := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.Local)
+	items := []queryTestItem{
+		{Name: "Engine Oil", Stock: 10, Active: true},
+		{Name: "Oil filter", Stock: 3, Active: true, Note: &note},
+		{Name: "Brake pad", Stock: 0, Active: false},
+		{Name: "Spark plug", Stock: 25, Active: true},
+		{Name: "Air filter", Stock: 7, Active: false, Note: &note},
+	}
+	for i := range items {
+		items[i].CreatedAt = first.AddDate(0, 0, i)
+	}
+	if err := db.Create(&items).Error; err != nil {
+		t.Fatalf("create items: %v", err)
+	}
+	return db
+}
+
+// listTestItems runs the query and returns the IDs of the page in order with the total
+func listTestItems(t *testing.T, db *gorm.DB, params map[string]string) ([]uint, int64, error) {
+	t.Helper()
+	q, err := Parse(params)
+	if err != nil {
+		t.Fatalf("parse %v: %v", params, err)
+	}
+	var items []queryTestItem
+	total, err := Find(db.Model(&queryTestItem{}), q, queryTestFields, &items)
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids, total, err
+}
+
+func TestFind(t *testing.T) {
+	db := newQueryTestDB(t)
+	tests := []struct {
+		name   string
+		params map[string]string
+		ids    []uint
+		total  int64
+	}{
+		{"default sort newest first", map[string]string{}, []uint{5, 4, 3, 2, 1}, 5},
+		{"like ignores case", map[string]string{"filter[name][like]": "OIL", "sort": "name"}, []uint{1, 2}, 2},
+		{"number range", map[string]string{"filter[stock][gt]": "3", "filter[stock][lte]": "10", "sort": "stock"}, []uint{5, 1}, 2},
+		{"in", map[string]string{"filter[stock][in]": "0, 25", "sort": "id"}, []uint{3, 4}, 2},
+		{"bool", map[string]string{"filter[active]": "false", "sort": "id"}, []uint{3, 5}, 2},
+		{"null", map[string]string{"filter[note][null]": "false", "sort": "-id"}, []uint{5, 2}, 2},
+		{"a bare date is the whole day", map[string]string{"filter[created_at]": "2024-03-02"}, []uint{2}, 1},
+		{"after a bare date", map[string]string{"filter[created_at][gt]": "2024-03-04"}, []uint{5}, 1},
+		{"up to a bare date", map[string]string{"filter[created_at][lte]": "2024-03-02", "sort": "id"}, []uint{1, 2}, 2},
+		{"page of the total", map[string]string{"sort": "id", "per_page": "2", "page": "2"}, []uint{3, 4}, 5},
+	}
+	for _, tt := range tests {
+		ids, total, err := listTestItems(t, db, tt.params)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if total != tt.total || len(ids) != len(tt.ids) {
+			t.Fatalf("%s: got %v of %d, want %v of %d", tt.name, ids, total, tt.ids, tt.total)
+		}
+		for i := range ids {
+			if ids[i] != tt.ids[i] {
+				t.Fatalf("%s: got %v, want %v", tt.name, ids, tt.ids)
+			}
+		}
+	}
+}
+
+func TestFindRejects(t *testing.T) {
+	db := newQueryTestDB(t)
+	for _, params := range []map[string]string{
+		{"filter[password]": "x"},
+		{"sort": "password"},
+		{"filter[stock]": "many"},
+		{"filter[stock][like]": "1"},
+		{"filter[active]": "maybe"},
+		{"filter[created_at]": "yesterday"},
+		{"filter[note][null]": "perhaps"},
+	} {
+		if _, _, err := listTestItems(t, db, params); !errors.Is(err, ErrInvalidQuery) {
+			t.Fatalf("%v: got %v, want %v", params, err, ErrInvalidQuery)
+		}
+	}
+}
+
+func TestAfter(t *testing.T) {
+	db := newQueryTestDB(t)
+	var ids []uint
+	q := New(2, 0)
+	q.Cursor = EncodeCursor(4)
+	if err := db.Model(&queryTestItem{}).Scopes(After(q, queryTestFields)).Pluck("id", &ids).Error; err != nil {
+		t.Fatalf("after: %v", err)
+	}
+	// One row more than the page tells there is a next page
+	if len(ids) != 3 || ids[0] != 3 || ids[2] != 1 {
+		t.Fatalf("got %v, want 3, 2 and 1", ids)
+	}
+
+	q.Cursor = "not a cursor"
+	if err := db.Model(&queryTestItem{}).Scopes(After(q, queryTestFields)).Pluck("id", &ids).Error; !errors.Is(err, ErrInvalidQuery) {
+		t.Fatalf("malformed cursor: got %v, want %v", err, ErrInvalidQuery)
+	}
+}
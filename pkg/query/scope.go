@@ -0,0 +1,227 @@
+package query
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FieldType decides how filter values for a field are parsed
+type FieldType int
+
+const (
+	String FieldType = iota
+	Number
+	Time
+	Bool
+)
+
+const dateLayout = "2006-01-02"
+
+// Fields is the allowlist of filterable and sortable columns of one resource
+type Fields struct {
+	// Key is the primary key column, used as sort tie-breaker and cursor
+	Key string
+	// DefaultSort is applied when the request has no sort, e.g. "-created_at"
+	DefaultSort string
+	Allowed     map[string]FieldType
+}
+
+// Filtered returns a scope applying the query filters; disallowed fields or bad values are added as errors
+func Filtered(q *ListQuery, fields Fields) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, filter := range q.Filters {
+			fieldType, ok := fields.Allowed[filter.Field]
+			if !ok {
+				db.AddError(fmt.Errorf("%w: filtering by %q is not allowed", ErrInvalidQuery, filter.Field))
+				return db
+			}
+			var err error
+			db, err = where(db, filter, fieldType)
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+		}
+		return db
+	}
+}
+
+// Sorted returns a scope ordering by the query sorts, or the default sort, followed by the key
+func Sorted(q *ListQuery, fields Fields) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		sorts := q.Sorts
+		if len(sorts) == 0 && fields.DefaultSort != "" {
+			sorts = []Sort{{Field: strings.TrimPrefix(fields.DefaultSort, "-"), Desc: strings.HasPrefix(fields.DefaultSort, "-")}}
+		}
+		keySorted := false
+		for _, sort := range sorts {
+			if _, ok := fields.Allowed[sort.Field]; !ok && sort.Field != fields.Key {
+				db.AddError(fmt.Errorf("%w: sorting by %q is not allowed", ErrInvalidQuery, sort.Field))
+				return db
+			}
+			db = db.Order(order(sort.Field, sort.Desc))
+			keySorted = keySorted || sort.Field == fields.Key
+		}
+		if !keySorted && fields.Key != "" {
+			db = db.Order(order(fields.Key, false))
+		}
+		return db
+	}
+}
+
+// Paginated returns a scope limiting the result to the query page
+func Paginated(q *ListQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Limit(q.Limit).Offset(q.Offset)
+	}
+}
+
+// Find counts the rows matching the filters and loads the requested page into dest.
+// db must carry the model; preloads are only applied to the page query.
+func Find(db *gorm.DB, q *ListQuery, fields Fields, dest interface{}, preloads ...string) (int64, error) {
+	var total int64
+	if err := db.Session(&gorm.Session{}).Scopes(Filtered(q, fields)).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	tx := db.Session(&gorm.Session{})
+	for _, preload := range preloads {
+		tx = tx.Preload(preload)
+	}
+	err := tx.Scopes(Filtered(q, fields), Sorted(q, fields), Paginated(q)).Find(dest).Error
+	return total, err
+}
+
+// After returns a scope for keyset pagination: rows with a key lower than the cursor,
+// newest first, fetching one extra row so callers can tell whether more rows exist
+func After(q *ListQuery, fields Fields) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if q.Cursor != "" {
+			key, err := DecodeCursor(q.Cursor)
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			db = db.Where(fields.Key+" < ?", key)
+		}
+		return db.Order(order(fields.Key, true)).Limit(q.Limit + 1)
+	}
+}
+
+// EncodeCursor returns the opaque cursor pointing after key
+func EncodeCursor(key uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(key), 10)))
+}
+
+// DecodeCursor returns the key encoded in a cursor
+func DecodeCursor(cursor string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	key, err := strconv.ParseUint(string(raw), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	return uint(key), nil
+}
+
+func order(field string, desc bool) string {
+	if desc {
+		return field + " DESC"
+	}
+	return field + " ASC"
+}
+
+func where(db *gorm.DB, filter Filter, fieldType FieldType) (*gorm.DB, error) {
+	column := filter.Field
+
+	switch filter.Operator {
+	case OpNull:
+		isNull, err := strconv.ParseBool(filter.Value)
+		if err != nil {
+			return db, fmt.Errorf("%w: filter %q [null] expects true or false", ErrInvalidQuery, column)
+		}
+		if isNull {
+			return db.Where(column + " IS NULL"), nil
+		}
+		return db.Where(column + " IS NOT NULL"), nil
+
+	case OpLike:
+		if fieldType != String {
+			return db, fmt.Errorf("%w: filter %q does not support like", ErrInvalidQuery, column)
+		}
+		return db.Where("LOWER("+column+") LIKE ?", "%"+strings.ToLower(filter.Value)+"%"), nil
+
+	case OpIn:
+		var values []interface{}
+		for _, raw := range strings.Split(filter.Value, ",") {
+			value, _, err := parseValue(column, strings.TrimSpace(raw), fieldType)
+			if err != nil {
+				return db, err
+			}
+			values = append(values, value)
+		}
+		return db.Where(column+" IN ?", values), nil
+	}
+
+	value, dateOnly, err := parseValue(column, filter.Value, fieldType)
+	if err != nil {
+		return db, err
+	}
+
+	// A bare date covers the whole day
+	if dateOnly {
+		day := value.(time.Time)
+		next := day.AddDate(0, 0, 1)
+		switch filter.Operator {
+		case OpEq:
+			return db.Where(column+" >= ? AND "+column+" < ?", day, next), nil
+		case OpNe:
+			return db.Where("("+column+" < ? OR "+column+" >= ?)", day, next), nil
+		case OpGt:
+			return db.Where(column+" >= ?", next), nil
+		case OpLte:
+			return db.Where(column+" < ?", next), nil
+		}
+	}
+
+	comparators := map[Operator]string{OpEq: "=", OpNe: "<>", OpGt: ">", OpGte: ">=", OpLt: "<", OpLte: "<="}
+	return db.Where(column+" "+comparators[filter.Operator]+" ?", value), nil
+}
+
+// parseValue converts a raw filter value to the field type; dateOnly reports a bare YYYY-MM-DD time
+func parseValue(column, raw string, fieldType FieldType) (value interface{}, dateOnly bool, err error) {
+	switch fieldType {
+	case Number:
+		if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return i, false, nil
+		}
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: filter %q expects a number", ErrInvalidQuery, column)
+		}
+		return f, false, nil
+	case Time:
+		if t, err := time.ParseInLocation(dateLayout, raw, time.Local); err == nil {
+			return t, true, nil
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: filter %q expects YYYY-MM-DD or RFC3339 time", ErrInvalidQuery, column)
+		}
+		return t, false, nil
+	case Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: filter %q expects true or false", ErrInvalidQuery, column)
+		}
+		return b, false, nil
+	}
+	return raw, false, nil
+}
@@ -0,0 +1,133 @@
+// Package sequence formats document numbers from patterns such as "INV/{OUTLET}/{YYYY}/{MM}/{SEQ:5}".
+// Tokens: {OUTLET} the outlet ID, {YYYY} and {YY} the year, {MM} the month, {DD} the day and {SEQ} the counter;
+// {OUTLET:n} and {SEQ:n} pad with zeros to n digits. Everything else is copied as is.
+//
+// The counter runs per scope: the pattern with every token but {SEQ} filled in, so "INV/{OUTLET}/{YYYY}/{MM}/{SEQ:5}"
+// counts per outlet per month and "SJ-{YYYY}{MM}{DD}-{SEQ}" per day for all outlets.
+package sequence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pattern is a parsed document number pattern
+type Pattern struct {
+	pattern string
+	parts   []part
+}
+
+type part struct {
+	literal string
+	token   string // OUTLET, YYYY, YY, MM, DD or SEQ, empty for a literal
+	width   int
+}
+
+// Parse parses a pattern, it must contain {SEQ} exactly once
+func Parse(pattern string) (*Pattern, error) {
+	p := &Pattern{pattern: pattern}
+	rest := pattern
+	seqs := 0
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			p.parts = append(p.parts, part{literal: rest})
+			break
+		}
+		if open > 0 {
+			p.parts = append(p.parts, part{literal: rest[:open]})
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("pattern %q: unclosed {", pattern)
+		}
+		tok, err := parseToken(rest[open+1 : open+end])
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+		if tok.token == "SEQ" {
+			seqs++
+		}
+		p.parts = append(p.parts, tok)
+		rest = rest[open+end+1:]
+	}
+	if seqs != 1 {
+		return nil, fmt.Errorf("pattern %q: needs {SEQ} exactly once", pattern)
+	}
+	return p, nil
+}
+
+func parseToken(spec string) (part, error) {
+	name, width, hasWidth := strings.Cut(spec, ":")
+	tok := part{token: strings.ToUpper(name)}
+	switch tok.token {
+	case "OUTLET", "SEQ":
+		if hasWidth {
+			n, err := strconv.Atoi(width)
+			if err != nil || n < 1 || n > 12 {
+				return part{}, fmt.Errorf("invalid width in {%s}", spec)
+			}
+			tok.width = n
+		}
+	case "YYYY", "YY", "MM", "DD":
+		if hasWidth {
+			return part{}, fmt.Errorf("{%s} takes no width", name)
+		}
+	default:
+		return part{}, fmt.Errorf("unknown token {%s}", spec)
+	}
+	return tok, nil
+}
+
+// String returns the pattern as written
+func (p *Pattern) String() string {
+	return p.pattern
+}
+
+// Scope is the pattern filled in for the outlet and the time with {SEQ} left as is; numbers of the same scope share
+// a counter
+func (p *Pattern) Scope(outletID uint, at time.Time) string {
+	return p.render(outletID, at, -1)
+}
+
+// Format is the document number of the outlet, the time and the counter value
+func (p *Pattern) Format(outletID uint, at time.Time, seq int64) string {
+	return p.render(outletID, at, seq)
+}
+
+func (p *Pattern) render(outletID uint, at time.Time, seq int64) string {
+	var b strings.Builder
+	for _, part := range p.parts {
+		switch part.token {
+		case "":
+			b.WriteString(part.literal)
+		case "OUTLET":
+			b.WriteString(pad(int64(outletID), part.width))
+		case "YYYY":
+			b.WriteString(fmt.Sprintf("%04d", at.Year()))
+		case "YY":
+			b.WriteString(fmt.Sprintf("%02d", at.Year()%100))
+		case "MM":
+			b.WriteString(fmt.Sprintf("%02d", int(at.Month())))
+		case "DD":
+			b.WriteString(fmt.Sprintf("%02d", at.Day()))
+		case "SEQ":
+			if seq < 0 {
+				b.WriteString("{SEQ}")
+			} else {
+				b.WriteString(pad(seq, part.width))
+			}
+		}
+	}
+	return b.String()
+}
+
+// pad writes n with at least width digits, numbers longer than width are not cut
+func pad(n int64, width int) string {
+	if width == 0 {
+		return strconv.FormatInt(n, 10)
+	}
+	return fmt.Sprintf("%0*d", width, n)
+}
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"boilerplate/config"
+	"context"
+	"fmt"
+)
+
+// Storage drivers selected with CloudStorage.Driver
+const (
+	DriverLocal = "local"
+	DriverGCS   = "gcs"
+)
+
+// FromConfig creates the store selected in the CloudStorage config, the local disk when no driver is set.
+// GCS objects are kept below "<AppName>/<Env>/" so environments can share a bucket.
+func FromConfig(ctx context.Context, conf *config.Config) (Storage, error) {
+	switch conf.CloudStorage.Driver {
+	case "", DriverLocal:
+		local := conf.CloudStorage.Local
+		return NewLocal(local.Directory, local.BaseURL), nil
+	case DriverGCS:
+		gcs := conf.CloudStorage.GoogleStorage
+		prefix := fmt.Sprintf("%s/%s/", gcs.AppName, conf.App.Env)
+		return NewGCS(ctx, gcs.GoogleCredentialsFile, gcs.GoogleCloudStorageBucket, prefix, gcs.GoogleCloudStorageURL)
+	}
+	return nil, fmt.Errorf("storage: unknown driver %q", conf.CloudStorage.Driver)
+}
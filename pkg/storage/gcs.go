@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// gcsTimeout bounds a single upload, download or delete call
+const gcsTimeout = 50 * time.Second
+
+// GCS stores objects in a Google Cloud Storage bucket below a prefix such as "APP/prod/"
+type GCS struct {
+	client  *storage.Client
+	bucket  string
+	prefix  string
+	baseURL string
+}
+
+// NewGCS connects to the bucket with the service account in credentialsFile.
+// baseURL is the public storage host, e.g. "https://storage.googleapis.com".
+func NewGCS(ctx context.Context, credentialsFile, bucket, prefix, baseURL string) (*GCS, error) {
+	if credentialsFile != "" {
+		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsFile)
+	}
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &GCS{
+		client:  client,
+		bucket:  bucket,
+		prefix:  prefix,
+		baseURL: strings.TrimSuffix(baseURL, "/") + "/" + bucket + "/",
+	}, nil
+}
+
+func (s *GCS) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	object, err := s.object(key)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, gcsTimeout)
+	defer cancel()
+
+	w := s.client.Bucket(s.bucket).Object(object).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := io.Copy(w, body); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// Get opens a reader on the object, the download timeout is left to ctx as the caller streams it
+func (s *GCS) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.object(key)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.client.Bucket(s.bucket).Object(object).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	return r, err
+}
+
+func (s *GCS) Delete(ctx context.Context, key string) error {
+	object, err := s.object(key)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, gcsTimeout)
+	defer cancel()
+
+	err = s.client.Bucket(s.bucket).Object(object).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL returns the public bucket URL for public objects and a V4 signed URL for the others
+func (s *GCS) URL(ctx context.Context, key string) (string, error) {
+	object, err := s.object(key)
+	if err != nil {
+		return "", err
+	}
+	if IsPublic(key) {
+		return s.baseURL + object, nil
+	}
+	return s.client.Bucket(s.bucket).SignedURL(object, &storage.SignedURLOptions{
+		Method:  "GET",
+		Expires: time.Now().Add(SignedURLExpiry),
+		Scheme:  storage.SigningSchemeV4,
+	})
+}
+
+func (s *GCS) Key(url string) (string, bool) {
+	object := strings.TrimPrefix(url, s.baseURL)
+	if object == url {
+		return "", false
+	}
+	key := strings.TrimPrefix(object, s.prefix)
+	if key == object && s.prefix != "" {
+		return "", false
+	}
+	return key, key != ""
+}
+
+// object maps a key to the object name below the prefix
+func (s *GCS) object(key string) (string, error) {
+	key = strings.TrimPrefix(strings.TrimSpace(key), "/")
+	if key == "" || strings.Contains(key, "..") {
+		return "", ErrInvalidKey
+	}
+	return s.prefix + key, nil
+}
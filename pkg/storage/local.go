@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores objects as files below a root directory. Public objects are served by the
+// application below baseURL, see PublicDir.
+type Local struct {
+	root    string
+	baseURL string
+}
+
+// DefaultLocalRoot is used when no directory is configured
+const DefaultLocalRoot = "storage"
+
+// NewLocal returns a store writing below root, the directory is created on first write.
+// baseURL is where the application serves PublicDir, e.g. "http://localhost:3000/files".
+func NewLocal(root, baseURL string) *Local {
+	if root == "" {
+		root = DefaultLocalRoot
+	}
+	return &Local{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// PublicDir is the directory holding the objects under PublicPrefix
+func (s *Local) PublicDir() string {
+	return filepath.Join(s.root, filepath.FromSlash(PublicPrefix))
+}
+
+// Put writes the object to a temporary file first so readers never see a partial file
+func (s *Local) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (s *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (s *Local) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key to a file below the root, rejecting keys that would leave it
+func (s *Local) path(key string) (string, error) {
+	cleaned := path.Clean("/" + strings.TrimSpace(key))
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
+
+// URL returns the served address of a public object, local files cannot be signed
+func (s *Local) URL(ctx context.Context, key string) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	if !IsPublic(key) {
+		return "", ErrNotServed
+	}
+	return s.baseURL + "/" + strings.TrimPrefix(key, PublicPrefix), nil
+}
+
+func (s *Local) Key(url string) (string, bool) {
+	if s.baseURL == "" {
+		return "", false
+	}
+	rest := strings.TrimPrefix(url, s.baseURL+"/")
+	if rest == url || rest == "" {
+		return "", false
+	}
+	return PublicPrefix + rest, true
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when no object is stored under a key
+var ErrNotFound = errors.New("storage: object not found")
+
+// ErrInvalidKey is returned for keys that are empty or escape the store, e.g. "../secret"
+var ErrInvalidKey = errors.New("storage: invalid key")
+
+// ErrNotServed is returned by URL for private objects of a backend that cannot sign URLs
+var ErrNotServed = errors.New("storage: object is not served")
+
+// PublicPrefix marks keys that are readable by anyone, such as product images.
+// Objects outside it, e.g. generated reports, are only reachable through the API or a signed URL.
+const PublicPrefix = "public/"
+
+// SignedURLExpiry is how long a signed URL for a private object stays valid
+const SignedURLExpiry = 15 * time.Minute
+
+// Storage keeps files under slash separated keys such as "reports/2024/01/sales.csv"
+type Storage interface {
+	// Put stores the content of body under key, replacing an existing object
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// Get opens the object stored under key, the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the address clients fetch the object from: a permanent URL for public keys,
+	// a signed URL valid for SignedURLExpiry otherwise
+	URL(ctx context.Context, key string) (string, error)
+	// Key reverses URL for a public object, false when the URL does not point into this store
+	Key(url string) (string, bool)
+}
+
+// IsPublic reports whether the key is below PublicPrefix
+func IsPublic(key string) bool {
+	return strings.HasPrefix(key, PublicPrefix)
+}
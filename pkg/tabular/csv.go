@@ -0,0 +1,31 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// WriteCSV writes the columns, rows and totals; title and meta are left out so the file stays machine readable
+func WriteCSV(w io.Writer, table *Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+
+	rows := table.Rows
+	if len(table.Totals) > 0 {
+		rows = append(rows[:len(rows):len(rows)], table.Totals)
+	}
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = text(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,209 @@
+package tabular
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Layout of the PDF output: A4 landscape in points with the standard Helvetica fonts
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+	pdfMargin     = 36.0
+	pdfFontSize   = 8.0
+	pdfLineHeight = 12.0
+	// pdfCharWidth approximates the average Helvetica glyph width as a fraction of the font size
+	pdfCharWidth = 0.55
+)
+
+// WritePDF writes the table as a PDF document, repeating the column header on every page.
+// Text is encoded as WinAnsi, characters outside Latin-1 are printed as "?".
+func WritePDF(w io.Writer, table *Table) error {
+	widths := pdfColumnWidths(table)
+
+	var pages []*bytes.Buffer
+	var page *bytes.Buffer
+	y := 0.0
+
+	newPage := func() {
+		page = &bytes.Buffer{}
+		pages = append(pages, page)
+		y = pdfPageHeight - pdfMargin
+		pdfText(page, "F1", pdfFontSize, pdfPageWidth-pdfMargin-60, pdfMargin/2, "Halaman "+strconv.Itoa(len(pages)))
+	}
+	writeRow := func(values []interface{}, font string) {
+		x := pdfMargin
+		for i, width := range widths {
+			var value interface{}
+			if i < len(values) {
+				value = values[i]
+			}
+			s := fitText(text(value), width)
+			offset := 2.0
+			if numeric(value) {
+				offset = width - 2 - textWidth(s, pdfFontSize)
+			}
+			pdfText(page, font, pdfFontSize, x+offset, y, s)
+			x += width
+		}
+		y -= pdfLineHeight
+	}
+	writeHeader := func() {
+		header := make([]interface{}, len(table.Columns))
+		for i, column := range table.Columns {
+			header[i] = column
+		}
+		writeRow(header, "F2")
+		fmt.Fprintf(page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, y+pdfLineHeight-3, pdfPageWidth-pdfMargin, y+pdfLineHeight-3)
+	}
+	ensureSpace := func() {
+		if y < pdfMargin+pdfLineHeight {
+			newPage()
+			writeHeader()
+		}
+	}
+
+	newPage()
+	if table.Title != "" {
+		pdfText(page, "F2", 14, pdfMargin, y-4, table.Title)
+		y -= 24
+	}
+	for _, meta := range table.Meta {
+		pdfText(page, "F1", 9, pdfMargin, y, meta[0]+": "+meta[1])
+		y -= pdfLineHeight
+	}
+	y -= pdfLineHeight / 2
+	writeHeader()
+
+	for _, values := range table.Rows {
+		ensureSpace()
+		writeRow(values, "F1")
+	}
+	if len(table.Totals) > 0 {
+		ensureSpace()
+		fmt.Fprintf(page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, y+pdfLineHeight-3, pdfPageWidth-pdfMargin, y+pdfLineHeight-3)
+		writeRow(table.Totals, "F2")
+	}
+
+	return writePDFDocument(w, pages)
+}
+
+// pdfColumnWidths shares the printable width between columns by their longest value
+func pdfColumnWidths(table *Table) []float64 {
+	lengths := make([]float64, len(table.Columns))
+	measure := func(i int, s string) {
+		n := float64(len([]rune(s)))
+		if n > 40 {
+			n = 40
+		}
+		if n < 4 {
+			n = 4
+		}
+		if n > lengths[i] {
+			lengths[i] = n
+		}
+	}
+	for i, column := range table.Columns {
+		measure(i, column)
+	}
+	for _, row := range append(table.Rows[:len(table.Rows):len(table.Rows)], table.Totals) {
+		for i, value := range row {
+			if i < len(lengths) {
+				measure(i, text(value))
+			}
+		}
+	}
+
+	total := 0.0
+	for _, n := range lengths {
+		total += n
+	}
+	widths := make([]float64, len(lengths))
+	for i, n := range lengths {
+		widths[i] = (pdfPageWidth - 2*pdfMargin) * n / total
+	}
+	return widths
+}
+
+func textWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * size * pdfCharWidth
+}
+
+// fitText shortens s with an ellipsis so it fits a column
+func fitText(s string, width float64) string {
+	max := int((width - 4) / (pdfFontSize * pdfCharWidth))
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max <= 3 {
+		return string(runes[:max])
+	}
+	return string(runes[:max-3]) + "..."
+}
+
+func pdfText(page *bytes.Buffer, font string, size, x, y float64, s string) {
+	fmt.Fprintf(page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+// pdfString escapes a literal string and encodes it as WinAnsi
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// writePDFDocument assembles the page content streams into a PDF file with its cross-reference table
+func writePDFDocument(w io.Writer, pages []*bytes.Buffer) error {
+	var doc bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, doc.Len())
+		fmt.Fprintf(&doc, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	doc.WriteString("%PDF-1.4\n")
+
+	// 1 catalog, 2 page tree, 3 and 4 fonts, then a page and its content stream per page
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := doc.Len()
+	fmt.Fprintf(&doc, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&doc, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := doc.WriteTo(w)
+	return err
+}
@@ -0,0 +1,99 @@
+package tabular
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownFormat is returned for formats other than csv, xlsx and pdf
+var ErrUnknownFormat = errors.New("tabular: unknown format")
+
+// Format is an output document format
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+	PDF  Format = "pdf"
+)
+
+// ContentType returns the MIME type of documents in the format
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case PDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+// Extension returns the file extension of the format including the dot
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Table is a titled grid of values. Cells hold strings, integers, float64 amounts or time.Time;
+// spreadsheet formats keep numbers numeric while CSV and PDF print them with two decimals.
+type Table struct {
+	Title string
+	// Meta lines are printed below the title in XLSX and PDF, e.g. {"Periode", "2024-01-01 s/d 2024-01-31"}
+	Meta    [][2]string
+	Columns []string
+	Rows    [][]interface{}
+	// Totals is an optional footer row
+	Totals []interface{}
+}
+
+// Write renders the table in the given format
+func Write(w io.Writer, format Format, table *Table) error {
+	switch format {
+	case CSV:
+		return WriteCSV(w, table)
+	case XLSX:
+		return WriteXLSX(w, table)
+	case PDF:
+		return WritePDF(w, table)
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// text formats a cell for the text based formats
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 {
+			return v.Format("2006-01-02")
+		}
+		return v.Format("2006-01-02 15:04")
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+	return fmt.Sprint(value)
+}
+
+// numeric reports whether a cell is a number, numbers are right aligned and kept numeric in XLSX
+func numeric(value interface{}) bool {
+	switch value.(type) {
+	case int, int64, uint, uint64, float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"context"
+)
+
+type actorContextKey struct{}
+
+// Actor is the authenticated user performing the current request
+type Actor struct {
+	UserID   uint   `json:"user_id"`
+	OutletID *uint  `json:"outlet_id"`
+	Username string `json:"username"`
+	IsAdmin  bool   `json:"is_admin"`
+}
+
+// WithActor returns a copy of ctx carrying the given actor
+func WithActor(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx, if any
+func ActorFromContext(ctx context.Context) (*Actor, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	actor, ok := ctx.Value(actorContextKey{}).(*Actor)
+	return actor, ok && actor != nil
+}
+
+// ActorFromClaims builds an actor from access token claims
+func ActorFromClaims(claims map[string]interface{}) *Actor {
+	accessID, ok := claims["access_id"].(float64)
+	if !ok || accessID <= 0 {
+		return nil
+	}
+
+	actor := &Actor{UserID: uint(accessID)}
+	if username, ok := claims["username"].(string); ok {
+		actor.Username = username
+	}
+	if isAdmin, ok := claims["is_admin"].(bool); ok {
+		actor.IsAdmin = isAdmin
+	}
+	if outletID, ok := claims["outlet_id"].(float64); ok && outletID > 0 {
+		id := uint(outletID)
+		actor.OutletID = &id
+	}
+	return actor
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// bulanIndonesia are the month names of FormatTanggal
+var bulanIndonesia = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// FormatTanggal formats a day the Indonesian way, e.g. "5 Januari 2024"
+func FormatTanggal(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), bulanIndonesia[t.Month()-1], t.Year())
+}
+
+// FormatRupiah formats an amount in whole rupiah with dots between thousands, e.g. "Rp150.000"
+func FormatRupiah(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	digits := fmt.Sprintf("%.0f", amount)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+	return sign + "Rp" + b.String()
+}
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gofrs/uuid"
 	"golang.org/x/exp/utf8string"
@@ -83,6 +84,24 @@ func GenerateMaskEmail(email string) string {
 	return fmt.Sprintf("%s%s%s@%s", strs, mask, stre, str2)
 }
 
+// GenerateMaskPlateNumber masks the digits of a plate number but the last one, keeping the region and the letters
+// an owner recognizes: "B 1234 XYZ" becomes "B ***4 XYZ". Plates without digits keep their first and last character.
+func GenerateMaskPlateNumber(plateNumber string) string {
+	runes := []rune(strings.TrimSpace(plateNumber))
+	lastDigit := -1
+	for i, r := range runes {
+		if unicode.IsDigit(r) {
+			lastDigit = i
+		}
+	}
+	for i := 1; i < len(runes)-1; i++ {
+		if lastDigit < 0 && !unicode.IsSpace(runes[i]) || i < lastDigit && unicode.IsDigit(runes[i]) {
+			runes[i] = '*'
+		}
+	}
+	return string(runes)
+}
+
 func GenerateBasicToken(conf *config.Config, timeStamp string) string {
 
 	apiKey := conf.Authorization.Basic.ApiKey
@@ -13,6 +13,7 @@ type JWTDataToken struct {
 	Username string `json:"username"`
 	FullName string `json:"full_name"`
 	IsAdmin  bool   `json:"is_admin"`
+	OutletID int64  `json:"outlet_id"`
 }
 
 type JWTResponse struct {
@@ -54,6 +55,7 @@ func GenerateAccessToken(conf *config.Config, data JWTDataToken) (error, string,
 		"username":  data.Username,
 		"name":      data.FullName,
 		"is_admin":  data.IsAdmin,
+		"outlet_id": data.OutletID,
 		"exp":       exp.Unix(),
 		"issued_at": time.Now().Unix(),
 	})
@@ -77,6 +79,7 @@ func GenerateRefreshToken(conf *config.Config, data JWTDataToken) (error, string
 		"username":  data.Username,
 		"name":      data.FullName,
 		"is_admin":  data.IsAdmin,
+		"outlet_id": data.OutletID,
 		"exp":       exp.Unix(),
 		"issued_at": time.Now().Unix(),
 	})
@@ -138,12 +141,14 @@ func CheckRefreshToken(conf *config.Config, refreshToken string) (error, *JWTDat
 	username := string(claims["username"].(string))
 	name := string(claims["name"].(string))
 	isAdmin := bool(claims["is_admin"].(bool))
+	outletID, _ := claims["outlet_id"].(float64)
 
 	data := &JWTDataToken{
 		AccessID: accessID,
 		Username: username,
 		FullName: name,
 		IsAdmin:  isAdmin,
+		OutletID: int64(outletID),
 	}
 	return nil, data
 }
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Enum is implemented by string enums that know their allowed values
+type Enum interface {
+	IsValid() bool
+}
+
+var (
+	//* 08xx, 628xx or +628xx mobile numbers and area-code landlines such as 021xxxxxxx
+	phonePattern = regexp.MustCompile(`^(?:\+62|62|0)[2-9][0-9]{7,11}$`)
+	//* Region code, number and optional suffix, e.g. B 1234 ABC or AB1234CD
+	platePattern = regexp.MustCompile(`^[A-Z]{1,2} ?[0-9]{1,4} ?[A-Z]{0,3}$`)
+)
+
+// rules are the custom tags registered on the shared validator
+var rules = map[string]validator.Func{
+	// enum: the value implements Enum and is one of its constants
+	"enum": func(fl validator.FieldLevel) bool {
+		enum, ok := fl.Field().Interface().(Enum)
+		return ok && enum.IsValid()
+	},
+	// phone_id: Indonesian phone number, separators are ignored
+	"phone_id": func(fl validator.FieldLevel) bool {
+		phone := strings.NewReplacer(" ", "", "-", "", ".", "").Replace(fl.Field().String())
+		return phonePattern.MatchString(phone)
+	},
+	// plate_id: Indonesian vehicle plate number, case-insensitive
+	"plate_id": func(fl validator.FieldLevel) bool {
+		plate := strings.ToUpper(strings.TrimSpace(fl.Field().String()))
+		return platePattern.MatchString(plate)
+	},
+	// notbefore=Field: the time is not before another time field; passes when the other field is not set,
+	// unlike gtefield, so partial updates can carry only one of the two dates
+	"notbefore": func(fl validator.FieldLevel) bool {
+		other, kind, _, found := fl.GetStructFieldOK2()
+		if !found || kind != reflect.Struct {
+			return true
+		}
+		otherTime, ok := other.Interface().(time.Time)
+		if !ok || otherTime.IsZero() {
+			return true
+		}
+		current, ok := fl.Field().Interface().(time.Time)
+		return ok && !current.Before(otherTime)
+	},
+}
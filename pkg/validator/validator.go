@@ -1,57 +1,163 @@
 package validator
 
 import (
+	"boilerplate/pkg/exception"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
-//? A Custom struct validation
-func ValidateDataRequest(dataReq interface{}) (string, string) {
-	//* Validate data request
-	validate := validator.New()
+// ErrValidationFailed is returned by Validate, its details list every failing field
+var ErrValidationFailed = exception.Validation("VALIDATION_FAILED", "request validation failed", "validasi data permintaan gagal")
+
+// FieldError is a single failing field of a request, named by its JSON path
+type FieldError struct {
+	Field      string `json:"field"`
+	Rule       string `json:"rule"`
+	Message    string `json:"message"`
+	MessageInd string `json:"messageInd"`
+}
+
+// validate is shared, building one per call would throw away its cached struct metadata
+var validate = newValidate()
+
+func newValidate() *validator.Validate {
+	v := validator.New()
+
+	//* Report fields by their json name so errors match the request body
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	for tag, fn := range rules {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(err)
+		}
+	}
+	return v
+}
+
+// Validate checks a request against its validate tags and returns a validation domain error listing every failing field
+func Validate(dataReq interface{}) error {
 	err := validate.Struct(dataReq)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
 
-	var errMsg string
-	var errMsgInd string
-
-	if err != nil {
-		if strings.Contains(err.Error(), "failed on the 'required' tag") {
-			field := between(err.Error(), "Field validation for ", " failed on the 'required")
-			errMsg = fmt.Sprintf("Data %s cannot be empty", field)
-			errMsgInd = fmt.Sprintf("Data %s tidak boleh kosong", field)
-		} else if strings.Contains(err.Error(), "failed on the 'email' tag") {
-			errMsg = "Data Email not valid"
-			errMsgInd = "Data Email tidak valid"
-		} else if strings.Contains(err.Error(), "failed on the 'confirmPassword' tag") {
-			errMsg = "Password not equal"
-			errMsgInd = "Password tidak sama"
-		} else if strings.Contains(err.Error(), "failed on the 'minPassword' tag") {
-			errMsg = "Password cannot be less than 8 characters"
-			errMsgInd = "Kata sandi tidak boleh kurang dari 8 karakter"
-		} else {
-			errMsg = err.Error()
-			errMsgInd = err.Error()
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields = append(fields, newFieldError(fe))
+	}
+	return ErrValidationFailed.WithDetails(fields)
+}
+
+//? A Custom struct validation, returns the message of the first failing field
+func ValidateDataRequest(dataReq interface{}) (string, string) {
+	err := Validate(dataReq)
+	if err == nil {
+		return "", ""
+	}
+
+	if domainErr, ok := exception.AsDomainError(err); ok {
+		if fields, ok := domainErr.Details.([]FieldError); ok && len(fields) > 0 {
+			return fields[0].Message, fields[0].MessageInd
 		}
-		return errMsg, errMsgInd
 	}
-	return "", ""
+	return err.Error(), err.Error()
 }
 
-func between(value string, a string, b string) string {
-	// Get substring between two strings.
-	posFirst := strings.Index(value, a)
-	if posFirst == -1 {
-		return ""
+func newFieldError(fe validator.FieldError) FieldError {
+	field := fieldPath(fe.Namespace())
+	param := fe.Param()
+	var msg, msgInd string
+
+	switch fe.Tag() {
+	case "required":
+		msg = fmt.Sprintf("%s cannot be empty", field)
+		msgInd = fmt.Sprintf("%s tidak boleh kosong", field)
+	case "email":
+		msg = fmt.Sprintf("%s must be a valid email address", field)
+		msgInd = fmt.Sprintf("%s harus berupa alamat email yang valid", field)
+	case "min", "gte":
+		msg, msgInd = boundMessage(field, param, fe.Kind(), "at least", "minimal")
+	case "max", "lte":
+		msg, msgInd = boundMessage(field, param, fe.Kind(), "at most", "maksimal")
+	case "gt":
+		msg = fmt.Sprintf("%s must be greater than %s", field, param)
+		msgInd = fmt.Sprintf("%s harus lebih besar dari %s", field, param)
+	case "lt":
+		msg = fmt.Sprintf("%s must be less than %s", field, param)
+		msgInd = fmt.Sprintf("%s harus lebih kecil dari %s", field, param)
+	case "oneof":
+		options := strings.ReplaceAll(param, " ", ", ")
+		msg = fmt.Sprintf("%s must be one of: %s", field, options)
+		msgInd = fmt.Sprintf("%s harus salah satu dari: %s", field, options)
+	case "enum":
+		msg = fmt.Sprintf("%s has an unknown value %q", field, fmt.Sprint(fe.Value()))
+		msgInd = fmt.Sprintf("%s memiliki nilai yang tidak dikenal %q", field, fmt.Sprint(fe.Value()))
+	case "phone_id":
+		msg = fmt.Sprintf("%s must be an Indonesian phone number, e.g. 081234567890 or +6281234567890", field)
+		msgInd = fmt.Sprintf("%s harus berupa nomor telepon Indonesia, contoh 081234567890 atau +6281234567890", field)
+	case "plate_id":
+		msg = fmt.Sprintf("%s must be a vehicle plate number, e.g. B 1234 ABC", field)
+		msgInd = fmt.Sprintf("%s harus berupa nomor polisi kendaraan, contoh B 1234 ABC", field)
+	case "notbefore":
+		other := snakeCase(param)
+		msg = fmt.Sprintf("%s must not be before %s", field, other)
+		msgInd = fmt.Sprintf("%s tidak boleh sebelum %s", field, other)
+	default:
+		msg = fmt.Sprintf("%s is not valid (%s)", field, fe.Tag())
+		msgInd = fmt.Sprintf("%s tidak valid (%s)", field, fe.Tag())
 	}
-	posLast := strings.Index(value, b)
-	if posLast == -1 {
-		return ""
+
+	return FieldError{Field: field, Rule: fe.Tag(), Message: msg, MessageInd: msgInd}
+}
+
+// fieldPath drops the struct name from a namespace, e.g. CreateXRequest.items[0].quantity becomes items[0].quantity
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// boundMessage words a min/max rule as a length for strings, an item count for collections and a value otherwise
+func boundMessage(field, param string, kind reflect.Kind, bound, boundInd string) (string, string) {
+	switch kind {
+	case reflect.String:
+		return fmt.Sprintf("%s must be %s %s characters", field, bound, param),
+			fmt.Sprintf("%s %s %s karakter", field, boundInd, param)
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return fmt.Sprintf("%s must contain %s %s items", field, bound, param),
+			fmt.Sprintf("%s %s berisi %s item", field, boundInd, param)
 	}
-	posFirstAdjusted := posFirst + len(a)
-	if posFirstAdjusted >= posLast {
-		return ""
+	return fmt.Sprintf("%s must be %s %s", field, bound, param),
+		fmt.Sprintf("%s %s %s", field, boundInd, param)
+}
+
+// snakeCase turns a Go field name used as rule parameter into its json spelling, e.g. ServiceInDate to service_in_date
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 && !(name[i-1] >= 'A' && name[i-1] <= 'Z') {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
 	}
-	return value[posFirstAdjusted:posLast]
+	return b.String()
 }
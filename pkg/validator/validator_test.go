@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"boilerplate/pkg/exception"
+	"errors"
+	"testing"
+	"time"
+)
+
+// validatorTestStatus is an enum with two valid values
+type validatorTestStatus string
+
+func (s validatorTestStatus) IsValid() bool {
+	return s == "Aktif" || s == "Nonaktif"
+}
+
+type validatorTestRequest struct {
+	Status    validatorTestStatus `json:"status" validate:"omitempty,enum"`
+	Phone     string              `json:"phone" validate:"omitempty,phone_id"`
+	Plate     string              `json:"plate" validate:"omitempty,plate_id"`
+	StartDate time.Time           `json:"start_date"`
+	EndDate   time.Time           `json:"end_date" validate:"notbefore=StartDate"`
+	Items     []validatorTestItem `json:"items" validate:"dive"`
+}
+
+type validatorTestItem struct {
+	Quantity int `json:"quantity" validate:"min=1"`
+}
+
+// failingRules validates the request and returns the failing rule per field
+func failingRules(t *testing.T, req validatorTestRequest) map[string]string {
+	t.Helper()
+	err := Validate(req)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrValidationFailed) || !errors.Is(err, exception.ErrValidation) {
+		t.Fatalf("got %v, want %v", err, ErrValidationFailed)
+	}
+	domainErr, _ := exception.AsDomainError(err)
+	fields, ok := domainErr.Details.([]FieldError)
+	if !ok {
+		t.Fatalf("got details %#v, want the failing fields", domainErr.Details)
+	}
+	rules := make(map[string]string, len(fields))
+	for _, field := range fields {
+		rules[field.Field] = field.Rule
+	}
+	return rules
+}
+
+func TestRules(t *testing.T) {
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name  string
+		req   validatorTestRequest
+		field string
+		rule  string
+	}{
+		{"known enum value", validatorTestRequest{Status: "Aktif"}, "", ""},
+		{"unknown enum value", validatorTestRequest{Status: "aktif"}, "status", "enum"},
+		{"mobile number", validatorTestRequest{Phone: "0812-3456-7890"}, "", ""},
+		{"international mobile number", validatorTestRequest{Phone: "+62 812 3456 7890"}, "", ""},
+		{"landline", validatorTestRequest{Phone: "0215551234"}, "", ""},
+		{"too short", validatorTestRequest{Phone: "08123"}, "phone", "phone_id"},
+		{"not from Indonesia", validatorTestRequest{Phone: "+6512345678"}, "phone", "phone_id"},
+		{"letters", validatorTestRequest{Phone: "08abc4567890"}, "phone", "phone_id"},
+		{"plate", validatorTestRequest{Plate: "B 1234 ABC"}, "", ""},
+		{"plate without spaces in lower case", validatorTestRequest{Plate: "ab1234cd"}, "", ""},
+		{"plate without region", validatorTestRequest{Plate: "1234 ABC"}, "plate", "plate_id"},
+		{"plate number too long", validatorTestRequest{Plate: "B 12345 A"}, "plate", "plate_id"},
+		{"end after start", validatorTestRequest{StartDate: day, EndDate: day.AddDate(0, 0, 1)}, "", ""},
+		{"end on start", validatorTestRequest{StartDate: day, EndDate: day}, "", ""},
+		{"end before start", validatorTestRequest{StartDate: day, EndDate: day.AddDate(0, 0, -1)}, "end_date", "notbefore"},
+		{"only the end", validatorTestRequest{EndDate: day}, "", ""},
+	}
+	for _, tt := range tests {
+		rules := failingRules(t, tt.req)
+		if tt.field == "" && len(rules) != 0 {
+			t.Fatalf("%s: got failing rules %v, want none", tt.name, rules)
+		}
+		if tt.field != "" && (len(rules) != 1 || rules[tt.field] != tt.rule) {
+			t.Fatalf("%s: got failing rules %v, want %s on %s", tt.name, rules, tt.rule, tt.field)
+		}
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	req := validatorTestRequest{Status: "Hapus", Plate: "?", Items: []validatorTestItem{{Quantity: 2}, {Quantity: 0}}}
+
+	rules := failingRules(t, req)
+	want := map[string]string{"status": "enum", "plate": "plate_id", "items[1].quantity": "min"}
+	if len(rules) != len(want) {
+		t.Fatalf("got failing rules %v, want %v", rules, want)
+	}
+	for field, rule := range want {
+		if rules[field] != rule {
+			t.Fatalf("got failing rules %v, want %v", rules, want)
+		}
+	}
+
+	msg, msgInd := ValidateDataRequest(validatorTestRequest{StartDate: time.Now(), EndDate: time.Now().AddDate(0, 0, -1)})
+	if msg != "end_date must not be before start_date" || msgInd != "end_date tidak boleh sebelum start_date" {
+		t.Fatalf("got %q and %q, want the notbefore message naming start_date", msg, msgInd)
+	}
+}